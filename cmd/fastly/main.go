@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/fastly/cli/pkg/api/transport"
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/check"
 	"github.com/fastly/cli/pkg/cmd"
@@ -25,6 +32,9 @@ import (
 
 const sentryTimeout = 2 * time.Second
 
+// verboseShortFlag matches the (repeatable) short verbose flag, e.g. -vv.
+var verboseShortFlag = regexp.MustCompile(`^-v+$`)
+
 //go:embed static/config.toml
 var cfg []byte
 
@@ -90,7 +100,7 @@ func main() {
 	// output related to the application configuration file in this file.
 	var verboseOutput bool
 	for _, seg := range args {
-		if seg == "-v" || seg == "--verbose" {
+		if seg == "--verbose" || verboseShortFlag.MatchString(seg) {
 			verboseOutput = true
 		}
 	}
@@ -113,7 +123,7 @@ func main() {
 			// We've hit a scenario where our fallback static config is invalid, and
 			// that is very much an unexpected situation.
 			fsterr.Deduce(err).Print(color.Error)
-			os.Exit(1)
+			os.Exit(fsterr.ExitCode(err))
 		}
 	}
 
@@ -128,7 +138,7 @@ func main() {
 		err = file.UseStatic(cfg, config.FilePath)
 		if err != nil {
 			fsterr.Deduce(err).Print(color.Error)
-			os.Exit(1)
+			os.Exit(fsterr.ExitCode(err))
 		}
 	}
 
@@ -183,15 +193,29 @@ Compatibility and versioning information for the Fastly CLI is being updated in
 		}()
 	}
 
+	// Interrupting the CLI (e.g. with Ctrl-C) cancels the context, so commands
+	// can stop promptly and show any partial results. Once the context is
+	// cancelled the signals are no longer caught, so a second Ctrl-C exits
+	// immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	// Main is basically just a shim to call Run, so we do that here.
 	opts := app.RunOpts{
 		APIClient:  clientFactory,
 		Args:       args,
 		ConfigFile: file,
 		ConfigPath: config.FilePath,
+		Context:    ctx,
 		Env:        env,
 		ErrLog:     fsterr.Log,
 		HTTPClient: httpClient,
+		RTSClient:  app.FastlyRTSClient,
+		StateDir:   filepath.Dir(config.FilePath),
+		Stderr:     color.Error,
 		Stdin:      in,
 		Stdout:     out,
 		Versioners: app.Versioners{
@@ -210,9 +234,13 @@ Compatibility and versioning information for the Fastly CLI is being updated in
 	if logErr != nil {
 		fsterr.Deduce(logErr).Print(color.Error)
 	}
+	logErr = transport.Failures.Persist(transport.LogPath)
+	if logErr != nil {
+		fsterr.Deduce(logErr).Print(color.Error)
+	}
 
 	if err != nil {
-		fsterr.Deduce(err).Print(color.Error)
+		printError(err, transport.Failures.Failed(), color.Error)
 
 		// NOTE: if we have an error processing the command, then we should be sure
 		// to wait for the async file write to complete (otherwise we'll end up in
@@ -253,7 +281,7 @@ Compatibility and versioning information for the Fastly CLI is being updated in
 		// flush the Sentry buffer here (as well as the deferred call at the top of
 		// the main function).
 		sentry.Flush(sentryTimeout)
-		os.Exit(1)
+		os.Exit(fsterr.ExitCode(err))
 	}
 
 	// If the command being run finishes before the latest config is written back
@@ -279,6 +307,41 @@ func afterWrite(verboseOutput bool, errLoadConfig error, out io.Writer) {
 	}
 }
 
+// printError writes the error a command returned to out, along with the IDs
+// of the API requests that failed. The error of a command rendering JSON is
+// written as JSON, including the last failed request.
+func printError(err error, failed []transport.FailedRequest, out io.Writer) {
+	var je fsterr.JSONError
+	if errors.As(err, &je) {
+		var (
+			status int
+			id     string
+		)
+		if len(failed) > 0 {
+			status, id = failed[len(failed)-1].StatusCode, failed[len(failed)-1].RequestID
+		}
+		fsterr.NewStructuredError(je.Err, status, id).Print(out)
+		return
+	}
+	fsterr.Deduce(err).Print(out)
+	printRequestIDs(failed, out)
+}
+
+// printRequestIDs displays the request IDs of any failed API requests, which
+// Fastly support will need in order to investigate the error.
+func printRequestIDs(failed []transport.FailedRequest, out io.Writer) {
+	var ids []string
+	for _, f := range failed {
+		if f.RequestID != "" {
+			ids = append(ids, f.RequestID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	text.Info(out, "API request ID: %s\n\nInclude the request ID when contacting Fastly support (see also: 'fastly support-bundle').", strings.Join(ids, ", "))
+}
+
 func parseEnv(environ []string) map[string]string {
 	env := map[string]string{}
 	for _, kv := range environ {
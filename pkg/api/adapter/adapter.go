@@ -0,0 +1,40 @@
+package adapter
+
+import "github.com/fastly/cli/pkg/api"
+
+// Adapter provides the interfaces of each area of the API, implemented via the
+// Fastly API client.
+type Adapter struct {
+	client api.Interface
+}
+
+// New returns an adapter for the Fastly API client, which is typically the
+// APIClient of the command's globals.
+func New(client api.Interface) *Adapter {
+	return &Adapter{client: client}
+}
+
+// Domains returns the API for the domains of a service version.
+func (a *Adapter) Domains() Domains {
+	return domains{client: a.client}
+}
+
+// Versions returns the API for the versions of a service.
+func (a *Adapter) Versions() Versions {
+	return versions{client: a.client}
+}
+
+// String returns a pointer to s, for the optional fields of inputs.
+func String(s string) *string {
+	return &s
+}
+
+// Int returns a pointer to i, for the optional fields of inputs.
+func Int(i int) *int {
+	return &i
+}
+
+// Bool returns a pointer to b, for the optional fields of inputs.
+func Bool(b bool) *bool {
+	return &b
+}
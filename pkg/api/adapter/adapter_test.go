@@ -0,0 +1,64 @@
+package adapter_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/adapter"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestDomains(t *testing.T) {
+	var updated *fastly.UpdateDomainInput
+	a := adapter.New(mock.API{
+		ListDomainsFn: func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+			return []*fastly.Domain{
+				{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: "www.example.com", CreatedAt: &testutil.Date},
+			}, nil
+		},
+		UpdateDomainFn: func(i *fastly.UpdateDomainInput) (*fastly.Domain, error) {
+			updated = i
+			return nil, testutil.Err
+		},
+	})
+
+	domains, err := a.Domains().List(&adapter.ListDomainsInput{ServiceID: "123", ServiceVersion: 2})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []*adapter.Domain{
+		{ServiceID: "123", ServiceVersion: 2, Name: "www.example.com", CreatedAt: &testutil.Date},
+	}, domains)
+
+	d, err := a.Domains().Update(&adapter.UpdateDomainInput{
+		ServiceID:      "123",
+		ServiceVersion: 2,
+		Name:           "www.example.com",
+		Comment:        adapter.String("hello"),
+	})
+	testutil.AssertErrorContains(t, err, testutil.Err.Error())
+	testutil.AssertBool(t, true, d == nil)
+	testutil.AssertEqual(t, &fastly.UpdateDomainInput{
+		ServiceID:      "123",
+		ServiceVersion: 2,
+		Name:           "www.example.com",
+		Comment:        fastly.String("hello"),
+	}, updated)
+}
+
+func TestVersions(t *testing.T) {
+	a := adapter.New(mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CloneVersionFn: testutil.CloneVersionResult(4),
+	})
+
+	versions, err := a.Versions().List(&adapter.ListVersionsInput{ServiceID: "123"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(versions))
+	testutil.AssertBool(t, true, versions[0].Active)
+	testutil.AssertBool(t, true, versions[1].Locked)
+
+	v, err := a.Versions().Clone(&adapter.VersionInput{ServiceID: "123", ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 4, v.Number)
+	testutil.AssertString(t, "123", v.ServiceID)
+}
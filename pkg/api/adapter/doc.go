@@ -0,0 +1,14 @@
+// Package adapter is a stable layer between commands and the Fastly API client
+// library (go-fastly).
+//
+// Each area of the API (e.g. domains) has an interface whose methods accept
+// and return types defined by this package, with helpers that convert them to
+// and from the go-fastly types. A major version upgrade of go-fastly then only
+// requires changes to the conversion helpers, rather than to every command
+// that uses the API. Commands that extend the CLI should use this package, so
+// they keep compiling across go-fastly upgrades.
+//
+// NOTE: Not every area of the API has been moved behind the adapter yet. The
+// remaining commands use api.Interface directly, and will move to the adapter
+// as each area is added.
+package adapter
@@ -0,0 +1,145 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Domain is a domain that a service version responds to.
+type Domain struct {
+	ServiceID      string
+	ServiceVersion int
+	Name           string
+	Comment        string
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	DeletedAt      *time.Time
+}
+
+// ListDomainsInput is the input to Domains.List.
+type ListDomainsInput struct {
+	ServiceID      string
+	ServiceVersion int
+}
+
+// DomainInput identifies a domain of a service version, and is the input to
+// the methods of Domains that operate on a single domain.
+type DomainInput struct {
+	ServiceID      string
+	ServiceVersion int
+	Name           string
+}
+
+// CreateDomainInput is the input to Domains.Create.
+type CreateDomainInput struct {
+	ServiceID      string
+	ServiceVersion int
+	Name           string
+	Comment        string
+}
+
+// UpdateDomainInput is the input to Domains.Update. Fields that are nil aren't
+// changed.
+type UpdateDomainInput struct {
+	ServiceID      string
+	ServiceVersion int
+	Name           string
+	NewName        *string
+	Comment        *string
+}
+
+// Domains is the API for the domains of a service version.
+type Domains interface {
+	List(*ListDomainsInput) ([]*Domain, error)
+	Get(*DomainInput) (*Domain, error)
+	Create(*CreateDomainInput) (*Domain, error)
+	Update(*UpdateDomainInput) (*Domain, error)
+	Delete(*DomainInput) error
+}
+
+type domains struct {
+	client api.Interface
+}
+
+// List implements the Domains interface.
+func (d domains) List(i *ListDomainsInput) ([]*Domain, error) {
+	ds, err := d.client.ListDomains(&fastly.ListDomainsInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	})
+	return DomainsFromFastly(ds), err
+}
+
+// Get implements the Domains interface.
+func (d domains) Get(i *DomainInput) (*Domain, error) {
+	return domainResult(d.client.GetDomain(&fastly.GetDomainInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+	}))
+}
+
+// Create implements the Domains interface.
+func (d domains) Create(i *CreateDomainInput) (*Domain, error) {
+	return domainResult(d.client.CreateDomain(&fastly.CreateDomainInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+		Comment:        i.Comment,
+	}))
+}
+
+// Update implements the Domains interface.
+func (d domains) Update(i *UpdateDomainInput) (*Domain, error) {
+	return domainResult(d.client.UpdateDomain(&fastly.UpdateDomainInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+		NewName:        i.NewName,
+		Comment:        i.Comment,
+	}))
+}
+
+// Delete implements the Domains interface.
+func (d domains) Delete(i *DomainInput) error {
+	return d.client.DeleteDomain(&fastly.DeleteDomainInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+	})
+}
+
+func domainResult(d *fastly.Domain, err error) (*Domain, error) {
+	return DomainFromFastly(d), err
+}
+
+// DomainFromFastly converts a go-fastly domain. A nil domain is converted to
+// nil.
+func DomainFromFastly(d *fastly.Domain) *Domain {
+	if d == nil {
+		return nil
+	}
+	return &Domain{
+		ServiceID:      d.ServiceID,
+		ServiceVersion: d.ServiceVersion,
+		Name:           d.Name,
+		Comment:        d.Comment,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		DeletedAt:      d.DeletedAt,
+	}
+}
+
+// DomainsFromFastly converts a list of go-fastly domains.
+func DomainsFromFastly(ds []*fastly.Domain) []*Domain {
+	if ds == nil {
+		return nil
+	}
+	result := make([]*Domain, len(ds))
+	for i, d := range ds {
+		result[i] = DomainFromFastly(d)
+	}
+	return result
+}
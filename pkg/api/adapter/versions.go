@@ -0,0 +1,132 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Version is a version of a service's configuration.
+type Version struct {
+	Number    int
+	Comment   string
+	ServiceID string
+	Active    bool
+	Locked    bool
+	Deployed  bool
+	Staging   bool
+	Testing   bool
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+// ListVersionsInput is the input to Versions.List.
+type ListVersionsInput struct {
+	ServiceID string
+}
+
+// VersionInput identifies a service version, and is the input to the methods
+// of Versions that operate on a single version.
+type VersionInput struct {
+	ServiceID      string
+	ServiceVersion int
+}
+
+// Versions is the API for the versions of a service.
+type Versions interface {
+	List(*ListVersionsInput) ([]*Version, error)
+	Get(*VersionInput) (*Version, error)
+	Clone(*VersionInput) (*Version, error)
+	Activate(*VersionInput) (*Version, error)
+	Deactivate(*VersionInput) (*Version, error)
+	Lock(*VersionInput) (*Version, error)
+}
+
+type versions struct {
+	client api.Interface
+}
+
+// List implements the Versions interface.
+func (v versions) List(i *ListVersionsInput) ([]*Version, error) {
+	vs, err := v.client.ListVersions(&fastly.ListVersionsInput{ServiceID: i.ServiceID})
+	return VersionsFromFastly(vs), err
+}
+
+// Get implements the Versions interface.
+func (v versions) Get(i *VersionInput) (*Version, error) {
+	return versionResult(v.client.GetVersion(&fastly.GetVersionInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}))
+}
+
+// Clone implements the Versions interface.
+func (v versions) Clone(i *VersionInput) (*Version, error) {
+	return versionResult(v.client.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}))
+}
+
+// Activate implements the Versions interface.
+func (v versions) Activate(i *VersionInput) (*Version, error) {
+	return versionResult(v.client.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}))
+}
+
+// Deactivate implements the Versions interface.
+func (v versions) Deactivate(i *VersionInput) (*Version, error) {
+	return versionResult(v.client.DeactivateVersion(&fastly.DeactivateVersionInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}))
+}
+
+// Lock implements the Versions interface.
+func (v versions) Lock(i *VersionInput) (*Version, error) {
+	return versionResult(v.client.LockVersion(&fastly.LockVersionInput{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}))
+}
+
+func versionResult(v *fastly.Version, err error) (*Version, error) {
+	return VersionFromFastly(v), err
+}
+
+// VersionFromFastly converts a go-fastly version. A nil version is converted to
+// nil.
+func VersionFromFastly(v *fastly.Version) *Version {
+	if v == nil {
+		return nil
+	}
+	return &Version{
+		Number:    v.Number,
+		Comment:   v.Comment,
+		ServiceID: v.ServiceID,
+		Active:    v.Active,
+		Locked:    v.Locked,
+		Deployed:  v.Deployed,
+		Staging:   v.Staging,
+		Testing:   v.Testing,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+		DeletedAt: v.DeletedAt,
+	}
+}
+
+// VersionsFromFastly converts a list of go-fastly versions.
+func VersionsFromFastly(vs []*fastly.Version) []*Version {
+	if vs == nil {
+		return nil
+	}
+	result := make([]*Version, len(vs))
+	for i, v := range vs {
+		result[i] = VersionFromFastly(v)
+	}
+	return result
+}
@@ -57,6 +57,15 @@ type Interface interface {
 	UpdateHealthCheck(*fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error)
 	DeleteHealthCheck(*fastly.DeleteHealthCheckInput) error
 
+	CreateGzip(*fastly.CreateGzipInput) (*fastly.Gzip, error)
+	ListGzips(*fastly.ListGzipsInput) ([]*fastly.Gzip, error)
+	UpdateGzip(*fastly.UpdateGzipInput) (*fastly.Gzip, error)
+	DeleteGzip(*fastly.DeleteGzipInput) error
+
+	CreateCacheSetting(*fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error)
+	ListCacheSettings(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error)
+	DeleteCacheSetting(*fastly.DeleteCacheSettingInput) error
+
 	GetPackage(*fastly.GetPackageInput) (*fastly.Package, error)
 	UpdatePackage(*fastly.UpdatePackageInput) (*fastly.Package, error)
 
@@ -228,11 +237,14 @@ type Interface interface {
 	GetRegions() (*fastly.RegionsResponse, error)
 	GetStatsJSON(*fastly.GetStatsInput, interface{}) error
 
+	GetAPIEvents(*fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error)
+
 	CreateManagedLogging(*fastly.CreateManagedLoggingInput) (*fastly.ManagedLogging, error)
 
 	CreateVCL(*fastly.CreateVCLInput) (*fastly.VCL, error)
 	ListVCLs(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
 	GetVCL(*fastly.GetVCLInput) (*fastly.VCL, error)
+	GetGeneratedVCL(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error)
 	UpdateVCL(*fastly.UpdateVCLInput) (*fastly.VCL, error)
 	DeleteVCL(*fastly.DeleteVCLInput) error
 
@@ -244,11 +256,33 @@ type Interface interface {
 	UpdateDynamicSnippet(i *fastly.UpdateDynamicSnippetInput) (*fastly.DynamicSnippet, error)
 	DeleteSnippet(i *fastly.DeleteSnippetInput) error
 
+	ListConditions(i *fastly.ListConditionsInput) ([]*fastly.Condition, error)
+	CreateCondition(i *fastly.CreateConditionInput) (*fastly.Condition, error)
+	GetCondition(i *fastly.GetConditionInput) (*fastly.Condition, error)
+	UpdateCondition(i *fastly.UpdateConditionInput) (*fastly.Condition, error)
+	DeleteCondition(i *fastly.DeleteConditionInput) error
+
+	ListDirectors(i *fastly.ListDirectorsInput) ([]*fastly.Director, error)
+	ListRequestSettings(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error)
+	CreateDirectorBackend(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error)
+	GetDirectorBackend(i *fastly.GetDirectorBackendInput) (*fastly.DirectorBackend, error)
+	DeleteDirectorBackend(i *fastly.DeleteDirectorBackendInput) error
+
+	CreateHeader(i *fastly.CreateHeaderInput) (*fastly.Header, error)
+	ListHeaders(i *fastly.ListHeadersInput) ([]*fastly.Header, error)
+	DeleteHeader(i *fastly.DeleteHeaderInput) error
+
+	CreateResponseObject(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error)
+	GetResponseObject(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error)
+	DeleteResponseObject(i *fastly.DeleteResponseObjectInput) error
+
 	Purge(i *fastly.PurgeInput) (*fastly.Purge, error)
 	PurgeKey(i *fastly.PurgeKeyInput) (*fastly.Purge, error)
 	PurgeKeys(i *fastly.PurgeKeysInput) (map[string]string, error)
 	PurgeAll(i *fastly.PurgeAllInput) (*fastly.Purge, error)
 
+	EdgeCheck(i *fastly.EdgeCheckInput) ([]*fastly.EdgeCheck, error)
+
 	CreateACL(i *fastly.CreateACLInput) (*fastly.ACL, error)
 	DeleteACL(i *fastly.DeleteACLInput) error
 	GetACL(i *fastly.GetACLInput) (*fastly.ACL, error)
@@ -284,6 +318,25 @@ type Interface interface {
 	ListCustomerTokens(i *fastly.ListCustomerTokensInput) ([]*fastly.Token, error)
 	ListTokens() ([]*fastly.Token, error)
 
+	ListWAFs(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error)
+
+	ListAllWAFActiveRules(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error)
+	CreateWAFActiveRules(i *fastly.CreateWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error)
+	DeleteWAFActiveRules(i *fastly.DeleteWAFActiveRulesInput) error
+
+	ListAllWAFRuleExclusions(i *fastly.ListAllWAFRuleExclusionsInput) (*fastly.WAFRuleExclusionResponse, error)
+	CreateWAFRuleExclusion(i *fastly.CreateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error)
+	UpdateWAFRuleExclusion(i *fastly.UpdateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error)
+	DeleteWAFRuleExclusion(i *fastly.DeleteWAFRuleExclusionInput) error
+
+	ListAllWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error)
+	CloneWAFVersion(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error)
+	LockWAFVersion(i *fastly.LockWAFVersionInput) (*fastly.WAFVersion, error)
+	DeployWAFVersion(i *fastly.DeployWAFVersionInput) error
+
+	ListTLSActivations(i *fastly.ListTLSActivationsInput) ([]*fastly.TLSActivation, error)
+	ListTLSSubscriptions(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error)
+
 	NewListACLEntriesPaginator(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries
 	NewListDictionaryItemsPaginator(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems
 	NewListServicesPaginator(i *fastly.ListServicesInput) fastly.PaginatorServices
@@ -0,0 +1,200 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// cacheablePaths are the paths of the read-only API calls whose responses
+// Cache stores. They're the calls that are made repeatedly, both within one
+// invocation (e.g. looking up a service's versions) and across scripted runs.
+var cacheablePaths = []*regexp.Regexp{
+	regexp.MustCompile(`^/service$`),
+	regexp.MustCompile(`^/service/search$`),
+	regexp.MustCompile(`^/service/[^/]+$`),
+	regexp.MustCompile(`^/service/[^/]+/details$`),
+	regexp.MustCompile(`^/service/[^/]+/version$`),
+	regexp.MustCompile(`^/service/[^/]+/version/\d+$`),
+	regexp.MustCompile(`^/datacenters$`),
+	regexp.MustCompile(`^/public-ip-list$`),
+}
+
+// Cacheable reports whether the response to a request with the given method
+// and URL path may be stored by Cache.
+func Cacheable(method, path string) bool {
+	if method != http.MethodGet {
+		return false
+	}
+	for _, re := range cacheablePaths {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cache stores the successful responses of read-only API calls (see
+// Cacheable) on disk, and serves them until they're older than TTL.
+//
+// Responses are stored separately for each API token, so that a response is
+// never served to a different user. Any request that could modify the
+// account removes all of the responses stored for its token, so that
+// commands run after a change never see stale data.
+type Cache struct {
+	// Dir is the directory the responses are stored in.
+	Dir string
+	// TTL is how long a stored response is served for. If it's zero then
+	// responses are neither stored nor served, but those made stale by
+	// requests that could modify the account are still removed.
+	TTL time.Duration
+	// Now returns the current time. If nil then time.Now is used.
+	Now func() time.Time
+	// Out, if set, is where each response served from the cache is reported.
+	Out io.Writer
+}
+
+// Transport returns a http.RoundTripper that makes requests via next, unless
+// a response to the request is stored in c. If next is nil then
+// http.DefaultTransport is used.
+func (c *Cache) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cacheTransport{cache: c, next: next}
+}
+
+func (c *Cache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// tokenDir returns the directory of the responses stored for the request's
+// API token.
+func (c *Cache) tokenDir(req *http.Request) string {
+	return filepath.Join(c.Dir, hash(req.Header.Get("Fastly-Key"))[:16])
+}
+
+func (c *Cache) path(req *http.Request) string {
+	return filepath.Join(c.tokenDir(req), hash(req.Method+" "+req.URL.String()))
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// load returns the stored response to the request, if there's one that
+// hasn't expired.
+func (c *Cache) load(req *http.Request) (*http.Response, time.Duration, bool) {
+	path := c.path(req)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, false
+	}
+	age := c.now().Sub(info.ModTime())
+	if age < 0 || age >= c.TTL {
+		return nil, 0, false
+	}
+	// #nosec G304 (CWE-22) the path is derived from a hash of the request.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, false
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil, 0, false
+	}
+	return resp, age, true
+}
+
+// store saves the response, whose body is replaced so it can still be read.
+// Failing to store a response isn't an error, as it can always be requested
+// again.
+func (c *Cache) store(req *http.Request, resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	stored := *resp
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+	stored.ContentLength = int64(len(body))
+	stored.TransferEncoding = nil
+	data, err := httputil.DumpResponse(&stored, true)
+	if err != nil {
+		return nil
+	}
+
+	dir := c.tokenDir(req)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil
+	}
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return nil
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	// The modification time records when the response was stored.
+	if err == nil {
+		now := c.now()
+		err = os.Chtimes(f.Name(), now, now)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(req))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return nil
+}
+
+type cacheTransport struct {
+	cache *Cache
+	next  http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		_ = os.RemoveAll(t.cache.tokenDir(req))
+		return t.next.RoundTrip(req)
+	}
+
+	cacheable := t.cache.TTL > 0 && Cacheable(req.Method, req.URL.Path)
+	if cacheable {
+		if resp, age, ok := t.cache.load(req); ok {
+			if t.cache.Out != nil {
+				fmt.Fprintf(t.cache.Out, "%s %s served from the cache (age: %s)\n", req.Method, req.URL.Path, age.Round(time.Second))
+			}
+			return resp, nil
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || !cacheable || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	if err := t.cache.store(req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,103 @@
+package transport_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestCache(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"id":"123"}]`)
+	}))
+	defer ts.Close()
+
+	now := time.Now()
+	var out bytes.Buffer
+	cache := &transport.Cache{
+		Dir: t.TempDir(),
+		TTL: time.Minute,
+		Now: func() time.Time { return now },
+		Out: &out,
+	}
+	c := &http.Client{Transport: cache.Transport(nil)}
+
+	get := func(path, token string) string {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Fastly-Key", token)
+		resp, err := c.Do(req)
+		testutil.AssertNoError(t, err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		testutil.AssertNoError(t, err)
+		return string(b)
+	}
+
+	testutil.AssertString(t, `[{"id":"123"}]`, get("/service", "a"))
+	testutil.AssertString(t, `[{"id":"123"}]`, get("/service", "a"))
+	testutil.AssertEqual(t, 1, calls)
+	testutil.AssertStringContains(t, out.String(), "GET /service served from the cache")
+
+	// Responses aren't shared between tokens.
+	get("/service", "b")
+	testutil.AssertEqual(t, 2, calls)
+
+	// Only the listed read-only calls are cached.
+	get("/service/123/version/1/backend", "a")
+	get("/service/123/version/1/backend", "a")
+	testutil.AssertEqual(t, 4, calls)
+
+	// Responses expire.
+	now = now.Add(2 * time.Minute)
+	get("/service", "a")
+	testutil.AssertEqual(t, 5, calls)
+	get("/service", "a")
+	testutil.AssertEqual(t, 5, calls)
+	get("/service", "b")
+	testutil.AssertEqual(t, 6, calls)
+
+	// A request that could modify the account removes the token's responses.
+	req, err := http.NewRequest(http.MethodPut, ts.URL+"/service/123", strings.NewReader("name=foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Fastly-Key", "a")
+	resp, err := c.Do(req)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+	testutil.AssertEqual(t, 7, calls)
+	get("/service", "a")
+	testutil.AssertEqual(t, 8, calls)
+	get("/service", "b")
+	testutil.AssertEqual(t, 8, calls)
+}
+
+func TestCacheable(t *testing.T) {
+	for _, testcase := range []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodGet, "/service", true},
+		{http.MethodGet, "/service/123/version", true},
+		{http.MethodGet, "/service/123/version/2", true},
+		{http.MethodGet, "/datacenters", true},
+		{http.MethodGet, "/public-ip-list", true},
+		{http.MethodGet, "/service/123/version/2/backend", false},
+		{http.MethodPost, "/service", false},
+	} {
+		testutil.AssertEqual(t, testcase.want, transport.Cacheable(testcase.method, testcase.path))
+	}
+}
@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+// Capture records the body of the last successful JSON response received via
+// its transport, so that a command's result can be displayed as structured
+// output even when the command only renders it as text (see --output).
+type Capture struct {
+	mu   sync.Mutex
+	last []byte
+}
+
+// Transport returns a http.RoundTripper that records the successful JSON
+// responses received via next. If next is nil then http.DefaultTransport is
+// used.
+func (c *Capture) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &captureTransport{capture: c, next: next}
+}
+
+// Last returns the body of the last successful JSON response, or nil if there
+// hasn't been one. A nil *Capture has recorded nothing.
+func (c *Capture) Last() []byte {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last
+}
+
+type captureTransport struct {
+	capture *Capture
+	next    http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, err
+	}
+	mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mt != "application/json" {
+		return resp, err
+	}
+
+	// The body is read in full so that it can be both recorded and decoded
+	// by the API client.
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.capture.mu.Lock()
+	t.capture.last = body
+	t.capture.mu.Unlock()
+	return resp, nil
+}
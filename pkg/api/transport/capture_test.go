@@ -0,0 +1,52 @@
+package transport_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestCapture(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			io.WriteString(w, `{"name":"example"}`)
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, "ok")
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"msg":"not found"}`)
+		}
+	}))
+	defer ts.Close()
+
+	var capture *transport.Capture
+	testutil.AssertEqual(t, []byte(nil), capture.Last())
+
+	capture = new(transport.Capture)
+	c := &http.Client{Transport: capture.Transport(nil)}
+	get := func(path string) string {
+		resp, err := c.Get(ts.URL + path)
+		testutil.AssertNoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		testutil.AssertNoError(t, err)
+		return string(body)
+	}
+
+	// The body is still readable by the client after it's been recorded.
+	testutil.AssertString(t, `{"name":"example"}`, get("/json"))
+	testutil.AssertString(t, `{"name":"example"}`, string(capture.Last()))
+
+	// Neither non-JSON nor error responses replace the recorded body.
+	get("/text")
+	get("/missing")
+	testutil.AssertString(t, `{"name":"example"}`, string(capture.Last()))
+}
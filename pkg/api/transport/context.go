@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// Context returns a http.RoundTripper that makes requests via next bound to
+// ctx, so that in-flight requests are cancelled when ctx is (e.g. when the
+// user presses Ctrl-C).
+//
+// NOTE: go-fastly doesn't accept a context, so its requests are made with the
+// background context. Requests that already have a cancellable context are
+// left alone.
+//
+// If next is nil then http.DefaultTransport is used.
+func Context(next http.RoundTripper, ctx context.Context) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &contextTransport{next: next, ctx: ctx}
+}
+
+type contextTransport struct {
+	next http.RoundTripper
+	ctx  context.Context
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(bind(req, t.ctx))
+}
+
+// ContextClient returns an api.HTTPClient that makes requests via c bound to
+// ctx, in the same way as Context.
+func ContextClient(c api.HTTPClient, ctx context.Context) api.HTTPClient {
+	return &contextClient{client: c, ctx: ctx}
+}
+
+type contextClient struct {
+	client api.HTTPClient
+	ctx    context.Context
+}
+
+// Do implements the api.HTTPClient interface.
+func (c *contextClient) Do(req *http.Request) (*http.Response, error) {
+	return c.client.Do(bind(req, c.ctx))
+}
+
+func bind(req *http.Request, ctx context.Context) *http.Request {
+	if req.Context().Done() != nil {
+		return req
+	}
+	return req.WithContext(ctx)
+}
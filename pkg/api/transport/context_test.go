@@ -0,0 +1,49 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+)
+
+func TestContext(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	for name, do := range map[string]func(ctx context.Context, req *http.Request) (*http.Response, error){
+		"transport": func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			c := &http.Client{Transport: transport.Context(nil, ctx)}
+			return c.Do(req)
+		},
+		"client": func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return transport.ContextClient(http.DefaultClient, ctx).Do(req)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := time.Now()
+			_, err = do(ctx, req)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("want the request to be cancelled, got: %v", err)
+			}
+			if d := time.Since(start); d > 5*time.Second {
+				t.Fatalf("want the request to be cancelled promptly, took %s", d)
+			}
+		})
+	}
+}
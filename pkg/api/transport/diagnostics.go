@@ -0,0 +1,176 @@
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// MaxTraceBodySize is the largest request/response body included in a trace.
+// Larger bodies (e.g. Compute@Edge package uploads) are omitted.
+const MaxTraceBodySize = 64 * 1024
+
+var (
+	// sensitiveHeaderRegEx matches the names of headers whose values are
+	// redacted from traces: cookies, and any whose name suggests a credential
+	// (e.g. Authorization, Fastly-Key, or the DD-API-KEY sent to Datadog).
+	sensitiveHeaderRegEx = regexp.MustCompile(`(?i)key|token|secret|auth|cookie`)
+	// sensitiveFormRegEx matches form values whose field name suggests a
+	// credential (e.g. password=..., secret_key=...).
+	sensitiveFormRegEx = regexp.MustCompile(`(?im)((?:^|[&?])[^=&\s]*(?:password|secret|token|key)[^=&\s]*=)[^&\s]*`)
+	// sensitiveJSONRegEx matches JSON string values whose field name suggests a
+	// credential (e.g. "password": "...").
+	sensitiveJSONRegEx = regexp.MustCompile(`(?i)("[^"]*(?:password|secret|token|key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// Diagnostics returns a http.RoundTripper that writes the method, URL, status
+// and duration of every request made via next to out. If trace is true then a
+// sanitized dump of each request and response is also written.
+//
+// If next is nil then http.DefaultTransport is used.
+func Diagnostics(next http.RoundTripper, trace bool, out io.Writer) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &diagnosticsTransport{do: next.RoundTrip, out: out, trace: trace}
+}
+
+// DiagnosticsClient returns an api.HTTPClient that writes the requests made via
+// c to out, in the same way as Diagnostics.
+func DiagnosticsClient(c api.HTTPClient, trace bool, out io.Writer) api.HTTPClient {
+	return &diagnosticsTransport{do: c.Do, out: out, trace: trace}
+}
+
+type diagnosticsTransport struct {
+	do    func(*http.Request) (*http.Response, error)
+	mu    sync.Mutex
+	out   io.Writer
+	trace bool
+}
+
+// Do implements the api.HTTPClient interface.
+func (t *diagnosticsTransport) Do(req *http.Request) (*http.Response, error) {
+	return t.RoundTrip(req)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *diagnosticsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqDump []byte
+	if t.trace {
+		reqDump = dumpRequest(req)
+	}
+
+	start := time.Now()
+	resp, err := t.do(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		fmt.Fprintf(t.out, "[api] %s %s failed after %s: %v\n", req.Method, req.URL.RequestURI(), elapsed, err)
+	} else {
+		fmt.Fprintf(t.out, "[api] %s %s -> %s (%s)\n", req.Method, req.URL.RequestURI(), resp.Status, elapsed)
+	}
+
+	if t.trace {
+		fmt.Fprintf(t.out, "\n--- request ---\n%s\n", bytes.TrimSpace(reqDump))
+		if resp != nil {
+			fmt.Fprintf(t.out, "--- response ---\n%s\n\n", bytes.TrimSpace(dumpResponse(resp)))
+		}
+	}
+
+	return resp, err
+}
+
+// dumpRequest returns a sanitized dump of the request.
+func dumpRequest(req *http.Request) []byte {
+	body := includeBody(req.Header, req.ContentLength)
+	dump, err := httputil.DumpRequestOut(req, body)
+	if err != nil {
+		return []byte(fmt.Sprintf("error dumping request: %v", err))
+	}
+	if !body && req.ContentLength != 0 {
+		dump = append(dump, []byte(bodyOmitted(req.ContentLength))...)
+	}
+	return Sanitize(dump)
+}
+
+// dumpResponse returns a sanitized dump of the response.
+func dumpResponse(resp *http.Response) []byte {
+	body := includeBody(resp.Header, resp.ContentLength)
+	dump, err := httputil.DumpResponse(resp, body)
+	if err != nil {
+		return []byte(fmt.Sprintf("error dumping response: %v", err))
+	}
+	if !body && resp.ContentLength != 0 {
+		dump = append(dump, []byte(bodyOmitted(resp.ContentLength))...)
+	}
+	return Sanitize(dump)
+}
+
+// includeBody indicates whether a body is small and textual enough to trace.
+func includeBody(h http.Header, length int64) bool {
+	if length < 0 || length > MaxTraceBodySize {
+		return false
+	}
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	for _, t := range []string{"json", "text/", "x-www-form-urlencoded", "xml"} {
+		if strings.Contains(ct, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func bodyOmitted(length int64) string {
+	if length < 0 {
+		return "<body omitted>"
+	}
+	return fmt.Sprintf("<%d byte body omitted>", length)
+}
+
+// SensitiveHeader reports whether the value of the named header is redacted
+// from traces, as it may be a credential.
+func SensitiveHeader(name string) bool {
+	return sensitiveHeaderRegEx.MatchString(name)
+}
+
+// Sanitize redacts credentials from a HTTP dump: the values of sensitive
+// headers, and any form or JSON fields that look like credentials.
+func Sanitize(dump []byte) []byte {
+	lines := strings.Split(string(dump), "\n")
+	// The headers follow the request or status line, up to the blank line
+	// that separates them from the body.
+	for i := 1; i < len(lines) && strings.TrimSuffix(lines[i], "\r") != ""; i++ {
+		name, _, ok := strings.Cut(lines[i], ":")
+		if !ok || !SensitiveHeader(name) {
+			continue
+		}
+		redacted := name + ": REDACTED"
+		if strings.HasSuffix(lines[i], "\r") {
+			redacted += "\r"
+		}
+		lines[i] = redacted
+	}
+	return SanitizeBody([]byte(strings.Join(lines, "\n")))
+}
+
+// SanitizeBody redacts any form or JSON fields that look like credentials from
+// a HTTP body (see Sanitize).
+func SanitizeBody(body []byte) []byte {
+	s := sensitiveFormRegEx.ReplaceAllString(string(body), "${1}REDACTED")
+	s = sensitiveJSONRegEx.ReplaceAllString(s, `${1}"REDACTED"`)
+	return []byte(s)
+}
@@ -0,0 +1,93 @@
+package transport_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestDiagnostics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		io.WriteString(w, `{"id":"123","access_token":"s3cr3t"}`)
+	}))
+	defer ts.Close()
+
+	for _, trace := range []bool{false, true} {
+		var out bytes.Buffer
+		c := &http.Client{Transport: transport.Diagnostics(nil, trace, &out)}
+
+		form := url.Values{"name": {"example"}, "password": {"hunter2"}}
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/tokens?per_page=100", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Fastly-Key", "my-api-token")
+
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The response body must still be readable by the caller.
+		testutil.AssertString(t, `{"id":"123","access_token":"s3cr3t"}`, string(body))
+
+		testutil.AssertStringContains(t, out.String(), "[api] POST /tokens?per_page=100 -> 200 OK")
+		if !trace {
+			testutil.AssertStringDoesntContain(t, out.String(), "--- request ---")
+			continue
+		}
+		testutil.AssertStringContains(t, out.String(), "--- request ---")
+		testutil.AssertStringContains(t, out.String(), "Fastly-Key: REDACTED")
+		testutil.AssertStringContains(t, out.String(), "name=example&password=REDACTED")
+		testutil.AssertStringContains(t, out.String(), "Set-Cookie: REDACTED")
+		testutil.AssertStringContains(t, out.String(), `"access_token":"REDACTED"`)
+		testutil.AssertStringDoesntContain(t, out.String(), "my-api-token")
+		testutil.AssertStringDoesntContain(t, out.String(), "hunter2")
+		testutil.AssertStringDoesntContain(t, out.String(), "s3cr3t")
+	}
+}
+
+func TestDiagnosticsClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	c := transport.DiagnosticsClient(http.DefaultClient, true, &out)
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/hooks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer my-secret")
+	req.Header.Set("DD-API-KEY", "datadog-secret")
+	req.Header.Set("X-Vault-Token", "vault-secret")
+	req.Header.Set("X-Request-Source", "fastly-cli")
+	resp, err := c.Do(req)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	testutil.AssertStringContains(t, out.String(), "[api] GET /hooks -> 202 Accepted")
+	testutil.AssertStringContains(t, out.String(), "Authorization: REDACTED")
+	testutil.AssertStringContains(t, out.String(), "Dd-Api-Key: REDACTED")
+	testutil.AssertStringContains(t, out.String(), "X-Vault-Token: REDACTED")
+	testutil.AssertStringContains(t, out.String(), "X-Request-Source: fastly-cli")
+	testutil.AssertStringDoesntContain(t, out.String(), "my-secret")
+	testutil.AssertStringDoesntContain(t, out.String(), "datadog-secret")
+	testutil.AssertStringDoesntContain(t, out.String(), "vault-secret")
+}
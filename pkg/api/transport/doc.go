@@ -0,0 +1,3 @@
+// Package transport provides http.RoundTripper implementations that decorate
+// the HTTP requests made to the Fastly API.
+package transport
@@ -0,0 +1,90 @@
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// dryRunResponse is the body of the responses to requests that weren't sent.
+// The status field satisfies the API client's checks of delete responses, and
+// is ignored when the body is decoded into any other resource.
+const dryRunResponse = `{"status":"ok"}`
+
+// DryRun prevents any request that could modify a resource, i.e. any request
+// whose method isn't GET, HEAD or OPTIONS, from being sent via its transport.
+// The method, path and body of each such request are written to Out instead,
+// with any credentials in the body redacted, and a successful response is
+// returned in its place (see --dry-run).
+type DryRun struct {
+	Out io.Writer
+
+	mu      sync.Mutex
+	skipped int
+}
+
+// Transport returns a http.RoundTripper that sends read requests via next and
+// reports all others to d.Out. If next is nil then http.DefaultTransport is
+// used.
+func (d *DryRun) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &dryRunTransport{dryRun: d, next: next}
+}
+
+// Skipped returns the number of requests that weren't sent. A nil *DryRun has
+// skipped none.
+func (d *DryRun) Skipped() int {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.skipped
+}
+
+type dryRunTransport struct {
+	dryRun *DryRun
+	next   http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.dryRun.mu.Lock()
+	t.dryRun.skipped++
+	fmt.Fprintf(t.dryRun.Out, "DRY RUN: %s %s\n", req.Method, req.URL.RequestURI())
+	if len(body) > 0 {
+		fmt.Fprintf(t.dryRun.Out, "%s\n", SanitizeBody(body))
+	}
+	t.dryRun.mu.Unlock()
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(dryRunResponse))),
+		ContentLength: int64(len(dryRunResponse)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package transport_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestDryRun(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	d := &transport.DryRun{Out: &out}
+	c := &http.Client{Transport: d.Transport(nil)}
+
+	resp, err := c.Get(ts.URL)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	req, err := http.NewRequest(http.MethodPut, ts.URL+"/service/123/version/1/backend/foo", strings.NewReader("name=bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = c.Do(req)
+	testutil.AssertNoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, http.StatusOK, resp.StatusCode)
+	testutil.AssertEqual(t, `{"status":"ok"}`, string(body))
+
+	req, err = http.NewRequest(http.MethodDelete, ts.URL+"/service/123", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = c.Do(req)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	testutil.AssertEqual(t, []string{http.MethodGet}, methods)
+	testutil.AssertEqual(t, 2, d.Skipped())
+	testutil.AssertString(t, "DRY RUN: PUT /service/123/version/1/backend/foo\nname=bar\nDRY RUN: DELETE /service/123\n", out.String())
+}
+
+func TestDryRunRedactsCredentials(t *testing.T) {
+	var out bytes.Buffer
+	d := &transport.DryRun{Out: &out}
+	c := &http.Client{Transport: d.Transport(nil)}
+
+	req, err := http.NewRequest(http.MethodPost, "http://127.0.0.1/tokens", strings.NewReader("name=ci&password=hunter2&services=123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Do(req)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	req, err = http.NewRequest(http.MethodPut, "http://127.0.0.1/service/123/version/1/logging/s3/logs", strings.NewReader(`{"name":"logs","secret_key":"abc123"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = c.Do(req)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	testutil.AssertString(t, "DRY RUN: POST /tokens\nname=ci&password=REDACTED&services=123\nDRY RUN: PUT /service/123/version/1/logging/s3/logs\n{\"name\":\"logs\",\"secret_key\":\"REDACTED\"}\n", out.String())
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// NewNetwork returns a transport that connects via the proxy, and trusts the
+// root certificates in the PEM encoded caFile in addition to the system's.
+//
+// If proxy is empty then the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables are respected, as they are by default. If caFile is empty then
+// only the system's root certificates are trusted.
+func NewNetwork(proxy, caFile string) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL '%s'", proxy)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	if caFile != "" {
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we need to load the CA file the user configured.
+		/* #nosec */
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in CA file '%s'", caFile)
+		}
+		t.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,66 @@
+package transport_test
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestNetworkProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		io.WriteString(w, "ok")
+	}))
+	defer proxy.Close()
+
+	n, err := transport.NewNetwork(proxy.URL, "")
+	testutil.AssertNoError(t, err)
+	resp, err := (&http.Client{Transport: n}).Get("http://api.example.com/service")
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+	testutil.AssertString(t, "http://api.example.com/service", proxied)
+
+	_, err = transport.NewNetwork("proxy.example.com:3128", "")
+	testutil.AssertErrorContains(t, err, "invalid proxy URL 'proxy.example.com:3128'")
+}
+
+func TestNetworkCAFile(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	// The server's certificate isn't trusted by default.
+	n, err := transport.NewNetwork("", "")
+	testutil.AssertNoError(t, err)
+	_, err = (&http.Client{Transport: n}).Get(ts.URL)
+	testutil.AssertErrorContains(t, err, "certificate")
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(caFile, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	n, err = transport.NewNetwork("", caFile)
+	testutil.AssertNoError(t, err)
+	resp, err := (&http.Client{Transport: n}).Get(ts.URL)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = transport.NewNetwork("", empty)
+	testutil.AssertErrorContains(t, err, "no PEM encoded certificates found in CA file")
+
+	_, err = transport.NewNetwork("", filepath.Join(dir, "missing.pem"))
+	testutil.AssertErrorContains(t, err, "error reading CA file")
+}
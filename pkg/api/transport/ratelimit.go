@@ -0,0 +1,200 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// The headers the Fastly API reports a token's rate limit with.
+const (
+	RateLimitRemainingHeader = "Fastly-RateLimit-Remaining"
+	RateLimitResetHeader     = "Fastly-RateLimit-Reset"
+)
+
+// RateLimitsFile is the name of the state file RateLimits persists the rate
+// limit observed for each token to.
+const RateLimitsFile = "ratelimits.json"
+
+// RateLimit is the state of the API's rate limit, as reported by a response.
+type RateLimit struct {
+	// Remaining is the number of requests that can be made before the limit
+	// is reached.
+	Remaining int `json:"remaining"`
+	// Reset is when the limit is reset.
+	Reset time.Time `json:"reset"`
+	// Observed is when the response reporting the limit was received.
+	Observed time.Time `json:"observed"`
+}
+
+// ResetIn returns the time from now until the limit is reset, to the second.
+func (rl RateLimit) ResetIn(now time.Time) time.Duration {
+	return nonNegative(rl.Reset.Sub(now).Round(time.Second))
+}
+
+// ParseRateLimit returns the rate limit reported by the headers of a
+// response. It reports false if the headers are missing or invalid, as the
+// API only includes them in responses to requests that count towards the
+// limit (i.e. those that modify the account).
+func ParseRateLimit(h http.Header) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(h.Get(RateLimitRemainingHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	reset, err := strconv.ParseInt(h.Get(RateLimitResetHeader), 10, 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	return RateLimit{Remaining: remaining, Reset: time.Unix(reset, 0)}, true
+}
+
+// RateLimits records the most recent rate limit reported by the responses to
+// requests made via its transports, for the verbose output. If Path is set
+// then the rate limit is also persisted for each token, as it's only reported
+// by requests that modify the account, so that 'fastly rate-limit' can
+// display it later (see LoadRateLimit).
+type RateLimits struct {
+	// Path is the file the rate limits are persisted to. If it's empty then
+	// they aren't persisted.
+	Path string
+	// Now returns the current time. If nil then time.Now is used.
+	Now func() time.Time
+
+	mu   sync.Mutex
+	last *RateLimit
+}
+
+// Transport returns a http.RoundTripper that records the rate limit reported
+// by the responses to requests made via next. If next is nil then
+// http.DefaultTransport is used.
+func (r *RateLimits) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitTransport{do: next.RoundTrip, limits: r}
+}
+
+// Client returns an api.HTTPClient that records the rate limit reported by
+// the responses to requests made via c, in the same way as Transport.
+func (r *RateLimits) Client(c api.HTTPClient) api.HTTPClient {
+	return &rateLimitTransport{do: c.Do, limits: r}
+}
+
+// Last returns the most recently reported rate limit, or false if no response
+// reported one.
+func (r *RateLimits) Last() (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last == nil {
+		return RateLimit{}, false
+	}
+	return *r.last, true
+}
+
+func (r *RateLimits) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// record stores the rate limit reported for the token. Failing to persist it
+// is ignored, as it's only informational.
+func (r *RateLimits) record(token string, rl RateLimit) {
+	rl.Observed = r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &rl
+	if r.Path == "" || token == "" {
+		return
+	}
+
+	limits := readRateLimits(r.Path)
+	limits[tokenKey(token)] = rl
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return
+	}
+	_ = writeFileAtomic(r.Path, data)
+}
+
+// LoadRateLimit returns the rate limit most recently persisted for the token
+// to the file at path, or false if there isn't one.
+func LoadRateLimit(path, token string) (RateLimit, bool) {
+	rl, ok := readRateLimits(path)[tokenKey(token)]
+	return rl, ok
+}
+
+// readRateLimits returns the rate limits persisted to path, keyed by token.
+// A missing or corrupt file is treated as empty.
+func readRateLimits(path string) map[string]RateLimit {
+	limits := map[string]RateLimit{}
+	// #nosec G304 (CWE-22) the path is the CLI's own state file.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return limits
+	}
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return map[string]RateLimit{}
+	}
+	return limits
+}
+
+// tokenKey identifies a token in the persisted rate limits, without storing
+// the token itself.
+func tokenKey(token string) string {
+	return hash(token)[:16]
+}
+
+// writeFileAtomic replaces the file at path with data via a temporary file,
+// so that a concurrent reader never sees it partially written.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+type rateLimitTransport struct {
+	do     func(*http.Request) (*http.Response, error)
+	limits *RateLimits
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.do(req)
+	if err != nil {
+		return resp, err
+	}
+	if rl, ok := ParseRateLimit(resp.Header); ok {
+		t.limits.record(req.Header.Get("Fastly-Key"), rl)
+	}
+	return resp, err
+}
+
+// Do implements the api.HTTPClient interface.
+func (t *rateLimitTransport) Do(req *http.Request) (*http.Response, error) {
+	return t.RoundTrip(req)
+}
@@ -0,0 +1,98 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	h := http.Header{}
+	h.Set(transport.RateLimitRemainingHeader, "997")
+	h.Set(transport.RateLimitResetHeader, "946688400")
+	rl, ok := transport.ParseRateLimit(h)
+	testutil.AssertBool(t, true, ok)
+	testutil.AssertEqual(t, 997, rl.Remaining)
+	testutil.AssertBool(t, true, time.Date(2000, time.January, 1, 1, 0, 0, 0, time.UTC).Equal(rl.Reset))
+
+	_, ok = transport.ParseRateLimit(http.Header{})
+	testutil.AssertBool(t, false, ok)
+
+	h.Set(transport.RateLimitRemainingHeader, "lots")
+	_, ok = transport.ParseRateLimit(h)
+	testutil.AssertBool(t, false, ok)
+}
+
+func TestRateLimits(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set(transport.RateLimitRemainingHeader, "999")
+			w.Header().Set(transport.RateLimitResetHeader, "946688400")
+		}
+	}))
+	defer ts.Close()
+
+	var limits transport.RateLimits
+	c := &http.Client{Transport: limits.Transport(nil)}
+
+	_, ok := limits.Last()
+	testutil.AssertBool(t, false, ok)
+
+	resp, err := c.Post(ts.URL+"/service", "text/plain", nil)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	// A response without the headers doesn't replace the last rate limit.
+	resp, err = c.Get(ts.URL + "/service")
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	rl, ok := limits.Last()
+	testutil.AssertBool(t, true, ok)
+	testutil.AssertEqual(t, 999, rl.Remaining)
+}
+
+func TestRateLimitsPersist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(transport.RateLimitRemainingHeader, "998")
+		w.Header().Set(transport.RateLimitResetHeader, "946688400")
+	}))
+	defer ts.Close()
+
+	observed := time.Date(2000, time.January, 1, 0, 30, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "state", "ratelimits.json")
+	limits := transport.RateLimits{Path: path, Now: func() time.Time { return observed }}
+	c := &http.Client{Transport: limits.Transport(nil)}
+
+	for _, token := range []string{"alpha", "beta"} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/service", nil)
+		testutil.AssertNoError(t, err)
+		req.Header.Set("Fastly-Key", token)
+		resp, err := c.Do(req)
+		testutil.AssertNoError(t, err)
+		resp.Body.Close()
+	}
+
+	rl, ok := transport.LoadRateLimit(path, "alpha")
+	testutil.AssertBool(t, true, ok)
+	testutil.AssertEqual(t, 998, rl.Remaining)
+	testutil.AssertBool(t, true, observed.Equal(rl.Observed))
+	testutil.AssertBool(t, true, time.Date(2000, time.January, 1, 1, 0, 0, 0, time.UTC).Equal(rl.Reset))
+
+	_, ok = transport.LoadRateLimit(path, "beta")
+	testutil.AssertBool(t, true, ok)
+
+	_, ok = transport.LoadRateLimit(path, "gamma")
+	testutil.AssertBool(t, false, ok)
+
+	// The token itself isn't stored.
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringDoesntContain(t, string(data), "alpha")
+}
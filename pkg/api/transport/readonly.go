@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"net/http"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// ReadOnly returns a http.RoundTripper that refuses to make any request via
+// next that could modify a resource, i.e. any request whose method isn't GET,
+// HEAD or OPTIONS. Refused requests fail with fsterr.ErrReadOnly.
+//
+// If next is nil then http.DefaultTransport is used.
+func ReadOnly(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &readOnlyTransport{next: next}
+}
+
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fsterr.ErrReadOnly
+}
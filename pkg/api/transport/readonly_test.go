@@ -0,0 +1,40 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestReadOnly(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer ts.Close()
+
+	c := &http.Client{Transport: transport.ReadOnly(nil)}
+
+	resp, err := c.Get(ts.URL)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, ts.URL, strings.NewReader("body"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Do(req)
+		if !errors.Is(err, fsterr.ErrReadOnly) {
+			t.Errorf("want %s request to fail with ErrReadOnly, got: %v", method, err)
+		}
+	}
+
+	testutil.AssertEqual(t, []string{http.MethodGet}, methods)
+}
@@ -0,0 +1,139 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Recording stores the response to every request made via its transport in
+// Dir (see --record) or, if Replay is set, serves the responses stored in Dir
+// instead of sending the requests (see --replay).
+//
+// Responses are matched by the request's method, path, query and body, but
+// not its host or headers, so a recording can be replayed with any API token
+// or endpoint. A request made more than once by a command is served the
+// responses in the order they were recorded, and then the last one again. As
+// each command records its own sequence, recording a request again (e.g. by a
+// later command of a script) replaces the earlier recording.
+type Recording struct {
+	// Dir is the directory the responses are stored in.
+	Dir string
+	// Replay is whether the responses are served rather than recorded.
+	Replay bool
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// Transport returns a http.RoundTripper that records the responses to requests
+// made via next, or replays them without using next. If next is nil then
+// http.DefaultTransport is used.
+func (r *Recording) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{recording: r, next: next}
+}
+
+// next returns the key of the request and the number of times a request with
+// that key was made before it.
+func (r *Recording) next(req *http.Request, body []byte) (key string, n int) {
+	key = hash(req.Method + " " + req.URL.RequestURI() + "\n" + string(body))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen == nil {
+		r.seen = make(map[string]int)
+	}
+	n = r.seen[key]
+	r.seen[key]++
+	return key, n
+}
+
+func (r *Recording) path(req *http.Request, key string, n int) string {
+	return filepath.Join(r.Dir, fmt.Sprintf("%s-%s-%d.http", strings.ToLower(req.Method), key[:16], n))
+}
+
+// replay returns the nth recorded response to the request, or the last one
+// if it was made fewer times when it was recorded.
+func (r *Recording) replay(req *http.Request, key string, n int) (*http.Response, error) {
+	for ; n >= 0; n-- {
+		// #nosec G304 (CWE-22) the path is derived from a hash of the request.
+		data, err := os.ReadFile(r.path(req, key, n))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	}
+	return nil, fmt.Errorf("no recorded response to %s %s in %s", req.Method, req.URL.RequestURI(), r.Dir)
+}
+
+// record saves the response, whose body is replaced so it can still be read.
+func (r *Recording) record(req *http.Request, resp *http.Response, key string, n int) error {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	stored := *resp
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+	stored.ContentLength = int64(len(body))
+	stored.TransferEncoding = nil
+	data, err := httputil.DumpResponse(&stored, true)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(r.Dir, 0o700); err != nil {
+		return fmt.Errorf("error creating recording directory: %w", err)
+	}
+	if err := os.WriteFile(r.path(req, key, n), data, 0o600); err != nil {
+		return fmt.Errorf("error recording response: %w", err)
+	}
+	return nil
+}
+
+type recordingTransport struct {
+	recording *Recording
+	next      http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	key, n := t.recording.next(req, body)
+	if t.recording.Replay {
+		return t.recording.replay(req, key, n)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if err := t.recording.record(req, resp, key, n); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
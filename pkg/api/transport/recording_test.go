@@ -0,0 +1,82 @@
+package transport_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestRecording(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		fmt.Fprintf(w, `{"call":%d,"body":"%s"}`, calls, body)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	send := func(r *transport.Recording, method, path, body string) (int, string, error) {
+		c := &http.Client{Transport: r.Transport(nil)}
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Fastly-Key", "secret")
+		resp, err := c.Do(req)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		testutil.AssertNoError(t, err)
+		return resp.StatusCode, string(b), nil
+	}
+
+	record := &transport.Recording{Dir: dir}
+	_, have, err := send(record, http.MethodGet, "/service", "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, `{"call":1,"body":""}`, have)
+	send(record, http.MethodPost, "/service", "name=a")
+	send(record, http.MethodGet, "/service", "")
+	testutil.AssertEqual(t, 3, calls)
+
+	entries, err := os.ReadDir(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(entries))
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringDoesntContain(t, string(b), "secret")
+	}
+
+	// Responses are replayed in the order they were recorded, and the last
+	// one is repeated.
+	replay := &transport.Recording{Dir: dir, Replay: true}
+	for _, want := range []string{`{"call":1,"body":""}`, `{"call":3,"body":""}`, `{"call":3,"body":""}`} {
+		_, have, err := send(replay, http.MethodGet, "/service", "")
+		testutil.AssertNoError(t, err)
+		testutil.AssertString(t, want, have)
+	}
+	status, have, err := send(replay, http.MethodPost, "/service", "name=a")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, http.StatusCreated, status)
+	testutil.AssertString(t, `{"call":2,"body":"name=a"}`, have)
+	testutil.AssertEqual(t, 3, calls)
+
+	// A request with a different body wasn't recorded.
+	_, _, err = send(replay, http.MethodPost, "/service", "name=b")
+	testutil.AssertErrorContains(t, err, "no recorded response to POST /service in "+dir)
+}
@@ -0,0 +1,144 @@
+package transport
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// LogPath is the location of the log of failed API requests. It sits alongside
+// the fastly CLI error log.
+var LogPath = filepath.Join(filepath.Dir(fsterr.LogPath), "requests.log")
+
+// LogLimit is the number of failed requests retained in the log.
+var LogLimit = 100
+
+// RequestIDHeaders are the response headers, in order of preference, that the
+// API uses to identify a request.
+var RequestIDHeaders = []string{"Fastly-Request-ID", "X-Request-ID"}
+
+// FailedRequest represents an API request that returned an error response.
+type FailedRequest struct {
+	Method     string
+	Path       string
+	RequestID  string
+	StatusCode int
+}
+
+// Recorder records the API requests that returned an error response, so their
+// request IDs can be displayed to the user and shared with Fastly support.
+type Recorder struct {
+	mu     sync.Mutex
+	failed []FailedRequest
+}
+
+// Failures is the primary recorder for consumers.
+var Failures = new(Recorder)
+
+// Failed returns the failed requests recorded so far.
+func (r *Recorder) Failed() []FailedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := make([]FailedRequest, len(r.failed))
+	copy(failed, r.failed)
+	return failed
+}
+
+// Transport returns a http.RoundTripper that records failed requests made via
+// next. If next is nil then http.DefaultTransport is used.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &requestIDTransport{next: next, recorder: r}
+}
+
+// Persist appends the failed requests to the log at logPath, retaining only
+// the most recent LogLimit entries.
+//
+// Each line records the time, method, path, status and request ID of a failed
+// request separated by tabs.
+func (r *Recorder) Persist(logPath string) error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	lines, err := ReadLog(logPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	now := fsterr.Now().Format(time.RFC3339)
+	for _, f := range failed {
+		lines = append(lines, strings.Join([]string{now, f.Method, f.Path, fmt.Sprint(f.StatusCode), f.RequestID}, "\t"))
+	}
+	if len(lines) > LogLimit {
+		lines = lines[len(lines)-LogLimit:]
+	}
+
+	data := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(logPath, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("error writing request log file: %w", err)
+	}
+	return nil
+}
+
+// ReadLog returns the lines of the failed request log at logPath.
+func ReadLog(logPath string) ([]string, error) {
+	f, err := os.Open(filepath.Clean(logPath))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // #nosec G307
+
+	var lines []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := s.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, s.Err()
+}
+
+func (r *Recorder) add(f FailedRequest) {
+	r.mu.Lock()
+	r.failed = append(r.failed, f)
+	r.mu.Unlock()
+}
+
+type requestIDTransport struct {
+	next     http.RoundTripper
+	recorder *Recorder
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+
+	var id string
+	for _, h := range RequestIDHeaders {
+		if id = resp.Header.Get(h); id != "" {
+			break
+		}
+	}
+
+	t.recorder.add(FailedRequest{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		RequestID:  id,
+		StatusCode: resp.StatusCode,
+	})
+
+	return resp, nil
+}
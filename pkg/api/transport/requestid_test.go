@@ -0,0 +1,99 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.Header().Set("Fastly-Request-ID", "ok-123")
+			w.WriteHeader(http.StatusOK)
+		case "/fastly":
+			w.Header().Set("Fastly-Request-ID", "fastly-456")
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("X-Request-ID", "generic-789")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	var r transport.Recorder
+	c := &http.Client{Transport: r.Transport(nil)}
+
+	for _, path := range []string{"/ok", "/fastly", "/other"} {
+		resp, err := c.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	testutil.AssertEqual(t, []transport.FailedRequest{
+		{
+			Method:     http.MethodGet,
+			Path:       "/fastly",
+			RequestID:  "fastly-456",
+			StatusCode: http.StatusNotFound,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/other",
+			RequestID:  "generic-789",
+			StatusCode: http.StatusInternalServerError,
+		},
+	}, r.Failed())
+}
+
+func TestRecorderPersist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Fastly-Request-ID", "abc")
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	fsterr.Now = func() time.Time { return time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC) }
+	defer func() { fsterr.Now = time.Now }()
+
+	limit := transport.LogLimit
+	transport.LogLimit = 2
+	defer func() { transport.LogLimit = limit }()
+
+	path := filepath.Join(t.TempDir(), "requests.log")
+	if err := os.WriteFile(path, []byte("old-1\nold-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var r transport.Recorder
+	c := &http.Client{Transport: r.Transport(nil)}
+	resp, err := c.Get(ts.URL + "/service/123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if err := r.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := transport.ReadLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, []string{
+		"old-2",
+		strings.Join([]string{"2022-01-02T03:04:05Z", "GET", "/service/123", "400", "abc"}, "\t"),
+	}, lines)
+}
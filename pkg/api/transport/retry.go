@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults used by a Retry whose delays aren't set.
+const (
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// MaxRetryAfter is the longest delay requested by an API response (via the
+// Retry-After or Fastly-RateLimit-Reset headers) that Retry will wait for.
+// Responses asking the client to wait for longer are returned as-is.
+const MaxRetryAfter = time.Minute
+
+// Retry resends API requests that were rate limited (429) or that failed with
+// a transient server error (500, 502, 503 or 504), waiting between attempts
+// with exponential backoff and jitter, or for as long as the response asked.
+//
+// Requests that may not be idempotent (i.e. POST and PATCH) are only retried
+// when the API didn't process them (429 and 503).
+type Retry struct {
+	// Attempts is the maximum number of times a request is retried.
+	Attempts int
+	// BaseDelay is the delay before the first retry, which doubles for each
+	// subsequent retry up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Context cancels any pending retry when it's done.
+	Context context.Context
+	// Out, if set, is where each retry is reported.
+	Out io.Writer
+}
+
+// Transport returns a http.RoundTripper that makes requests via next,
+// retrying them according to r. If next is nil then http.DefaultTransport is
+// used.
+func (r *Retry) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{retry: r, next: next}
+}
+
+// delay returns how long to wait before the given retry (numbered from zero)
+// of a request that received resp. ok is false if the response asked for a
+// longer delay than MaxRetryAfter.
+func (r *Retry) delay(retry int, resp *http.Response) (d time.Duration, ok bool) {
+	if d, ok := retryAfter(resp, time.Now()); ok {
+		return d, d <= MaxRetryAfter
+	}
+
+	base, max := r.BaseDelay, r.MaxDelay
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	if max <= 0 {
+		max = DefaultRetryMaxDelay
+	}
+	d = base << retry
+	if d <= 0 || d > max {
+		d = max
+	}
+	// "Equal jitter": wait for at least half the delay so retries from
+	// concurrent clients are spread out without collapsing to zero.
+	// #nosec G404 (CWE-338) jitter doesn't need a secure random number.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1)), true
+}
+
+// retryAfter returns the delay requested by the response's Retry-After (in
+// seconds or as a HTTP date) or Fastly-RateLimit-Reset (a Unix timestamp)
+// header.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+			return time.Duration(s) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if v := resp.Header.Get(RateLimitResetHeader); v != "" {
+			if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return nonNegative(time.Unix(s, 0).Sub(now)), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// retryable reports whether a request with the given method that received
+// the status code should be retried.
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return method != http.MethodPost && method != http.MethodPatch
+	}
+	return false
+}
+
+type retryTransport struct {
+	retry *Retry
+	next  http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.retry.Context
+	if ctx == nil {
+		ctx = req.Context()
+	}
+
+	for i := 0; ; i++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || i >= t.retry.Attempts || !retryable(req.Method, resp.StatusCode) {
+			return resp, err
+		}
+		// The body can only be resent if it can be read again.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+		d, ok := t.retry.delay(i, resp)
+		if !ok {
+			return resp, err
+		}
+
+		if t.retry.Out != nil {
+			fmt.Fprintf(t.retry.Out, "%s %s returned %s, retrying in %s (%d of %d)\n", req.Method, req.URL.Path, resp.Status, d.Round(time.Millisecond), i+1, t.retry.Attempts)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestRetry(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		method     string
+		statuses   []int
+		header     http.Header
+		wantStatus int
+		wantCalls  int
+	}{
+		{
+			name:       "success isn't retried",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "client errors aren't retried",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusNotFound},
+			wantStatus: http.StatusNotFound,
+			wantCalls:  1,
+		},
+		{
+			name:       "rate limited",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			header:     http.Header{"Retry-After": {"0"}},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "transient server error",
+			method:     http.MethodPut,
+			statuses:   []int{http.StatusBadGateway, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "server errors aren't retried for non-idempotent requests",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusInternalServerError, http.StatusOK},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  1,
+		},
+		{
+			name:       "unavailable is retried for non-idempotent requests",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "attempts exhausted",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  3,
+		},
+		{
+			name:       "rate limit reset too far in the future",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			header:     http.Header{"Fastly-RateLimit-Reset": {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)}},
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var (
+				calls  int
+				bodies []string
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+				for k, v := range testcase.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(testcase.statuses[calls])
+				calls++
+			}))
+			defer ts.Close()
+
+			var out bytes.Buffer
+			r := &transport.Retry{Attempts: 2, BaseDelay: time.Millisecond, Out: &out}
+			c := &http.Client{Transport: r.Transport(nil)}
+
+			req, err := http.NewRequest(testcase.method, ts.URL+"/service/123", strings.NewReader("name=foo"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.Do(req)
+			testutil.AssertNoError(t, err)
+			resp.Body.Close()
+
+			testutil.AssertEqual(t, testcase.wantStatus, resp.StatusCode)
+			testutil.AssertEqual(t, testcase.wantCalls, calls)
+			for _, b := range bodies {
+				testutil.AssertString(t, "name=foo", b)
+			}
+			if calls > 1 {
+				testutil.AssertStringContains(t, out.String(), testcase.method+" /service/123 returned ")
+				testutil.AssertStringContains(t, out.String(), "(1 of 2)")
+			}
+		})
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &transport.Retry{Attempts: 1, Context: ctx}
+	c := &http.Client{Transport: r.Transport(nil)}
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := c.Get(ts.URL)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, have %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// Call is an API request recorded by Stats.
+type Call struct {
+	Method string
+	Path   string
+	// Status is the response status code, or 0 if the request failed.
+	Status   int
+	Duration time.Duration
+	// RequestBytes and ResponseBytes are the sizes of the request and
+	// response bodies. The response size is only known once its body has been
+	// read.
+	RequestBytes  int64
+	ResponseBytes int64
+	// Retry reports whether the request repeated an earlier request for the
+	// same method and path that failed.
+	Retry bool
+}
+
+// Failed reports whether the request failed or returned an error response.
+func (c Call) Failed() bool {
+	return c.Status == 0 || c.Status >= http.StatusBadRequest
+}
+
+// Stats records the API requests made via its transports, for the summary
+// displayed by --timings.
+type Stats struct {
+	mu     sync.Mutex
+	calls  []*Call
+	failed map[string]bool
+}
+
+// Transport returns a http.RoundTripper that records the requests made via
+// next. If next is nil then http.DefaultTransport is used.
+func (s *Stats) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &statsTransport{do: next.RoundTrip, stats: s}
+}
+
+// Client returns an api.HTTPClient that records the requests made via c, in
+// the same way as Transport.
+func (s *Stats) Client(c api.HTTPClient) api.HTTPClient {
+	return &statsTransport{do: c.Do, stats: s}
+}
+
+// Calls returns the requests recorded so far, in the order they were made.
+func (s *Stats) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := make([]Call, len(s.calls))
+	for i, c := range s.calls {
+		calls[i] = *c
+	}
+	return calls
+}
+
+// StatsSummary totals the requests recorded by Stats.
+type StatsSummary struct {
+	Calls         int
+	Failed        int
+	Retries       int
+	Latency       time.Duration
+	RequestBytes  int64
+	ResponseBytes int64
+	// Slowest are the slowest requests, slowest first.
+	Slowest []Call
+}
+
+// Summary totals the requests recorded so far, including the n slowest.
+func (s *Stats) Summary(n int) StatsSummary {
+	calls := s.Calls()
+	var sum StatsSummary
+	for _, c := range calls {
+		sum.Calls++
+		sum.Latency += c.Duration
+		sum.RequestBytes += c.RequestBytes
+		sum.ResponseBytes += c.ResponseBytes
+		if c.Failed() {
+			sum.Failed++
+		}
+		if c.Retry {
+			sum.Retries++
+		}
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].Duration > calls[j].Duration
+	})
+	if len(calls) > n {
+		calls = calls[:n]
+	}
+	sum.Slowest = calls
+	return sum
+}
+
+// record adds a request, returning it so its response size can be updated.
+func (s *Stats) record(c Call) *Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed == nil {
+		s.failed = make(map[string]bool)
+	}
+	key := c.Method + " " + c.Path
+	c.Retry = s.failed[key]
+	s.failed[key] = c.Failed()
+	s.calls = append(s.calls, &c)
+	return s.calls[len(s.calls)-1]
+}
+
+type statsTransport struct {
+	do    func(*http.Request) (*http.Response, error)
+	stats *Stats
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.do(req)
+
+	c := Call{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Duration: time.Since(start),
+	}
+	if req.ContentLength > 0 {
+		c.RequestBytes = req.ContentLength
+	}
+	if err != nil {
+		t.stats.record(c)
+		return resp, err
+	}
+	c.Status = resp.StatusCode
+	call := t.stats.record(c)
+	if resp.Body != nil {
+		resp.Body = &countingBody{ReadCloser: resp.Body, stats: t.stats, call: call}
+	}
+	return resp, err
+}
+
+// Do implements the api.HTTPClient interface.
+func (t *statsTransport) Do(req *http.Request) (*http.Response, error) {
+	return t.RoundTrip(req)
+}
+
+// countingBody adds the bytes read from a response body to its call.
+type countingBody struct {
+	io.ReadCloser
+	stats *Stats
+	call  *Call
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.stats.mu.Lock()
+	b.call.ResponseBytes += int64(n)
+	b.stats.mu.Unlock()
+	return n, err
+}
@@ -0,0 +1,63 @@
+package transport_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestStats(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/flaky" {
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	var stats transport.Stats
+	c := &http.Client{Transport: stats.Transport(nil)}
+
+	do := func(method, path, body string) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.Do(req)
+		testutil.AssertNoError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	do(http.MethodPost, "/service", "name=foo")
+	do(http.MethodGet, "/flaky", "")
+	do(http.MethodGet, "/flaky", "")
+
+	calls := stats.Calls()
+	testutil.AssertEqual(t, 3, len(calls))
+	testutil.AssertEqual(t, int64(8), calls[0].RequestBytes)
+	testutil.AssertEqual(t, int64(5), calls[0].ResponseBytes)
+	testutil.AssertEqual(t, http.StatusServiceUnavailable, calls[1].Status)
+	testutil.AssertBool(t, false, calls[1].Retry)
+	testutil.AssertBool(t, true, calls[2].Retry)
+
+	sum := stats.Summary(2)
+	testutil.AssertEqual(t, 3, sum.Calls)
+	testutil.AssertEqual(t, 1, sum.Failed)
+	testutil.AssertEqual(t, 1, sum.Retries)
+	testutil.AssertEqual(t, int64(8), sum.RequestBytes)
+	testutil.AssertEqual(t, int64(10), sum.ResponseBytes)
+	testutil.AssertEqual(t, 2, len(sum.Slowest))
+	if sum.Slowest[0].Duration < sum.Slowest[1].Duration {
+		t.Errorf("want slowest requests first, got %v", sum.Slowest)
+	}
+}
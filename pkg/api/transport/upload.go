@@ -0,0 +1,194 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/andybalholm/brotli"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// Content encodings that Upload can compress request bodies with.
+const (
+	EncodingGzip   = "gzip"
+	EncodingBrotli = "br"
+)
+
+// CompressMinSize is the size of the smallest request body Upload compresses,
+// as compressing smaller bodies isn't worth the overhead.
+const CompressMinSize = 64 << 10
+
+// ProgressMinSize is the size of the smallest request body whose upload
+// progress is reported.
+const ProgressMinSize = 1 << 20
+
+// compressiblePaths are the API endpoints that accept large request bodies
+// (packages, VCL files and batches of dictionary items), keyed by method.
+var compressiblePaths = map[string][]*regexp.Regexp{
+	http.MethodPost: {
+		regexp.MustCompile(`^/service/[^/]+/version/\d+/vcl$`),
+	},
+	http.MethodPut: {
+		regexp.MustCompile(`^/service/[^/]+/version/\d+/package$`),
+		regexp.MustCompile(`^/service/[^/]+/version/\d+/vcl/[^/]+$`),
+	},
+	http.MethodPatch: {
+		regexp.MustCompile(`^/service/[^/]+/dictionary/[^/]+/items$`),
+	},
+}
+
+// Compressible reports whether the body of a request with the given method
+// and URL path may be compressed by Upload.
+func Compressible(method, path string) bool {
+	for _, re := range compressiblePaths[method] {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Upload compresses the large request bodies of the API endpoints that
+// accept them (see Compressible) and reports the progress of large uploads.
+//
+// If the API rejects a compressed body (with a 400 or 415 response), the
+// request is resent uncompressed.
+type Upload struct {
+	// Encoding is the content encoding bodies are compressed with, i.e.
+	// EncodingGzip or EncodingBrotli. Bodies aren't compressed if it's any
+	// other value (e.g. empty).
+	Encoding string
+	// Progress, if set, is where the progress of uploads is reported.
+	Progress io.Writer
+}
+
+// Transport returns a http.RoundTripper that makes requests via next,
+// compressing their bodies and reporting their progress according to u. If
+// next is nil then http.DefaultTransport is used.
+func (u *Upload) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &uploadTransport{upload: u, next: next}
+}
+
+// compress returns the request body compressed with u's encoding, or nil if
+// it shouldn't be compressed.
+func (u *Upload) compress(req *http.Request) ([]byte, error) {
+	if u.Encoding != EncodingGzip && u.Encoding != EncodingBrotli {
+		return nil, nil
+	}
+	if req.GetBody == nil || req.ContentLength < CompressMinSize || req.Header.Get("Content-Encoding") != "" || !Compressible(req.Method, req.URL.Path) {
+		return nil, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	if u.Encoding == EncodingBrotli {
+		w = brotli.NewWriter(&buf)
+	} else {
+		w = gzip.NewWriter(&buf)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	// Bodies that are already compressed (e.g. packages) may not shrink.
+	if int64(buf.Len()) >= req.ContentLength {
+		return nil, nil
+	}
+	return buf.Bytes(), nil
+}
+
+type uploadTransport struct {
+	upload *Upload
+	next   http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *uploadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	compressed, err := t.upload.compress(req)
+	if err != nil {
+		return nil, err
+	}
+	if compressed == nil {
+		return t.next.RoundTrip(t.withProgress(req))
+	}
+
+	// The compressed body is sent instead of the original, which can still be
+	// read again via GetBody.
+	req.Body.Close()
+	creq := req.Clone(req.Context())
+	creq.Body = io.NopCloser(bytes.NewReader(compressed))
+	creq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	creq.ContentLength = int64(len(compressed))
+	creq.Header.Set("Content-Encoding", t.upload.Encoding)
+
+	resp, err := t.next.RoundTrip(t.withProgress(creq))
+	if err != nil || (resp.StatusCode != http.StatusUnsupportedMediaType && resp.StatusCode != http.StatusBadRequest) {
+		return resp, err
+	}
+
+	// The endpoint doesn't accept compressed bodies.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = body
+	return t.next.RoundTrip(t.withProgress(req))
+}
+
+// withProgress returns the request with a body that reports its progress, if
+// it's large enough.
+func (t *uploadTransport) withProgress(req *http.Request) *http.Request {
+	if t.upload.Progress == nil || req.Body == nil || req.ContentLength < ProgressMinSize {
+		return req
+	}
+	req = req.Clone(req.Context())
+	req.Body = &progressReader{
+		ReadCloser: req.Body,
+		out:        t.upload.Progress,
+		label:      req.Method + " " + req.URL.Path,
+		total:      req.ContentLength,
+	}
+	return req
+}
+
+// progressReader reports each additional tenth of the body that's read.
+type progressReader struct {
+	io.ReadCloser
+	out      io.Writer
+	label    string
+	total    int64
+	read     int64
+	reported int64
+}
+
+// Read implements the io.Reader interface.
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	if tenths := r.read * 10 / r.total; tenths > r.reported {
+		r.reported = tenths
+		f := text.Formatter{HumanSizes: true}
+		fmt.Fprintf(r.out, "Uploading %s: %s of %s (%d%%)\n", r.label, f.Bytes(uint64(r.read)), f.Bytes(uint64(r.total)), r.read*100/r.total)
+	}
+	return n, err
+}
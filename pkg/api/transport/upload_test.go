@@ -0,0 +1,135 @@
+package transport_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestUpload(t *testing.T) {
+	large := strings.Repeat("sub vcl_recv { return(pass); }\n", 2*transport.ProgressMinSize/32)
+
+	for _, testcase := range []struct {
+		name         string
+		encoding     string
+		method       string
+		path         string
+		body         string
+		reject       bool
+		wantEncoding []string
+		wantProgress bool
+	}{
+		{
+			name:         "gzip",
+			encoding:     transport.EncodingGzip,
+			method:       http.MethodPut,
+			path:         "/service/123/version/1/vcl/main",
+			body:         large,
+			wantEncoding: []string{"gzip"},
+		},
+		{
+			name:         "brotli",
+			encoding:     transport.EncodingBrotli,
+			method:       http.MethodPost,
+			path:         "/service/123/version/1/vcl",
+			body:         large,
+			wantEncoding: []string{"br"},
+		},
+		{
+			name:         "disabled",
+			method:       http.MethodPut,
+			path:         "/service/123/version/1/vcl/main",
+			body:         large,
+			wantEncoding: []string{""},
+			wantProgress: true,
+		},
+		{
+			name:         "small bodies aren't compressed",
+			encoding:     transport.EncodingGzip,
+			method:       http.MethodPut,
+			path:         "/service/123/version/1/vcl/main",
+			body:         "sub vcl_recv {}",
+			wantEncoding: []string{""},
+		},
+		{
+			name:         "other endpoints aren't compressed",
+			encoding:     transport.EncodingGzip,
+			method:       http.MethodPut,
+			path:         "/service/123/version/1/backend/origin",
+			body:         large,
+			wantEncoding: []string{""},
+			wantProgress: true,
+		},
+		{
+			name:         "rejected compressed body is resent uncompressed",
+			encoding:     transport.EncodingGzip,
+			method:       http.MethodPatch,
+			path:         "/service/123/dictionary/456/items",
+			body:         large,
+			reject:       true,
+			wantEncoding: []string{"gzip", ""},
+			wantProgress: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var encodings []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encoding := r.Header.Get("Content-Encoding")
+				encodings = append(encodings, encoding)
+				if encoding != "" && testcase.reject {
+					w.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+
+				var body io.Reader = r.Body
+				switch encoding {
+				case "gzip":
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					body = zr
+				case "br":
+					body = brotli.NewReader(r.Body)
+				}
+				b, err := io.ReadAll(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != testcase.body {
+					t.Errorf("request body wasn't the original body")
+				}
+			}))
+			defer ts.Close()
+
+			var progress bytes.Buffer
+			u := &transport.Upload{Encoding: testcase.encoding, Progress: &progress}
+			c := &http.Client{Transport: u.Transport(nil)}
+
+			req, err := http.NewRequest(testcase.method, ts.URL+testcase.path, strings.NewReader(testcase.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.Do(req)
+			testutil.AssertNoError(t, err)
+			resp.Body.Close()
+
+			testutil.AssertEqual(t, http.StatusOK, resp.StatusCode)
+			testutil.AssertEqual(t, testcase.wantEncoding, encodings)
+			if testcase.wantProgress {
+				testutil.AssertStringContains(t, progress.String(), "Uploading "+testcase.method+" "+testcase.path+": ")
+				testutil.AssertStringContains(t, progress.String(), "(100%)\n")
+			} else {
+				testutil.AssertString(t, "", progress.String())
+			}
+		})
+	}
+}
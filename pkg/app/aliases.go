@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/kingpin"
+)
+
+const aliasRemediation = "Correct the alias in the [aliases] section of the configuration file (see 'fastly config --location')."
+
+// expandAlias returns args with the name of the command replaced by the
+// command line of the alias with that name, if there's one (see
+// config.Aliases). An alias can't replace a built-in command or a plugin, so
+// aliases with the same name as one are ignored.
+func expandAlias(app *kingpin.Application, args []string, aliases config.Aliases) ([]string, error) {
+	if len(aliases) == 0 {
+		return args, nil
+	}
+	i := commandIndex(app, args)
+	if i < 0 || app.GetCommand(args[i]) != nil {
+		return args, nil
+	}
+	line, ok := aliases[args[i]]
+	if !ok {
+		return args, nil
+	}
+
+	words, err := splitCommandLine(line)
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing alias '%s': %w", args[i], err),
+			Remediation: aliasRemediation,
+		}
+	}
+	if len(words) == 0 {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing alias '%s': no command", args[i]),
+			Remediation: aliasRemediation,
+		}
+	}
+
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, words...)
+	return append(expanded, args[i+1:]...), nil
+}
+
+// splitCommandLine splits line into words as a POSIX shell would, so that
+// words may be quoted with single or double quotes, or characters escaped with
+// a backslash. Variables and other expansions aren't supported.
+func splitCommandLine(line string) ([]string, error) {
+	var (
+		words  []string
+		word   strings.Builder
+		inWord bool
+		quote  rune
+	)
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				word.WriteRune(runes[i])
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -2,17 +2,31 @@ package app
 
 import (
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/abtest"
+	"github.com/fastly/cli/pkg/commands/account"
 	"github.com/fastly/cli/pkg/commands/acl"
 	"github.com/fastly/cli/pkg/commands/aclentry"
+	"github.com/fastly/cli/pkg/commands/analyze"
+	"github.com/fastly/cli/pkg/commands/apicall"
 	"github.com/fastly/cli/pkg/commands/authtoken"
 	"github.com/fastly/cli/pkg/commands/backend"
+	"github.com/fastly/cli/pkg/commands/bootstrap"
+	"github.com/fastly/cli/pkg/commands/catalog"
+	"github.com/fastly/cli/pkg/commands/completion"
 	"github.com/fastly/cli/pkg/commands/compute"
 	"github.com/fastly/cli/pkg/commands/config"
+	"github.com/fastly/cli/pkg/commands/curl"
+	"github.com/fastly/cli/pkg/commands/dashboard"
 	"github.com/fastly/cli/pkg/commands/dictionary"
 	"github.com/fastly/cli/pkg/commands/dictionaryitem"
 	"github.com/fastly/cli/pkg/commands/domain"
+	"github.com/fastly/cli/pkg/commands/errorlog"
+	"github.com/fastly/cli/pkg/commands/flags"
+	"github.com/fastly/cli/pkg/commands/generate"
+	"github.com/fastly/cli/pkg/commands/gzip"
 	"github.com/fastly/cli/pkg/commands/healthcheck"
 	"github.com/fastly/cli/pkg/commands/ip"
+	"github.com/fastly/cli/pkg/commands/limits"
 	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/commands/logging/azureblob"
 	"github.com/fastly/cli/pkg/commands/logging/bigquery"
@@ -41,19 +55,32 @@ import (
 	"github.com/fastly/cli/pkg/commands/logging/sumologic"
 	"github.com/fastly/cli/pkg/commands/logging/syslog"
 	"github.com/fastly/cli/pkg/commands/logtail"
+	"github.com/fastly/cli/pkg/commands/maintenance"
+	"github.com/fastly/cli/pkg/commands/monitor"
+	"github.com/fastly/cli/pkg/commands/origin"
 	"github.com/fastly/cli/pkg/commands/pop"
 	"github.com/fastly/cli/pkg/commands/profile"
 	"github.com/fastly/cli/pkg/commands/purge"
+	"github.com/fastly/cli/pkg/commands/ratelimit"
+	"github.com/fastly/cli/pkg/commands/route"
+	"github.com/fastly/cli/pkg/commands/scan"
+	"github.com/fastly/cli/pkg/commands/schedule"
+	"github.com/fastly/cli/pkg/commands/search"
 	"github.com/fastly/cli/pkg/commands/service"
 	"github.com/fastly/cli/pkg/commands/serviceversion"
 	"github.com/fastly/cli/pkg/commands/shellcomplete"
 	"github.com/fastly/cli/pkg/commands/stats"
+	"github.com/fastly/cli/pkg/commands/supportbundle"
 	"github.com/fastly/cli/pkg/commands/update"
 	"github.com/fastly/cli/pkg/commands/user"
 	"github.com/fastly/cli/pkg/commands/vcl"
 	"github.com/fastly/cli/pkg/commands/vcl/custom"
 	"github.com/fastly/cli/pkg/commands/vcl/snippet"
 	"github.com/fastly/cli/pkg/commands/version"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/commands/waf/exclusion"
+	"github.com/fastly/cli/pkg/commands/waf/rule"
+	"github.com/fastly/cli/pkg/commands/waf/wafversion"
 	"github.com/fastly/cli/pkg/commands/whoami"
 	cfg "github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/manifest"
@@ -67,6 +94,12 @@ func defineCommands(
 	opts RunOpts,
 ) []cmd.Command {
 	shellcompleteCmdRoot := shellcomplete.NewRootCommand(app, globals)
+	abtestCmdRoot := abtest.NewRootCommand(app, globals)
+	abtestCreate := abtest.NewCreateCommand(abtestCmdRoot.CmdClause, globals, data)
+	abtestReport := abtest.NewReportCommand(abtestCmdRoot.CmdClause, globals)
+	accountCmdRoot := account.NewRootCommand(app, globals)
+	accountDescribe := account.NewDescribeCommand(accountCmdRoot.CmdClause, globals)
+	accountLimits := account.NewLimitsCommand(accountCmdRoot.CmdClause, globals)
 	aclCmdRoot := acl.NewRootCommand(app, globals)
 	aclCreate := acl.NewCreateCommand(aclCmdRoot.CmdClause, globals, data)
 	aclDelete := acl.NewDeleteCommand(aclCmdRoot.CmdClause, globals, data)
@@ -77,48 +110,79 @@ func defineCommands(
 	aclEntryCreate := aclentry.NewCreateCommand(aclEntryCmdRoot.CmdClause, globals, data)
 	aclEntryDelete := aclentry.NewDeleteCommand(aclEntryCmdRoot.CmdClause, globals, data)
 	aclEntryDescribe := aclentry.NewDescribeCommand(aclEntryCmdRoot.CmdClause, globals, data)
+	aclEntryExpireRun := aclentry.NewExpireRunCommand(aclEntryCmdRoot.CmdClause, globals, data)
 	aclEntryList := aclentry.NewListCommand(aclEntryCmdRoot.CmdClause, globals, data)
 	aclEntryUpdate := aclentry.NewUpdateCommand(aclEntryCmdRoot.CmdClause, globals, data)
+	analyzeCmdRoot := analyze.NewRootCommand(app, globals)
+	analyzeCache := analyze.NewCacheCommand(analyzeCmdRoot.CmdClause, globals, data)
+	apiCmdRoot := apicall.NewRootCommand(app, globals)
 	authtokenCmdRoot := authtoken.NewRootCommand(app, globals)
 	authtokenCreate := authtoken.NewCreateCommand(authtokenCmdRoot.CmdClause, globals, data)
 	authtokenDelete := authtoken.NewDeleteCommand(authtokenCmdRoot.CmdClause, globals, data)
 	authtokenDescribe := authtoken.NewDescribeCommand(authtokenCmdRoot.CmdClause, globals, data)
 	authtokenList := authtoken.NewListCommand(authtokenCmdRoot.CmdClause, globals, data)
+	authtokenPurge := authtoken.NewPurgeCommand(authtokenCmdRoot.CmdClause, globals)
 	backendCmdRoot := backend.NewRootCommand(app, globals)
+	backendBlame := backend.NewBlameCommand(backendCmdRoot.CmdClause, globals, data)
 	backendCreate := backend.NewCreateCommand(backendCmdRoot.CmdClause, globals, data)
 	backendDelete := backend.NewDeleteCommand(backendCmdRoot.CmdClause, globals, data)
 	backendDescribe := backend.NewDescribeCommand(backendCmdRoot.CmdClause, globals, data)
+	backendHarden := backend.NewHardenCommand(backendCmdRoot.CmdClause, globals, data)
 	backendList := backend.NewListCommand(backendCmdRoot.CmdClause, globals, data)
+	backendMirror := backend.NewMirrorCommand(backendCmdRoot.CmdClause, globals, data)
+	backendRecommendShield := backend.NewRecommendShieldCommand(backendCmdRoot.CmdClause, globals, data)
 	backendUpdate := backend.NewUpdateCommand(backendCmdRoot.CmdClause, globals, data)
+	catalogCmdRoot := catalog.NewRootCommand(app, globals)
+	completionCmdRoot := completion.NewRootCommand(app, globals)
 	computeCmdRoot := compute.NewRootCommand(app, globals)
 	computeBuild := compute.NewBuildCommand(computeCmdRoot.CmdClause, globals, data)
 	computeDeploy := compute.NewDeployCommand(computeCmdRoot.CmdClause, globals, data)
 	computeInit := compute.NewInitCommand(computeCmdRoot.CmdClause, globals, data)
 	computePack := compute.NewPackCommand(computeCmdRoot.CmdClause, globals, data)
+	computeProfile := compute.NewProfileCommand(computeCmdRoot.CmdClause, globals, opts.Versioners.Viceroy, data)
 	computePublish := compute.NewPublishCommand(computeCmdRoot.CmdClause, globals, computeBuild, computeDeploy, data)
+	computeReplay := compute.NewReplayCommand(computeCmdRoot.CmdClause, globals)
 	computeServe := compute.NewServeCommand(computeCmdRoot.CmdClause, globals, computeBuild, opts.Versioners.Viceroy, data)
 	computeUpdate := compute.NewUpdateCommand(computeCmdRoot.CmdClause, globals, data)
 	computeValidate := compute.NewValidateCommand(computeCmdRoot.CmdClause, globals)
+	bootstrapCmdRoot := bootstrap.NewRootCommand(app, globals, computeInit)
 	configCmdRoot := config.NewRootCommand(app, globals)
+	curlCmdRoot := curl.NewRootCommand(app, globals)
+	dashboardCmdRoot := dashboard.NewRootCommand(app, globals)
 	dictionaryCmdRoot := dictionary.NewRootCommand(app, globals)
 	dictionaryCreate := dictionary.NewCreateCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryDelete := dictionary.NewDeleteCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryDescribe := dictionary.NewDescribeCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryItemCmdRoot := dictionaryitem.NewRootCommand(app, globals)
+	dictionaryItemApplyDue := dictionaryitem.NewApplyDueCommand(dictionaryItemCmdRoot.CmdClause, globals)
 	dictionaryItemCreate := dictionaryitem.NewCreateCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryItemDelete := dictionaryitem.NewDeleteCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryItemDescribe := dictionaryitem.NewDescribeCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryItemList := dictionaryitem.NewListCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
+	dictionaryItemSchedule := dictionaryitem.NewScheduleCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryItemUpdate := dictionaryitem.NewUpdateCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryList := dictionary.NewListCommand(dictionaryCmdRoot.CmdClause, globals, data)
+	dictionaryLookup := dictionary.NewLookupCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryUpdate := dictionary.NewUpdateCommand(dictionaryCmdRoot.CmdClause, globals, data)
+	dictionaryUsages := dictionary.NewUsagesCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	domainCmdRoot := domain.NewRootCommand(app, globals)
 	domainCreate := domain.NewCreateCommand(domainCmdRoot.CmdClause, globals, data)
 	domainDelete := domain.NewDeleteCommand(domainCmdRoot.CmdClause, globals, data)
 	domainDescribe := domain.NewDescribeCommand(domainCmdRoot.CmdClause, globals, data)
 	domainList := domain.NewListCommand(domainCmdRoot.CmdClause, globals, data)
+	domainMigrate := domain.NewMigrateCommand(domainCmdRoot.CmdClause, globals)
 	domainUpdate := domain.NewUpdateCommand(domainCmdRoot.CmdClause, globals, data)
 	domainValidate := domain.NewValidateCommand(domainCmdRoot.CmdClause, globals, data)
+	errorsCmdRoot := errorlog.NewRootCommand(app, globals)
+	errorsShow := errorlog.NewShowCommand(errorsCmdRoot.CmdClause, globals)
+	flagsCmdRoot := flags.NewRootCommand(app, globals)
+	flagsDisable := flags.NewDisableCommand(flagsCmdRoot.CmdClause, globals, data)
+	flagsEnable := flags.NewEnableCommand(flagsCmdRoot.CmdClause, globals, data)
+	flagsList := flags.NewListCommand(flagsCmdRoot.CmdClause, globals, data)
+	generateCmdRoot := generate.NewRootCommand(app, globals)
+	generateCommands := generate.NewCommandsCommand(generateCmdRoot.CmdClause, globals)
+	gzipCmdRoot := gzip.NewRootCommand(app, globals)
+	gzipAudit := gzip.NewAuditCommand(gzipCmdRoot.CmdClause, globals, data)
 	healthcheckCmdRoot := healthcheck.NewRootCommand(app, globals)
 	healthcheckCreate := healthcheck.NewCreateCommand(healthcheckCmdRoot.CmdClause, globals, data)
 	healthcheckDelete := healthcheck.NewDeleteCommand(healthcheckCmdRoot.CmdClause, globals, data)
@@ -126,8 +190,11 @@ func defineCommands(
 	healthcheckList := healthcheck.NewListCommand(healthcheckCmdRoot.CmdClause, globals, data)
 	healthcheckUpdate := healthcheck.NewUpdateCommand(healthcheckCmdRoot.CmdClause, globals, data)
 	ipCmdRoot := ip.NewRootCommand(app, globals)
+	limitsCmdRoot := limits.NewRootCommand(app, globals)
+	limitsStatus := limits.NewStatusCommand(limitsCmdRoot.CmdClause, globals, data)
 	logtailCmdRoot := logtail.NewRootCommand(app, globals, data)
 	loggingCmdRoot := logging.NewRootCommand(app, globals)
+	loggingAudit := logging.NewAuditCommand(loggingCmdRoot.CmdClause, globals, data)
 	loggingAzureblobCmdRoot := azureblob.NewRootCommand(loggingCmdRoot.CmdClause, globals)
 	loggingAzureblobCreate := azureblob.NewCreateCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
 	loggingAzureblobDelete := azureblob.NewDeleteCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
@@ -284,6 +351,13 @@ func defineCommands(
 	loggingSyslogDescribe := syslog.NewDescribeCommand(loggingSyslogCmdRoot.CmdClause, globals, data)
 	loggingSyslogList := syslog.NewListCommand(loggingSyslogCmdRoot.CmdClause, globals, data)
 	loggingSyslogUpdate := syslog.NewUpdateCommand(loggingSyslogCmdRoot.CmdClause, globals, data)
+	maintenanceCmdRoot := maintenance.NewRootCommand(app, globals)
+	maintenanceDisable := maintenance.NewDisableCommand(maintenanceCmdRoot.CmdClause, globals, data)
+	maintenanceEnable := maintenance.NewEnableCommand(maintenanceCmdRoot.CmdClause, globals, data)
+	monitorCmdRoot := monitor.NewRootCommand(app, globals)
+	monitorGenerate := monitor.NewGenerateCommand(monitorCmdRoot.CmdClause, globals, data)
+	originCmdRoot := origin.NewRootCommand(app, globals)
+	originSwitch := origin.NewSwitchCommand(originCmdRoot.CmdClause, globals, data)
 	popCmdRoot := pop.NewRootCommand(app, globals)
 	profileCmdRoot := profile.NewRootCommand(app, globals)
 	profileCreate := profile.NewCreateCommand(profileCmdRoot.CmdClause, profile.APIClientFactory(opts.APIClient), globals)
@@ -292,12 +366,25 @@ func defineCommands(
 	profileSwitch := profile.NewSwitchCommand(profileCmdRoot.CmdClause, globals)
 	profileUpdate := profile.NewUpdateCommand(profileCmdRoot.CmdClause, profile.APIClientFactory(opts.APIClient), globals)
 	purgeCmdRoot := purge.NewRootCommand(app, globals, data)
+	ratelimitCmdRoot := ratelimit.NewRootCommand(app, globals)
+	routeCmdRoot := route.NewRootCommand(app, globals)
+	routePreview := route.NewPreviewCommand(routeCmdRoot.CmdClause, globals, data)
+	scanCmdRoot := scan.NewRootCommand(app, globals)
+	scanHeaders := scan.NewHeadersCommand(scanCmdRoot.CmdClause, globals, data)
+	scheduleCmdRoot := schedule.NewRootCommand(app, globals)
+	scheduleAdd := schedule.NewAddCommand(scheduleCmdRoot.CmdClause, globals)
+	scheduleDelete := schedule.NewDeleteCommand(scheduleCmdRoot.CmdClause, globals)
+	scheduleList := schedule.NewListCommand(scheduleCmdRoot.CmdClause, globals)
+	scheduleRun := schedule.NewRunCommand(scheduleCmdRoot.CmdClause, globals)
+	searchCmdRoot := search.NewRootCommand(app, globals)
 	serviceCmdRoot := service.NewRootCommand(app, globals)
+	serviceActivity := service.NewActivityCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceCreate := service.NewCreateCommand(serviceCmdRoot.CmdClause, globals)
 	serviceDelete := service.NewDeleteCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceDescribe := service.NewDescribeCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceList := service.NewListCommand(serviceCmdRoot.CmdClause, globals)
 	serviceSearch := service.NewSearchCommand(serviceCmdRoot.CmdClause, globals, data)
+	serviceSmokeTest := service.NewSmokeTestCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceUpdate := service.NewUpdateCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceVersionCmdRoot := serviceversion.NewRootCommand(app, globals)
 	serviceVersionActivate := serviceversion.NewActivateCommand(serviceVersionCmdRoot.CmdClause, globals, data)
@@ -305,13 +392,18 @@ func defineCommands(
 	serviceVersionDeactivate := serviceversion.NewDeactivateCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionList := serviceversion.NewListCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionLock := serviceversion.NewLockCommand(serviceVersionCmdRoot.CmdClause, globals, data)
+	serviceVersionPrune := serviceversion.NewPruneCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionUpdate := serviceversion.NewUpdateCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	statsCmdRoot := stats.NewRootCommand(app, globals)
+	statsExport := stats.NewExportCommand(statsCmdRoot.CmdClause, globals, data)
 	statsHistorical := stats.NewHistoricalCommand(statsCmdRoot.CmdClause, globals, data)
+	statsPops := stats.NewPopsCommand(statsCmdRoot.CmdClause, globals, data)
 	statsRealtime := stats.NewRealtimeCommand(statsCmdRoot.CmdClause, globals, data)
 	statsRegions := stats.NewRegionsCommand(statsCmdRoot.CmdClause, globals)
+	supportBundleCmdRoot := supportbundle.NewRootCommand(app, globals)
 	updateRoot := update.NewRootCommand(app, opts.ConfigPath, opts.Versioners.CLI, globals)
 	userCmdRoot := user.NewRootCommand(app, globals)
+	userAudit := user.NewAuditCommand(userCmdRoot.CmdClause, globals)
 	userCreate := user.NewCreateCommand(userCmdRoot.CmdClause, globals, data)
 	userDelete := user.NewDeleteCommand(userCmdRoot.CmdClause, globals, data)
 	userDescribe := user.NewDescribeCommand(userCmdRoot.CmdClause, globals, data)
@@ -325,16 +417,41 @@ func defineCommands(
 	vclCustomList := custom.NewListCommand(vclCustomCmdRoot.CmdClause, globals, data)
 	vclCustomUpdate := custom.NewUpdateCommand(vclCustomCmdRoot.CmdClause, globals, data)
 	vclSnippetCmdRoot := snippet.NewRootCommand(vclCmdRoot.CmdClause, globals)
+	vclSnippetAnalyze := snippet.NewAnalyzeCommand(vclSnippetCmdRoot.CmdClause, globals)
+	vclSnippetBlame := snippet.NewBlameCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetCreate := snippet.NewCreateCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetDelete := snippet.NewDeleteCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetDescribe := snippet.NewDescribeCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetList := snippet.NewListCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetUpdate := snippet.NewUpdateCommand(vclSnippetCmdRoot.CmdClause, globals, data)
+	vclSnippetUsages := snippet.NewUsagesCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	versionCmdRoot := version.NewRootCommand(app, opts.Versioners.Viceroy)
+	wafCmdRoot := waf.NewRootCommand(app, globals)
+	wafExclusionCmdRoot := exclusion.NewRootCommand(wafCmdRoot.CmdClause, globals)
+	wafExclusionCreate := exclusion.NewCreateCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafExclusionDelete := exclusion.NewDeleteCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafExclusionList := exclusion.NewListCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafExclusionUpdate := exclusion.NewUpdateCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafList := waf.NewListCommand(wafCmdRoot.CmdClause, globals, data)
+	wafRuleCmdRoot := rule.NewRootCommand(wafCmdRoot.CmdClause, globals)
+	wafRuleDisable := rule.NewDisableCommand(wafRuleCmdRoot.CmdClause, globals)
+	wafRuleEnable := rule.NewEnableCommand(wafRuleCmdRoot.CmdClause, globals)
+	wafRuleList := rule.NewListCommand(wafRuleCmdRoot.CmdClause, globals)
+	wafVersionCmdRoot := wafversion.NewRootCommand(wafCmdRoot.CmdClause, globals)
+	wafVersionClone := wafversion.NewCloneCommand(wafVersionCmdRoot.CmdClause, globals)
+	wafVersionDeploy := wafversion.NewDeployCommand(wafVersionCmdRoot.CmdClause, globals)
+	wafVersionList := wafversion.NewListCommand(wafVersionCmdRoot.CmdClause, globals)
+	wafVersionLock := wafversion.NewLockCommand(wafVersionCmdRoot.CmdClause, globals)
 	whoamiCmdRoot := whoami.NewRootCommand(app, globals)
 
 	return []cmd.Command{
 		shellcompleteCmdRoot,
+		abtestCmdRoot,
+		abtestCreate,
+		abtestReport,
+		accountCmdRoot,
+		accountDescribe,
+		accountLimits,
 		aclCmdRoot,
 		aclCreate,
 		aclDelete,
@@ -345,48 +462,79 @@ func defineCommands(
 		aclEntryCreate,
 		aclEntryDelete,
 		aclEntryDescribe,
+		aclEntryExpireRun,
 		aclEntryList,
 		aclEntryUpdate,
+		analyzeCmdRoot,
+		analyzeCache,
+		apiCmdRoot,
 		authtokenCmdRoot,
 		authtokenCreate,
 		authtokenDelete,
 		authtokenDescribe,
 		authtokenList,
+		authtokenPurge,
 		backendCmdRoot,
+		backendBlame,
 		backendCreate,
 		backendDelete,
 		backendDescribe,
+		backendHarden,
 		backendList,
+		backendMirror,
+		backendRecommendShield,
 		backendUpdate,
+		bootstrapCmdRoot,
+		catalogCmdRoot,
 		computeBuild,
+		completionCmdRoot,
 		computeCmdRoot,
 		computeDeploy,
 		computeInit,
 		computePack,
+		computeProfile,
 		computePublish,
+		computeReplay,
 		computeServe,
 		computeUpdate,
 		computeValidate,
 		configCmdRoot,
+		curlCmdRoot,
+		dashboardCmdRoot,
 		dictionaryCmdRoot,
 		dictionaryCreate,
 		dictionaryDelete,
 		dictionaryDescribe,
 		dictionaryItemCmdRoot,
+		dictionaryItemApplyDue,
 		dictionaryItemCreate,
 		dictionaryItemDelete,
 		dictionaryItemDescribe,
 		dictionaryItemList,
+		dictionaryItemSchedule,
 		dictionaryItemUpdate,
 		dictionaryList,
+		dictionaryLookup,
 		dictionaryUpdate,
+		dictionaryUsages,
 		domainCmdRoot,
 		domainCreate,
 		domainDelete,
 		domainDescribe,
 		domainList,
+		domainMigrate,
 		domainUpdate,
 		domainValidate,
+		errorsCmdRoot,
+		errorsShow,
+		flagsCmdRoot,
+		flagsDisable,
+		flagsEnable,
+		flagsList,
+		generateCmdRoot,
+		generateCommands,
+		gzipCmdRoot,
+		gzipAudit,
 		healthcheckCmdRoot,
 		healthcheckCreate,
 		healthcheckDelete,
@@ -394,7 +542,10 @@ func defineCommands(
 		healthcheckList,
 		healthcheckUpdate,
 		ipCmdRoot,
+		limitsCmdRoot,
+		limitsStatus,
 		logtailCmdRoot,
+		loggingAudit,
 		loggingAzureblobCmdRoot,
 		loggingAzureblobCreate,
 		loggingAzureblobDelete,
@@ -552,6 +703,13 @@ func defineCommands(
 		loggingSyslogDescribe,
 		loggingSyslogList,
 		loggingSyslogUpdate,
+		maintenanceCmdRoot,
+		maintenanceDisable,
+		maintenanceEnable,
+		monitorCmdRoot,
+		monitorGenerate,
+		originCmdRoot,
+		originSwitch,
 		popCmdRoot,
 		profileCmdRoot,
 		profileCreate,
@@ -560,12 +718,25 @@ func defineCommands(
 		profileSwitch,
 		profileUpdate,
 		purgeCmdRoot,
+		ratelimitCmdRoot,
+		routeCmdRoot,
+		routePreview,
+		scanCmdRoot,
+		scanHeaders,
+		scheduleCmdRoot,
+		scheduleAdd,
+		scheduleDelete,
+		scheduleList,
+		scheduleRun,
+		searchCmdRoot,
 		serviceCmdRoot,
+		serviceActivity,
 		serviceCreate,
 		serviceDelete,
 		serviceDescribe,
 		serviceList,
 		serviceSearch,
+		serviceSmokeTest,
 		serviceUpdate,
 		serviceVersionActivate,
 		serviceVersionClone,
@@ -573,13 +744,18 @@ func defineCommands(
 		serviceVersionDeactivate,
 		serviceVersionList,
 		serviceVersionLock,
+		serviceVersionPrune,
 		serviceVersionUpdate,
 		statsCmdRoot,
+		statsExport,
 		statsHistorical,
+		statsPops,
 		statsRealtime,
 		statsRegions,
+		supportBundleCmdRoot,
 		updateRoot,
 		userCmdRoot,
+		userAudit,
 		userCreate,
 		userDelete,
 		userDescribe,
@@ -593,12 +769,31 @@ func defineCommands(
 		vclCustomList,
 		vclCustomUpdate,
 		vclSnippetCmdRoot,
+		vclSnippetAnalyze,
+		vclSnippetBlame,
 		vclSnippetCreate,
 		vclSnippetDelete,
 		vclSnippetDescribe,
 		vclSnippetList,
 		vclSnippetUpdate,
+		vclSnippetUsages,
 		versionCmdRoot,
+		wafCmdRoot,
+		wafExclusionCmdRoot,
+		wafExclusionCreate,
+		wafExclusionDelete,
+		wafExclusionList,
+		wafExclusionUpdate,
+		wafList,
+		wafRuleCmdRoot,
+		wafRuleDisable,
+		wafRuleEnable,
+		wafRuleList,
+		wafVersionCmdRoot,
+		wafVersionClone,
+		wafVersionDeploy,
+		wafVersionList,
+		wafVersionLock,
 		whoamiCmdRoot,
 	}
 }
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logger"
+	"github.com/fastly/cli/pkg/revision"
+)
+
+// The built-in command middleware. Each can be enabled or disabled via the
+// middleware setting of a profile, e.g.
+//
+//	[profile.production.middleware]
+//	confirm = true
+//	timing = true
+func init() {
+	cmd.RegisterMiddleware(cmd.Middleware{
+		Name:    "log",
+		Enabled: true,
+		Wrap:    logMiddleware,
+	})
+	cmd.RegisterMiddleware(cmd.Middleware{
+		Name: "timing",
+		Wrap: timingMiddleware,
+	})
+	cmd.RegisterMiddleware(cmd.Middleware{
+		Name: "confirm",
+		Wrap: confirmMiddleware,
+	})
+}
+
+// logMiddleware writes the start and outcome of the command to the structured
+// log (see --log-file).
+func logMiddleware(name string, g *config.Data, next cmd.ExecFunc) cmd.ExecFunc {
+	return func(in io.Reader, out io.Writer) error {
+		g.Logger.Info("command started", logger.Fields{
+			"command": name,
+			"version": revision.AppVersion,
+		})
+		start := g.Clock.Now()
+		err := next(in, out)
+
+		fields := logger.Fields{
+			"command":     name,
+			"duration_ms": g.Clock.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			fields["error"] = err
+			g.Logger.Error("command failed", fields)
+		} else {
+			g.Logger.Info("command finished", fields)
+		}
+		return err
+	}
+}
+
+// timingMiddleware displays how long the command took, on the diagnostics
+// stream so it doesn't corrupt structured output.
+func timingMiddleware(name string, g *config.Data, next cmd.ExecFunc) cmd.ExecFunc {
+	return func(in io.Reader, out io.Writer) error {
+		start := g.Clock.Now()
+		err := next(in, out)
+		fmt.Fprintf(g.Diagnostics, "'fastly %s' took %s\n", name, g.Clock.Since(start).Round(time.Millisecond))
+		return err
+	}
+}
+
+// confirmMiddleware asks for confirmation before running a command that
+// modifies the Fastly account, e.g. for a profile of a production account.
+func confirmMiddleware(name string, g *config.Data, next cmd.ExecFunc) cmd.ExecFunc {
+	return func(in io.Reader, out io.Writer) error {
+		if g.Mutating {
+			profile, _ := g.CurrentProfile()
+			prompt := fmt.Sprintf("'fastly %s' modifies the Fastly account of profile '%s'. Are you sure? [y/N] ", name, profile)
+			ok, err := cmd.Confirm(cmd.ConfirmDestructive, prompt, g, in, out)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fsterr.ErrConfirmationDeclined
+			}
+		}
+		return next(in, out)
+	}
+}
@@ -0,0 +1,269 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/env"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/kingpin"
+)
+
+// pluginPrefix is the prefix of the executables on PATH that are exposed as
+// commands, e.g. `fastly foo` runs the fastly-foo executable.
+const pluginPrefix = "fastly-"
+
+// plugin is an external executable exposed as a command.
+type plugin struct {
+	// Name is the name of the command, i.e. the executable's name without
+	// pluginPrefix (or a Windows file extension).
+	Name string
+	// Path is the path of the executable.
+	Path string
+}
+
+// findPlugins returns the plugins found in the directories of path, a list of
+// directories in the format of the PATH environment variable. As with PATH,
+// when there are several executables with the same name the first is used.
+func findPlugins(path string) []plugin {
+	var plugins []plugin
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e)
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, plugin{Name: name, Path: filepath.Join(dir, e.Name())})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// pluginName returns the command name of the plugin executable e, reporting
+// whether e is a plugin executable.
+func pluginName(e os.DirEntry) (string, bool) {
+	name := e.Name()
+	if !strings.HasPrefix(name, pluginPrefix) || e.IsDir() {
+		return "", false
+	}
+	info, err := e.Info()
+	if err != nil {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+	name = strings.TrimPrefix(name, pluginPrefix)
+	if name == "" || strings.HasPrefix(name, "-") {
+		return "", false
+	}
+	return name, true
+}
+
+// definePlugins registers the plugins found on opts.Env.Path as commands. A
+// plugin can't replace a built-in command, so plugins with the same name as
+// one are ignored.
+func definePlugins(app *kingpin.Application, globals *config.Data, data manifest.Data, opts RunOpts) []cmd.Command {
+	var commands []cmd.Command
+	for _, p := range findPlugins(opts.Env.Path) {
+		if app.GetCommand(p.Name) != nil {
+			continue
+		}
+		commands = append(commands, newPluginCommand(app, globals, data, p))
+	}
+	return commands
+}
+
+// pluginArgs returns args with the arguments following the name of a plugin
+// command marked as positional (by inserting "--"), so that they're passed to
+// the plugin as given rather than being parsed as flags.
+func pluginArgs(app *kingpin.Application, args []string, commands []cmd.Command) []string {
+	plugins := make(map[string]bool)
+	for _, c := range commands {
+		if p, ok := c.(*pluginCommand); ok {
+			plugins[p.name] = true
+		}
+	}
+	if len(plugins) == 0 {
+		return args
+	}
+
+	i := commandIndex(app, args)
+	if i < 0 || !plugins[args[i]] {
+		return args
+	}
+	split := append([]string{}, args[:i+1]...)
+	split = append(split, "--")
+	return append(split, args[i+1:]...)
+}
+
+// commandIndex returns the index in args of the name of the command, i.e. the
+// first positional argument following any global flags (and their values), or
+// -1 if there isn't one.
+func commandIndex(app *kingpin.Application, args []string) int {
+	flags := app.Model().Flags
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--":
+			return -1
+		case strings.HasPrefix(a, "--"):
+			// The value of a non-boolean flag is the following argument,
+			// unless it's given as --flag=value.
+			if f := findFlag(flags, strings.TrimPrefix(a, "--")); f != nil && !f.IsBoolFlag() && !strings.Contains(a, "=") {
+				i++
+			}
+		case strings.HasPrefix(a, "-") && len(a) > 1:
+			// Short flags may be combined (e.g. -vv), and the last of them
+			// may take the following argument as its value.
+			if f := findShortFlag(flags, rune(a[len(a)-1])); f != nil && !f.IsBoolFlag() {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+func findFlag(flags []*kingpin.ClauseModel, name string) *kingpin.ClauseModel {
+	for _, f := range flags {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func findShortFlag(flags []*kingpin.ClauseModel, short rune) *kingpin.ClauseModel {
+	for _, f := range flags {
+		if f.Short == short {
+			return f
+		}
+	}
+	return nil
+}
+
+// pluginCommand runs a plugin executable, passing it the remaining arguments
+// and the context of the CLI via environment variables:
+//
+//   - FASTLY_API_TOKEN and FASTLY_API_ENDPOINT: the resolved token and API
+//     endpoint.
+//   - FASTLY_SERVICE_ID: the service ID given by fastly.toml, the environment
+//     or the profile, if any.
+//   - FASTLY_MANIFEST: the absolute path of fastly.toml, if it exists.
+//   - FASTLY_PROFILE: the name of the profile in use, if any.
+//   - FASTLY_CLI_VERSION: the version of the CLI.
+//   - FASTLY_CLI_FLAG_<NAME>: the value of each global flag that was set, e.g.
+//     FASTLY_CLI_FLAG_NON_INTERACTIVE=true.
+type pluginCommand struct {
+	cmd.Base
+
+	args     []string
+	app      *kingpin.Application
+	manifest manifest.Data
+	name     string
+	path     string
+}
+
+// newPluginCommand returns a command that runs the plugin p.
+func newPluginCommand(app *kingpin.Application, globals *config.Data, data manifest.Data, p plugin) *pluginCommand {
+	var c pluginCommand
+	c.Globals = globals
+	c.app = app
+	c.manifest = data
+	c.name = p.Name
+	c.path = p.Path
+	c.CmdClause = app.Command(p.Name, fmt.Sprintf("Run the %s plugin (%s)", p.Name, p.Path))
+	c.CmdClause.Arg("args", "Arguments passed to the plugin").StringsVar(&c.args)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *pluginCommand) Exec(in io.Reader, out io.Writer) error {
+	// #nosec G204 -- the plugin was found on the user's PATH.
+	p := exec.CommandContext(c.Globals.Context, c.path, c.args...)
+	p.Env = append(os.Environ(), c.environ()...)
+	// Plugins handle non-interactive mode themselves (see environ), so they're
+	// given the CLI's stdin rather than the input that fails prompts.
+	p.Stdin = in
+	if in == cmd.NonInteractiveInput {
+		p.Stdin = c.Globals.Stdin
+	}
+	p.Stdout = out
+	p.Stderr = c.Globals.Diagnostics
+
+	if err := p.Run(); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error running plugin %s: %w", c.path, err),
+			Remediation: "The plugin isn't part of the Fastly CLI. Check its documentation, or remove it from your PATH.",
+		}
+	}
+	return nil
+}
+
+// environ returns the environment variables that give the plugin the context
+// of the CLI.
+func (c *pluginCommand) environ() []string {
+	token, _ := c.Globals.Token()
+	endpoint, _ := c.Globals.Endpoint()
+	vars := []string{
+		env.Token + "=" + token,
+		env.Endpoint + "=" + endpoint,
+		"FASTLY_CLI_VERSION=" + revision.AppVersion,
+	}
+	if serviceID, _, _, err := cmd.ServiceID(cmd.OptionalServiceNameID{}, c.manifest, nil, nil, c.Globals.ProfileServiceID()); err == nil {
+		vars = append(vars, env.ServiceID+"="+serviceID)
+	}
+	if path, err := filepath.Abs(manifest.Filename); err == nil {
+		if _, err := os.Stat(path); err == nil {
+			vars = append(vars, "FASTLY_MANIFEST="+path)
+		}
+	}
+	if name, _ := c.Globals.CurrentProfile(); name != "" {
+		vars = append(vars, "FASTLY_PROFILE="+name)
+	}
+
+	for _, f := range c.app.Model().Flags {
+		// The token and endpoint are passed as resolved above.
+		if f.Name == "help" || f.Name == "token" || f.Name == "endpoint" || f.Hidden {
+			continue
+		}
+		v := f.Value.String()
+		if v == "" || v == "false" || v == "0" || v == "[]" {
+			continue
+		}
+		name := strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		vars = append(vars, "FASTLY_CLI_FLAG_"+name+"="+v)
+	}
+	return vars
+}
@@ -2,22 +2,31 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/commands/update"
 	"github.com/fastly/cli/pkg/commands/version"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logger"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/profile"
 	"github.com/fastly/cli/pkg/revision"
 	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/tracing"
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/fastly/kingpin"
 )
@@ -34,9 +43,20 @@ type RunOpts struct {
 	Args       []string
 	ConfigFile config.File
 	ConfigPath string
+	// Clock is the source of the current time and of generated IDs. It
+	// defaults to the system clock.
+	Clock *clock.Clock
+	// Context is cancelled when the user interrupts the CLI. It defaults to
+	// context.Background().
+	Context    context.Context
 	Env        config.Environment
 	ErrLog     fsterr.LogInterface
 	HTTPClient api.HTTPClient
+	RTSClient  RTSClientFactory
+	// StateDir is where state is kept between invocations. If empty then no
+	// state is kept.
+	StateDir   string
+	Stderr     io.Writer
 	Stdin      io.Reader
 	Stdout     io.Writer
 	Versioners Versioners
@@ -52,15 +72,17 @@ type RunOpts struct {
 // The Run helper should NOT output any error-related information to the out
 // io.Writer. All error-related information should be encoded into an error type
 // and returned to the caller. This includes usage text.
-func Run(opts RunOpts) error {
+func Run(opts RunOpts) (err error) {
 	var md manifest.Data
 	md.File.SetErrLog(opts.ErrLog)
 	md.File.SetOutput(opts.Stdout)
+	md.File.SetCommands(opts.ConfigFile.Manifest.Commands)
 	md.File.Read(manifest.Filename)
 
 	// The globals will hold generally-applicable configuration parameters
 	// from a variety of sources, and is provided to each concrete command.
 	globals := config.Data{
+		Clock:      opts.Clock,
 		Env:        opts.Env,
 		ErrLog:     opts.ErrLog,
 		File:       opts.ConfigFile,
@@ -68,6 +90,18 @@ func Run(opts RunOpts) error {
 		Manifest:   md,
 		Output:     opts.Stdout,
 		Path:       opts.ConfigPath,
+		StateDir:   opts.StateDir,
+	}
+	globals.Diagnostics = opts.Stderr
+	if globals.Diagnostics == nil {
+		globals.Diagnostics = io.Discard
+	}
+	globals.Context = opts.Context
+	if globals.Context == nil {
+		globals.Context = context.Background()
+	}
+	if globals.HTTPClient != nil {
+		globals.HTTPClient = transport.ContextClient(globals.HTTPClient, globals.Context)
 	}
 
 	// Set up the main application root, including global flags, and then each
@@ -98,14 +132,53 @@ func Run(opts RunOpts) error {
 	// NOTE: Short flags CAN be safely reused across commands.
 	tokenHelp := fmt.Sprintf("Fastly API token (or via %s)", env.Token)
 	app.Flag("accept-defaults", "Accept default options for all interactive prompts apart from Yes/No confirmations").Short('d').BoolVar(&globals.Flag.AcceptDefaults)
-	app.Flag("auto-yes", "Answer yes automatically to all Yes/No confirmations. This may suppress security warnings").Short('y').BoolVar(&globals.Flag.AutoYes)
+	app.Flag("api-endpoint", fmt.Sprintf("Fastly API endpoint, e.g. of a staging environment or a local mock (or via %s, or the profile's api_endpoint setting)", env.Endpoint)).StringVar(&globals.Flag.Endpoint)
+	app.Flag("api-timeout", "Timeout, in seconds, for each API request (default: no timeout, or the config file's [network] api_timeout setting)").IntVar(&globals.Flag.APITimeout)
+	app.Flag("auto-yes", "Answer yes automatically to informational Yes/No confirmations. This may suppress security warnings").Short('y').BoolVar(&globals.Flag.AutoYes)
+	app.Flag("ca-file", "Path of a PEM file of root certificates to trust in addition to the system's when connecting to the API, e.g. those of a TLS-intercepting proxy (or via the config file's [network] ca_file setting)").StringVar(&globals.Flag.CAFile)
+	app.Flag("confirm-destructive", "Answer yes automatically to Yes/No confirmations for destructive operations").BoolVar(&globals.Flag.ConfirmDestructive)
+	app.Flag("confirm-irreversible", "Answer yes automatically to all Yes/No confirmations, including irreversible operations (e.g. purge all, service delete)").BoolVar(&globals.Flag.ConfirmIrreversible)
+	app.Flag("debug-http", "Write every HTTP request and response the CLI makes (method, URL, headers, bodies, status and timing) to stderr, with credentials redacted").BoolVar(&globals.Flag.DebugHTTP)
+	app.Flag("deterministic", "Use a fixed clock and sequential IDs, so time-dependent output (e.g. timestamps and generated names) is reproducible").BoolVar(&globals.Flag.Deterministic)
+	app.Flag("dry-run", "Display the API requests that would modify the Fastly account (method, path and body) instead of sending them").BoolVar(&globals.Flag.DryRun)
+	// --endpoint is the original name of --api-endpoint, kept for scripts.
 	app.Flag("endpoint", "Fastly API endpoint").Hidden().StringVar(&globals.Flag.Endpoint)
-	app.Flag("non-interactive", "Do not prompt for user input - suitable for CI processes. Equivalent to --accept-defaults and --auto-yes").Short('i').BoolVar(&globals.Flag.NonInteractive)
+	app.Flag("human-sizes", "Display byte quantities using binary units (e.g. 1.5 GiB)").BoolVar(&globals.Flag.HumanSizes)
+	app.Flag("iso8601", "Display timestamps in ISO 8601 (RFC 3339) format").BoolVar(&globals.Flag.ISO8601)
+	app.Flag("log-file", "Append structured (JSON) logs of the CLI's internals to this file, e.g. for diagnosing automation").StringVar(&globals.Flag.LogFile)
+	app.Flag("log-level", fmt.Sprintf("Minimum level of the structured logs: %s (default: info). Without --log-file, logs are written to stderr", strings.Join(logger.Levels, ", "))).EnumVar(&globals.Flag.LogLevel, logger.Levels...)
+	app.Flag("no-cache", "Don't use or update the on-disk cache of read-only API responses (enabled by the config file's [cache] ttl setting)").BoolVar(&globals.Flag.NoCache)
+	app.Flag("non-interactive", "Do not prompt for user input - suitable for CI processes (also enabled by the FASTLY_NONINTERACTIVE env var). Equivalent to --accept-defaults and --auto-yes, and prompts that require input fail instead").Short('i').BoolVar(&globals.Flag.NonInteractive)
+	app.Flag("output", "Render the output of any command as json or yaml (default: table, the regular output). Commands without a --json flag render the response of their last API request").EnumVar(&globals.Flag.Output, cmd.OutputFormats...)
+	app.Flag("preset", "Render the output of a command as a table using a named output preset, defined in the config file's [output.<name>] section (columns and sort)").StringVar(&globals.Flag.Preset)
 	app.Flag("profile", "Switch account profile for single command execution (see also: 'fastly profile switch')").Short('o').StringVar(&globals.Flag.Profile)
+	app.Flag("proxy", "URL of the proxy to send API requests via (or via the config file's [network] proxy setting, otherwise the HTTPS_PROXY env var)").StringVar(&globals.Flag.Proxy)
+	app.Flag("read-only", "Refuse to run commands that modify the Fastly account (also enabled by a profile's read_only setting)").BoolVar(&globals.Flag.ReadOnly)
+	app.Flag("record", "Store the response to every API request in this directory, so that the command can be run again offline with --replay").StringVar(&globals.Flag.Record)
+	app.Flag("replay", "Serve the API responses stored in this directory by --record instead of sending the requests, e.g. to test scripts without the live API (any --token is accepted)").StringVar(&globals.Flag.Replay)
+	app.Flag("retry", fmt.Sprintf("Maximum number of times an API request that's rate limited or fails with a transient server error is retried, with backoff (default: %d, or the config file's [retry] setting). Set to 0 to disable retries", config.DefaultRetryAttempts)).Action(func(*kingpin.ParseElement, *kingpin.ParseContext) error {
+		globals.Flag.RetrySet = true
+		return nil
+	}).IntVar(&globals.Flag.Retry)
+	app.Flag("timings", "After the command finishes, display a summary of the API requests it made (count, latency, retries and payload sizes)").BoolVar(&globals.Flag.Timings)
 	app.Flag("token", tokenHelp).Short('t').StringVar(&globals.Flag.Token)
-	app.Flag("verbose", "Verbose logging").Short('v').BoolVar(&globals.Flag.Verbose)
+	app.Flag("utc", "Display timestamps in UTC").BoolVar(&globals.Flag.UTC)
+	app.Flag("verbose", "Verbose logging (repeat for more detail: -vv API timings, -vvv HTTP traces)").Short('v').CounterVar(&globals.Flag.VerboseLevel)
+
+	opts.Args, globals.AutoCloneDryRun = cmd.SplitAutoCloneArgs(opts.Args)
+	opts.APIClient = networkClientFactory(opts.APIClient, &globals)
 
 	commands := defineCommands(app, &globals, md, opts)
+	commands = append(commands, definePlugins(app, &globals, md, opts)...)
+	args, err := expandAlias(app, opts.Args, globals.File.Aliases)
+	if err != nil {
+		return err
+	}
+	args, serviceID, serviceConfig, err := cmd.ServiceConfigArgs(app, args, &globals)
+	if err != nil {
+		return err
+	}
+	opts.Args = joinStdinArgs(app, pluginArgs(app, args, commands))
 	command, name, err := processCommandInput(opts, app, &globals, commands)
 	if err != nil {
 		return err
@@ -123,18 +196,176 @@ func Run(opts RunOpts) error {
 		return nil
 	}
 
+	// Non-interactive mode answers every prompt with its default, and those
+	// without a default fail rather than waiting for input.
+	stdin := opts.Stdin
+	if globals.Env.NonInteractive {
+		globals.Flag.NonInteractive = true
+	}
+	if globals.Flag.NonInteractive {
+		globals.Flag.AcceptDefaults = true
+		globals.Flag.AutoYes = true
+		stdin = cmd.NonInteractiveInput
+	}
+
+	// Flags whose content is read via cmd.Content or cmd.Open accept "-" to
+	// mean stdin.
+	if err := validateStdinFlags(app, name); err != nil {
+		return err
+	}
+	globals.Stdin = opts.Stdin
+
+	// The verbose flag is a counter (-v, -vv, -vvv) but most commands only
+	// distinguish between verbose and non-verbose output.
+	//
+	// NOTE: When a command is rendering structured output (--json) or raw
+	// content (--raw) we disable the verbose output commands write alongside
+	// their regular output, as it would otherwise corrupt it. Diagnostics are
+	// instead written to the separate Diagnostics stream (stderr).
+	//
+	// The global --output flag sets a command's --json flag, if it has one, so
+	// the structured output is the one the command renders itself.
+	structured := globals.Flag.Output == cmd.OutputJSON || globals.Flag.Output == cmd.OutputYAML
+	preset, err := outputPreset(&globals, structured)
+	if err != nil {
+		return err
+	}
+	// The --json flag is checked before --output sets it, as YAML output sets
+	// it too.
+	jsonOutput := globals.Flag.Output == cmd.OutputJSON || isJSONOutput(app, name)
+	rendersJSON := (structured || preset != nil) && setJSONFlag(app, name)
+	machineOutput := structured || preset != nil || isMachineOutput(app, name)
+	globals.Flag.Verbose = globals.Flag.VerboseLevel > 0 && !machineOutput
+	verboseOutput := opts.Stdout
+	if machineOutput {
+		verboseOutput = globals.Diagnostics
+	}
+
+	// A command rendering JSON reports its error as JSON too, so that
+	// automation can parse it (see fsterr.JSONError).
+	if jsonOutput {
+		defer func() {
+			if err != nil {
+				err = fsterr.JSONError{Err: err}
+			}
+		}()
+	}
+
+	if globals.Flag.Deterministic {
+		globals.Clock = clock.Fixed(clock.Epoch)
+	}
+
+	// A proxy or CA file replaces the transport that the API clients connect
+	// with (see networkClientFactory).
+	if n := globals.Network(); n.Proxy != "" || n.CAFile != "" {
+		globals.Transport, err = transport.NewNetwork(n.Proxy, n.CAFile)
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return fsterr.RemediationError{
+				Inner:       err,
+				Remediation: "Correct the --proxy or --ca-file flag, or the [network] section of the config file ('fastly config --location' displays its path).",
+			}
+		}
+		if c, ok := opts.HTTPClient.(*http.Client); ok {
+			globals.HTTPClient = transport.ContextClient(&http.Client{Transport: globals.Transport, Timeout: c.Timeout}, globals.Context)
+		}
+	}
+
+	recording, err := newRecording(globals.Flag)
+	if err != nil {
+		globals.ErrLog.Add(err)
+		return err
+	}
+
+	log, closeLog, err := newLogger(globals.Flag, globals.Diagnostics)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	if log != nil {
+		globals.Logger = log
+		globals.ErrLog = logger.ErrLog(globals.ErrLog, log)
+		if globals.HTTPClient != nil {
+			globals.HTTPClient = log.Client(globals.HTTPClient)
+		}
+	}
+
+	// Tracing is only a diagnostic, so a configuration that can't be used
+	// (e.g. for another tool) is reported rather than failing the command.
+	tracer, warning := newTracer(globals.Env.Tracing)
+	if warning != nil {
+		globals.ErrLog.Add(warning)
+		text.Warning(globals.Diagnostics, "OpenTelemetry tracing is disabled: %s", warning)
+	}
+	span := tracer.Start("fastly "+name, tracing.KindInternal, nil)
+	span.SetAttribute("cli.command", name)
+	defer func() {
+		span.End(err)
+		if err := tracer.Export(context.Background()); err != nil {
+			globals.ErrLog.Add(err)
+			text.Warning(globals.Diagnostics, "Failed to export the CLI's spans: %s", err)
+		}
+	}()
+
+	var capture *transport.Capture
+	if (structured || preset != nil) && !rendersJSON {
+		capture = new(transport.Capture)
+	}
+
+	var dryRun *transport.DryRun
+	if globals.Flag.DryRun {
+		dryRun = &transport.DryRun{Out: verboseOutput}
+	}
+
+	var stats *transport.Stats
+	if globals.Flag.Timings {
+		stats = new(transport.Stats)
+		if globals.HTTPClient != nil {
+			globals.HTTPClient = stats.Client(globals.HTTPClient)
+		}
+	}
+	// The verbose output reports the API rate limit remaining after the
+	// command, so that automation can throttle itself before it's exhausted.
+	// The API only reports it for requests that modify the account, so it's
+	// also kept for the rate-limit command to display later (unless the
+	// responses are replayed, as they weren't sent by this token).
+	rateLimits := &transport.RateLimits{Now: globals.Clock.Now}
+	if globals.Flag.Replay == "" {
+		rateLimits.Path = globals.StatePath(transport.RateLimitsFile)
+	}
+	if globals.HTTPClient != nil {
+		globals.HTTPClient = rateLimits.Client(globals.HTTPClient)
+	}
+	if globals.HTTPClient != nil && globals.TraceHTTP() {
+		globals.HTTPClient = transport.DiagnosticsClient(globals.HTTPClient, true, globals.Diagnostics)
+	}
+
+	globals.Mutating = command != nil && command.Mutates()
+	if globals.ReadOnly() && globals.Mutating {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("%w: 'fastly %s' modifies the Fastly account", fsterr.ErrReadOnly.Inner, name),
+			Remediation: fsterr.ReadOnlyRemediation,
+		}
+	}
+
+	if serviceConfig != nil && serviceConfig.Protected && globals.Mutating {
+		if err := cmd.ConfirmProtected(serviceID, &globals, stdin, opts.Stdout); err != nil {
+			return err
+		}
+	}
+
 	token, source := globals.Token()
 
-	if globals.Verbose() {
+	if globals.VerboseLevel() > 0 {
 		displayTokenSource(
 			source,
-			opts.Stdout,
+			verboseOutput,
 			env.Token,
 			determineProfile(md.File.Profile, globals.Flag.Profile, globals.File.Profiles),
 		)
 	}
 
-	token, err = profile.Init(token, &md, &globals, opts.Stdin, opts.Stdout)
+	token, err = profile.Init(token, &md, &globals, stdin, opts.Stdout)
 	if err != nil {
 		return err
 	}
@@ -155,14 +386,22 @@ func Run(opts RunOpts) error {
 	}
 
 	endpoint, source := globals.Endpoint()
-	if globals.Verbose() {
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		err := fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid API endpoint '%s'", endpoint),
+			Remediation: fmt.Sprintf("Set the --api-endpoint flag, %s or the profile's api_endpoint setting to an http or https URL, e.g. https://api.fastly.com.", env.Endpoint),
+		}
+		globals.ErrLog.Add(err)
+		return err
+	}
+	if globals.VerboseLevel() > 0 {
 		switch source {
 		case config.SourceEnvironment:
-			fmt.Fprintf(opts.Stdout, "Fastly API endpoint (via %s): %s\n", env.Endpoint, endpoint)
+			fmt.Fprintf(verboseOutput, "Fastly API endpoint (via %s): %s\n", env.Endpoint, endpoint)
 		case config.SourceFile:
-			fmt.Fprintf(opts.Stdout, "Fastly API endpoint (via config file): %s\n", endpoint)
+			fmt.Fprintf(verboseOutput, "Fastly API endpoint (via config file): %s\n", endpoint)
 		default:
-			fmt.Fprintf(opts.Stdout, "Fastly API endpoint: %s\n", endpoint)
+			fmt.Fprintf(verboseOutput, "Fastly API endpoint: %s\n", endpoint)
 		}
 	}
 
@@ -171,8 +410,81 @@ func Run(opts RunOpts) error {
 		globals.ErrLog.Add(err)
 		return fmt.Errorf("error constructing Fastly API client: %w", err)
 	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok {
+		client.HTTPClient.Transport = transport.Context(client.HTTPClient.Transport, globals.Context)
+		client.HTTPClient.Timeout = globals.APITimeout()
+	}
+	// Uploads are compressed by the innermost transport, so the others (e.g.
+	// --debug-http) see the original request body.
+	if client, ok := globals.APIClient.(*fastly.Client); ok {
+		encoding, err := globals.UploadEncoding()
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return err
+		}
+		upload := &transport.Upload{Encoding: encoding, Progress: globals.Diagnostics}
+		client.HTTPClient.Transport = upload.Transport(client.HTTPClient.Transport)
+	}
+	// The recording is made beneath the other transports (e.g. --dry-run and
+	// retries), so that it holds the responses the API actually sent.
+	if client, ok := globals.APIClient.(*fastly.Client); ok && recording != nil {
+		client.HTTPClient.Transport = recording.Transport(client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && stats != nil {
+		client.HTTPClient.Transport = stats.Transport(client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok {
+		client.HTTPClient.Transport = rateLimits.Transport(client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && capture != nil {
+		client.HTTPClient.Transport = capture.Transport(client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && globals.Logger != nil {
+		client.HTTPClient.Transport = globals.Logger.Transport(client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && tracer != nil {
+		client.HTTPClient.Transport = tracer.Transport(span, client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && (globals.VerboseLevel() >= config.VerboseLevelTimings || globals.TraceHTTP()) {
+		client.HTTPClient.Transport = transport.Diagnostics(client.HTTPClient.Transport, globals.TraceHTTP(), globals.Diagnostics)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && globals.RetryAttempts() > 0 {
+		retry := &transport.Retry{Attempts: globals.RetryAttempts(), Context: globals.Context}
+		if globals.Verbose() {
+			retry.Out = globals.Diagnostics
+		}
+		client.HTTPClient.Transport = retry.Transport(client.HTTPClient.Transport)
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok {
+		ttl, err := globals.CacheTTL()
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return err
+		}
+		// The cache is bypassed by --record and --replay, as the responses it
+		// serves would otherwise be missing from (or replace) a recording.
+		if ttl > 0 && recording == nil {
+			cache := &transport.Cache{Dir: config.CacheDir, TTL: ttl}
+			if globals.Flag.NoCache {
+				cache.TTL = 0
+			}
+			if globals.Verbose() {
+				cache.Out = globals.Diagnostics
+			}
+			client.HTTPClient.Transport = cache.Transport(client.HTTPClient.Transport)
+		}
+	}
+	if client, ok := globals.APIClient.(*fastly.Client); ok && dryRun != nil {
+		client.HTTPClient.Transport = dryRun.Transport(client.HTTPClient.Transport)
+	}
+	// Commands that only modify the account in some modes (e.g. gzip audit
+	// --apply-preset) don't declare that they're mutating, so the API client
+	// also refuses to send any request that isn't a read.
+	if client, ok := globals.APIClient.(*fastly.Client); ok && globals.ReadOnly() {
+		client.HTTPClient.Transport = transport.ReadOnly(client.HTTPClient.Transport)
+	}
 
-	globals.RTSClient, err = fastly.NewRealtimeStatsClientForEndpoint(token, fastly.DefaultRealtimeStatsEndpoint)
+	globals.RTSClient, err = opts.RTSClient(token)
 	if err != nil {
 		globals.ErrLog.Add(err)
 		return fmt.Errorf("error constructing Fastly realtime stats client: %w", err)
@@ -194,7 +506,45 @@ func Run(opts RunOpts) error {
 		defer f(opts.Stdout) // ...and the printing function second, so we hit the timeout
 	}
 
-	return command.Exec(opts.Stdin, opts.Stdout)
+	exec := command.Exec
+	if structured {
+		exec = cmd.StructuredOutput(name, globals.Flag.Output, rendersJSON, capture.Last, exec)
+	}
+	if preset != nil {
+		exec = cmd.PresetOutput(name, globals.Flag.Preset, *preset, rendersJSON, capture.Last, exec)
+	}
+
+	start := globals.Clock.Now()
+	err = cmd.Chain(name, &globals, exec)(stdin, opts.Stdout)
+	if stats != nil {
+		printTimings(globals.Diagnostics, name, globals.Clock.Since(start), stats.Summary(TimingsSlowest))
+	}
+	// The rate-limit command displays the rate limit itself.
+	if globals.VerboseLevel() > 0 && name != "rate-limit" {
+		if rl, ok := rateLimits.Last(); ok {
+			text.Break(verboseOutput)
+			text.Info(verboseOutput, "API rate limit: %d requests remaining, reset at %s (in %s).", rl.Remaining, globals.Formatter().Time(rl.Reset), rl.ResetIn(globals.Clock.Now()))
+		}
+	}
+	if errors.Is(err, fsterr.ErrAutoCloneDryRun) {
+		return nil
+	}
+	if err == nil && dryRun.Skipped() > 0 {
+		text.Break(verboseOutput)
+		text.Info(verboseOutput, "Dry run: %d API requests were not sent, so the Fastly account wasn't modified.", dryRun.Skipped())
+	}
+	// A command that's interrupted typically fails with a cancelled API
+	// request, which isn't a helpful error to show.
+	if err != nil && globals.Context.Err() != nil {
+		return fsterr.ErrInterrupted
+	}
+	if errors.Is(err, fsterr.ErrReadOnly) {
+		return fsterr.ErrReadOnly
+	}
+	if errors.Is(err, fsterr.ErrNonInteractive) {
+		return fsterr.ErrNonInteractive
+	}
+	return err
 }
 
 // APIClientFactory creates a Fastly API client (modeled as an api.Interface)
@@ -207,8 +557,315 @@ type APIClientFactory func(token, endpoint string) (api.Interface, error)
 // FastlyAPIClient is a ClientFactory that returns a real Fastly API client
 // using the provided token and endpoint.
 func FastlyAPIClient(token, endpoint string) (api.Interface, error) {
-	client, err := fastly.NewClientForEndpoint(token, endpoint)
-	return client, err
+	return fastly.NewClientForEndpoint(token, endpoint)
+}
+
+// networkClientFactory returns a factory whose real Fastly API clients connect
+// via the transport configured by the --proxy and --ca-file flags (see
+// config.Data.Transport), if it's set when they're created, and are decorated
+// so the request IDs of failed API requests are recorded (see
+// transport.Failures). Other clients (e.g. mocks) are returned as f creates
+// them.
+func networkClientFactory(f APIClientFactory, g *config.Data) APIClientFactory {
+	return func(token, endpoint string) (api.Interface, error) {
+		c, err := f(token, endpoint)
+		client, ok := c.(*fastly.Client)
+		if err != nil || !ok {
+			return c, err
+		}
+		if g.Transport != nil {
+			client.HTTPClient.Transport = g.Transport
+		}
+		client.HTTPClient.Transport = transport.Failures.Transport(client.HTTPClient.Transport)
+		return client, nil
+	}
+}
+
+// RTSClientFactory creates a Fastly realtime stats client (modeled as an
+// api.RealtimeStatsInterface) from a user-provided API token.
+type RTSClientFactory func(token string) (api.RealtimeStatsInterface, error)
+
+// FastlyRTSClient is a RTSClientFactory that returns a real Fastly realtime
+// stats client using the provided token.
+func FastlyRTSClient(token string) (api.RealtimeStatsInterface, error) {
+	return fastly.NewRealtimeStatsClientForEndpoint(token, fastly.DefaultRealtimeStatsEndpoint)
+}
+
+// newRecording returns the recording of API responses configured by the
+// --record or --replay flag, or nil if neither is set.
+func newRecording(flag config.Flag) (*transport.Recording, error) {
+	switch {
+	case flag.Record != "" && flag.Replay != "":
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("--record and --replay are mutually exclusive"),
+			Remediation: "Record the API responses with --record, then run the command again with --replay.",
+		}
+	case flag.Record != "":
+		return &transport.Recording{Dir: flag.Record}, nil
+	case flag.Replay != "":
+		if fi, err := os.Stat(flag.Replay); err != nil || !fi.IsDir() {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("no recording found at '%s'", flag.Replay),
+				Remediation: "Set --replay to a directory of API responses stored by --record.",
+			}
+		}
+		return &transport.Recording{Dir: flag.Replay, Replay: true}, nil
+	}
+	return nil, nil
+}
+
+// newTracer returns the tracer configured by the standard OpenTelemetry env
+// vars, or nil if no OTLP endpoint is set (or tracing is disabled).
+func newTracer(e config.TracingEnvironment) (*tracing.Tracer, error) {
+	endpoint := tracing.Endpoint(e.Endpoint, e.TracesEndpoint)
+	if endpoint == "" || e.Disabled {
+		return nil, nil
+	}
+	if e.Protocol == "grpc" {
+		return nil, fmt.Errorf("%s is grpc, but only OTLP over HTTP is supported (e.g. a collector's receiver on port 4318)", env.OTLPProtocol)
+	}
+	headers, err := tracing.ParseHeaders(e.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", env.OTLPHeaders, err)
+	}
+	return tracing.New(tracing.Config{
+		Endpoint:    endpoint,
+		Headers:     headers,
+		ServiceName: e.ServiceName,
+		Parent:      e.Parent,
+		Version:     revision.SemVer(revision.AppVersion),
+	}), nil
+}
+
+// newLogger returns the logger configured by the --log-file and --log-level
+// flags, and a function that closes the log file. If neither flag is set then
+// the logger is nil, which discards every record.
+func newLogger(flag config.Flag, stderr io.Writer) (*logger.Logger, func(), error) {
+	noop := func() {}
+	if flag.LogFile == "" && flag.LogLevel == "" {
+		return nil, noop, nil
+	}
+
+	level := logger.LevelInfo
+	if flag.LogLevel != "" {
+		var err error
+		if level, err = logger.ParseLevel(flag.LogLevel); err != nil {
+			return nil, noop, err
+		}
+	}
+
+	if flag.LogFile == "" {
+		return logger.New(stderr, level), noop, nil
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as we require a user to configure their own log file.
+	/* #nosec */
+	f, err := os.OpenFile(flag.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, noop, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error opening log file: %w", err),
+			Remediation: "Check the --log-file path is a writable file.",
+		}
+	}
+	return logger.New(f, level), func() { f.Close() }, nil
+}
+
+// selectedCommand returns the model for the named command, if it exists.
+func selectedCommand(app *kingpin.Application, name string) *kingpin.CmdModel {
+	var find func(cmds []*kingpin.CmdModel) *kingpin.CmdModel
+	find = func(cmds []*kingpin.CmdModel) *kingpin.CmdModel {
+		for _, c := range cmds {
+			if c.FullCommand() == name {
+				return c
+			}
+			if m := find(c.Commands); m != nil {
+				return m
+			}
+		}
+		return nil
+	}
+	return find(app.Model().Commands)
+}
+
+// isMachineOutput indicates whether the selected command was asked to render
+// its output for consumption by another program (i.e. its --json or --raw flag
+// was set).
+func isMachineOutput(app *kingpin.Application, name string) bool {
+	m := selectedCommand(app, name)
+	if m == nil {
+		return false
+	}
+	for _, name := range []string{cmd.FlagJSONName, cmd.FlagRawName} {
+		if f := m.FlagByName(name); f != nil && f.Value.String() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSONOutput indicates whether the selected command was asked to render its
+// output as JSON (i.e. its --json flag was set).
+func isJSONOutput(app *kingpin.Application, name string) bool {
+	m := selectedCommand(app, name)
+	if m == nil {
+		return false
+	}
+	f := m.FlagByName(cmd.FlagJSONName)
+	return f != nil && f.Value.String() == "true"
+}
+
+// setJSONFlag sets the selected command's --json flag, reporting whether it
+// has one.
+func setJSONFlag(app *kingpin.Application, name string) bool {
+	m := selectedCommand(app, name)
+	if m == nil {
+		return false
+	}
+	f := m.FlagByName(cmd.FlagJSONName)
+	return f != nil && f.Value.Set("true") == nil
+}
+
+// outputPreset returns the output preset selected by the --preset flag, or nil
+// if there isn't one.
+func outputPreset(g *config.Data, structured bool) (*config.OutputPreset, error) {
+	if g.Flag.Preset == "" {
+		return nil, nil
+	}
+	if structured {
+		return nil, fmt.Errorf("error parsing arguments: the --preset flag is mutually exclusive with the --output flag")
+	}
+	p, ok := g.File.OutputPresets[g.Flag.Preset]
+	if !ok {
+		names := make([]string, 0, len(g.File.OutputPresets))
+		for n := range g.File.OutputPresets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		remediation := "Define the preset in an [output.<name>] section of the config file ('fastly config --location' displays its path)."
+		if len(names) > 0 {
+			remediation = fmt.Sprintf("The available presets are: %s.", strings.Join(names, ", "))
+		}
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("output preset '%s' not found", g.Flag.Preset),
+			Remediation: remediation,
+		}
+	}
+	if err := cmd.ValidatePreset(g.Flag.Preset, p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// validateStdinFlags ensures at most one of the selected command's flags reads
+// its content from stdin, as stdin can only be consumed once.
+func validateStdinFlags(app *kingpin.Application, name string) error {
+	m := selectedCommand(app, name)
+	if m == nil {
+		return nil
+	}
+	var flags []string
+	for _, f := range m.Flags {
+		// A hidden flag is typically the original name of a visible one (e.g.
+		// --file of --batch-file), which shares its value.
+		if f.Hidden {
+			continue
+		}
+		if f.Value.String() == cmd.StdinFlagValue {
+			flags = append(flags, "--"+f.Name)
+		}
+	}
+	if len(flags) > 1 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: only one flag can read from stdin, got: %s", strings.Join(flags, ", ")),
+			Remediation: "Pass the content of all but one of these flags as a file path or value.",
+		}
+	}
+	return nil
+}
+
+// joinStdinArgs joins a standalone "-" argument to the non-boolean flag
+// preceding it (e.g. `--file -` becomes `--file=-`), as kingpin otherwise
+// parses a lone "-" as an empty value. Flags are looked up in the same way as
+// commandIndex, along with those of the commands named so far, and arguments
+// following "--" (including those of a plugin) are left alone.
+func joinStdinArgs(app *kingpin.Application, args []string) []string {
+	model := app.Model()
+	flags := model.Flags
+	commands := model.Commands
+
+	joined := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		var f *kingpin.ClauseModel
+		switch {
+		case a == "--":
+			return append(joined, args[i:]...)
+		case strings.HasPrefix(a, "--"):
+			if !strings.Contains(a, "=") {
+				f = findFlag(flags, strings.TrimPrefix(a, "--"))
+			}
+		case strings.HasPrefix(a, "-") && len(a) > 1:
+			f = valueShortFlag(flags, a[1:])
+		default:
+			if c := findCommand(commands, a); c != nil {
+				flags = append(append([]*kingpin.ClauseModel{}, flags...), c.Flags...)
+				commands = c.Commands
+			}
+		}
+		if f == nil || f.IsBoolFlag() || i+1 == len(args) {
+			joined = append(joined, a)
+			continue
+		}
+
+		// The following argument is the flag's value.
+		i++
+		switch {
+		case args[i] != cmd.StdinFlagValue:
+			joined = append(joined, a, args[i])
+		case strings.HasPrefix(a, "--"):
+			joined = append(joined, a+"="+args[i])
+		default:
+			joined = append(joined, a+args[i])
+		}
+	}
+	return joined
+}
+
+// valueShortFlag returns the flag that takes the argument following the
+// combined short flags (e.g. -vk), or nil if the last of them is boolean or
+// they already include a value (e.g. -kfoo).
+func valueShortFlag(flags []*kingpin.ClauseModel, shorts string) *kingpin.ClauseModel {
+	for i, r := range shorts {
+		f := findShortFlag(flags, r)
+		if f == nil {
+			return nil
+		}
+		if !f.IsBoolFlag() {
+			if i+1 < len(shorts) {
+				return nil
+			}
+			return f
+		}
+	}
+	return nil
+}
+
+// findCommand returns the model of the named command (or alias) in cmds.
+func findCommand(cmds []*kingpin.CmdModel, name string) *kingpin.CmdModel {
+	for _, c := range cmds {
+		if c.Name == name {
+			return c
+		}
+		for _, alias := range c.Aliases {
+			if alias == name {
+				return c
+			}
+		}
+	}
+	return nil
 }
 
 // displayTokenSource prints the token source.
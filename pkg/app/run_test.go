@@ -4,13 +4,23 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/transport"
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 func TestApplication(t *testing.T) {
@@ -103,29 +113,53 @@ complete -F _fastly_bash_autocomplete fastly
 			Name: "shell evaluate completion options",
 			Args: args("--completion-bash"),
 			WantOutput: `help
+ab-test
+account
 acl
 acl-entry
+analyze
+api
 auth-token
 backend
+commands
+completion
 compute
+init
 config
+curl
+dashboard
 dictionary
 dictionary-item
 domain
+errors
+flags
+generate
+gzip
 healthcheck
 ip-list
+limits
 log-tail
 logging
+maintenance
+monitor
+origin
 pops
 profile
 purge
+rate-limit
+route
+scan
+schedule
+search
 service
 service-version
 stats
+support-bundle
 update
 user
 vcl
 version
+waf
 whoami
 `,
 		},
@@ -167,6 +201,662 @@ whoami
 	}
 }
 
+func TestVerboseJSON(t *testing.T) {
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			return []*fastly.Backend{
+				{
+					ServiceID:      i.ServiceID,
+					ServiceVersion: i.ServiceVersion,
+					Name:           "test.com",
+				},
+			}, nil
+		},
+	}
+
+	var stdout, stderr bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("backend list --service-id 123 --version 1 --json -vv"), &stdout)
+	opts.APIClient = mock.APIClient(api)
+	opts.Stderr = &stderr
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	// The structured output should be unaffected by the verbose flag, with the
+	// diagnostics written to the separate stream.
+	testutil.AssertBool(t, true, strings.HasPrefix(stdout.String(), `[{"ServiceID":"123"`))
+	testutil.AssertStringDoesntContain(t, stdout.String(), "Fastly API endpoint")
+	testutil.AssertStringContains(t, stderr.String(), "Fastly API endpoint: https://api.fastly.com")
+}
+
+func TestReadOnly(t *testing.T) {
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			return []*fastly.Backend{}, nil
+		},
+	}
+	readOnlyProfile := config.File{
+		Profiles: config.Profiles{
+			"auditor": &config.Profile{Default: true, ReadOnly: true, Token: "123"},
+		},
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		args      []string
+		file      config.File
+		wantError string
+	}{
+		{
+			name:      "--read-only flag refuses a mutating command",
+			args:      testutil.Args("service create --name foo --read-only --token 123"),
+			wantError: "read-only mode: refusing to make changes to the Fastly account: 'fastly service create' modifies the Fastly account",
+		},
+		{
+			name:      "read_only profile refuses a mutating command",
+			args:      testutil.Args("backend delete --name foo --service-id 123 --version 1"),
+			file:      readOnlyProfile,
+			wantError: "'fastly backend delete' modifies the Fastly account",
+		},
+		{
+			name:      "read_only profile refuses a command that declares it's mutating",
+			args:      testutil.Args("origin switch --from a --to b --service-id 123"),
+			file:      readOnlyProfile,
+			wantError: "'fastly origin switch' modifies the Fastly account",
+		},
+		{
+			name:      "read_only profile refuses an API request that isn't a read",
+			args:      testutil.Args("api POST /service"),
+			file:      readOnlyProfile,
+			wantError: "'fastly api' modifies the Fastly account",
+		},
+		{
+			name: "read_only profile allows a read command",
+			args: testutil.Args("backend list --service-id 123 --version 1"),
+			file: readOnlyProfile,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			opts.ConfigFile = testcase.file
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
+
+func TestServiceConfig(t *testing.T) {
+	var (
+		token   string
+		version int
+	)
+	client := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			version = i.ServiceVersion
+			return []*fastly.Backend{{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: "origin"}}, nil
+		},
+		DeleteBackendFn: func(i *fastly.DeleteBackendInput) error {
+			return nil
+		},
+	}
+	file := config.File{
+		Profiles: config.Profiles{
+			"default": &config.Profile{Default: true, Token: "default-token"},
+			"team":    &config.Profile{Token: "team-token"},
+		},
+		Services: config.Services{
+			"123": &config.Service{Version: "active", Output: "json", Profile: "team"},
+			"456": &config.Service{Protected: true},
+			"789": &config.Service{Output: "xml"},
+		},
+	}
+
+	for _, testcase := range []struct {
+		name        string
+		args        string
+		wantError   string
+		wantOutput  string
+		wantToken   string
+		wantVersion int
+	}{
+		{
+			name:        "settings are applied to the service's commands",
+			args:        "backend list --service-id 123",
+			wantOutput:  `"Name": "origin"`,
+			wantToken:   "team-token",
+			wantVersion: 1,
+		},
+		{
+			name:        "flags override the settings",
+			args:        "backend list --service-id 123 --version 3 --output table --profile default",
+			wantOutput:  "origin",
+			wantToken:   "default-token",
+			wantVersion: 3,
+		},
+		{
+			name:      "settings aren't applied to other services",
+			args:      "backend list --service-id 456",
+			wantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			name:      "a protected service can't be modified without confirmation",
+			args:      "backend delete --name origin --service-id 456 --version 3 --non-interactive",
+			wantError: "service 456 is protected, so modifying it requires confirmation",
+		},
+		{
+			name:      "a protected service can't be switched to another origin without confirmation",
+			args:      "origin switch --from origin --to other --service-id 456 --non-interactive",
+			wantError: "service 456 is protected, so modifying it requires confirmation",
+		},
+		{
+			name:      "a protected service can be modified with --confirm-destructive",
+			args:      "backend delete --name origin --service-id 456 --version 3 --confirm-destructive",
+			wantToken: "default-token",
+		},
+		{
+			name:        "a protected service can be read",
+			args:        "backend list --service-id 456 --version 3 --non-interactive",
+			wantToken:   "default-token",
+			wantVersion: 3,
+		},
+		{
+			name:      "invalid settings are reported",
+			args:      "backend list --service-id 789 --version 1",
+			wantError: "invalid output 'xml' for service 789 in the config file",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			token, version = "", 0
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args), &stdout)
+			opts.APIClient = func(tok, endpoint string) (api.Interface, error) {
+				token = tok
+				return client, nil
+			}
+			opts.ConfigFile = file
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			testutil.AssertString(t, testcase.wantToken, token)
+			testutil.AssertEqual(t, testcase.wantVersion, version)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			return []*fastly.Backend{}, nil
+		},
+		DeleteBackendFn: func(i *fastly.DeleteBackendInput) error {
+			return nil
+		},
+	}
+	production := config.File{
+		Profiles: config.Profiles{
+			"production": &config.Profile{
+				Default:    true,
+				Token:      "123",
+				Middleware: map[string]bool{"confirm": true, "timing": true},
+			},
+		},
+	}
+
+	for _, testcase := range []struct {
+		name       string
+		args       []string
+		file       config.File
+		stdin      string
+		wantError  string
+		wantStderr string
+	}{
+		{
+			name:  "disabled middleware doesn't run",
+			args:  testutil.Args("backend delete --name foo --service-id 123 --version 3 --token 123"),
+			stdin: "n",
+		},
+		{
+			name:       "profile enables confirmation of a mutating command",
+			args:       testutil.Args("backend delete --name foo --service-id 123 --version 3"),
+			file:       production,
+			stdin:      "n",
+			wantError:  "operation cancelled by user",
+			wantStderr: "'fastly backend delete' took ",
+		},
+		{
+			name:       "confirmed mutating command runs",
+			args:       testutil.Args("backend delete --name foo --service-id 123 --version 3"),
+			file:       production,
+			stdin:      "y",
+			wantStderr: "'fastly backend delete' took ",
+		},
+		{
+			name:       "read command isn't confirmed",
+			args:       testutil.Args("backend list --service-id 123 --version 1"),
+			file:       production,
+			wantStderr: "'fastly backend list' took ",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			opts.ConfigFile = testcase.file
+			opts.Stdin = strings.NewReader(testcase.stdin)
+			opts.Stderr = &stderr
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stderr.String(), testcase.wantStderr)
+			if testcase.wantStderr == "" {
+				testutil.AssertString(t, "", stderr.String())
+			}
+		})
+	}
+}
+
+func TestTimings(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := testutil.Args("service-version list --service-id 123 --token 123 --timings --endpoint " + ts.URL)
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	opts.Stderr = &stderr
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stderr.String(), "Timings for 'fastly service-version list':")
+	testutil.AssertStringContains(t, stderr.String(), "API requests:     1 (0 failed, 0 retries)")
+	testutil.AssertStringContains(t, stderr.String(), "GET /service/123/version (200)")
+
+	// Without --timings nothing is displayed.
+	stderr.Reset()
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	opts.Stderr = &stderr
+	err = app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "", stderr.String())
+}
+
+func TestAPIEndpoint(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --api-endpoint "+ts.URL+"/"), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string{"/service/123/version"}, paths)
+
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --api-endpoint localhost:8080"), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	err = app.Run(opts)
+	testutil.AssertErrorContains(t, err, "invalid API endpoint 'localhost:8080'")
+}
+
+func TestRecordReplay(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	var recorded, replayed bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --record "+dir+" --api-endpoint "+ts.URL), &recorded)
+	opts.APIClient = app.FastlyAPIClient
+	testutil.AssertNoError(t, app.Run(opts))
+
+	// The endpoint isn't reachable, so the response must be replayed.
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token abc --replay "+dir+" --api-endpoint http://127.0.0.1:1"), &replayed)
+	opts.APIClient = app.FastlyAPIClient
+	testutil.AssertNoError(t, app.Run(opts))
+	testutil.AssertEqual(t, 1, calls)
+	testutil.AssertString(t, recorded.String(), replayed.String())
+
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 456 --token abc --replay "+dir+" --api-endpoint http://127.0.0.1:1"), &replayed)
+	opts.APIClient = app.FastlyAPIClient
+	testutil.AssertErrorContains(t, app.Run(opts), "no recorded response to GET /service/456/version")
+
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token abc --record "+dir+" --replay "+dir), &replayed)
+	testutil.AssertErrorContains(t, app.Run(opts), "--record and --replay are mutually exclusive")
+
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token abc --replay "+filepath.Join(dir, "missing")), &replayed)
+	testutil.AssertErrorContains(t, app.Run(opts), "no recording found at")
+}
+
+func TestTracing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	var exported []string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		exported = append(exported, r.URL.Path+" "+string(b))
+	}))
+	defer collector.Close()
+
+	var stdout, stderr bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --api-endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	opts.Env.Tracing = config.TracingEnvironment{Endpoint: collector.URL}
+	testutil.AssertNoError(t, app.Run(opts))
+	testutil.AssertEqual(t, 1, len(exported))
+	testutil.AssertStringContains(t, exported[0], "/v1/traces ")
+	testutil.AssertStringContains(t, exported[0], `"name":"fastly service-version list"`)
+	testutil.AssertStringContains(t, exported[0], `"name":"HTTP GET"`)
+
+	// An unsupported configuration disables tracing rather than failing.
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --api-endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	opts.Stderr = &stderr
+	opts.Env.Tracing = config.TracingEnvironment{Endpoint: collector.URL, Protocol: "grpc"}
+	testutil.AssertNoError(t, app.Run(opts))
+	testutil.AssertEqual(t, 1, len(exported))
+	testutil.AssertStringContains(t, stderr.String(), "OpenTelemetry tracing is disabled: OTEL_EXPORTER_OTLP_PROTOCOL is grpc")
+}
+
+func TestRateLimitVerbose(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(transport.RateLimitRemainingHeader, "997")
+		w.Header().Set(transport.RateLimitResetHeader, "946688400")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --utc --iso8601 --deterministic --verbose --api-endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	testutil.AssertNoError(t, app.Run(opts))
+	testutil.AssertStringContains(t, stdout.String(), "API rate limit: 997 requests remaining, reset at 2000-01-01T01:00:00Z (in 1h0m0s).")
+
+	stdout.Reset()
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --api-endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	testutil.AssertNoError(t, app.Run(opts))
+	testutil.AssertStringDoesntContain(t, stdout.String(), "API rate limit")
+}
+
+func TestDebugHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	var stdout, stderr bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token my-api-token --debug-http --endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	opts.Stderr = &stderr
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stderr.String(), "[api] GET /service/123/version -> 200 OK")
+	testutil.AssertStringContains(t, stderr.String(), "--- request ---")
+	testutil.AssertStringContains(t, stderr.String(), "Fastly-Key: REDACTED")
+	testutil.AssertStringContains(t, stderr.String(), `"service_id":"123"`)
+	testutil.AssertStringDoesntContain(t, stderr.String(), "my-api-token")
+	testutil.AssertStringDoesntContain(t, stdout.String(), "--- request ---")
+}
+
+func TestOutput(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			io.WriteString(w, `{"number":1,"service_id":"123","locked":true}`)
+			return
+		}
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true}]`)
+	}))
+	defer ts.Close()
+
+	for _, testcase := range []struct {
+		args string
+		want string
+	}{
+		// The command's own --json output, rendered as YAML.
+		{
+			args: "service-version list --service-id 123 --output yaml",
+			want: "- Number: 1\n  Comment: \"\"\n  ServiceID: \"123\"\n  Active: true\n  Locked: false\n  Deployed: false\n" +
+				"  Staging: false\n  Testing: false\n  CreatedAt: null\n  UpdatedAt: null\n  DeletedAt: null\n",
+		},
+		// A command without --json renders the response of its last API request.
+		{
+			args: "service-version lock --service-id 123 --version 1 --output json",
+			want: "{\n  \"number\": 1,\n  \"service_id\": \"123\",\n  \"locked\": true\n}\n",
+		},
+	} {
+		t.Run(testcase.args, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args+" --token 123 --endpoint "+ts.URL), &stdout)
+			opts.APIClient = app.FastlyAPIClient
+			err := app.Run(opts)
+			testutil.AssertNoError(t, err)
+			testutil.AssertString(t, testcase.want, stdout.String())
+		})
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	client := mock.API{
+		ListVersionsFn: func(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+			return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	for _, testcase := range []struct {
+		args     string
+		wantJSON bool
+	}{
+		{args: "service-version list --service-id 123", wantJSON: false},
+		{args: "service-version list --service-id 123 --json", wantJSON: true},
+		{args: "service-version list --service-id 123 --output json", wantJSON: true},
+		{args: "service-version list --service-id 123 --output yaml", wantJSON: false},
+	} {
+		t.Run(testcase.args, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args+" --token 123"), &stdout)
+			opts.APIClient = mock.APIClient(client)
+			err := app.Run(opts)
+			_, ok := err.(errors.JSONError)
+			testutil.AssertEqual(t, testcase.wantJSON, ok)
+			testutil.AssertEqual(t, errors.ExitNotFound, errors.ExitCode(err))
+		})
+	}
+}
+
+func TestPreset(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":false,"comment":"first"},{"number":2,"service_id":"123","active":true,"comment":"second"}]`)
+	}))
+	defer ts.Close()
+
+	for _, testcase := range []struct {
+		args      string
+		want      string
+		wantError string
+	}{
+		{
+			args: "service-version list --service-id 123 --preset versions",
+			want: "NUMBER  ACTIVE  COMMENT\n2       true    second\n1       false   first\n",
+		},
+		{
+			args:      "service-version list --service-id 123 --preset nope",
+			wantError: "output preset 'nope' not found",
+		},
+		{
+			args:      "service-version list --service-id 123 --preset versions --output json",
+			wantError: "the --preset flag is mutually exclusive with the --output flag",
+		},
+	} {
+		t.Run(testcase.args, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args+" --token 123 --endpoint "+ts.URL), &stdout)
+			opts.APIClient = app.FastlyAPIClient
+			opts.ConfigFile.OutputPresets = config.OutputPresets{
+				"versions": {Columns: []string{"number", "active", "comment"}, Sort: "-number"},
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.want, stdout.String())
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true}]`)
+	}))
+	defer ts.Close()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version lock --service-id 123 --version 1 --dry-run --token 123 --endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string{http.MethodGet}, methods)
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: PUT /service/123/version/1/lock\n")
+	testutil.AssertStringContains(t, stdout.String(), "Dry run: 1 API requests were not sent, so the Fastly account wasn't modified.")
+}
+
+func TestProxy(t *testing.T) {
+	var proxied []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"number":1,"service_id":"123","active":true,"updated_at":"2021-06-15T23:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --endpoint http://api.example.com --proxy "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string{"http://api.example.com/service/123/version"}, proxied)
+
+	opts = testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --proxy api.example.com"), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	err = app.Run(opts)
+	testutil.AssertErrorContains(t, err, "invalid proxy URL 'api.example.com'")
+}
+
+func TestAPITimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(1500 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version list --service-id 123 --token 123 --api-timeout 1 --retry 0 --endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "Client.Timeout exceeded")
+	testutil.AssertString(t, errors.TimeoutRemediation, errors.Deduce(err).Remediation)
+}
+
+func TestPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are shell scripts")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"args: $*\"\necho \"token: $FASTLY_API_TOKEN\"\necho \"flag: $FASTLY_CLI_FLAG_NON_INTERACTIVE\"\n"
+	for _, name := range []string{"fastly-hello", "fastly-completion"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("--non-interactive --token 123 hello --json -v a"), &stdout)
+	opts.Env.Path = dir
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "args: --json -v a\ntoken: 123\nflag: true\n", stdout.String())
+
+	// A plugin's arguments are passed on as given, even if they look like a
+	// flag reading from stdin.
+	stdout.Reset()
+	opts = testutil.NewRunOpts(testutil.Args("hello --file - -j -"), &stdout)
+	opts.Env.Path = dir
+	err = app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "args: --file - -j -\n")
+
+	// A plugin can't replace a built-in command.
+	stdout.Reset()
+	opts = testutil.NewRunOpts(testutil.Args("completion bash"), &stdout)
+	opts.Env.Path = dir
+	err = app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "--completion-bash")
+}
+
+func TestAliases(t *testing.T) {
+	aliases := config.Aliases{
+		"bash-completion": "completion 'bash'",
+		"comp":            "completion",
+		"broken":          "completion 'bash",
+		// An alias can't replace a built-in command.
+		"completion": "comp zsh",
+	}
+	for _, testcase := range []struct {
+		args       string
+		wantOutput string
+		wantError  string
+	}{
+		{
+			args:       "bash-completion",
+			wantOutput: "--completion-bash",
+		},
+		{
+			args:       "-v comp bash",
+			wantOutput: "--completion-bash",
+		},
+		{
+			args:       "completion zsh",
+			wantOutput: "#compdef fastly",
+		},
+		{
+			args:      "broken",
+			wantError: "error parsing alias 'broken': unterminated ' quote",
+		},
+	} {
+		t.Run(testcase.args, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args), &stdout)
+			opts.ConfigFile.Aliases = aliases
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
 // stripTrailingSpace removes any trailing spaces from the multiline str.
 func stripTrailingSpace(str string) string {
 	buf := bytes.NewBuffer(nil)
@@ -186,46 +876,164 @@ USAGE
 A tool to interact with the Fastly API
 
 GLOBAL FLAGS
-      --help             Show context-sensitive help.
-  -d, --accept-defaults  Accept default options for all interactive prompts
-                         apart from Yes/No confirmations
-  -y, --auto-yes         Answer yes automatically to all Yes/No confirmations.
-                         This may suppress security warnings
-  -i, --non-interactive  Do not prompt for user input - suitable for CI
-                         processes. Equivalent to --accept-defaults and
-                         --auto-yes
-  -o, --profile=PROFILE  Switch account profile for single command execution
-                         (see also: 'fastly profile switch')
-  -t, --token=TOKEN      Fastly API token (or via FASTLY_API_TOKEN)
-  -v, --verbose          Verbose logging
+      --help                     Show context-sensitive help.
+  -d, --accept-defaults          Accept default options for all interactive
+                                 prompts apart from Yes/No confirmations
+      --api-endpoint=API-ENDPOINT
+                                 Fastly API endpoint, e.g. of a staging
+                                 environment or a local mock (or via
+                                 FASTLY_API_ENDPOINT, or the profile's
+                                 api_endpoint setting)
+      --api-timeout=API-TIMEOUT  Timeout, in seconds, for each API request
+                                 (default: no timeout, or the config file's
+                                 [network] api_timeout setting)
+  -y, --auto-yes                 Answer yes automatically to informational
+                                 Yes/No confirmations. This may suppress
+                                 security warnings
+      --ca-file=CA-FILE          Path of a PEM file of root certificates
+                                 to trust in addition to the system's when
+                                 connecting to the API, e.g. those of a
+                                 TLS-intercepting proxy (or via the config
+                                 file's [network] ca_file setting)
+      --confirm-destructive      Answer yes automatically to Yes/No
+                                 confirmations for destructive operations
+      --confirm-irreversible     Answer yes automatically to all Yes/No
+                                 confirmations, including irreversible
+                                 operations (e.g. purge all, service delete)
+      --debug-http               Write every HTTP request and response the CLI
+                                 makes (method, URL, headers, bodies, status and
+                                 timing) to stderr, with credentials redacted
+      --deterministic            Use a fixed clock and sequential IDs,
+                                 so time-dependent output (e.g. timestamps and
+                                 generated names) is reproducible
+      --dry-run                  Display the API requests that would modify the
+                                 Fastly account (method, path and body) instead
+                                 of sending them
+      --human-sizes              Display byte quantities using binary units
+                                 (e.g. 1.5 GiB)
+      --iso8601                  Display timestamps in ISO 8601 (RFC 3339)
+                                 format
+      --log-file=LOG-FILE        Append structured (JSON) logs of the CLI's
+                                 internals to this file, e.g. for diagnosing
+                                 automation
+      --log-level=LOG-LEVEL      Minimum level of the structured logs: debug,
+                                 info, warn, error (default: info). Without
+                                 --log-file, logs are written to stderr
+      --no-cache                 Don't use or update the on-disk cache of
+                                 read-only API responses (enabled by the config
+                                 file's [cache] ttl setting)
+  -i, --non-interactive          Do not prompt for user input - suitable
+                                 for CI processes (also enabled by the
+                                 FASTLY_NONINTERACTIVE env var). Equivalent to
+                                 --accept-defaults and --auto-yes, and prompts
+                                 that require input fail instead
+      --output=OUTPUT            Render the output of any command as json or
+                                 yaml (default: table, the regular output).
+                                 Commands without a --json flag render the
+                                 response of their last API request
+      --preset=PRESET            Render the output of a command as a table using
+                                 a named output preset, defined in the config
+                                 file's [output.<name>] section (columns and
+                                 sort)
+  -o, --profile=PROFILE          Switch account profile for single command
+                                 execution (see also: 'fastly profile switch')
+      --proxy=PROXY              URL of the proxy to send API requests via (or
+                                 via the config file's [network] proxy setting,
+                                 otherwise the HTTPS_PROXY env var)
+      --read-only                Refuse to run commands that modify the Fastly
+                                 account (also enabled by a profile's read_only
+                                 setting)
+      --record=RECORD            Store the response to every API request in this
+                                 directory, so that the command can be run again
+                                 offline with --replay
+      --replay=REPLAY            Serve the API responses stored in this
+                                 directory by --record instead of sending the
+                                 requests, e.g. to test scripts without the live
+                                 API (any --token is accepted)
+      --retry=RETRY              Maximum number of times an API request that's
+                                 rate limited or fails with a transient server
+                                 error is retried, with backoff (default: 3,
+                                 or the config file's [retry] setting). Set to 0
+                                 to disable retries
+      --timings                  After the command finishes, display a summary
+                                 of the API requests it made (count, latency,
+                                 retries and payload sizes)
+  -t, --token=TOKEN              Fastly API token (or via FASTLY_API_TOKEN)
+      --utc                      Display timestamps in UTC
+  -v, --verbose ...              Verbose logging (repeat for more detail:
+                                 -vv API timings, -vvv HTTP traces)
 
 COMMANDS
   help             Show help.
+  ab-test          A/B test VCL snippets by splitting a service's clients
+                   between two variants
+  account          Inspect the settings of a Fastly account
   acl              Manipulate Fastly ACLs (Access Control Lists)
   acl-entry        Manipulate Fastly ACL (Access Control List) entries
+  analyze          Analyze a Fastly service and suggest changes to its
+                   configuration
+  api              Make an authenticated request to the Fastly API and display
+                   the raw response
   auth-token       Manage API tokens for Fastly service users
   backend          Manipulate Fastly service version backends
+  commands         List all available commands
+  completion       Output a shell completion script for the Fastly CLI, e.g.
+                   'source <(fastly completion bash)'
   compute          Manage Compute@Edge packages
+  init             Create a new Fastly service, guided by prompts: a VCL service
+                   with its domain and backend, or a Compute@Edge project
   config           Display the Fastly CLI configuration
+  curl             Make an HTTP request through Fastly and display the cache
+                   diagnostics of the response
+  dashboard        Browse services, realtime traffic and recent events in an
+                   interactive terminal dashboard
   dictionary       Manipulate Fastly edge dictionaries
   dictionary-item  Manipulate Fastly edge dictionary items
   domain           Manipulate Fastly service version domains
+  errors           Browse the errors logged by past runs of the CLI
+  flags            Manage feature flags stored in an edge dictionary
+  generate         Generate content from Fastly service configuration
+  gzip             Manipulate Fastly service version gzip (compression)
+                   configuration
   healthcheck      Manipulate Fastly service version healthchecks
   ip-list          List Fastly's public IPs
+  limits           Check a Fastly service against its resource limits
   log-tail         Tail Compute@Edge logs
   logging          Manipulate Fastly service version logging endpoints
+  maintenance      Serve a maintenance page in place of a Fastly service's
+                   content
+  monitor          Generate external monitoring for a Fastly service
+  origin           Change which backends serve a Fastly service's requests
   pops             List Fastly datacenters
   profile          Manage user profiles
   purge            Invalidate objects in the Fastly cache
+  rate-limit       Show the last observed API rate limit of the API token
+  route            Inspect how a Fastly service routes requests
+  scan             Check a site served by Fastly for common problems
+  schedule         Run CLI commands on a cron schedule
+  search           Search service configuration across the account
   service          Manipulate Fastly services
   service-version  Manipulate Fastly service versions
   stats            View historical and realtime statistics for a Fastly service
+  support-bundle   Generate an archive of diagnostic information for Fastly
+                   support
   update           Update the CLI to the latest version
   user             Manipulate users of the Fastly API and web interface
   vcl              Manipulate Fastly service version VCL
   version          Display version information for the Fastly CLI
+  waf              Manipulate Fastly legacy Web Application Firewalls (WAF)
   whoami           Get information about the currently authenticated account
 
+EXIT CODES
+  0    Success
+  1    Error without a more specific exit code
+  2    Invalid arguments or input
+  3    Missing, invalid or unauthorized API token
+  4    Resource not found
+  5    API error (5xx) or API unreachable; may succeed if retried
+  6    Local file or directory couldn't be read or written
+  130  Interrupted
+
 SEE ALSO
   https://developer.fastly.com/reference/cli/
 `) + "\n\n"
@@ -235,21 +1043,107 @@ USAGE
   fastly [<flags>] service
 
 GLOBAL FLAGS
-      --help             Show context-sensitive help.
-  -d, --accept-defaults  Accept default options for all interactive prompts
-                         apart from Yes/No confirmations
-  -y, --auto-yes         Answer yes automatically to all Yes/No confirmations.
-                         This may suppress security warnings
-  -i, --non-interactive  Do not prompt for user input - suitable for CI
-                         processes. Equivalent to --accept-defaults and
-                         --auto-yes
-  -o, --profile=PROFILE  Switch account profile for single command execution
-                         (see also: 'fastly profile switch')
-  -t, --token=TOKEN      Fastly API token (or via FASTLY_API_TOKEN)
-  -v, --verbose          Verbose logging
+      --help                     Show context-sensitive help.
+  -d, --accept-defaults          Accept default options for all interactive
+                                 prompts apart from Yes/No confirmations
+      --api-endpoint=API-ENDPOINT
+                                 Fastly API endpoint, e.g. of a staging
+                                 environment or a local mock (or via
+                                 FASTLY_API_ENDPOINT, or the profile's
+                                 api_endpoint setting)
+      --api-timeout=API-TIMEOUT  Timeout, in seconds, for each API request
+                                 (default: no timeout, or the config file's
+                                 [network] api_timeout setting)
+  -y, --auto-yes                 Answer yes automatically to informational
+                                 Yes/No confirmations. This may suppress
+                                 security warnings
+      --ca-file=CA-FILE          Path of a PEM file of root certificates
+                                 to trust in addition to the system's when
+                                 connecting to the API, e.g. those of a
+                                 TLS-intercepting proxy (or via the config
+                                 file's [network] ca_file setting)
+      --confirm-destructive      Answer yes automatically to Yes/No
+                                 confirmations for destructive operations
+      --confirm-irreversible     Answer yes automatically to all Yes/No
+                                 confirmations, including irreversible
+                                 operations (e.g. purge all, service delete)
+      --debug-http               Write every HTTP request and response the CLI
+                                 makes (method, URL, headers, bodies, status and
+                                 timing) to stderr, with credentials redacted
+      --deterministic            Use a fixed clock and sequential IDs,
+                                 so time-dependent output (e.g. timestamps and
+                                 generated names) is reproducible
+      --dry-run                  Display the API requests that would modify the
+                                 Fastly account (method, path and body) instead
+                                 of sending them
+      --human-sizes              Display byte quantities using binary units
+                                 (e.g. 1.5 GiB)
+      --iso8601                  Display timestamps in ISO 8601 (RFC 3339)
+                                 format
+      --log-file=LOG-FILE        Append structured (JSON) logs of the CLI's
+                                 internals to this file, e.g. for diagnosing
+                                 automation
+      --log-level=LOG-LEVEL      Minimum level of the structured logs: debug,
+                                 info, warn, error (default: info). Without
+                                 --log-file, logs are written to stderr
+      --no-cache                 Don't use or update the on-disk cache of
+                                 read-only API responses (enabled by the config
+                                 file's [cache] ttl setting)
+  -i, --non-interactive          Do not prompt for user input - suitable
+                                 for CI processes (also enabled by the
+                                 FASTLY_NONINTERACTIVE env var). Equivalent to
+                                 --accept-defaults and --auto-yes, and prompts
+                                 that require input fail instead
+      --output=OUTPUT            Render the output of any command as json or
+                                 yaml (default: table, the regular output).
+                                 Commands without a --json flag render the
+                                 response of their last API request
+      --preset=PRESET            Render the output of a command as a table using
+                                 a named output preset, defined in the config
+                                 file's [output.<name>] section (columns and
+                                 sort)
+  -o, --profile=PROFILE          Switch account profile for single command
+                                 execution (see also: 'fastly profile switch')
+      --proxy=PROXY              URL of the proxy to send API requests via (or
+                                 via the config file's [network] proxy setting,
+                                 otherwise the HTTPS_PROXY env var)
+      --read-only                Refuse to run commands that modify the Fastly
+                                 account (also enabled by a profile's read_only
+                                 setting)
+      --record=RECORD            Store the response to every API request in this
+                                 directory, so that the command can be run again
+                                 offline with --replay
+      --replay=REPLAY            Serve the API responses stored in this
+                                 directory by --record instead of sending the
+                                 requests, e.g. to test scripts without the live
+                                 API (any --token is accepted)
+      --retry=RETRY              Maximum number of times an API request that's
+                                 rate limited or fails with a transient server
+                                 error is retried, with backoff (default: 3,
+                                 or the config file's [retry] setting). Set to 0
+                                 to disable retries
+      --timings                  After the command finishes, display a summary
+                                 of the API requests it made (count, latency,
+                                 retries and payload sizes)
+  -t, --token=TOKEN              Fastly API token (or via FASTLY_API_TOKEN)
+      --utc                      Display timestamps in UTC
+  -v, --verbose ...              Verbose logging (repeat for more detail:
+                                 -vv API timings, -vvv HTTP traces)
 
 SUBCOMMANDS
 
+  service activity [<flags>]
+    Summarise recent activations, configuration changes and traffic for a Fastly
+    service
+
+        --all-services           Report on every service in the account
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --since="7d"             How far back to report, e.g. 7d, 2w or 12h
+
   service create --name=NAME [<flags>]
     Create a Fastly service
 
@@ -279,17 +1173,34 @@ SUBCOMMANDS
   service list [<flags>]
     List Fastly services
 
-        --direction=ascend   Direction in which to sort results
-    -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
-        --per-page=PER-PAGE  Number of records per page
-        --sort="created"     Field on which to sort
+        --direction=ascend     Direction in which to sort results
+    -j, --json                 Render output as JSON
+        --max-items=MAX-ITEMS  Maximum number of services to list
+        --page=PAGE            Page number of data set to fetch
+        --per-page=PER-PAGE    Number of records per page
+        --sort="created"       Field on which to sort
 
   service search --name=NAME
     Search for a Fastly service by name
 
     -n, --name=NAME  Service name
 
+  service smoke-test --version=VERSION --spec=SPEC [<flags>]
+    Check the responses of a service version's domains against the assertions of
+    a YAML spec file
+
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version
+        --spec=SPEC              Path to the YAML file of tests to run
+        --wait=WAIT              Retry failing tests for up to this long, e.g.
+                                 while a version propagates (e.g. 2m)
+        --rollback-on-failure    If any test fails and the version is active,
+                                 activate the previously activated version
+
   service update [<flags>]
     Update a Fastly service
 
@@ -302,7 +1213,6 @@ SUBCOMMANDS
 
 SEE ALSO
   https://developer.fastly.com/reference/cli/service/
-
 `) + "\n\n"
 
 var fullFatHelpDefault = strings.TrimSpace(`
@@ -312,24 +1222,155 @@ USAGE
 A tool to interact with the Fastly API
 
 GLOBAL FLAGS
-      --help             Show context-sensitive help.
-  -d, --accept-defaults  Accept default options for all interactive prompts
-                         apart from Yes/No confirmations
-  -y, --auto-yes         Answer yes automatically to all Yes/No confirmations.
-                         This may suppress security warnings
-  -i, --non-interactive  Do not prompt for user input - suitable for CI
-                         processes. Equivalent to --accept-defaults and
-                         --auto-yes
-  -o, --profile=PROFILE  Switch account profile for single command execution
-                         (see also: 'fastly profile switch')
-  -t, --token=TOKEN      Fastly API token (or via FASTLY_API_TOKEN)
-  -v, --verbose          Verbose logging
+      --help                     Show context-sensitive help.
+  -d, --accept-defaults          Accept default options for all interactive
+                                 prompts apart from Yes/No confirmations
+      --api-endpoint=API-ENDPOINT
+                                 Fastly API endpoint, e.g. of a staging
+                                 environment or a local mock (or via
+                                 FASTLY_API_ENDPOINT, or the profile's
+                                 api_endpoint setting)
+      --api-timeout=API-TIMEOUT  Timeout, in seconds, for each API request
+                                 (default: no timeout, or the config file's
+                                 [network] api_timeout setting)
+  -y, --auto-yes                 Answer yes automatically to informational
+                                 Yes/No confirmations. This may suppress
+                                 security warnings
+      --ca-file=CA-FILE          Path of a PEM file of root certificates
+                                 to trust in addition to the system's when
+                                 connecting to the API, e.g. those of a
+                                 TLS-intercepting proxy (or via the config
+                                 file's [network] ca_file setting)
+      --confirm-destructive      Answer yes automatically to Yes/No
+                                 confirmations for destructive operations
+      --confirm-irreversible     Answer yes automatically to all Yes/No
+                                 confirmations, including irreversible
+                                 operations (e.g. purge all, service delete)
+      --debug-http               Write every HTTP request and response the CLI
+                                 makes (method, URL, headers, bodies, status and
+                                 timing) to stderr, with credentials redacted
+      --deterministic            Use a fixed clock and sequential IDs,
+                                 so time-dependent output (e.g. timestamps and
+                                 generated names) is reproducible
+      --dry-run                  Display the API requests that would modify the
+                                 Fastly account (method, path and body) instead
+                                 of sending them
+      --human-sizes              Display byte quantities using binary units
+                                 (e.g. 1.5 GiB)
+      --iso8601                  Display timestamps in ISO 8601 (RFC 3339)
+                                 format
+      --log-file=LOG-FILE        Append structured (JSON) logs of the CLI's
+                                 internals to this file, e.g. for diagnosing
+                                 automation
+      --log-level=LOG-LEVEL      Minimum level of the structured logs: debug,
+                                 info, warn, error (default: info). Without
+                                 --log-file, logs are written to stderr
+      --no-cache                 Don't use or update the on-disk cache of
+                                 read-only API responses (enabled by the config
+                                 file's [cache] ttl setting)
+  -i, --non-interactive          Do not prompt for user input - suitable
+                                 for CI processes (also enabled by the
+                                 FASTLY_NONINTERACTIVE env var). Equivalent to
+                                 --accept-defaults and --auto-yes, and prompts
+                                 that require input fail instead
+      --output=OUTPUT            Render the output of any command as json or
+                                 yaml (default: table, the regular output).
+                                 Commands without a --json flag render the
+                                 response of their last API request
+      --preset=PRESET            Render the output of a command as a table using
+                                 a named output preset, defined in the config
+                                 file's [output.<name>] section (columns and
+                                 sort)
+  -o, --profile=PROFILE          Switch account profile for single command
+                                 execution (see also: 'fastly profile switch')
+      --proxy=PROXY              URL of the proxy to send API requests via (or
+                                 via the config file's [network] proxy setting,
+                                 otherwise the HTTPS_PROXY env var)
+      --read-only                Refuse to run commands that modify the Fastly
+                                 account (also enabled by a profile's read_only
+                                 setting)
+      --record=RECORD            Store the response to every API request in this
+                                 directory, so that the command can be run again
+                                 offline with --replay
+      --replay=REPLAY            Serve the API responses stored in this
+                                 directory by --record instead of sending the
+                                 requests, e.g. to test scripts without the live
+                                 API (any --token is accepted)
+      --retry=RETRY              Maximum number of times an API request that's
+                                 rate limited or fails with a transient server
+                                 error is retried, with backoff (default: 3,
+                                 or the config file's [retry] setting). Set to 0
+                                 to disable retries
+      --timings                  After the command finishes, display a summary
+                                 of the API requests it made (count, latency,
+                                 retries and payload sizes)
+  -t, --token=TOKEN              Fastly API token (or via FASTLY_API_TOKEN)
+      --utc                      Display timestamps in UTC
+  -v, --verbose ...              Verbose logging (repeat for more detail:
+                                 -vv API timings, -vvv HTTP traces)
 
 COMMANDS
   help [<command> ...]
     Show help.
 
 
+  ab-test create --name=NAME --variant-a=VARIANT-A --variant-b=VARIANT-B --version=VERSION [<flags>]
+    Create an A/B test, which assigns each client to a variant with a cookie and
+    runs that variant's VCL snippet
+
+        --name=NAME              Name of the test (letters, digits and
+                                 underscores), used to name its snippets,
+                                 cookie and header
+        --variant-a=VARIANT-A    VCL snippet of variant A passed as file path,
+                                 content, or - for stdin
+        --variant-b=VARIANT-B    VCL snippet of variant B passed as file path,
+                                 content, or - for stdin
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version
+        --allow-secrets          Upload the VCL even if it appears to contain
+                                 secrets such as API tokens or private keys
+        --autoclone              If the selected service version is not
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+    -p, --priority=100           Priority of the variants' snippets. Lower
+                                 numbers execute first
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --split=50               Percentage of new clients assigned to variant A
+                                 (1-99), the rest being assigned to variant B
+        --type=recv              The location in generated VCL where the
+                                 variants' snippets are placed
+
+  ab-test report --file=FILE --name=NAME [<flags>]
+    Report on an A/B test from JSON logs of its requests, split by the logged
+    variant
+
+        --file=FILE              Path of the logs, with one JSON object per
+                                 line, or - for stdin
+        --name=NAME              Name of the test
+        --field=FIELD            Field of the logs holding the variant (default:
+                                 ab_<name>)
+    -j, --json                   Render output as JSON
+        --status-field="status"  Field of the logs holding the response status
+
+  account describe [<flags>]
+    Show the security settings of an account, such as SSO and 2FA enforcement
+
+        --customer-id=CUSTOMER-ID  Alphanumeric string identifying the customer
+                                   (falls back to FASTLY_CUSTOMER_ID)
+    -j, --json                     Render output as JSON
+
+  account limits [<flags>]
+    Show the plan entitlements of an account that affect what the CLI can do,
+    such as Compute, custom VCL, WAF and TLS subscriptions
+
+        --customer-id=CUSTOMER-ID  Alphanumeric string identifying the customer
+                                   (falls back to FASTLY_CUSTOMER_ID)
+    -j, --json                     Render output as JSON
+
   acl create --name=NAME --version=VERSION [<flags>]
     Create a new ACL attached to the specified service version
 
@@ -340,7 +1381,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -353,7 +1396,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -390,7 +1435,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -399,9 +1446,13 @@ COMMANDS
   acl-entry create --acl-id=ACL-ID --ip=IP [<flags>]
     Add an ACL entry to an ACL
 
-        --acl-id=ACL-ID          Alphanumeric string identifying a ACL
+        --acl-id=ACL-ID          Alphanumeric string identifying a ACL (or its
+                                 name)
         --ip=IP                  An IP address
         --comment=COMMENT        A freeform descriptive note
+        --expires=EXPIRES        Remove the entry with 'acl-entry expire-run'
+                                 once this duration has passed (e.g. 72h),
+                                 recorded in the entry's comment
         --negated                Whether to negate the match
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -413,7 +1464,8 @@ COMMANDS
   acl-entry delete --acl-id=ACL-ID --id=ID [<flags>]
     Delete an ACL entry from a specified ACL
 
-        --acl-id=ACL-ID          Alphanumeric string identifying a ACL
+        --acl-id=ACL-ID          Alphanumeric string identifying a ACL (or its
+                                 name)
         --id=ID                  Alphanumeric string identifying an ACL Entry
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -423,7 +1475,8 @@ COMMANDS
   acl-entry describe --acl-id=ACL-ID --id=ID [<flags>]
     Retrieve a single ACL entry
 
-        --acl-id=ACL-ID          Alphanumeric string identifying a ACL
+        --acl-id=ACL-ID          Alphanumeric string identifying a ACL (or its
+                                 name)
         --id=ID                  Alphanumeric string identifying an ACL Entry
     -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -431,12 +1484,25 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
 
+  acl-entry expire-run --acl-id=ACL-ID [<flags>]
+    Delete the entries of an ACL whose expiry (see 'acl-entry create --expires')
+    has passed
+
+        --acl-id=ACL-ID          Alphanumeric string identifying a ACL (or its
+                                 name)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   acl-entry list --acl-id=ACL-ID [<flags>]
     List ACLs
 
-        --acl-id=ACL-ID          Alphanumeric string identifying a ACL
+        --acl-id=ACL-ID          Alphanumeric string identifying a ACL (or its
+                                 name)
         --direction=ascend       Direction in which to sort results
     -j, --json                   Render output as JSON
+        --max-items=MAX-ITEMS    Maximum number of ACL entries to list
         --page=PAGE              Page number of data set to fetch
         --per-page=PER-PAGE      Number of records per page
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -448,10 +1514,13 @@ COMMANDS
   acl-entry update --acl-id=ACL-ID [<flags>]
     Update an ACL entry for a specified ACL
 
-        --acl-id=ACL-ID          Alphanumeric string identifying a ACL
+        --acl-id=ACL-ID          Alphanumeric string identifying a ACL (or its
+                                 name)
+        --batch-file=BATCH-FILE  JSON batch patch passed as file path, content,
+                                 or - for stdin, whose "entries" are create,
+                                 update or delete operations (op, id, ip,
+                                 subnet, negated, comment)
         --comment=COMMENT        A freeform descriptive note
-        --file=FILE              Batch update json passed as file path or
-                                 content, e.g. $(< batch.json)
         --id=ID                  Alphanumeric string identifying an ACL Entry
         --ip=IP                  An IP address
         --negated                Whether to negate the match
@@ -462,6 +1531,30 @@ COMMANDS
         --subnet=SUBNET          Number of bits for the subnet mask applied to
                                  the IP address
 
+  analyze cache [<flags>]
+    Suggest changes to a service's caching configuration based on its hit ratio,
+    passes and errors
+
+    -j, --json                   Render output as JSON
+        --last="24h"             Period of stats to analyze, e.g. 24h or 7d
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --target-hit-ratio=0.9   Hit ratio below which TTL changes are suggested
+                                 (0-1)
+
+  api [<flags>] <method> <path>
+    Make an authenticated request to the Fastly API and display the raw response
+
+        --data=DATA          Request body, as a value, a file path, or '-' to
+                             read it from stdin. It's sent as JSON if it's valid
+                             JSON, otherwise as a form
+    -H, --header=HEADER ...  Request header in the form 'Name: value' (repeat to
+                             send several headers)
+        --include            Display the status and headers of the response
+                             before its body
+
   auth-token create --password=PASSWORD [<flags>]
     Create an API token
 
@@ -493,6 +1586,32 @@ COMMANDS
                                    (falls back to FASTLY_CUSTOMER_ID)
     -j, --json                     Render output as JSON
 
+  auth-token purge [<flags>]
+    Revoke all the customer's API tokens that match the given filters
+
+    --customer-id=CUSTOMER-ID  Alphanumeric string identifying the customer
+                               (falls back to FASTLY_CUSTOMER_ID)
+    --older-than=OLDER-THAN    Match tokens created more than this long ago,
+                               e.g. 1y or 90d
+    --unused-for=UNUSED-FOR    Match tokens that haven't been used for this
+                               long, e.g. 90d
+    --user=USER                Match tokens belonging to this user (login email
+                               address or user ID)
+
+  backend blame --name=NAME [<flags>]
+    Find the service version in which a backend last changed
+
+    -n, --name=NAME              Name of backend
+        --concurrency=8          Number of service versions to fetch at once
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a
+                                 specific version to walk back from (defaults to
+                                 'latest')
+
   backend create --version=VERSION --name=NAME --address=ADDRESS [<flags>]
     Create a backend on a Fastly service version
 
@@ -503,7 +1622,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                Backend name
         --address=ADDRESS          A hostname, IPv4, or IPv6 address for the
                                    backend
@@ -568,7 +1689,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Backend name
 
   backend describe --version=VERSION --name=NAME [<flags>]
@@ -583,6 +1706,24 @@ COMMANDS
                                  version
     -n, --name=NAME              Name of backend
 
+  backend harden --version=VERSION [<flags>]
+    Enable certificate verification, require a minimum TLS version and set the
+    certificate hostname of every TLS backend missing them
+
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version
+        --autoclone              If the selected service version is not
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+    -j, --json                   Render output as JSON
+        --min-tls-version=1.2    Minimum TLS version to require of backends that
+                                 allow older versions
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   backend list --version=VERSION [<flags>]
     List backends on a Fastly service version
 
@@ -594,6 +1735,54 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
 
+  backend mirror --percent=PERCENT --primary=PRIMARY --shadow=SHADOW --version=VERSION [<flags>]
+    Send a percentage of requests to a shadow backend, falling back to the
+    primary backend if it fails
+
+        --percent=PERCENT        Percentage of requests to send to the shadow
+                                 backend (1-100)
+        --primary=PRIMARY        Name of the backend that currently serves the
+                                 requests
+        --shadow=SHADOW          Name of the backend to send the sampled
+                                 requests to
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version
+        --autoclone              If the selected service version is not
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+        --print                  Display the generated VCL snippets without
+                                 changing the service
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --shadow-address=SHADOW-ADDRESS
+                                 Create the shadow backend with this address and
+                                 the primary backend's other settings
+
+  backend recommend-shield --name=NAME [<flags>]
+    Recommend the shield POP with the lowest latency to a backend
+
+    -n, --name=NAME              Name of backend
+        --apply                  Set the backend's shield to the recommended POP
+        --autoclone              If the selected service version is not
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+    -j, --json                   Render output as JSON
+        --probes=3               Number of times to fetch the URL from each POP
+                                 (the median response time is used)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --url=URL                A URL on the service that's fetched from the
+                                 backend (defaults to the root of the service's
+                                 first domain)
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'latest')
+
   backend update --version=VERSION --name=NAME [<flags>]
     Update a backend on a Fastly service version
 
@@ -604,7 +1793,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                backend name
         --new-name=NEW-NAME        New backend name
         --comment=COMMENT          A descriptive note
@@ -660,14 +1851,29 @@ COMMANDS
                                    https://www.openssl.org/docs/man1.0.2/man1/ciphers
                                    for details)
 
+  commands [<flags>]
+    List all available commands
+
+    -j, --json  Render the full command tree, including flags and arguments,
+                as JSON
+
+  completion <shell>
+    Output a shell completion script for the Fastly CLI, e.g. 'source <(fastly
+    completion bash)'
+
+
   compute build [<flags>]
     Build a Compute@Edge package locally
 
-    --include-source     Include source code in built package
-    --language=LANGUAGE  Language type
-    --name=NAME          Package name
-    --skip-verification  Skip verification steps and force build
-    --timeout=TIMEOUT    Timeout, in seconds, for the build compilation step
+    --include-source         Include source code in built package
+    --language=LANGUAGE      Language type
+    --name=NAME              Package name
+    --sbom=SBOM              Also write the package's software bill of materials
+                             to this file
+    --sbom-format=cyclonedx  Format of the software bill of materials embedded
+                             in the package
+    --skip-verification      Skip verification steps and force build
+    --timeout=TIMEOUT        Timeout, in seconds, for the build compilation step
 
   compute deploy [<flags>]
     Deploy a package to a Fastly Compute@Edge service
@@ -681,8 +1887,20 @@ COMMANDS
         --comment=COMMENT        Human-readable comment
         --domain=DOMAIN          The name of the domain associated to the
                                  package
+        --log-endpoint-param=LOG-ENDPOINT-PARAM ...
+                                 A parameter of a log endpoint in the
+                                 fastly.toml [setup.log_endpoints], as
+                                 <endpoint>.<parameter>=<value> (repeat flag per
+                                 parameter)
         --name=NAME              Package name
     -p, --package=PACKAGE        Path to a package tar.gz
+        --upload-timeout=UPLOAD-TIMEOUT
+                                 Timeout, in seconds, for each attempt to upload
+                                 the package (an upload that fails or times out
+                                 is attempted up to 3 times)
+        --verify-signature=VERIFY-SIGNATURE
+                                 Path to a minisign public key the package must
+                                 be signed by (see 'compute pack --sign-key')
 
   compute init [<flags>]
     Initialize a new Compute@Edge package locally
@@ -699,45 +1917,99 @@ COMMANDS
         --force                    Skip non-empty directory verification step
                                    and force new project creation
 
-  compute pack --wasm-binary=WASM-BINARY
+  compute pack --wasm-binary=WASM-BINARY [<flags>]
     Package a pre-compiled Wasm binary for a Fastly Compute@Edge service
 
+        --sbom=SBOM                Also write the package's software bill of
+                                   materials to this file
+        --sbom-format=cyclonedx    Format of the software bill of materials
+                                   embedded in the package
+        --sign-key=SIGN-KEY        Path to a minisign secret key to sign the
+                                   package with (the key's password is read from
+                                   FASTLY_SIGNING_KEY_PASSWORD or prompted for)
     -w, --wasm-binary=WASM-BINARY  Path to a pre-compiled Wasm binary
 
+  compute profile [<flags>]
+    Run a Compute@Edge package locally and produce a flame graph of each request
+
+    --addr="127.0.0.1:7676"  The IPv4 address and port to listen on
+    --env=ENV                The environment configuration to use (e.g. stage)
+    --file="bin/main.wasm"   The Wasm file to run (see 'compute build')
+    --dir="profiles"         The directory to write the profiles and flame
+                             graphs to
+
   compute publish [<flags>]
     Build and deploy a Compute@Edge package to a Fastly service
 
-        --comment=COMMENT        Human-readable comment
-        --domain=DOMAIN          The name of the domain associated to the
-                                 package
-        --include-source         Include source code in built package
-        --language=LANGUAGE      Language type
-        --name=NAME              Package name
-    -p, --package=PACKAGE        Path to a package tar.gz
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --comment=COMMENT          Human-readable comment
+        --domain=DOMAIN            The name of the domain associated to the
+                                   package
+        --include-source           Include source code in built package
+        --language=LANGUAGE        Language type
+        --log-endpoint-param=LOG-ENDPOINT-PARAM ...
+                                   A parameter of a log endpoint in the
+                                   fastly.toml [setup.log_endpoints], as
+                                   <endpoint>.<parameter>=<value> (repeat flag
+                                   per parameter)
+        --name=NAME                Package name
+    -p, --package=PACKAGE          Path to a package tar.gz
+        --sbom=SBOM                Also write the package's software bill of
+                                   materials to this file
+        --sbom-format=SBOM-FORMAT  Format of the software bill of materials
+                                   embedded in the package
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --skip-verification      Skip verification steps and force build
-        --timeout=TIMEOUT        Timeout, in seconds, for the build compilation
-                                 step
+                                   The name of the service
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --skip-verification        Skip verification steps and force build
+        --timeout=TIMEOUT          Timeout, in seconds, for the build
+                                   compilation step
+        --upload-timeout=UPLOAD-TIMEOUT
+                                   Timeout, in seconds, for each attempt to
+                                   upload the package (an upload that fails or
+                                   times out is attempted up to 3 times)
+        --verify-signature=VERIFY-SIGNATURE
+                                   Path to a minisign public key the package
+                                   must be signed by (see 'compute pack
+                                   --sign-key')
+
+  compute replay [<flags>]
+    Replay recorded requests against a local server and compare the responses
+
+        --addr="127.0.0.1:7676"  The address of the local server (see 'compute
+                                 serve --addr')
+        --har=HAR                Path to a HAR file of recorded requests and
+                                 responses
+        --ignore-header=IGNORE-HEADER ...
+                                 A response header not to compare (repeat for
+                                 multiple headers, in addition to headers such
+                                 as Date and Age)
+        --ndjson=NDJSON          Path to a file of recorded requests and
+                                 responses, one JSON object per line
+        --timeout=30             Timeout, in seconds, for each request
+    -j, --json                   Render output as JSON
 
   compute serve [<flags>]
     Build and run a Compute@Edge package locally
 
-    --addr="127.0.0.1:7676"  The IPv4 address and port to listen on
-    --env=ENV                The environment configuration to use (e.g. stage)
-    --file="bin/main.wasm"   The Wasm file to run
-    --include-source         Include source code in built package
-    --language=LANGUAGE      Language type
-    --name=NAME              Package name
-    --skip-build             Skip the build step
-    --skip-verification      Skip verification steps and force build
-    --timeout=TIMEOUT        Timeout, in seconds, for the build compilation step
-    --watch                  Watch for file changes, then rebuild project and
-                             restart local server
+    --addr="127.0.0.1:7676"    The IPv4 address and port to listen on
+    --env=ENV                  The environment configuration to use (e.g. stage)
+    --file="bin/main.wasm"     The Wasm file to run
+    --include-source           Include source code in built package
+    --language=LANGUAGE        Language type
+    --name=NAME                Package name
+    --sbom=SBOM                Also write the package's software bill of
+                               materials to this file
+    --sbom-format=SBOM-FORMAT  Format of the software bill of materials embedded
+                               in the package
+    --skip-build               Skip the build step
+    --skip-verification        Skip verification steps and force build
+    --timeout=TIMEOUT          Timeout, in seconds, for the build compilation
+                               step
+    --watch                    Watch for file changes, then rebuild project and
+                               restart local server
 
   compute update --version=VERSION --package=PACKAGE [<flags>]
     Update a package on a Fastly Compute@Edge service version
@@ -749,19 +2021,63 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -p, --package=PACKAGE        Path to a package tar.gz
 
-  compute validate --package=PACKAGE
+  compute validate --package=PACKAGE [<flags>]
     Validate a Compute@Edge package
 
-    -p, --package=PACKAGE  Path to a package tar.gz
+    -p, --package=PACKAGE          Path to a package tar.gz
+        --report=REPORT            Write the validation findings as a report
+                                   (junit, sarif)
+        --report-file=REPORT-FILE  Path to write the --report output to
+                                   (default: stdout)
+
+  init [<flags>]
+    Create a new Fastly service, guided by prompts: a VCL service with its
+    domain and backend, or a Compute@Edge project
+
+    --backend=BACKEND        Hostname or IP address of the VCL service's backend
+    --backend-port=80        Port number of the VCL service's backend
+    --domain=DOMAIN          Domain of the VCL service (defaults to a subdomain
+                             of global.ssl.fastly.net)
+    --export="service.yaml"  Path of the YAML file that describes the new VCL
+                             service
+    --name=NAME              Name of the VCL service (defaults to the name of
+                             the current directory)
+    --skip-activation        Leave the new VCL service's version inactive
+    --type=TYPE              Type of service to create: vcl or compute (prompts
+                             if not set, suggesting compute if the current
+                             directory contains a Compute@Edge project)
 
   config [<flags>]
     Display the Fastly CLI configuration
 
     -l, --location  Print the location of the CLI configuration file
 
+  curl [<flags>] <url>
+    Make an HTTP request through Fastly and display the cache diagnostics of the
+    response
+
+        --debug-cache        Send a Fastly-Debug header so the response includes
+                             Fastly's cache debugging headers
+    -H, --header=HEADER ...  Request header in the form 'Name: value' (repeat to
+                             send several headers)
+        --ip=IP              Send the request to this IP address instead of
+                             resolving the URL's host (see 'fastly ip-list')
+        --pop=POP            Code of the POP expected to serve the request, e.g.
+                             AMS (see 'fastly pops')
+    -X, --request="GET"      HTTP method to use
+
+  dashboard [<flags>]
+    Browse services, realtime traffic and recent events in an interactive
+    terminal dashboard
+
+    --events=10    Number of recent events to show for the selected service
+    --refresh=30s  How often to refresh the service list and events
+
   dictionary create --version=VERSION --name=NAME [<flags>]
     Create a Fastly edge dictionary on a Fastly service version
 
@@ -772,7 +2088,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Name of Dictionary
         --write-only=WRITE-ONLY  Whether to mark this dictionary as write-only.
                                  Can be true or false (defaults to false)
@@ -787,7 +2105,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Name of Dictionary
 
   dictionary describe --version=VERSION --name=NAME [<flags>]
@@ -813,6 +2133,26 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
 
+  dictionary lookup --name=NAME --key=KEY [<flags>]
+    Look up a key in an edge dictionary the way table.lookup() does in VCL
+
+    -n, --name=NAME ...          Name of Dictionary (repeat to fall back to
+                                 further dictionaries when the key isn't found)
+        --key=KEY                Dictionary item key
+        --default=DEFAULT        Value to use when the key isn't found in any
+                                 dictionary
+        --simulate-file=SIMULATE-FILE
+                                 Path to a JSON or YAML file of dictionaries
+                                 (name to key/value map) to look up instead of
+                                 the API, or - for stdin
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'active')
+
   dictionary update --version=VERSION --name=NAME [<flags>]
     Update name of dictionary on a Fastly service version
 
@@ -823,12 +2163,32 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Old name of Dictionary
         --new-name=NEW-NAME      New name of Dictionary
         --write-only=WRITE-ONLY  Whether to mark this dictionary as write-only.
                                  Can be true or false (defaults to false)
 
+  dictionary usages --name=NAME [<flags>]
+    Report where a dictionary is looked up (e.g. table.lookup) in a service
+    version's VCL
+
+    -n, --name=NAME              Name of Dictionary
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'active')
+
+  dictionary-item apply-due
+    Apply the scheduled dictionary item changes (see 'fastly dictionary-item
+    schedule') whose time has passed
+
+
   dictionary-item create --dictionary-id=DICTIONARY-ID --key=KEY --value=VALUE [<flags>]
     Create a new item on a Fastly edge dictionary
 
@@ -837,7 +2197,7 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
         --dictionary-id=DICTIONARY-ID
-                                 Dictionary ID
+                                 Dictionary ID (or its name)
         --key=KEY                Dictionary item key
         --value=VALUE            Dictionary item value
 
@@ -849,7 +2209,7 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
         --dictionary-id=DICTIONARY-ID
-                                 Dictionary ID
+                                 Dictionary ID (or its name)
         --key=KEY                Dictionary item key
 
   dictionary-item describe --dictionary-id=DICTIONARY-ID --key=KEY [<flags>]
@@ -861,16 +2221,17 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
         --dictionary-id=DICTIONARY-ID
-                                 Dictionary ID
+                                 Dictionary ID (or its name)
         --key=KEY                Dictionary item key
 
   dictionary-item list --dictionary-id=DICTIONARY-ID [<flags>]
     List items in a Fastly edge dictionary
 
         --dictionary-id=DICTIONARY-ID
-                                 Dictionary ID
+                                 Dictionary ID (or its name)
         --direction=ascend       Direction in which to sort results
     -j, --json                   Render output as JSON
+        --max-items=MAX-ITEMS    Maximum number of dictionary items to list
         --page=PAGE              Page number of data set to fetch
         --per-page=PER-PAGE      Number of records per page
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -879,12 +2240,34 @@ COMMANDS
                                  The name of the service
         --sort="created"         Field on which to sort
 
+  dictionary-item schedule --at=AT --dictionary-id=DICTIONARY-ID --key=KEY [<flags>]
+    Schedule a change to a dictionary item, applied by 'fastly dictionary-item
+    apply-due'
+
+        --at=AT                  When to apply the change, e.g.
+                                 2024-07-01T09:00Z (local time if no zone is
+                                 given)
+        --delete                 Delete the item instead of setting its value
+        --dictionary-id=DICTIONARY-ID
+                                 Dictionary ID (or its name)
+        --key=KEY                Dictionary item key
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --value=VALUE            The value to set the item to
+
   dictionary-item update --dictionary-id=DICTIONARY-ID [<flags>]
     Update or insert an item on a Fastly edge dictionary
 
         --dictionary-id=DICTIONARY-ID
-                                 Dictionary ID
-        --file=FILE              Batch update json file
+                                 Dictionary ID (or its name)
+        --batch-file=BATCH-FILE  Path of a JSON batch patch file (or - for
+                                 stdin) whose "items" are create, update, upsert
+                                 or delete operations (op, item_key, item_value)
+        --edit                   Open the current content in $VISUAL or $EDITOR,
+                                 display a diff and upload the changes once
+                                 confirmed
         --key=KEY                Dictionary item key
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -904,7 +2287,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
 
   domain delete --name=NAME --version=VERSION [<flags>]
     Delete a domain on a Fastly service version
@@ -917,7 +2302,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
 
   domain describe --version=VERSION --name=NAME [<flags>]
     Show detailed information about a domain on a Fastly service version
@@ -942,6 +2329,16 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
 
+  domain migrate --domain=DOMAIN --from-service=FROM-SERVICE --to-service=TO-SERVICE [<flags>]
+    Move a domain from one Fastly service to another, rolling back on failure
+
+    -n, --domain=DOMAIN          Domain name
+        --from-service=FROM-SERVICE
+                                 ID of the service the domain is currently on
+        --skip-tls-check         Don't require a TLS certificate to be activated
+                                 for the domain
+        --to-service=TO-SERVICE  ID of the service to move the domain to
+
   domain update --version=VERSION --name=NAME [<flags>]
     Update a domain on a Fastly service version
 
@@ -952,7 +2349,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Domain name
         --new-name=NEW-NAME      New domain name
         --comment=COMMENT        A descriptive note
@@ -960,12 +2359,99 @@ COMMANDS
   domain validate --version=VERSION [<flags>]
     Checks the status of a specific domain's DNS record for a Service Version
 
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+    -a, --all                      Checks the status of all domains' DNS records
+                                   for a Service Version
+    -n, --name=NAME                The name of the domain associated with this
+                                   service
+        --report=REPORT            Write the validation findings as a report
+                                   (junit, sarif)
+        --report-file=REPORT-FILE  Path to write the --report output to
+                                   (default: stdout)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
+
+  errors show [<flags>]
+    Show the errors, and their context, logged by past runs that failed.
+    Tokens are redacted
+
+    -j, --json         Render output as JSON
+        --last=LAST    Number of most recent runs to show (default: 1, or all of
+                       those in the --since period)
+        --since=SINCE  Only show runs in this period before now, e.g. 1h or 7d
+
+  flags disable --name=NAME [<flags>]
+    Disable a feature flag
+
+        --dictionary="feature_flags"
+                                 Name of the dictionary that holds the flags
+    -n, --name=NAME              Name of the flag
+        --reason=REASON          Why the flag is being changed, recorded in the
+                                 log (see --log-file)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version whose dictionary holds the flags
+                                 (defaults to 'active')
+
+  flags enable --name=NAME [<flags>]
+    Enable a feature flag, optionally for a percentage of requests
+
+        --dictionary="feature_flags"
+                                 Name of the dictionary that holds the flags
+    -n, --name=NAME              Name of the flag
+        --reason=REASON          Why the flag is being changed, recorded in the
+                                 log (see --log-file)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version whose dictionary holds the flags
+                                 (defaults to 'active')
+        --percentage=PERCENTAGE  Only enable the flag for this percentage of
+                                 requests (0-100), to ramp it up gradually
+
+  flags list [<flags>]
+    List the feature flags and their states
+
+        --dictionary="feature_flags"
+                                 Name of the dictionary that holds the flags
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version whose dictionary holds the flags
+                                 (defaults to 'active')
+
+  generate commands --from=FROM [<flags>]
+    Print the CLI commands that would recreate a service from a service export
+
+    --from=FROM  Path to a YAML, TOML or JSON service export, or - for stdin
+    --activate   Include a final command to activate the recreated service
+                 version
+
+  gzip audit --version=VERSION [<flags>]
+    Report which content types a service version compresses and flag conflicting
+    configuration
+
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
-    -a, --all                    Checks the status of all domains' DNS records
-                                 for a Service Version
-    -n, --name=NAME              The name of the domain associated with this
-                                 service
+        --apply-preset=APPLY-PRESET
+                                 Create or update a gzip configuration with the
+                                 given compression policy
+        --autoclone              If the selected service version is not
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+    -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -981,7 +2467,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Healthcheck name
         --comment=COMMENT        A descriptive note
         --method=METHOD          Which HTTP method to use
@@ -1012,7 +2500,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Healthcheck name
 
   healthcheck describe --version=VERSION --name=NAME [<flags>]
@@ -1048,7 +2538,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              Healthcheck name
         --new-name=NEW-NAME      Healthcheck name
         --comment=COMMENT        A descriptive note
@@ -1074,6 +2566,23 @@ COMMANDS
     List Fastly's public IPs
 
 
+  limits status [<flags>]
+    Report a service version's usage of resources against Fastly's limits
+
+    -j, --json                   Render output as JSON
+        --limit=LIMIT ...        Override a limit raised for your account, in
+                                 the form 'resource=limit' (repeat for multiple
+                                 limits)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --strict                 Exit with an error if any resource is
+                                 approaching its limit
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'latest')
+        --warn-at=80             Percentage of a limit at which to warn
+
   log-tail [<flags>]
     Tail Compute@Edge logs
 
@@ -1086,67 +2595,99 @@ COMMANDS
         --sort-buffer=1s         Duration of sort buffer for received logs
         --search-padding=2s      Time beyond from/to to consider in searches
         --stream=STREAM          Output: stdout, stderr, both (default)
+        --pretty                 Indent log messages that are JSON objects
+                                 or arrays (use --no-pretty to print them as
+                                 received)
+        --jq=JQ                  Print only these comma-separated fields of JSON
+                                 log messages, e.g. '.level, .req.url, .tags[0]'
+
+  logging audit --version=VERSION [<flags>]
+    Audit the log formats of every logging endpoint of a service version
+
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+    -j, --json                     Render output as JSON
+        --pii                      Flag formats that log sensitive fields
+                                   (cookies, authorization headers, query
+                                   strings and request bodies) and suggest
+                                   scrubbed alternatives
+        --report=REPORT            Write the audit findings as a report (junit,
+                                   sarif)
+        --report-file=REPORT-FILE  Path to write the --report output to
+                                   (default: stdout)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
 
   logging azureblob create --name=NAME --version=VERSION --container=CONTAINER --account-name=ACCOUNT-NAME --sas-token=SAS-TOKEN [<flags>]
     Create an Azure Blob Storage logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Azure Blob Storage logging
-                                 object. Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --container=CONTAINER    The name of the Azure Blob Storage container in
-                                 which to store logs
+    -n, --name=NAME                The name of the Azure Blob Storage logging
+                                   object. Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --container=CONTAINER      The name of the Azure Blob Storage container
+                                   in which to store logs
         --account-name=ACCOUNT-NAME
-                                 The unique Azure Blob Storage namespace in
-                                 which your data objects are stored
-        --sas-token=SAS-TOKEN    The Azure shared access signature providing
-                                 write access to the blob service objects. Be
-                                 sure to update your token before it expires or
-                                 the logging functionality will not work
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+                                   The unique Azure Blob Storage namespace in
+                                   which your data objects are stored
+        --sas-token=SAS-TOKEN      The Azure shared access signature providing
+                                   write access to the blob service objects. Be
+                                   sure to update your token before it expires
+                                   or the logging functionality will not work
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --file-max-bytes=FILE-MAX-BYTES
-                                 The maximum size of a log file in bytes
+                                   The maximum size of a log file in bytes
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging azureblob delete --version=VERSION --name=NAME [<flags>]
     Delete an Azure Blob Storage logging endpoint on a Fastly service version
@@ -1154,7 +2695,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Azure Blob Storage logging
                                  object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1190,108 +2733,124 @@ COMMANDS
   logging azureblob update --version=VERSION --name=NAME [<flags>]
     Update an Azure Blob Storage logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Azure Blob Storage logging
-                                 object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Azure Blob Storage logging
+                                   object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Azure Blob Storage logging
-                                 object
-        --container=CONTAINER    The name of the Azure Blob Storage container in
-                                 which to store logs
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Azure Blob Storage logging
+                                   object
+        --container=CONTAINER      The name of the Azure Blob Storage container
+                                   in which to store logs
         --account-name=ACCOUNT-NAME
-                                 The unique Azure Blob Storage namespace in
-                                 which your data objects are stored
-        --sas-token=SAS-TOKEN    The Azure shared access signature providing
-                                 write access to the blob service objects. Be
-                                 sure to update your token before it expires or
-                                 the logging functionality will not work
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The unique Azure Blob Storage namespace in
+                                   which your data objects are stored
+        --sas-token=SAS-TOKEN      The Azure shared access signature providing
+                                   write access to the blob service objects. Be
+                                   sure to update your token before it expires
+                                   or the logging functionality will not work
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --file-max-bytes=FILE-MAX-BYTES
-                                 The maximum size of a log file in bytes
+                                   The maximum size of a log file in bytes
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging bigquery create --name=NAME --version=VERSION --project-id=PROJECT-ID --dataset=DATASET --table=TABLE --user=USER --secret-key=SECRET-KEY [<flags>]
     Create a BigQuery logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the BigQuery logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --project-id=PROJECT-ID  Your Google Cloud Platform project ID
-        --dataset=DATASET        Your BigQuery dataset
-        --table=TABLE            Your BigQuery table
-        --user=USER              Your Google Cloud Platform service account
-                                 email address. The client_email field in your
-                                 service account authentication JSON.
-        --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
-                                 The private_key field in your service account
-                                 authentication JSON.
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the BigQuery logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --project-id=PROJECT-ID    Your Google Cloud Platform project ID
+        --dataset=DATASET          Your BigQuery dataset
+        --table=TABLE              Your BigQuery table
+        --user=USER                Your Google Cloud Platform service account
+                                   email address. The client_email field in your
+                                   service account authentication JSON.
+        --secret-key=SECRET-KEY    Your Google Cloud Platform account secret
+                                   key. The private_key field in your service
+                                   account authentication JSON.
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
+                                   The name of the service
         --template-suffix=TEMPLATE-SUFFIX
-                                 BigQuery table name suffix template
-        --format=FORMAT          Apache style log formatting. Must produce JSON
-                                 that matches the schema of your BigQuery table
+                                   BigQuery table name suffix template
+        --format=FORMAT            Apache style log formatting. Must produce
+                                   JSON that matches the schema of your BigQuery
+                                   table
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
 
   logging bigquery delete --version=VERSION --name=NAME [<flags>]
     Delete a BigQuery logging endpoint on a Fastly service version
@@ -1299,7 +2858,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the BigQuery logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -1333,101 +2894,118 @@ COMMANDS
   logging bigquery update --version=VERSION --name=NAME [<flags>]
     Update a BigQuery logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the BigQuery logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the BigQuery logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the BigQuery logging object
-        --project-id=PROJECT-ID  Your Google Cloud Platform project ID
-        --dataset=DATASET        Your BigQuery dataset
-        --table=TABLE            Your BigQuery table
-        --user=USER              Your Google Cloud Platform service account
-                                 email address. The client_email field in your
-                                 service account authentication JSON.
-        --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
-                                 The private_key field in your service account
-                                 authentication JSON.
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the BigQuery logging object
+        --project-id=PROJECT-ID    Your Google Cloud Platform project ID
+        --dataset=DATASET          Your BigQuery dataset
+        --table=TABLE              Your BigQuery table
+        --user=USER                Your Google Cloud Platform service account
+                                   email address. The client_email field in your
+                                   service account authentication JSON.
+        --secret-key=SECRET-KEY    Your Google Cloud Platform account secret
+                                   key. The private_key field in your service
+                                   account authentication JSON.
         --template-suffix=TEMPLATE-SUFFIX
-                                 BigQuery table name suffix template
-        --format=FORMAT          Apache style log formatting. Must produce JSON
-                                 that matches the schema of your BigQuery table
+                                   BigQuery table name suffix template
+        --format=FORMAT            Apache style log formatting. Must produce
+                                   JSON that matches the schema of your BigQuery
+                                   table
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
 
   logging cloudfiles create --name=NAME --version=VERSION --user=USER --access-key=ACCESS-KEY --bucket=BUCKET [<flags>]
     Create a Cloudfiles logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Cloudfiles logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --user=USER              The username for your Cloudfile account
-        --access-key=ACCESS-KEY  Your Cloudfile account access key
-        --bucket=BUCKET          The name of your Cloudfiles container
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
-        --service-name=SERVICE-NAME
-                                 The name of the service
-        --path=PATH              The path to upload logs to
-        --region=REGION          The region to stream logs to. One of:
-                                 DFW-Dallas, ORD-Chicago, IAD-Northern Virginia,
-                                 LON-London, SYD-Sydney, HKG-Hong Kong
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+    -n, --name=NAME                The name of the Cloudfiles logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --user=USER                The username for your Cloudfile account
+        --access-key=ACCESS-KEY    Your Cloudfile account access key
+        --bucket=BUCKET            The name of your Cloudfiles container
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
+        --path=PATH                The path to upload logs to
+        --region=REGION            The region to stream logs to. One of:
+                                   DFW-Dallas, ORD-Chicago, IAD-Northern
+                                   Virginia, LON-London, SYD-Sydney, HKG-Hong
+                                   Kong
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging cloudfiles delete --version=VERSION --name=NAME [<flags>]
     Delete a Cloudfiles logging endpoint on a Fastly service version
@@ -1435,7 +3013,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Cloudfiles logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -1469,97 +3049,115 @@ COMMANDS
   logging cloudfiles update --version=VERSION --name=NAME [<flags>]
     Update a Cloudfiles logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Cloudfiles logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Cloudfiles logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Cloudfiles logging object
-        --user=USER              The username for your Cloudfile account
-        --access-key=ACCESS-KEY  Your Cloudfile account access key
-        --bucket=BUCKET          The name of your Cloudfiles container
-        --path=PATH              The path to upload logs to
-        --region=REGION          The region to stream logs to. One of:
-                                 DFW-Dallas, ORD-Chicago, IAD-Northern Virginia,
-                                 LON-London, SYD-Sydney, HKG-Hong Kong
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Cloudfiles logging object
+        --user=USER                The username for your Cloudfile account
+        --access-key=ACCESS-KEY    Your Cloudfile account access key
+        --bucket=BUCKET            The name of your Cloudfiles container
+        --path=PATH                The path to upload logs to
+        --region=REGION            The region to stream logs to. One of:
+                                   DFW-Dallas, ORD-Chicago, IAD-Northern
+                                   Virginia, LON-London, SYD-Sydney, HKG-Hong
+                                   Kong
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging datadog create --name=NAME --version=VERSION --auth-token=AUTH-TOKEN [<flags>]
     Create a Datadog logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Datadog logging object. Used as
-                                 a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --auth-token=AUTH-TOKEN  The API key from your Datadog account
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Datadog logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --auth-token=AUTH-TOKEN    The API key from your Datadog account
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --region=REGION          The region that log data will be sent to. One
-                                 of US or EU. Defaults to US if undefined
-        --format=FORMAT          Apache style log formatting. For details on the
-                                 default value refer to the documentation
-                                 (https://developer.fastly.com/reference/api/logging/datadog/)
+                                   The name of the service
+        --region=REGION            The region that log data will be sent to.
+                                   One of US or EU. Defaults to US if undefined
+        --format=FORMAT            Apache style log formatting. For details on
+                                   the default value refer to the documentation
+                                   (https://developer.fastly.com/reference/api/logging/datadog/)
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --response-condition=RESPONSE-CONDITION
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+
   logging datadog delete --version=VERSION --name=NAME [<flags>]
     Delete a Datadog logging endpoint on a Fastly service version
 
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Datadog logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -1593,88 +3191,104 @@ COMMANDS
   logging datadog update --version=VERSION --name=NAME [<flags>]
     Update a Datadog logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Datadog logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Datadog logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Datadog logging object
-        --auth-token=AUTH-TOKEN  The API key from your Datadog account
-        --region=REGION          The region that log data will be sent to. One
-                                 of US or EU. Defaults to US if undefined
-        --format=FORMAT          Apache style log formatting. For details on the
-                                 default value refer to the documentation
-                                 (https://developer.fastly.com/reference/api/logging/datadog/)
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Datadog logging object
+        --auth-token=AUTH-TOKEN    The API key from your Datadog account
+        --region=REGION            The region that log data will be sent to.
+                                   One of US or EU. Defaults to US if undefined
+        --format=FORMAT            Apache style log formatting. For details on
+                                   the default value refer to the documentation
+                                   (https://developer.fastly.com/reference/api/logging/datadog/)
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging digitalocean create --name=NAME --version=VERSION --bucket=BUCKET --access-key=ACCESS-KEY --secret-key=SECRET-KEY [<flags>]
     Create a DigitalOcean Spaces logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the DigitalOcean Spaces logging
-                                 object. Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --bucket=BUCKET          The name of the DigitalOcean Space
-        --access-key=ACCESS-KEY  Your DigitalOcean Spaces account access key
-        --secret-key=SECRET-KEY  Your DigitalOcean Spaces account secret key
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the DigitalOcean Spaces logging
+                                   object. Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --bucket=BUCKET            The name of the DigitalOcean Space
+        --access-key=ACCESS-KEY    Your DigitalOcean Spaces account access key
+        --secret-key=SECRET-KEY    Your DigitalOcean Spaces account secret key
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --domain=DOMAIN          The domain of the DigitalOcean Spaces endpoint
-                                 (default 'nyc3.digitaloceanspaces.com')
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --domain=DOMAIN            The domain of the DigitalOcean
+                                   Spaces endpoint (default
+                                   'nyc3.digitaloceanspaces.com')
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging digitalocean delete --version=VERSION --name=NAME [<flags>]
     Delete a DigitalOcean Spaces logging endpoint on a Fastly service version
@@ -1682,7 +3296,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the DigitalOcean Spaces logging
                                  object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1718,59 +3334,68 @@ COMMANDS
   logging digitalocean update --version=VERSION --name=NAME [<flags>]
     Update a DigitalOcean Spaces logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the DigitalOcean Spaces logging
-                                 object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the DigitalOcean Spaces logging
+                                   object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the DigitalOcean Spaces logging
-                                 object
-        --bucket=BUCKET          The name of the DigitalOcean Space
-        --domain=DOMAIN          The domain of the DigitalOcean Spaces endpoint
-                                 (default 'nyc3.digitaloceanspaces.com')
-        --access-key=ACCESS-KEY  Your DigitalOcean Spaces account access key
-        --secret-key=SECRET-KEY  Your DigitalOcean Spaces account secret key
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the DigitalOcean Spaces logging
+                                   object
+        --bucket=BUCKET            The name of the DigitalOcean Space
+        --domain=DOMAIN            The domain of the DigitalOcean
+                                   Spaces endpoint (default
+                                   'nyc3.digitaloceanspaces.com')
+        --access-key=ACCESS-KEY    Your DigitalOcean Spaces account access key
+        --secret-key=SECRET-KEY    Your DigitalOcean Spaces account secret key
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging elasticsearch create --name=NAME --version=VERSION --index=INDEX --url=URL [<flags>]
     Create an Elasticsearch logging endpoint on a Fastly service version
@@ -1780,10 +3405,12 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
-        --index=INDEX              The name of the Elasticsearch index to send
-                                   documents (logs) to. The index must follow
-                                   the Elasticsearch index format rules
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --index=INDEX              The name of the Elasticsearch index to
+                                   send documents (logs) to. The index must
+                                   follow the Elasticsearch index format rules
                                    (https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html).
                                    We support strftime
                                    (http://man7.org/linux/man-pages/man3/strftime.3.html)
@@ -1796,10 +3423,10 @@ COMMANDS
         --service-name=SERVICE-NAME
                                    The name of the service
         --pipeline=PIPELINE        The ID of the Elasticsearch ingest pipeline
-                                   to apply pre-process transformations to
-                                   before indexing. For example my_pipeline_id.
-                                   Learn more about creating a pipeline in the
-                                   Elasticsearch docs
+                                   to apply pre-process transformations
+                                   to before indexing. For example
+                                   my_pipeline_id. Learn more about creating
+                                   a pipeline in the Elasticsearch docs
                                    (https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest.html)
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
@@ -1816,12 +3443,16 @@ COMMANDS
         --format=FORMAT            Apache style log formatting. Your log must
                                    produce valid JSON that Elasticsearch can
                                    ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
                                    (default) or 1
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --response-condition=RESPONSE-CONDITION
@@ -1841,7 +3472,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Elasticsearch logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -1878,16 +3511,18 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                The name of the Elasticsearch logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
         --service-name=SERVICE-NAME
                                    The name of the service
         --new-name=NEW-NAME        New name of the Elasticsearch logging object
-        --index=INDEX              The name of the Elasticsearch index to send
-                                   documents (logs) to. The index must follow
-                                   the Elasticsearch index format rules
+        --index=INDEX              The name of the Elasticsearch index to
+                                   send documents (logs) to. The index must
+                                   follow the Elasticsearch index format rules
                                    (https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html).
                                    We support strftime
                                    (http://man7.org/linux/man-pages/man3/strftime.3.html)
@@ -1896,10 +3531,10 @@ COMMANDS
                                    interpolate as YYYY-MM-DD with today's date
         --url=URL                  The URL to stream logs to. Must use HTTPS.
         --pipeline=PIPELINE        The ID of the Elasticsearch ingest pipeline
-                                   to apply pre-process transformations to
-                                   before indexing. For example my_pipeline_id.
-                                   Learn more about creating a pipeline in the
-                                   Elasticsearch docs
+                                   to apply pre-process transformations
+                                   to before indexing. For example
+                                   my_pipeline_id. Learn more about creating
+                                   a pipeline in the Elasticsearch docs
                                    (https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest.html)
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
@@ -1916,12 +3551,16 @@ COMMANDS
         --format=FORMAT            Apache style log formatting. Your log must
                                    produce valid JSON that Elasticsearch can
                                    ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
                                    (default) or 1
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --response-condition=RESPONSE-CONDITION
@@ -1938,52 +3577,61 @@ COMMANDS
   logging ftp create --name=NAME --version=VERSION --address=ADDRESS --user=USER --password=PASSWORD [<flags>]
     Create an FTP logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the FTP logging object. Used as a
-                                 primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --address=ADDRESS        An hostname or IPv4 address
-        --user=USER              The username for the server (can be anonymous)
-        --password=PASSWORD      The password for the server (for anonymous use
-                                 an email address)
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the FTP logging object. Used as a
+                                   primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --address=ADDRESS          An hostname or IPv4 address
+        --user=USER                The username for the server (can be
+                                   anonymous)
+        --password=PASSWORD        The password for the server (for anonymous
+                                   use an email address)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --port=PORT              The port number
-        --path=PATH              The path to upload log files to. If the path
-                                 ends in / then it is treated as a directory
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --port=PORT                The port number
+        --path=PATH                The path to upload log files to. If the path
+                                   ends in / then it is treated as a directory
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging ftp delete --version=VERSION --name=NAME [<flags>]
     Delete an FTP logging endpoint on a Fastly service version
@@ -1991,7 +3639,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the FTP logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2025,117 +3675,134 @@ COMMANDS
   logging ftp update --version=VERSION --name=NAME [<flags>]
     Update an FTP logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the FTP logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the FTP logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the FTP logging object
-        --address=ADDRESS        An hostname or IPv4 address
-        --port=PORT              The port number
-        --username=USERNAME      The username for the server (can be anonymous)
-        --password=PASSWORD      The password for the server (for anonymous use
-                                 an email address)
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
-        --path=PATH              The path to upload log files to. If the path
-                                 ends in / then it is treated as a directory
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the FTP logging object
+        --address=ADDRESS          An hostname or IPv4 address
+        --port=PORT                The port number
+        --username=USERNAME        The username for the server (can be
+                                   anonymous)
+        --password=PASSWORD        The password for the server (for anonymous
+                                   use an email address)
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
+        --path=PATH                The path to upload log files to. If the path
+                                   ends in / then it is treated as a directory
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging gcs create --name=NAME --version=VERSION --user=USER --bucket=BUCKET --secret-key=SECRET-KEY [<flags>]
     Create a GCS logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the GCS logging object. Used as a
-                                 primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --user=USER              Your GCS service account email address. The
-                                 client_email field in your service account
-                                 authentication JSON
-        --bucket=BUCKET          The bucket of the GCS bucket
-        --secret-key=SECRET-KEY  Your GCS account secret key. The private_key
-                                 field in your service account authentication
-                                 JSON
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the GCS logging object. Used as a
+                                   primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --user=USER                Your GCS service account email address. The
+                                   client_email field in your service account
+                                   authentication JSON
+        --bucket=BUCKET            The bucket of the GCS bucket
+        --secret-key=SECRET-KEY    Your GCS account secret key. The private_key
+                                   field in your service account authentication
+                                   JSON
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --path=PATH              The path to upload logs to (default '/')
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --path=PATH                The path to upload logs to (default '/')
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging gcs delete --version=VERSION --name=NAME [<flags>]
     Delete a GCS logging endpoint on a Fastly service version
@@ -2143,7 +3810,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the GCS logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2177,96 +3846,111 @@ COMMANDS
   logging gcs update --version=VERSION --name=NAME [<flags>]
     Update a GCS logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the GCS logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the GCS logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the GCS logging object
-        --bucket=BUCKET          The bucket of the GCS bucket
-        --user=USER              Your GCS service account email address. The
-                                 client_email field in your service account
-                                 authentication JSON
-        --secret-key=SECRET-KEY  Your GCS account secret key. The private_key
-                                 field in your service account authentication
-                                 JSON
-        --path=PATH              The path to upload logs to (default '/')
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the GCS logging object
+        --bucket=BUCKET            The bucket of the GCS bucket
+        --user=USER                Your GCS service account email address. The
+                                   client_email field in your service account
+                                   authentication JSON
+        --secret-key=SECRET-KEY    Your GCS account secret key. The private_key
+                                   field in your service account authentication
+                                   JSON
+        --path=PATH                The path to upload logs to (default '/')
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging googlepubsub create --name=NAME --version=VERSION --user=USER --secret-key=SECRET-KEY --topic=TOPIC --project-id=PROJECT-ID [<flags>]
     Create a Google Cloud Pub/Sub logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
-                                 object. Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --user=USER              Your Google Cloud Platform service account
-                                 email address. The client_email field in your
-                                 service account authentication JSON
-        --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
-                                 The private_key field in your service account
-                                 authentication JSON
-        --topic=TOPIC            The Google Cloud Pub/Sub topic to which logs
-                                 will be published
-        --project-id=PROJECT-ID  The ID of your Google Cloud Platform project
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Google Cloud Pub/Sub logging
+                                   object. Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --user=USER                Your Google Cloud Platform service account
+                                   email address. The client_email field in your
+                                   service account authentication JSON
+        --secret-key=SECRET-KEY    Your Google Cloud Platform account secret
+                                   key. The private_key field in your service
+                                   account authentication JSON
+        --topic=TOPIC              The Google Cloud Pub/Sub topic to which logs
+                                   will be published
+        --project-id=PROJECT-ID    The ID of your Google Cloud Platform project
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
 
   logging googlepubsub delete --version=VERSION --name=NAME [<flags>]
     Delete a Google Cloud Pub/Sub logging endpoint on a Fastly service version
@@ -2274,7 +3958,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
                                  object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2310,69 +3996,83 @@ COMMANDS
   logging googlepubsub update --version=VERSION --name=NAME [<flags>]
     Update a Google Cloud Pub/Sub logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
-                                 object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Google Cloud Pub/Sub logging
+                                   object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Google Cloud Pub/Sub logging
-                                 object
-        --user=USER              Your Google Cloud Platform service account
-                                 email address. The client_email field in your
-                                 service account authentication JSON
-        --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
-                                 The private_key field in your service account
-                                 authentication JSON
-        --topic=TOPIC            The Google Cloud Pub/Sub topic to which logs
-                                 will be published
-        --project-id=PROJECT-ID  The ID of your Google Cloud Platform project
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Google Cloud Pub/Sub logging
+                                   object
+        --user=USER                Your Google Cloud Platform service account
+                                   email address. The client_email field in your
+                                   service account authentication JSON
+        --secret-key=SECRET-KEY    Your Google Cloud Platform account secret
+                                   key. The private_key field in your service
+                                   account authentication JSON
+        --topic=TOPIC              The Google Cloud Pub/Sub topic to which logs
+                                   will be published
+        --project-id=PROJECT-ID    The ID of your Google Cloud Platform project
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
 
   logging heroku create --name=NAME --version=VERSION --url=URL --auth-token=AUTH-TOKEN [<flags>]
     Create a Heroku logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Heroku logging object. Used as
-                                 a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --url=URL                The url to stream logs to
-        --auth-token=AUTH-TOKEN  The token to use for authentication
-                                 (https://devcenter.heroku.com/articles/add-on-partner-log-integration)
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Heroku logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --url=URL                  The url to stream logs to
+        --auth-token=AUTH-TOKEN    The token to use for authentication
+                                   (https://devcenter.heroku.com/articles/add-on-partner-log-integration)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging heroku delete --version=VERSION --name=NAME [<flags>]
     Delete a Heroku logging endpoint on a Fastly service version
@@ -2380,7 +4080,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Heroku logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2414,61 +4116,75 @@ COMMANDS
   logging heroku update --version=VERSION --name=NAME [<flags>]
     Update a Heroku logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Heroku logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Heroku logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Heroku logging object
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Heroku logging object
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --url=URL                The url to stream logs to
-        --auth-token=AUTH-TOKEN  The token to use for authentication
-                                 (https://devcenter.heroku.com/articles/add-on-partner-log-integration)
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --url=URL                  The url to stream logs to
+        --auth-token=AUTH-TOKEN    The token to use for authentication
+                                   (https://devcenter.heroku.com/articles/add-on-partner-log-integration)
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging honeycomb create --name=NAME --version=VERSION --dataset=DATASET --auth-token=AUTH-TOKEN [<flags>]
     Create a Honeycomb logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Honeycomb logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --dataset=DATASET        The Honeycomb Dataset you want to log to
-        --auth-token=AUTH-TOKEN  The Write Key from the Account page of your
-                                 Honeycomb account
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Honeycomb logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --dataset=DATASET          The Honeycomb Dataset you want to log to
+        --auth-token=AUTH-TOKEN    The Write Key from the Account page of your
+                                   Honeycomb account
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --format=FORMAT          Apache style log formatting. Your log must
-                                 produce valid JSON that Honeycomb can ingest
+                                   The name of the service
+        --format=FORMAT            Apache style log formatting. Your log must
+                                   produce valid JSON that Honeycomb can ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging honeycomb delete --version=VERSION --name=NAME [<flags>]
     Delete a Honeycomb logging endpoint on a Fastly service version
@@ -2476,7 +4192,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Honeycomb logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2510,42 +4228,51 @@ COMMANDS
   logging honeycomb update --version=VERSION --name=NAME [<flags>]
     Update a Honeycomb logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Honeycomb logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Honeycomb logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Honeycomb logging object
-        --format=FORMAT          Apache style log formatting. Your log must
-                                 produce valid JSON that Honeycomb can ingest
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Honeycomb logging object
+        --format=FORMAT            Apache style log formatting. Your log must
+                                   produce valid JSON that Honeycomb can ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --dataset=DATASET        The Honeycomb Dataset you want to log to
-        --auth-token=AUTH-TOKEN  The Write Key from the Account page of your
-                                 Honeycomb account
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --dataset=DATASET          The Honeycomb Dataset you want to log to
+        --auth-token=AUTH-TOKEN    The Write Key from the Account page of your
+                                   Honeycomb account
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging https create --name=NAME --version=VERSION --url=URL [<flags>]
     Create an HTTPS logging endpoint on a Fastly service version
 
-    -n, --name=NAME                The name of the HTTPS logging object. Used as
-                                   a primary key for API access
+    -n, --name=NAME                The name of the HTTPS logging object.
+                                   Used as a primary key for API access
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
         --url=URL                  URL that log data will be sent to. Must use
                                    the https protocol
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2564,9 +4291,9 @@ COMMANDS
                                    PUT. Defaults to POST if not specified
         --json-format=JSON-FORMAT  Enforces valid JSON formatting for log
                                    entries. Can be disabled 0, array of json
-                                   (wraps JSON log batches in an array) 1, or
-                                   newline delimited json (places each JSON log
-                                   entry onto a new line in a batch) 2
+                                   (wraps JSON log batches in an array) 1,
+                                   or newline delimited json (places each JSON
+                                   log entry onto a new line in a batch) 2
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
         --tls-client-cert=TLS-CLIENT-CERT
@@ -2584,12 +4311,16 @@ COMMANDS
                                    classic (default), loggly, logplex or blank
         --format=FORMAT            Apache style log formatting. Your log must
                                    produce valid JSON that HTTPS can ingest
+        --format-json=FORMAT-JSON  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
                                    (default) or 1
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --response-condition=RESPONSE-CONDITION
@@ -2609,7 +4340,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the HTTPS logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2646,7 +4379,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                The name of the HTTPS logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -2667,9 +4402,9 @@ COMMANDS
                                    PUT. Defaults to POST if not specified
         --json-format=JSON-FORMAT  Enforces valid JSON formatting for log
                                    entries. Can be disabled 0, array of json
-                                   (wraps JSON log batches in an array) 1, or
-                                   newline delimited json (places each JSON log
-                                   entry onto a new line in a batch) 2
+                                   (wraps JSON log batches in an array) 1,
+                                   or newline delimited json (places each JSON
+                                   log entry onto a new line in a batch) 2
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
         --tls-client-cert=TLS-CLIENT-CERT
@@ -2687,12 +4422,16 @@ COMMANDS
                                    classic (default), loggly, logplex or blank
         --format=FORMAT            Apache style log formatting. Your log must
                                    produce valid JSON that HTTPS can ingest
+        --format-json=FORMAT-JSON  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
                                    (default) or 1
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --response-condition=RESPONSE-CONDITION
@@ -2709,12 +4448,14 @@ COMMANDS
   logging kafka create --name=NAME --version=VERSION --topic=TOPIC --brokers=BROKERS [<flags>]
     Create a Kafka logging endpoint on a Fastly service version
 
-    -n, --name=NAME                The name of the Kafka logging object. Used as
-                                   a primary key for API access
+    -n, --name=NAME                The name of the Kafka logging object.
+                                   Used as a primary key for API access
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
         --topic=TOPIC              The Kafka topic to send logs to
         --brokers=BROKERS          A comma-separated list of IP addresses or
                                    hostnames of Kafka brokers
@@ -2726,14 +4467,14 @@ COMMANDS
                                    The codec used for compression of your logs.
                                    One of: gzip, snappy, lz4
         --required-acks=REQUIRED-ACKS
-                                   The Number of acknowledgements a leader must
-                                   receive before a write is considered
+                                   The Number of acknowledgements a leader
+                                   must receive before a write is considered
                                    successful. One of: 1 (default) One server
                                    needs to respond. 0 No servers need to
                                    respond. -1 Wait for all in-sync replicas to
                                    respond
-        --use-tls                  Whether to use TLS for secure logging. Can be
-                                   either true or false
+        --use-tls                  Whether to use TLS for secure logging.
+                                   Can be either true or false
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
         --tls-client-cert=TLS-CLIENT-CERT
@@ -2748,12 +4489,16 @@ COMMANDS
                                    or a Subject Alternative Name (SAN)
         --format=FORMAT            Apache style log formatting. Your log must
                                    produce valid JSON that Kafka can ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
                                    (default) or 1
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --response-condition=RESPONSE-CONDITION
@@ -2779,7 +4524,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Kafka logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2816,7 +4563,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                The name of the Kafka logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -2830,14 +4579,14 @@ COMMANDS
                                    The codec used for compression of your logs.
                                    One of: gzip, snappy, lz4
         --required-acks=REQUIRED-ACKS
-                                   The Number of acknowledgements a leader must
-                                   receive before a write is considered
+                                   The Number of acknowledgements a leader
+                                   must receive before a write is considered
                                    successful. One of: 1 (default) One server
                                    needs to respond. 0 No servers need to
                                    respond. -1 Wait for all in-sync replicas to
                                    respond
-        --use-tls                  Whether to use TLS for secure logging. Can be
-                                   either true or false
+        --use-tls                  Whether to use TLS for secure logging.
+                                   Can be either true or false
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
         --tls-client-cert=TLS-CLIENT-CERT
@@ -2852,12 +4601,16 @@ COMMANDS
                                    or a Subject Alternative Name (SAN)
         --format=FORMAT            Apache style log formatting. Your log must
                                    produce valid JSON that Kafka can ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
                                    (default) or 1
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --response-condition=RESPONSE-CONDITION
@@ -2880,8 +4633,8 @@ COMMANDS
   logging kinesis create --name=NAME --version=VERSION --stream-name=STREAM-NAME --region=REGION [<flags>]
     Create an Amazon Kinesis logging endpoint on a Fastly service version
 
-    -n, --name=NAME                The name of the Kinesis logging object. Used
-                                   as a primary key for API access
+    -n, --name=NAME                The name of the Kinesis logging object.
+                                   Used as a primary key for API access
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --stream-name=STREAM-NAME  The Amazon Kinesis stream to send logs to
@@ -2893,12 +4646,18 @@ COMMANDS
                                    Amazon Kinesis stream
         --iam-role=IAM-ROLE        The IAM role ARN for logging
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
         --service-name=SERVICE-NAME
                                    The name of the service
         --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
@@ -2907,8 +4666,8 @@ COMMANDS
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
                                    execute
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
 
@@ -2918,7 +4677,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Kinesis logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -2955,7 +4716,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                The name of the Kinesis logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -2969,6 +4732,10 @@ COMMANDS
         --region=REGION            The AWS region where the Kinesis stream
                                    exists
         --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
@@ -2977,47 +4744,54 @@ COMMANDS
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
                                    execute
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
 
   logging logentries create --name=NAME --version=VERSION [<flags>]
     Create a Logentries logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Logentries logging object. Used
-                                 as a primary key for API access
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Logentries logging object.
+                                   Used as a primary key for API access
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --port=PORT              The port number
-        --use-tls                Whether to use TLS for secure logging. Can be
-                                 either true or false
-        --auth-token=AUTH-TOKEN  Use token based authentication
-                                 (https://logentries.com/doc/input-token/)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --port=PORT                The port number
+        --use-tls                  Whether to use TLS for secure logging.
+                                   Can be either true or false
+        --auth-token=AUTH-TOKEN    Use token based authentication
+                                   (https://logentries.com/doc/input-token/)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
-        --region=REGION          The region to which to stream logs
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
+        --region=REGION            The region to which to stream logs
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
 
   logging logentries delete --version=VERSION --name=NAME [<flags>]
     Delete a Logentries logging endpoint on a Fastly service version
@@ -3025,7 +4799,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Logentries logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3059,67 +4835,81 @@ COMMANDS
   logging logentries update --version=VERSION --name=NAME [<flags>]
     Update a Logentries logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Logentries logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Logentries logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Logentries logging object
-        --port=PORT              The port number
-        --use-tls                Whether to use TLS for secure logging. Can be
-                                 either true or false
-        --auth-token=AUTH-TOKEN  Use token based authentication
-                                 (https://logentries.com/doc/input-token/)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Logentries logging object
+        --port=PORT                The port number
+        --use-tls                  Whether to use TLS for secure logging.
+                                   Can be either true or false
+        --auth-token=AUTH-TOKEN    Use token based authentication
+                                   (https://logentries.com/doc/input-token/)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
-        --region=REGION          The region to which to stream logs
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
+        --region=REGION            The region to which to stream logs
 
   logging loggly create --name=NAME --version=VERSION --auth-token=AUTH-TOKEN [<flags>]
     Create a Loggly logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Loggly logging object. Used as
-                                 a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --auth-token=AUTH-TOKEN  The token to use for authentication
-                                 (https://www.loggly.com/docs/customer-token-authentication-token/)
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Loggly logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --auth-token=AUTH-TOKEN    The token to use for authentication
+                                   (https://www.loggly.com/docs/customer-token-authentication-token/)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging loggly delete --version=VERSION --name=NAME [<flags>]
     Delete a Loggly logging endpoint on a Fastly service version
@@ -3127,7 +4917,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Loggly logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3161,59 +4953,73 @@ COMMANDS
   logging loggly update --version=VERSION --name=NAME [<flags>]
     Update a Loggly logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Loggly logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Loggly logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Loggly logging object
-        --auth-token=AUTH-TOKEN  The token to use for authentication
-                                 (https://www.loggly.com/docs/customer-token-authentication-token/)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Loggly logging object
+        --auth-token=AUTH-TOKEN    The token to use for authentication
+                                   (https://www.loggly.com/docs/customer-token-authentication-token/)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging logshuttle create --name=NAME --version=VERSION --url=URL --auth-token=AUTH-TOKEN [<flags>]
     Create a Logshuttle logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Logshuttle logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --url=URL                Your Log Shuttle endpoint url
-        --auth-token=AUTH-TOKEN  The data authentication token associated with
-                                 this endpoint
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Logshuttle logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --url=URL                  Your Log Shuttle endpoint url
+        --auth-token=AUTH-TOKEN    The data authentication token associated with
+                                   this endpoint
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging logshuttle delete --version=VERSION --name=NAME [<flags>]
     Delete a Logshuttle logging endpoint on a Fastly service version
@@ -3221,7 +5027,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Logshuttle logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3255,59 +5063,72 @@ COMMANDS
   logging logshuttle update --version=VERSION --name=NAME [<flags>]
     Update a Logshuttle logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Logshuttle logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Logshuttle logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Logshuttle logging object
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Logshuttle logging object
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --url=URL                Your Log Shuttle endpoint url
-        --auth-token=AUTH-TOKEN  The data authentication token associated with
-                                 this endpoint
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --url=URL                  Your Log Shuttle endpoint url
+        --auth-token=AUTH-TOKEN    The data authentication token associated with
+                                   this endpoint
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging newrelic create --key=KEY --name=NAME --version=VERSION [<flags>]
     Create an New Relic logging endpoint attached to the specified service
     version
 
-        --key=KEY                The Insert API key from the Account page of
-                                 your New Relic account
-        --name=NAME              The name for the real-time logging
-                                 configuration
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --format=FORMAT          A Fastly log format string. Must produce valid
-                                 JSON that New Relic Logs can ingest
+        --key=KEY                  The Insert API key from the Account page of
+                                   your New Relic account
+        --name=NAME                The name for the real-time logging
+                                   configuration
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --format=FORMAT            A Fastly log format string. Must produce
+                                   valid JSON that New Relic Logs can ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed
-        --region=REGION          The region to which to stream logs
+                                   The version of the custom logging format used
+                                   for the configured endpoint
+        --placement=PLACEMENT      Where in the generated VCL the logging call
+                                   should be placed
+        --region=REGION            The region to which to stream logs
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+                                   The name of an existing condition in the
+                                   configured endpoint
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
+                                   The name of the service
 
   logging newrelic delete --name=NAME --version=VERSION [<flags>]
     Delete the New Relic Logs logging object for a particular service and
@@ -3318,7 +5139,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -3353,85 +5176,99 @@ COMMANDS
   logging newrelic update --name=NAME --version=VERSION [<flags>]
     Update a New Relic Logs logging object for a particular service and version
 
-        --name=NAME              The name for the real-time logging
-                                 configuration to update
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --format=FORMAT          A Fastly log format string. Must produce valid
-                                 JSON that New Relic Logs can ingest
+        --name=NAME                The name for the real-time logging
+                                   configuration to update
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --format=FORMAT            A Fastly log format string. Must produce
+                                   valid JSON that New Relic Logs can ingest
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint
-        --key=KEY                The Insert API key from the Account page of
-                                 your New Relic account
-        --new-name=NEW-NAME      The name for the real-time logging
-                                 configuration
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed
-        --region=REGION          The region to which to stream logs
+                                   The version of the custom logging format used
+                                   for the configured endpoint
+        --key=KEY                  The Insert API key from the Account page of
+                                   your New Relic account
+        --new-name=NEW-NAME        The name for the real-time logging
+                                   configuration
+        --placement=PLACEMENT      Where in the generated VCL the logging call
+                                   should be placed
+        --region=REGION            The region to which to stream logs
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+                                   The name of an existing condition in the
+                                   configured endpoint
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
+                                   The name of the service
 
   logging openstack create --name=NAME --version=VERSION --bucket=BUCKET --access-key=ACCESS-KEY --user=USER --url=URL [<flags>]
     Create an OpenStack logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the OpenStack logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --bucket=BUCKET          The name of your OpenStack container
-        --access-key=ACCESS-KEY  Your OpenStack account access key
-        --user=USER              The username for your OpenStack account
-        --url=URL                Your OpenStack auth url
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the OpenStack logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --bucket=BUCKET            The name of your OpenStack container
+        --access-key=ACCESS-KEY    Your OpenStack account access key
+        --user=USER                The username for your OpenStack account
+        --url=URL                  Your OpenStack auth url
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging openstack delete --version=VERSION --name=NAME [<flags>]
     Delete an OpenStack logging endpoint on a Fastly service version
@@ -3439,7 +5276,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the OpenStack logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3473,89 +5312,104 @@ COMMANDS
   logging openstack update --version=VERSION --name=NAME [<flags>]
     Update an OpenStack logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the OpenStack logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the OpenStack logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the OpenStack logging object
-        --bucket=BUCKET          The name of the Openstack Space
-        --access-key=ACCESS-KEY  Your OpenStack account access key
-        --user=USER              The username for your OpenStack account.
-        --url=URL                Your OpenStack auth url.
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the OpenStack logging object
+        --bucket=BUCKET            The name of the Openstack Space
+        --access-key=ACCESS-KEY    Your OpenStack account access key
+        --user=USER                The username for your OpenStack account.
+        --url=URL                  Your OpenStack auth url.
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging papertrail create --name=NAME --version=VERSION --address=ADDRESS [<flags>]
     Create a Papertrail logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Papertrail logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --address=ADDRESS        A hostname or IPv4 address
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Papertrail logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --address=ADDRESS          A hostname or IPv4 address
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --port=PORT              The port number
+                                   The name of the service
+        --port=PORT                The port number
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
-        --format=FORMAT          Apache style log formatting
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
 
   logging papertrail delete --version=VERSION --name=NAME [<flags>]
     Delete a Papertrail logging endpoint on a Fastly service version
@@ -3563,7 +5417,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Papertrail logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3597,98 +5453,113 @@ COMMANDS
   logging papertrail update --version=VERSION --name=NAME [<flags>]
     Update a Papertrail logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Papertrail logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Papertrail logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Papertrail logging object
-        --address=ADDRESS        A hostname or IPv4 address
-        --port=PORT              The port number
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Papertrail logging object
+        --address=ADDRESS          A hostname or IPv4 address
+        --port=PORT                The port number
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
-        --format=FORMAT          Apache style log formatting
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
 
   logging s3 create --name=NAME --version=VERSION --bucket=BUCKET [<flags>]
     Create an Amazon S3 logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the S3 logging object. Used as a
-                                 primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --bucket=BUCKET          Your S3 bucket name
-        --access-key=ACCESS-KEY  Your S3 account access key
-        --secret-key=SECRET-KEY  Your S3 account secret key
-        --iam-role=IAM-ROLE      The IAM role ARN for logging
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the S3 logging object. Used as a
+                                   primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --bucket=BUCKET            Your S3 bucket name
+        --access-key=ACCESS-KEY    Your S3 account access key
+        --secret-key=SECRET-KEY    Your S3 account secret key
+        --iam-role=IAM-ROLE        The IAM role ARN for logging
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --domain=DOMAIN          The domain of the S3 endpoint
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --domain=DOMAIN            The domain of the S3 endpoint
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --redundancy=REDUNDANCY  The S3 redundancy level. Can be either standard
-                                 or reduced_redundancy
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --redundancy=REDUNDANCY    The S3 redundancy level. Can be either
+                                   standard or reduced_redundancy
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --server-side-encryption=SERVER-SIDE-ENCRYPTION
-                                 Set to enable S3 Server Side Encryption. Can be
-                                 either AES256 or aws:kms
+                                   Set to enable S3 Server Side Encryption.
+                                   Can be either AES256 or aws:kms
         --server-side-encryption-kms-key-id=SERVER-SIDE-ENCRYPTION-KMS-KEY-ID
-                                 Server-side KMS Key ID. Must be set if
-                                 server-side-encryption is set to aws:kms
+                                   Server-side KMS Key ID. Must be set if
+                                   server-side-encryption is set to aws:kms
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging s3 delete --version=VERSION --name=NAME [<flags>]
     Delete a S3 logging endpoint on a Fastly service version
@@ -3696,7 +5567,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the S3 logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3730,95 +5603,110 @@ COMMANDS
   logging s3 update --version=VERSION --name=NAME [<flags>]
     Update a S3 logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the S3 logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the S3 logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the S3 logging object
-        --bucket=BUCKET          Your S3 bucket name
-        --access-key=ACCESS-KEY  Your S3 account access key
-        --secret-key=SECRET-KEY  Your S3 account secret key
-        --iam-role=IAM-ROLE      The IAM role ARN for logging
-        --domain=DOMAIN          The domain of the S3 endpoint
-        --path=PATH              The path to upload logs to
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the S3 logging object
+        --bucket=BUCKET            Your S3 bucket name
+        --access-key=ACCESS-KEY    Your S3 account access key
+        --secret-key=SECRET-KEY    Your S3 account secret key
+        --iam-role=IAM-ROLE        The IAM role ARN for logging
+        --domain=DOMAIN            The domain of the S3 endpoint
+        --path=PATH                The path to upload logs to
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --redundancy=REDUNDANCY  The S3 redundancy level. Can be either standard
-                                 or reduced_redundancy
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --redundancy=REDUNDANCY    The S3 redundancy level. Can be either
+                                   standard or reduced_redundancy
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
         --server-side-encryption=SERVER-SIDE-ENCRYPTION
-                                 Set to enable S3 Server Side Encryption. Can be
-                                 either AES256 or aws:kms
+                                   Set to enable S3 Server Side Encryption.
+                                   Can be either AES256 or aws:kms
         --server-side-encryption-kms-key-id=SERVER-SIDE-ENCRYPTION-KMS-KEY-ID
-                                 Server-side KMS Key ID. Must be set if
-                                 server-side-encryption is set to aws:kms
+                                   Server-side KMS Key ID. Must be set if
+                                   server-side-encryption is set to aws:kms
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging scalyr create --name=NAME --version=VERSION --auth-token=AUTH-TOKEN [<flags>]
     Create a Scalyr logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Scalyr logging object. Used as
-                                 a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --auth-token=AUTH-TOKEN  The token to use for authentication
-                                 (https://www.scalyr.com/keys)
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Scalyr logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --auth-token=AUTH-TOKEN    The token to use for authentication
+                                   (https://www.scalyr.com/keys)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --region=REGION          The region that log data will be sent to. One
-                                 of US or EU. Defaults to US if undefined
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --region=REGION            The region that log data will be sent to.
+                                   One of US or EU. Defaults to US if undefined
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging scalyr delete --version=VERSION --name=NAME [<flags>]
     Delete a Scalyr logging endpoint on a Fastly service version
@@ -3826,7 +5714,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Scalyr logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3860,96 +5750,111 @@ COMMANDS
   logging scalyr update --version=VERSION --name=NAME [<flags>]
     Update a Scalyr logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Scalyr logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Scalyr logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Scalyr logging object
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Scalyr logging object
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --auth-token=AUTH-TOKEN  The token to use for authentication
-                                 (https://www.scalyr.com/keys)
-        --region=REGION          The region that log data will be sent to. One
-                                 of US or EU. Defaults to US if undefined
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --auth-token=AUTH-TOKEN    The token to use for authentication
+                                   (https://www.scalyr.com/keys)
+        --region=REGION            The region that log data will be sent to.
+                                   One of US or EU. Defaults to US if undefined
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
 
   logging sftp create --name=NAME --version=VERSION --address=ADDRESS --user=USER --ssh-known-hosts=SSH-KNOWN-HOSTS [<flags>]
     Create an SFTP logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the SFTP logging object. Used as a
-                                 primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --address=ADDRESS        The hostname or IPv4 addres
-        --user=USER              The username for the server
+    -n, --name=NAME                The name of the SFTP logging object. Used as
+                                   a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --address=ADDRESS          The hostname or IPv4 addres
+        --user=USER                The username for the server
         --ssh-known-hosts=SSH-KNOWN-HOSTS
-                                 A list of host keys for all hosts we can
-                                 connect to over SFTP
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
-        --service-name=SERVICE-NAME
-                                 The name of the service
-        --port=PORT              The port number
-        --password=PASSWORD      The password for the server. If both password
-                                 and secret_key are passed, secret_key will be
-                                 used in preference
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
-        --secret-key=SECRET-KEY  The SSH private key for the server. If both
-                                 password and secret_key are passed, secret_key
-                                 will be used in preference
-        --path=PATH              The path to upload logs to. The directory must
-                                 exist on the SFTP server before logs can be
-                                 saved to it
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --format=FORMAT          Apache style log formatting
+                                   A list of host keys for all hosts we can
+                                   connect to over SFTP
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
+        --port=PORT                The port number
+        --password=PASSWORD        The password for the server. If both password
+                                   and secret_key are passed, secret_key will be
+                                   used in preference
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
+        --secret-key=SECRET-KEY    The SSH private key for the server.
+                                   If both password and secret_key are passed,
+                                   secret_key will be used in preference
+        --path=PATH                The path to upload logs to. The directory
+                                   must exist on the SFTP server before logs can
+                                   be saved to it
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging sftp delete --version=VERSION --name=NAME [<flags>]
     Delete an SFTP logging endpoint on a Fastly service version
@@ -3957,7 +5862,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the SFTP logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3991,76 +5898,86 @@ COMMANDS
   logging sftp update --version=VERSION --name=NAME [<flags>]
     Update an SFTP logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the SFTP logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the SFTP logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the SFTP logging object
-        --address=ADDRESS        The hostname or IPv4 address
-        --port=PORT              The port number
-        --public-key=PUBLIC-KEY  A PGP public key that Fastly will use to
-                                 encrypt your log files before writing them to
-                                 disk
-        --secret-key=SECRET-KEY  The SSH private key for the server. If both
-                                 password and secret_key are passed, secret_key
-                                 will be used in preference
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the SFTP logging object
+        --address=ADDRESS          The hostname or IPv4 address
+        --port=PORT                The port number
+        --public-key=PUBLIC-KEY    A PGP public key that Fastly will use to
+                                   encrypt your log files before writing them to
+                                   disk
+        --secret-key=SECRET-KEY    The SSH private key for the server.
+                                   If both password and secret_key are passed,
+                                   secret_key will be used in preference
         --ssh-known-hosts=SSH-KNOWN-HOSTS
-                                 A list of host keys for all hosts we can
-                                 connect to over SFTP
-        --user=USER              The username for the server
-        --password=PASSWORD      The password for the server. If both password
-                                 and secret_key are passed, secret_key will be
-                                 used in preference
-        --path=PATH              The path to upload logs to. The directory must
-                                 exist on the SFTP server before logs can be
-                                 saved to it
-        --period=PERIOD          How frequently log files are finalized so they
-                                 can be available for reading (in seconds,
-                                 default 3600)
-        --format=FORMAT          Apache style log formatting
+                                   A list of host keys for all hosts we can
+                                   connect to over SFTP
+        --user=USER                The username for the server
+        --password=PASSWORD        The password for the server. If both password
+                                   and secret_key are passed, secret_key will be
+                                   used in preference
+        --path=PATH                The path to upload logs to. The directory
+                                   must exist on the SFTP server before logs can
+                                   be saved to it
+        --period=PERIOD            How frequently log files are finalized
+                                   so they can be available for reading (in
+                                   seconds, default 3600)
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (default) or 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either 2
+                                   (default) or 1
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
-        --gzip-level=GZIP-LEVEL  What level of GZIP encoding to have when
-                                 dumping logs (default 0, no compression)
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
+        --gzip-level=GZIP-LEVEL    What level of GZIP encoding to have when
+                                   dumping logs (default 0, no compression)
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --timestamp-format=TIMESTAMP-FORMAT
-                                 strftime specified timestamp formatting
-                                 (default "%Y-%m-%dT%H:%M:%S.000")
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug
+                                   strftime specified timestamp formatting
+                                   (default "%Y-%m-%dT%H:%M:%S.000")
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug
         --compression-codec=COMPRESSION-CODEC
-                                 The codec used for compression of your logs.
-                                 Valid values are zstd, snappy, and gzip. If the
-                                 specified codec is "gzip", gzip_level will
-                                 default to 3. To specify a different level,
-                                 leave compression_codec blank and explicitly
-                                 set the level using gzip_level. Specifying both
-                                 compression_codec and gzip_level in the same
-                                 API request will result in an error.
+                                   The codec used for compression of your logs.
+                                   Valid values are zstd, snappy, and gzip.
+                                   If the specified codec is "gzip", gzip_level
+                                   will default to 3. To specify a different
+                                   level, leave compression_codec blank and
+                                   explicitly set the level using gzip_level.
+                                   Specifying both compression_codec and
+                                   gzip_level in the same API request will
+                                   result in an error.
 
   logging splunk create --name=NAME --version=VERSION --url=URL [<flags>]
     Create a Splunk logging endpoint on a Fastly service version
 
-    -n, --name=NAME                The name of the Splunk logging object. Used
-                                   as a primary key for API access
+    -n, --name=NAME                The name of the Splunk logging object.
+                                   Used as a primary key for API access
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
         --url=URL                  The URL to POST to
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -4079,6 +5996,10 @@ COMMANDS
                                    The client private key used to make
                                    authenticated requests. Must be in PEM format
         --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
@@ -4087,8 +6008,8 @@ COMMANDS
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
                                    execute
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
         --auth-token=AUTH-TOKEN    A Splunk token for use in posting logs over
@@ -4100,7 +6021,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Splunk logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -4137,7 +6060,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                The name of the Splunk logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -4158,6 +6083,10 @@ COMMANDS
                                    The client private key used to make
                                    authenticated requests. Must be in PEM format
         --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
@@ -4166,8 +6095,8 @@ COMMANDS
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
                                    execute
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug. This field is not required and has
                                    no default value
@@ -4176,37 +6105,44 @@ COMMANDS
   logging sumologic create --name=NAME --version=VERSION --url=URL [<flags>]
     Create a Sumologic logging endpoint on a Fastly service version
 
-    -n, --name=NAME              The name of the Sumologic logging object. Used
-                                 as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --url=URL                The URL to POST to
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+    -n, --name=NAME                The name of the Sumologic logging object.
+                                   Used as a primary key for API access
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+        --url=URL                  The URL to POST to
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
 
   logging sumologic delete --version=VERSION --name=NAME [<flags>]
     Delete a Sumologic logging endpoint on a Fastly service version
@@ -4214,7 +6150,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Sumologic logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -4248,55 +6186,64 @@ COMMANDS
   logging sumologic update --version=VERSION --name=NAME [<flags>]
     Update a Sumologic logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-    -n, --name=NAME              The name of the Sumologic logging object
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --version=VERSION          'latest', 'active', or the number of a
+                                   specific version
+        --autoclone                If the selected service version is not
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
+    -n, --name=NAME                The name of the Sumologic logging object
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --new-name=NEW-NAME      New name of the Sumologic logging object
-        --url=URL                The URL to POST to
-        --format=FORMAT          Apache style log formatting
+                                   The name of the service
+        --new-name=NEW-NAME        New name of the Sumologic logging object
+        --url=URL                  The URL to POST to
+        --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
-                                 The version of the custom logging format used
-                                 for the configured endpoint. Can be either 2
-                                 (the default, version 2 log format) or 1 (the
-                                 version 1 log format). The logging call gets
-                                 placed by default in vcl_log if format_version
-                                 is set to 2 and in vcl_deliver if
-                                 format_version is set to 1
+                                   The version of the custom logging format used
+                                   for the configured endpoint. Can be either
+                                   2 (the default, version 2 log format) or
+                                   1 (the version 1 log format). The logging
+                                   call gets placed by default in vcl_log if
+                                   format_version is set to 2 and in vcl_deliver
+                                   if format_version is set to 1
         --response-condition=RESPONSE-CONDITION
-                                 The name of an existing condition in the
-                                 configured endpoint, or leave blank to always
-                                 execute
+                                   The name of an existing condition in the
+                                   configured endpoint, or leave blank to always
+                                   execute
         --message-type=MESSAGE-TYPE
-                                 How the message should be formatted. One of:
-                                 classic (default), loggly, logplex or blank
-        --placement=PLACEMENT    Where in the generated VCL the logging call
-                                 should be placed, overriding any format_version
-                                 default. Can be none or waf_debug. This field
-                                 is not required and has no default value
+                                   How the message should be formatted. One of:
+                                   classic (default), loggly, logplex or blank
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
+                                   format_version default. Can be none or
+                                   waf_debug. This field is not required and has
+                                   no default value
 
   logging syslog create --name=NAME --version=VERSION --address=ADDRESS [<flags>]
     Create a Syslog logging endpoint on a Fastly service version
 
-    -n, --name=NAME                The name of the Syslog logging object. Used
-                                   as a primary key for API access
+    -n, --name=NAME                The name of the Syslog logging object.
+                                   Used as a primary key for API access
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
         --address=ADDRESS          A hostname or IPv4 address
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
         --service-name=SERVICE-NAME
                                    The name of the service
         --port=PORT                The port number
-        --use-tls                  Whether to use TLS for secure logging. Can be
-                                   either true or false
+        --use-tls                  Whether to use TLS for secure logging.
+                                   Can be either true or false
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
         --tls-hostname=TLS-HOSTNAME
@@ -4311,6 +6258,10 @@ COMMANDS
         --auth-token=AUTH-TOKEN    Whether to prepend each message with a
                                    specific token
         --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
@@ -4322,8 +6273,8 @@ COMMANDS
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
                                    execute
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
 
@@ -4333,7 +6284,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -n, --name=NAME              The name of the Syslog logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -4370,7 +6323,9 @@ COMMANDS
         --version=VERSION          'latest', 'active', or the number of a
                                    specific version
         --autoclone                If the selected service version is not
-                                   editable, clone it and use the clone.
+                                   editable, clone it and use the clone
+                                   (--autoclone=dry-run reports whether a clone
+                                   is needed)
     -n, --name=NAME                The name of the Syslog logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -4379,8 +6334,8 @@ COMMANDS
         --new-name=NEW-NAME        New name of the Syslog logging object
         --address=ADDRESS          A hostname or IPv4 address
         --port=PORT                The port number
-        --use-tls                  Whether to use TLS for secure logging. Can be
-                                   either true or false
+        --use-tls                  Whether to use TLS for secure logging.
+                                   Can be either true or false
         --tls-ca-cert=TLS-CA-CERT  A secure certificate to authenticate the
                                    server with. Must be in PEM format
         --tls-hostname=TLS-HOSTNAME
@@ -4395,6 +6350,10 @@ COMMANDS
         --auth-token=AUTH-TOKEN    Whether to prepend each message with a
                                    specific token
         --format=FORMAT            Apache style log formatting
+        --json-format=JSON-FORMAT  Path to a JSON log format template,
+                                   with {{expression}} placeholders for VCL
+                                   expressions, to validate and use in place of
+                                   --format
         --format-version=FORMAT-VERSION
                                    The version of the custom logging format used
                                    for the configured endpoint. Can be either 2
@@ -4406,18 +6365,80 @@ COMMANDS
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
                                    execute
-        --placement=PLACEMENT      Where in the generated VCL the logging call
-                                   should be placed, overriding any
+        --placement=PLACEMENT      Where in the generated VCL the logging
+                                   call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
 
+  maintenance disable [<flags>]
+    Activate a new service version without the maintenance page
+
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  maintenance enable [<flags>]
+    Activate a new service version that responds to every request with a
+    maintenance page
+
+        --content-type="text/html"
+                                 The Content-Type of the maintenance page
+        --message-file=MESSAGE-FILE
+                                 Path to the maintenance page to serve (defaults
+                                 to a simple HTML page)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --status=503             The HTTP status code of the maintenance page
+
+  monitor generate [<flags>]
+    Generate an uptime check for each domain of a service version, for an
+    external monitoring tool
+
+        --blackbox-exporter="127.0.0.1:9115"
+                                 Address of the Prometheus blackbox exporter
+                                 (blackbox format only)
+        --expected-status=200    HTTP status the checks expect (not used by the
+                                 blackbox format, whose http_2xx module accepts
+                                 any 2xx)
+        --format=terraform       Format of the checks: terraform (Datadog
+                                 synthetic tests), checkly (Checkly API checks)
+                                 or blackbox (Prometheus scrape config)
+        --interval=1m            How often the checks run
+        --path="/"               Path requested by the checks
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'active')
+
+  origin switch --from=FROM --to=TO [<flags>]
+    Activate a new service version that routes the requests for one backend to
+    another
+
+        --canary=CANARY          Only route this percentage of the requests to
+                                 the new backend (1-99)
+        --from=FROM              Name of the backend that currently serves the
+                                 requests
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --to=TO                  Name of the backend to route the requests to
+
   pops
     List Fastly datacenters
 
 
-  profile create [<profile>]
+  profile create [<flags>] [<profile>]
     Create user profile
 
+    --service-id=SERVICE-ID  Default service of the profile, for commands
+                             not given --service-id, FASTLY_SERVICE_ID or a
+                             fastly.toml service_id
 
   profile delete <profile>
     Delete user profile
@@ -4431,9 +6452,12 @@ COMMANDS
     Switch user profile
 
 
-  profile update [<profile>]
+  profile update [<flags>] [<profile>]
     Update user profile
 
+    --service-id=SERVICE-ID  Default service of the profile, for commands
+                             not given --service-id, FASTLY_SERVICE_ID or a
+                             fastly.toml service_id (empty to unset)
 
   purge [<flags>]
     Invalidate objects in the Fastly cache
@@ -4451,6 +6475,92 @@ COMMANDS
                                  rather than making them inaccessible
         --url=URL                Purge an individual URL
 
+  rate-limit [<flags>]
+    Show the last observed API rate limit of the API token
+
+    -j, --json  Render output as JSON
+
+  route preview --url=URL [<flags>]
+    Explain which backend a request would be routed to and which headers would
+    be applied, without sending it
+
+        --url=URL                URL of the request (e.g.
+                                 https://www.example.com/api/x)
+        --client-ip="127.0.0.1"  IP address of the client (client.ip)
+    -H, --header=HEADER ...      Request header in the form 'Name: value' (may
+                                 be repeated)
+        --method="GET"           HTTP method of the request
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'active')
+
+  scan headers --url=URL [<flags>]
+    Grade the security headers of a response served by Fastly
+
+        --autoclone              If the selected service version is not
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+        --fix                    Offer to create header objects on the service
+                                 that set the missing or weak headers
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --url=URL                URL to request
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'latest')
+
+  schedule add --cron=CRON --command=COMMAND [<flags>]
+    Add a CLI command to run on a cron schedule
+
+    --cron=CRON        When to run the command, as a cron expression in local
+                       time (e.g. '0 3 * * *' for 3am every day)
+    --command=COMMAND  The CLI command to run, without the leading 'fastly'
+                       (e.g. 'purge --all --service-id 123')
+    --name=NAME        The name of the job (defaults to job-N)
+
+  schedule delete --name=NAME
+    Delete a scheduled job
+
+    -n, --name=NAME  The name of the job
+
+  schedule list [<flags>]
+    List the scheduled jobs
+
+    -j, --json  Render output as JSON
+
+  schedule run [<flags>]
+    Run the scheduled jobs when they're due, until interrupted (e.g. with
+    Ctrl-C)
+
+    --once  Run the jobs that are due (including any missed since their last
+            run) and exit, e.g. from a system scheduler
+
+  search [<flags>] <term>
+    Search service configuration across the account
+
+    -j, --json                   Render output as JSON
+        --resource=RESOURCE ...  Limit the search to a resource type (domain,
+                                 backend, snippet, logging)
+
+  service activity [<flags>]
+    Summarise recent activations, configuration changes and traffic for a Fastly
+    service
+
+        --all-services           Report on every service in the account
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --since="7d"             How far back to report, e.g. 7d, 2w or 12h
+
   service create --name=NAME [<flags>]
     Create a Fastly service
 
@@ -4480,17 +6590,34 @@ COMMANDS
   service list [<flags>]
     List Fastly services
 
-        --direction=ascend   Direction in which to sort results
-    -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
-        --per-page=PER-PAGE  Number of records per page
-        --sort="created"     Field on which to sort
+        --direction=ascend     Direction in which to sort results
+    -j, --json                 Render output as JSON
+        --max-items=MAX-ITEMS  Maximum number of services to list
+        --page=PAGE            Page number of data set to fetch
+        --per-page=PER-PAGE    Number of records per page
+        --sort="created"       Field on which to sort
 
   service search --name=NAME
     Search for a Fastly service by name
 
     -n, --name=NAME  Service name
 
+  service smoke-test --version=VERSION --spec=SPEC [<flags>]
+    Check the responses of a service version's domains against the assertions of
+    a YAML spec file
+
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version
+        --spec=SPEC              Path to the YAML file of tests to run
+        --wait=WAIT              Retry failing tests for up to this long, e.g.
+                                 while a version propagates (e.g. 2m)
+        --rollback-on-failure    If any test fails and the version is active,
+                                 activate the previously activated version
+
   service update [<flags>]
     Update a Fastly service
 
@@ -4511,7 +6638,16 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+        --smoke-test=SMOKE-TEST  After activating, check the service's domains
+                                 against the assertions of this YAML spec file
+                                 (see 'fastly service smoke-test')
+        --wait=WAIT              Retry failing smoke tests for up to this long
+                                 while the version propagates (e.g. 2m)
+        --rollback-on-failure    If any smoke test fails, activate the
+                                 previously active version again
 
   service-version clone --version=VERSION [<flags>]
     Clone a Fastly service version
@@ -4522,6 +6658,10 @@ COMMANDS
                                  The name of the service
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
+        --from-service=FROM-SERVICE
+                                 Copy the configuration of --version of this
+                                 service ID into a new draft version of the
+                                 current service
 
   service-version deactivate --version=VERSION [<flags>]
     Deactivate a Fastly service version
@@ -4552,6 +6692,19 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
 
+  service-version prune [<flags>]
+    Identify old draft service versions that were never activated
+
+    -j, --json                   Render output as JSON
+        --keep=20                Number of the most recent service versions to
+                                 exclude
+        --older-than=OLDER-THAN  Only include versions last edited longer ago
+                                 than this duration (e.g. 720h)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   service-version update --version=VERSION [<flags>]
     Update a Fastly service version
 
@@ -4562,9 +6715,28 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
         --comment=COMMENT        Human-readable comment
 
+  stats export --to=TO [<flags>]
+    Export historical stats for a Fastly service to files partitioned by date,
+    locally or in S3
+
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --to=TO                  Destination: a local directory or
+                                 s3://bucket/prefix (credentials and region are
+                                 read from the AWS_* environment variables)
+        --granularity=hour       Aggregation period (minute/hour/day)
+        --last="1d"              How far back to export, e.g. 12h, 30d or 1y
+        --region=REGION          Filter by region ('stats regions' to list)
+        --format=csv             File format (csv/json). JSON files have a stats
+                                 block per line
+
   stats historical [<flags>]
     View historical stats for a Fastly service
 
@@ -4579,6 +6751,22 @@ COMMANDS
         --region=REGION          Filter by region ('stats regions' to list)
         --format=FORMAT          Output format (json)
 
+  stats pops [<flags>]
+    View a Fastly service's recent traffic broken down by POP
+
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --geojson=GEOJSON        Write a GeoJSON FeatureCollection of the POPs
+                                 and their traffic to this file, for plotting a
+                                 heat map
+    -j, --json                   Render output as JSON
+        --last=1m                Period of traffic to report, up to 2m (the
+                                 retention of realtime stats)
+        --metric="requests"      Stats field to report, e.g. requests,
+                                 bandwidth, hits, miss or status_5xx
+
   stats realtime [<flags>]
     View realtime stats for a Fastly service
 
@@ -4592,9 +6780,29 @@ COMMANDS
     List stats regions
 
 
-  update
+  support-bundle [<flags>]
+    Generate an archive of diagnostic information for Fastly support
+
+    --file=FILE  Path to write the tar.gz archive to (default:
+                 fastly-support-bundle-<TIMESTAMP>.tar.gz)
+
+  update [<flags>]
     Update the CLI to the latest version
 
+    --channel=CHANNEL  Release channel to update from: stable (the default) or
+                       beta, which includes pre-releases
+    --rollback         Restore the binary that the last update replaced
+    --version=VERSION  Update (or downgrade) to a pinned version, e.g. 4.3.0
+
+  user audit [<flags>]
+    Report the 2FA status, role, last login and API token count of a customer's
+    users
+
+        --customer-id=CUSTOMER-ID  Alphanumeric string identifying the customer
+                                   (falls back to FASTLY_CUSTOMER_ID)
+        --fail-if-no-2fa           Exit with an error if any user doesn't have
+                                   two-factor authentication enabled
+    -j, --json                     Render output as JSON
 
   user create --login=LOGIN --name=NAME [<flags>]
     Create a user of the Fastly API and web interface
@@ -4638,13 +6846,17 @@ COMMANDS
   vcl custom create --content=CONTENT --name=NAME --version=VERSION [<flags>]
     Upload a VCL for a particular service and version
 
-        --content=CONTENT        VCL passed as file path or content, e.g. $(<
-                                 main.vcl)
+        --content=CONTENT        VCL passed as file path, content, or - for
+                                 stdin, e.g. $(< main.vcl)
         --name=NAME              The name of the VCL
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
+        --allow-secrets          Upload the VCL even if it appears to contain
+                                 secrets such as API tokens or private keys
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
         --main                   Whether the VCL is the 'main' entrypoint
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -4658,7 +6870,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -4670,7 +6884,11 @@ COMMANDS
         --name=NAME              The name of the VCL
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
+        --highlight              Display the content with syntax highlighting
+                                 and line numbers
     -j, --json                   Render output as JSON
+        --raw                    Write only the content, byte-for-byte, suitable
+                                 for piping to a file
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -4693,28 +6911,59 @@ COMMANDS
         --name=NAME              The name of the VCL to update
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
+        --allow-secrets          Upload the VCL even if it appears to contain
+                                 secrets such as API tokens or private keys
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
         --new-name=NEW-NAME      New name for the VCL
-        --content=CONTENT        VCL passed as file path or content, e.g. $(<
-                                 main.vcl)
+        --edit                   Open the current content in $VISUAL or $EDITOR,
+                                 display a diff and upload the changes once
+                                 confirmed
+        --content=CONTENT        VCL passed as file path, content, or - for
+                                 stdin, e.g. $(< main.vcl)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  vcl snippet analyze [<flags>]
+    Analyze local VCL snippets for subroutine, variable and ordering problems
+
+        --dir="."  Directory containing .vcl snippet files
+    -j, --json     Render output as JSON
+
+  vcl snippet blame --name=NAME [<flags>]
+    Find the service version in which a VCL snippet last changed
+
+        --name=NAME              The name of the VCL snippet
+        --concurrency=8          Number of service versions to fetch at once
+    -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
+        --version=VERSION        'latest', 'active', or the number of a
+                                 specific version to walk back from (defaults to
+                                 'latest')
 
   vcl snippet create --content=CONTENT --name=NAME --version=VERSION --type=TYPE [<flags>]
     Create a snippet for a particular service and version
 
-        --content=CONTENT        VCL snippet passed as file path or content,
-                                 e.g. $(< snippet.vcl)
+        --content=CONTENT        VCL snippet passed as file path, content,
+                                 or - for stdin, e.g. $(< snippet.vcl)
         --name=NAME              The name of the VCL snippet
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --type=TYPE              The location in generated VCL where the snippet
                                  should be placed
+        --allow-secrets          Upload the VCL even if it appears to contain
+                                 secrets such as API tokens or private keys
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
         --dynamic                Whether the VCL snippet is dynamic or versioned
     -p, --priority=PRIORITY      Priority determines execution order. Lower
                                  numbers execute first
@@ -4730,7 +6979,9 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -4742,13 +6993,18 @@ COMMANDS
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
         --dynamic                Whether the VCL snippet is dynamic or versioned
+        --highlight              Display the content with syntax highlighting
+                                 and line numbers
     -j, --json                   Render output as JSON
         --name=NAME              The name of the VCL snippet
+        --raw                    Write only the content, byte-for-byte, suitable
+                                 for piping to a file
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
         --snippet-id=SNIPPET-ID  Alphanumeric string identifying a VCL Snippet
+                                 (or its name)
 
   vcl snippet list --version=VERSION [<flags>]
     List the uploaded VCL snippets for a particular service and version
@@ -4766,11 +7022,18 @@ COMMANDS
 
         --version=VERSION        'latest', 'active', or the number of a specific
                                  version
+        --allow-secrets          Upload the VCL even if it appears to contain
+                                 secrets such as API tokens or private keys
         --autoclone              If the selected service version is not
-                                 editable, clone it and use the clone.
-        --content=CONTENT        VCL snippet passed as file path or content,
-                                 e.g. $(< snippet.vcl)
+                                 editable, clone it and use the clone
+                                 (--autoclone=dry-run reports whether a clone is
+                                 needed)
+        --content=CONTENT        VCL snippet passed as file path, content,
+                                 or - for stdin, e.g. $(< snippet.vcl)
         --dynamic                Whether the VCL snippet is dynamic or versioned
+        --edit                   Open the current content in $VISUAL or $EDITOR,
+                                 display a diff and upload the changes once
+                                 confirmed
         --name=NAME              The name of the VCL snippet to update
         --new-name=NEW-NAME      New name for the VCL snippet
     -p, --priority=PRIORITY      Priority determines execution order. Lower
@@ -4780,13 +7043,164 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
         --snippet-id=SNIPPET-ID  Alphanumeric string identifying a VCL Snippet
+                                 (or its name)
         --type=TYPE              The location in generated VCL where the snippet
                                  should be placed
 
+  vcl snippet usages --name=NAME [<flags>]
+    Report where a VCL snippet is included, or its subroutines called, in a
+    service version's VCL
+
+    -n, --name=NAME              The name of the VCL snippet
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version (defaults to 'active')
+
   version
     Display version information for the Fastly CLI
 
 
+  waf exclusion create --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION --name=NAME --condition=CONDITION [<flags>]
+    Create a rule exclusion on a legacy WAF firewall version
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+    --name=NAME                Name of the rule exclusion
+    --condition=CONDITION      VCL condition that requests must match for the
+                               exclusion to apply
+    --exclusion-type=rule      Whether to exclude specific rules or skip the
+                               firewall entirely
+    --modsec-id=MODSEC-ID ...  ModSecurity ID of a rule to exclude (repeat to
+                               exclude several rules)
+
+  waf exclusion delete --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION --number=NUMBER
+    Delete a rule exclusion from a legacy WAF firewall version
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+    --number=NUMBER            Number of the rule exclusion to delete
+
+  waf exclusion list --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION [<flags>]
+    List the rule exclusions of a legacy WAF firewall version
+
+        --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                                   firewall (see 'fastly waf list')
+        --firewall-version=FIREWALL-VERSION
+                                   'latest', 'active', or the number of a
+                                   specific firewall version
+    -j, --json                     Render output as JSON
+
+  waf exclusion update --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION --number=NUMBER [<flags>]
+    Update a rule exclusion on a legacy WAF firewall version
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+    --number=NUMBER            Number of the rule exclusion to update
+    --name=NAME                New name for the rule exclusion
+    --condition=CONDITION      New VCL condition for the rule exclusion
+    --exclusion-type=EXCLUSION-TYPE
+                               Whether to exclude specific rules or skip the
+                               firewall entirely
+    --modsec-id=MODSEC-ID ...  ModSecurity ID of a rule to exclude, replacing
+                               the existing rules (repeat to exclude several
+                               rules)
+
+  waf list --version=VERSION [<flags>]
+    List the legacy WAF firewalls of a service version
+
+        --version=VERSION        'latest', 'active', or the number of a specific
+                                 version
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  waf rule disable --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION --modsec-id=MODSEC-ID
+    Disable rules on a legacy WAF firewall version
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+    --modsec-id=MODSEC-ID ...  ModSecurity ID of the rule to disable (repeat to
+                               disable several rules)
+
+  waf rule enable --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION --modsec-id=MODSEC-ID [<flags>]
+    Enable rules on a legacy WAF firewall version, or change the status of
+    enabled rules
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+    --modsec-id=MODSEC-ID ...  ModSecurity ID of the rule to enable (repeat to
+                               enable several rules)
+    --revision=REVISION        Revision of the rule to enable (defaults to the
+                               latest revision)
+    --status=log               Whether the rules log, block or contribute to the
+                               anomaly score of matching requests
+
+  waf rule list --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION [<flags>]
+    List the rules enabled on a legacy WAF firewall version
+
+        --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                                   firewall (see 'fastly waf list')
+        --firewall-version=FIREWALL-VERSION
+                                   'latest', 'active', or the number of a
+                                   specific firewall version
+    -j, --json                     Render output as JSON
+        --status=STATUS            Only list rules with the given status
+
+  waf version clone --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION
+    Clone a legacy WAF firewall version to create an editable copy
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+
+  waf version deploy --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION
+    Deploy a legacy WAF firewall version, making it the active version
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+
+  waf version list --firewall-id=FIREWALL-ID [<flags>]
+    List the versions of a legacy WAF firewall
+
+        --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                                   firewall (see 'fastly waf list')
+    -j, --json                     Render output as JSON
+
+  waf version lock --firewall-id=FIREWALL-ID --firewall-version=FIREWALL-VERSION
+    Lock a legacy WAF firewall version so it can no longer be modified
+
+    --firewall-id=FIREWALL-ID  Alphanumeric string identifying a legacy WAF
+                               firewall (see 'fastly waf list')
+    --firewall-version=FIREWALL-VERSION
+                               'latest', 'active', or the number of a specific
+                               firewall version
+
   whoami
     Get information about the currently authenticated account
 
@@ -4799,3 +7213,32 @@ For help on a specific command, try e.g.
 	fastly help profile
 	fastly profile --help
 `) + "\n\n"
+
+func TestNonInteractive(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		args string
+		env  config.Environment
+	}{
+		{
+			name: "flag",
+			args: "profile create foo --non-interactive",
+		},
+		{
+			name: "environment variable",
+			args: "profile create foo",
+			env:  config.Environment{NonInteractive: true},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args), &stdout)
+			opts.Env = testcase.env
+			// The input isn't read when running non-interactively.
+			opts.Stdin = strings.NewReader("123\n")
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, "input is required, but the CLI is running non-interactively")
+			testutil.AssertStringContains(t, stdout.String(), "Fastly API token: ")
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// TimingsSlowest is the number of slowest API requests listed by --timings.
+const TimingsSlowest = 5
+
+// printTimings displays the --timings summary of the API requests made by the
+// command, on the diagnostics stream so it doesn't corrupt structured output.
+//
+// NOTE: Requests made by the realtime stats client aren't included, as its
+// HTTP client isn't exposed.
+func printTimings(out io.Writer, name string, elapsed time.Duration, s transport.StatsSummary) {
+	sizes := text.Formatter{HumanSizes: true}
+
+	fmt.Fprintf(out, "\nTimings for 'fastly %s':\n", name)
+	fmt.Fprintf(out, "  Command duration: %s\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(out, "  API requests:     %d (%d failed, %d retries)\n", s.Calls, s.Failed, s.Retries)
+	if s.Calls == 0 {
+		return
+	}
+	average := s.Latency / time.Duration(s.Calls)
+	fmt.Fprintf(out, "  API latency:      %s total, %s average\n", s.Latency.Round(time.Millisecond), average.Round(time.Millisecond))
+	fmt.Fprintf(out, "  Payload:          %s sent, %s received\n", sizes.Bytes(uint64(s.RequestBytes)), sizes.Bytes(uint64(s.ResponseBytes)))
+	fmt.Fprintf(out, "  Slowest requests:\n")
+	for _, c := range s.Slowest {
+		status := "failed"
+		if c.Status != 0 {
+			status = fmt.Sprintf("%d", c.Status)
+		}
+		fmt.Fprintf(out, "    %8s  %s %s (%s)\n", c.Duration.Round(time.Millisecond), c.Method, c.Path, status)
+	}
+}
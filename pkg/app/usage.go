@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -90,6 +91,10 @@ var CompactUsageTemplate = `{{define "FormatCommand" -}}
 {{T "COMMANDS"|Bold}}
 {{.App.Commands|CommandsToTwoColumns|FormatTwoColumns}}
 {{end -}}
+{{if not .Context.SelectedCommand -}}
+{{T "EXIT CODES"|Bold}}
+{{ExitCodesToTwoColumns|FormatTwoColumns}}
+{{end -}}
 {{T "SEE ALSO"|Bold}}
 {{.Context.SelectedCommand|SeeAlso}}
 `
@@ -106,6 +111,13 @@ var UsageTemplateFuncs = template.FuncMap{
 		}
 		return rows
 	},
+	"ExitCodesToTwoColumns": func() [][2]string {
+		rows := [][2]string{}
+		for _, e := range fsterr.ExitCodes {
+			rows = append(rows, [2]string{strconv.Itoa(e.Code), e.Description})
+		}
+		return rows
+	},
 	"GlobalFlags": func(f []*kingpin.ClauseModel) []*kingpin.ClauseModel {
 		flags := []*kingpin.ClauseModel{}
 		for _, flag := range f {
@@ -144,13 +156,35 @@ var UsageTemplateFuncs = template.FuncMap{
 //
 // NOTE: This map is used to help populate the CLI 'usage' template renderer.
 var globalFlags = map[string]bool{
-	"accept-defaults": true,
-	"auto-yes":        true,
-	"help":            true,
-	"non-interactive": true,
-	"profile":         true,
-	"token":           true,
-	"verbose":         true,
+	"accept-defaults":      true,
+	"api-endpoint":         true,
+	"api-timeout":          true,
+	"auto-yes":             true,
+	"ca-file":              true,
+	"confirm-destructive":  true,
+	"confirm-irreversible": true,
+	"debug-http":           true,
+	"deterministic":        true,
+	"dry-run":              true,
+	"help":                 true,
+	"human-sizes":          true,
+	"iso8601":              true,
+	"log-file":             true,
+	"log-level":            true,
+	"no-cache":             true,
+	"non-interactive":      true,
+	"output":               true,
+	"preset":               true,
+	"profile":              true,
+	"proxy":                true,
+	"read-only":            true,
+	"record":               true,
+	"replay":               true,
+	"retry":                true,
+	"timings":              true,
+	"token":                true,
+	"utc":                  true,
+	"verbose":              true,
 }
 
 // VerboseUsageTemplate is the full-fat usage template, rendered when users type
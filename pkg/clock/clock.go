@@ -0,0 +1,61 @@
+package clock
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Epoch is the time of the clock used by the --deterministic flag.
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Clock is a source of the current time and of unique IDs.
+//
+// A nil *Clock is valid and uses the system clock and random IDs, so callers
+// don't need to check whether a clock was provided. A Clock is safe for
+// concurrent use.
+type Clock struct {
+	mu    sync.Mutex
+	fixed time.Time
+	ids   int
+}
+
+// Fixed returns a clock that's always at t, and whose IDs are sequential, so
+// that output which includes them is reproducible.
+func Fixed(t time.Time) *Clock {
+	return &Clock{fixed: t}
+}
+
+// Now returns the current time.
+func (c *Clock) Now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.fixed
+}
+
+// Since returns the time elapsed since t.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// ID returns a unique ID, formatted as a (version 4) UUID.
+func (c *Clock) ID() string {
+	if c == nil {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			// The system's random source is never expected to fail, but an ID
+			// that's unique to this process is better than none.
+			copy(b, fmt.Sprintf("%016x", time.Now().UnixNano()))
+		}
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", c.ids)
+}
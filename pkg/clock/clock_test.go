@@ -0,0 +1,34 @@
+package clock_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestFixed(t *testing.T) {
+	c := clock.Fixed(testutil.Date)
+	testutil.AssertEqual(t, testutil.Date, c.Now())
+	testutil.AssertEqual(t, time.Duration(0), c.Since(c.Now()))
+	testutil.AssertString(t, "00000000-0000-4000-8000-000000000001", c.ID())
+	testutil.AssertString(t, "00000000-0000-4000-8000-000000000002", c.ID())
+}
+
+func TestSystem(t *testing.T) {
+	var c *clock.Clock
+	if d := time.Since(c.Now()); d < 0 || d > time.Minute {
+		t.Errorf("want the current time, have %v", c.Now())
+	}
+	a, b := c.ID(), c.ID()
+	if !uuidPattern.MatchString(a) {
+		t.Errorf("want a UUID, have %q", a)
+	}
+	if a == b {
+		t.Errorf("want unique IDs, have %q twice", a)
+	}
+}
@@ -0,0 +1,4 @@
+// Package clock provides the source of the current time and of unique IDs used
+// by commands, so their output can be made deterministic (see --deterministic)
+// for golden-file testing of scripts that wrap the CLI.
+package clock
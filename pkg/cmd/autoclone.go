@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/env"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// AutoCloneDryRun is the --autoclone flag value that reports whether a clone
+// would be needed without making any changes.
+const AutoCloneDryRun = "dry-run"
+
+// AutoCloneStateFile is the name of the state file (see config.Data.StatePath)
+// used to reuse a cloned service version across commands run from the same
+// shell session.
+const AutoCloneStateFile = "autoclone.json"
+
+// AutoCloneStateTTL is how long a recorded clone is considered for reuse.
+var AutoCloneStateTTL = 12 * time.Hour
+
+// AutoCloneSession returns the identifier of the shell session the CLI is
+// running in (see shellSession).
+var AutoCloneSession = shellSession
+
+// shellProcess caches the identity of the user's shell process, as it's
+// constant for the life of the CLI.
+var shellProcess struct {
+	once sync.Once
+	id   string
+}
+
+// shellSession identifies the shell session the CLI is running in. The
+// FASTLY_SESSION env var is used if set. Otherwise, as each command is a child
+// of the user's shell, the session is the parent process: its ID, its start
+// time (so a reused process ID isn't mistaken for the same session) and its
+// terminal, where they're available.
+func shellSession() string {
+	if s := os.Getenv(env.Session); s != "" {
+		return "env:" + s
+	}
+	shellProcess.once.Do(func() {
+		ppid := os.Getppid()
+		id := strconv.Itoa(ppid)
+		start, tty := processDetails(ppid)
+		if start != "" {
+			id += "@" + start
+		}
+		if tty != "" {
+			id += " " + tty
+		}
+		shellProcess.id = id
+	})
+	return shellProcess.id
+}
+
+// processDetails returns the start time and terminal of the process, or empty
+// strings where they can't be determined.
+func processDetails(pid int) (start, tty string) {
+	switch runtime.GOOS {
+	case "windows":
+		return "", ""
+	case "linux":
+		// The start time is the 22nd field of /proc/<pid>/stat, counted from
+		// the end of the command name (the 2nd field), which may contain spaces.
+		// #nosec G304 (CWE-22) the path is derived from a process ID.
+		if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+			if i := bytes.LastIndexByte(data, ')'); i >= 0 {
+				if fields := strings.Fields(string(data[i+1:])); len(fields) > 19 {
+					start = fields[19]
+				}
+			}
+		}
+		if link, err := os.Readlink(fmt.Sprintf("/proc/%d/fd/0", pid)); err == nil && strings.HasPrefix(link, "/dev/") {
+			tty = link
+		}
+		return start, tty
+	}
+	// #nosec G204 (CWE-78) the arguments are a process ID.
+	out, err := exec.Command("ps", "-o", "tty=,lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return "", ""
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return "", ""
+	}
+	return strings.Join(fields[1:], " "), fields[0]
+}
+
+// SplitAutoCloneArgs rewrites `--autoclone=dry-run` as `--autoclone` (kingpin
+// doesn't accept a value for a boolean flag) and reports whether the dry-run
+// mode was requested.
+func SplitAutoCloneArgs(args []string) ([]string, bool) {
+	var dryRun bool
+	split := make([]string, 0, len(args))
+	for i, a := range args {
+		if a == "--" {
+			return append(split, args[i:]...), dryRun
+		}
+		if a == "--autoclone="+AutoCloneDryRun {
+			dryRun = true
+			a = "--autoclone"
+		}
+		split = append(split, a)
+	}
+	return split, dryRun
+}
+
+// AutoCloneEntry records a service version cloned by --autoclone.
+type AutoCloneEntry struct {
+	Session   string    `json:"session"`
+	ServiceID string    `json:"service_id"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	Created   time.Time `json:"created"`
+}
+
+// LookupAutoClone returns the version previously cloned from the given service
+// version in the current session, according to the state file at path, or zero
+// if there isn't one. Reuse is disabled when path is empty (e.g. in tests).
+func LookupAutoClone(path, serviceID string, from int) int {
+	if path == "" {
+		return 0
+	}
+	session := AutoCloneSession()
+	for _, e := range readAutoCloneState(path) {
+		if e.Session == session && e.ServiceID == serviceID && e.From == from {
+			return e.To
+		}
+	}
+	return 0
+}
+
+// RecordAutoClone records a cloned service version for the current session in
+// the state file at path, so later commands can reuse it. Expired entries are
+// discarded. Nothing is recorded when path is empty.
+func RecordAutoClone(path, serviceID string, from, to int) error {
+	if path == "" {
+		return nil
+	}
+	session := AutoCloneSession()
+	entries := []AutoCloneEntry{{
+		Session:   session,
+		ServiceID: serviceID,
+		From:      from,
+		To:        to,
+		Created:   fsterr.Now(),
+	}}
+	for _, e := range readAutoCloneState(path) {
+		if e.Session == session && e.ServiceID == serviceID && e.From == from {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("error creating autoclone state directory: %w", err)
+	}
+	// The file is replaced via a temporary file, so that commands run
+	// concurrently never read it partially written.
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error writing autoclone state file: %w", err)
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("error writing autoclone state file: %w", err)
+	}
+	return nil
+}
+
+// readAutoCloneState returns the unexpired entries in the state file at path. A
+// missing or corrupt file is treated as empty, as the state is only a cache.
+func readAutoCloneState(path string) []AutoCloneEntry {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil
+	}
+	var entries []AutoCloneEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	cutoff := fsterr.Now().Add(-AutoCloneStateTTL)
+	valid := entries[:0]
+	for _, e := range entries {
+		if e.Created.After(cutoff) {
+			valid = append(valid, e)
+		}
+	}
+	return valid
+}
+
+// autoCloneDryRunMessage describes what --autoclone would do for the given
+// version, where reuse is the version that would be reused (if any).
+func autoCloneDryRunMessage(number, reuse int, editable bool) string {
+	var msg string
+	switch {
+	case editable:
+		msg = fmt.Sprintf("Service version %d is editable, so --autoclone would not clone it.", number)
+	case reuse > 0:
+		msg = fmt.Sprintf("Service version %d is not editable, so --autoclone would reuse version %d (cloned from version %d earlier in this shell session).", number, reuse, number)
+	default:
+		msg = fmt.Sprintf("Service version %d is not editable, so --autoclone would clone it.", number)
+	}
+	return msg + " No changes were made (--autoclone=dry-run)."
+}
+
+// reusableAutoClone returns the version cloned from the given service version
+// earlier in the current session, provided it's still editable.
+func reusableAutoClone(path, serviceID string, from int, client api.Interface) *fastly.Version {
+	to := LookupAutoClone(path, serviceID, from)
+	if to == 0 {
+		return nil
+	}
+	v, err := client.GetVersion(&fastly.GetVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: to,
+	})
+	if err != nil || v.Active || v.Locked {
+		return nil
+	}
+	return v
+}
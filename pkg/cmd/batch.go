@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// BatchOperations are the operations of a batch patch file, in the order
+// they're summarised by BatchSummary.
+var BatchOperations = []fastly.BatchOperation{
+	fastly.CreateBatchOperation,
+	fastly.UpdateBatchOperation,
+	fastly.UpsertBatchOperation,
+	fastly.DeleteBatchOperation,
+}
+
+// ValidateBatchOperation returns an error if op isn't one of the supported
+// operations, which are a subset of BatchOperations.
+func ValidateBatchOperation(op fastly.BatchOperation, supported ...fastly.BatchOperation) error {
+	names := make([]string, len(supported))
+	for i, s := range supported {
+		if op == s {
+			return nil
+		}
+		names[i] = string(s)
+	}
+	if op == "" {
+		return fmt.Errorf("missing 'op' (must be one of %s)", strings.Join(names, ", "))
+	}
+	return fmt.Errorf("invalid 'op' '%s' (must be one of %s)", op, strings.Join(names, ", "))
+}
+
+// BatchSummary returns the number of each operation of a batch, e.g. "1
+// create, 2 delete".
+func BatchSummary(ops []fastly.BatchOperation) string {
+	counts := make(map[fastly.BatchOperation]int)
+	for _, op := range ops {
+		counts[op]++
+	}
+	var parts []string
+	for _, op := range BatchOperations {
+		if counts[op] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[op], op))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplyBatch calls apply with the operations of a batch in order, in chunks
+// of at most fastly.BatchModifyMaximumOperations (the API's limit per
+// request). If a chunk fails then the error says how many operations were
+// applied before it, as the earlier chunks aren't rolled back.
+func ApplyBatch[T any](ops []T, apply func([]T) error) error {
+	for start := 0; start < len(ops); start += fastly.BatchModifyMaximumOperations {
+		end := start + fastly.BatchModifyMaximumOperations
+		if end > len(ops) {
+			end = len(ops)
+		}
+		if err := apply(ops[start:end]); err != nil {
+			if start > 0 {
+				return fmt.Errorf("applied %d of %d operations before the error: %w", start, len(ops), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestValidateBatchOperation(t *testing.T) {
+	testutil.AssertNoError(t, cmd.ValidateBatchOperation(fastly.UpsertBatchOperation, cmd.BatchOperations...))
+
+	err := cmd.ValidateBatchOperation(fastly.UpsertBatchOperation, fastly.CreateBatchOperation, fastly.DeleteBatchOperation)
+	testutil.AssertErrorContains(t, err, "invalid 'op' 'upsert' (must be one of create, delete)")
+
+	err = cmd.ValidateBatchOperation("", cmd.BatchOperations...)
+	testutil.AssertErrorContains(t, err, "missing 'op' (must be one of create, update, upsert, delete)")
+}
+
+func TestBatchSummary(t *testing.T) {
+	testutil.AssertString(t, "", cmd.BatchSummary(nil))
+	testutil.AssertString(t, "1 create, 2 delete", cmd.BatchSummary([]fastly.BatchOperation{
+		fastly.DeleteBatchOperation,
+		fastly.CreateBatchOperation,
+		fastly.DeleteBatchOperation,
+	}))
+}
+
+func TestApplyBatch(t *testing.T) {
+	ops := make([]int, 2500)
+
+	var chunks []int
+	err := cmd.ApplyBatch(ops, func(chunk []int) error {
+		chunks = append(chunks, len(chunk))
+		return nil
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []int{1000, 1000, 500}, chunks)
+
+	chunks = nil
+	err = cmd.ApplyBatch(ops, func(chunk []int) error {
+		chunks = append(chunks, len(chunk))
+		if len(chunks) == 2 {
+			return testutil.Err
+		}
+		return nil
+	})
+	testutil.AssertErrorContains(t, err, "applied 1000 of 2500 operations before the error: test error")
+	testutil.AssertEqual(t, []int{1000, 1000}, chunks)
+
+	err = cmd.ApplyBatch(ops[:10], func(chunk []int) error { return testutil.Err })
+	testutil.AssertString(t, testutil.Err.Error(), err.Error())
+}
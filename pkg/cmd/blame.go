@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// BlameConcurrency is the default number of service versions fetched at once
+// when walking back through a service's history.
+const BlameConcurrency = 8
+
+// BlameFetchFunc returns the fields of a resource in the given service
+// version, and whether the resource exists in that version.
+type BlameFetchFunc func(version int) (fields map[string]string, found bool, err error)
+
+// BlameResult describes the service version in which a resource's fields last
+// changed.
+type BlameResult struct {
+	// Version is the version in which the resource's fields last changed.
+	Version int `json:"version"`
+	// UpdatedAt is when Version was last updated.
+	UpdatedAt *time.Time `json:"updated_at"`
+	// Comment is the comment of Version.
+	Comment string `json:"comment"`
+	// Previous is the version Version was compared against, or zero if
+	// Version is the service's first version.
+	Previous int `json:"previous,omitempty"`
+	// Created is true if the resource doesn't exist in Previous.
+	Created bool `json:"created"`
+	// Changed lists the fields that differ from Previous.
+	Changed []string `json:"changed,omitempty"`
+	// Fields are the resource's fields in Version.
+	Fields map[string]string `json:"fields"`
+}
+
+// Blame walks back from the service version from until it finds the version
+// in which the resource's fields last changed. Versions are fetched in
+// batches of concurrency so the answer only takes a few round trips.
+//
+// NOTE: versions are compared in numerical order, which is how they're
+// normally created, although a version may have been cloned from an older
+// version than the one before it.
+func Blame(versions []*fastly.Version, from int, fetch BlameFetchFunc, concurrency int) (*BlameResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	vs := make([]*fastly.Version, 0, len(versions))
+	for _, v := range versions {
+		if v.Number <= from {
+			vs = append(vs, v)
+		}
+	}
+	sort.Slice(vs, func(i, j int) bool {
+		return vs[i].Number > vs[j].Number
+	})
+	if len(vs) == 0 || vs[0].Number != from {
+		return nil, fmt.Errorf("service version %d not found", from)
+	}
+
+	var current map[string]string
+	for start := 0; start < len(vs); start += concurrency {
+		end := start + concurrency
+		if end > len(vs) {
+			end = len(vs)
+		}
+		batch, err := blameFetch(vs[start:end], fetch)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, b := range batch {
+			if start+i == 0 {
+				if !b.found {
+					return nil, fmt.Errorf("resource not found in service version %d", from)
+				}
+				current = b.fields
+				continue
+			}
+			changed := changedFields(b.fields, current)
+			if b.found && len(changed) == 0 {
+				continue
+			}
+			r := newBlameResult(vs[start+i-1], current)
+			r.Previous = vs[start+i].Number
+			r.Created = !b.found
+			if b.found {
+				r.Changed = changed
+			}
+			return r, nil
+		}
+	}
+
+	// The resource is unchanged since the oldest version.
+	return newBlameResult(vs[len(vs)-1], current), nil
+}
+
+type blameVersion struct {
+	fields map[string]string
+	found  bool
+}
+
+// blameFetch fetches the resource from each of the versions concurrently.
+func blameFetch(vs []*fastly.Version, fetch BlameFetchFunc) ([]blameVersion, error) {
+	results := make([]blameVersion, len(vs))
+	errs := make([]error, len(vs))
+
+	var wg sync.WaitGroup
+	for i, v := range vs {
+		wg.Add(1)
+		go func(i, number int) {
+			defer wg.Done()
+			fields, found, err := fetch(number)
+			results[i] = blameVersion{fields: fields, found: found}
+			if err != nil {
+				errs[i] = fmt.Errorf("error fetching service version %d: %w", number, err)
+			}
+		}(i, v.Number)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func newBlameResult(v *fastly.Version, fields map[string]string) *BlameResult {
+	return &BlameResult{
+		Version:   v.Number,
+		UpdatedAt: v.UpdatedAt,
+		Comment:   v.Comment,
+		Fields:    fields,
+	}
+}
+
+// changedFields returns the sorted names of the fields that differ between a
+// and b.
+func changedFields(a, b map[string]string) []string {
+	var changed []string
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// DisplayBlame describes when the named resource last changed, where kind is
+// the type of resource (e.g. "Backend"). The time of the change is rendered
+// using f.
+func DisplayBlame(out io.Writer, kind, name string, r *BlameResult, f text.Formatter) {
+	var when string
+	if r.UpdatedAt != nil {
+		when = fmt.Sprintf(" (%s)", f.UTCTime(*r.UpdatedAt))
+	}
+	switch {
+	case r.Previous == 0:
+		text.Output(out, "%s '%s' is unchanged since version %d%s", kind, name, r.Version, when)
+	case r.Created:
+		text.Output(out, "%s '%s' was created in version %d%s", kind, name, r.Version, when)
+	default:
+		text.Output(out, "%s '%s' last changed in version %d%s", kind, name, r.Version, when)
+		text.Output(out, "Changed since version %d: %s", r.Previous, strings.Join(r.Changed, ", "))
+	}
+	if r.Comment != "" {
+		text.Output(out, "Version comment: %s", r.Comment)
+	}
+}
@@ -0,0 +1,79 @@
+package cmd_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestBlame(t *testing.T) {
+	var versions []*fastly.Version
+	for n := 1; n <= 20; n++ {
+		versions = append(versions, &fastly.Version{Number: n, Comment: "v" + strconv.Itoa(n)})
+	}
+
+	// The resource is created in version 3, changes in version 7, and is
+	// unchanged from then on.
+	fetch := func(version int) (map[string]string, bool, error) {
+		switch {
+		case version < 3:
+			return nil, false, nil
+		case version < 7:
+			return map[string]string{"port": "80"}, true, nil
+		default:
+			return map[string]string{"port": "443", "shield": ""}, true, nil
+		}
+	}
+
+	for _, testcase := range []struct {
+		name        string
+		from        int
+		concurrency int
+		wantVersion int
+		wantCreated bool
+		wantChanged []string
+		wantError   string
+	}{
+		{name: "changed across batches", from: 20, concurrency: 4, wantVersion: 7, wantChanged: []string{"port", "shield"}},
+		{name: "changed within a batch", from: 9, concurrency: 8, wantVersion: 7, wantChanged: []string{"port", "shield"}},
+		{name: "created", from: 6, concurrency: 1, wantVersion: 3, wantCreated: true},
+		{name: "missing", from: 2, concurrency: 8, wantError: "resource not found in service version 2"},
+		{name: "unknown version", from: 21, concurrency: 8, wantError: "service version 21 not found"},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			r, err := cmd.Blame(versions, testcase.from, fetch, testcase.concurrency)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if err != nil {
+				return
+			}
+			testutil.AssertEqual(t, testcase.wantVersion, r.Version)
+			testutil.AssertEqual(t, "v"+strconv.Itoa(testcase.wantVersion), r.Comment)
+			testutil.AssertEqual(t, testcase.wantVersion-1, r.Previous)
+			testutil.AssertEqual(t, testcase.wantCreated, r.Created)
+			testutil.AssertEqual(t, testcase.wantChanged, r.Changed)
+		})
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		r, err := cmd.Blame(versions, 20, func(int) (map[string]string, bool, error) {
+			return map[string]string{"port": "80"}, true, nil
+		}, 3)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, 1, r.Version)
+		testutil.AssertEqual(t, 0, r.Previous)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		_, err := cmd.Blame(versions, 20, func(version int) (map[string]string, bool, error) {
+			if version == 15 {
+				return nil, false, errors.New("boom")
+			}
+			return map[string]string{"port": "80"}, true, nil
+		}, 4)
+		testutil.AssertErrorContains(t, err, "error fetching service version 15: boom")
+	})
+}
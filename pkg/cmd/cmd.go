@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/config"
@@ -17,9 +19,13 @@ import (
 // Command is an interface that abstracts over all of the concrete command
 // structs. The Name method lets us select which command should be run, and the
 // Exec method invokes whatever business logic the command should do.
+//
+// The Mutates method reports whether the command modifies the Fastly account,
+// so that it's refused in read-only mode and confirmed for protected services.
 type Command interface {
 	Name() string
 	Exec(in io.Reader, out io.Writer) error
+	Mutates() bool
 }
 
 // Select chooses the command matching name, if it exists.
@@ -53,6 +59,9 @@ type Globals struct {
 type Base struct {
 	CmdClause *kingpin.CmdClause
 	Globals   *config.Data
+
+	// mutating is set by SetMutating.
+	mutating bool
 }
 
 // Name implements the Command interface, and returns the FullCommand from the
@@ -61,6 +70,40 @@ func (b Base) Name() string {
 	return b.CmdClause.FullCommand()
 }
 
+// MutatingVerbs are the names of the subcommands that modify the Fastly
+// account, e.g. 'fastly backend create'.
+var MutatingVerbs = []string{
+	"activate", "clone", "create", "deactivate", "delete", "deploy",
+	"disable", "enable", "lock", "publish", "purge", "update",
+}
+
+// Mutates implements the Command interface. A command whose name ends in one
+// of MutatingVerbs modifies the Fastly account (other than the profile
+// commands, which only modify the config file). Any other command that does
+// must declare it with SetMutating, or override Mutates if it depends on the
+// command's flags.
+func (b Base) Mutates() bool {
+	if b.mutating {
+		return true
+	}
+	segs := strings.Fields(b.Name())
+	if len(segs) == 0 || segs[0] == "profile" {
+		return false
+	}
+	for _, v := range MutatingVerbs {
+		if segs[len(segs)-1] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMutating declares that the command modifies the Fastly account, although
+// its name isn't one of MutatingVerbs.
+func (b *Base) SetMutating() {
+	b.mutating = true
+}
+
 // Optional models an optional type that consumers can use to assert whether the
 // inner value has been set and is therefore valid for use.
 type Optional struct {
@@ -122,11 +165,20 @@ type ServiceDetailsOpts struct {
 	ServiceVersionFlag OptionalServiceVersion
 	VerboseMode        bool
 	ErrLog             fsterr.LogInterface
+	// Globals provides the current profile's default service and the
+	// --autoclone settings. It may be nil (e.g. in tests), in which case
+	// there are none.
+	Globals *config.Data
 }
 
 // ServiceDetails returns the Service ID and Service Version.
 func ServiceDetails(opts ServiceDetailsOpts) (serviceID string, serviceVersion *fastly.Version, err error) {
-	serviceID, source, flag, err := ServiceID(opts.ServiceNameFlag, opts.Manifest, opts.APIClient, opts.ErrLog)
+	g := opts.Globals
+	if g == nil {
+		g = &config.Data{}
+	}
+
+	serviceID, source, flag, err := ServiceID(opts.ServiceNameFlag, opts.Manifest, opts.APIClient, opts.ErrLog, g.ProfileServiceID())
 	if err != nil {
 		return serviceID, serviceVersion, err
 	}
@@ -141,7 +193,7 @@ func ServiceDetails(opts ServiceDetailsOpts) (serviceID string, serviceVersion *
 
 	if opts.AutoCloneFlag.WasSet {
 		currentVersion := v
-		v, err = opts.AutoCloneFlag.Parse(currentVersion, serviceID, opts.VerboseMode, opts.Out, opts.APIClient)
+		v, err = opts.AutoCloneFlag.Parse(currentVersion, serviceID, opts.Out, opts.APIClient, g)
 		if err != nil {
 			return serviceID, currentVersion, err
 		}
@@ -159,12 +211,17 @@ func ServiceDetails(opts ServiceDetailsOpts) (serviceID string, serviceVersion *
 // ServiceID returns the Service ID and the source of that information.
 //
 // NOTE: If Service ID not provided then check if Service Name provided and use
-// that information to acquire the Service ID.
-func ServiceID(serviceName OptionalServiceNameID, data manifest.Data, client api.Interface, li fsterr.LogInterface) (serviceID string, source manifest.Source, flag string, err error) {
+// that information to acquire the Service ID, and otherwise fall back to the
+// current profile's default service, profileServiceID (see
+// config.Data.ProfileServiceID), if it isn't empty.
+func ServiceID(serviceName OptionalServiceNameID, data manifest.Data, client api.Interface, li fsterr.LogInterface, profileServiceID string) (serviceID string, source manifest.Source, flag string, err error) {
 	flag = "--service-id"
 	serviceID, source = data.ServiceID()
 
 	if source == manifest.SourceUndefined {
+		if !serviceName.WasSet && profileServiceID != "" {
+			return profileServiceID, manifest.SourceProfile, "--profile", nil
+		}
 		if !serviceName.WasSet {
 			err = fsterr.ErrNoServiceID
 			if li != nil {
@@ -195,6 +252,8 @@ func DisplayServiceID(sid, flag string, s manifest.Source, out io.Writer) {
 		via = fmt.Sprintf(" (via %s)", manifest.Filename)
 	case manifest.SourceEnv:
 		via = fmt.Sprintf(" (via %s)", env.ServiceID)
+	case manifest.SourceProfile:
+		via = " (via profile)"
 	case manifest.SourceUndefined:
 		via = " (not provided)"
 	}
@@ -228,6 +287,10 @@ func IsHelpFlagOnly(args []string) bool {
 	return args[0] == "--help"
 }
 
+// verboseShortFlagRegExp matches the short verbose flag repeated to increase
+// the verbose level (e.g. -v, -vv, -vvv).
+var verboseShortFlagRegExp = regexp.MustCompile(`^-v+$`)
+
 // IsGlobalFlagsOnly indicates if the user called the binary with any
 // permutation order of the globally defined flags.
 //
@@ -249,14 +312,19 @@ func IsHelpFlagOnly(args []string) bool {
 func IsGlobalFlagsOnly(args []string) bool {
 	// Global flags are defined in pkg/app/run.go#84
 	globals := map[string]int{
-		"--verbose":  0,
-		"-v":         0,
-		"--token":    1,
-		"-t":         1,
-		"--endpoint": 1,
+		"--verbose":      0,
+		"--token":        1,
+		"-t":             1,
+		"--api-endpoint": 1,
+		"--endpoint":     1,
 	}
 	var total int
 	for _, a := range args {
+		// The verbose flag is a counter and so can be repeated (e.g. -vvv).
+		if verboseShortFlagRegExp.MatchString(a) {
+			total++
+			continue
+		}
 		for k := range globals {
 			if a == k {
 				total++
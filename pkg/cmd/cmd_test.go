@@ -0,0 +1,34 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestMutates(t *testing.T) {
+	app := kingpin.New("fastly", "")
+	backend := app.Command("backend", "")
+	profile := app.Command("profile", "")
+
+	for _, testcase := range []struct {
+		clause   *kingpin.CmdClause
+		declared bool
+		want     bool
+	}{
+		{clause: backend.Command("create", ""), want: true},
+		{clause: backend.Command("list", ""), want: false},
+		{clause: backend.Command("harden", ""), declared: true, want: true},
+		{clause: profile.Command("update", ""), want: false},
+	} {
+		t.Run(testcase.clause.FullCommand(), func(t *testing.T) {
+			b := cmd.Base{CmdClause: testcase.clause}
+			if testcase.declared {
+				b.SetMutating()
+			}
+			testutil.AssertBool(t, testcase.want, b.Mutates())
+		})
+	}
+}
@@ -9,6 +9,10 @@ var (
 	FlagJSONName = "json"
 	// FlagJSONDesc is the flag description.
 	FlagJSONDesc = "Render output as JSON"
+	// FlagRawName is the flag name.
+	FlagRawName = "raw"
+	// FlagRawDesc is the flag description.
+	FlagRawDesc = "Write only the content, byte-for-byte, suitable for piping to a file"
 	// FlagServiceIDName is the flag name.
 	FlagServiceIDName = "service-id"
 	// FlagServiceIDDesc is the flag description.
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ConfirmLevel represents how consequential the operation a Yes/No prompt is
+// guarding is. Each level can be bypassed independently so automation can
+// auto-confirm ordinary prompts while still having to explicitly opt-in to the
+// more dangerous operations.
+type ConfirmLevel int
+
+const (
+	// ConfirmInformational guards operations that are safe but that a user
+	// might not expect (e.g. creating a new service, running a custom build
+	// script). Bypassed by --auto-yes, --non-interactive or the [confirm]
+	// informational config setting.
+	ConfirmInformational ConfirmLevel = iota
+	// ConfirmDestructive guards operations that modify or remove resources but
+	// which can be recovered from. Bypassed by --confirm-destructive or the
+	// [confirm] destructive config setting.
+	ConfirmDestructive
+	// ConfirmIrreversible guards operations that cannot be undone (e.g. purging
+	// all cached content, deleting a service). Only bypassed by
+	// --confirm-irreversible.
+	ConfirmIrreversible
+)
+
+// String returns the name of the confirmation level.
+func (l ConfirmLevel) String() string {
+	switch l {
+	case ConfirmInformational:
+		return "informational"
+	case ConfirmDestructive:
+		return "destructive"
+	case ConfirmIrreversible:
+		return "irreversible"
+	}
+	return "unknown"
+}
+
+// flag returns the name of the flag that bypasses the confirmation level.
+func (l ConfirmLevel) flag() string {
+	switch l {
+	case ConfirmDestructive:
+		return "--confirm-destructive"
+	case ConfirmIrreversible:
+		return "--confirm-irreversible"
+	}
+	return "--auto-yes"
+}
+
+// Bypass indicates whether prompts of the given level should be automatically
+// confirmed. Bypassing a level implicitly bypasses all lower levels.
+//
+// NOTE: Irreversible operations deliberately can't be bypassed via the config
+// file, so they always require an explicit flag on the command line.
+func Bypass(level ConfirmLevel, g *config.Data) bool {
+	if g.Flag.ConfirmIrreversible {
+		return true
+	}
+	if level == ConfirmIrreversible {
+		return false
+	}
+	if g.Flag.ConfirmDestructive || g.File.Confirm.Destructive {
+		return true
+	}
+	if level == ConfirmDestructive {
+		return false
+	}
+	return g.Flag.AutoYes || g.Flag.NonInteractive || g.File.Confirm.Informational
+}
+
+// Confirm displays a Yes/No prompt unless the confirmation level is bypassed.
+//
+// When running non-interactively, prompts for destructive and irreversible
+// operations that haven't been bypassed result in an error rather than
+// blocking on (or reading) input that won't be provided.
+func Confirm(level ConfirmLevel, prompt string, g *config.Data, in io.Reader, out io.Writer) (bool, error) {
+	if Bypass(level, g) {
+		return true, nil
+	}
+	if g.Flag.NonInteractive {
+		return false, fsterr.RemediationError{
+			Inner:       fmt.Errorf("%s operation requires confirmation", level),
+			Remediation: fmt.Sprintf("Pass %s to confirm the operation when running non-interactively.", level.flag()),
+		}
+	}
+	return text.AskYesNo(out, prompt, in)
+}
+
+// NonInteractiveInput is the input given to commands when running
+// non-interactively. Rather than blocking on (or reading) input that won't be
+// provided, any prompt that reads it fails with fsterr.ErrNonInteractive.
+var NonInteractiveInput io.Reader = nonInteractiveReader{}
+
+type nonInteractiveReader struct{}
+
+// Read implements the io.Reader interface.
+func (nonInteractiveReader) Read([]byte) (int, error) {
+	return 0, fsterr.ErrNonInteractive
+}
@@ -0,0 +1,76 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestBypass(t *testing.T) {
+	cases := map[string]struct {
+		flag    config.Flag
+		confirm config.Confirm
+		want    map[cmd.ConfirmLevel]bool
+	}{
+		"no bypass": {
+			want: map[cmd.ConfirmLevel]bool{
+				cmd.ConfirmInformational: false,
+				cmd.ConfirmDestructive:   false,
+				cmd.ConfirmIrreversible:  false,
+			},
+		},
+		"auto-yes": {
+			flag: config.Flag{AutoYes: true},
+			want: map[cmd.ConfirmLevel]bool{
+				cmd.ConfirmInformational: true,
+				cmd.ConfirmDestructive:   false,
+				cmd.ConfirmIrreversible:  false,
+			},
+		},
+		"confirm-destructive": {
+			flag: config.Flag{ConfirmDestructive: true},
+			want: map[cmd.ConfirmLevel]bool{
+				cmd.ConfirmInformational: true,
+				cmd.ConfirmDestructive:   true,
+				cmd.ConfirmIrreversible:  false,
+			},
+		},
+		"confirm-irreversible": {
+			flag: config.Flag{ConfirmIrreversible: true},
+			want: map[cmd.ConfirmLevel]bool{
+				cmd.ConfirmInformational: true,
+				cmd.ConfirmDestructive:   true,
+				cmd.ConfirmIrreversible:  true,
+			},
+		},
+		"config informational": {
+			confirm: config.Confirm{Informational: true},
+			want: map[cmd.ConfirmLevel]bool{
+				cmd.ConfirmInformational: true,
+				cmd.ConfirmDestructive:   false,
+				cmd.ConfirmIrreversible:  false,
+			},
+		},
+		"config destructive": {
+			confirm: config.Confirm{Destructive: true},
+			want: map[cmd.ConfirmLevel]bool{
+				cmd.ConfirmInformational: true,
+				cmd.ConfirmDestructive:   true,
+				cmd.ConfirmIrreversible:  false,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := &config.Data{
+				File: config.File{Confirm: c.confirm},
+				Flag: c.flag,
+			}
+			for level, want := range c.want {
+				testutil.AssertBool(t, want, cmd.Bypass(level, g))
+			}
+		})
+	}
+}
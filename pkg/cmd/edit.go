@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// EditFlagDesc is the description shared by the --edit flag of commands that
+// support modifying a resource's content in an editor.
+const EditFlagDesc = "Open the current content in $VISUAL or $EDITOR, display a diff and upload the changes once confirmed"
+
+// ErrEditCancelled indicates the user made no changes in the editor or didn't
+// confirm the changes should be applied.
+var ErrEditCancelled = errors.New("edit cancelled")
+
+// Editor returns the command used to edit content, which is taken from the
+// VISUAL and EDITOR environment variables, in that order.
+func Editor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(name)); e != "" {
+			return e
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Edit writes the content to a temporary file matching pattern (see
+// os.CreateTemp), opens it in the user's editor, displays a diff of the
+// changes and asks for confirmation before returning the edited content.
+//
+// ErrEditCancelled is returned if nothing was changed or the changes were
+// declined.
+func Edit(content, pattern string, g *config.Data, in io.Reader, out io.Writer) (string, error) {
+	if g.Flag.NonInteractive {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("--edit requires an interactive terminal"),
+			Remediation: "Pass the new content using --content or --value instead.",
+		}
+	}
+
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path) // #nosec G104
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close() // #nosec G104
+		return "", fmt.Errorf("error writing temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("error writing temporary file: %w", err)
+	}
+
+	args := strings.Fields(Editor())
+	args = append(args, path)
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	//
+	// Disabling as the editor is configured by the user's own environment.
+	/* #nosec */
+	c := exec.Command(args[0], args[1:]...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("error running editor '%s': %w", args[0], err),
+			Remediation: "Set the VISUAL or EDITOR environment variable to the editor you'd like to use.",
+		}
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as we created the file ourselves.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading temporary file: %w", err)
+	}
+	edited := string(data)
+
+	// Most editors append a newline to the final line when saving, which would
+	// otherwise show up as a change to content that didn't have one.
+	if !strings.HasSuffix(content, "\n") {
+		edited = strings.TrimSuffix(edited, "\n")
+	}
+
+	if edited == content {
+		text.Info(out, "No changes were made.")
+		return "", ErrEditCancelled
+	}
+
+	text.Break(out)
+	fmt.Fprint(out, Diff(content, edited))
+	text.Break(out)
+
+	ok, err := Confirm(ConfirmInformational, "Apply these changes? [y/N] ", g, in, out)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", ErrEditCancelled
+	}
+	return edited, nil
+}
+
+// Diff returns a line based diff of the two strings, where removed lines are
+// prefixed with '-', added lines with '+' and unchanged lines with a space.
+func Diff(from, to string) string {
+	a := strings.Split(strings.TrimSuffix(from, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(to, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&sb, " %s\n", a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			fmt.Fprintf(&sb, "%s\n", text.BoldGreen("+"+b[j]))
+			j++
+		default:
+			fmt.Fprintf(&sb, "%s\n", text.BoldRed("-"+a[i]))
+			i++
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestDiff(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{
+			name: "unchanged",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: " a\n b\n",
+		},
+		{
+			name: "changed line",
+			from: "a\nb\nc",
+			to:   "a\nB\nc",
+			want: " a\n-b\n+B\n c\n",
+		},
+		{
+			name: "added and removed lines",
+			from: "a\nb\nc\n",
+			to:   "b\nc\nd\n",
+			want: "-a\n b\n c\n+d\n",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertString(t, testcase.want, cmd.Diff(testcase.from, testcase.to))
+		})
+	}
+}
+
+// writeEditor writes a script that replaces the content of the file it's
+// given and sets it as the user's editor.
+func writeEditor(t *testing.T, content string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "content"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	editor := filepath.Join(dir, "editor")
+	script := "#!/bin/sh\ncat '" + filepath.Join(dir, "content") + "' > \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+}
+
+func TestEdit(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		edited     string
+		flag       config.Flag
+		stdin      string
+		want       string
+		wantError  string
+		wantOutput []string
+	}{
+		{
+			name:       "confirmed",
+			edited:     "new content\n",
+			stdin:      "y\n",
+			want:       "new content",
+			wantOutput: []string{"-old content", "+new content", "Apply these changes?"},
+		},
+		{
+			name:      "declined",
+			edited:    "new content\n",
+			stdin:     "n\n",
+			wantError: cmd.ErrEditCancelled.Error(),
+		},
+		{
+			name:       "auto-yes",
+			edited:     "new content",
+			flag:       config.Flag{AutoYes: true},
+			want:       "new content",
+			wantOutput: []string{"+new content"},
+		},
+		{
+			name:       "unchanged",
+			edited:     "old content\n",
+			wantError:  cmd.ErrEditCancelled.Error(),
+			wantOutput: []string{"No changes were made."},
+		},
+		{
+			name:      "non-interactive",
+			edited:    "new content",
+			flag:      config.Flag{NonInteractive: true},
+			wantError: "--edit requires an interactive terminal",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			writeEditor(t, testcase.edited)
+
+			var out bytes.Buffer
+			g := &config.Data{Flag: testcase.flag}
+			got, err := cmd.Edit("old content", "edit-*.txt", g, strings.NewReader(testcase.stdin), &out)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.want, got)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, out.String(), s)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/text"
@@ -32,6 +32,7 @@ type StringFlagOpts struct {
 	Name        string
 	Required    bool
 	Short       rune
+	Validators  []Validator
 }
 
 // RegisterFlag defines a flag.
@@ -46,6 +47,9 @@ func (b Base) RegisterFlag(opts StringFlagOpts) {
 	if opts.Action != nil {
 		clause = clause.Action(opts.Action)
 	}
+	if len(opts.Validators) > 0 {
+		clause = clause.Action(Validate(opts.Validators...))
+	}
 	clause.StringVar(opts.Dst)
 }
 
@@ -131,7 +135,7 @@ func (sv *OptionalServiceNameID) Parse(client api.Interface) (serviceID string,
 			return s.ID, nil
 		}
 	}
-	return serviceID, errors.New("error matching service name with available services")
+	return serviceID, fsterr.ErrServiceNameNotFound
 }
 
 // OptionalCustomerID represents a Fastly customer ID.
@@ -154,6 +158,20 @@ func (sv *OptionalCustomerID) Parse() error {
 	return nil
 }
 
+// CustomerID returns the customer ID from the flag or environment (see
+// OptionalCustomerID.Parse), falling back to the customer of the
+// authenticated user.
+func CustomerID(flag *OptionalCustomerID, client api.Interface) (string, error) {
+	if err := flag.Parse(); err == nil {
+		return flag.Value, nil
+	}
+	u, err := client.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+	return u.CustomerID, nil
+}
+
 // AutoCloneFlagOpts enables easy configuration of the --autoclone flag defined
 // via the RegisterAutoCloneFlag constructor.
 type AutoCloneFlagOpts struct {
@@ -163,8 +181,10 @@ type AutoCloneFlagOpts struct {
 
 // RegisterAutoCloneFlag defines a --autoclone flag that will cause a clone of the
 // identified service version if it's found to be active or locked.
+//
+// NOTE: --autoclone=dry-run is rewritten by app.Run (see SplitAutoCloneArgs).
 func (b Base) RegisterAutoCloneFlag(opts AutoCloneFlagOpts) {
-	b.CmdClause.Flag("autoclone", "If the selected service version is not editable, clone it and use the clone.").Action(opts.Action).BoolVar(opts.Dst)
+	b.CmdClause.Flag("autoclone", "If the selected service version is not editable, clone it and use the clone (--autoclone=dry-run reports whether a clone is needed)").Action(opts.Action).BoolVar(opts.Dst)
 }
 
 // OptionalAutoClone defines a method set for abstracting the logic required to
@@ -176,32 +196,62 @@ type OptionalAutoClone struct {
 // Parse returns a service version.
 //
 // The returned version is either the same as the input argument `v` or it's a
-// cloned version if the input argument was either active or locked.
-func (ac *OptionalAutoClone) Parse(v *fastly.Version, sid string, verbose bool, out io.Writer, client api.Interface) (*fastly.Version, error) {
+// cloned version if the input argument was either active or locked. A version
+// cloned from `v` earlier in the same shell session is reused while it remains
+// editable.
+//
+// When --autoclone=dry-run was given (see config.Data.AutoCloneDryRun), Parse
+// reports whether a clone would be needed and returns
+// fsterr.ErrAutoCloneDryRun.
+func (ac *OptionalAutoClone) Parse(v *fastly.Version, sid string, out io.Writer, client api.Interface, g *config.Data) (*fastly.Version, error) {
+	editable := !v.Active && !v.Locked
+
 	// if user didn't provide --autoclone flag
-	if !ac.Value && (v.Active || v.Locked) {
+	if !ac.Value && !editable {
 		return nil, fsterr.RemediationError{
 			Inner:       fmt.Errorf("service version %d is not editable", v.Number),
 			Remediation: fsterr.AutoCloneRemediation,
 		}
 	}
-	if ac.Value && (v.Active || v.Locked) {
-		version, err := client.CloneVersion(&fastly.CloneVersionInput{
-			ServiceID:      sid,
-			ServiceVersion: v.Number,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("error cloning service version: %w", err)
-		}
-		if verbose {
-			msg := fmt.Sprintf("Service version %d is not editable, so it was automatically cloned because --autoclone is enabled. Now operating on version %d.", v.Number, version.Number)
-			text.Output(out, msg)
+
+	statePath := g.StatePath(AutoCloneStateFile)
+	var reuse *fastly.Version
+	if !editable {
+		reuse = reusableAutoClone(statePath, sid, v.Number, client)
+	}
+
+	if g.AutoCloneDryRun {
+		var reuseNumber int
+		if reuse != nil {
+			reuseNumber = reuse.Number
 		}
-		return version, nil
+		text.Output(out, autoCloneDryRunMessage(v.Number, reuseNumber, editable))
+		return nil, fsterr.ErrAutoCloneDryRun
 	}
 
 	// Treat the function as a no-op if the version is editable.
-	return v, nil
+	if editable {
+		return v, nil
+	}
+
+	if reuse != nil {
+		text.Output(out, "Service version %d is not editable, so version %d (cloned from it earlier in this shell session) is being reused because --autoclone is enabled.", v.Number, reuse.Number)
+		return reuse, nil
+	}
+
+	version, err := client.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      sid,
+		ServiceVersion: v.Number,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error cloning service version: %w", err)
+	}
+	// The state file only allows the clone to be reused, so failing to record
+	// it shouldn't prevent the command from completing.
+	_ = RecordAutoClone(statePath, sid, v.Number, version.Number)
+
+	text.Output(out, "Service version %d is not editable, so it was automatically cloned because --autoclone is enabled. Now operating on version %d.", v.Number, version.Number)
+	return version, nil
 }
 
 // GetActiveVersion returns the active service version.
@@ -227,12 +277,29 @@ func GetSpecifiedVersion(vs []*fastly.Version, version string) (*fastly.Version,
 		}
 	}
 
-	return nil, fmt.Errorf("specified service version not found: %s", version)
+	return nil, fmt.Errorf("%w: %s", fsterr.ErrVersionNotFound, version)
 }
 
-// Content determines if the given flag value is a file path, and if so read
-// the contents from disk, otherwise presume the given value is the content.
-func Content(flagval string) string {
+// StdinFlagValue is the flag value that indicates a flag's content should be
+// read from stdin rather than from disk or the value itself.
+const StdinFlagValue = "-"
+
+// Content determines if the given flag value is "-" (and if so reads the
+// contents from stdin) or a file path (and if so reads the contents from
+// disk), otherwise it presumes the given value is the content. An error is
+// only returned if stdin can't be read.
+func Content(flagval string, stdin io.Reader) (string, error) {
+	if flagval == StdinFlagValue {
+		if stdin == nil {
+			return "", nil
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("error reading stdin: %w", err)
+		}
+		return string(data), nil
+	}
+
 	content := flagval
 	if path, err := filepath.Abs(flagval); err == nil {
 		if _, err := os.Stat(path); err == nil {
@@ -246,7 +313,25 @@ func Content(flagval string) string {
 			}
 		}
 	}
-	return content
+	return content, nil
+}
+
+// Open opens the file at the path given by a flag value, or returns stdin when
+// the value is "-". The caller is responsible for closing the reader.
+func Open(flagval string, stdin io.Reader) (io.ReadCloser, error) {
+	if flagval == StdinFlagValue {
+		if stdin == nil {
+			return nil, fmt.Errorf("error reading stdin: no input available")
+		}
+		return io.NopCloser(stdin), nil
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as we require a user to configure their own environment.
+	/* #nosec */
+	return os.Open(flagval)
 }
 
 // IntToBool converts a binary 0|1 to a boolean.
@@ -2,13 +2,22 @@ package cmd_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/env"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -282,10 +291,9 @@ func TestOptionalAutoCloneParse(t *testing.T) {
 				}
 			}
 
-			verboseMode := true
-			v, err := acv.Parse(c.version, "123", verboseMode, buf, mock.API{
+			v, err := acv.Parse(c.version, "123", buf, mock.API{
 				CloneVersionFn: cloneVersionResult(c.version.Number + 1),
-			})
+			}, &config.Data{})
 			if err != nil {
 				if c.errExpected && errMatches(c.version.Number, err) {
 					return
@@ -315,6 +323,105 @@ func TestOptionalAutoCloneParse(t *testing.T) {
 	}
 }
 
+func TestOptionalAutoCloneDryRun(t *testing.T) {
+	g := &config.Data{AutoCloneDryRun: true}
+	for _, c := range []struct {
+		version *fastly.Version
+		want    string
+	}{
+		{
+			version: &fastly.Version{Number: 1},
+			want:    "Service version 1 is editable, so --autoclone would not clone it.",
+		},
+		{
+			version: &fastly.Version{Number: 1, Active: true},
+			want:    "Service version 1 is not editable, so --autoclone would clone it.",
+		},
+	} {
+		var buf bytes.Buffer
+		acv := &cmd.OptionalAutoClone{OptionalBool: cmd.OptionalBool{Value: true}}
+		_, err := acv.Parse(c.version, "123", &buf, mock.API{
+			CloneVersionFn: func(i *fastly.CloneVersionInput) (*fastly.Version, error) {
+				t.Fatal("unexpected clone during dry run")
+				return nil, nil
+			},
+		}, g)
+		if err != fsterr.ErrAutoCloneDryRun {
+			t.Fatalf("want ErrAutoCloneDryRun, have %v", err)
+		}
+		testutil.AssertStringContains(t, strings.ReplaceAll(buf.String(), "\n", " "), c.want)
+	}
+}
+
+func TestOptionalAutoCloneReuse(t *testing.T) {
+	g := &config.Data{StateDir: t.TempDir()}
+
+	var clones int
+	locked := map[int]bool{}
+	api := mock.API{
+		CloneVersionFn: func(i *fastly.CloneVersionInput) (*fastly.Version, error) {
+			clones++
+			return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion + clones}, nil
+		},
+		GetVersionFn: func(i *fastly.GetVersionInput) (*fastly.Version, error) {
+			return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Locked: locked[i.ServiceVersion]}, nil
+		},
+	}
+	acv := &cmd.OptionalAutoClone{OptionalBool: cmd.OptionalBool{Value: true}}
+	active := &fastly.Version{Number: 1, Active: true}
+
+	v, err := acv.Parse(active, "123", io.Discard, api, g)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, v.Number)
+
+	// A second command in the same session reuses the clone.
+	var buf bytes.Buffer
+	v, err = acv.Parse(active, "123", &buf, api, g)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, v.Number)
+	testutil.AssertEqual(t, 1, clones)
+	testutil.AssertStringContains(t, strings.ReplaceAll(buf.String(), "\n", " "), "version 2 (cloned from it earlier in this shell session) is being reused")
+
+	// Once the clone is no longer editable a new clone is made.
+	locked[2] = true
+	v, err = acv.Parse(active, "123", io.Discard, api, g)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, v.Number)
+	testutil.AssertEqual(t, 2, clones)
+
+	// A command in another session doesn't reuse it.
+	t.Setenv(env.Session, "other")
+	v, err = acv.Parse(active, "123", io.Discard, api, g)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 4, v.Number)
+	testutil.AssertEqual(t, 3, clones)
+}
+
+func TestAutoCloneSession(t *testing.T) {
+	t.Setenv(env.Session, "ci-123")
+	testutil.AssertString(t, "env:ci-123", cmd.AutoCloneSession())
+
+	// Without FASTLY_SESSION the session is the parent process, which is
+	// identified by its start time as well as its ID where possible.
+	t.Setenv(env.Session, "")
+	session := cmd.AutoCloneSession()
+	testutil.AssertBool(t, true, strings.HasPrefix(session, strconv.Itoa(os.Getppid())))
+	if runtime.GOOS == "linux" {
+		testutil.AssertStringContains(t, session, "@")
+	}
+	testutil.AssertString(t, session, cmd.AutoCloneSession())
+}
+
+func TestSplitAutoCloneArgs(t *testing.T) {
+	args, dryRun := cmd.SplitAutoCloneArgs([]string{"backend", "create", "--autoclone=dry-run", "--", "--autoclone=dry-run"})
+	testutil.AssertEqual(t, []string{"backend", "create", "--autoclone", "--", "--autoclone=dry-run"}, args)
+	testutil.AssertEqual(t, true, dryRun)
+
+	args, dryRun = cmd.SplitAutoCloneArgs([]string{"backend", "create", "--autoclone"})
+	testutil.AssertEqual(t, []string{"backend", "create", "--autoclone"}, args)
+	testutil.AssertEqual(t, false, dryRun)
+}
+
 // cloneVersionResult returns a function which returns a specific cloned version.
 func cloneVersionResult(version int) func(i *fastly.CloneVersionInput) (*fastly.Version, error) {
 	return func(i *fastly.CloneVersionInput) (*fastly.Version, error) {
@@ -331,3 +438,60 @@ func cloneVersionResult(version int) func(i *fastly.CloneVersionInput) (*fastly.
 func errMatches(version int, err error) bool {
 	return err.Error() == fmt.Sprintf("service version %d is not editable", version)
 }
+
+func TestContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "content.vcl")
+	if err := os.WriteFile(path, []byte("from file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for flagval, want := range map[string]string{
+		"-":       "from stdin\n",
+		path:      "from file",
+		"literal": "literal",
+	} {
+		content, err := cmd.Content(flagval, strings.NewReader("from stdin\n"))
+		testutil.AssertNoError(t, err)
+		testutil.AssertString(t, want, content)
+	}
+
+	_, err := cmd.Content("-", iotest.ErrReader(errors.New("closed")))
+	testutil.AssertErrorContains(t, err, "error reading stdin: closed")
+
+	r, err := cmd.Open("-", strings.NewReader("a\nb\n"))
+	testutil.AssertNoError(t, err)
+	data, err := io.ReadAll(r)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "a\nb\n", string(data))
+
+	r, err = cmd.Open(path, nil)
+	testutil.AssertNoError(t, err)
+	data, err = io.ReadAll(r)
+	testutil.AssertNoError(t, err)
+	r.Close()
+	testutil.AssertString(t, "from file", string(data))
+}
+
+func TestCustomerID(t *testing.T) {
+	client := mock.API{
+		GetCurrentUserFn: func() (*fastly.User, error) {
+			return &fastly.User{CustomerID: "current"}, nil
+		},
+	}
+
+	flag := cmd.OptionalCustomerID{OptionalString: cmd.OptionalString{Value: "flag"}}
+	id, err := cmd.CustomerID(&flag, client)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "flag", id)
+
+	t.Setenv(env.CustomerID, "env")
+	id, err = cmd.CustomerID(&cmd.OptionalCustomerID{}, client)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "env", id)
+
+	t.Setenv(env.CustomerID, "")
+	id, err = cmd.CustomerID(&cmd.OptionalCustomerID{}, client)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "current", id)
+}
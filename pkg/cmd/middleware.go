@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"io"
+	"sync"
+
+	"github.com/fastly/cli/pkg/config"
+)
+
+// ExecFunc runs a command, in the same way as the Command interface's Exec
+// method.
+type ExecFunc func(in io.Reader, out io.Writer) error
+
+// Middleware adds cross-cutting behaviour (e.g. timing, auditing or policy
+// checks) around the execution of every command, so that it doesn't need to be
+// implemented in each command's Exec method.
+type Middleware struct {
+	// Name identifies the middleware in the middleware setting of a profile,
+	// which can enable or disable it.
+	Name string
+	// Enabled is whether the middleware runs when the current profile doesn't
+	// enable or disable it.
+	Enabled bool
+	// Wrap returns a function that runs next, the execution of the named
+	// command, doing whatever the middleware does before and/or after it.
+	Wrap func(name string, g *config.Data, next ExecFunc) ExecFunc
+}
+
+var (
+	middleware      []Middleware
+	middlewareMutex sync.Mutex
+)
+
+// RegisterMiddleware adds m to the middleware that wraps the execution of
+// every command. Middleware runs in the order it's registered, so the first
+// middleware registered is the outermost.
+func RegisterMiddleware(m Middleware) {
+	middlewareMutex.Lock()
+	defer middlewareMutex.Unlock()
+	middleware = append(middleware, m)
+}
+
+// RegisteredMiddleware returns the middleware registered so far.
+func RegisteredMiddleware() []Middleware {
+	middlewareMutex.Lock()
+	defer middlewareMutex.Unlock()
+	return append([]Middleware{}, middleware...)
+}
+
+// Chain returns a function that runs exec, the execution of the named command,
+// via the registered middleware that's enabled for the current profile.
+func Chain(name string, g *config.Data, exec ExecFunc) ExecFunc {
+	m := RegisteredMiddleware()
+	for i := len(m) - 1; i >= 0; i-- {
+		if g.MiddlewareEnabled(m[i].Name, m[i].Enabled) {
+			exec = m[i].Wrap(name, g, exec)
+		}
+	}
+	return exec
+}
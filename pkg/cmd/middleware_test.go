@@ -0,0 +1,64 @@
+package cmd_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) cmd.Middleware {
+		return cmd.Middleware{
+			Name:    name,
+			Enabled: name != "off",
+			Wrap: func(command string, g *config.Data, next cmd.ExecFunc) cmd.ExecFunc {
+				return func(in io.Reader, out io.Writer) error {
+					calls = append(calls, name+" before "+command)
+					err := next(in, out)
+					calls = append(calls, name+" after "+command)
+					return err
+				}
+			},
+		}
+	}
+	cmd.RegisterMiddleware(record("outer"))
+	cmd.RegisterMiddleware(record("inner"))
+	cmd.RegisterMiddleware(record("off"))
+
+	exec := func(in io.Reader, out io.Writer) error {
+		calls = append(calls, "exec")
+		return nil
+	}
+
+	for name, testcase := range map[string]struct {
+		profile *config.Profile
+		want    []string
+	}{
+		"defaults": {
+			want: []string{"outer before foo", "inner before foo", "exec", "inner after foo", "outer after foo"},
+		},
+		"profile enables and disables middleware": {
+			profile: &config.Profile{
+				Default:    true,
+				Middleware: map[string]bool{"outer": false, "off": true},
+			},
+			want: []string{"inner before foo", "off before foo", "exec", "off after foo", "inner after foo"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls = nil
+			var g config.Data
+			if testcase.profile != nil {
+				g.File.Profiles = config.Profiles{"test": testcase.profile}
+			}
+			err := cmd.Chain("foo", &g, exec)(strings.NewReader(""), io.Discard)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, testcase.want, calls)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// The formats accepted by the global --output flag. OutputTable is each
+// command's regular, human readable, output.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// OutputFormats are the formats accepted by the global --output flag.
+var OutputFormats = []string{OutputTable, OutputJSON, OutputYAML}
+
+// StructuredOutput returns a function that runs exec, the execution of the
+// named command, and writes its result to out in the given format (JSON or
+// YAML) instead of the command's regular output.
+//
+// The result is the output of the command if it renders JSON (i.e. its --json
+// flag is set), and otherwise the body of the command's last API response, as
+// returned by response.
+func StructuredOutput(name, format string, renders bool, response func() []byte, exec ExecFunc) ExecFunc {
+	return func(in io.Reader, out io.Writer) error {
+		var buf bytes.Buffer
+		w := io.Discard
+		if renders {
+			w = &buf
+		}
+		if err := exec(in, w); err != nil {
+			return err
+		}
+
+		data := bytes.TrimSpace(buf.Bytes())
+		if !renders {
+			data = response()
+		}
+		if len(data) == 0 {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("'fastly %s' has no structured output to render as %s", name, format),
+				Remediation: "Run the command without --output, or with --output table.",
+			}
+		}
+
+		if format == OutputYAML {
+			y, err := JSONToYAML(data)
+			if err != nil {
+				return fmt.Errorf("error rendering output as YAML: %w", err)
+			}
+			_, err = out.Write(y)
+			return err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err != nil {
+			return fmt.Errorf("error rendering output as JSON: %w", err)
+		}
+		indented.WriteByte('\n')
+		_, err := indented.WriteTo(out)
+		return err
+	}
+}
+
+// JSONToYAML converts a JSON document to YAML, keeping the order of the keys
+// of each object.
+func JSONToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(v)
+}
+
+// decodeOrdered decodes the next JSON value from dec, decoding objects as a
+// yaml.MapSlice so that the order of their keys is kept.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		m := yaml.MapSlice{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, yaml.MapItem{Key: key, Value: v})
+		}
+		_, err = dec.Token() // the closing '}'
+		return m, err
+	case json.Delim('['):
+		s := []interface{}{}
+		for dec.More() {
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		_, err = dec.Token() // the closing ']'
+		return s, err
+	}
+	if n, ok := tok.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := n.Float64()
+		return f, err
+	}
+	return tok, nil
+}
@@ -0,0 +1,51 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	y, err := cmd.JSONToYAML([]byte(`{"name":"foo","id":"123","versions":[{"number":1,"active":true}],"ttl":1.5}`))
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "name: foo\nid: \"123\"\nversions:\n- number: 1\n  active: true\nttl: 1.5\n", string(y))
+}
+
+func TestStructuredOutput(t *testing.T) {
+	render := func(in io.Reader, out io.Writer) error {
+		fmt.Fprintln(out, `{"id":"123"}`)
+		return nil
+	}
+	table := func(in io.Reader, out io.Writer) error {
+		fmt.Fprintln(out, "ID: 123")
+		return nil
+	}
+	response := func() []byte { return []byte(`[{"id":"456"}]`) }
+	none := func() []byte { return nil }
+
+	for name, testcase := range map[string]struct {
+		format    string
+		renders   bool
+		exec      cmd.ExecFunc
+		response  func() []byte
+		want      string
+		wantError string
+	}{
+		"json from command":  {format: cmd.OutputJSON, renders: true, exec: render, response: none, want: "{\n  \"id\": \"123\"\n}\n"},
+		"yaml from command":  {format: cmd.OutputYAML, renders: true, exec: render, response: none, want: "id: \"123\"\n"},
+		"yaml from response": {format: cmd.OutputYAML, exec: table, response: response, want: "- id: \"456\"\n"},
+		"no response":        {format: cmd.OutputJSON, exec: table, response: none, wantError: "'fastly service describe' has no structured output to render as json"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := cmd.StructuredOutput("service describe", testcase.format, testcase.renders, testcase.response, testcase.exec)(nil, &out)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.want, out.String())
+		})
+	}
+}
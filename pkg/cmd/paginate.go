@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/fastly/cli/pkg/snapshot"
+)
+
+// Paginator fetches a list from the API a page at a time. It's implemented by
+// the API client's paginators (e.g. fastly.PaginatorServices).
+type Paginator[T any] interface {
+	HasNext() bool
+	Remaining() int
+	GetNext() ([]T, error)
+}
+
+// PaginateOpts controls which pages of a list Paginate fetches.
+type PaginateOpts struct {
+	// Page is the only page to fetch, if set. Otherwise every page is fetched.
+	Page int
+	// MaxItems is the maximum number of items to return, if set. No more pages
+	// are fetched than are needed for them.
+	MaxItems int
+	// Concurrency is the maximum number of pages to fetch at once, which
+	// defaults to snapshot.Concurrency.
+	Concurrency int
+	// Fetched, if set, is called with the number of each page and how many
+	// items it had, once it's fetched.
+	Fetched func(page, items int)
+}
+
+// Paginate fetches the pages of a list, returning their items in order.
+//
+// newPaginator returns a paginator that starts at the given page, where 0 is
+// the first page. The first page is fetched to find out how many more there
+// are, and then those are fetched concurrently, each by its own paginator.
+// If a page fails to be fetched, the error of the earliest one is returned.
+func Paginate[T any](newPaginator func(page int) Paginator[T], opts PaginateOpts) ([]T, error) {
+	first := newPaginator(opts.Page)
+	if !first.HasNext() {
+		return nil, nil
+	}
+	items, err := first.GetNext()
+	if err != nil {
+		return nil, err
+	}
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+	if opts.Fetched != nil {
+		opts.Fetched(page, len(items))
+	}
+
+	remaining := first.Remaining()
+	if opts.Page > 0 || remaining <= 0 {
+		return truncate(items, opts.MaxItems), nil
+	}
+	if opts.MaxItems > 0 {
+		if len(items) >= opts.MaxItems || len(items) == 0 {
+			return truncate(items, opts.MaxItems), nil
+		}
+		// Every page but the last is as full as the first, so this is how
+		// many more pages are needed for the rest of the items.
+		needed := (opts.MaxItems - 1) / len(items)
+		if needed < remaining {
+			remaining = needed
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = snapshot.Concurrency
+	}
+
+	var mu sync.Mutex
+	pages := make([][]T, remaining)
+	err = snapshot.ForEach(remaining, concurrency, func(i int) error {
+		p := newPaginator(page + 1 + i)
+		if !p.HasNext() {
+			return nil
+		}
+		data, err := p.GetNext()
+		if err != nil {
+			return err
+		}
+		pages[i] = data
+		if opts.Fetched != nil {
+			mu.Lock()
+			opts.Fetched(page+1+i, len(data))
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range pages {
+		items = append(items, data...)
+	}
+	return truncate(items, opts.MaxItems), nil
+}
+
+// truncate returns the first max items, or all of them if max isn't set.
+func truncate[T any](items []T, max int) []T {
+	if max > 0 && len(items) > max {
+		return items[:max]
+	}
+	return items
+}
@@ -0,0 +1,90 @@
+package cmd_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9}}
+
+	for _, testcase := range []struct {
+		name      string
+		opts      cmd.PaginateOpts
+		errPage   int
+		want      []int
+		wantPages []int
+		wantError string
+	}{
+		{
+			name:      "all pages",
+			want:      []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			wantPages: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "all pages one at a time",
+			opts:      cmd.PaginateOpts{Concurrency: 1},
+			want:      []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			wantPages: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "one page",
+			opts:      cmd.PaginateOpts{Page: 3},
+			want:      []int{5, 6},
+			wantPages: []int{3},
+		},
+		{
+			name:      "max items",
+			opts:      cmd.PaginateOpts{MaxItems: 5},
+			want:      []int{1, 2, 3, 4, 5},
+			wantPages: []int{1, 2, 3},
+		},
+		{
+			name:      "max items within the first page",
+			opts:      cmd.PaginateOpts{MaxItems: 1},
+			want:      []int{1},
+			wantPages: []int{1},
+		},
+		{
+			name:      "error",
+			errPage:   2,
+			wantError: testutil.Err.Error(),
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				fetched = make([]bool, len(pages)+1)
+			)
+			testcase.opts.Fetched = func(page, items int) {
+				mu.Lock()
+				defer mu.Unlock()
+				fetched[page] = true
+			}
+
+			items, err := cmd.Paginate(func(page int) cmd.Paginator[int] {
+				p := testutil.NewPaginator(page, pages...)
+				if testcase.errPage > 0 && page == testcase.errPage {
+					p.Err = testutil.Err
+				}
+				return p
+			}, testcase.opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantError != "" {
+				return
+			}
+			testutil.AssertEqual(t, testcase.want, items)
+
+			var fetchedPages []int
+			for page, ok := range fetched {
+				if ok {
+					fetchedPages = append(fetchedPages, page)
+				}
+			}
+			testutil.AssertEqual(t, testcase.wantPages, fetchedPages)
+		})
+	}
+}
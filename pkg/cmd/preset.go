@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// PresetOutput returns a function that runs exec, the execution of the named
+// command, and renders its result as a table of the preset's columns, sorted
+// by the preset's sort field (see --preset).
+//
+// As with StructuredOutput, the result is the output of the command if it
+// renders JSON, and otherwise the body of its last API response.
+//
+// Columns (and the sort field) are paths of fields in each item of the
+// result, with nested fields separated by dots (e.g. versions.0.number).
+// Field names are matched regardless of case and underscores, so
+// active_version matches both active_version and ActiveVersion.
+func PresetOutput(name, preset string, p config.OutputPreset, renders bool, response func() []byte, exec ExecFunc) ExecFunc {
+	return func(in io.Reader, out io.Writer) error {
+		var buf bytes.Buffer
+		w := io.Discard
+		if renders {
+			w = &buf
+		}
+		if err := exec(in, w); err != nil {
+			return err
+		}
+
+		data := bytes.TrimSpace(buf.Bytes())
+		if !renders {
+			data = response()
+		}
+		if len(data) == 0 {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("'fastly %s' has no output to render with the '%s' preset", name, preset),
+				Remediation: "Run the command without --preset.",
+			}
+		}
+
+		rows, err := presetRows(data)
+		if err != nil {
+			return fmt.Errorf("error rendering output with the '%s' preset: %w", preset, err)
+		}
+		for _, c := range p.Columns {
+			if !anyHasField(rows, c) {
+				return fsterr.RemediationError{
+					Inner:       fmt.Errorf("column '%s' of the '%s' preset isn't a field of the output of 'fastly %s'", c, preset, name),
+					Remediation: fmt.Sprintf("Run 'fastly %s --output json' to see the available fields.", name),
+				}
+			}
+		}
+
+		if p.Sort != "" {
+			field := strings.TrimPrefix(p.Sort, "-")
+			desc := field != p.Sort
+			sort.SliceStable(rows, func(i, j int) bool {
+				a, _ := lookupField(rows[i], field)
+				b, _ := lookupField(rows[j], field)
+				if desc {
+					return lessValue(b, a)
+				}
+				return lessValue(a, b)
+			})
+		}
+
+		tw := text.NewTable(out)
+		header := make([]interface{}, len(p.Columns))
+		for i, c := range p.Columns {
+			header[i] = strings.ToUpper(strings.NewReplacer("_", " ", ".", " ").Replace(c))
+		}
+		tw.AddHeader(header...)
+		for _, r := range rows {
+			line := make([]interface{}, len(p.Columns))
+			for i, c := range p.Columns {
+				v, _ := lookupField(r, c)
+				line[i] = formatValue(v)
+			}
+			tw.AddLine(line...)
+		}
+		tw.Print()
+		return nil
+	}
+}
+
+// ValidatePreset checks the preset can be used to render a table.
+func ValidatePreset(preset string, p config.OutputPreset) error {
+	if len(p.Columns) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the '%s' output preset has no columns", preset),
+			Remediation: fmt.Sprintf("Add a columns setting to the [output.%s] section of the config file ('fastly config --location' displays its path).", preset),
+		}
+	}
+	return nil
+}
+
+// presetRows decodes the JSON result into the items to render. An object is
+// rendered as a single row, unless its data field is an array of items (as in
+// JSON:API responses).
+func presetRows(data []byte) ([]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch t := v.(type) {
+	case []interface{}:
+		return t, nil
+	case map[string]interface{}:
+		if items, ok := t["data"].([]interface{}); ok {
+			return items, nil
+		}
+		return []interface{}{t}, nil
+	}
+	return nil, fmt.Errorf("expected a JSON array or object")
+}
+
+// lookupField returns the value at the dot separated path within v.
+func lookupField(v interface{}, path string) (interface{}, bool) {
+	for _, seg := range strings.Split(path, ".") {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			found := false
+			for k, fv := range t {
+				if normalizeField(k) == normalizeField(seg) {
+					v, found = fv, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			v = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func normalizeField(s string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(s))
+}
+
+func anyHasField(rows []interface{}, path string) bool {
+	if len(rows) == 0 {
+		return true
+	}
+	for _, r := range rows {
+		if _, ok := lookupField(r, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lessValue orders numbers numerically and everything else by its formatted
+// value, with missing (nil) values first.
+func lessValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		af, aerr := an.Float64()
+		bf, berr := bn.Float64()
+		if aerr == nil && berr == nil {
+			return af < bf
+		}
+	}
+	return formatValue(a) < formatValue(b)
+}
+
+// formatValue formats a JSON value for display in a table cell.
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
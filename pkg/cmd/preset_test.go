@@ -0,0 +1,91 @@
+package cmd_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestPresetOutput(t *testing.T) {
+	const services = `[
+		{"Name": "beta", "ID": "2", "ActiveVersion": 10, "Versions": [{"Number": 1}]},
+		{"Name": "alpha", "ID": "1", "ActiveVersion": 3, "Versions": [{"Number": 7}]},
+		{"Name": "gamma", "ID": "3", "ActiveVersion": null, "Versions": []}
+	]`
+
+	for _, testcase := range []struct {
+		name      string
+		preset    config.OutputPreset
+		data      string
+		renders   bool
+		want      string
+		wantError string
+	}{
+		{
+			name:    "columns in the order given",
+			preset:  config.OutputPreset{Columns: []string{"id", "name"}},
+			data:    services,
+			renders: true,
+			want:    "ID  NAME\n2   beta\n1   alpha\n3   gamma\n",
+		},
+		{
+			name:    "sorted numerically and nested fields",
+			preset:  config.OutputPreset{Columns: []string{"name", "active_version", "versions.0.number"}, Sort: "active_version"},
+			data:    services,
+			renders: true,
+			want:    "NAME   ACTIVE VERSION  VERSIONS 0 NUMBER\ngamma                  \nalpha  3               7\nbeta   10              1\n",
+		},
+		{
+			name:    "sorted descending",
+			preset:  config.OutputPreset{Columns: []string{"name"}, Sort: "-name"},
+			data:    services,
+			renders: true,
+			want:    "NAME\ngamma\nbeta\nalpha\n",
+		},
+		{
+			name:   "last API response",
+			preset: config.OutputPreset{Columns: []string{"number", "comment"}},
+			data:   `{"number": 1, "comment": "initial"}`,
+			want:   "NUMBER  COMMENT\n1       initial\n",
+		},
+		{
+			name:   "JSON:API response",
+			preset: config.OutputPreset{Columns: []string{"id", "attributes.name"}},
+			data:   `{"data": [{"id": "a", "attributes": {"name": "foo"}}]}`,
+			want:   "ID  ATTRIBUTES NAME\na   foo\n",
+		},
+		{
+			name:      "unknown column",
+			preset:    config.OutputPreset{Columns: []string{"nope"}},
+			data:      services,
+			renders:   true,
+			wantError: "column 'nope' of the 'test' preset isn't a field of the output of 'fastly service list'",
+		},
+		{
+			name:      "no output",
+			preset:    config.OutputPreset{Columns: []string{"id"}},
+			wantError: "'fastly service list' has no output to render with the 'test' preset",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			exec := func(in io.Reader, out io.Writer) error {
+				if testcase.renders {
+					io.WriteString(out, testcase.data)
+				}
+				return nil
+			}
+			response := func() []byte {
+				return []byte(testcase.data)
+			}
+
+			var out bytes.Buffer
+			err := cmd.PresetOutput("service list", "test", testcase.preset, testcase.renders, response, exec)(nil, &out)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// IDOrNameDesc is appended to the description of ID flags that also accept
+// the resource's name (see ResolveOnNotFound).
+const IDOrNameDesc = " (or its name)"
+
+// Resolver returns the ID of a resource, given the value of a flag that
+// accepts either the resource's ID or its name.
+type Resolver func(idOrName string) (string, error)
+
+// ResolveOnNotFound calls fn with the value of an ID flag. If the API responds
+// that the resource wasn't found, the value is treated as the resource's name:
+// it's resolved to an ID and fn is called again with that ID.
+//
+// This allows ID flags to also accept names, without the cost of looking up
+// the IDs that are given directly.
+func ResolveOnNotFound(value string, resolve Resolver, fn func(id string) error) error {
+	err := fn(value)
+	var httpErr *fastly.HTTPError
+	if err == nil || !errors.As(err, &httpErr) || !httpErr.IsNotFound() {
+		return err
+	}
+
+	id, rerr := resolve(value)
+	if rerr != nil {
+		return rerr
+	}
+	// The value was already a valid ID, so it's something else that wasn't
+	// found (e.g. an entry within the resource).
+	if id == value {
+		return err
+	}
+	return fn(id)
+}
+
+// namedResource is a resource that can be identified by its ID or name.
+type namedResource struct {
+	ID   string
+	Name string
+}
+
+// matchResource returns the ID of the resource whose ID or name is idOrName.
+// kind names the type of resource (e.g. "ACL") and list is the command that
+// lists them, for use in errors.
+func matchResource(kind, list, idOrName string, resources []namedResource) (string, error) {
+	var ids []string
+	for _, r := range resources {
+		if r.ID == idOrName {
+			return r.ID, nil
+		}
+		if r.Name == idOrName {
+			ids = append(ids, r.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("no %s found with the ID or name '%s'", kind, idOrName),
+			Remediation: fmt.Sprintf("Run 'fastly %s' to see the available %ss.", list, kind),
+		}
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fsterr.RemediationError{
+		Inner:       fmt.Errorf("the name '%s' matches %d %ss (IDs: %s)", idOrName, len(ids), kind, strings.Join(ids, ", ")),
+		Remediation: "Pass the ID of the intended resource instead of its name.",
+	}
+}
+
+// resolverVersions returns the service versions whose resources are searched
+// when resolving a name: the active version (if there is one) followed by
+// the latest version.
+func resolverVersions(client api.Interface, serviceID string) ([]int, error) {
+	vs, err := client.ListVersions(&fastly.ListVersionsInput{ServiceID: serviceID})
+	if err != nil {
+		return nil, fmt.Errorf("error listing service versions: %w", err)
+	}
+	var numbers []int
+	if v, err := GetActiveVersion(vs); err == nil {
+		numbers = append(numbers, v.Number)
+	}
+	latest := 0
+	for _, v := range vs {
+		if v.Number > latest {
+			latest = v.Number
+		}
+	}
+	if latest > 0 && (len(numbers) == 0 || numbers[0] != latest) {
+		numbers = append(numbers, latest)
+	}
+	return numbers, nil
+}
+
+// resolveVersioned resolves idOrName against the resources returned by list
+// for each of the service's resolver versions in turn.
+func resolveVersioned(client api.Interface, serviceID, kind, listCmd, idOrName string, list func(version int) ([]namedResource, error)) (string, error) {
+	versions, err := resolverVersions(client, serviceID)
+	if err != nil {
+		return "", err
+	}
+	err = fsterr.RemediationError{
+		Inner:       fmt.Errorf("no %s found with the ID or name '%s'", kind, idOrName),
+		Remediation: fmt.Sprintf("Run 'fastly %s' to see the available %ss.", listCmd, kind),
+	}
+	for _, v := range versions {
+		resources, lerr := list(v)
+		if lerr != nil {
+			return "", lerr
+		}
+		var id string
+		id, err = matchResource(kind, listCmd, idOrName, resources)
+		if err == nil {
+			return id, nil
+		}
+	}
+	return "", err
+}
+
+// ACLResolver returns a Resolver for the names of the service's ACLs.
+func ACLResolver(client api.Interface, serviceID string) Resolver {
+	return func(idOrName string) (string, error) {
+		return resolveVersioned(client, serviceID, "ACL", "acl list", idOrName, func(version int) ([]namedResource, error) {
+			acls, err := client.ListACLs(&fastly.ListACLsInput{ServiceID: serviceID, ServiceVersion: version})
+			if err != nil {
+				return nil, fmt.Errorf("error listing ACLs: %w", err)
+			}
+			resources := make([]namedResource, len(acls))
+			for i, a := range acls {
+				resources[i] = namedResource{ID: a.ID, Name: a.Name}
+			}
+			return resources, nil
+		})
+	}
+}
+
+// DictionaryResolver returns a Resolver for the names of the service's
+// dictionaries.
+func DictionaryResolver(client api.Interface, serviceID string) Resolver {
+	return func(idOrName string) (string, error) {
+		return resolveVersioned(client, serviceID, "dictionary", "dictionary list", idOrName, func(version int) ([]namedResource, error) {
+			ds, err := client.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: serviceID, ServiceVersion: version})
+			if err != nil {
+				return nil, fmt.Errorf("error listing dictionaries: %w", err)
+			}
+			resources := make([]namedResource, len(ds))
+			for i, d := range ds {
+				resources[i] = namedResource{ID: d.ID, Name: d.Name}
+			}
+			return resources, nil
+		})
+	}
+}
+
+// SnippetResolver returns a Resolver for the names of the VCL snippets in the
+// given version of the service.
+func SnippetResolver(client api.Interface, serviceID string, version int) Resolver {
+	return func(idOrName string) (string, error) {
+		ss, err := client.ListSnippets(&fastly.ListSnippetsInput{ServiceID: serviceID, ServiceVersion: version})
+		if err != nil {
+			return "", fmt.Errorf("error listing VCL snippets: %w", err)
+		}
+		resources := make([]namedResource, len(ss))
+		for i, s := range ss {
+			resources[i] = namedResource{ID: s.ID, Name: s.Name}
+		}
+		return matchResource("VCL snippet", "vcl snippet list", idOrName, resources)
+	}
+}
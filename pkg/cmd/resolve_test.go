@@ -0,0 +1,103 @@
+package cmd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestResolveOnNotFound(t *testing.T) {
+	notFound := &fastly.HTTPError{StatusCode: http.StatusNotFound}
+	resolver := func(idOrName string) (string, error) {
+		if idOrName == "name" {
+			return "id", nil
+		}
+		return idOrName, nil
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		value     string
+		exists    string
+		err       error
+		wantIDs   []string
+		wantError string
+	}{
+		{
+			name:    "ID",
+			value:   "id",
+			exists:  "id",
+			wantIDs: []string{"id"},
+		},
+		{
+			name:    "name",
+			value:   "name",
+			exists:  "id",
+			wantIDs: []string{"name", "id"},
+		},
+		{
+			name:      "something else isn't found",
+			value:     "id",
+			wantIDs:   []string{"id"},
+			wantError: "404 - Not Found",
+		},
+		{
+			name:      "other errors aren't resolved",
+			value:     "name",
+			err:       testutil.Err,
+			wantIDs:   []string{"name"},
+			wantError: testutil.Err.Error(),
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var ids []string
+			err := cmd.ResolveOnNotFound(testcase.value, resolver, func(id string) error {
+				ids = append(ids, id)
+				if testcase.err != nil {
+					return testcase.err
+				}
+				if id != testcase.exists {
+					return notFound
+				}
+				return nil
+			})
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.wantIDs, ids)
+		})
+	}
+}
+
+func TestDictionaryResolver(t *testing.T) {
+	var versions []int
+	newClient := mock.APIClient(mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListDictionariesFn: func(i *fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+			versions = append(versions, i.ServiceVersion)
+			// The dictionary was only added in the latest version.
+			if i.ServiceVersion == 3 {
+				return []*fastly.Dictionary{{ID: "abc", Name: "new"}}, nil
+			}
+			return []*fastly.Dictionary{{ID: "def", Name: "old"}}, nil
+		},
+	})
+	client, _ := newClient("", "")
+	resolve := cmd.DictionaryResolver(client, "123")
+
+	id, err := resolve("old")
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "def", id)
+
+	id, err = resolve("new")
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "abc", id)
+
+	_, err = resolve("nope")
+	testutil.AssertErrorContains(t, err, "no dictionary found with the ID or name 'nope'")
+
+	// The active version is searched first.
+	testutil.AssertEqual(t, []int{1, 1, 3, 1, 3}, versions)
+}
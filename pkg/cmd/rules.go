@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagRule is a constraint between the flags of a command that kingpin can't
+// express. Rules are checked together by CheckFlagRules, so every problem is
+// reported at once rather than one at a time by the API.
+type FlagRule struct {
+	// Violated is true if the flags break the rule.
+	Violated bool
+	// Message describes the rule.
+	Message string
+}
+
+// ExclusiveFlags returns a rule that's violated if both flags are set.
+func ExclusiveFlags(a string, aSet bool, b string, bSet bool) FlagRule {
+	return FlagRule{
+		Violated: aSet && bSet,
+		Message:  fmt.Sprintf("the --%s flag is mutually exclusive with the --%s flag", a, b),
+	}
+}
+
+// FlagRequires returns a rule that's violated if the flag is set and the
+// requirement isn't met. The requirement completes the sentence "the --flag
+// flag requires ...".
+func FlagRequires(flag string, set, met bool, requirement string) FlagRule {
+	return FlagRule{
+		Violated: set && !met,
+		Message:  fmt.Sprintf("the --%s flag requires %s", flag, requirement),
+	}
+}
+
+// FlagOneOf returns a rule that's violated if the flag is set to a value that
+// isn't one of values.
+func FlagOneOf(flag string, set bool, value string, values ...string) FlagRule {
+	r := FlagRule{
+		Violated: set,
+		Message:  fmt.Sprintf("the --%s flag must be one of %s (got '%s')", flag, strings.Join(values, ", "), value),
+	}
+	for _, v := range values {
+		if value == v {
+			r.Violated = false
+		}
+	}
+	return r
+}
+
+// FlagRange returns a rule that's violated if the flag is set to a value
+// outside of min and max, inclusive.
+func FlagRange(flag string, set bool, value, min, max uint) FlagRule {
+	return FlagRule{
+		Violated: set && (value < min || value > max),
+		Message:  fmt.Sprintf("the --%s flag must be between %d and %d (got %d)", flag, min, max, value),
+	}
+}
+
+// CheckFlagRules returns an error listing every rule that's violated.
+func CheckFlagRules(rules ...FlagRule) error {
+	var problems []string
+	for _, r := range rules {
+		if r.Violated {
+			problems = append(problems, r.Message)
+		}
+	}
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("error parsing arguments: %s", problems[0])
+	}
+	return fmt.Errorf("error parsing arguments: %d problems with the flags:\n\n\t- %s", len(problems), strings.Join(problems, "\n\t- "))
+}
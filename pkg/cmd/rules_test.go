@@ -0,0 +1,47 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestCheckFlagRules(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		rules     []cmd.FlagRule
+		wantError string
+	}{
+		{
+			name: "no violations",
+			rules: []cmd.FlagRule{
+				cmd.ExclusiveFlags("a", true, "b", false),
+				cmd.FlagRequires("c", false, false, "--d"),
+				cmd.FlagOneOf("e", true, "x", "x", "y"),
+				cmd.FlagRange("f", false, 20, 0, 9),
+			},
+		},
+		{
+			name: "one violation",
+			rules: []cmd.FlagRule{
+				cmd.ExclusiveFlags("a", true, "b", true),
+				cmd.FlagRange("f", true, 9, 0, 9),
+			},
+			wantError: "error parsing arguments: the --a flag is mutually exclusive with the --b flag",
+		},
+		{
+			name: "several violations",
+			rules: []cmd.FlagRule{
+				cmd.FlagRequires("c", true, false, "--d"),
+				cmd.FlagOneOf("e", true, "z", "x", "y"),
+				cmd.FlagRange("f", true, 20, 0, 9),
+			},
+			wantError: "error parsing arguments: 3 problems with the flags:\n\n\t- the --c flag requires --d\n\t- the --e flag must be one of x, y (got 'z')\n\t- the --f flag must be between 0 and 9 (got 20)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testutil.AssertErrorContains(t, cmd.CheckFlagRules(tc.rules...), tc.wantError)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/kingpin"
+)
+
+// serviceConfigRemediation is the remediation of an invalid [service.<id>]
+// section of the config file.
+const serviceConfigRemediation = "Correct the [service.%s] section of the config file ('fastly config --location' displays its path)."
+
+// ServiceConfigArgs returns args with the settings of the config file's
+// [service.<id>] section (see config.Services) added as flags, for the service
+// the command operates on. A setting is only added if its flag isn't given, so
+// that flags take priority. The service's ID and settings are also returned,
+// where the settings are nil if the service has none.
+//
+// The service is given by the --service-id flag, the FASTLY_SERVICE_ID env var
+// or fastly.toml. As resolving the --service-name flag requires the API, which
+// itself depends on the settings (i.e. the profile), services given by name
+// don't have their settings applied.
+func ServiceConfigArgs(app *kingpin.Application, args []string, g *config.Data) ([]string, string, *config.Service, error) {
+	if len(g.File.Services) == 0 {
+		return args, "", nil, nil
+	}
+
+	// Parsing errors are reported when the args are parsed for real.
+	ctx, err := app.ParseContext(args)
+	if err != nil || ctx.SelectedCommand == nil {
+		return args, "", nil, nil
+	}
+	flags := ctx.Elements.FlagMap()
+	if _, ok := flags[FlagServiceName]; ok {
+		return args, "", nil, nil
+	}
+	serviceID, _ := g.Manifest.ServiceID()
+	if e, ok := flags[FlagServiceIDName]; ok && e.Value != nil {
+		serviceID = *e.Value
+	}
+	s := g.File.Services[serviceID]
+	if s == nil {
+		return args, "", nil, nil
+	}
+
+	if s.Version != "" && ctx.SelectedCommand.Model(nil).FlagByName(FlagVersionName) != nil {
+		if _, ok := flags[FlagVersionName]; !ok {
+			args = appendFlag(args, FlagVersionName, s.Version)
+		}
+	}
+	if s.Output != "" {
+		if !contains(OutputFormats, s.Output) {
+			return nil, "", nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid output '%s' for service %s in the config file, must be one of: %s", s.Output, serviceID, strings.Join(OutputFormats, ", ")),
+				Remediation: fmt.Sprintf(serviceConfigRemediation, serviceID),
+			}
+		}
+		_, output := flags["output"]
+		_, preset := flags["preset"]
+		if !output && !preset {
+			args = appendFlag(args, "output", s.Output)
+		}
+	}
+	if s.Profile != "" {
+		if _, ok := g.File.Profiles[s.Profile]; !ok {
+			return nil, "", nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("profile '%s' of service %s not found in the config file", s.Profile, serviceID),
+				Remediation: fmt.Sprintf(serviceConfigRemediation, serviceID),
+			}
+		}
+		_, profile := flags["profile"]
+		_, token := flags["token"]
+		if !profile && !token {
+			args = appendFlag(args, "profile", s.Profile)
+		}
+	}
+	return args, serviceID, s, nil
+}
+
+// appendFlag returns args with the flag appended, before any "--" that marks
+// the remaining args as positional.
+func appendFlag(args []string, name, value string) []string {
+	flag := []string{"--" + name, value}
+	for i, a := range args {
+		if a == "--" {
+			return append(append(append([]string{}, args[:i]...), flag...), args[i:]...)
+		}
+	}
+	return append(append([]string{}, args...), flag...)
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmProtected displays a Yes/No prompt before a command modifies a service
+// that's protected by the config file's [service.<id>] protected setting, and
+// returns an error unless it's confirmed.
+//
+// Unlike Confirm, the prompt is only bypassed by the --confirm-destructive (or
+// --confirm-irreversible) flag, and not by the config file's [confirm]
+// settings, as the point of protecting a service is that a shared default
+// can't modify it unnoticed.
+func ConfirmProtected(serviceID string, g *config.Data, in io.Reader, out io.Writer) error {
+	if g.Flag.ConfirmDestructive || g.Flag.ConfirmIrreversible {
+		return nil
+	}
+	if g.Flag.NonInteractive {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("service %s is protected, so modifying it requires confirmation", serviceID),
+			Remediation: fmt.Sprintf("Pass %s to confirm the operation when running non-interactively.", ConfirmDestructive.flag()),
+		}
+	}
+	label := fmt.Sprintf("Service %s is protected by the config file. Are you sure you want to modify it? [y/N] ", serviceID)
+	cont, err := text.AskYesNo(out, label, in)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return fsterr.ErrConfirmationDeclined
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestServiceConfigArgs(t *testing.T) {
+	services := config.Services{
+		"123": &config.Service{Version: "latest", Output: "yaml"},
+		"456": &config.Service{Protected: true},
+	}
+
+	for _, testcase := range []struct {
+		name          string
+		args          string
+		manifestID    string
+		wantArgs      string
+		wantServiceID string
+	}{
+		{
+			name:          "settings added as flags",
+			args:          "list --service-id 123",
+			wantArgs:      "list --service-id 123 --version latest --output yaml",
+			wantServiceID: "123",
+		},
+		{
+			name:          "flags take priority",
+			args:          "list --service-id 123 --version 2 --output table",
+			wantArgs:      "list --service-id 123 --version 2 --output table",
+			wantServiceID: "123",
+		},
+		{
+			name:          "version only added to commands with a --version flag",
+			args:          "run --service-id 123 -- --version",
+			wantArgs:      "run --service-id 123 --output yaml -- --version",
+			wantServiceID: "123",
+		},
+		{
+			name:          "service given by fastly.toml",
+			args:          "list",
+			manifestID:    "123",
+			wantArgs:      "list --version latest --output yaml",
+			wantServiceID: "123",
+		},
+		{
+			name:     "service given by name",
+			args:     "list --service-name foo",
+			wantArgs: "list --service-name foo",
+		},
+		{
+			name:     "service without settings",
+			args:     "list --service-id 789",
+			wantArgs: "list --service-id 789",
+		},
+		{
+			name:          "settings without flags",
+			args:          "list --service-id 456",
+			wantArgs:      "list --service-id 456",
+			wantServiceID: "456",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			app := kingpin.New("fastly", "")
+			app.Writers(io.Discard, io.Discard)
+			app.Terminate(nil)
+			app.Flag("output", "").String()
+			app.Flag("profile", "").String()
+			list := app.Command("list", "")
+			list.Flag("service-id", "").String()
+			list.Flag("service-name", "").String()
+			list.Flag("version", "").Required().String()
+			run := app.Command("run", "")
+			run.Flag("service-id", "").String()
+			run.Arg("args", "").Strings()
+
+			var g config.Data
+			g.File.Services = services
+			g.Manifest.File.ServiceID = testcase.manifestID
+			args, serviceID, _, err := cmd.ServiceConfigArgs(app, strings.Fields(testcase.args), &g)
+			testutil.AssertNoError(t, err)
+			testutil.AssertString(t, testcase.wantArgs, strings.Join(args, " "))
+			testutil.AssertString(t, testcase.wantServiceID, serviceID)
+		})
+	}
+}
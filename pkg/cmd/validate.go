@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/kingpin"
+)
+
+// Validator checks the raw value given to a flag, returning an error that
+// describes the problem if the value is invalid.
+type Validator func(value string) error
+
+// Validate returns a kingpin.Action that runs the given validators against
+// the raw flag value. Actions are applied while the arguments are parsed, so
+// invalid input is reported before any API requests (such as an autoclone)
+// are made.
+func Validate(validators ...Validator) kingpin.Action {
+	return func(e *kingpin.ParseElement, _ *kingpin.ParseContext) error {
+		if e == nil || e.Value == nil {
+			return nil
+		}
+		for _, v := range validators {
+			if err := v(*e.Value); err != nil {
+				name := "value"
+				if e.OneOf.Flag != nil {
+					name = "--" + e.OneOf.Flag.Model().Name
+				}
+				return fmt.Errorf("invalid %s '%s': %w", name, *e.Value, err)
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateHostname validates an RFC 1123 hostname. A leading wildcard label
+// (e.g. *.example.com) is permitted.
+func ValidateHostname(value string) error {
+	host := strings.TrimSuffix(value, ".")
+	if host == "" {
+		return fmt.Errorf("hostname must not be empty")
+	}
+	if len(host) > 253 {
+		return fmt.Errorf("hostname must be no more than 253 characters")
+	}
+	for i, label := range strings.Split(host, ".") {
+		if i == 0 && label == "*" {
+			continue
+		}
+		if err := validateLabel(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("hostname must not contain empty labels")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("hostname label '%s' must be no more than 63 characters", label)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("hostname label '%s' must not start or end with a hyphen", label)
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("hostname label '%s' contains invalid character '%c'", label, r)
+		}
+	}
+	return nil
+}
+
+// ValidateAddress validates a hostname, IPv4, or IPv6 address, optionally
+// followed by a port (e.g. example.com:514).
+func ValidateAddress(value string) error {
+	if strings.Contains(value, "://") {
+		return fmt.Errorf("must be a hostname, IPv4, or IPv6 address without a scheme (e.g. example.com)")
+	}
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+	host := value
+	if h, port, err := net.SplitHostPort(value); err == nil {
+		if err := ValidatePort(port); err != nil {
+			return fmt.Errorf("port %w", err)
+		}
+		host = h
+		if net.ParseIP(host) != nil {
+			return nil
+		}
+	}
+	if err := ValidateHostname(host); err != nil {
+		return fmt.Errorf("must be a hostname, IPv4, or IPv6 address (%w)", err)
+	}
+	return nil
+}
+
+// ValidateIP validates an IPv4 or IPv6 address.
+func ValidateIP(value string) error {
+	if net.ParseIP(value) == nil {
+		return fmt.Errorf("must be an IPv4 or IPv6 address (e.g. 192.0.2.1)")
+	}
+	return nil
+}
+
+// ValidateCIDR validates an IP address range in CIDR notation.
+func ValidateCIDR(value string) error {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return fmt.Errorf("must be an IP range in CIDR notation (e.g. 192.0.2.0/24)")
+	}
+	return nil
+}
+
+// ValidatePort validates a TCP/UDP port number.
+func ValidatePort(value string) error {
+	return ValidateRange(1, 65535)(value)
+}
+
+// ValidateRange returns a Validator that accepts integers between min and max
+// inclusive.
+func ValidateRange(min, max int) Validator {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < min || n > max {
+			return fmt.Errorf("must be a whole number between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+// ValidateDuration validates a positive duration such as "30s" or "1h30m".
+func ValidateDuration(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("must be a duration such as 30s, 5m or 1h30m")
+	}
+	if d <= 0 {
+		return fmt.Errorf("must be a positive duration")
+	}
+	return nil
+}
+
+// ValidateLongDuration validates a positive duration that may be given in
+// days, weeks or years (see ParseLongDuration).
+func ValidateLongDuration(value string) error {
+	_, err := ParseLongDuration(value)
+	return err
+}
+
+// ParseLongDuration parses a duration such as "90d", "2w" or "1y", as well as
+// any duration accepted by time.ParseDuration. A day is 24 hours and a year
+// is 365 days.
+func ParseLongDuration(value string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+		"y": 365 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if strings.HasSuffix(value, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(value, suffix))
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("must be a positive duration such as 90d, 2w or 1y")
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("must be a duration such as 12h, 90d, 2w or 1y")
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be a positive duration")
+	}
+	return d, nil
+}
+
+// ValidateTTL validates a time-to-live given as a whole number of seconds.
+func ValidateTTL(value string) error {
+	if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+		return fmt.Errorf("must be a whole number of seconds")
+	}
+	return nil
+}
+
+// ValidatePercentage validates a percentage between 0 and 100, with an
+// optional trailing % sign.
+func ValidatePercentage(value string) error {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || n < 0 || n > 100 {
+		return fmt.Errorf("must be a percentage between 0 and 100")
+	}
+	return nil
+}
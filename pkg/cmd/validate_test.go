@@ -0,0 +1,110 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestValidators(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		validator cmd.Validator
+		valid     []string
+		invalid   []string
+	}{
+		{
+			name:      "hostname",
+			validator: cmd.ValidateHostname,
+			valid:     []string{"example.com", "www.example.com.", "*.example.com", "my_host.example.com", "localhost"},
+			invalid:   []string{"", "-example.com", "example-.com", "exa mple.com", "example..com", "www.*.com"},
+		},
+		{
+			name:      "address",
+			validator: cmd.ValidateAddress,
+			valid:     []string{"example.com", "192.0.2.1", "2001:db8::1", "example.com:514", "192.0.2.1:443", "[2001:db8::1]:443"},
+			invalid:   []string{"", "http://example.com", "example.com:0", "example.com:http"},
+		},
+		{
+			name:      "ip",
+			validator: cmd.ValidateIP,
+			valid:     []string{"192.0.2.1", "2001:db8::1"},
+			invalid:   []string{"", "192.0.2", "192.0.2.0/24", "example.com"},
+		},
+		{
+			name:      "cidr",
+			validator: cmd.ValidateCIDR,
+			valid:     []string{"192.0.2.0/24", "2001:db8::/32"},
+			invalid:   []string{"", "192.0.2.1", "192.0.2.0/33"},
+		},
+		{
+			name:      "port",
+			validator: cmd.ValidatePort,
+			valid:     []string{"1", "443", "65535"},
+			invalid:   []string{"", "0", "65536", "-1", "http"},
+		},
+		{
+			name:      "range",
+			validator: cmd.ValidateRange(100, 599),
+			valid:     []string{"100", "200", "599"},
+			invalid:   []string{"99", "600", "2xx"},
+		},
+		{
+			name:      "duration",
+			validator: cmd.ValidateDuration,
+			valid:     []string{"30s", "5m", "1h30m"},
+			invalid:   []string{"", "30", "0s", "-5m"},
+		},
+		{
+			name:      "long duration",
+			validator: cmd.ValidateLongDuration,
+			valid:     []string{"12h", "7d", "2w", "1y"},
+			invalid:   []string{"", "7", "0d", "-1y", "1.5d", "d"},
+		},
+		{
+			name:      "ttl",
+			validator: cmd.ValidateTTL,
+			valid:     []string{"0", "3600"},
+			invalid:   []string{"", "-1", "1h", "1.5"},
+		},
+		{
+			name:      "percentage",
+			validator: cmd.ValidatePercentage,
+			valid:     []string{"0", "12.5", "100", "50%"},
+			invalid:   []string{"", "-1", "100.1", "half"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			for _, v := range testcase.valid {
+				if err := testcase.validator(v); err != nil {
+					t.Errorf("want %q to be valid, got: %v", v, err)
+				}
+			}
+			for _, v := range testcase.invalid {
+				if err := testcase.validator(v); err == nil {
+					t.Errorf("want %q to be invalid", v)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterFlagValidators(t *testing.T) {
+	var host string
+	app := kingpin.New("fastly", "")
+	c := cmd.Base{CmdClause: app.Command("example", "")}
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:       "host",
+		Dst:        &host,
+		Validators: []cmd.Validator{cmd.ValidateHostname},
+	})
+
+	_, err := app.Parse([]string{"example", "--host", "www.example.com"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "www.example.com", host)
+
+	_, err = app.Parse([]string{"example", "--host", "www example com"})
+	testutil.AssertErrorContains(t, err, "invalid --host 'www example com': hostname label 'www example com' contains invalid character ' '")
+}
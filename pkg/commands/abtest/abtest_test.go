@@ -0,0 +1,150 @@
+package abtest_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --variant-b flag",
+			Args:      args("ab-test create --name exp1 --variant-a ./testdata/variant_a.vcl --version 3"),
+			WantError: "error parsing arguments: required flag --variant-b not provided",
+		},
+		{
+			Name:      "validate invalid --name flag",
+			Args:      args("ab-test create --name exp-1 --variant-a ./testdata/variant_a.vcl --variant-b ./testdata/variant_b.vcl --service-id 123 --version 3"),
+			WantError: "invalid test name 'exp-1'",
+		},
+		{
+			Name:      "validate invalid --split flag",
+			Args:      args("ab-test create --name exp1 --variant-a ./testdata/variant_a.vcl --variant-b ./testdata/variant_b.vcl --split 100 --service-id 123 --version 3"),
+			WantError: "invalid split 100",
+		},
+		{
+			Name:      "validate both variants from stdin",
+			Args:      args("ab-test create --name exp1 --variant-a - --variant-b - --service-id 123 --version 3"),
+			WantError: "only one flag can read from stdin",
+		},
+		{
+			Name: "validate uneditable version",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:      args("ab-test create --name exp1 --variant-a ./testdata/variant_a.vcl --variant-b ./testdata/variant_b.vcl --service-id 123 --version 1"),
+			WantError: "service version 1 is not editable",
+		},
+	}
+	testutil.RunScenarios(t, scenarios)
+}
+
+func TestCreateSnippets(t *testing.T) {
+	var created []*fastly.CreateSnippetInput
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CreateSnippetFn: func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+			created = append(created, i)
+			return &fastly.Snippet{Name: i.Name}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("ab-test create --name exp1 --variant-a ./testdata/variant_a.vcl --variant-b ./testdata/variant_b.vcl --split 20 --service-id 123 --version 3"), &stdout)
+	opts.APIClient = mock.APIClient(api)
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Created A/B test 'exp1' (service: 123, version: 3, split: 20/80)")
+	testutil.AssertStringContains(t, stdout.String(), `"ab_exp1":"%{req.http.Fastly-AB-exp1}V"`)
+
+	want := []struct {
+		name     string
+		location fastly.SnippetType
+		priority int
+		content  string
+	}{
+		{"ab_exp1_bucket", "recv", 10, "randombool(20, 100)"},
+		{"ab_exp1_hash", "hash", 10, "set req.hash += req.http.Fastly-AB-exp1;"},
+		{"ab_exp1_cookie", "deliver", 10, `add resp.http.Set-Cookie = "ab_exp1=" req.http.Fastly-AB-exp1`},
+		{"ab_exp1_a", "recv", 100, "if (req.http.Fastly-AB-exp1 == \"A\") {\nset req.backend = F_origin_a;"},
+		{"ab_exp1_b", "recv", 100, "if (req.http.Fastly-AB-exp1 == \"B\") {\nset req.backend = F_origin_b;"},
+	}
+	if len(created) != len(want) {
+		t.Fatalf("want %d snippets, have %d", len(want), len(created))
+	}
+	for i, w := range want {
+		s := created[i]
+		testutil.AssertString(t, w.name, s.Name)
+		testutil.AssertString(t, string(w.location), string(s.Type))
+		testutil.AssertEqual(t, w.priority, *s.Priority)
+		testutil.AssertStringContains(t, s.Content, w.content)
+	}
+}
+
+func TestCreateUndo(t *testing.T) {
+	var deleted []string
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CreateSnippetFn: func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+			if strings.HasSuffix(i.Name, "_b") {
+				return nil, testutil.Err
+			}
+			return &fastly.Snippet{Name: i.Name}, nil
+		},
+		DeleteSnippetFn: func(i *fastly.DeleteSnippetInput) error {
+			deleted = append(deleted, i.Name)
+			return nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("ab-test create --name exp1 --variant-a ./testdata/variant_a.vcl --variant-b ./testdata/variant_b.vcl --type fetch --service-id 123 --version 3"), &stdout)
+	opts.APIClient = mock.APIClient(api)
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "error creating snippet 'ab_exp1_b'")
+	testutil.AssertEqual(t, []string{"ab_exp1_a", "ab_exp1_cookie", "ab_exp1_hash", "ab_exp1_bucket"}, deleted)
+}
+
+func TestReport(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --file flag",
+			Args:      args("ab-test report --name exp1"),
+			WantError: "error parsing arguments: required flag --file not provided",
+		},
+		{
+			Name:      "validate missing file",
+			Args:      args("ab-test report --name exp1 --file ./testdata/missing.json"),
+			WantError: "error opening logs",
+		},
+		{
+			Name:      "validate no variants",
+			Args:      args("ab-test report --name exp2 --file ./testdata/logs.json"),
+			WantError: "no requests in the logs have a variant of test 'exp2'",
+		},
+		{
+			Name: "success",
+			Args: args("ab-test report --name exp1 --file ./testdata/logs.json"),
+			WantOutputs: []string{
+				"VARIANT  REQUESTS  SHARE  2XX  3XX  4XX  5XX  ERROR RATIO",
+				"A        4         66.7%  2    1    1    0    0.0%",
+				"B        2         33.3%  1    0    0    1    50.0%",
+				"2 lines without a variant were skipped.",
+			},
+		},
+		{
+			Name:       "success with --field and --json",
+			Args:       args("ab-test report --name other --field ab_exp1 --file ./testdata/logs.json --json"),
+			WantOutput: `[{"variant":"A","requests":4,"status_2xx":2,"status_3xx":1,"status_4xx":1,"status_5xx":0},{"variant":"B","requests":2,"status_2xx":1,"status_3xx":0,"status_4xx":0,"status_5xx":1}]`,
+		},
+	}
+	testutil.RunScenarios(t, scenarios)
+}
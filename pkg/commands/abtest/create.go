@@ -0,0 +1,251 @@
+package abtest
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/vcl"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/undo"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const (
+	// cookieMaxAge is the time, in seconds, a client stays in its variant.
+	cookieMaxAge = 2592000
+
+	// setupPriority is the priority of the snippets that assign clients to a
+	// variant, so that they run before the variants themselves.
+	setupPriority = 10
+)
+
+// Locations are the VCL subroutines the variants can be placed in. Unlike
+// snippets in general, a variant can't be placed in vcl_init or outside a
+// subroutine, as it's conditional on the request's variant.
+var Locations = []string{"recv", "hash", "hit", "miss", "pass", "fetch", "error", "deliver", "log"}
+
+// validName matches the names of tests, which are used in VCL identifiers.
+var validName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Header returns the name of the request header holding the variant ("A" or
+// "B") of a request in the test, which is the header to log to report on it.
+func Header(name string) string {
+	return "Fastly-AB-" + name
+}
+
+// Cookie returns the name of the cookie that keeps a client in its variant of
+// the test.
+func Cookie(name string) string {
+	return "ab_" + name
+}
+
+// CreateCommand calls the Fastly API to create the snippets of an A/B test.
+type CreateCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	allowSecrets   bool
+	autoClone      cmd.OptionalAutoClone
+	location       string
+	name           string
+	priority       int
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	split          int
+	variantA       string
+	variantB       string
+}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *CreateCommand {
+	var c CreateCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("create", "Create an A/B test, which assigns each client to a variant with a cookie and runs that variant's VCL snippet")
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of the test (letters, digits and underscores), used to name its snippets, cookie and header").Required().StringVar(&c.name)
+	c.CmdClause.Flag("variant-a", "VCL snippet of variant A passed as file path, content, or - for stdin").Required().StringVar(&c.variantA)
+	c.CmdClause.Flag("variant-b", "VCL snippet of variant B passed as file path, content, or - for stdin").Required().StringVar(&c.variantB)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.CmdClause.Flag("allow-secrets", vcl.AllowSecretsFlagDesc).BoolVar(&c.allowSecrets)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("priority", "Priority of the variants' snippets. Lower numbers execute first").Short('p').Default("100").IntVar(&c.priority)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("split", "Percentage of new clients assigned to variant A (1-99), the rest being assigned to variant B").Default("50").IntVar(&c.split)
+	c.CmdClause.Flag("type", "The location in generated VCL where the variants' snippets are placed").Default("recv").HintOptions(Locations...).EnumVar(&c.location, Locations...)
+
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(in io.Reader, out io.Writer) (err error) {
+	if !validName.MatchString(c.name) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid test name '%s'", c.name),
+			Remediation: "The name can only contain letters, digits and underscores, as it's used in VCL.",
+		}
+	}
+	if c.split < 1 || c.split > 99 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid split %d", c.split),
+			Remediation: "Give the percentage of clients assigned to variant A, from 1 to 99.",
+		}
+	}
+
+	// The content is read once, as it may be read from stdin.
+	variants := []struct{ label, content string }{
+		{label: "A"},
+		{label: "B"},
+	}
+	for i, flagval := range []string{c.variantA, c.variantB} {
+		v := &variants[i]
+		if v.content, err = cmd.Content(flagval, c.Globals.Stdin); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		if err := vcl.CheckSecrets(v.content, c.allowSecrets, out); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	inputs := []*fastly.CreateSnippetInput{
+		c.snippet(serviceID, serviceVersion.Number, "bucket", "recv", setupPriority, bucketVCL(c.name, c.split)),
+		c.snippet(serviceID, serviceVersion.Number, "hash", "hash", setupPriority, hashVCL(c.name)),
+		c.snippet(serviceID, serviceVersion.Number, "cookie", "deliver", setupPriority, cookieVCL(c.name)),
+	}
+	for _, v := range variants {
+		inputs = append(inputs, c.snippet(serviceID, serviceVersion.Number, strings.ToLower(v.label), c.location, c.priority, variantVCL(c.name, v.label, v.content)))
+	}
+
+	// If a snippet can't be created, those already created are deleted, so
+	// that the version isn't left with part of the test.
+	undoStack := undo.NewStack()
+	defer func() {
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Test":            c.name,
+			})
+		}
+		undoStack.RunIfError(out, err)
+	}()
+	for _, input := range inputs {
+		input := input
+		if _, err = c.Globals.APIClient.CreateSnippet(input); err != nil {
+			return fmt.Errorf("error creating snippet '%s': %w", input.Name, err)
+		}
+		undoStack.Push(func() error {
+			return c.Globals.APIClient.DeleteSnippet(&fastly.DeleteSnippetInput{
+				Name:           input.Name,
+				ServiceID:      input.ServiceID,
+				ServiceVersion: input.ServiceVersion,
+			})
+		})
+	}
+
+	text.Success(out, "Created A/B test '%s' (service: %s, version: %d, split: %d/%d)", c.name, serviceID, serviceVersion.Number, c.split, 100-c.split)
+	text.Break(out)
+	text.Output(out, "Each request's variant is in the %s request header. To report on the test with 'fastly ab-test report', log it from a JSON logging format, e.g.", Header(c.name))
+	text.Break(out)
+	text.Indent(out, 4, `"%s":"%%{req.http.%s}V"`, Cookie(c.name), Header(c.name))
+	return nil
+}
+
+// snippet returns the input to create one of the test's snippets.
+func (c *CreateCommand) snippet(serviceID string, serviceVersion int, suffix, location string, priority int, content string) *fastly.CreateSnippetInput {
+	return &fastly.CreateSnippetInput{
+		Content:        content,
+		Name:           fmt.Sprintf("%s_%s", Cookie(c.name), suffix),
+		Priority:       fastly.Int(priority),
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Type:           fastly.SnippetType(location),
+	}
+}
+
+// bucketVCL returns the vcl_recv snippet that assigns a request to a variant,
+// either the variant in the client's cookie, or, for a new client, a random
+// variant. The header is only set once, so a restart keeps the variant.
+func bucketVCL(name string, split int) string {
+	header, cookie := Header(name), Cookie(name)
+	return fmt.Sprintf(`if (!req.http.%[1]s) {
+  if (req.http.Cookie:%[2]s == "A" || req.http.Cookie:%[2]s == "B") {
+    set req.http.%[1]s = req.http.Cookie:%[2]s;
+  } else if (randombool(%[3]d, 100)) {
+    set req.http.%[1]s = "A";
+  } else {
+    set req.http.%[1]s = "B";
+  }
+}
+`, header, cookie, split)
+}
+
+// hashVCL returns the vcl_hash snippet that caches each variant's responses
+// separately, as the variants may change them.
+func hashVCL(name string) string {
+	return fmt.Sprintf("set req.hash += req.http.%s;\n", Header(name))
+}
+
+// cookieVCL returns the vcl_deliver snippet that sets the cookie keeping a
+// client in its variant, unless the client already has it.
+func cookieVCL(name string) string {
+	header, cookie := Header(name), Cookie(name)
+	return fmt.Sprintf(`if (req.http.Cookie:%[2]s != req.http.%[1]s) {
+  add resp.http.Set-Cookie = "%[2]s=" req.http.%[1]s "; Path=/; Max-Age=%[3]d";
+}
+`, header, cookie, cookieMaxAge)
+}
+
+// variantVCL returns a variant's snippet, which only runs for requests
+// assigned to the variant.
+func variantVCL(name, label, content string) string {
+	return fmt.Sprintf("if (req.http.%s == \"%s\") {\n%s\n}\n", Header(name), label, content)
+}
@@ -0,0 +1,3 @@
+// Package abtest contains commands to run A/B tests of VCL snippets on a
+// Fastly service, and to report on their results.
+package abtest
@@ -0,0 +1,201 @@
+package abtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// VariantStats are the stats of the requests assigned to one variant of a
+// test.
+type VariantStats struct {
+	Variant   string `json:"variant"`
+	Requests  uint64 `json:"requests"`
+	Status2xx uint64 `json:"status_2xx"`
+	Status3xx uint64 `json:"status_3xx"`
+	Status4xx uint64 `json:"status_4xx"`
+	Status5xx uint64 `json:"status_5xx"`
+}
+
+// ErrorRatio returns the share of the variant's requests that errored.
+func (s VariantStats) ErrorRatio() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Status5xx) / float64(s.Requests)
+}
+
+// ReportCommand reports on an A/B test from the logs of its requests.
+type ReportCommand struct {
+	cmd.Base
+
+	field       string
+	file        string
+	json        bool
+	name        string
+	statusField string
+}
+
+// NewReportCommand returns a usable command registered under the parent.
+func NewReportCommand(parent cmd.Registerer, globals *config.Data) *ReportCommand {
+	var c ReportCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("report", "Report on an A/B test from JSON logs of its requests, split by the logged variant")
+
+	// Required flags
+	c.CmdClause.Flag("file", "Path of the logs, with one JSON object per line, or - for stdin").Required().StringVar(&c.file)
+	c.CmdClause.Flag("name", "Name of the test").Required().StringVar(&c.name)
+
+	// Optional flags
+	c.CmdClause.Flag("field", "Field of the logs holding the variant (default: ab_<name>)").StringVar(&c.field)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("status-field", "Field of the logs holding the response status").Default("status").StringVar(&c.statusField)
+
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ReportCommand) Exec(in io.Reader, out io.Writer) error {
+	field := c.field
+	if field == "" {
+		field = Cookie(c.name)
+	}
+
+	r := in
+	if c.file != cmd.StdinFlagValue {
+		path, err := filepath.Abs(c.file)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path) // #nosec G304 (CWE-22)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error opening logs: %w", err)
+		}
+		defer f.Close() // #nosec G307
+		r = f
+	}
+
+	stats, skipped, err := Report(r, field, c.statusField)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"File": c.file,
+		})
+		return fmt.Errorf("error reading logs: %w", err)
+	}
+	if len(stats) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no requests in the logs have a variant of test '%s'", c.name),
+			Remediation: fmt.Sprintf("Log the %s request header in the '%s' field of a JSON logging format, or use --field to give the field it's logged in.", Header(c.name), field),
+		}
+	}
+
+	if c.json {
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	var total uint64
+	for _, s := range stats {
+		total += s.Requests
+	}
+	t := text.NewTable(out)
+	t.AddHeader("VARIANT", "REQUESTS", "SHARE", "2XX", "3XX", "4XX", "5XX", "ERROR RATIO")
+	for _, s := range stats {
+		t.AddLine(s.Variant, s.Requests, percent(float64(s.Requests)/float64(total)), s.Status2xx, s.Status3xx, s.Status4xx, s.Status5xx, percent(s.ErrorRatio()))
+	}
+	t.Print()
+	if skipped > 0 {
+		text.Break(out)
+		text.Info(out, "%d lines without a variant were skipped.", skipped)
+	}
+	return nil
+}
+
+// Report returns the stats of each variant in the logs, ordered by variant,
+// and the number of lines skipped as they aren't JSON or have no variant. A
+// line's variant is given by its field, and its response status by its
+// statusField, which may be a number or a string.
+func Report(r io.Reader, field, statusField string) ([]VariantStats, int, error) {
+	byVariant := make(map[string]*VariantStats)
+	var skipped int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			skipped++
+			continue
+		}
+		variant, ok := line[field].(string)
+		if !ok || variant == "" || variant == "(null)" {
+			skipped++
+			continue
+		}
+		s, ok := byVariant[variant]
+		if !ok {
+			s = &VariantStats{Variant: variant}
+			byVariant[variant] = s
+		}
+		s.Requests++
+		switch status(line[statusField]) / 100 {
+		case 2:
+			s.Status2xx++
+		case 3:
+			s.Status3xx++
+		case 4:
+			s.Status4xx++
+		case 5:
+			s.Status5xx++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	stats := make([]VariantStats, 0, len(byVariant))
+	for _, s := range byVariant {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Variant < stats[j].Variant
+	})
+	return stats, skipped, nil
+}
+
+// status returns the response status of a logged value, or zero if it isn't
+// one.
+func status(v interface{}) int {
+	switch v := v.(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+func percent(r float64) string {
+	return fmt.Sprintf("%.1f%%", r*100)
+}
@@ -0,0 +1,209 @@
+package account_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestAccountDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		status int
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate GetCurrentUser API error",
+				Args: args("account describe --token 123"),
+				API: mock.API{
+					GetCurrentUserFn: func() (*fastly.User, error) {
+						return nil, testutil.Err
+					},
+				},
+				WantError: testutil.Err.Error(),
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate customer API error",
+				Args:      args("account describe --customer-id abc --token 123"),
+				WantError: "error from API: 403 Forbidden",
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate ListCustomerUsers API error",
+				Args: args("account describe --customer-id abc --token 123"),
+				API: mock.API{
+					ListCustomerUsersFn: func(i *fastly.ListCustomerUsersInput) ([]*fastly.User, error) {
+						return nil, testutil.Err
+					},
+				},
+				WantError: testutil.Err.Error(),
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate settings of the current user's account",
+				Args: args("account describe --token 123"),
+				API: mock.API{
+					GetCurrentUserFn: func() (*fastly.User, error) {
+						return &fastly.User{CustomerID: "abc"}, nil
+					},
+					ListCustomerUsersFn: listCustomerUsers,
+				},
+				WantOutput: describeOutput,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate JSON output",
+				Args: args("account describe --customer-id abc --json --token 123"),
+				API: mock.API{
+					ListCustomerUsersFn: listCustomerUsers,
+				},
+				WantOutput: `{"id":"abc","name":"Computer Company","owner_id":"u1","pricing_plan":"Enterprise","force_sso":true,"force_2fa":false,"ip_whitelist":"192.0.2.0/24,198.51.100.7/32","users_by_role":{"engineer":1,"superuser":2}}`,
+			},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			status := testcase.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			opts.HTTPClient = mock.HTMLClient(&http.Response{
+				Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(customerResponse)),
+			}, nil)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestAccountLimits(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListServicesFn: func(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+			return []*fastly.Service{{Type: "vcl"}, {Type: "vcl"}, {Type: "wasm"}}, nil
+		},
+		ListWAFsFn: func(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+			return nil, &fastly.HTTPError{StatusCode: http.StatusForbidden}
+		},
+		ListTLSSubscriptionsFn: func(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error) {
+			return []*fastly.TLSSubscription{{ID: "s1"}, {ID: "s2"}}, nil
+		},
+	}
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListServices API error",
+			Args: args("account limits --customer-id abc --token 123"),
+			API: mock.API{
+				ListServicesFn: func(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+					return nil, testutil.Err
+				},
+			},
+			WantError: "error listing services: " + testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListTLSSubscriptions API error",
+			Args: args("account limits --customer-id abc --token 123"),
+			API: mock.API{
+				ListServicesFn: api.ListServicesFn,
+				ListWAFsFn:     api.ListWAFsFn,
+				ListTLSSubscriptionsFn: func(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error) {
+					return nil, testutil.Err
+				},
+			},
+			WantError: "error listing TLS subscriptions: " + testutil.Err.Error(),
+		},
+		{
+			Name: "validate entitlements are reported",
+			Args: args("account limits --customer-id abc --token 123"),
+			API:  api,
+			WantOutputs: []string{
+				"Pricing plan: Enterprise",
+				"services           enabled   3      2 VCL and 1 Compute services",
+				"compute            enabled   1",
+				"custom-vcl         enabled   -",
+				"waf                disabled  -      'fastly waf' commands will fail",
+				"ngwaf              unknown   -",
+				"tls-subscriptions  enabled   2",
+			},
+		},
+		{
+			Name:       "validate JSON output",
+			Args:       args("account limits --customer-id abc --json --token 123"),
+			API:        api,
+			WantOutput: `{"name":"tls-subscriptions","status":"enabled","usage":2}`,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			opts.HTTPClient = mock.HTMLClient(&http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(customerResponse)),
+			}, nil)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+var customerResponse = `{
+	"id": "abc",
+	"name": "Computer Company",
+	"owner_id": "u1",
+	"pricing_plan": "Enterprise",
+	"force_sso": true,
+	"force_2fa": false,
+	"ip_whitelist": "192.0.2.0/24,198.51.100.7/32",
+	"can_upload_vcl": true
+}`
+
+func listCustomerUsers(i *fastly.ListCustomerUsersInput) ([]*fastly.User, error) {
+	return []*fastly.User{
+		{ID: "u1", Role: "superuser"},
+		{ID: "u2", Role: "superuser"},
+		{ID: "u3", Role: "engineer"},
+	}, nil
+}
+
+var describeOutput = strings.TrimSpace(`
+ID: abc
+Name: Computer Company
+Owner ID: u1
+Pricing plan: Enterprise
+SSO enforced: true
+2FA enforced: false
+Web interface IP allowlist: 192.0.2.0/24, 198.51.100.7/32
+Users by role:
+	engineer: 1
+	superuser: 2
+`) + "\n"
@@ -0,0 +1,175 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/useragent"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDescribeCommand returns a usable command registered under the parent.
+func NewDescribeCommand(parent cmd.Registerer, globals *config.Data) *DescribeCommand {
+	var c DescribeCommand
+	c.CmdClause = parent.Command("describe", "Show the security settings of an account, such as SSO and 2FA enforcement").Alias("get")
+	c.Globals = globals
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagCustomerIDName,
+		Description: cmd.FlagCustomerIDDesc,
+		Dst:         &c.customerID.Value,
+		Action:      c.customerID.Set,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// DescribeCommand calls the Fastly API to describe the settings of an account.
+type DescribeCommand struct {
+	cmd.Base
+
+	customerID cmd.OptionalCustomerID
+	json       bool
+}
+
+// Customer models the settings of a customer returned by the Fastly API.
+//
+// NOTE: go-fastly doesn't support the customer endpoints, so the response is
+// decoded here. Only the settings that are relevant to a security review are
+// included.
+type Customer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	OwnerID     string `json:"owner_id"`
+	PricingPlan string `json:"pricing_plan"`
+	ForceSSO    bool   `json:"force_sso"`
+	Force2FA    bool   `json:"force_2fa"`
+	// IPAllowlist is the comma separated IP ranges allowed to access the web
+	// interface.
+	IPAllowlist string `json:"ip_whitelist"`
+}
+
+// Settings describes the settings of an account.
+//
+// NOTE: The API has no default role for new users, so the roles of the
+// existing users are counted instead.
+type Settings struct {
+	Customer
+	UsersByRole map[string]int `json:"users_by_role"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
+	token, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	customerID, err := cmd.CustomerID(&c.customerID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	var cust Customer
+	err = getCustomer(c.Globals, token, customerID, &cust)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return err
+	}
+
+	users, err := c.Globals.APIClient.ListCustomerUsers(&fastly.ListCustomerUsersInput{CustomerID: customerID})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return err
+	}
+	settings := Settings{Customer: cust, UsersByRole: make(map[string]int)}
+	for _, u := range users {
+		settings.UsersByRole[u.Role]++
+	}
+
+	if c.json {
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	c.print(out, settings)
+	return nil
+}
+
+// getCustomer fetches the customer from the API, decoding the response into
+// v.
+func getCustomer(globals *config.Data, token, customerID string, v interface{}) error {
+	endpoint, _ := globals.Endpoint()
+	fullurl := fmt.Sprintf("%s/customer/%s", strings.TrimSuffix(endpoint, "/"), customerID)
+	req, err := http.NewRequest("GET", fullurl, nil)
+	if err != nil {
+		return fmt.Errorf("error constructing API request: %w", err)
+	}
+
+	req.Header.Set("Fastly-Key", token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", useragent.Name)
+	resp, err := globals.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error from API: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding API response: %w", err)
+	}
+	return nil
+}
+
+// print displays the information returned from the API.
+func (c *DescribeCommand) print(out io.Writer, s Settings) {
+	fmt.Fprintf(out, "ID: %s\n", s.ID)
+	fmt.Fprintf(out, "Name: %s\n", s.Name)
+	fmt.Fprintf(out, "Owner ID: %s\n", s.OwnerID)
+	fmt.Fprintf(out, "Pricing plan: %s\n", s.PricingPlan)
+	fmt.Fprintf(out, "SSO enforced: %t\n", s.ForceSSO)
+	fmt.Fprintf(out, "2FA enforced: %t\n", s.Force2FA)
+
+	allowlist := "any (no IP allowlist)"
+	if s.IPAllowlist != "" {
+		allowlist = strings.Join(strings.Split(s.IPAllowlist, ","), ", ")
+	}
+	fmt.Fprintf(out, "Web interface IP allowlist: %s\n", allowlist)
+
+	roles := make([]string, 0, len(s.UsersByRole))
+	for r := range s.UsersByRole {
+		roles = append(roles, r)
+	}
+	sort.Strings(roles)
+	fmt.Fprintf(out, "Users by role:\n")
+	for _, r := range roles {
+		fmt.Fprintf(out, "\t%s: %d\n", r, s.UsersByRole[r])
+	}
+	if len(roles) == 0 {
+		fmt.Fprintf(out, "\tnone\n")
+	}
+}
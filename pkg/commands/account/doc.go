@@ -0,0 +1,3 @@
+// Package account contains commands to inspect the settings of a Fastly
+// account.
+package account
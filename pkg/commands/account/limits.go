@@ -0,0 +1,265 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// The statuses of an entitlement.
+const (
+	StatusEnabled  = "enabled"
+	StatusDisabled = "disabled"
+	StatusUnknown  = "unknown"
+)
+
+// tlsSubscriptionsPageSize is the number of TLS subscriptions requested per
+// page when counting them.
+const tlsSubscriptionsPageSize = 100
+
+// NewLimitsCommand returns a usable command registered under the parent.
+func NewLimitsCommand(parent cmd.Registerer, globals *config.Data) *LimitsCommand {
+	var c LimitsCommand
+	c.CmdClause = parent.Command("limits", "Show the plan entitlements of an account that affect what the CLI can do, such as Compute, custom VCL, WAF and TLS subscriptions")
+	c.Globals = globals
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagCustomerIDName,
+		Description: cmd.FlagCustomerIDDesc,
+		Dst:         &c.customerID.Value,
+		Action:      c.customerID.Set,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// LimitsCommand calls the Fastly API to describe the entitlements of an
+// account.
+type LimitsCommand struct {
+	cmd.Base
+
+	customerID cmd.OptionalCustomerID
+	json       bool
+}
+
+// Plan models the settings of a customer that reflect its plan.
+//
+// NOTE: The API doesn't describe a plan's entitlements directly, so most of
+// them are inferred from what the account can access.
+type Plan struct {
+	ID           string `json:"id"`
+	PricingPlan  string `json:"pricing_plan"`
+	CanUploadVCL bool   `json:"can_upload_vcl"`
+}
+
+// Entitlement describes whether an account has access to a feature, and how
+// much of it is used.
+type Entitlement struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Usage is the number of resources of the feature, if they're counted.
+	Usage  *int   `json:"usage,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Limits describes the entitlements of an account.
+type Limits struct {
+	CustomerID   string        `json:"customer_id"`
+	PricingPlan  string        `json:"pricing_plan"`
+	Entitlements []Entitlement `json:"entitlements"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *LimitsCommand) Exec(in io.Reader, out io.Writer) error {
+	token, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	customerID, err := cmd.CustomerID(&c.customerID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	var plan Plan
+	err = getCustomer(c.Globals, token, customerID, &plan)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return err
+	}
+
+	services, err := c.Globals.APIClient.ListServices(&fastly.ListServicesInput{})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error listing services: %w", err)
+	}
+
+	limits := Limits{CustomerID: customerID, PricingPlan: plan.PricingPlan}
+	limits.Entitlements = append(limits.Entitlements, servicesEntitlements(services)...)
+	limits.Entitlements = append(limits.Entitlements, vclEntitlement(plan))
+
+	waf, err := c.wafEntitlement()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	limits.Entitlements = append(limits.Entitlements, waf, Entitlement{
+		Name:   "ngwaf",
+		Status: StatusUnknown,
+		Detail: "Next-Gen WAF is provisioned through the Signal Sciences console, not the Fastly API",
+	})
+
+	tls, err := c.tlsEntitlement()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	limits.Entitlements = append(limits.Entitlements, tls)
+
+	if c.json {
+		data, err := json.Marshal(limits)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	c.print(out, limits)
+	return nil
+}
+
+// servicesEntitlements describes the services of the account, and whether
+// Compute@Edge is enabled.
+//
+// NOTE: The API doesn't report whether Compute@Edge is enabled, so it's only
+// known to be if there are Compute services.
+func servicesEntitlements(services []*fastly.Service) []Entitlement {
+	var vcl, wasm int
+	for _, s := range services {
+		if s.Type == "wasm" {
+			wasm++
+		} else {
+			vcl++
+		}
+	}
+	total := len(services)
+
+	e := []Entitlement{{
+		Name:   "services",
+		Status: StatusEnabled,
+		Usage:  &total,
+		Detail: fmt.Sprintf("%d VCL and %d Compute services (Fastly support can raise the number of services an account can create)", vcl, wasm),
+	}}
+	if wasm > 0 {
+		return append(e, Entitlement{Name: "compute", Status: StatusEnabled, Usage: &wasm})
+	}
+	return append(e, Entitlement{
+		Name:   "compute",
+		Status: StatusUnknown,
+		Detail: "there are no Compute services ('fastly compute deploy' offers to start a free trial if Compute isn't enabled)",
+	})
+}
+
+// vclEntitlement describes whether custom VCL can be uploaded.
+func vclEntitlement(plan Plan) Entitlement {
+	if plan.CanUploadVCL {
+		return Entitlement{Name: "custom-vcl", Status: StatusEnabled}
+	}
+	return Entitlement{
+		Name:   "custom-vcl",
+		Status: StatusDisabled,
+		Detail: "'fastly vcl custom create' will fail, but VCL snippets can be used (contact Fastly support to enable custom VCL)",
+	}
+}
+
+// wafEntitlement describes whether the account has the (legacy) Fastly WAF,
+// which is inferred from whether its firewalls can be listed.
+func (c *LimitsCommand) wafEntitlement() (Entitlement, error) {
+	resp, err := c.Globals.APIClient.ListWAFs(&fastly.ListWAFsInput{PageSize: 1})
+	if denied(err) {
+		return Entitlement{
+			Name:   "waf",
+			Status: StatusDisabled,
+			Detail: "'fastly waf' commands will fail as the account has no Fastly WAF subscription",
+		}, nil
+	}
+	if err != nil {
+		return Entitlement{}, fmt.Errorf("error listing WAFs: %w", err)
+	}
+	n := len(resp.Items)
+	if resp.Info.Meta.RecordCount > n {
+		n = resp.Info.Meta.RecordCount
+	}
+	return Entitlement{Name: "waf", Status: StatusEnabled, Usage: &n}, nil
+}
+
+// tlsEntitlement describes whether the account has Fastly TLS, and counts its
+// certificate subscriptions.
+func (c *LimitsCommand) tlsEntitlement() (Entitlement, error) {
+	var n int
+	for page := 1; ; page++ {
+		subs, err := c.Globals.APIClient.ListTLSSubscriptions(&fastly.ListTLSSubscriptionsInput{
+			PageNumber: page,
+			PageSize:   tlsSubscriptionsPageSize,
+		})
+		if denied(err) {
+			return Entitlement{
+				Name:   "tls-subscriptions",
+				Status: StatusDisabled,
+				Detail: "Fastly TLS certificates can't be requested (contact Fastly support to enable TLS subscriptions)",
+			}, nil
+		}
+		if err != nil {
+			return Entitlement{}, fmt.Errorf("error listing TLS subscriptions: %w", err)
+		}
+		n += len(subs)
+		if len(subs) < tlsSubscriptionsPageSize {
+			break
+		}
+	}
+	return Entitlement{Name: "tls-subscriptions", Status: StatusEnabled, Usage: &n}, nil
+}
+
+// denied indicates whether err is the API refusing access to a feature the
+// account isn't entitled to.
+func denied(err error) bool {
+	var httpErr *fastly.HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	return httpErr.StatusCode == http.StatusForbidden || httpErr.StatusCode == http.StatusNotFound
+}
+
+// print displays the information returned from the API.
+func (c *LimitsCommand) print(out io.Writer, l Limits) {
+	fmt.Fprintf(out, "Customer ID: %s\n", l.CustomerID)
+	fmt.Fprintf(out, "Pricing plan: %s\n", l.PricingPlan)
+	text.Break(out)
+
+	t := text.NewTable(out)
+	t.AddHeader("ENTITLEMENT", "STATUS", "USAGE", "DETAIL")
+	for _, e := range l.Entitlements {
+		usage := "-"
+		if e.Usage != nil {
+			usage = fmt.Sprintf("%d", *e.Usage)
+		}
+		t.AddLine(e.Name, e.Status, usage, e.Detail)
+	}
+	t.Print()
+}
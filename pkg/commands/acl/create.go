@@ -64,6 +64,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		ErrLog:             c.Globals.ErrLog,
 		Manifest:           c.manifest,
 		Out:                out,
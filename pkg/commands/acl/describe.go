@@ -64,13 +64,10 @@ type DescribeCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -132,13 +129,13 @@ func (c *DescribeCommand) print(out io.Writer, a *fastly.ACL) error {
 	fmt.Fprintf(out, "Name: %s\n", a.Name)
 	fmt.Fprintf(out, "ID: %s\n\n", a.ID)
 	if a.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", a.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*a.CreatedAt))
 	}
 	if a.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", a.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*a.UpdatedAt))
 	}
 	if a.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", a.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*a.DeletedAt))
 	}
 	return nil
 }
@@ -63,13 +63,10 @@ type ListCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -126,13 +123,13 @@ func (c *ListCommand) printVerbose(out io.Writer, serviceVersion int, as []*fast
 		fmt.Fprintf(out, "ID: %s\n\n", a.ID)
 
 		if a.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", a.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*a.CreatedAt))
 		}
 		if a.UpdatedAt != nil {
-			fmt.Fprintf(out, "Updated at: %s\n", a.UpdatedAt)
+			fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*a.UpdatedAt))
 		}
 		if a.DeletedAt != nil {
-			fmt.Fprintf(out, "Deleted at: %s\n", a.DeletedAt)
+			fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*a.DeletedAt))
 		}
 
 		fmt.Fprintf(out, "\n")
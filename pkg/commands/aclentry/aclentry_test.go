@@ -2,9 +2,15 @@ package aclentry_test
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/commands/aclentry"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -171,6 +177,48 @@ func TestACLEntryDescribe(t *testing.T) {
 			Args:       args("acl-entry describe --acl-id 123 --id 456 --service-id 123"),
 			WantOutput: "\nService ID: 123\nACL ID: 123\nID: 456\nIP: 127.0.0.1\nSubnet: 0\nNegated: false\nComment: \n\nCreated at: 2021-06-15 23:00:00 +0000 UTC\nUpdated at: 2021-06-15 23:00:00 +0000 UTC\nDeleted at: 2021-06-15 23:00:00 +0000 UTC\n",
 		},
+		{
+			Name: "validate --acl-id accepts the ACL's name",
+			API: mock.API{
+				GetACLEntryFn:  getACLEntryByID("123"),
+				ListVersionsFn: testutil.ListVersions,
+				ListACLsFn:     listACLs,
+			},
+			Args:       args("acl-entry describe --acl-id blocklist --id 456 --service-id 123"),
+			WantOutput: "\nService ID: 123\nACL ID: 123\nID: 456\n",
+		},
+		{
+			Name: "validate --acl-id with an ambiguous name",
+			API: mock.API{
+				GetACLEntryFn:  getACLEntryByID("123"),
+				ListVersionsFn: testutil.ListVersions,
+				ListACLsFn:     listACLs,
+			},
+			Args:      args("acl-entry describe --acl-id shared --id 456 --service-id 123"),
+			WantError: "the name 'shared' matches 2 ACLs (IDs: 456, 789)",
+		},
+		{
+			Name: "validate --acl-id with an unknown ACL",
+			API: mock.API{
+				GetACLEntryFn:  getACLEntryByID("123"),
+				ListVersionsFn: testutil.ListVersions,
+				ListACLsFn:     listACLs,
+			},
+			Args:      args("acl-entry describe --acl-id nope --id 456 --service-id 123"),
+			WantError: "no ACL found with the ID or name 'nope'",
+		},
+		{
+			Name: "validate a missing entry of a valid ACL",
+			API: mock.API{
+				GetACLEntryFn: func(i *fastly.GetACLEntryInput) (*fastly.ACLEntry, error) {
+					return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+				},
+				ListVersionsFn: testutil.ListVersions,
+				ListACLsFn:     listACLs,
+			},
+			Args:      args("acl-entry describe --acl-id 123 --id 456 --service-id 123"),
+			WantError: "404 - Not Found",
+		},
 	}
 
 	for _, testcase := range scenarios {
@@ -185,34 +233,109 @@ func TestACLEntryDescribe(t *testing.T) {
 	}
 }
 
-// TODO: Use generics support in go 1.18 to remove the need for multiple types.
-//
-// e.g. replace mockACLPaginator, mockDictionaryItemPaginator, mockServicesPaginator
+func TestACLEntryExpires(t *testing.T) {
+	var comment string
+	api := mock.API{
+		CreateACLEntryFn: func(i *fastly.CreateACLEntryInput) (*fastly.ACLEntry, error) {
+			comment = i.Comment
+			return &fastly.ACLEntry{ACLID: i.ACLID, ID: "456", IP: i.IP, ServiceID: i.ServiceID, Comment: i.Comment}, nil
+		},
+	}
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("acl-entry create --acl-id 123 --ip 127.0.0.1 --service-id 123 --comment blocked --expires 72h"), &stdout)
+	opts.APIClient = mock.APIClient(api)
+	opts.Clock = clock.Fixed(testutil.Date)
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "blocked [expires=2021-06-18T23:00:00Z]", comment)
+	testutil.AssertStringContains(t, stdout.String(), "The entry expires at 2021-06-18 23:00 UTC")
 
-type mockACLPaginator struct {
-	count         int
-	maxPages      int
-	numOfPages    int
-	requestedPage int
-	returnErr     bool
+	expiry, ok := aclentry.Expiry(comment)
+	testutil.AssertBool(t, true, ok)
+	testutil.AssertEqual(t, testutil.Date.Add(72*time.Hour), expiry)
+	testutil.AssertString(t, "blocked [expires=2021-06-19T23:00:00Z]", aclentry.WithExpiry(comment, expiry.Add(24*time.Hour)))
 }
 
-func (p *mockACLPaginator) HasNext() bool {
-	if p.count > p.maxPages {
-		return false
+func TestACLEntryExpireRun(t *testing.T) {
+	args := testutil.Args
+	entries := []*fastly.ACLEntry{
+		{ID: "1", IP: "192.0.2.1", Comment: "[expires=2021-06-15T22:00:00Z]"},
+		{ID: "2", IP: "192.0.2.2", Comment: "blocked [expires=2021-06-16T23:00:00Z]"},
+		{ID: "3", IP: "192.0.2.3", Comment: "office"},
+		{ID: "4", IP: "192.0.2.4", Comment: "scanner [expires=2021-06-01T00:00:00Z]"},
+	}
+	paginator := func(*fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
+		return &entriesPaginator{pages: [][]*fastly.ACLEntry{entries[:2], entries[2:]}}
+	}
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --acl-id flag",
+			Args:      args("acl-entry expire-run --service-id 123"),
+			WantError: "error parsing arguments: required flag --acl-id not provided",
+		},
+		{
+			Name: "validate expired entries are deleted",
+			API: mock.API{
+				NewListACLEntriesPaginatorFn: paginator,
+				DeleteACLEntryFn: func(i *fastly.DeleteACLEntryInput) error {
+					if i.ID != "1" && i.ID != "4" {
+						t.Errorf("unexpected deletion of ACL entry '%s'", i.ID)
+					}
+					return nil
+				},
+			},
+			Args:       args("acl-entry expire-run --acl-id 123 --service-id 123"),
+			WantOutput: "Deleted 2 expired ACL entries (1 entries yet to expire, service: 123)",
+		},
+		{
+			Name: "validate DeleteACLEntry API error",
+			API: mock.API{
+				NewListACLEntriesPaginatorFn: paginator,
+				DeleteACLEntryFn: func(i *fastly.DeleteACLEntryInput) error {
+					if i.ID == "1" {
+						return testutil.Err
+					}
+					return nil
+				},
+			},
+			Args:       args("acl-entry expire-run --acl-id 123 --service-id 123"),
+			WantError:  "failed to delete 1 of 2 expired ACL entries",
+			WantOutput: "Unable to delete ACL entry '1' (ip: 192.0.2.1)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			opts.Clock = clock.Fixed(testutil.Date)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
 	}
-	p.count++
-	return true
 }
 
-func (p mockACLPaginator) Remaining() int {
-	return 1
+type entriesPaginator struct {
+	pages [][]*fastly.ACLEntry
 }
 
-func (p *mockACLPaginator) GetNext() (as []*fastly.ACLEntry, err error) {
-	if p.returnErr {
-		err = testutil.Err
-	}
+func (p *entriesPaginator) HasNext() bool {
+	return len(p.pages) > 0
+}
+
+func (p *entriesPaginator) Remaining() int {
+	return len(p.pages)
+}
+
+func (p *entriesPaginator) GetNext() ([]*fastly.ACLEntry, error) {
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return page, nil
+}
+
+func listACLEntriesPaginator(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
 	t := testutil.Date
 	pageOne := fastly.ACLEntry{
 		ACLID:     "123",
@@ -235,16 +358,7 @@ func (p *mockACLPaginator) GetNext() (as []*fastly.ACLEntry, err error) {
 		ServiceID: "123",
 		UpdatedAt: &t,
 	}
-	if p.count == 1 {
-		as = append(as, &pageOne)
-	}
-	if p.count == 2 {
-		as = append(as, &pageTwo)
-	}
-	if p.requestedPage > 0 && p.numOfPages == 1 {
-		p.count = p.maxPages + 1 // forces only one result to be displayed
-	}
-	return as, err
+	return testutil.NewPaginator(i.Page, []*fastly.ACLEntry{&pageOne}, []*fastly.ACLEntry{&pageTwo})
 }
 
 func TestACLEntryList(t *testing.T) {
@@ -264,7 +378,9 @@ func TestACLEntryList(t *testing.T) {
 			Name: "validate ListACLEntries API error (via GetNext() call)",
 			API: mock.API{
 				NewListACLEntriesPaginatorFn: func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
-					return &mockACLPaginator{returnErr: true}
+					p := testutil.NewPaginator[*fastly.ACLEntry](i.Page)
+					p.Err = testutil.Err
+					return p
 				},
 			},
 			Args:      args("acl-entry list --acl-id 123 --service-id 123"),
@@ -275,9 +391,7 @@ func TestACLEntryList(t *testing.T) {
 		{
 			Name: "validate ListACLEntries API success",
 			API: mock.API{
-				NewListACLEntriesPaginatorFn: func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
-					return &mockACLPaginator{numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListACLEntriesPaginatorFn: listACLEntriesPaginator,
 			},
 			Args:       args("acl-entry list --acl-id 123 --per-page 1 --service-id 123"),
 			WantOutput: listACLEntriesOutput,
@@ -287,9 +401,7 @@ func TestACLEntryList(t *testing.T) {
 		{
 			Name: "validate all results displayed even when page is set",
 			API: mock.API{
-				NewListACLEntriesPaginatorFn: func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
-					return &mockACLPaginator{count: i.Page - 1, requestedPage: i.Page, numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListACLEntriesPaginatorFn: listACLEntriesPaginator,
 			},
 			Args:       args("acl-entry list --acl-id 123 --page 1 --per-page 1 --service-id 123"),
 			WantOutput: listACLEntriesOutputPageOne,
@@ -299,9 +411,7 @@ func TestACLEntryList(t *testing.T) {
 		{
 			Name: "validate only page two of the results are displayed",
 			API: mock.API{
-				NewListACLEntriesPaginatorFn: func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
-					return &mockACLPaginator{count: i.Page - 1, requestedPage: i.Page, numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListACLEntriesPaginatorFn: listACLEntriesPaginator,
 			},
 			Args:       args("acl-entry list --acl-id 123 --page 2 --per-page 1 --service-id 123"),
 			WantOutput: listACLEntriesOutputPageTwo,
@@ -309,9 +419,7 @@ func TestACLEntryList(t *testing.T) {
 		{
 			Name: "validate --verbose flag",
 			API: mock.API{
-				NewListACLEntriesPaginatorFn: func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
-					return &mockACLPaginator{numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListACLEntriesPaginatorFn: listACLEntriesPaginator,
 			},
 			Args:       args("acl-entry list --acl-id 123 --per-page 1 --service-id 123 --verbose"),
 			WantOutput: listACLEntriesOutputVerbose,
@@ -439,8 +547,49 @@ func TestACLEntryUpdate(t *testing.T) {
 					return nil
 				},
 			},
-			Args:       args(`acl-entry update --acl-id 123 --file {"entries":[{"op":"create","ip":"127.0.0.1","subnet":8},{"op":"update"},{"op":"upsert"}]} --id 456 --service-id 123`),
-			WantOutput: "Updated 3 ACL entries (service: 123)",
+			Args:       args(`acl-entry update --acl-id 123 --file {"entries":[{"op":"create","ip":"127.0.0.1","subnet":8},{"op":"update","id":"abc","negated":"1"},{"op":"delete","id":"def"}]} --id 456 --service-id 123`),
+			WantOutput: "Updated 3 ACL entries (service: 123): 1 create, 1 update, 1 delete",
+		},
+		{
+			Name: "validate success with --batch-file",
+			API: mock.API{
+				BatchModifyACLEntriesFn: func(i *fastly.BatchModifyACLEntriesInput) error {
+					return nil
+				},
+			},
+			Args:       args("acl-entry update --acl-id 123 --batch-file testdata/batch.json --service-id 123"),
+			WantOutput: "Updated 3 ACL entries (service: 123): 1 create, 1 update, 1 delete",
+		},
+		{
+			Name:      "validate error from --batch-file with an unsupported operation",
+			Args:      args(`acl-entry update --acl-id 123 --batch-file {"entries":[{"op":"upsert","id":"abc"}]} --service-id 123`),
+			WantError: "invalid batch: entry 1: invalid 'op' 'upsert' (must be one of create, update, delete)",
+		},
+		{
+			Name:      "validate error from --batch-file with a missing id",
+			Args:      args(`acl-entry update --acl-id 123 --batch-file {"entries":[{"op":"create","ip":"127.0.0.1"},{"op":"delete"}]} --service-id 123`),
+			WantError: "invalid batch: entry 2: missing 'id' to delete",
+		},
+		{
+			Name:      "validate error from --batch-file with a missing ip",
+			Args:      args(`acl-entry update --acl-id 123 --batch-file {"entries":[{"op":"create","subnet":8}]} --service-id 123`),
+			WantError: "invalid batch: entry 1: missing 'ip' to create",
+		},
+		{
+			Name: "validate --batch-file operations are sent in chunks",
+			API: mock.API{
+				BatchModifyACLEntriesFn: func(i *fastly.BatchModifyACLEntriesInput) error {
+					if len(i.Entries) > fastly.BatchModifyMaximumOperations {
+						return testutil.Err
+					}
+					if *i.Entries[0].IP == "10.0.3.232" {
+						return testutil.Err
+					}
+					return nil
+				},
+			},
+			Args:      args("acl-entry update --acl-id 123 --batch-file " + largeACLBatch() + " --service-id 123"),
+			WantError: "applied 1000 of 1200 operations before the error: test error",
 		},
 	}
 
@@ -470,3 +619,31 @@ func getACLEntry(i *fastly.GetACLEntryInput) (*fastly.ACLEntry, error) {
 		UpdatedAt: &t,
 	}, nil
 }
+
+// getACLEntryByID returns a GetACLEntryFn for which only the ACL with the
+// given ID exists.
+func getACLEntryByID(aclID string) func(i *fastly.GetACLEntryInput) (*fastly.ACLEntry, error) {
+	return func(i *fastly.GetACLEntryInput) (*fastly.ACLEntry, error) {
+		if i.ACLID != aclID {
+			return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+		}
+		return getACLEntry(i)
+	}
+}
+
+func listACLs(i *fastly.ListACLsInput) ([]*fastly.ACL, error) {
+	return []*fastly.ACL{
+		{ID: "123", Name: "blocklist", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion},
+		{ID: "456", Name: "shared", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion},
+		{ID: "789", Name: "shared", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion},
+	}, nil
+}
+
+// largeACLBatch returns a batch of 1200 create operations, as inline JSON.
+func largeACLBatch() string {
+	entries := make([]string, 1200)
+	for i := range entries {
+		entries[i] = fmt.Sprintf(`{"op":"create","ip":"10.0.%d.%d"}`, i/256, i%256)
+	}
+	return `{"entries":[` + strings.Join(entries, ",") + `]}`
+}
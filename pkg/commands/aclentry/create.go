@@ -2,6 +2,7 @@ package aclentry
 
 import (
 	"io"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
@@ -18,11 +19,12 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.manifest = data
 
 	// Required flags
-	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL").Required().StringVar(&c.aclID)
-	c.CmdClause.Flag("ip", "An IP address").Required().StringVar(&c.ip)
+	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL"+cmd.IDOrNameDesc).Required().StringVar(&c.aclID)
+	c.CmdClause.Flag("ip", "An IP address").Required().Action(cmd.Validate(cmd.ValidateIP)).StringVar(&c.ip)
 
 	// Optional flags
 	c.CmdClause.Flag("comment", "A freeform descriptive note").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("expires", "Remove the entry with 'acl-entry expire-run' once this duration has passed (e.g. 72h), recorded in the entry's comment").Action(cmd.Validate(cmd.ValidateDuration)).DurationVar(&c.expires)
 	c.CmdClause.Flag("negated", "Whether to negate the match").Action(c.negated.Set).BoolVar(&c.negated.Value)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
@@ -36,7 +38,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("subnet", "Number of bits for the subnet mask applied to the IP address").Action(c.subnet.Set).IntVar(&c.subnet.Value)
+	c.CmdClause.Flag("subnet", "Number of bits for the subnet mask applied to the IP address").Action(c.subnet.Set).Action(cmd.Validate(cmd.ValidateRange(0, 128))).IntVar(&c.subnet.Value)
 
 	return &c
 }
@@ -47,6 +49,7 @@ type CreateCommand struct {
 
 	aclID       string
 	comment     cmd.OptionalString
+	expires     time.Duration
 	ip          string
 	manifest    manifest.Data
 	negated     cmd.OptionalBool
@@ -56,7 +59,7 @@ type CreateCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -66,7 +69,12 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 
 	input := c.constructInput(serviceID)
 
-	a, err := c.Globals.APIClient.CreateACLEntry(input)
+	var a *fastly.ACLEntry
+	err = cmd.ResolveOnNotFound(input.ACLID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) (err error) {
+		input.ACLID = id
+		a, err = c.Globals.APIClient.CreateACLEntry(input)
+		return err
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -75,6 +83,9 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	text.Success(out, "Created ACL entry '%s' (ip: %s, negated: %t, service: %s)", a.ID, a.IP, a.Negated, a.ServiceID)
+	if t, ok := Expiry(a.Comment); ok {
+		text.Info(out, "The entry expires at %s UTC. Run 'fastly acl-entry expire-run --acl-id %s' to remove expired entries.", c.Globals.Formatter().UTCTime(t), a.ACLID)
+	}
 	return nil
 }
 
@@ -89,6 +100,9 @@ func (c *CreateCommand) constructInput(serviceID string) *fastly.CreateACLEntryI
 	if c.comment.WasSet {
 		input.Comment = c.comment.Value
 	}
+	if c.expires > 0 {
+		input.Comment = WithExpiry(input.Comment, c.Globals.Clock.Now().Add(c.expires))
+	}
 	if c.negated.WasSet {
 		input.Negated = fastly.Compatibool(c.negated.Value)
 	}
@@ -18,7 +18,7 @@ func NewDeleteCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.manifest = data
 
 	// Required flags
-	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL").Required().StringVar(&c.aclID)
+	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL"+cmd.IDOrNameDesc).Required().StringVar(&c.aclID)
 	c.CmdClause.Flag("id", "Alphanumeric string identifying an ACL Entry").Required().StringVar(&c.id)
 
 	// Optional flags
@@ -50,7 +50,7 @@ type DeleteCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -60,7 +60,10 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 
 	input := c.constructInput(serviceID)
 
-	err = c.Globals.APIClient.DeleteACLEntry(input)
+	err = cmd.ResolveOnNotFound(input.ACLID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) error {
+		input.ACLID = id
+		return c.Globals.APIClient.DeleteACLEntry(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
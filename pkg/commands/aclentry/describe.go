@@ -7,7 +7,6 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
-	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
@@ -20,7 +19,7 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.manifest = data
 
 	// Required flags
-	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL").Required().StringVar(&c.aclID)
+	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL"+cmd.IDOrNameDesc).Required().StringVar(&c.aclID)
 	c.CmdClause.Flag("id", "Alphanumeric string identifying an ACL Entry").Required().StringVar(&c.id)
 
 	// Optional Flags
@@ -59,11 +58,7 @@ type DescribeCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -73,7 +68,12 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 
 	input := c.constructInput(serviceID)
 
-	a, err := c.Globals.APIClient.GetACLEntry(input)
+	var a *fastly.ACLEntry
+	err = cmd.ResolveOnNotFound(input.ACLID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) (err error) {
+		input.ACLID = id
+		a, err = c.Globals.APIClient.GetACLEntry(input)
+		return err
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -121,13 +121,13 @@ func (c *DescribeCommand) print(out io.Writer, a *fastly.ACLEntry) error {
 	fmt.Fprintf(out, "Comment: %s\n\n", a.Comment)
 
 	if a.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", a.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*a.CreatedAt))
 	}
 	if a.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", a.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*a.UpdatedAt))
 	}
 	if a.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", a.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*a.DeletedAt))
 	}
 	return nil
 }
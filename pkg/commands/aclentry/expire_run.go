@@ -0,0 +1,128 @@
+package aclentry
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewExpireRunCommand returns a usable command registered under the parent.
+func NewExpireRunCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *ExpireRunCommand {
+	var c ExpireRunCommand
+	c.CmdClause = parent.Command("expire-run", "Delete the entries of an ACL whose expiry (see 'acl-entry create --expires') has passed")
+	c.SetMutating()
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL"+cmd.IDOrNameDesc).Required().StringVar(&c.aclID)
+
+	// Optional flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// ExpireRunCommand deletes expired ACL entries.
+type ExpireRunCommand struct {
+	cmd.Base
+
+	aclID       string
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+}
+
+// Exec invokes the application logic for the command.
+func (c *ExpireRunCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	// The resolved ACL ID is kept for deleting the expired entries.
+	var as []*fastly.ACLEntry
+	err = cmd.ResolveOnNotFound(c.aclID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) error {
+		paginator := c.Globals.APIClient.NewListACLEntriesPaginator(&fastly.ListACLEntriesInput{
+			ACLID:     id,
+			ServiceID: serviceID,
+		})
+		as = nil
+		for paginator.HasNext() {
+			data, err := paginator.GetNext()
+			if err != nil {
+				c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+					"ACL ID":          id,
+					"Service ID":      serviceID,
+					"Remaining Pages": paginator.Remaining(),
+				})
+				return err
+			}
+			as = append(as, data...)
+		}
+		c.aclID = id
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	now := c.Globals.Clock.Now()
+	var deleted, failed, pending int
+	for _, a := range as {
+		expiry, ok := Expiry(a.Comment)
+		if !ok {
+			continue
+		}
+		if expiry.After(now) {
+			pending++
+			continue
+		}
+
+		err := c.Globals.APIClient.DeleteACLEntry(&fastly.DeleteACLEntryInput{
+			ACLID:     c.aclID,
+			ID:        a.ID,
+			ServiceID: serviceID,
+		})
+		if err != nil {
+			// Carry on, so that one failure doesn't leave the rest of the
+			// expired entries in place.
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"ACL ID":       c.aclID,
+				"ACL Entry ID": a.ID,
+				"Service ID":   serviceID,
+			})
+			text.Warning(out, "Unable to delete ACL entry '%s' (ip: %s): %s", a.ID, a.IP, err)
+			failed++
+			continue
+		}
+		deleted++
+		if c.Globals.Verbose() {
+			text.Output(out, "Deleted ACL entry '%s' (ip: %s, expired: %s UTC)", a.ID, a.IP, c.Globals.Formatter().UTCTime(expiry))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d expired ACL entries", failed, failed+deleted)
+	}
+	text.Success(out, "Deleted %d expired ACL entries (%d entries yet to expire, service: %s)", deleted, pending, serviceID)
+	return nil
+}
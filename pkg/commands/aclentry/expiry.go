@@ -0,0 +1,36 @@
+package aclentry
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ACL entries have no expiry of their own, so it's recorded in the entry's
+// comment as a tag such as "[expires=2022-01-02T15:04:05Z]", which expire-run
+// and the list command recognise.
+var expiryTag = regexp.MustCompile(`\s*\[expires=([^\]]+)\]`)
+
+// WithExpiry returns the comment with an expiry tag, replacing any existing
+// one.
+func WithExpiry(comment string, t time.Time) string {
+	comment = expiryTag.ReplaceAllString(comment, "")
+	tag := "[expires=" + t.UTC().Format(time.RFC3339) + "]"
+	if comment == "" {
+		return tag
+	}
+	return comment + " " + tag
+}
+
+// Expiry returns the expiry recorded in a comment, if it has one.
+func Expiry(comment string) (time.Time, bool) {
+	m := expiryTag.FindStringSubmatch(comment)
+	if m == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(m[1]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
-	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -21,7 +20,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Required flags
-	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL").Required().StringVar(&c.aclID)
+	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL"+cmd.IDOrNameDesc).Required().StringVar(&c.aclID)
 
 	// Optional Flags
 	c.CmdClause.Flag("direction", "Direction in which to sort results").Default(cmd.PaginationDirection[0]).HintOptions(cmd.PaginationDirection...).EnumVar(&c.direction, cmd.PaginationDirection...)
@@ -31,6 +30,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
+	c.CmdClause.Flag("max-items", "Maximum number of ACL entries to list").IntVar(&c.maxItems)
 	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.page)
 	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.perPage)
 	c.RegisterFlag(cmd.StringFlagOpts{
@@ -58,6 +58,7 @@ type ListCommand struct {
 	direction   string
 	json        bool
 	manifest    manifest.Data
+	maxItems    int
 	page        int
 	perPage     int
 	serviceName cmd.OptionalServiceNameID
@@ -66,11 +67,7 @@ type ListCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -79,22 +76,32 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	input := c.constructInput(serviceID)
-	paginator := c.Globals.APIClient.NewListACLEntriesPaginator(input)
 
-	// TODO: Use generics support in go 1.18 to replace this almost identical
-	// logic inside of 'dictionary-item list' and 'service list'.
 	var as []*fastly.ACLEntry
-	for paginator.HasNext() {
-		data, err := paginator.GetNext()
+	err = cmd.ResolveOnNotFound(input.ACLID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) error {
+		input.ACLID = id
+		as, err = cmd.Paginate(func(page int) cmd.Paginator[*fastly.ACLEntry] {
+			i := *input
+			i.Page = page
+			return c.Globals.APIClient.NewListACLEntriesPaginator(&i)
+		}, cmd.PaginateOpts{
+			Page:     input.Page,
+			MaxItems: c.maxItems,
+			Fetched: func(page, items int) {
+				c.Globals.Diagnostic(config.VerboseLevelTimings, "Fetched %d ACL entries (page %d)", items, page)
+			},
+		})
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
-				"ACL ID":          c.aclID,
-				"Service ID":      serviceID,
-				"Remaining Pages": paginator.Remaining(),
+				"ACL ID":     id,
+				"Service ID": serviceID,
+				"Page":       input.Page,
 			})
-			return err
 		}
-		as = append(as, data...)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	if c.Globals.Verbose() {
@@ -131,16 +138,20 @@ func (c *ListCommand) printVerbose(out io.Writer, as []*fastly.ACLEntry) {
 		fmt.Fprintf(out, "IP: %s\n", a.IP)
 		fmt.Fprintf(out, "Subnet: %d\n", a.Subnet)
 		fmt.Fprintf(out, "Negated: %t\n", a.Negated)
-		fmt.Fprintf(out, "Comment: %s\n\n", a.Comment)
+		fmt.Fprintf(out, "Comment: %s\n", a.Comment)
+		if t, ok := Expiry(a.Comment); ok {
+			fmt.Fprintf(out, "Expires at (UTC): %s\n", c.Globals.Formatter().UTCTime(t))
+		}
+		fmt.Fprintf(out, "\n")
 
 		if a.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", a.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*a.CreatedAt))
 		}
 		if a.UpdatedAt != nil {
-			fmt.Fprintf(out, "Updated at: %s\n", a.UpdatedAt)
+			fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*a.UpdatedAt))
 		}
 		if a.DeletedAt != nil {
-			fmt.Fprintf(out, "Deleted at: %s\n", a.DeletedAt)
+			fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*a.DeletedAt))
 		}
 
 		fmt.Fprintf(out, "\n")
@@ -21,13 +21,15 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.manifest = data
 
 	// Required flags
-	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL").Required().StringVar(&c.aclID)
+	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL"+cmd.IDOrNameDesc).Required().StringVar(&c.aclID)
 
 	// Optional flags
+	c.CmdClause.Flag("batch-file", `JSON batch patch passed as file path, content, or - for stdin, whose "entries" are create, update or delete operations (op, id, ip, subnet, negated, comment)`).Action(c.file.Set).StringVar(&c.file.Value)
+	// --file is the original name of --batch-file, kept for scripts.
+	c.CmdClause.Flag("file", "Batch update json passed as file path, content, or - for stdin, e.g. $(< batch.json)").Hidden().Action(c.file.Set).StringVar(&c.file.Value)
 	c.CmdClause.Flag("comment", "A freeform descriptive note").Action(c.comment.Set).StringVar(&c.comment.Value)
-	c.CmdClause.Flag("file", "Batch update json passed as file path or content, e.g. $(< batch.json)").Action(c.file.Set).StringVar(&c.file.Value)
 	c.CmdClause.Flag("id", "Alphanumeric string identifying an ACL Entry").Action(c.id.Set).StringVar(&c.id.Value)
-	c.CmdClause.Flag("ip", "An IP address").Action(c.ip.Set).StringVar(&c.ip.Value)
+	c.CmdClause.Flag("ip", "An IP address").Action(c.ip.Set).Action(cmd.Validate(cmd.ValidateIP)).StringVar(&c.ip.Value)
 	c.CmdClause.Flag("negated", "Whether to negate the match").Action(c.negated.Set).BoolVar(&c.negated.Value)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
@@ -41,7 +43,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("subnet", "Number of bits for the subnet mask applied to the IP address").Action(c.subnet.Set).IntVar(&c.subnet.Value)
+	c.CmdClause.Flag("subnet", "Number of bits for the subnet mask applied to the IP address").Action(c.subnet.Set).Action(cmd.Validate(cmd.ValidateRange(0, 128))).IntVar(&c.subnet.Value)
 
 	return &c
 }
@@ -63,7 +65,7 @@ type UpdateCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -77,7 +79,16 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 			return err
 		}
 
-		err = c.Globals.APIClient.BatchModifyACLEntries(input)
+		err = cmd.ResolveOnNotFound(input.ACLID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) error {
+			input.ACLID = id
+			return cmd.ApplyBatch(input.Entries, func(entries []*fastly.BatchACLEntry) error {
+				return c.Globals.APIClient.BatchModifyACLEntries(&fastly.BatchModifyACLEntriesInput{
+					ServiceID: serviceID,
+					ACLID:     id,
+					Entries:   entries,
+				})
+			})
+		})
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID": serviceID,
@@ -85,7 +96,11 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 			return err
 		}
 
-		text.Success(out, "Updated %d ACL entries (service: %s)", len(input.Entries), serviceID)
+		ops := make([]fastly.BatchOperation, len(input.Entries))
+		for i, entry := range input.Entries {
+			ops[i] = entry.Operation
+		}
+		text.Success(out, "Updated %d ACL entries (service: %s): %s", len(input.Entries), serviceID, cmd.BatchSummary(ops))
 		return nil
 	}
 
@@ -94,7 +109,12 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
-	a, err := c.Globals.APIClient.UpdateACLEntry(input)
+	var a *fastly.ACLEntry
+	err = cmd.ResolveOnNotFound(input.ACLID, cmd.ACLResolver(c.Globals.APIClient, serviceID), func(id string) (err error) {
+		input.ACLID = id
+		a, err = c.Globals.APIClient.UpdateACLEntry(input)
+		return err
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -113,10 +133,14 @@ func (c *UpdateCommand) constructBatchInput(serviceID string) (*fastly.BatchModi
 	input.ACLID = c.aclID
 	input.ServiceID = serviceID
 
-	s := cmd.Content(c.file.Value)
+	s, err := cmd.Content(c.file.Value, c.Globals.Stdin)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return nil, err
+	}
 	bs := []byte(s)
 
-	err := json.Unmarshal(bs, &input)
+	err = json.Unmarshal(bs, &input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"File": s,
@@ -124,9 +148,9 @@ func (c *UpdateCommand) constructBatchInput(serviceID string) (*fastly.BatchModi
 		return nil, err
 	}
 
-	if len(input.Entries) == 0 {
+	if err := validateBatch(input.Entries); err != nil {
 		err := errors.RemediationError{
-			Inner:       fmt.Errorf("missing 'entries' %s", c.file.Value),
+			Inner:       fmt.Errorf("invalid batch: %w", err),
 			Remediation: "Consult the API documentation for the JSON format: https://developer.fastly.com/reference/api/acls/acl-entry/#bulk-update-acl-entries",
 		}
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
@@ -138,6 +162,31 @@ func (c *UpdateCommand) constructBatchInput(serviceID string) (*fastly.BatchModi
 	return &input, nil
 }
 
+// validateBatch checks every operation of a batch patch before any are sent,
+// so that a mistake doesn't leave the ACL partially modified. The API's ACL
+// entry batches don't support upserts.
+func validateBatch(entries []*fastly.BatchACLEntry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("missing 'entries'")
+	}
+	for i, entry := range entries {
+		if entry == nil {
+			return fmt.Errorf("entry %d: missing operation", i+1)
+		}
+		err := cmd.ValidateBatchOperation(entry.Operation, fastly.CreateBatchOperation, fastly.UpdateBatchOperation, fastly.DeleteBatchOperation)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		switch {
+		case entry.Operation == fastly.CreateBatchOperation && (entry.IP == nil || *entry.IP == ""):
+			return fmt.Errorf("entry %d: missing 'ip' to create", i+1)
+		case entry.Operation != fastly.CreateBatchOperation && (entry.ID == nil || *entry.ID == ""):
+			return fmt.Errorf("entry %d: missing 'id' to %s", i+1, entry.Operation)
+		}
+	}
+	return nil
+}
+
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
 func (c *UpdateCommand) constructInput(serviceID string) (*fastly.UpdateACLEntryInput, error) {
 	var input fastly.UpdateACLEntryInput
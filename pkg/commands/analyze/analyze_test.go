@@ -0,0 +1,107 @@
+package analyze_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/analyze"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestAnalyzeCache(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		GetStatsJSONFn:      getStatsJSON(`[{"requests":600,"hits":300,"miss":200,"pass":100,"errors":10,"edge_resp_header_bytes":1},{"requests":400,"hits":200,"miss":100,"pass":100,"errors":0}]`),
+		ListVersionsFn:      testutil.ListVersions,
+		ListCacheSettingsFn: listCacheSettingsOK,
+		ListHeadersFn:       listHeadersOK,
+	}
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate invalid --last",
+			Args:      args("analyze cache --service-id 123 --last soon"),
+			WantError: "must be a duration such as 12h",
+		},
+		{
+			Name: "validate no requests",
+			Args: args("analyze cache --service-id 123"),
+			API: mock.API{
+				GetStatsJSONFn: getStatsJSON(`[]`),
+			},
+			WantError: "service 123 received no requests in the last 24h",
+		},
+		{
+			Name: "validate suggestions",
+			Args: args("analyze cache --service-id 123"),
+			API:  api,
+			WantOutputs: []string{
+				"1000      62.5%      20.0%  1.0%",
+				"Suggested changes to the active version (1):",
+				"Raise short TTLs (hit ratio 62.5% is below 90.0%)",
+				"Cache settings with a TTL under 3600s: 'api' (60s).",
+				"fastly vcl snippet create --service-id 123 --version active --autoclone --name analyze-min-ttl --type fetch",
+				"Simplify Vary",
+				"Header objects 'Vary on UA' vary the cache on User-Agent or Cookie",
+				"Reduce passes (20.0% of requests)",
+				"Cache settings 'no-cache' pass requests to the origin.",
+			},
+		},
+		{
+			Name:       "validate --target-hit-ratio",
+			Args:       args("analyze cache --service-id 123 --target-hit-ratio 0.5 --json"),
+			API:        api,
+			WantOutput: `"suggestions":[{"title":"Simplify Vary"`,
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+func TestSuggestStaleIfError(t *testing.T) {
+	stats := analyze.CacheStats{Requests: 100, Hits: 95, Miss: 5, Errors: 5}
+
+	s := analyze.Suggest("123", stats, 0.9, nil, nil)
+	if len(s) != 1 {
+		t.Fatalf("want 1 suggestion, have %d", len(s))
+	}
+	testutil.AssertStringContains(t, s[0].Commands[0], "set beresp.stale_if_error = 86400s;")
+
+	s = analyze.Suggest("123", stats, 0.9, []*fastly.CacheSetting{{Name: "default", TTL: 3600, StaleTTL: 60}}, nil)
+	testutil.AssertEqual(t, 0, len(s))
+}
+
+func getStatsJSON(data string) func(*fastly.GetStatsInput, interface{}) error {
+	return func(i *fastly.GetStatsInput, dst interface{}) error {
+		return json.Unmarshal([]byte(`{"status":"success","msg":null,"data":`+data+`}`), dst)
+	}
+}
+
+func listCacheSettingsOK(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	return []*fastly.CacheSetting{
+		{Name: "api", Action: fastly.CacheSettingActionCache, TTL: 60},
+		{Name: "no-cache", Action: fastly.CacheSettingActionPass},
+		{Name: "static", Action: fastly.CacheSettingActionCache, TTL: 86400},
+	}, nil
+}
+
+func listHeadersOK(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	return []*fastly.Header{
+		{Name: "Vary on UA", Type: fastly.HeaderTypeCache, Destination: "http.Vary", Source: `"Accept-Encoding, User-Agent"`},
+		{Name: "Set HSTS", Type: fastly.HeaderTypeResponse, Destination: "http.Strict-Transport-Security", Source: `"max-age=31536000"`},
+	}, nil
+}
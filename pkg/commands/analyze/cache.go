@@ -0,0 +1,349 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const (
+	// minTTL is the TTL, in seconds, that short TTLs are raised to.
+	minTTL = 3600
+
+	// maxPassRatio is the share of requests passed to the origin above which
+	// the passes are reported.
+	maxPassRatio = 0.1
+
+	// maxErrorRatio is the share of requests that error above which serving
+	// stale content is suggested.
+	maxErrorRatio = 0.01
+
+	// staleIfError is the time, in seconds, stale content is served for when
+	// the origin errors.
+	staleIfError = 86400
+)
+
+// varyHighCardinality matches request headers with so many distinct values
+// that varying on them makes a cache hit unlikely.
+var varyHighCardinality = regexp.MustCompile(`(?i)\b(User-Agent|Cookie)\b`)
+
+// CacheStats are the cache stats of a service over a period.
+type CacheStats struct {
+	Requests uint64 `json:"requests"`
+	Hits     uint64 `json:"hits"`
+	Miss     uint64 `json:"miss"`
+	Pass     uint64 `json:"pass"`
+	Errors   uint64 `json:"errors"`
+}
+
+// HitRatio returns the share of cacheable requests served from cache.
+func (s CacheStats) HitRatio() float64 {
+	return ratio(s.Hits, s.Hits+s.Miss)
+}
+
+// PassRatio returns the share of requests passed to the origin.
+func (s CacheStats) PassRatio() float64 {
+	return ratio(s.Pass, s.Requests)
+}
+
+// ErrorRatio returns the share of requests that errored.
+func (s CacheStats) ErrorRatio() float64 {
+	return ratio(s.Errors, s.Requests)
+}
+
+// Suggestion is a change to a service's configuration that should improve its
+// cache performance, with the commands that apply it.
+type Suggestion struct {
+	Title    string   `json:"title"`
+	Detail   string   `json:"detail"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// CacheCommand analyzes a service's cache stats alongside its configuration.
+type CacheCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+
+	json           bool
+	last           string
+	targetHitRatio float64
+}
+
+// NewCacheCommand returns a usable command registered under the parent.
+func NewCacheCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *CacheCommand {
+	var c CacheCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("cache", "Suggest changes to a service's caching configuration based on its hit ratio, passes and errors")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("last", "Period of stats to analyze, e.g. 24h or 7d").Default("24h").Action(cmd.Validate(cmd.ValidateLongDuration)).StringVar(&c.last)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("target-hit-ratio", "Hit ratio below which TTL changes are suggested (0-1)").Default("0.9").Float64Var(&c.targetHitRatio)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CacheCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	// The flag is validated when it's parsed.
+	last, _ := cmd.ParseLongDuration(c.last)
+
+	stats, err := c.stats(serviceID, last)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+			"Last":       c.last,
+		})
+		return err
+	}
+	if stats.Requests == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("service %s received no requests in the last %s", serviceID, c.last),
+			Remediation: "Use --last to analyze a longer period.",
+		}
+	}
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+	active, err := cmd.GetActiveVersion(versions)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	settings, err := c.Globals.APIClient.ListCacheSettings(&fastly.ListCacheSettingsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: active.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": active.Number,
+		})
+		return fmt.Errorf("error listing cache settings: %w", err)
+	}
+	headers, err := c.Globals.APIClient.ListHeaders(&fastly.ListHeadersInput{
+		ServiceID:      serviceID,
+		ServiceVersion: active.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": active.Number,
+		})
+		return fmt.Errorf("error listing headers: %w", err)
+	}
+
+	suggestions := Suggest(serviceID, stats, c.targetHitRatio, settings, headers)
+
+	if c.json {
+		data, err := json.Marshal(struct {
+			Stats       CacheStats   `json:"stats"`
+			HitRatio    float64      `json:"hit_ratio"`
+			Suggestions []Suggestion `json:"suggestions"`
+		}{stats, stats.HitRatio(), suggestions})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	text.Output(out, "Cache stats for service %s over the last %s:", serviceID, c.last)
+	text.Break(out)
+	t := text.NewTable(out)
+	t.AddHeader("REQUESTS", "HIT RATIO", "PASS", "ERRORS")
+	t.AddLine(stats.Requests, percent(stats.HitRatio()), percent(stats.PassRatio()), percent(stats.ErrorRatio()))
+	t.Print()
+	text.Break(out)
+
+	if len(suggestions) == 0 {
+		text.Success(out, "No changes suggested for the active version (%d)", active.Number)
+		return nil
+	}
+	text.Output(out, "Suggested changes to the active version (%d):", active.Number)
+	for i, s := range suggestions {
+		text.Break(out)
+		text.Output(out, "%d. %s", i+1, text.Bold(s.Title))
+		text.Indent(out, 3, "%s", s.Detail)
+		// Commands aren't wrapped so they can be copied.
+		for _, command := range s.Commands {
+			fmt.Fprintf(out, "\n      %s\n", command)
+		}
+	}
+	return nil
+}
+
+// stats sums the service's stats over the period.
+func (c *CacheCommand) stats(serviceID string, last time.Duration) (CacheStats, error) {
+	by := "hour"
+	if last > 7*24*time.Hour {
+		by = "day"
+	}
+	now := c.Globals.Clock.Now()
+
+	var envelope struct {
+		Status string `json:"status"`
+		Msg    string `json:"msg"`
+		// Each period's stats hold many fields, some of which aren't
+		// numbers, so only the cache fields are decoded.
+		Data []CacheStats `json:"data"`
+	}
+	err := c.Globals.APIClient.GetStatsJSON(&fastly.GetStatsInput{
+		Service: serviceID,
+		From:    strconv.FormatInt(now.Add(-last).Unix(), 10),
+		To:      strconv.FormatInt(now.Unix(), 10),
+		By:      by,
+	}, &envelope)
+	if err != nil {
+		return CacheStats{}, err
+	}
+	if envelope.Status != "success" {
+		return CacheStats{}, fmt.Errorf("non-success response: %s", envelope.Msg)
+	}
+
+	var sum CacheStats
+	for _, s := range envelope.Data {
+		sum.Requests += s.Requests
+		sum.Hits += s.Hits
+		sum.Miss += s.Miss
+		sum.Pass += s.Pass
+		sum.Errors += s.Errors
+	}
+	return sum, nil
+}
+
+// Suggest returns the changes suggested by the service's stats and the cache
+// settings and headers of its active version.
+func Suggest(serviceID string, stats CacheStats, targetHitRatio float64, settings []*fastly.CacheSetting, headers []*fastly.Header) []Suggestion {
+	var suggestions []Suggestion
+
+	if stats.HitRatio() < targetHitRatio {
+		s := Suggestion{
+			Title:    fmt.Sprintf("Raise short TTLs (hit ratio %s is below %s)", percent(stats.HitRatio()), percent(targetHitRatio)),
+			Commands: []string{snippetCommand(serviceID, "analyze-min-ttl", "fetch", fmt.Sprintf("if (beresp.ttl > 0s && beresp.ttl < %[1]ds) { set beresp.ttl = %[1]ds; }", minTTL))},
+		}
+		var short []string
+		for _, cs := range settings {
+			if cs.Action != fastly.CacheSettingActionPass && cs.TTL < minTTL {
+				short = append(short, fmt.Sprintf("'%s' (%ds)", cs.Name, cs.TTL))
+			}
+		}
+		if len(short) > 0 {
+			s.Detail = fmt.Sprintf("Cache settings with a TTL under %ds: %s. Raise them, or add a snippet that sets a minimum TTL for cacheable responses:", minTTL, strings.Join(short, ", "))
+		} else {
+			s.Detail = fmt.Sprintf("TTLs are set by the origin's Cache-Control headers. Raise them at the origin, or add a snippet that sets a minimum TTL of %ds for cacheable responses:", minTTL)
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	var vary []string
+	for _, h := range headers {
+		if h.Type == fastly.HeaderTypeCache && h.Destination == "http.Vary" && varyHighCardinality.MatchString(h.Source) {
+			vary = append(vary, fmt.Sprintf("'%s'", h.Name))
+		}
+	}
+	if len(vary) > 0 {
+		suggestions = append(suggestions, Suggestion{
+			Title:    "Simplify Vary",
+			Detail:   fmt.Sprintf("Header objects %s vary the cache on User-Agent or Cookie, which have so many values that few requests share a cached response. Vary on a normalized header, such as a device class, instead, or remove them with a snippet:", strings.Join(vary, ", ")),
+			Commands: []string{snippetCommand(serviceID, "analyze-vary", "fetch", `if (beresp.http.Vary) { set beresp.http.Vary = regsuball(beresp.http.Vary, "(?i)(User-Agent|Cookie) *,? *", ""); }`)},
+		})
+	}
+
+	if stats.PassRatio() > maxPassRatio {
+		s := Suggestion{
+			Title: fmt.Sprintf("Reduce passes (%s of requests)", percent(stats.PassRatio())),
+		}
+		var pass []string
+		for _, cs := range settings {
+			if cs.Action == fastly.CacheSettingActionPass {
+				pass = append(pass, fmt.Sprintf("'%s'", cs.Name))
+			}
+		}
+		if len(pass) > 0 {
+			s.Detail = fmt.Sprintf("Cache settings %s pass requests to the origin. Check their conditions only match uncacheable requests.", strings.Join(pass, ", "))
+		} else {
+			s.Detail = "Responses with Set-Cookie or Cache-Control: private are passed. Check which responses are passed, and why:"
+			s.Commands = []string{"fastly curl <url> --debug-cache"}
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	if stats.ErrorRatio() > maxErrorRatio {
+		stale := false
+		for _, cs := range settings {
+			if cs.StaleTTL > 0 {
+				stale = true
+			}
+		}
+		if !stale {
+			suggestions = append(suggestions, Suggestion{
+				Title:    fmt.Sprintf("Serve stale content on errors (%s of requests errored)", percent(stats.ErrorRatio())),
+				Detail:   "No cache settings serve stale content. Serve cached content while the origin is failing:",
+				Commands: []string{snippetCommand(serviceID, "analyze-stale-if-error", "fetch", fmt.Sprintf("set beresp.stale_if_error = %ds;", staleIfError))},
+			})
+		}
+	}
+
+	return suggestions
+}
+
+func snippetCommand(serviceID, name, location, content string) string {
+	return fmt.Sprintf("fastly vcl snippet create --service-id %s --version active --autoclone --name %s --type %s --content '%s'", serviceID, name, location, content)
+}
+
+func percent(r float64) string {
+	return fmt.Sprintf("%.1f%%", r*100)
+}
+
+func ratio(n, d uint64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
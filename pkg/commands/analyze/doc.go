@@ -0,0 +1,3 @@
+// Package analyze contains commands to analyze a Fastly service's stats and
+// suggest changes to its configuration.
+package analyze
@@ -0,0 +1,164 @@
+package apicall_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestAPI(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		name            string
+		args            []string
+		stdin           string
+		code            int
+		response        string
+		wantError       string
+		wantOutput      string
+		wantRequest     string
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:      "no token",
+			args:      args("api GET /service"),
+			wantError: "no token provided",
+		},
+		{
+			name:      "invalid method",
+			args:      args("api FETCH /service --token 123"),
+			wantError: "invalid method 'FETCH'",
+		},
+		{
+			name:      "invalid path",
+			args:      args("api GET service --token 123"),
+			wantError: "invalid path 'service'",
+		},
+		{
+			name:        "GET request",
+			args:        args("api get /service/123/version/1/snippet?page=2 --token 123"),
+			code:        http.StatusOK,
+			response:    `[{"name":"foo"}]`,
+			wantOutput:  `[{"name":"foo"}]`,
+			wantRequest: "GET https://api.fastly.com/service/123/version/1/snippet?page=2",
+		},
+		{
+			name:            "JSON body from stdin",
+			args:            args("api PUT /service/123 --data - --token 123"),
+			stdin:           `{"name":"bar"}`,
+			code:            http.StatusOK,
+			response:        `{"id":"123","name":"bar"}`,
+			wantOutput:      `{"id":"123","name":"bar"}`,
+			wantRequest:     "PUT https://api.fastly.com/service/123",
+			wantContentType: "application/json",
+			wantBody:        `{"name":"bar"}`,
+		},
+		{
+			name:            "form body",
+			args:            args("api POST /service --data name=bar --token 123"),
+			code:            http.StatusOK,
+			response:        `{"id":"456"}`,
+			wantRequest:     "POST https://api.fastly.com/service",
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "name=bar",
+		},
+		{
+			name:            "header overrides content type",
+			args:            args("api PATCH /service/123 --data {} -H Content-Type:application/vnd.api+json --token 123"),
+			code:            http.StatusOK,
+			wantRequest:     "PATCH https://api.fastly.com/service/123",
+			wantContentType: "application/vnd.api+json",
+			wantBody:        "{}",
+		},
+		{
+			name:        "include response status and headers",
+			args:        args("api GET /service --include --token 123"),
+			code:        http.StatusOK,
+			response:    "[]",
+			wantOutput:  "HTTP/1.1 200 OK\nContent-Type: application/json\n\n[]",
+			wantRequest: "GET https://api.fastly.com/service",
+		},
+		{
+			name:        "unsuccessful response",
+			args:        args("api DELETE /service/123 --token 123"),
+			code:        http.StatusNotFound,
+			response:    `{"msg":"Record not found"}`,
+			wantError:   "error from API: 404 Not Found",
+			wantOutput:  `{"msg":"Record not found"}`,
+			wantRequest: "DELETE https://api.fastly.com/service/123",
+		},
+		{
+			name:      "read-only",
+			args:      args("api DELETE /service/123 --read-only --token 123"),
+			wantError: "'fastly api' modifies the Fastly account",
+		},
+		{
+			name:       "dry run",
+			args:       args("api POST /service --data name=bar --dry-run --token 123"),
+			wantOutput: "DRY RUN: POST /service\nname=bar\n",
+		},
+		{
+			name:        "read during a dry run",
+			args:        args("api GET /service --dry-run --token 123"),
+			code:        http.StatusOK,
+			response:    "[]",
+			wantOutput:  "[]",
+			wantRequest: "GET https://api.fastly.com/service",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			client := &recordingClient{code: testcase.code, response: testcase.response}
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.HTTPClient = client
+			opts.Stdin = strings.NewReader(testcase.stdin)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			testutil.AssertString(t, testcase.wantRequest, client.request)
+			testutil.AssertString(t, testcase.wantContentType, client.contentType)
+			testutil.AssertString(t, testcase.wantBody, client.body)
+			if client.request != "" {
+				testutil.AssertString(t, "123", client.token)
+			}
+		})
+	}
+}
+
+// recordingClient records the request it's sent, and responds with the given
+// status code and JSON body.
+type recordingClient struct {
+	code     int
+	response string
+
+	request     string
+	token       string
+	contentType string
+	body        string
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	c.request = req.Method + " " + req.URL.String()
+	c.token = req.Header.Get("Fastly-Key")
+	c.contentType = req.Header.Get("Content-Type")
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		c.body = string(body)
+	}
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	rec.WriteHeader(c.code)
+	rec.WriteString(c.response)
+	return rec.Result(), nil
+}
@@ -0,0 +1,3 @@
+// Package apicall contains the 'fastly api' command, which makes arbitrary
+// requests to the Fastly API.
+package apicall
@@ -0,0 +1,180 @@
+package apicall
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// Methods are the HTTP methods of the requests that can be made.
+var Methods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// RootCommand is the 'fastly api' command, which makes a request to the API
+// and displays the raw response. It covers the parts of the API that the CLI
+// doesn't otherwise wrap. It should be installed under the primary root
+// command.
+type RootCommand struct {
+	cmd.Base
+
+	data    string
+	headers []string
+	include bool
+	method  string
+	path    string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("api", "Make an authenticated request to the Fastly API and display the raw response")
+	c.CmdClause.Arg("method", fmt.Sprintf("HTTP method: %s", strings.Join(Methods, ", "))).Required().HintOptions(Methods...).StringVar(&c.method)
+	c.CmdClause.Arg("path", "Path of the API endpoint, including any query string, e.g. /service/SERVICE_ID/version/1/snippet").Required().StringVar(&c.path)
+	c.CmdClause.Flag("data", "Request body, as a value, a file path, or '-' to read it from stdin. It's sent as JSON if it's valid JSON, otherwise as a form").StringVar(&c.data)
+	c.CmdClause.Flag("header", "Request header in the form 'Name: value' (repeat to send several headers)").Short('H').StringsVar(&c.headers)
+	c.CmdClause.Flag("include", "Display the status and headers of the response before its body").BoolVar(&c.include)
+	return &c
+}
+
+// Mutates implements the cmd.Command interface. The command modifies the
+// Fastly account unless the method only reads resources.
+func (c *RootCommand) Mutates() bool {
+	return !isRead(strings.ToUpper(c.method))
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	method := strings.ToUpper(c.method)
+	if !isMethod(method) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid method '%s'", c.method),
+			Remediation: fmt.Sprintf("Use one of the methods: %s.", strings.Join(Methods, ", ")),
+		}
+	}
+	if !strings.HasPrefix(c.path, "/") {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid path '%s'", c.path),
+			Remediation: "Give the path of the API endpoint, starting with '/', e.g. /service/SERVICE_ID/version. The API reference is at https://developer.fastly.com/reference/api/",
+		}
+	}
+
+	token, source := c.Globals.Token()
+	if source == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	var body string
+	if c.data != "" {
+		var err error
+		if body, err = cmd.Content(c.data, c.Globals.Stdin); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	// The request isn't sent via the API client, so --dry-run is applied
+	// here. Read-only mode is enforced before the command runs (see Mutates).
+	if !isRead(method) && c.Globals.Flag.DryRun {
+		fmt.Fprintf(out, "DRY RUN: %s %s\n", method, c.path)
+		if body != "" {
+			fmt.Fprintf(out, "%s\n", body)
+		}
+		return nil
+	}
+
+	endpoint, _ := c.Globals.Endpoint()
+	req, err := http.NewRequest(method, strings.TrimSuffix(endpoint, "/")+c.path, strings.NewReader(body))
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error constructing API request: %w", err)
+	}
+	req.Header.Set("Fastly-Key", token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", useragent.Name)
+	if body != "" {
+		if json.Valid([]byte(body)) {
+			req.Header.Set("Content-Type", "application/json")
+		} else {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	for _, h := range c.headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid header '%s'", h),
+				Remediation: "Headers must be in the form 'Name: value'.",
+			}
+		}
+		req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	resp, err := c.Globals.HTTPClient.Do(req)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error executing API request: %w", err)
+	}
+	defer resp.Body.Close() // #nosec G307
+
+	if c.include {
+		fmt.Fprintf(out, "%s %s\n", resp.Proto, resp.Status)
+		names := make([]string, 0, len(resp.Header))
+		for name := range resp.Header {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range resp.Header[name] {
+				fmt.Fprintf(out, "%s: %s\n", name, value)
+			}
+		}
+		text.Break(out)
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading API response: %w", err)
+	}
+
+	// The body of an unsuccessful response (usually describing the error)
+	// has been displayed, so the error only needs to give the status.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("error from API: %s", resp.Status)
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Method": method,
+			"Path":   c.path,
+		})
+		return err
+	}
+	return nil
+}
+
+func isMethod(method string) bool {
+	for _, m := range Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// isRead indicates whether a request with the method only reads resources.
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/errors"
@@ -322,6 +324,112 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestPurge(t *testing.T) {
+	args := testutil.Args
+	purgeAPI := mock.API{
+		GetCurrentUserFn: func() (*fastly.User, error) {
+			return &fastly.User{ID: "456", CustomerID: "abc"}, nil
+		},
+		ListCustomerUsersFn: func(i *fastly.ListCustomerUsersInput) ([]*fastly.User, error) {
+			return []*fastly.User{
+				{ID: "456", Login: "foo@example.com"},
+				{ID: "789", Login: "bar@example.com"},
+			}, nil
+		},
+		ListCustomerTokensFn: listPurgeTokens,
+		GetTokenSelfFn: func() (*fastly.Token, error) {
+			return &fastly.Token{ID: "self"}, nil
+		},
+		BatchDeleteTokensFn: func(i *fastly.BatchDeleteTokensInput) error {
+			var ids []string
+			for _, t := range i.Tokens {
+				ids = append(ids, t.ID)
+			}
+			if strings.Join(ids, ",") != "old,unused" {
+				return fmt.Errorf("unexpected tokens: %v", ids)
+			}
+			return nil
+		},
+	}
+
+	for _, testcase := range []struct {
+		name       string
+		args       []string
+		api        mock.API
+		stdin      string
+		wantError  string
+		wantOutput []string
+	}{
+		{
+			name:      "validate missing filter flags",
+			args:      args("auth-token purge --token 123"),
+			wantError: "must provide at least one of the --user, --older-than or --unused-for flags",
+		},
+		{
+			name:      "validate invalid --older-than",
+			args:      args("auth-token purge --older-than 1x --token 123"),
+			wantError: "invalid --older-than '1x'",
+		},
+		{
+			name:      "validate unknown --user",
+			args:      args("auth-token purge --user nobody@example.com --token 123"),
+			api:       purgeAPI,
+			wantError: "user 'nobody@example.com' not found",
+		},
+		{
+			name:       "validate no matching tokens",
+			args:       args("auth-token purge --user foo@example.com --older-than 10y --token 123"),
+			api:        purgeAPI,
+			wantOutput: []string{"No tokens match the given filters"},
+		},
+		{
+			name:  "validate declined confirmation",
+			args:  args("auth-token purge --user BAR@example.com --token 123"),
+			api:   purgeAPI,
+			stdin: "n",
+			wantOutput: []string{
+				"Skipping token 'self'",
+				"old",
+				"Revoke these 4 tokens?",
+			},
+		},
+		{
+			name: "validate purge with --older-than and --unused-for",
+			args: args("auth-token purge --older-than 1y --unused-for 90d --confirm-irreversible --token 123"),
+			api:  purgeAPI,
+			wantOutput: []string{
+				"Skipping token 'self'",
+				"Revoked 2 tokens",
+			},
+		},
+		{
+			name: "validate BatchDeleteTokens API error",
+			args: args("auth-token purge --older-than 1y --confirm-irreversible --token 123"),
+			api: mock.API{
+				GetCurrentUserFn:     purgeAPI.GetCurrentUserFn,
+				ListCustomerTokensFn: listPurgeTokens,
+				GetTokenSelfFn:       purgeAPI.GetTokenSelfFn,
+				BatchDeleteTokensFn: func(i *fastly.BatchDeleteTokensInput) error {
+					return testutil.Err
+				},
+			},
+			wantError: testutil.Err.Error(),
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			opts.Stdin = strings.NewReader(testcase.stdin)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
 func getToken() (*fastly.Token, error) {
 	t := testutil.Date
 
@@ -362,6 +470,21 @@ func listCustomerTokens(i *fastly.ListCustomerTokensInput) ([]*fastly.Token, err
 	return listTokens()
 }
 
+// listPurgeTokens returns tokens of differing ages for the bar@example.com
+// user, one of which is the token used to make the request.
+func listPurgeTokens(i *fastly.ListCustomerTokensInput) ([]*fastly.Token, error) {
+	day := 24 * time.Hour
+	old := time.Now().Add(-400 * day)
+	recent := time.Now().Add(-day)
+	return []*fastly.Token{
+		{ID: "old", Name: "Old", UserID: "789", CreatedAt: &old, LastUsedAt: &old},
+		{ID: "unused", Name: "Unused", UserID: "789", CreatedAt: &old},
+		{ID: "active", Name: "Active", UserID: "789", CreatedAt: &old, LastUsedAt: &recent},
+		{ID: "new", Name: "New", UserID: "789", CreatedAt: &recent},
+		{ID: "self", Name: "Self", UserID: "789", CreatedAt: &old},
+	}, nil
+}
+
 func fileTokensOutput() string {
 	return `Deleted tokens
 
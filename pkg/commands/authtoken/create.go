@@ -76,7 +76,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 
 	expires := "never"
 	if r.ExpiresAt != nil {
-		expires = r.ExpiresAt.String()
+		expires = c.Globals.Formatter().Time(*r.ExpiresAt)
 	}
 
 	text.Success(out, "Created token '%s' (name: %s, id: %s, scope: %s, expires: %s)", r.AccessToken, r.Name, r.ID, r.Scope, expires)
@@ -4,8 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
@@ -108,26 +106,18 @@ func (c *DeleteCommand) constructInput() *fastly.DeleteTokenInput {
 func (c *DeleteCommand) constructInputBatch() (*fastly.BatchDeleteTokensInput, error) {
 	var (
 		err    error
-		file   io.Reader
+		file   io.ReadCloser
 		input  fastly.BatchDeleteTokensInput
-		path   string
 		tokens []*fastly.BatchToken
 	)
 
-	if path, err = filepath.Abs(c.file); err == nil {
-		if _, err = os.Stat(path); err == nil {
-			// gosec flagged this:
-			// G304 (CWE-22): Potential file inclusion via variable
-			// Disabling as we trust the source of the path variable.
-			/* #nosec */
-			if file, err = os.Open(path); err == nil {
-				scanner := bufio.NewScanner(file)
-				for scanner.Scan() {
-					tokens = append(tokens, &fastly.BatchToken{ID: scanner.Text()})
-				}
-				err = scanner.Err()
-			}
+	if file, err = cmd.Open(c.file, c.Globals.Stdin); err == nil {
+		defer file.Close() // #nosec G307
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			tokens = append(tokens, &fastly.BatchToken{ID: scanner.Text()})
 		}
+		err = scanner.Err()
 	}
 
 	input.Tokens = tokens
@@ -42,10 +42,6 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 	if s == config.SourceUndefined {
 		return fsterr.ErrNoToken
 	}
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	r, err := c.Globals.APIClient.GetTokenSelf()
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -78,13 +74,13 @@ func (c *DescribeCommand) print(out io.Writer, r *fastly.Token) error {
 	fmt.Fprintf(out, "IP: %s\n\n", r.IP)
 
 	if r.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", r.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*r.CreatedAt))
 	}
 	if r.LastUsedAt != nil {
-		fmt.Fprintf(out, "Last used at: %s\n", r.LastUsedAt)
+		fmt.Fprintf(out, "Last used at: %s\n", c.Globals.Formatter().Time(*r.LastUsedAt))
 	}
 	if r.ExpiresAt != nil {
-		fmt.Fprintf(out, "Expires at: %s\n", r.ExpiresAt)
+		fmt.Fprintf(out, "Expires at: %s\n", c.Globals.Formatter().Time(*r.ExpiresAt))
 	}
 	return nil
 }
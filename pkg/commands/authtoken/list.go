@@ -50,10 +50,6 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 	if s == config.SourceUndefined {
 		return fsterr.ErrNoToken
 	}
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	var (
 		err error
 		rs  []*fastly.Token
@@ -112,13 +108,13 @@ func (c *ListCommand) printVerbose(out io.Writer, rs []*fastly.Token) {
 		fmt.Fprintf(out, "IP: %s\n\n", r.IP)
 
 		if r.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", r.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*r.CreatedAt))
 		}
 		if r.LastUsedAt != nil {
-			fmt.Fprintf(out, "Last used at: %s\n", r.LastUsedAt)
+			fmt.Fprintf(out, "Last used at: %s\n", c.Globals.Formatter().Time(*r.LastUsedAt))
 		}
 		if r.ExpiresAt != nil {
-			fmt.Fprintf(out, "Expires at: %s\n", r.ExpiresAt)
+			fmt.Fprintf(out, "Expires at: %s\n", c.Globals.Formatter().Time(*r.ExpiresAt))
 		}
 	}
 	fmt.Fprintf(out, "\n")
@@ -0,0 +1,216 @@
+package authtoken
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewPurgeCommand returns a usable command registered under the parent.
+func NewPurgeCommand(parent cmd.Registerer, globals *config.Data) *PurgeCommand {
+	var c PurgeCommand
+	c.CmdClause = parent.Command("purge", "Revoke all the customer's API tokens that match the given filters")
+	c.Globals = globals
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagCustomerIDName,
+		Description: cmd.FlagCustomerIDDesc,
+		Dst:         &c.customerID.Value,
+		Action:      c.customerID.Set,
+	})
+	c.CmdClause.Flag("older-than", "Match tokens created more than this long ago, e.g. 1y or 90d").Action(cmd.Validate(cmd.ValidateLongDuration)).StringVar(&c.olderThan)
+	c.CmdClause.Flag("unused-for", "Match tokens that haven't been used for this long, e.g. 90d").Action(cmd.Validate(cmd.ValidateLongDuration)).StringVar(&c.unusedFor)
+	c.CmdClause.Flag("user", "Match tokens belonging to this user (login email address or user ID)").StringVar(&c.user)
+	return &c
+}
+
+// PurgeCommand calls the Fastly API to revoke the tokens matching a filter.
+type PurgeCommand struct {
+	cmd.Base
+
+	customerID cmd.OptionalCustomerID
+	olderThan  string
+	unusedFor  string
+	user       string
+}
+
+// Exec invokes the application logic for the command.
+func (c *PurgeCommand) Exec(in io.Reader, out io.Writer) error {
+	_, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	if c.user == "" && c.olderThan == "" && c.unusedFor == "" {
+		return fmt.Errorf("error parsing arguments: must provide at least one of the --user, --older-than or --unused-for flags")
+	}
+
+	filter, err := c.filter()
+	if err != nil {
+		return err
+	}
+
+	customerID, err := cmd.CustomerID(&c.customerID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	if c.user != "" {
+		filter.userID, err = c.userID(customerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	tokens, err := c.Globals.APIClient.ListCustomerTokens(&fastly.ListCustomerTokensInput{CustomerID: customerID})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return err
+	}
+
+	self, err := c.Globals.APIClient.GetTokenSelf()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	var matches []*fastly.Token
+	for _, t := range tokens {
+		if !filter.match(t) {
+			continue
+		}
+		if t.ID == self.ID {
+			text.Info(out, "Skipping token '%s' as it's the token being used to make this request (see 'fastly auth-token delete --current')", t.ID)
+			text.Break(out)
+			continue
+		}
+		matches = append(matches, t)
+	}
+
+	if len(matches) == 0 {
+		text.Info(out, "No tokens match the given filters")
+		return nil
+	}
+
+	printPurgeTokens(out, matches, c.Globals.Formatter())
+	text.Break(out)
+
+	label := fmt.Sprintf("Revoke these %d tokens? This cannot be undone. [y/N] ", len(matches))
+	cont, err := cmd.Confirm(cmd.ConfirmIrreversible, label, c.Globals, in, out)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return nil
+	}
+
+	input := fastly.BatchDeleteTokensInput{}
+	for _, t := range matches {
+		input.Tokens = append(input.Tokens, &fastly.BatchToken{ID: t.ID})
+	}
+	err = c.Globals.APIClient.BatchDeleteTokens(&input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Tokens": len(input.Tokens),
+		})
+		return err
+	}
+
+	text.Success(out, "Revoked %d tokens", len(matches))
+	return nil
+}
+
+// filter converts the duration flags into a tokenFilter.
+func (c *PurgeCommand) filter() (tokenFilter, error) {
+	var f tokenFilter
+	now := c.Globals.Clock.Now()
+	if c.olderThan != "" {
+		d, err := cmd.ParseLongDuration(c.olderThan)
+		if err != nil {
+			return f, fmt.Errorf("invalid --older-than '%s': %w", c.olderThan, err)
+		}
+		f.createdBefore = now.Add(-d)
+	}
+	if c.unusedFor != "" {
+		d, err := cmd.ParseLongDuration(c.unusedFor)
+		if err != nil {
+			return f, fmt.Errorf("invalid --unused-for '%s': %w", c.unusedFor, err)
+		}
+		f.unusedSince = now.Add(-d)
+	}
+	return f, nil
+}
+
+// userID resolves the --user flag, which may be a login or a user ID.
+func (c *PurgeCommand) userID(customerID string) (string, error) {
+	users, err := c.Globals.APIClient.ListCustomerUsers(&fastly.ListCustomerUsersInput{CustomerID: customerID})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return "", err
+	}
+	for _, u := range users {
+		if u.ID == c.user || strings.EqualFold(u.Login, c.user) {
+			return u.ID, nil
+		}
+	}
+	return "", fsterr.RemediationError{
+		Inner:       fmt.Errorf("user '%s' not found", c.user),
+		Remediation: "Run 'fastly user list' to see the customer's users.",
+	}
+}
+
+// tokenFilter matches tokens against all the filters that are set.
+type tokenFilter struct {
+	userID        string
+	createdBefore time.Time
+	unusedSince   time.Time
+}
+
+func (f tokenFilter) match(t *fastly.Token) bool {
+	if f.userID != "" && t.UserID != f.userID {
+		return false
+	}
+	if !f.createdBefore.IsZero() && (t.CreatedAt == nil || !t.CreatedAt.Before(f.createdBefore)) {
+		return false
+	}
+	if !f.unusedSince.IsZero() {
+		// A token that has never been used is matched once it was created
+		// before the cutoff, so new tokens aren't revoked before they're used.
+		lastUsed := t.LastUsedAt
+		if lastUsed == nil {
+			lastUsed = t.CreatedAt
+		}
+		if lastUsed == nil || !lastUsed.Before(f.unusedSince) {
+			return false
+		}
+	}
+	return true
+}
+
+// printPurgeTokens displays the tokens that will be revoked.
+func printPurgeTokens(out io.Writer, rs []*fastly.Token, f text.Formatter) {
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "TOKEN ID", "USER ID", "SCOPE", "CREATED", "LAST USED")
+	for _, r := range rs {
+		created, lastUsed := "-", "never"
+		if r.CreatedAt != nil {
+			created = f.Time(*r.CreatedAt)
+		}
+		if r.LastUsedAt != nil {
+			lastUsed = f.Time(*r.LastUsedAt)
+		}
+		t.AddLine(r.Name, r.ID, r.UserID, r.Scope, created, lastUsed)
+	}
+	t.Print()
+}
@@ -3,11 +3,12 @@ package backend_test
 import (
 	"bytes"
 	"errors"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
-	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/commands/backend"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -20,6 +21,25 @@ func TestBackendCreate(t *testing.T) {
 			Args:      args("backend create --version 1 --service-id 123 --address example.com"),
 			WantError: "error parsing arguments: required flag --name not provided",
 		},
+		// Invalid flag values are rejected before any API requests are made (the
+		// mock API has no functions defined and so would panic if called).
+		{
+			Args:      args("backend create --service-id 123 --version 1 --address example.com --name www.test.com --port 70000 --autoclone"),
+			WantError: "error parsing arguments: invalid --port '70000': must be a whole number between 1 and 65535",
+		},
+		{
+			Args:      args("backend create --service-id 123 --version 1 --address http://example.com --name www.test.com --autoclone"),
+			WantError: "error parsing arguments: invalid --address 'http://example.com': must be a hostname, IPv4, or IPv6 address",
+		},
+		// Every flag rule that's broken is reported at once.
+		{
+			Args:      args("backend create --service-id 123 --version 1 --address example.com --name www.test.com --port 80 --use-ssl --ssl-client-cert cert --autoclone"),
+			WantError: "error parsing arguments: 2 problems with the flags:\n\n\t- the --use-ssl flag requires a TLS port such as 443, not --port 80\n\t- the --ssl-client-cert flag requires --ssl-client-key",
+		},
+		{
+			Args:      args("backend create --service-id 123 --version 1 --address example.com --name www.test.com --ssl-sni-hostname example.com --autoclone"),
+			WantError: "error parsing arguments: the --ssl-sni-hostname flag requires --use-ssl",
+		},
 		// The following test specifies a service version that's 'active', and
 		// subsequently we expect it to not be cloned as we don't provide the
 		// --autoclone flag and trying to add a backend to an activated service
@@ -142,7 +162,7 @@ func TestBackendList(t *testing.T) {
 				ListVersionsFn: testutil.ListVersions,
 				ListBackendsFn: listBackendsOK,
 			},
-			WantError: fsterr.ErrInvalidVerboseJSONCombo.Error(),
+			WantOutput: `[{"ServiceID":"123","ServiceVersion":1,"Name":"test.com","Comment":"test","Address":"www.test.com","Port":80,"OverrideHost":"","ConnectTimeout":0,"MaxConn":0,"ErrorThreshold":0,"FirstByteTimeout":0,"BetweenBytesTimeout":0,"AutoLoadbalance":false,"Weight":0,"RequestCondition":"","HealthCheck":"","Hostname":"","Shield":"","UseSSL":false,"SSLCheckCert":false,"SSLCACert":"","SSLClientCert":"","SSLClientKey":"","SSLHostname":"","SSLCertHostname":"","SSLSNIHostname":"","MinTLSVersion":"","MaxTLSVersion":"","SSLCiphers":"","CreatedAt":null,"UpdatedAt":null,"DeletedAt":null},{"ServiceID":"123","ServiceVersion":1,"Name":"example.com","Comment":"example","Address":"www.example.com","Port":443,"OverrideHost":"","ConnectTimeout":0,"MaxConn":0,"ErrorThreshold":0,"FirstByteTimeout":0,"BetweenBytesTimeout":0,"AutoLoadbalance":false,"Weight":0,"RequestCondition":"","HealthCheck":"","Hostname":"","Shield":"","UseSSL":false,"SSLCheckCert":false,"SSLCACert":"","SSLClientCert":"","SSLClientKey":"","SSLHostname":"","SSLCertHostname":"","SSLSNIHostname":"","MinTLSVersion":"","MaxTLSVersion":"","SSLCiphers":"","CreatedAt":null,"UpdatedAt":null,"DeletedAt":null}]`,
 		},
 		{
 			Args: args("backend list --service-id 123 --version 1"),
@@ -168,6 +188,14 @@ func TestBackendList(t *testing.T) {
 			},
 			WantOutput: listBackendsVerboseOutput,
 		},
+		{
+			Args: args("backend list --service-id 123 --version 1 -vv"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: listBackendsOK,
+			},
+			WantOutput: listBackendsVerboseOutput,
+		},
 		{
 			Args: args("backend --verbose list --service-id 123 --version 1"),
 			API: mock.API{
@@ -243,6 +271,292 @@ func TestBackendDescribe(t *testing.T) {
 	}
 }
 
+func TestBackendBlame(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Args:      args("backend blame --service-id 123"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Args: args("backend blame --service-id 123 --name www.test.com"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn:   getBackendError,
+			},
+			WantError: "error fetching service version 3: " + errTest.Error(),
+		},
+		{
+			Args: args("backend blame --service-id 123 --name www.test.com"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn:   getBackendPortChanged,
+			},
+			WantOutput: "Changed since version 1: port",
+		},
+		{
+			Args: args("backend blame --service-id 123 --name www.test.com --version 1"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn:   getBackendPortChanged,
+			},
+			WantOutput: "Backend 'www.test.com' is unchanged since version 1",
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestBackendMirror(t *testing.T) {
+	var created *fastly.CreateBackendInput
+	var snippets []*fastly.CreateSnippetInput
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Args:      args("backend mirror --service-id 123 --version 1 --primary test.com --shadow canary"),
+			WantError: "error parsing arguments: required flag --percent not provided",
+		},
+		{
+			Args:      args("backend mirror --service-id 123 --version 1 --primary test.com --shadow canary --percent 0"),
+			WantError: "invalid --percent 0",
+		},
+		{
+			Args:      args("backend mirror --service-id 123 --version 1 --primary test.com --shadow test.com --percent 10"),
+			WantError: "--primary and --shadow are the same backend",
+		},
+		{
+			Args: args("backend mirror --service-id 123 --version 1 --primary test.com --shadow canary --percent 10"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			WantError: "service version 1 is not editable",
+		},
+		{
+			Args: args("backend mirror --service-id 123 --version 3 --primary test.com --shadow canary --percent 10"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn:   getBackendNamed("test.com"),
+			},
+			WantError: "error getting shadow backend 'canary'",
+		},
+		{
+			Args: args("backend mirror --service-id 123 --version 1 --primary test.com --shadow origin-canary --percent 10 --shadow-address canary.test.com --print"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn:   getBackendNamed("test.com"),
+			},
+			WantOutputs: []string{
+				"# Backend 'origin-canary' (address canary.test.com) would be created.",
+				"if (randombool(10, 100)) {",
+				"set req.backend = F_origin_canary;",
+				"set req.backend = F_test_com;",
+				"# vcl_error snippet 'fastly-cli-mirror-error'",
+			},
+		},
+		{
+			Args: args("backend mirror --service-id 123 --version 1 --primary test.com --shadow origin-canary --percent 10 --shadow-address canary.test.com --autoclone"),
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				GetBackendFn:      getBackendNamed("test.com"),
+				CreateConditionFn: createConditionOK,
+				CreateBackendFn: func(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
+					created = i
+					return createBackendOK(i)
+				},
+				CreateSnippetFn: func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+					snippets = append(snippets, i)
+					return &fastly.Snippet{Name: i.Name, Type: i.Type}, nil
+				},
+			},
+			WantOutput: "Configured backend origin-canary to receive 10% of the requests for backend test.com (service 123 version 4)",
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+
+	if created == nil {
+		t.Fatal("expected the shadow backend to be created")
+	}
+	testutil.AssertString(t, "canary.test.com", created.Address)
+	testutil.AssertEqual(t, uint(80), *created.Port)
+	testutil.AssertString(t, backend.MirrorName, created.RequestCondition)
+	testutil.AssertEqual(t, fastly.Compatibool(false), created.AutoLoadbalance)
+	testutil.AssertEqual(t, 4, len(snippets))
+}
+
+func TestBackendHarden(t *testing.T) {
+	var updated []*fastly.UpdateBackendInput
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CloneVersionFn: testutil.CloneVersionResult(4),
+		ListBackendsFn: func(*fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			return []*fastly.Backend{
+				{Name: "plain", Address: "plain.example.com", Port: 80},
+				{Name: "origin", Address: "origin.example.com", Port: 443, UseSSL: true, MinTLSVersion: "1.0"},
+				{Name: "by-ip", Address: "192.0.2.1", Port: 443, UseSSL: true, SSLCheckCert: true, MinTLSVersion: "1.3"},
+				{Name: "done", Address: "done.example.com", Port: 443, UseSSL: true, SSLCheckCert: true, MinTLSVersion: "1.2", SSLCertHostname: "done.example.com", SSLSNIHostname: "done.example.com"},
+			}, nil
+		},
+		UpdateBackendFn: func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+			updated = append(updated, i)
+			return &fastly.Backend{Name: i.Name}, nil
+		},
+	}
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("backend harden --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate dry run of a locked version",
+			API:  api,
+			Args: args("backend harden --service-id 123 --version 1 --dry-run"),
+			WantOutputs: []string{
+				"origin   ssl_check_cert     false    true",
+				"origin   min_tls_version    1.0      1.2",
+				"origin   ssl_cert_hostname  (unset)  origin.example.com",
+				"Backend 'plain': doesn't use TLS",
+				"Backend 'by-ip': the address 192.0.2.1 is an IP address",
+				"1 backends of service 123 version 1 would be updated. Run without --dry-run to update them.",
+			},
+		},
+		{
+			Name:      "validate locked version without --autoclone",
+			API:       api,
+			Args:      args("backend harden --service-id 123 --version 1"),
+			WantError: "service version 1 is not editable",
+		},
+		{
+			Name:       "validate backends are updated",
+			API:        api,
+			Args:       args("backend harden --service-id 123 --version 1 --autoclone --min-tls-version 1.3"),
+			WantOutput: "Hardened 2 backends of service 123 version 4",
+		},
+	}
+	testutil.RunScenarios(t, scenarios)
+
+	testutil.AssertEqual(t, 2, len(updated))
+	testutil.AssertString(t, "done", updated[0].Name)
+	testutil.AssertEqual(t, (*string)(nil), updated[0].SSLCertHostname)
+	testutil.AssertString(t, "origin", updated[1].Name)
+	testutil.AssertEqual(t, 4, updated[1].ServiceVersion)
+	testutil.AssertEqual(t, fastly.CBool(true), updated[1].SSLCheckCert)
+	testutil.AssertString(t, "1.3", *updated[1].MinTLSVersion)
+	testutil.AssertString(t, "origin.example.com", *updated[1].SSLSNIHostname)
+}
+
+func TestBackendRecommendShield(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Args:      args("backend recommend-shield --service-id 123"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Args: args("backend recommend-shield --service-id 123 --name www.test.com"),
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				GetBackendFn:     getBackendOK,
+				ListDomainsFn:    listDomainsOK,
+				AllDatacentersFn: allDatacentersOK,
+				EdgeCheckFn:      edgeCheckError,
+			},
+			WantError: errTest.Error(),
+		},
+		{
+			Args: args("backend recommend-shield --service-id 123 --name www.test.com --url https://www.test.com/hit"),
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				GetBackendFn:     getBackendOK,
+				AllDatacentersFn: allDatacentersOK,
+				EdgeCheckFn:      edgeCheckOK,
+			},
+			WantError: "no shield POPs fetched https://www.test.com/hit from the backend",
+		},
+		{
+			Args: args("backend recommend-shield --service-id 123 --name www.test.com"),
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				GetBackendFn:     getBackendOK,
+				ListDomainsFn:    listDomainsOK,
+				AllDatacentersFn: allDatacentersOK,
+				EdgeCheckFn:      edgeCheckOK,
+			},
+			WantOutput: "Recommended shield: london-uk (London, 40ms)",
+		},
+		{
+			Args: args("backend recommend-shield --service-id 123 --name www.test.com --probes 1 --json"),
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				GetBackendFn:     getBackendOK,
+				ListDomainsFn:    listDomainsOK,
+				AllDatacentersFn: allDatacentersOK,
+				EdgeCheckFn:      edgeCheckOK,
+			},
+			WantOutput: `"recommended":{"code":"LCY","name":"London","shield":"london-uk","response_time_ms":40}`,
+		},
+		{
+			Args: args("backend recommend-shield --service-id 123 --version 1 --name www.test.com --apply"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			WantError: "service version 1 is not editable",
+		},
+		{
+			Args: args("backend recommend-shield --service-id 123 --version 1 --name www.test.com --apply --autoclone"),
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				CloneVersionFn:   testutil.CloneVersionResult(4),
+				GetBackendFn:     getBackendOK,
+				ListDomainsFn:    listDomainsOK,
+				AllDatacentersFn: allDatacentersOK,
+				EdgeCheckFn:      edgeCheckOK,
+				UpdateBackendFn: func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+					if i.Shield == nil || *i.Shield != "london-uk" {
+						return nil, errTest
+					}
+					return &fastly.Backend{Name: i.Name, Shield: *i.Shield}, nil
+				},
+			},
+			WantOutput: "Updated backend test.com shield to london-uk (service 123 version 4)",
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
 func TestBackendUpdate(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -445,10 +759,79 @@ func getBackendOK(i *fastly.GetBackendInput) (*fastly.Backend, error) {
 	}, nil
 }
 
+// getBackendPortChanged returns a backend whose port changed in version 2.
+func getBackendPortChanged(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+	b, err := getBackendOK(i)
+	if err != nil {
+		return nil, err
+	}
+	if i.ServiceVersion > 1 {
+		b.Port = 443
+	}
+	return b, nil
+}
+
+// getBackendNamed returns getBackendOK for the named backend and a not found
+// error for any other.
+func getBackendNamed(name string) func(*fastly.GetBackendInput) (*fastly.Backend, error) {
+	return func(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+		if i.Name != name {
+			return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+		}
+		return getBackendOK(i)
+	}
+}
+
+func createConditionOK(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	return &fastly.Condition{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+	}, nil
+}
+
 func getBackendError(i *fastly.GetBackendInput) (*fastly.Backend, error) {
 	return nil, errTest
 }
 
+func listDomainsOK(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	return []*fastly.Domain{
+		{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: "www.test.com"},
+	}, nil
+}
+
+func allDatacentersOK() ([]fastly.Datacenter, error) {
+	return []fastly.Datacenter{
+		{Code: "AMS", Name: "Amsterdam", Shield: "amsterdam-nl"},
+		{Code: "JFK", Name: "New York", Shield: "jfk-ny-us"},
+		{Code: "LCY", Name: "London", Shield: "london-uk"},
+		{Code: "SYD", Name: "Sydney"},
+	}, nil
+}
+
+// edgeCheckOK returns results from a POP that isn't a shield and a shield POP
+// that served the URL from cache, which should both be ignored. URLs with the
+// path /hit are served from cache by every POP.
+func edgeCheckOK(i *fastly.EdgeCheckInput) ([]*fastly.EdgeCheck, error) {
+	if !strings.Contains(i.URL, "?fastly-recommend-shield=") {
+		return nil, errTest
+	}
+	miss := &fastly.EdgeCheckResponse{Headers: &http.Header{"X-Cache": []string{"MISS, MISS"}}}
+	if strings.Contains(i.URL, "/hit") {
+		miss = &fastly.EdgeCheckResponse{Headers: &http.Header{"X-Cache": []string{"HIT"}}}
+	}
+	return []*fastly.EdgeCheck{
+		{Server: "cache-ams21020-AMS", ResponseTime: 0.001, Response: &fastly.EdgeCheckResponse{Headers: &http.Header{"X-Cache": []string{"MISS, HIT"}}}},
+		{Server: "cache-jfk1020-JFK", ResponseTime: 0.12, Response: miss},
+		{Server: "cache-lcy19221-LCY", ResponseTime: 0.04, Response: miss},
+		{Server: "cache-syd10120-SYD", ResponseTime: 0.01, Response: miss},
+	}, nil
+}
+
+func edgeCheckError(i *fastly.EdgeCheckInput) ([]*fastly.EdgeCheck, error) {
+	return nil, errTest
+}
+
 var describeBackendOutput = strings.Join([]string{
 	"\nService ID: 123",
 	"Service Version: 1\n",
@@ -0,0 +1,165 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewBlameCommand returns a usable command registered under the parent.
+func NewBlameCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *BlameCommand {
+	var c BlameCommand
+	c.CmdClause = parent.Command("blame", "Find the service version in which a backend last changed")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of backend").Short('n').Required().StringVar(&c.name)
+
+	// Optional flags
+	c.CmdClause.Flag("concurrency", "Number of service versions to fetch at once").Default(strconv.Itoa(cmd.BlameConcurrency)).IntVar(&c.concurrency)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " to walk back from (defaults to 'latest')",
+		Dst:         &c.serviceVersion.Value,
+	})
+
+	return &c
+}
+
+// BlameCommand calls the Fastly API to find when a backend last changed.
+type BlameCommand struct {
+	cmd.Base
+
+	concurrency    int
+	json           bool
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *BlameCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	r, err := cmd.Blame(versions, serviceVersion.Number, c.fetch(serviceID), c.concurrency)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Name":            c.name,
+		})
+		return err
+	}
+
+	if c.json {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	cmd.DisplayBlame(out, "Backend", c.name, r, c.Globals.Formatter())
+	return nil
+}
+
+// fetch returns the fields of the backend in a service version.
+func (c *BlameCommand) fetch(serviceID string) cmd.BlameFetchFunc {
+	return func(version int) (map[string]string, bool, error) {
+		b, err := c.Globals.APIClient.GetBackend(&fastly.GetBackendInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			Name:           c.name,
+		})
+		var httpErr *fastly.HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return map[string]string{
+			"address":               b.Address,
+			"auto_loadbalance":      strconv.FormatBool(b.AutoLoadbalance),
+			"between_bytes_timeout": fmt.Sprint(b.BetweenBytesTimeout),
+			"comment":               b.Comment,
+			"connect_timeout":       fmt.Sprint(b.ConnectTimeout),
+			"error_threshold":       fmt.Sprint(b.ErrorThreshold),
+			"first_byte_timeout":    fmt.Sprint(b.FirstByteTimeout),
+			"healthcheck":           b.HealthCheck,
+			"hostname":              b.Hostname,
+			"max_conn":              fmt.Sprint(b.MaxConn),
+			"max_tls_version":       b.MaxTLSVersion,
+			"min_tls_version":       b.MinTLSVersion,
+			"override_host":         b.OverrideHost,
+			"port":                  fmt.Sprint(b.Port),
+			"request_condition":     b.RequestCondition,
+			"shield":                b.Shield,
+			"ssl_ca_cert":           b.SSLCACert,
+			"ssl_cert_hostname":     b.SSLCertHostname,
+			"ssl_check_cert":        strconv.FormatBool(b.SSLCheckCert),
+			"ssl_ciphers":           b.SSLCiphers,
+			"ssl_client_cert":       b.SSLClientCert,
+			"ssl_client_key":        b.SSLClientKey,
+			"ssl_hostname":          b.SSLHostname,
+			"ssl_sni_hostname":      b.SSLSNIHostname,
+			"use_ssl":               strconv.FormatBool(b.UseSSL),
+			"weight":                fmt.Sprint(b.Weight),
+		}, true, nil
+	}
+}
@@ -68,10 +68,10 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Dst:    &c.autoClone.Value,
 	})
 	c.CmdClause.Flag("name", "Backend name").Short('n').Required().StringVar(&c.input.Name)
-	c.CmdClause.Flag("address", "A hostname, IPv4, or IPv6 address for the backend").Required().StringVar(&c.input.Address)
+	c.CmdClause.Flag("address", "A hostname, IPv4, or IPv6 address for the backend").Required().Action(cmd.Validate(cmd.ValidateAddress)).StringVar(&c.input.Address)
 	c.CmdClause.Flag("comment", "A descriptive note").StringVar(&c.input.Comment)
-	c.CmdClause.Flag("port", "Port number of the address").Action(c.port.Set).UintVar(&c.port.Value)
-	c.CmdClause.Flag("override-host", "The hostname to override the Host header").Action(c.overrideHost.Set).StringVar(&c.overrideHost.Value)
+	c.CmdClause.Flag("port", "Port number of the address").Action(c.port.Set).Action(cmd.Validate(cmd.ValidatePort)).UintVar(&c.port.Value)
+	c.CmdClause.Flag("override-host", "The hostname to override the Host header").Action(c.overrideHost.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.overrideHost.Value)
 	c.CmdClause.Flag("connect-timeout", "How long to wait for a timeout in milliseconds").Action(c.connectTimeout.Set).UintVar(&c.connectTimeout.Value)
 	c.CmdClause.Flag("max-conn", "Maximum number of connections").Action(c.maxConn.Set).UintVar(&c.maxConn.Value)
 	c.CmdClause.Flag("first-byte-timeout", "How long to wait for the first bytes in milliseconds").Action(c.firstByteTimeout.Set).UintVar(&c.firstByteTimeout.Value)
@@ -86,8 +86,8 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("ssl-ca-cert", "CA certificate attached to origin").StringVar(&c.input.SSLCACert)
 	c.CmdClause.Flag("ssl-client-cert", "Client certificate attached to origin").StringVar(&c.input.SSLClientCert)
 	c.CmdClause.Flag("ssl-client-key", "Client key attached to origin").StringVar(&c.input.SSLClientKey)
-	c.CmdClause.Flag("ssl-cert-hostname", "Overrides ssl_hostname, but only for cert verification. Does not affect SNI at all.").Action(c.sslCertHostname.Set).StringVar(&c.sslCertHostname.Value)
-	c.CmdClause.Flag("ssl-sni-hostname", "Overrides ssl_hostname, but only for SNI in the handshake. Does not affect cert validation at all.").Action(c.sslSNIHostname.Set).StringVar(&c.sslSNIHostname.Value)
+	c.CmdClause.Flag("ssl-cert-hostname", "Overrides ssl_hostname, but only for cert verification. Does not affect SNI at all.").Action(c.sslCertHostname.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.sslCertHostname.Value)
+	c.CmdClause.Flag("ssl-sni-hostname", "Overrides ssl_hostname, but only for SNI in the handshake. Does not affect cert validation at all.").Action(c.sslSNIHostname.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.sslSNIHostname.Value)
 	c.CmdClause.Flag("min-tls-version", "Minimum allowed TLS version on SSL connections to this backend").StringVar(&c.input.MinTLSVersion)
 	c.CmdClause.Flag("max-tls-version", "Maximum allowed TLS version on SSL connections to this backend").StringVar(&c.input.MaxTLSVersion)
 	c.CmdClause.Flag("ssl-ciphers", "Colon delimited list of OpenSSL ciphers (see https://www.openssl.org/docs/man1.0.2/man1/ciphers for details)").StringVar(&c.input.SSLCiphers)
@@ -97,9 +97,14 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 
 // Exec invokes the application logic for the command.
 func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := cmd.CheckFlagRules(c.flagRules()...); err != nil {
+		return err
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -117,6 +122,15 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	c.input.ServiceID = serviceID
 	c.input.ServiceVersion = serviceVersion.Number
 
+	// Certificates and keys can be provided as a value, a file path, or "-" to
+	// read from stdin.
+	for _, v := range []*string{&c.input.SSLCACert, &c.input.SSLClientCert, &c.input.SSLClientKey} {
+		if *v, err = cmd.Content(*v, c.Globals.Stdin); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
 	// Sadly, go-fastly uses custom a `Compatibool` type as a boolean value that
 	// marshalls to 0/1 instead of true/false for compatability with the API.
 	// Therefore, we need to cast our real flag bool to a fastly.Compatibool.
@@ -180,6 +194,32 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	return nil
 }
 
+// flagRules returns the rules between the command's flags.
+func (c *CreateCommand) flagRules() []cmd.FlagRule {
+	rules := []cmd.FlagRule{
+		cmd.FlagRequires("use-ssl", c.useSSL && c.port.WasSet, c.port.Value != 80, "a TLS port such as 443, not --port 80"),
+		cmd.FlagRequires("ssl-client-cert", c.input.SSLClientCert != "", c.input.SSLClientKey != "", "--ssl-client-key"),
+		cmd.FlagRequires("ssl-client-key", c.input.SSLClientKey != "", c.input.SSLClientCert != "", "--ssl-client-cert"),
+	}
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"ssl-check-cert", c.sslCheckCert},
+		{"ssl-ca-cert", c.input.SSLCACert != ""},
+		{"ssl-client-cert", c.input.SSLClientCert != ""},
+		{"ssl-client-key", c.input.SSLClientKey != ""},
+		{"ssl-cert-hostname", c.sslCertHostname.WasSet},
+		{"ssl-sni-hostname", c.sslSNIHostname.WasSet},
+		{"min-tls-version", c.input.MinTLSVersion != ""},
+		{"max-tls-version", c.input.MaxTLSVersion != ""},
+		{"ssl-ciphers", c.input.SSLCiphers != ""},
+	} {
+		rules = append(rules, cmd.FlagRequires(f.name, f.set, c.useSSL, "--use-ssl"))
+	}
+	return rules
+}
+
 // SetBackendHostDefaults configures the OverrideHost and SSLSNIHostname fields.
 //
 // By default we set the override_host and ssl_sni_hostname properties of the
@@ -0,0 +1,260 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// TLSVersions are the TLS versions a backend can require, oldest first.
+var TLSVersions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+// NewHardenCommand returns a usable command registered under the parent.
+func NewHardenCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *HardenCommand {
+	var c HardenCommand
+	c.CmdClause = parent.Command("harden", "Enable certificate verification, require a minimum TLS version and set the certificate hostname of every TLS backend missing them")
+	c.SetMutating()
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("min-tls-version", "Minimum TLS version to require of backends that allow older versions").Default("1.2").HintOptions(TLSVersions...).EnumVar(&c.minTLSVersion, TLSVersions...)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// HardenCommand calls the Fastly API to tighten the TLS settings of the
+// backends of a service version.
+type HardenCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	json           bool
+	manifest       manifest.Data
+	minTLSVersion  string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Hardening describes the changes made, or that would be made, to a backend.
+type Hardening struct {
+	Backend string           `json:"backend"`
+	Changes []HardeningField `json:"changes,omitempty"`
+	// Skipped explains why the backend, or one of its settings, was left
+	// unchanged.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// HardeningField is a setting changed by the harden command.
+type HardeningField struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Harden returns the changes needed to harden a backend's TLS settings, and
+// the input to make them (nil if there are none).
+//
+// NOTE: Backends that don't use TLS are reported but not changed, as enabling
+// TLS also requires the origin to accept it (typically on another port). The
+// SNI hostname is filled alongside the certificate hostname, as without it
+// most shared origins can't present the certificate being verified.
+func Harden(b *fastly.Backend, minTLSVersion string) (Hardening, *fastly.UpdateBackendInput) {
+	h := Hardening{Backend: b.Name}
+	if !b.UseSSL {
+		h.Skipped = "doesn't use TLS"
+		return h, nil
+	}
+
+	input := fastly.UpdateBackendInput{
+		ServiceID:      b.ServiceID,
+		ServiceVersion: b.ServiceVersion,
+		Name:           b.Name,
+	}
+	if !b.SSLCheckCert {
+		h.Changes = append(h.Changes, HardeningField{Field: "ssl_check_cert", From: "false", To: "true"})
+		input.SSLCheckCert = fastly.CBool(true)
+	}
+	if tlsVersionIndex(b.MinTLSVersion) < tlsVersionIndex(minTLSVersion) {
+		from := b.MinTLSVersion
+		if from == "" {
+			from = "(unset)"
+		}
+		h.Changes = append(h.Changes, HardeningField{Field: "min_tls_version", From: from, To: minTLSVersion})
+		input.MinTLSVersion = fastly.String(minTLSVersion)
+	}
+	if b.SSLCertHostname == "" || b.SSLSNIHostname == "" {
+		// A certificate is issued for a hostname, so the hostname of a
+		// backend addressed by IP has to be set by hand.
+		if net.ParseIP(b.Address) != nil {
+			h.Skipped = fmt.Sprintf("the address %s is an IP address, so its certificate hostname must be set with 'fastly backend update --ssl-cert-hostname'", b.Address)
+		} else {
+			if b.SSLCertHostname == "" {
+				h.Changes = append(h.Changes, HardeningField{Field: "ssl_cert_hostname", From: "(unset)", To: b.Address})
+				input.SSLCertHostname = fastly.String(b.Address)
+			}
+			if b.SSLSNIHostname == "" {
+				h.Changes = append(h.Changes, HardeningField{Field: "ssl_sni_hostname", From: "(unset)", To: b.Address})
+				input.SSLSNIHostname = fastly.String(b.Address)
+			}
+		}
+	}
+
+	if len(h.Changes) == 0 {
+		return h, nil
+	}
+	return h, &input
+}
+
+// tlsVersionIndex returns the position of a TLS version in TLSVersions, or -1
+// if it's unset or unknown.
+func tlsVersionIndex(v string) int {
+	for i, tv := range TLSVersions {
+		if tv == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Exec invokes the application logic for the command.
+func (c *HardenCommand) Exec(in io.Reader, out io.Writer) error {
+	opts := cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  c.Globals.Flag.DryRun,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	}
+	// A dry run doesn't modify the service version, so it's only cloned when
+	// the changes are going to be made.
+	if !c.Globals.Flag.DryRun {
+		opts.AutoCloneFlag = c.autoClone
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(opts)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	backends, err := c.Globals.APIClient.ListBackends(&fastly.ListBackendsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		return backends[i].Name < backends[j].Name
+	})
+
+	report := []Hardening{}
+	var inputs []*fastly.UpdateBackendInput
+	for _, b := range backends {
+		// The version is taken from the command, as it may have been cloned.
+		b.ServiceID, b.ServiceVersion = serviceID, serviceVersion.Number
+		h, input := Harden(b, c.minTLSVersion)
+		report = append(report, h)
+		if input != nil {
+			inputs = append(inputs, input)
+		}
+	}
+
+	if !c.Globals.Flag.DryRun {
+		for _, input := range inputs {
+			if _, err := c.Globals.APIClient.UpdateBackend(input); err != nil {
+				c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+					"Service ID":      serviceID,
+					"Service Version": serviceVersion.Number,
+					"Name":            input.Name,
+				})
+				return fmt.Errorf("error updating backend '%s': %w", input.Name, err)
+			}
+		}
+	}
+
+	if c.json {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(inputs) > 0 {
+		t := text.NewTable(out)
+		t.AddHeader("BACKEND", "FIELD", "FROM", "TO")
+		for _, h := range report {
+			for _, f := range h.Changes {
+				t.AddLine(h.Backend, f.Field, f.From, f.To)
+			}
+		}
+		t.Print()
+		text.Break(out)
+	}
+	for _, h := range report {
+		if h.Skipped != "" {
+			text.Warning(out, "Backend '%s': %s", h.Backend, h.Skipped)
+		}
+	}
+
+	switch {
+	case len(inputs) == 0:
+		text.Success(out, "All TLS backends of service %s version %d are already hardened", serviceID, serviceVersion.Number)
+	case c.Globals.Flag.DryRun:
+		text.Info(out, "%d backends of service %s version %d would be updated. Run without --dry-run to update them.", len(inputs), serviceID, serviceVersion.Number)
+	default:
+		text.Success(out, "Hardened %d backends of service %s version %d", len(inputs), serviceID, serviceVersion.Number)
+	}
+	return nil
+}
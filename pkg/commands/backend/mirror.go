@@ -0,0 +1,333 @@
+package backend
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// MirrorName is the prefix of the name of the VCL snippets, and the name of
+// the condition attached to the shadow backend, created by the mirror command.
+const MirrorName = "fastly-cli-mirror"
+
+// mirrorHeader is the request header that records whether a request was
+// sampled for the shadow backend.
+const mirrorHeader = "Fastly-CLI-Mirror"
+
+// NewMirrorCommand returns a usable command registered under the parent.
+func NewMirrorCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *MirrorCommand {
+	var c MirrorCommand
+	c.CmdClause = parent.Command("mirror", "Send a percentage of requests to a shadow backend, falling back to the primary backend if it fails")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("percent", "Percentage of requests to send to the shadow backend (1-100)").Required().IntVar(&c.percent)
+	c.CmdClause.Flag("primary", "Name of the backend that currently serves the requests").Required().StringVar(&c.primary)
+	c.CmdClause.Flag("shadow", "Name of the backend to send the sampled requests to").Required().StringVar(&c.shadow)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("print", "Display the generated VCL snippets without changing the service").BoolVar(&c.print)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("shadow-address", "Create the shadow backend with this address and the primary backend's other settings").Action(cmd.Validate(cmd.ValidateAddress)).StringVar(&c.shadowAddress)
+
+	return &c
+}
+
+// MirrorCommand generates the VCL snippets and backend needed to send a
+// sample of a service's requests to a shadow backend.
+type MirrorCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	manifest       manifest.Data
+	percent        int
+	primary        string
+	print          bool
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	shadow         string
+	shadowAddress  string
+}
+
+// MirrorSnippet is a VCL snippet generated by the mirror command.
+type MirrorSnippet struct {
+	Type    fastly.SnippetType
+	Content string
+}
+
+// Mutates implements the cmd.Command interface. The command only modifies
+// the service if the snippets aren't just displayed (--print).
+func (c *MirrorCommand) Mutates() bool {
+	return !c.print
+}
+
+// Exec invokes the application logic for the command.
+//
+// NOTE: VCL can't send a request to two backends, so the sampled requests
+// are served by the shadow backend rather than copied to it. They bypass the
+// cache, and are restarted against the primary backend if the shadow backend
+// returns a 5xx status or can't be reached.
+func (c *MirrorCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.percent < 1 || c.percent > 100 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --percent %d", c.percent),
+			Remediation: "Set --percent to a whole number between 1 and 100.",
+		}
+	}
+	if c.primary == c.shadow {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--primary and --shadow are the same backend"),
+			Remediation: "Set --shadow to the name of the backend to send the sampled requests to.",
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  c.print,
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	primary, err := c.Globals.APIClient.GetBackend(&fastly.GetBackendInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+		Name:           c.primary,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Name":            c.primary,
+		})
+		return err
+	}
+
+	var shadow *fastly.CreateBackendInput
+	if c.shadowAddress != "" {
+		shadow = c.shadowBackend(primary)
+	} else {
+		_, err = c.Globals.APIClient.GetBackend(&fastly.GetBackendInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion.Number,
+			Name:           c.shadow,
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Name":            c.shadow,
+			})
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("error getting shadow backend '%s': %w", c.shadow, err),
+				Remediation: "Set --shadow-address to create the shadow backend.",
+			}
+		}
+	}
+
+	snippets := MirrorVCL(c.primary, c.shadow, c.percent)
+
+	if c.print {
+		if shadow != nil {
+			text.Output(out, "# Backend '%s' (address %s) would be created.", shadow.Name, shadow.Address)
+			text.Break(out)
+		}
+		for _, s := range snippets {
+			text.Output(out, "# vcl_%s snippet '%s'", s.Type, mirrorSnippetName(s.Type))
+			text.Output(out, "%s", s.Content)
+		}
+		return nil
+	}
+
+	if shadow != nil {
+		err = c.createShadow(serviceID, serviceVersion.Number, shadow)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Name":            c.shadow,
+			})
+			return err
+		}
+	}
+
+	for _, s := range snippets {
+		_, err = c.Globals.APIClient.CreateSnippet(&fastly.CreateSnippetInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion.Number,
+			Name:           mirrorSnippetName(s.Type),
+			Type:           s.Type,
+			Content:        s.Content,
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Type":            s.Type,
+			})
+			return fmt.Errorf("error creating vcl_%s snippet: %w", s.Type, err)
+		}
+	}
+
+	text.Success(out, "Configured backend %s to receive %d%% of the requests for backend %s (service %s version %d)", c.shadow, c.percent, c.primary, serviceID, serviceVersion.Number)
+	text.Info(out, "Activate the service version to start sending requests to the shadow backend. Responses from it include the header '%s: shadow'.", mirrorHeader)
+	return nil
+}
+
+// shadowBackend returns the input to create the shadow backend, which uses the
+// primary backend's settings with a different address.
+func (c *MirrorCommand) shadowBackend(primary *fastly.Backend) *fastly.CreateBackendInput {
+	input := &fastly.CreateBackendInput{
+		Name:                c.shadow,
+		Comment:             fmt.Sprintf("Shadow of backend %s", primary.Name),
+		Address:             c.shadowAddress,
+		Port:                fastly.Uint(primary.Port),
+		OverrideHost:        primary.OverrideHost,
+		ConnectTimeout:      fastly.Uint(primary.ConnectTimeout),
+		FirstByteTimeout:    fastly.Uint(primary.FirstByteTimeout),
+		BetweenBytesTimeout: fastly.Uint(primary.BetweenBytesTimeout),
+		Shield:              primary.Shield,
+		UseSSL:              fastly.Compatibool(primary.UseSSL),
+		SSLCheckCert:        fastly.Compatibool(primary.SSLCheckCert),
+		SSLCertHostname:     primary.SSLCertHostname,
+		SSLSNIHostname:      primary.SSLSNIHostname,
+		MinTLSVersion:       primary.MinTLSVersion,
+		MaxTLSVersion:       primary.MaxTLSVersion,
+		// The shadow backend is selected by the mirror snippets, so it must not
+		// be picked by the load balancer or as the default backend.
+		AutoLoadbalance:  false,
+		RequestCondition: MirrorName,
+	}
+	if input.OverrideHost == "" && input.SSLCertHostname == "" && input.SSLSNIHostname == "" {
+		input.OverrideHost, input.SSLSNIHostname, input.SSLCertHostname = SetBackendHostDefaults(c.shadowAddress)
+	}
+	return input
+}
+
+// createShadow creates the shadow backend along with a condition that never
+// matches, which stops Fastly selecting it as the default backend.
+func (c *MirrorCommand) createShadow(serviceID string, serviceVersion int, input *fastly.CreateBackendInput) error {
+	_, err := c.Globals.APIClient.CreateCondition(&fastly.CreateConditionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Name:           MirrorName,
+		Statement:      "false",
+		Type:           "REQUEST",
+	})
+	if err != nil {
+		return fmt.Errorf("error creating condition for shadow backend: %w", err)
+	}
+
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+	_, err = c.Globals.APIClient.CreateBackend(input)
+	if err != nil {
+		return fmt.Errorf("error creating shadow backend: %w", err)
+	}
+	return nil
+}
+
+// MirrorVCL returns the VCL snippets that send the percentage of requests to
+// the shadow backend, and restart them against the primary backend if the
+// shadow backend fails.
+func MirrorVCL(primary, shadow string, percent int) []MirrorSnippet {
+	p, s := vclBackendName(primary), vclBackendName(shadow)
+	return []MirrorSnippet{
+		{
+			Type: fastly.SnippetTypeRecv,
+			Content: fmt.Sprintf(`# Send %[1]d%% of requests to backend %[2]s instead of %[3]s.
+if (req.restarts == 0) {
+  unset req.http.%[4]s;
+  if (randombool(%[1]d, 100)) {
+    set req.http.%[4]s = "shadow";
+  }
+}
+if (req.http.%[4]s == "shadow") {
+  set req.backend = %[5]s;
+  return(pass);
+}
+if (req.http.%[4]s == "fallback") {
+  set req.backend = %[6]s;
+  return(pass);
+}
+`, percent, shadow, primary, mirrorHeader, s, p),
+		},
+		{
+			Type: fastly.SnippetTypeFetch,
+			Content: fmt.Sprintf(`# Retry requests that backend %[1]s failed against %[2]s.
+if (req.http.%[3]s == "shadow" && beresp.status >= 500) {
+  set req.http.%[3]s = "fallback";
+  restart;
+}
+`, shadow, primary, mirrorHeader),
+		},
+		{
+			Type: fastly.SnippetTypeError,
+			Content: fmt.Sprintf(`# Retry requests that couldn't reach backend %[1]s against %[2]s.
+if (req.http.%[3]s == "shadow" && obj.status == 503) {
+  set req.http.%[3]s = "fallback";
+  restart;
+}
+`, shadow, primary, mirrorHeader),
+		},
+		{
+			Type: fastly.SnippetTypeDeliver,
+			Content: fmt.Sprintf(`if (req.http.%[1]s) {
+  set resp.http.%[1]s = req.http.%[1]s;
+}
+`, mirrorHeader),
+		},
+	}
+}
+
+func mirrorSnippetName(t fastly.SnippetType) string {
+	return fmt.Sprintf("%s-%s", MirrorName, t)
+}
+
+var vclBackendNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// vclBackendName returns the name generated VCL uses for a backend, e.g.
+// F_origin_canary for origin-canary.
+func vclBackendName(name string) string {
+	return "F_" + vclBackendNameInvalid.ReplaceAllString(name, "_")
+}
@@ -0,0 +1,347 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// recommendShieldParam is the query parameter added to each probe so that the
+// request isn't served from cache.
+const recommendShieldParam = "fastly-recommend-shield"
+
+// recommendShieldLimit is the number of candidate shield POPs displayed.
+const recommendShieldLimit = 10
+
+// NewRecommendShieldCommand returns a usable command registered under the parent.
+func NewRecommendShieldCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *RecommendShieldCommand {
+	var c RecommendShieldCommand
+	c.CmdClause = parent.Command("recommend-shield", "Recommend the shield POP with the lowest latency to a backend")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of backend").Short('n').Required().StringVar(&c.name)
+
+	// Optional flags
+	c.CmdClause.Flag("apply", "Set the backend's shield to the recommended POP").BoolVar(&c.apply)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("probes", "Number of times to fetch the URL from each POP (the median response time is used)").Default("3").IntVar(&c.probes)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("url", "A URL on the service that's fetched from the backend (defaults to the root of the service's first domain)").StringVar(&c.url)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'latest')",
+		Dst:         &c.serviceVersion.Value,
+	})
+
+	return &c
+}
+
+// RecommendShieldCommand uses Fastly's edge check to measure the response time
+// of each shield POP when fetching from a backend.
+type RecommendShieldCommand struct {
+	cmd.Base
+
+	apply          bool
+	autoClone      cmd.OptionalAutoClone
+	json           bool
+	manifest       manifest.Data
+	name           string
+	probes         int
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	url            string
+}
+
+// ShieldCandidate is a shield POP and its median response time when fetching
+// from the backend.
+type ShieldCandidate struct {
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	Shield       string  `json:"shield"`
+	ResponseTime float64 `json:"response_time_ms"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *RecommendShieldCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  !c.apply,
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	b, err := c.Globals.APIClient.GetBackend(&fastly.GetBackendInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+		Name:           c.name,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Name":            c.name,
+		})
+		return err
+	}
+
+	probeURL, err := c.probeURL(serviceID, serviceVersion.Number)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := c.measure(probeURL)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"URL": probeURL,
+		})
+		return err
+	}
+	if len(candidates) == 0 {
+		err := fsterr.RemediationError{
+			Inner:       fmt.Errorf("no shield POPs fetched %s from the backend", probeURL),
+			Remediation: "Set --url to a URL on the service that's routed to the backend and isn't served from cache (e.g. is passed).",
+		}
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"URL": probeURL,
+		})
+		return err
+	}
+	recommended := candidates[0]
+
+	if c.json {
+		data, err := json.Marshal(struct {
+			Backend       string            `json:"backend"`
+			CurrentShield string            `json:"current_shield"`
+			Recommended   ShieldCandidate   `json:"recommended"`
+			Candidates    []ShieldCandidate `json:"candidates"`
+		}{b.Name, b.Shield, recommended, candidates})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+	} else {
+		c.display(out, b, probeURL, candidates)
+	}
+
+	if !c.apply {
+		return nil
+	}
+	if b.Shield == recommended.Shield {
+		text.Info(out, "Backend '%s' already uses the recommended shield", b.Name)
+		return nil
+	}
+
+	_, err = c.Globals.APIClient.UpdateBackend(&fastly.UpdateBackendInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+		Name:           c.name,
+		Shield:         fastly.String(recommended.Shield),
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Name":            c.name,
+			"Shield":          recommended.Shield,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated backend %s shield to %s (service %s version %d)", b.Name, recommended.Shield, serviceID, serviceVersion.Number)
+	return nil
+}
+
+// probeURL returns the URL to fetch from each POP.
+func (c *RecommendShieldCommand) probeURL(serviceID string, serviceVersion int) (string, error) {
+	if c.url != "" {
+		return c.url, nil
+	}
+
+	domains, err := c.Globals.APIClient.ListDomains(&fastly.ListDomainsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+		})
+		return "", err
+	}
+	if len(domains) == 0 {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("service version %d has no domains", serviceVersion),
+			Remediation: "Set --url to a URL on the service that's routed to the backend.",
+		}
+	}
+	return fmt.Sprintf("https://%s/", domains[0].Name), nil
+}
+
+// measure fetches the URL from every POP using the edge check API and returns
+// the shield POPs that fetched it from the backend, fastest first.
+//
+// NOTE: If the backend already has a shield, then the response times of other
+// POPs include the hop to that shield, which favours POPs close to it.
+func (c *RecommendShieldCommand) measure(rawURL string) ([]ShieldCandidate, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %s: %w", rawURL, err)
+	}
+
+	dcs, err := c.Globals.APIClient.AllDatacenters()
+	if err != nil {
+		return nil, err
+	}
+	shields := make(map[string]fastly.Datacenter)
+	for _, dc := range dcs {
+		if dc.Shield != "" {
+			shields[strings.ToUpper(dc.Code)] = dc
+		}
+	}
+
+	probes := c.probes
+	if probes < 1 {
+		probes = 1
+	}
+	times := make(map[string][]float64)
+	for i := 0; i < probes; i++ {
+		// A unique query string means the probe isn't served from cache.
+		q := u.Query()
+		q.Set(recommendShieldParam, strconv.FormatInt(time.Now().UnixNano(), 36))
+		u.RawQuery = q.Encode()
+
+		checks, err := c.Globals.APIClient.EdgeCheck(&fastly.EdgeCheckInput{
+			URL: u.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ec := range checks {
+			code := popCode(ec.Server)
+			if _, ok := shields[code]; !ok || cacheHit(ec.Response) {
+				continue
+			}
+			times[code] = append(times[code], ec.ResponseTime*1000)
+		}
+	}
+
+	candidates := make([]ShieldCandidate, 0, len(times))
+	for code, ts := range times {
+		dc := shields[code]
+		candidates = append(candidates, ShieldCandidate{
+			Code:         code,
+			Name:         dc.Name,
+			Shield:       dc.Shield,
+			ResponseTime: median(ts),
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].ResponseTime == candidates[j].ResponseTime {
+			return candidates[i].Code < candidates[j].Code
+		}
+		return candidates[i].ResponseTime < candidates[j].ResponseTime
+	})
+	return candidates, nil
+}
+
+func (c *RecommendShieldCommand) display(out io.Writer, b *fastly.Backend, probeURL string, candidates []ShieldCandidate) {
+	text.Output(out, "Response times of shield POPs fetching %s from backend '%s':", probeURL, b.Name)
+	text.Break(out)
+
+	t := text.NewTable(out)
+	t.AddHeader("POP", "NAME", "SHIELD", "RESPONSE TIME", "")
+	for i, sc := range candidates {
+		var current string
+		if sc.Shield == b.Shield {
+			current = "(current)"
+		}
+		if i >= recommendShieldLimit && current == "" {
+			continue
+		}
+		t.AddLine(sc.Code, sc.Name, sc.Shield, fmt.Sprintf("%.0fms", sc.ResponseTime), current)
+	}
+	t.Print()
+	text.Break(out)
+
+	recommended := candidates[0]
+	text.Output(out, "Recommended shield: %s (%s, %.0fms)", text.Bold(recommended.Shield), recommended.Name, recommended.ResponseTime)
+	if b.Shield != "" && b.Shield != recommended.Shield {
+		text.Warning(out, "Backend '%s' currently uses shield %s. Response times from other POPs include the hop to it.", b.Name, b.Shield)
+	}
+}
+
+// popCode returns the POP code from the name of a cache server, e.g. LCY from
+// cache-lcy19221-LCY.
+func popCode(server string) string {
+	i := strings.LastIndex(server, "-")
+	return strings.ToUpper(server[i+1:])
+}
+
+// cacheHit reports whether the POP served the response from cache. The POP
+// appends its own result to the X-Cache header.
+func cacheHit(resp *fastly.EdgeCheckResponse) bool {
+	if resp == nil || resp.Headers == nil {
+		return false
+	}
+	xc := resp.Headers.Get("X-Cache")
+	if i := strings.LastIndex(xc, ","); i >= 0 {
+		xc = xc[i+1:]
+	}
+	return strings.Contains(strings.ToUpper(xc), "HIT")
+}
+
+func median(vs []float64) float64 {
+	s := append([]float64{}, vs...)
+	sort.Float64s(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
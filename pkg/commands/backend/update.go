@@ -78,9 +78,9 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("name", "backend name").Short('n').Required().StringVar(&c.name)
 	c.CmdClause.Flag("new-name", "New backend name").Action(c.NewName.Set).StringVar(&c.NewName.Value)
 	c.CmdClause.Flag("comment", "A descriptive note").Action(c.Comment.Set).StringVar(&c.Comment.Value)
-	c.CmdClause.Flag("address", "A hostname, IPv4, or IPv6 address for the backend").Action(c.Address.Set).StringVar(&c.Address.Value)
-	c.CmdClause.Flag("port", "Port number of the address").Action(c.Port.Set).UintVar(&c.Port.Value)
-	c.CmdClause.Flag("override-host", "The hostname to override the Host header").Action(c.OverrideHost.Set).StringVar(&c.OverrideHost.Value)
+	c.CmdClause.Flag("address", "A hostname, IPv4, or IPv6 address for the backend").Action(c.Address.Set).Action(cmd.Validate(cmd.ValidateAddress)).StringVar(&c.Address.Value)
+	c.CmdClause.Flag("port", "Port number of the address").Action(c.Port.Set).Action(cmd.Validate(cmd.ValidatePort)).UintVar(&c.Port.Value)
+	c.CmdClause.Flag("override-host", "The hostname to override the Host header").Action(c.OverrideHost.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.OverrideHost.Value)
 	c.CmdClause.Flag("connect-timeout", "How long to wait for a timeout in milliseconds").Action(c.ConnectTimeout.Set).UintVar(&c.ConnectTimeout.Value)
 	c.CmdClause.Flag("max-conn", "Maximum number of connections").Action(c.MaxConn.Set).UintVar(&c.MaxConn.Value)
 	c.CmdClause.Flag("first-byte-timeout", "How long to wait for the first bytes in milliseconds").Action(c.FirstByteTimeout.Set).UintVar(&c.MaxConn.Value)
@@ -95,8 +95,8 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("ssl-ca-cert", "CA certificate attached to origin").Action(c.SSLCACert.Set).StringVar(&c.SSLCACert.Value)
 	c.CmdClause.Flag("ssl-client-cert", "Client certificate attached to origin").Action(c.SSLClientCert.Set).StringVar(&c.SSLClientCert.Value)
 	c.CmdClause.Flag("ssl-client-key", "Client key attached to origin").Action(c.SSLClientKey.Set).StringVar(&c.SSLClientKey.Value)
-	c.CmdClause.Flag("ssl-cert-hostname", "Overrides ssl_hostname, but only for cert verification. Does not affect SNI at all.").Action(c.SSLCertHostname.Set).StringVar(&c.SSLCertHostname.Value)
-	c.CmdClause.Flag("ssl-sni-hostname", "Overrides ssl_hostname, but only for SNI in the handshake. Does not affect cert validation at all.").Action(c.SSLSNIHostname.Set).StringVar(&c.SSLSNIHostname.Value)
+	c.CmdClause.Flag("ssl-cert-hostname", "Overrides ssl_hostname, but only for cert verification. Does not affect SNI at all.").Action(c.SSLCertHostname.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.SSLCertHostname.Value)
+	c.CmdClause.Flag("ssl-sni-hostname", "Overrides ssl_hostname, but only for SNI in the handshake. Does not affect cert validation at all.").Action(c.SSLSNIHostname.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.SSLSNIHostname.Value)
 	c.CmdClause.Flag("min-tls-version", "Minimum allowed TLS version on SSL connections to this backend").Action(c.MinTLSVersion.Set).StringVar(&c.MinTLSVersion.Value)
 	c.CmdClause.Flag("max-tls-version", "Maximum allowed TLS version on SSL connections to this backend").Action(c.MaxTLSVersion.Set).StringVar(&c.MaxTLSVersion.Value)
 	c.CmdClause.Flag("ssl-ciphers", "Colon delimited list of OpenSSL ciphers (see https://www.openssl.org/docs/man1.0.2/man1/ciphers for details)").Action(c.SSLCiphers.Set).StringVar(&c.SSLCiphers.Value)
@@ -105,9 +105,14 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 
 // Exec invokes the application logic for the command.
 func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := cmd.CheckFlagRules(c.flagRules()...); err != nil {
+		return err
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -193,15 +198,30 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	if c.SSLCACert.WasSet {
-		input.SSLCACert = fastly.String(c.SSLCACert.Value)
+		content, err := cmd.Content(c.SSLCACert.Value, c.Globals.Stdin)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		input.SSLCACert = fastly.String(content)
 	}
 
 	if c.SSLClientCert.WasSet {
-		input.SSLClientCert = fastly.String(c.SSLClientCert.Value)
+		content, err := cmd.Content(c.SSLClientCert.Value, c.Globals.Stdin)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		input.SSLClientCert = fastly.String(content)
 	}
 
 	if c.SSLClientKey.WasSet {
-		input.SSLClientKey = fastly.String(c.SSLClientKey.Value)
+		content, err := cmd.Content(c.SSLClientKey.Value, c.Globals.Stdin)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		input.SSLClientKey = fastly.String(content)
 	}
 
 	if c.SSLCertHostname.WasSet {
@@ -236,3 +256,29 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	text.Success(out, "Updated backend %s (service %s version %d)", b.Name, b.ServiceID, b.ServiceVersion)
 	return nil
 }
+
+// flagRules returns the rules between the command's flags. The SSL flags only
+// conflict with --use-ssl if it's set to false, as the backend may already use
+// SSL.
+func (c *UpdateCommand) flagRules() []cmd.FlagRule {
+	rules := []cmd.FlagRule{
+		cmd.FlagRequires("use-ssl", c.UseSSL.Value && c.Port.WasSet, c.Port.Value != 80, "a TLS port such as 443, not --port 80"),
+	}
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"ssl-check-cert", c.SSLCheckCert.WasSet && c.SSLCheckCert.Value},
+		{"ssl-ca-cert", c.SSLCACert.WasSet},
+		{"ssl-client-cert", c.SSLClientCert.WasSet},
+		{"ssl-client-key", c.SSLClientKey.WasSet},
+		{"ssl-cert-hostname", c.SSLCertHostname.WasSet},
+		{"ssl-sni-hostname", c.SSLSNIHostname.WasSet},
+		{"min-tls-version", c.MinTLSVersion.WasSet},
+		{"max-tls-version", c.MaxTLSVersion.WasSet},
+		{"ssl-ciphers", c.SSLCiphers.WasSet},
+	} {
+		rules = append(rules, cmd.FlagRequires(f.name, f.set && c.UseSSL.WasSet, c.UseSSL.Value, "--use-ssl"))
+	}
+	return rules
+}
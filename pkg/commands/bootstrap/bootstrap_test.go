@@ -0,0 +1,150 @@
+package bootstrap_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestInit(t *testing.T) {
+	var deleted bool
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		WantExport  string
+		WantDeleted bool
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --backend is required when not prompting",
+				Args:      args("init --type vcl --name test --non-interactive"),
+				WantError: "a backend is required to create a VCL service without prompting",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate a VCL service is created and exported",
+				Args: args("init --type vcl --name test --domain www.example.com --backend example.com --backend-port 443 --accept-defaults"),
+				API: mock.API{
+					CreateServiceFn:   createServiceOK,
+					CreateDomainFn:    createDomainOK,
+					CreateBackendFn:   createBackendOK,
+					ActivateVersionFn: activateVersionOK,
+					ListDomainsFn:     listDomainsOK,
+					ListBackendsFn:    listBackendsOK,
+				},
+				WantOutput: "Created service 123 (described in service.yaml)",
+			},
+			WantExport: `service_id: "123"
+name: test
+type: vcl
+version: 1
+active: true
+domains:
+- www.example.com
+backends:
+- name: origin
+  address: example.com
+  port: 443
+`,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate --skip-activation leaves the service inactive",
+				Args: args("init --type vcl --name test --domain www.example.com --backend example.com --skip-activation --export test.yaml --accept-defaults"),
+				API: mock.API{
+					CreateServiceFn: createServiceOK,
+					CreateDomainFn:  createDomainOK,
+					CreateBackendFn: createBackendOK,
+					ListDomainsFn:   listDomainsOK,
+					ListBackendsFn:  listBackendsOK,
+				},
+				WantOutput: "fastly service-version activate --service-id 123 --version 1",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate the service is deleted if it can't be set up",
+				Args: args("init --type vcl --name test --backend example.com --accept-defaults"),
+				API: mock.API{
+					CreateServiceFn: createServiceOK,
+					CreateDomainFn:  createDomainOK,
+					CreateBackendFn: createBackendError,
+					DeleteServiceFn: func(i *fastly.DeleteServiceInput) error {
+						deleted = i.ID == "123"
+						return nil
+					},
+				},
+				WantError: "error creating backend: " + errTest.Error(),
+			},
+			WantDeleted: true,
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			deleted = false
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err = app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			testutil.AssertBool(t, testcase.WantDeleted, deleted)
+
+			if testcase.WantExport != "" {
+				data, err := os.ReadFile(filepath.Join(dir, "service.yaml"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				testutil.AssertString(t, testcase.WantExport, string(data))
+			}
+		})
+	}
+}
+
+var errTest = errors.New("fixture error")
+
+func createServiceOK(i *fastly.CreateServiceInput) (*fastly.Service, error) {
+	return &fastly.Service{ID: "123", Name: i.Name, Type: i.Type}, nil
+}
+
+func createDomainOK(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
+	return &fastly.Domain{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: i.Name}, nil
+}
+
+func createBackendOK(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
+	return &fastly.Backend{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: i.Name}, nil
+}
+
+func createBackendError(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
+	return nil, errTest
+}
+
+func activateVersionOK(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Active: true}, nil
+}
+
+func listDomainsOK(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	return []*fastly.Domain{{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: "www.example.com"}}, nil
+}
+
+func listBackendsOK(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	return []*fastly.Backend{{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: "origin", Address: "example.com", Port: 443}}, nil
+}
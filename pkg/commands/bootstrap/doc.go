@@ -0,0 +1,3 @@
+// Package bootstrap contains the 'fastly init' command, which guides a new
+// user through creating their first Fastly service.
+package bootstrap
@@ -0,0 +1,364 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/commands/compute/setup"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/undo"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"gopkg.in/yaml.v2"
+)
+
+// The types of service that can be initialized.
+const (
+	TypeVCL     = "vcl"
+	TypeCompute = "compute"
+)
+
+// Types are the valid values of the --type flag.
+var Types = []string{TypeVCL, TypeCompute}
+
+// vclTopLevelDomain is the domain that the default domain of a VCL service is
+// a subdomain of, which is served by Fastly without further DNS setup.
+const vclTopLevelDomain = "global.ssl.fastly.net"
+
+// projectFiles are the files that indicate the current directory contains a
+// Compute@Edge project (or the start of one).
+var projectFiles = []string{manifest.Filename, "Cargo.toml", "package.json", "asconfig.json"}
+
+// RootCommand is the 'fastly init' command, which creates a VCL service or a
+// Compute@Edge project. It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+
+	backend        string
+	backendPort    uint
+	computeInit    *compute.InitCommand
+	domain         string
+	export         string
+	name           string
+	serviceType    string
+	skipActivation bool
+}
+
+// NewRootCommand returns a new command registered in the parent.
+//
+// Compute@Edge services are initialized by the computeInit command, as they're
+// created when their package is first deployed.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data, computeInit *compute.InitCommand) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.computeInit = computeInit
+	c.CmdClause = parent.Command("init", "Create a new Fastly service, guided by prompts: a VCL service with its domain and backend, or a Compute@Edge project")
+	c.CmdClause.Flag("backend", "Hostname or IP address of the VCL service's backend").StringVar(&c.backend)
+	c.CmdClause.Flag("backend-port", "Port number of the VCL service's backend").Default("80").UintVar(&c.backendPort)
+	c.CmdClause.Flag("domain", "Domain of the VCL service (defaults to a subdomain of "+vclTopLevelDomain+")").StringVar(&c.domain)
+	c.CmdClause.Flag("export", "Path of the YAML file that describes the new VCL service").Default("service.yaml").StringVar(&c.export)
+	c.CmdClause.Flag("name", "Name of the VCL service (defaults to the name of the current directory)").StringVar(&c.name)
+	c.CmdClause.Flag("skip-activation", "Leave the new VCL service's version inactive").BoolVar(&c.skipActivation)
+	c.CmdClause.Flag("type", "Type of service to create: vcl or compute (prompts if not set, suggesting compute if the current directory contains a Compute@Edge project)").HintOptions(Types...).EnumVar(&c.serviceType, Types...)
+	return &c
+}
+
+// Export describes a VCL service created by the command, as written to the
+// --export file.
+type Export struct {
+	ServiceID string          `yaml:"service_id"`
+	Name      string          `yaml:"name"`
+	Type      string          `yaml:"type"`
+	Version   int             `yaml:"version"`
+	Active    bool            `yaml:"active"`
+	Domains   []string        `yaml:"domains"`
+	Backends  []ExportBackend `yaml:"backends"`
+}
+
+// ExportBackend describes a backend of an exported service.
+type ExportBackend struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	Port    uint   `yaml:"port"`
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceType, err := c.chooseType(in, out)
+	if err != nil {
+		return err
+	}
+	if serviceType == TypeCompute {
+		return c.computeInit.Exec(in, out)
+	}
+	return c.initVCL(in, out)
+}
+
+// chooseType returns the type of service to create, which is given by the
+// --type flag or otherwise chosen by the user. The type suggested by the
+// contents of the current directory is used when not prompting.
+func (c *RootCommand) chooseType(in io.Reader, out io.Writer) (string, error) {
+	if c.serviceType != "" {
+		return c.serviceType, nil
+	}
+
+	detected := TypeVCL
+	for _, f := range projectFiles {
+		if _, err := os.Stat(f); err == nil {
+			detected = TypeCompute
+			break
+		}
+	}
+	if c.Globals.Flag.AcceptDefaults {
+		return detected, nil
+	}
+
+	def := 1
+	if detected == TypeCompute {
+		def = 2
+	}
+	text.Break(out)
+	text.Output(out, "%s", text.Bold("Service type:"))
+	text.Output(out, "[1] VCL (cache and configure traffic to your own origin servers)")
+	text.Output(out, "[2] Compute@Edge (run your own code, compiled to WebAssembly, at the edge)")
+	option, err := text.Input(out, fmt.Sprintf("Choose option: [%d] ", def), in, validateTypeOption)
+	if err != nil {
+		return "", fmt.Errorf("error reading input: %w", err)
+	}
+	if option == "" {
+		option = strconv.Itoa(def)
+	}
+	if option == "2" {
+		return TypeCompute, nil
+	}
+	return TypeVCL, nil
+}
+
+// validateTypeOption ensures the user selects one of the service types.
+func validateTypeOption(input string) error {
+	if input == "" || input == "1" || input == "2" {
+		return nil
+	}
+	return fmt.Errorf("must be a valid option")
+}
+
+// validateBackend ensures the user enters a backend.
+func validateBackend(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return fmt.Errorf("a backend is required")
+	}
+	return nil
+}
+
+// initVCL creates a VCL service with a domain and a backend, activates it
+// and describes it in the --export file.
+func (c *RootCommand) initVCL(in io.Reader, out io.Writer) (err error) {
+	name := c.name
+	if name == "" {
+		name = defaultName()
+		if !c.Globals.Flag.AcceptDefaults {
+			input, err := text.Input(out, text.BoldYellow(fmt.Sprintf("Service name: [%s] ", name)), in)
+			if err != nil {
+				return fmt.Errorf("error reading input: %w", err)
+			}
+			if input != "" {
+				name = input
+			}
+		}
+	}
+
+	// A VCL service needs a real backend, rather than the 'originless' backend
+	// a Compute@Edge service defaults to.
+	backend := c.backend
+	if backend == "" {
+		if c.Globals.Flag.AcceptDefaults {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("a backend is required to create a VCL service without prompting"),
+				Remediation: "Provide the hostname or IP address of the service's backend with the --backend flag.",
+			}
+		}
+		input, err := text.Input(out, text.BoldYellow("Backend (hostname or IP address): "), in, validateBackend)
+		if err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		backend = input
+	}
+
+	domains := &setup.Domains{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flag.AcceptDefaults,
+		Clock:          c.Globals.Clock,
+		PackageDomain:  c.domain,
+		Stdin:          in,
+		Stdout:         out,
+		TopLevelDomain: vclTopLevelDomain,
+	}
+	// The backend has already been chosen, so setup.Backends isn't allowed to
+	// prompt for it.
+	backends := &setup.Backends{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: true,
+		Setup: map[string]*manifest.SetupBackend{
+			"origin": {Address: backend, Port: c.backendPort},
+		},
+		Stdin:  in,
+		Stdout: out,
+	}
+	if err := domains.Configure(); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error configuring service domains: %w", err)
+	}
+	if err := backends.Configure(); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error configuring service backends: %w", err)
+	}
+	text.Break(out)
+
+	progress := text.ResetProgress(out, c.Globals.Verbose())
+	undoStack := undo.NewStack()
+	defer func() {
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service name": name,
+			})
+			progress.Fail()
+		}
+		undoStack.RunIfError(out, err)
+	}()
+
+	progress.Step("Creating service...")
+	service, err := c.Globals.APIClient.CreateService(&fastly.CreateServiceInput{
+		Name: name,
+		Type: TypeVCL,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating service: %w", err)
+	}
+	undoStack.Push(func() error {
+		return c.Globals.APIClient.DeleteService(&fastly.DeleteServiceInput{ID: service.ID})
+	})
+
+	// A new service has an editable first version.
+	version := 1
+	domains.Progress, domains.ServiceID, domains.ServiceVersion = progress, service.ID, version
+	if err := domains.Create(); err != nil {
+		return err
+	}
+	backends.Progress, backends.ServiceID, backends.ServiceVersion = progress, service.ID, version
+	if err := backends.Create(); err != nil {
+		return err
+	}
+
+	if !c.skipActivation {
+		progress.Step(fmt.Sprintf("Activating version %d...", version))
+		_, err := c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+			ServiceID:      service.ID,
+			ServiceVersion: version,
+		})
+		if err != nil {
+			return fmt.Errorf("error activating version: %w", err)
+		}
+	}
+
+	progress.Step(fmt.Sprintf("Writing %s...", c.export))
+	export, err := c.describe(service.ID, name, version)
+	if err != nil {
+		return err
+	}
+	if err := writeExport(c.export, export); err != nil {
+		return err
+	}
+	progress.Done()
+
+	c.printNextSteps(out, export)
+	return nil
+}
+
+// describe returns the Export of the service version, as created.
+func (c *RootCommand) describe(serviceID, name string, version int) (Export, error) {
+	export := Export{
+		ServiceID: serviceID,
+		Name:      name,
+		Type:      TypeVCL,
+		Version:   version,
+		Active:    !c.skipActivation,
+	}
+
+	domains, err := c.Globals.APIClient.ListDomains(&fastly.ListDomainsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return export, fmt.Errorf("error listing domains: %w", err)
+	}
+	for _, d := range domains {
+		export.Domains = append(export.Domains, d.Name)
+	}
+
+	backends, err := c.Globals.APIClient.ListBackends(&fastly.ListBackendsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return export, fmt.Errorf("error listing backends: %w", err)
+	}
+	for _, b := range backends {
+		export.Backends = append(export.Backends, ExportBackend{Name: b.Name, Address: b.Address, Port: b.Port})
+	}
+	return export, nil
+}
+
+// writeExport writes the export to the YAML file at path.
+func writeExport(path string, export Export) error {
+	data, err := yaml.Marshal(export)
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil { // #nosec G306 -- the file doesn't contain secrets
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// printNextSteps displays how to reach and continue configuring the service.
+func (c *RootCommand) printNextSteps(out io.Writer, export Export) {
+	text.Break(out)
+	if len(export.Domains) > 0 {
+		if export.Active {
+			text.Description(out, "View the service at", "https://"+export.Domains[0])
+		}
+		if !isFastlySubdomain(export.Domains[0]) {
+			text.Description(out, "Point the DNS of your domain at Fastly, as described at", "https://docs.fastly.com/en/guides/working-with-domains")
+		}
+	}
+	if !export.Active {
+		text.Description(out, "To activate the service, run", fmt.Sprintf("fastly service-version activate --service-id %s --version %d", export.ServiceID, export.Version))
+	}
+	text.Description(out, "To customize how requests are handled with VCL, run", fmt.Sprintf("fastly vcl snippet create --service-id %s --version latest --autoclone --name <name> --type recv --content <file>", export.ServiceID))
+	text.Description(out, "To view the service's configuration, run", fmt.Sprintf("fastly service describe --service-id %s", export.ServiceID))
+	text.Success(out, "Created service %s (described in %s)", text.Bold(export.ServiceID), c.export)
+}
+
+// defaultName returns the name of the current directory, or a generic name if
+// it can't be determined.
+func defaultName() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "my-service"
+	}
+	return filepath.Base(wd)
+}
+
+// isFastlySubdomain indicates whether domain is served by Fastly without
+// further DNS setup.
+func isFastlySubdomain(domain string) bool {
+	return strings.HasSuffix(domain, "."+vclTopLevelDomain)
+}
@@ -0,0 +1,150 @@
+package catalog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/catalog"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestCommands(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:       "validate list",
+			Args:       args("commands"),
+			WantOutput: "compute validate",
+		},
+		{
+			Name:       "validate nested subcommands are listed",
+			Args:       args("commands"),
+			WantOutput: "logging kafka create",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestCommandsJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("commands --json"), &stdout)
+	if err := app.Run(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var c catalog.Catalog
+	if err := json.Unmarshal(stdout.Bytes(), &c); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "fastly", c.Name)
+
+	validate := find(c.Commands, "compute validate")
+	if validate == nil {
+		t.Fatal("expected to find the 'compute validate' command")
+	}
+
+	pkg := flag(validate.Flags, "package")
+	if pkg == nil {
+		t.Fatal("expected to find the --package flag")
+	}
+	testutil.AssertString(t, "string", pkg.Type)
+	testutil.AssertString(t, "p", pkg.Short)
+	testutil.AssertBool(t, true, pkg.Required)
+
+	report := flag(validate.Flags, "report")
+	if report == nil {
+		t.Fatal("expected to find the --report flag")
+	}
+	testutil.AssertString(t, "enum", report.Type)
+	testutil.AssertEqual(t, []string{"junit", "sarif"}, report.Options)
+
+	direction := flag(find(c.Commands, "dictionary-item list").Flags, "direction")
+	testutil.AssertEqual(t, []string{"ascend"}, direction.Default)
+
+	scope := flag(find(c.Commands, "auth-token create").Flags, "scope")
+	testutil.AssertBool(t, true, scope.Repeatable)
+
+	if find(c.Commands, "shellcomplete") != nil {
+		t.Fatal("expected hidden commands to be omitted")
+	}
+}
+
+// TestCommandsKingpinValues checks the types and options derived from each
+// kind of kingpin value, as kingpin doesn't expose them and so they're read
+// from its unexported implementations (which an upgrade could change).
+func TestCommandsKingpinValues(t *testing.T) {
+	a := kingpin.New("test", "")
+	c := catalog.NewRootCommand(a, &config.Data{})
+	cl := a.Command("values", "")
+	cl.Flag("string", "").String()
+	cl.Flag("strings", "").Strings()
+	cl.Flag("bool", "").Bool()
+	cl.Flag("int", "").Int()
+	cl.Flag("duration", "").Duration()
+	cl.Flag("enum", "").Enum("a", "b")
+	cl.Flag("enums", "").Enums("c", "d")
+	cl.Arg("arg", "").Enum("e", "f")
+
+	if _, err := a.Parse([]string{"commands", "--json"}); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	if err := c.Exec(nil, &stdout); err != nil {
+		t.Fatal(err)
+	}
+	var cat catalog.Catalog
+	if err := json.Unmarshal(stdout.Bytes(), &cat); err != nil {
+		t.Fatal(err)
+	}
+
+	values := find(cat.Commands, "values")
+	for name, want := range map[string]string{
+		"string":   "string",
+		"strings":  "list",
+		"bool":     "bool",
+		"int":      "int",
+		"duration": "duration",
+		"enum":     "enum",
+		"enums":    "enum",
+	} {
+		testutil.AssertString(t, want, flag(values.Flags, name).Type)
+	}
+	testutil.AssertEqual(t, []string{"a", "b"}, flag(values.Flags, "enum").Options)
+	testutil.AssertEqual(t, []string{"c", "d"}, flag(values.Flags, "enums").Options)
+	testutil.AssertString(t, "enum", values.Args[0].Type)
+	testutil.AssertEqual(t, []string{"e", "f"}, values.Args[0].Options)
+}
+
+func find(cs []catalog.Command, name string) *catalog.Command {
+	for i := range cs {
+		if cs[i].FullCommand == name {
+			return &cs[i]
+		}
+		if c := find(cs[i].Commands, name); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+func flag(fs []catalog.Flag, name string) *catalog.Flag {
+	for i := range fs {
+		if fs[i].Name == name {
+			return &fs[i]
+		}
+	}
+	return nil
+}
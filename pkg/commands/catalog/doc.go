@@ -0,0 +1,4 @@
+// Package catalog contains a command that describes the full CLI command tree
+// in a machine-readable format, for use by documentation generators and custom
+// shell completion engines.
+package catalog
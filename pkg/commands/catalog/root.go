@@ -0,0 +1,240 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/kingpin"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	app  *kingpin.Application
+	json bool
+}
+
+// NewRootCommand returns a new command registered in the parent.
+//
+// NOTE: Unlike other commands, the parent must be the application itself, as
+// the command needs access to the application model in order to describe it.
+func NewRootCommand(app *kingpin.Application, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.app = app
+	c.CmdClause = app.Command("commands", "List all available commands")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: "Render the full command tree, including flags and arguments, as JSON",
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	m := c.app.Model()
+	cmds := commands(m.Commands)
+
+	if c.json {
+		data, err := json.MarshalIndent(Catalog{
+			Name:        m.Name,
+			Description: m.Help,
+			Version:     revision.AppVersion,
+			GlobalFlags: flags(m.Flags),
+			Commands:    cmds,
+		}, "", "  ")
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("COMMAND", "DESCRIPTION")
+	var walk func(cs []Command)
+	walk = func(cs []Command) {
+		for _, cm := range cs {
+			t.AddLine(cm.FullCommand, cm.Description)
+			walk(cm.Commands)
+		}
+	}
+	walk(cmds)
+	t.Print()
+	return nil
+}
+
+// Catalog describes the CLI application.
+type Catalog struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Version     string    `json:"version"`
+	GlobalFlags []Flag    `json:"global_flags"`
+	Commands    []Command `json:"commands"`
+}
+
+// Command describes a command and its subcommands.
+type Command struct {
+	Name        string    `json:"name"`
+	FullCommand string    `json:"full_command"`
+	Description string    `json:"description"`
+	Flags       []Flag    `json:"flags"`
+	Args        []Arg     `json:"args"`
+	Commands    []Command `json:"commands"`
+}
+
+// Flag describes a command flag.
+type Flag struct {
+	Name        string   `json:"name"`
+	Short       string   `json:"short,omitempty"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Placeholder string   `json:"placeholder,omitempty"`
+	Default     []string `json:"default"`
+	Options     []string `json:"options,omitempty"`
+	Envar       string   `json:"envar,omitempty"`
+	Required    bool     `json:"required"`
+	Repeatable  bool     `json:"repeatable"`
+}
+
+// Arg describes a positional command argument.
+type Arg struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Default     []string `json:"default"`
+	Options     []string `json:"options,omitempty"`
+	Required    bool     `json:"required"`
+	Repeatable  bool     `json:"repeatable"`
+}
+
+// commands converts the kingpin command models, excluding hidden commands.
+func commands(models []*kingpin.CmdModel) []Command {
+	cmds := []Command{}
+	for _, m := range models {
+		if m.Hidden {
+			continue
+		}
+		cmds = append(cmds, Command{
+			Name:        m.Name,
+			FullCommand: m.FullCommand(),
+			Description: m.Help,
+			Flags:       flags(m.Flags),
+			Args:        args(m.Args),
+			Commands:    commands(m.Commands),
+		})
+	}
+	return cmds
+}
+
+// flags converts the kingpin flag models, excluding hidden flags.
+func flags(models []*kingpin.ClauseModel) []Flag {
+	fs := []Flag{}
+	for _, m := range models {
+		if m.Hidden {
+			continue
+		}
+		f := Flag{
+			Name:        m.Name,
+			Description: m.Help,
+			Type:        valueType(m.Value),
+			Placeholder: m.PlaceHolder,
+			Default:     defaults(m.Default),
+			Options:     valueOptions(m.Value),
+			Envar:       m.Envar,
+			Required:    m.Required,
+			Repeatable:  m.Cumulative,
+		}
+		if m.Short > 0 {
+			f.Short = string(m.Short)
+		}
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+// args converts the kingpin argument models.
+func args(models []*kingpin.ClauseModel) []Arg {
+	as := []Arg{}
+	for _, m := range models {
+		if m.Hidden {
+			continue
+		}
+		as = append(as, Arg{
+			Name:        m.Name,
+			Description: m.Help,
+			Type:        valueType(m.Value),
+			Default:     defaults(m.Default),
+			Options:     valueOptions(m.Value),
+			Required:    m.Required,
+			Repeatable:  m.Cumulative,
+		})
+	}
+	return as
+}
+
+func defaults(d []string) []string {
+	if d == nil {
+		return []string{}
+	}
+	return d
+}
+
+// valueType derives a type name from the kingpin value implementation.
+//
+// NOTE: kingpin doesn't expose the type of a flag in its model, only the
+// (unexported) flag.Value implementation, whose type names follow the pattern
+// <type>Value (e.g. stringValue, enumValue, durationValue). Repeatable flags
+// (e.g. Strings()) are implemented by an accumulator. TestCommandsKingpinValues
+// fails if an upgrade of kingpin changes them.
+func valueType(v kingpin.Value) string {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return "unknown"
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := t.Name()
+	switch name {
+	case "accumulator":
+		return "list"
+	case "enumsValue":
+		return "enum"
+	}
+	if !strings.HasSuffix(name, "Value") || name == "Value" {
+		return "custom"
+	}
+	return strings.ToLower(strings.TrimSuffix(name, "Value"))
+}
+
+// valueOptions returns the permitted values for enum flags.
+//
+// NOTE: As with valueType, the options are not exposed by kingpin and so have
+// to be read from the unexported field of its enum value implementations.
+func valueOptions(v kingpin.Value) []string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := rv.Elem().FieldByName("options")
+	if !field.IsValid() || field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+		return nil
+	}
+	options := make([]string, field.Len())
+	for i := range options {
+		options[i] = field.Index(i).String()
+	}
+	return options
+}
@@ -0,0 +1,42 @@
+package completion_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestCompletion(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "bash",
+			Args: args("completion bash"),
+			WantOutputs: []string{
+				`opts=$( "${COMP_WORDS[0]}" --completion-bash "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null )`,
+				"complete -o default -F _fastly_completion fastly",
+			},
+		},
+		{
+			Name:        "zsh",
+			Args:        args("completion zsh"),
+			WantOutputs: []string{"#compdef fastly", "--completion-bash ${words[2,CURRENT]}", "compdef _fastly fastly"},
+		},
+		{
+			Name:        "fish",
+			Args:        args("completion fish"),
+			WantOutputs: []string{"fastly --completion-bash $args (commandline -ct)", "complete -c fastly -f -a '(__fastly_complete)'"},
+		},
+		{
+			Name:        "powershell",
+			Args:        args("completion powershell"),
+			WantOutputs: []string{"Register-ArgumentCompleter -Native -CommandName fastly", "fastly --completion-bash @words"},
+		},
+		{
+			Name:      "unknown shell",
+			Args:      args("completion tcsh"),
+			WantError: "error parsing arguments: enum value must be one of bash,zsh,fish,powershell, got 'tcsh'",
+		},
+	}
+	testutil.RunScenarios(t, scenarios)
+}
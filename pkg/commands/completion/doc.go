@@ -0,0 +1,3 @@
+// Package completion contains the command that outputs shell completion
+// scripts for the Fastly CLI.
+package completion
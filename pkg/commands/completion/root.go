@@ -0,0 +1,96 @@
+package completion
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+)
+
+// Shells are the shells that completion scripts can be generated for.
+var Shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	shell string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("completion", "Output a shell completion script for the Fastly CLI, e.g. 'source <(fastly completion bash)'")
+	c.CmdClause.Arg("shell", "The shell to output the script for: bash, zsh, fish or powershell").Required().HintOptions(Shells...).EnumVar(&c.shell, Shells...)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	_, err := fmt.Fprint(out, scripts[c.shell])
+	return err
+}
+
+// The scripts ask the CLI itself for the completions of the command line
+// (i.e. `fastly --completion-bash <args>`), so they cover every command, flag
+// and enum value (e.g. the `vcl snippet create --type` locations) without
+// being regenerated when the CLI is updated.
+var scripts = map[string]string{
+	"bash": `# fastly bash completion. Add to ~/.bashrc:
+#
+#   source <(fastly completion bash)
+
+_fastly_completion() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    local opts
+    opts=$( "${COMP_WORDS[0]}" --completion-bash "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null )
+    COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )
+    return 0
+}
+complete -o default -F _fastly_completion fastly
+`,
+	"zsh": `#compdef fastly
+# fastly zsh completion. Add to ~/.zshrc:
+#
+#   source <(fastly completion zsh)
+
+_fastly() {
+    local -a opts
+    opts=( ${(f)"$( ${words[1]} --completion-bash ${words[2,CURRENT]} 2>/dev/null )"} )
+    if (( ${#opts} )); then
+        compadd -- $opts
+    else
+        _files
+    fi
+}
+compdef _fastly fastly
+`,
+	"fish": `# fastly fish completion. Save to ~/.config/fish/completions/fastly.fish:
+#
+#   fastly completion fish > ~/.config/fish/completions/fastly.fish
+
+function __fastly_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    fastly --completion-bash $args (commandline -ct) 2>/dev/null
+end
+complete -c fastly -f -a '(__fastly_complete)'
+`,
+	"powershell": `# fastly PowerShell completion. Add to your $PROFILE:
+#
+#   fastly completion powershell | Out-String | Invoke-Expression
+
+Register-ArgumentCompleter -Native -CommandName fastly -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -eq '') {
+        $words += '""'
+    }
+    fastly --completion-bash @words 2>$null | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`,
+}
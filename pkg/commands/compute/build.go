@@ -43,6 +43,8 @@ type Flags struct {
 	IncludeSrc       bool
 	Lang             string
 	PackageName      string
+	SBOM             string
+	SBOMFormat       string
 	SkipVerification bool
 	Timeout          int
 }
@@ -69,6 +71,8 @@ func NewBuildCommand(parent cmd.Registerer, globals *config.Data, data manifest.
 	c.CmdClause.Flag("include-source", "Include source code in built package").BoolVar(&c.Flags.IncludeSrc)
 	c.CmdClause.Flag("language", "Language type").StringVar(&c.Flags.Lang)
 	c.CmdClause.Flag("name", "Package name").StringVar(&c.Flags.PackageName)
+	c.CmdClause.Flag("sbom", "Also write the package's software bill of materials to this file").StringVar(&c.Flags.SBOM)
+	c.CmdClause.Flag("sbom-format", "Format of the software bill of materials embedded in the package").Default(SBOMFormatCycloneDX).HintOptions(SBOMFormats...).EnumVar(&c.Flags.SBOMFormat, SBOMFormats...)
 	c.CmdClause.Flag("skip-verification", "Skip verification steps and force build").BoolVar(&c.Flags.SkipVerification)
 	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the build compilation step").IntVar(&c.Flags.Timeout)
 
@@ -181,7 +185,7 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	progress.Done()
 
 	if toolchain == "custom" {
-		if !c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive {
+		if !cmd.Bypass(cmd.ConfirmInformational, c.Globals) {
 			// NOTE: A third-party could share a project with a build command for a
 			// language that wouldn't normally require one (e.g. Rust), and do evil
 			// things. So we should notify the user and confirm they would like to
@@ -201,7 +205,7 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	progress.Step(fmt.Sprintf("Building package using %s toolchain...", toolchain))
 
 	postBuildCallback := func() error {
-		if !c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive {
+		if !cmd.Bypass(cmd.ConfirmInformational, c.Globals) {
 			err := promptForBuildContinue(CustomPostBuildScriptMessage, c.Manifest.File.Scripts.PostBuild, out, in, c.Globals.Verbose())
 			if err != nil {
 				return err
@@ -246,6 +250,39 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 	files = append(files, binFiles...)
 
+	// The SBOM is written alongside the other build artifacts so that it's
+	// embedded in the package.
+	//
+	// NOTE: the format defaults to CycloneDX as the composite commands don't
+	// always set it.
+	format := c.Flags.SBOMFormat
+	if format == "" {
+		format = SBOMFormatCycloneDX
+	}
+	sbom := filepath.Join("bin", SBOMFilenames[format])
+	paths := []string{sbom}
+	if c.Flags.SBOM != "" {
+		paths = append(paths, c.Flags.SBOM)
+	}
+	err = WriteSBOM(c.Globals.Clock, name, language.Name, format, paths...)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Language": language.Name,
+			"Paths":    paths,
+		})
+		return err
+	}
+	skip := ignoreFiles[sbom]
+	for _, f := range binFiles {
+		if f == sbom {
+			skip = true // included by a previous build
+			break
+		}
+	}
+	if !skip {
+		files = append(files, sbom)
+	}
+
 	if c.Flags.IncludeSrc {
 		srcFiles, err := GetNonIgnoredFiles(language.SourceDirectory, ignoreFiles)
 		if err != nil {
@@ -270,6 +307,9 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	progress.Done()
 
 	text.Success(out, "Built package '%s' (%s)", name, dest)
+	if c.Flags.SBOM != "" {
+		text.Info(out, "Wrote the software bill of materials to %s", c.Flags.SBOM)
+	}
 	return nil
 }
 
@@ -37,6 +37,17 @@ func createBackendOK(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
 	}, nil
 }
 
+func createHTTPSOK(i *fastly.CreateHTTPSInput) (*fastly.HTTPS, error) {
+	if i.Name != "my_logs" || i.URL != "https://example.com/logs" || i.Method != "PUT" {
+		return nil, fmt.Errorf("unexpected input: %+v", i)
+	}
+	return &fastly.HTTPS{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+	}, nil
+}
+
 func createDictionaryOK(i *fastly.CreateDictionaryInput) (*fastly.Dictionary, error) {
 	return &fastly.Dictionary{
 		ServiceID:      i.ServiceID,
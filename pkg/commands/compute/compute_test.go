@@ -1,6 +1,8 @@
 package compute_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/clock"
 	"github.com/fastly/cli/pkg/commands/compute"
 	"github.com/fastly/cli/pkg/commands/update"
 	"github.com/fastly/cli/pkg/config"
@@ -186,6 +189,192 @@ func TestCreatePackageArchive(t *testing.T) {
 	testutil.AssertEqual(t, wantFiles, files)
 }
 
+func TestSBOM(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{Src: filepath.Join("testdata", "build", "rust", "Cargo.lock"), Dst: "Cargo.lock"},
+		},
+		Write: []testutil.FileIO{
+			{Src: `{
+				"lockfileVersion": 2,
+				"packages": {
+					"": {"name": "app"},
+					"node_modules/@fastly/js-compute": {"version": "0.2.1", "integrity": "sha512-3q2+7w=="},
+					"node_modules/webpack": {"version": "5.64.0", "dev": true}
+				}
+			}`, Dst: "package-lock.json"},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	t.Run("rust", func(t *testing.T) {
+		s, err := compute.NewSBOM(nil, "app", "rust")
+		testutil.AssertNoError(t, err)
+
+		// The package being built has no source, so isn't a dependency.
+		testutil.AssertEqual(t, 39, len(s.Components))
+		testutil.AssertEqual(t, compute.SBOMComponent{
+			Name:      "anyhow",
+			Version:   "1.0.38",
+			PURL:      "pkg:cargo/anyhow@1.0.38",
+			Algorithm: "SHA-256",
+			Hash:      "afddf7f520a80dbf76e6f50a35bca42a2331ef227a28b3b6dc5c2e2338d114b1",
+		}, s.Components[0])
+		testutil.AssertEqual(t, "fastly", s.Tools[0].Name)
+
+		data, err := s.Encode(compute.SBOMFormatCycloneDX)
+		testutil.AssertNoError(t, err)
+		var bom struct {
+			BOMFormat  string `json:"bomFormat"`
+			Components []struct {
+				PURL   string `json:"purl"`
+				Hashes []struct {
+					Alg string `json:"alg"`
+				} `json:"hashes"`
+			} `json:"components"`
+		}
+		testutil.AssertNoError(t, json.Unmarshal(data, &bom))
+		testutil.AssertEqual(t, "CycloneDX", bom.BOMFormat)
+		testutil.AssertEqual(t, 39, len(bom.Components))
+		testutil.AssertEqual(t, "SHA-256", bom.Components[0].Hashes[0].Alg)
+	})
+
+	t.Run("javascript", func(t *testing.T) {
+		s, err := compute.NewSBOM(nil, "app", "javascript")
+		testutil.AssertNoError(t, err)
+
+		// Development dependencies aren't compiled into the package.
+		testutil.AssertEqual(t, []compute.SBOMComponent{{
+			Name:      "@fastly/js-compute",
+			Version:   "0.2.1",
+			PURL:      "pkg:npm/%40fastly/js-compute@0.2.1",
+			Algorithm: "SHA-512",
+			Hash:      "deadbeef",
+		}}, s.Components)
+
+		data, err := s.Encode(compute.SBOMFormatSPDX)
+		testutil.AssertNoError(t, err)
+		var doc struct {
+			SPDXVersion string `json:"spdxVersion"`
+			Packages    []struct {
+				Name      string `json:"name"`
+				Checksums []struct {
+					Algorithm string `json:"algorithm"`
+				} `json:"checksums"`
+			} `json:"packages"`
+			Relationships []struct {
+				Type string `json:"relationshipType"`
+			} `json:"relationships"`
+		}
+		testutil.AssertNoError(t, json.Unmarshal(data, &doc))
+		testutil.AssertEqual(t, "SPDX-2.3", doc.SPDXVersion)
+		testutil.AssertEqual(t, 2, len(doc.Packages))
+		testutil.AssertEqual(t, "app", doc.Packages[0].Name)
+		testutil.AssertEqual(t, "SHA512", doc.Packages[1].Checksums[0].Algorithm)
+		testutil.AssertEqual(t, "DEPENDS_ON", doc.Relationships[1].Type)
+	})
+
+	t.Run("other", func(t *testing.T) {
+		s, err := compute.NewSBOM(nil, "app", "other")
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, 0, len(s.Components))
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		s, err := compute.NewSBOM(clock.Fixed(testutil.Date), "app", "other")
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, testutil.Date, s.Created)
+		testutil.AssertString(t, "00000000-0000-4000-8000-000000000001", s.ID)
+	})
+}
+
+func TestSignature(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{Src: filepath.Join("testdata", "sign", "encrypted.key"), Dst: "encrypted.key"},
+			{Src: filepath.Join("testdata", "sign", "unencrypted.key"), Dst: "unencrypted.key"},
+			{Src: filepath.Join("testdata", "sign", "minisign.pub"), Dst: "minisign.pub"},
+			{Src: filepath.Join("testdata", "sign", "other.pub"), Dst: "other.pub"},
+		},
+		Write: []testutil.FileIO{
+			{Src: "package contents", Dst: "package.tar.gz"},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	password := func(pw string) func() (string, error) {
+		return func() (string, error) {
+			return pw, nil
+		}
+	}
+
+	_, err = compute.ReadSigningKey("encrypted.key", password("wrong"))
+	testutil.AssertErrorContains(t, err, "incorrect password")
+
+	for _, path := range []string{"encrypted.key", "unencrypted.key"} {
+		key, err := compute.ReadSigningKey(path, password("password"))
+		testutil.AssertNoError(t, err)
+		sig, err := key.Sign("package.tar.gz")
+		testutil.AssertNoError(t, err)
+		testutil.AssertNoError(t, os.WriteFile("package.tar.gz.minisig", sig, 0o600))
+
+		trusted, err := compute.VerifySignature("package.tar.gz", "package.tar.gz.minisig", "minisign.pub")
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, trusted, "file:package.tar.gz")
+
+		_, err = compute.VerifySignature("package.tar.gz", "package.tar.gz.minisig", "other.pub")
+		testutil.AssertErrorContains(t, err, "the package was signed with a different key")
+	}
+
+	testutil.AssertNoError(t, os.WriteFile("package.tar.gz", []byte("modified contents"), 0o600))
+	_, err = compute.VerifySignature("package.tar.gz", "package.tar.gz.minisig", "minisign.pub")
+	testutil.AssertErrorContains(t, err, compute.ErrSignatureMismatch.Error())
+}
+
+func TestGuestProfile(t *testing.T) {
+	fg, err := compute.ReadGuestProfile(filepath.Join("testdata", "profile", "guest.json"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 5, fg.Total)
+
+	var folded bytes.Buffer
+	testutil.AssertNoError(t, fg.WriteFolded(&folded))
+	testutil.AssertString(t, "main;handle_request 1\nmain;handle_request;alloc::raw_vec::grow 1\nmain;handle_request;sha256::compress 3\n", folded.String())
+
+	name, samples := fg.Hottest()
+	testutil.AssertString(t, "sha256::compress", name)
+	testutil.AssertEqual(t, 3, samples)
+
+	var svg bytes.Buffer
+	testutil.AssertNoError(t, fg.WriteSVG(&svg, "guest"))
+	testutil.AssertStringContains(t, svg.String(), "<title>sha256::compress (3 samples, 60.00%)</title>")
+	testutil.AssertStringContains(t, svg.String(), "<title>all (5 samples, 100.00%)</title>")
+
+	_, err = compute.ReadGuestProfile(filepath.Join("testdata", "profile", "missing.json"))
+	testutil.AssertErrorContains(t, err, "error reading profile")
+}
+
 func TestFileNameWithoutExtension(t *testing.T) {
 	for _, testcase := range []struct {
 		input      string
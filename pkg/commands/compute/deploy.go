@@ -2,22 +2,28 @@ package compute
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha512"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/transport"
 	"github.com/fastly/cli/pkg/api/undocumented"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/commands/compute/setup"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/hooks"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/cli/pkg/undo"
@@ -41,12 +47,15 @@ type DeployCommand struct {
 
 	// NOTE: these are public so that the "publish" composite command can set the
 	// values appropriately before calling the Exec() function.
-	Comment        cmd.OptionalString
-	Domain         string
-	Manifest       manifest.Data
-	Package        string
-	ServiceName    cmd.OptionalServiceNameID
-	ServiceVersion cmd.OptionalServiceVersion
+	Comment           cmd.OptionalString
+	Domain            string
+	LogEndpointParams []string
+	Manifest          manifest.Data
+	Package           string
+	ServiceName       cmd.OptionalServiceNameID
+	ServiceVersion    cmd.OptionalServiceVersion
+	UploadTimeout     int
+	VerifySignature   string
 }
 
 // NewDeployCommand returns a usable command registered under the parent.
@@ -78,8 +87,11 @@ func NewDeployCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	})
 	c.CmdClause.Flag("comment", "Human-readable comment").Action(c.Comment.Set).StringVar(&c.Comment.Value)
 	c.CmdClause.Flag("domain", "The name of the domain associated to the package").StringVar(&c.Domain)
+	c.CmdClause.Flag("log-endpoint-param", "A parameter of a log endpoint in the fastly.toml [setup.log_endpoints], as <endpoint>.<parameter>=<value> (repeat flag per parameter)").StringsVar(&c.LogEndpointParams)
 	c.CmdClause.Flag("name", "Package name").StringVar(&c.Manifest.Flag.Name)
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').StringVar(&c.Package)
+	c.CmdClause.Flag("upload-timeout", fmt.Sprintf("Timeout, in seconds, for each attempt to upload the package (an upload that fails or times out is attempted up to %d times)", PackageUploadAttempts)).IntVar(&c.UploadTimeout)
+	c.CmdClause.Flag("verify-signature", "Path to a minisign public key the package must be signed by (see 'compute pack --sign-key')").StringVar(&c.VerifySignature)
 	return &c
 }
 
@@ -90,7 +102,12 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return fsterr.ErrNoToken
 	}
 
-	serviceID, source, flag, err := cmd.ServiceID(c.ServiceName, c.Manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.ServiceName, c.Manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	// NOTE: A package's service is the one in its fastly.toml, so the current
+	// profile's default service isn't deployed to.
+	if source == manifest.SourceProfile {
+		serviceID, source, err = "", manifest.SourceUndefined, fsterr.ErrNoServiceID
+	}
 	if err == nil && c.Globals.Verbose() {
 		cmd.DisplayServiceID(serviceID, flag, source, out)
 	}
@@ -107,6 +124,17 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	if c.VerifySignature != "" {
+		err = verifyPackageSignature(pkgPath, c.VerifySignature, verbose, out)
+		if err != nil {
+			errLog.AddWithContext(err, map[string]interface{}{
+				"Package path": pkgPath,
+				"Public key":   c.VerifySignature,
+			})
+			return err
+		}
+	}
+
 	// FREE TRIAL ACTIVATION
 
 	endpoint, _ := c.Globals.Endpoint()
@@ -154,6 +182,7 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	domains := &setup.Domains{
 		APIClient:      apiClient,
 		AcceptDefaults: c.Globals.Flag.AcceptDefaults,
+		Clock:          c.Globals.Clock,
 		PackageDomain:  c.Domain,
 		ServiceID:      serviceID,
 		ServiceVersion: serviceVersion.Number,
@@ -194,9 +223,21 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			Stdout:         out,
 		}
 
+		loggerParams, err := setup.ParseLoggerParams(c.LogEndpointParams)
+		if err != nil {
+			errLogService(errLog, err, serviceID, serviceVersion.Number)
+			return err
+		}
+
 		loggers = &setup.Loggers{
-			Setup:  c.Manifest.File.Setup.Loggers,
-			Stdout: out,
+			APIClient:      apiClient,
+			AcceptDefaults: c.Globals.Flag.AcceptDefaults,
+			Params:         loggerParams,
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion.Number,
+			Setup:          c.Manifest.File.Setup.Loggers,
+			Stdin:          in,
+			Stdout:         out,
 		}
 	}
 
@@ -231,13 +272,15 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		}
 
 		if loggers.Predefined() {
-			// NOTE: We don't handle errors from the Configure() method because we
-			// don't actually do anything other than display a message to the user
-			// informing them that they need to create a log endpoint and which
-			// provider type they should be. The reason we don't implement logic for
-			// creating logging objects is because the API input fields vary
-			// significantly between providers.
-			loggers.Configure()
+			// NOTE: Only the log endpoints with parameters (in the fastly.toml or
+			// via --log-endpoint-param) are created, as the API input fields vary
+			// significantly between providers. The user is informed of the others
+			// that they need to create themselves.
+			err = loggers.Configure()
+			if err != nil {
+				errLogService(errLog, err, serviceID, serviceVersion.Number)
+				return err
+			}
 		}
 	}
 
@@ -275,11 +318,13 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 
 	if newService {
-		// NOTE: We can't pass a text.Progress instance to setup.Backends or
-		// setup.Dictionaries at the point of constructing the setup objects,
-		// as the text.Progress instance prevents other stdout from being read.
+		// NOTE: We can't pass a text.Progress instance to setup.Backends,
+		// setup.Dictionaries or setup.Loggers at the point of constructing the
+		// setup objects, as the text.Progress instance prevents other stdout from
+		// being read.
 		backends.Progress = progress
 		dictionaries.Progress = progress
+		loggers.Progress = progress
 
 		if err := backends.Create(); err != nil {
 			errLog.AddWithContext(err, map[string]interface{}{
@@ -302,6 +347,16 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			})
 			return err
 		}
+		if err := loggers.Create(); err != nil {
+			errLog.AddWithContext(err, map[string]interface{}{
+				"Accept defaults": c.Globals.Flag.AcceptDefaults,
+				"Auto-yes":        c.Globals.Flag.AutoYes,
+				"Non-interactive": c.Globals.Flag.NonInteractive,
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+			})
+			return err
+		}
 	}
 
 	// PACKAGE PROCESSING...
@@ -319,7 +374,7 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return nil
 	}
 
-	err = pkgUpload(progress, apiClient, serviceID, serviceVersion.Number, pkgPath)
+	err = pkgUpload(c.Globals.Context, progress, apiClient, token, serviceID, serviceVersion.Number, pkgPath, time.Duration(c.UploadTimeout)*time.Second)
 	if err != nil {
 		errLog.AddWithContext(err, map[string]interface{}{
 			"Package path":    pkgPath,
@@ -366,6 +421,12 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	displayDomain(apiClient, serviceID, serviceVersion.Number, out)
 
 	text.Success(out, "Deployed package (service %s, version %v)", serviceID, serviceVersion.Number)
+
+	hooks.Run(hooks.PostDeploy, hooks.Payload{
+		Command:        c.Name(),
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}, c.Globals, out)
 	return nil
 }
 
@@ -373,6 +434,32 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 // built a package to be deployed.
 //
 // NOTE: It also validates if the package size exceeds limit:
+// verifyPackageSignature checks the package's signature, which is expected
+// alongside the package, was made by the given public key.
+func verifyPackageSignature(pkgPath, publicKey string, verbose bool, out io.Writer) error {
+	sigPath := pkgPath + SignatureExtension
+	if !filesystem.FileExists(sigPath) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("package signature not found: %s", sigPath),
+			Remediation: "Sign the package with 'fastly compute pack --sign-key <path>' (or minisign), and deploy the package from the same directory as its signature.",
+		}
+	}
+	trusted, err := VerifySignature(pkgPath, sigPath, publicKey)
+	if errors.Is(err, ErrSignatureMismatch) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error verifying package signature: %w", err),
+			Remediation: "Ensure the package hasn't been modified since it was signed, and that --verify-signature is the public key of the key it was signed with.",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error verifying package signature: %w", err)
+	}
+	if verbose {
+		text.Info(out, "Verified package signature (%s)", trusted)
+	}
+	return nil
+}
+
 // https://docs.fastly.com/products/compute-at-edge-billing-and-resource-limits#resource-limits
 func validatePackage(data manifest.Data, packageFlag string, errLog fsterr.LogInterface, out io.Writer) (pkgName, pkgPath, hashSum string, err error) {
 	err = data.File.ReadError()
@@ -805,20 +892,85 @@ func getHashSum(contents map[string]*bytes.Buffer) (hash string, err error) {
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
-// pkgUpload uploads the package to the specified service and version.
-func pkgUpload(progress text.Progress, client api.Interface, serviceID string, version int, path string) error {
+// PackageUploadAttempts is the number of times a package upload that fails
+// with a network error (including a timeout) is attempted.
+//
+// NOTE: The API doesn't support resuming an upload, so each attempt uploads
+// the whole package.
+const PackageUploadAttempts = 3
+
+// pkgUpload uploads the package to the specified service and version. Each
+// attempt is limited to timeout, unless it's zero.
+func pkgUpload(ctx context.Context, progress text.Progress, client api.Interface, token, serviceID string, version int, path string, timeout time.Duration) error {
 	progress.Step("Uploading package...")
 
-	_, err := client.UpdatePackage(&fastly.UpdatePackageInput{
+	input := &fastly.UpdatePackageInput{
 		ServiceID:      serviceID,
 		ServiceVersion: version,
 		PackagePath:    path,
-	})
+	}
+	for attempt := 1; ; attempt++ {
+		err := uploadAttempt(ctx, client, token, input, timeout)
+		if err == nil {
+			return nil
+		}
+		if attempt >= PackageUploadAttempts || !retryableUploadError(err) {
+			err = fmt.Errorf("error uploading package: %w", err)
+			if isTimeout(err) {
+				return fsterr.RemediationError{
+					Inner:       err,
+					Remediation: "Increase the --upload-timeout, or omit it to not limit how long the upload can take.",
+				}
+			}
+			return err
+		}
+		progress.Step(fmt.Sprintf("Uploading package (attempt %d of %d)...", attempt+1, PackageUploadAttempts))
+	}
+}
+
+// uploadAttempt makes a single attempt to upload the package, limited to
+// timeout unless it's zero.
+//
+// NOTE: go-fastly doesn't accept a context, so a timed attempt is made with a
+// client of its own, whose requests are bound to a context with the timeout
+// (via the same transports as the shared client), rather than by changing
+// the timeout of the shared client.
+func uploadAttempt(ctx context.Context, client api.Interface, token string, input *fastly.UpdatePackageInput, timeout time.Duration) error {
+	shared, ok := client.(*fastly.Client)
+	if !ok || timeout <= 0 {
+		_, err := client.UpdatePackage(input)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	c, err := fastly.NewClientForEndpoint(token, shared.Address)
 	if err != nil {
-		return fmt.Errorf("error uploading package: %w", err)
+		return err
 	}
+	httpClient := *shared.HTTPClient
+	httpClient.Transport = transport.Context(shared.HTTPClient.Transport, ctx)
+	c.HTTPClient = &httpClient
 
-	return nil
+	_, err = c.UpdatePackage(input)
+	return err
+}
+
+// retryableUploadError indicates whether the package upload failed with a
+// network error (e.g. the connection was reset or the attempt timed out), in
+// which case it's worth trying again.
+func retryableUploadError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isTimeout indicates whether err is, or wraps, a network timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 // displayDomain displays a domain from those available in the service.
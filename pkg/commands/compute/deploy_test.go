@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/mock"
@@ -65,6 +67,18 @@ func TestDeploy(t *testing.T) {
 				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
 				Dst: filepath.Join("pkg", "package.tar.gz"),
 			},
+			{
+				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz.minisig"),
+				Dst: filepath.Join("pkg", "package.tar.gz.minisig"),
+			},
+			{
+				Src: filepath.Join("testdata", "sign", "minisign.pub"),
+				Dst: "minisign.pub",
+			},
+			{
+				Src: filepath.Join("testdata", "sign", "other.pub"),
+				Dst: "other.pub",
+			},
 		},
 	})
 	defer os.RemoveAll(rootdir)
@@ -437,6 +451,29 @@ func TestDeploy(t *testing.T) {
 				"Deployed package (service 123, version 3)",
 			},
 		},
+		{
+			name:                 "package signed with a different key",
+			args:                 args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --verify-signature other.pub"),
+			wantError:            "error verifying package signature: signature verification failed: the package was signed with a different key",
+			wantRemediationError: "--verify-signature is the public key of the key it was signed with",
+		},
+		{
+			name: "success with verified signature",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --verify-signature minisign.pub"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+			},
+			wantOutput: []string{
+				"Uploading package...",
+				"Deployed package (service 123, version 3)",
+			},
+		},
 		{
 			name: "success with path",
 			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
@@ -459,6 +496,40 @@ func TestDeploy(t *testing.T) {
 				"Deployed package (service 123, version 3)",
 			},
 		},
+		{
+			name: "success after a failed upload attempt",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --upload-timeout 60"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageFlaky(1, io.ErrUnexpectedEOF),
+			},
+			wantOutput: []string{
+				"Uploading package...",
+				"Uploading package (attempt 2 of 3)...",
+				"Deployed package (service 123, version 3)",
+			},
+		},
+		{
+			name: "upload attempts exhausted",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
+			api: mock.API{
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageFlaky(3, io.ErrUnexpectedEOF),
+			},
+			wantError: "error uploading package: Put \"https://api.fastly.com/service/123/version/3/package\": unexpected EOF",
+			wantOutput: []string{
+				"Uploading package (attempt 3 of 3)...",
+			},
+		},
 		// NOTE: The following test ensures that if the user runs the CLI from a
 		// directory that isn't a C@E project directory (i.e. it has no manifest
 		// file present) then the deploy command should try to locate a manifest
@@ -1256,6 +1327,67 @@ func TestDeploy(t *testing.T) {
 				"SUCCESS: Deployed package (service 12345, version 1)",
 			},
 		},
+		{
+			name: "success with setup.log_endpoints parameters and no existing service",
+			args: args("compute deploy --non-interactive --token 123 --log-endpoint-param my_logs.method=PUT"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CreateBackendFn:     createBackendOK,
+				CreateDomainFn:      createDomainOK,
+				CreateHTTPSFn:       createHTTPSOK,
+				CreateServiceFn:     createServiceOK,
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.log_endpoints.my_logs]
+			provider = "HTTPS"
+			[setup.log_endpoints.my_logs.parameters.url]
+			value = "https://example.com/logs"
+			`,
+			wantOutput: []string{
+				"Creating https log endpoint 'my_logs'...",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+			dontWantOutput: []string{
+				"The package code requires the following log endpoints to be created.",
+			},
+		},
+		{
+			name: "error with setup.log_endpoints missing a required parameter",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				CreateBackendFn:     createBackendOK,
+				CreateDomainFn:      createDomainOK,
+				CreateServiceFn:     createServiceOK,
+				DeleteServiceFn:     deleteServiceOK,
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.log_endpoints.my_logs]
+			provider = "bigquery"
+			[setup.log_endpoints.my_logs.parameters.dataset]
+			value = "logs"
+			`,
+			wantError:            "no value for the 'project_id' parameter of log endpoint 'my_logs'",
+			wantRemediationError: "--log-endpoint-param my_logs.project_id=<value>",
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			// Because the manifest can be mutated on each test scenario, we recreate
@@ -1366,6 +1498,72 @@ func TestDeploy(t *testing.T) {
 	}
 }
 
+func TestDeployDryRunHooks(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+	touched := filepath.Join(t.TempDir(), "touched")
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{
+				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
+				Dst: filepath.Join("pkg", "package.tar.gz"),
+			},
+		},
+		Write: []testutil.FileIO{
+			{Src: "manifest_version = 2\nname = \"package\"\n", Dst: manifest.Filename},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("compute deploy --service-id 123 --token 123 --dry-run"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ActivateVersionFn:   activateVersionOk,
+		GetPackageFn:        getPackageOk,
+		GetServiceFn:        getServiceOK,
+		GetServiceDetailsFn: getServiceDetailsWasm,
+		ListDomainsFn:       listDomainsOk,
+		ListVersionsFn:      testutil.ListVersions,
+		UpdatePackageFn:     updatePackageOk,
+	})
+	opts.ConfigFile.Hooks = config.Hooks{
+		"post-deploy": {URL: ts.URL + "/hook", Command: "touch " + touched},
+	}
+	opts.ConfigFile.Profiles = config.Profiles{
+		"user": &config.Profile{
+			Default: true,
+			Token:   "123",
+			DeployMarkers: &config.DeployMarkers{
+				Grafana: &config.GrafanaMarker{APIKey: "gf-key", URL: ts.URL},
+			},
+		},
+	}
+	err = app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertStringContains(t, stdout.String(), "Deployed package (service 123, version 3)")
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: Grafana deploy marker\n")
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: post-deploy hook: POST "+ts.URL+"/hook\n")
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: post-deploy hook: touch "+touched+"\n")
+	testutil.AssertEqual(t, 0, requests)
+	if _, err := os.Stat(touched); !os.IsNotExist(err) {
+		t.Errorf("want the hook command not to run, got %v", err)
+	}
+}
+
 func createServiceOK(i *fastly.CreateServiceInput) (*fastly.Service, error) {
 	return &fastly.Service{
 		ID:   "12345",
@@ -1435,3 +1633,19 @@ func listDomainsError(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 func listDomainsNone(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 	return []*fastly.Domain{}, nil
 }
+
+// updatePackageFlaky returns an UpdatePackageFn that fails the first
+// `failures` calls with a network error wrapping err.
+func updatePackageFlaky(failures int, err error) func(i *fastly.UpdatePackageInput) (*fastly.Package, error) {
+	return func(i *fastly.UpdatePackageInput) (*fastly.Package, error) {
+		if failures > 0 {
+			failures--
+			return nil, &url.Error{
+				Op:  "Put",
+				URL: fmt.Sprintf("https://api.fastly.com/service/%s/version/%d/package", i.ServiceID, i.ServiceVersion),
+				Err: err,
+			}
+		}
+		return updatePackageOk(i)
+	}
+}
@@ -131,7 +131,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		email = p.Email
 	}
 
-	name, desc, authors, err := promptOrReturn(c.manifest, c.dir, email, in, out)
+	name, desc, authors, err := promptOrReturn(c.manifest, c.dir, email, c.Globals.Flag.AcceptDefaults, in, out)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Description": desc,
@@ -141,7 +141,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 
 	languages := NewLanguages(c.Globals.File.StarterKits, c.Globals, name, mf.Scripts)
-	language, err := selectLanguage(c.from, c.language, languages, mf, in, out)
+	language, err := selectLanguage(c.from, c.language, languages, mf, c.Globals.Flag.AcceptDefaults, in, out)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Language": c.language,
@@ -152,7 +152,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	var from, branch, tag string
 
 	if noProjectFiles(c.from, language, mf) {
-		from, branch, tag, err = promptForStarterKit(language.StarterKits, in, out)
+		from, branch, tag, err = promptForStarterKit(language.StarterKits, c.Globals.Flag.AcceptDefaults, in, out)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"From":           c.from,
@@ -298,21 +298,21 @@ func verifyDestination(path string, progress text.Progress) (dst string, err err
 // promptOrReturn will prompt the user for information missing from the
 // fastly.toml manifest file, otherwise if it already exists then the value is
 // returned as is.
-func promptOrReturn(m manifest.Data, path, email string, in io.Reader, out io.Writer) (name, description string, authors []string, err error) {
+func promptOrReturn(m manifest.Data, path, email string, acceptDefaults bool, in io.Reader, out io.Writer) (name, description string, authors []string, err error) {
 	name, _ = m.Name()
-	name, err = packageName(name, path, in, out)
+	name, err = packageName(name, path, acceptDefaults, in, out)
 	if err != nil {
 		return name, description, authors, err
 	}
 
 	description, _ = m.Description()
-	description, err = packageDescription(description, in, out)
+	description, err = packageDescription(description, acceptDefaults, in, out)
 	if err != nil {
 		return name, description, authors, err
 	}
 
 	authors, _ = m.Authors()
-	authors, err = packageAuthors(authors, email, in, out)
+	authors, err = packageAuthors(authors, email, acceptDefaults, in, out)
 	if err != nil {
 		return name, description, authors, err
 	}
@@ -324,10 +324,13 @@ func promptOrReturn(m manifest.Data, path, email string, in io.Reader, out io.Wr
 // via the corresponding CLI flag or the manifest file.
 //
 // It will use a default of the current directory path if no value provided by
-// the user via the prompt.
-func packageName(name string, dirPath string, in io.Reader, out io.Writer) (string, error) {
+// the user via the prompt, or if acceptDefaults is set.
+func packageName(name string, dirPath string, acceptDefaults bool, in io.Reader, out io.Writer) (string, error) {
 	defaultName := filepath.Base(dirPath)
 
+	if name == "" && acceptDefaults {
+		return defaultName, nil
+	}
 	if name == "" {
 		var err error
 
@@ -346,8 +349,8 @@ func packageName(name string, dirPath string, in io.Reader, out io.Writer) (stri
 
 // packageDescription prompts the user for a package description unless already
 // defined either via the corresponding CLI flag or the manifest file.
-func packageDescription(desc string, in io.Reader, out io.Writer) (string, error) {
-	if desc == "" {
+func packageDescription(desc string, acceptDefaults bool, in io.Reader, out io.Writer) (string, error) {
+	if desc == "" && !acceptDefaults {
 		var err error
 
 		desc, err = text.Input(out, "Description: ", in)
@@ -364,7 +367,10 @@ func packageDescription(desc string, in io.Reader, out io.Writer) (string, error
 //
 // It will use a default of the user's email found within the manifest, if set
 // there, otherwise the value will be an empty slice.
-func packageAuthors(authors []string, manifestEmail string, in io.Reader, out io.Writer) ([]string, error) {
+func packageAuthors(authors []string, manifestEmail string, acceptDefaults bool, in io.Reader, out io.Writer) ([]string, error) {
+	if len(authors) == 0 && acceptDefaults {
+		return []string{manifestEmail}, nil
+	}
 	if len(authors) == 0 {
 		label := "Author: "
 
@@ -389,11 +395,15 @@ func packageAuthors(authors []string, manifestEmail string, in io.Reader, out io
 
 // selectLanguage decides whether to prompt the user for a language if none
 // defined or try and match the --language flag against available languages.
-func selectLanguage(from string, langFlag string, ls []*Language, mf manifest.File, in io.Reader, out io.Writer) (*Language, error) {
+func selectLanguage(from string, langFlag string, ls []*Language, mf manifest.File, acceptDefaults bool, in io.Reader, out io.Writer) (*Language, error) {
 	if from != "" || mf.Exists() {
 		return nil, nil
 	}
 
+	if langFlag == "" && acceptDefaults && len(ls) > 0 {
+		return ls[0], nil
+	}
+
 	if langFlag == "" {
 		return promptForLanguage(ls, in, out)
 	}
@@ -459,10 +469,15 @@ func noProjectFiles(from string, language *Language, mf manifest.File) bool {
 	return from == "" && language.Name != "other" && !mf.Exists()
 }
 
-// promptForStarterKit prompts the user for a package starter kit.
+// promptForStarterKit prompts the user for a package starter kit, unless
+// acceptDefaults is set, in which case the first one is used.
 //
 // It returns the path to the starter kit, and the corresponding branch/tag,
-func promptForStarterKit(kits []config.StarterKit, in io.Reader, out io.Writer) (from string, branch string, tag string, err error) {
+func promptForStarterKit(kits []config.StarterKit, acceptDefaults bool, in io.Reader, out io.Writer) (from string, branch string, tag string, err error) {
+	if acceptDefaults && len(kits) > 0 {
+		return kits[0].Path, kits[0].Branch, kits[0].Tag, nil
+	}
+
 	text.Output(out, "%s", text.Bold("Starter kit:"))
 	for i, kit := range kits {
 		fmt.Fprintf(out, "[%d] %s\n", i+1, text.Bold(kit.Name))
@@ -3,10 +3,12 @@ package compute
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/filesystem"
 	"github.com/fastly/cli/pkg/manifest"
@@ -19,6 +21,9 @@ import (
 type PackCommand struct {
 	cmd.Base
 	manifest   manifest.Data
+	sbom       string
+	sbomFormat string
+	signKey    string
 	wasmBinary string
 }
 
@@ -29,6 +34,9 @@ func NewPackCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	c.CmdClause = parent.Command("pack", "Package a pre-compiled Wasm binary for a Fastly Compute@Edge service")
+	c.CmdClause.Flag("sbom", "Also write the package's software bill of materials to this file").StringVar(&c.sbom)
+	c.CmdClause.Flag("sbom-format", "Format of the software bill of materials embedded in the package").Default(SBOMFormatCycloneDX).HintOptions(SBOMFormats...).EnumVar(&c.sbomFormat, SBOMFormats...)
+	c.CmdClause.Flag("sign-key", fmt.Sprintf("Path to a minisign secret key to sign the package with (the key's password is read from %s or prompted for)", env.SigningKeyPassword)).StringVar(&c.signKey)
 	c.CmdClause.Flag("wasm-binary", "Path to a pre-compiled Wasm binary").Short('w').Required().StringVar(&c.wasmBinary)
 
 	return &c
@@ -100,17 +108,45 @@ func (c *PackCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return fmt.Errorf("error copying manifest to '%s': %w", dst, err)
 	}
 
+	progress.Step("Generating software bill of materials...")
+	paths := []string{filepath.Join(dir, SBOMFilenames[c.sbomFormat])}
+	if c.sbom != "" {
+		paths = append(paths, c.sbom)
+	}
+	// The Wasm binary is pre-compiled, so the dependencies are read from the
+	// lockfile of the manifest's language, if there is one.
+	err = WriteSBOM(c.Globals.Clock, name, c.manifest.File.Language, c.sbomFormat, paths...)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Language": c.manifest.File.Language,
+			"Paths":    paths,
+		})
+		return err
+	}
+
 	progress.Step("Creating .tar.gz file...")
 	tar := archiver.NewTarGz()
 	tar.OverwriteExisting = true
+	archive := fmt.Sprintf("pkg/%s.tar.gz", name)
 	{
 		dir := fmt.Sprintf("pkg/%s", name)
 		src := []string{dir}
-		dst := fmt.Sprintf("%s.tar.gz", dir)
-		if err = tar.Archive(src, dst); err != nil {
+		if err = tar.Archive(src, archive); err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Tar source":      dir,
-				"Tar destination": dst,
+				"Tar destination": archive,
+			})
+			return err
+		}
+	}
+
+	if c.signKey != "" {
+		progress.Step("Signing package...")
+		err = c.sign(archive, in, out, progress)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Signing key": c.signKey,
+				"Package":     archive,
 			})
 			return err
 		}
@@ -119,3 +155,27 @@ func (c *PackCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	progress.Done()
 	return nil
 }
+
+// sign writes a signature of the package alongside it.
+func (c *PackCommand) sign(pkg string, in io.Reader, out io.Writer, progress text.Progress) error {
+	key, err := ReadSigningKey(c.signKey, func() (string, error) {
+		if pw := os.Getenv(env.SigningKeyPassword); pw != "" {
+			return pw, nil
+		}
+		// NOTE: the progress indicator would otherwise overwrite the prompt.
+		progress.Done()
+		return text.InputSecure(out, "Signing key password: ", in)
+	})
+	if err != nil {
+		return fmt.Errorf("error reading signing key: %w", err)
+	}
+	sig, err := key.Sign(pkg)
+	if err != nil {
+		return fmt.Errorf("error signing package: %w", err)
+	}
+	// #nosec G306
+	if err := os.WriteFile(pkg+SignatureExtension, sig, 0o644); err != nil {
+		return fmt.Errorf("error writing package signature: %w", err)
+	}
+	return nil
+}
@@ -37,6 +37,7 @@ func TestPack(t *testing.T) {
 			},
 			expectedFiles: [][]string{
 				{"pkg", "mypackagename", "bin", "main.wasm"},
+				{"pkg", "mypackagename", "bin", "sbom.cdx.json"},
 				{"pkg", "mypackagename", "fastly.toml"},
 				{"pkg", "mypackagename.tar.gz"},
 			},
@@ -61,6 +62,37 @@ func TestPack(t *testing.T) {
 				{"pkg", "another-name.tar.gz"},
 			},
 		},
+		// The following test validates that the SBOM can be exported in SPDX
+		// format.
+		{
+			name: "success with exported SBOM",
+			args: args("compute pack --wasm-binary ./main.wasm --sbom sbom.json --sbom-format spdx"),
+			manifest: `
+			manifest_version = 2
+			name = "mypackagename"`,
+			wantOutput: []string{
+				"Generating software bill of materials...",
+			},
+			expectedFiles: [][]string{
+				{"pkg", "mypackagename", "bin", "sbom.spdx.json"},
+				{"sbom.json"},
+			},
+		},
+		// The following test validates that the package is signed.
+		{
+			name: "success with signature",
+			args: args("compute pack --wasm-binary ./main.wasm --sign-key ./minisign.key"),
+			manifest: `
+			manifest_version = 2
+			name = "mypackagename"`,
+			wantOutput: []string{
+				"Signing package...",
+			},
+			expectedFiles: [][]string{
+				{"pkg", "mypackagename.tar.gz"},
+				{"pkg", "mypackagename.tar.gz.minisig"},
+			},
+		},
 		// The following tests validate that a valid path flag value should be
 		// provided.
 		{
@@ -91,6 +123,7 @@ func TestPack(t *testing.T) {
 				T: t,
 				Copy: []testutil.FileIO{
 					{Src: filepath.Join("testdata", "pack", "main.wasm"), Dst: "main.wasm"},
+					{Src: filepath.Join("testdata", "sign", "unencrypted.key"), Dst: "minisign.key"},
 				},
 				Write: []testutil.FileIO{
 					{Src: testcase.manifest, Dst: manifest.Filename},
@@ -0,0 +1,431 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/update"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ProfileCommand runs a package locally with guest profiling enabled.
+type ProfileCommand struct {
+	cmd.Base
+	manifest         manifest.Data
+	viceroyVersioner update.Versioner
+
+	addr string
+	env  cmd.OptionalString
+	file string
+	dir  string
+}
+
+// NewProfileCommand returns a usable command registered under the parent.
+func NewProfileCommand(parent cmd.Registerer, globals *config.Data, viceroyVersioner update.Versioner, data manifest.Data) *ProfileCommand {
+	var c ProfileCommand
+
+	c.viceroyVersioner = viceroyVersioner
+
+	c.Globals = globals
+	c.CmdClause = parent.Command("profile", "Run a Compute@Edge package locally and produce a flame graph of each request")
+	c.manifest = data
+
+	c.CmdClause.Flag("addr", "The IPv4 address and port to listen on").Default("127.0.0.1:7676").StringVar(&c.addr)
+	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").Action(c.env.Set).StringVar(&c.env.Value)
+	c.CmdClause.Flag("file", "The Wasm file to run (see 'compute build')").Default("bin/main.wasm").StringVar(&c.file)
+	c.CmdClause.Flag("dir", "The directory to write the profiles and flame graphs to").Default("profiles").StringVar(&c.dir)
+
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *ProfileCommand) Exec(in io.Reader, out io.Writer) error {
+	if _, err := os.Stat(c.file); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"File": c.file,
+		})
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error reading Wasm file: %w", err),
+			Remediation: "Run 'fastly compute build' to build the package, or set --file to the Wasm file to profile.",
+		}
+	}
+
+	dir, err := filepath.Abs(c.dir)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Output": dir,
+		})
+		return fmt.Errorf("error creating profile directory: %w", err)
+	}
+
+	progress := text.ResetProgress(out, c.Globals.Verbose())
+
+	bin, err := GetViceroy(progress, out, c.viceroyVersioner, c.Globals)
+	if err != nil {
+		return err
+	}
+
+	progress.Step("Running local server with profiling...")
+	progress.Done()
+
+	text.Info(out, "Send requests to http://%s and press Ctrl-C to stop the server and write the flame graphs.", c.addr)
+
+	// Viceroy writes a profile of each request it handles into the directory,
+	// which may also contain profiles from earlier runs.
+	start := time.Now()
+	err = local(bin, "", c.file, c.addr, c.env.Value, false, false, c.Globals.Verbose(), out, c.Globals.ErrLog, "--profile-guest="+dir)
+	if err != nil && err != fsterr.ErrSignalInterrupt && err != fsterr.ErrSignalKilled {
+		return err
+	}
+
+	text.Break(out)
+	text.Info(out, "Local server stopped")
+
+	profiles, err := guestProfiles(dir, start)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Output": dir,
+		})
+		return err
+	}
+	if len(profiles) == 0 {
+		text.Warning(out, "No requests were profiled.")
+		return nil
+	}
+
+	text.Break(out)
+	for _, p := range profiles {
+		if err := c.writeFlameGraph(p, out); err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Profile": p,
+			})
+			return err
+		}
+	}
+	text.Break(out)
+	text.Description(out, "The profiles can also be opened with the Firefox Profiler", "https://profiler.firefox.com")
+	return nil
+}
+
+// writeFlameGraph writes the folded stacks and SVG flame graph of the profile
+// alongside it.
+func (c *ProfileCommand) writeFlameGraph(path string, out io.Writer) error {
+	fg, err := ReadGuestProfile(path)
+	if err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	if err := writeFile(base+".folded", fg.WriteFolded); err != nil {
+		return err
+	}
+	svg := base + ".svg"
+	err = writeFile(svg, func(w io.Writer) error {
+		return fg.WriteSVG(w, filepath.Base(base))
+	})
+	if err != nil {
+		return err
+	}
+
+	name, samples := fg.Hottest()
+	if name == "" {
+		text.Output(out, "%s (no samples)", svg)
+		return nil
+	}
+	text.Output(out, "%s (%d samples, hottest function: %s %.1f%%)", svg, fg.Total, name, 100*float64(samples)/float64(fg.Total))
+	return nil
+}
+
+// guestProfiles returns the paths of the profiles in dir modified since start.
+func guestProfiles(dir string, start time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile directory: %w", err)
+	}
+	var profiles []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("error reading profile directory: %w", err)
+		}
+		if info.ModTime().Before(start) {
+			continue
+		}
+		profiles = append(profiles, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// FlameGraph is the sampled call stacks of a profile.
+type FlameGraph struct {
+	// Stacks maps each call stack, as function names separated by semicolons
+	// from the outermost function, to its number of samples.
+	Stacks map[string]int
+	// Total is the total number of samples.
+	Total int
+}
+
+// ReadGuestProfile reads a Wasm guest profile written by Viceroy, which uses
+// the Firefox Profiler's processed profile format.
+func ReadGuestProfile(path string) (*FlameGraph, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile: %w", err)
+	}
+
+	type thread struct {
+		StringArray []string `json:"stringArray"`
+		StringTable []string `json:"stringTable"`
+		FuncTable   struct {
+			Name []int `json:"name"`
+		} `json:"funcTable"`
+		FrameTable struct {
+			Func []int `json:"func"`
+		} `json:"frameTable"`
+		StackTable struct {
+			Frame  []int  `json:"frame"`
+			Prefix []*int `json:"prefix"`
+		} `json:"stackTable"`
+		Samples struct {
+			Stack  []*int    `json:"stack"`
+			Weight []float64 `json:"weight"`
+		} `json:"samples"`
+	}
+	var p struct {
+		Shared struct {
+			StringArray []string `json:"stringArray"`
+		} `json:"shared"`
+		Threads []thread `json:"threads"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("error parsing profile %s: %w", path, err)
+	}
+
+	fg := &FlameGraph{Stacks: make(map[string]int)}
+	for _, t := range p.Threads {
+		// The string table moved from each thread to the profile in later
+		// versions of the format.
+		strs := t.StringArray
+		if strs == nil {
+			strs = t.StringTable
+		}
+		if strs == nil {
+			strs = p.Shared.StringArray
+		}
+
+		name := func(stack int) (string, error) {
+			if stack < 0 || stack >= len(t.StackTable.Frame) {
+				return "", fmt.Errorf("error parsing profile %s: invalid stack %d", path, stack)
+			}
+			frame := t.StackTable.Frame[stack]
+			if frame < 0 || frame >= len(t.FrameTable.Func) {
+				return "", fmt.Errorf("error parsing profile %s: invalid frame %d", path, frame)
+			}
+			fn := t.FrameTable.Func[frame]
+			if fn < 0 || fn >= len(t.FuncTable.Name) || t.FuncTable.Name[fn] < 0 || t.FuncTable.Name[fn] >= len(strs) {
+				return "", fmt.Errorf("error parsing profile %s: invalid function %d", path, fn)
+			}
+			return strs[t.FuncTable.Name[fn]], nil
+		}
+
+		folded := make(map[int]string)
+		for i, s := range t.Samples.Stack {
+			if s == nil {
+				continue
+			}
+			stack, ok := folded[*s]
+			if !ok {
+				var names []string
+				// Limit the walk to the size of the table in case of a cycle.
+				for n, next := 0, s; next != nil && n <= len(t.StackTable.Frame); n, next = n+1, t.StackTable.Prefix[*next] {
+					if *next < 0 || *next >= len(t.StackTable.Prefix) {
+						return nil, fmt.Errorf("error parsing profile %s: invalid stack %d", path, *next)
+					}
+					fn, err := name(*next)
+					if err != nil {
+						return nil, err
+					}
+					names = append(names, strings.ReplaceAll(fn, ";", ":"))
+				}
+				for l, r := 0, len(names)-1; l < r; l, r = l+1, r-1 {
+					names[l], names[r] = names[r], names[l]
+				}
+				stack = strings.Join(names, ";")
+				folded[*s] = stack
+			}
+
+			weight := 1
+			if i < len(t.Samples.Weight) {
+				weight = int(t.Samples.Weight[i])
+			}
+			fg.Stacks[stack] += weight
+			fg.Total += weight
+		}
+	}
+	return fg, nil
+}
+
+// WriteFolded writes the stacks in the folded format used by flamegraph.pl,
+// inferno and speedscope, one stack and its samples per line.
+func (fg *FlameGraph) WriteFolded(w io.Writer) error {
+	stacks := make([]string, 0, len(fg.Stacks))
+	for s := range fg.Stacks {
+		stacks = append(stacks, s)
+	}
+	sort.Strings(stacks)
+	for _, s := range stacks {
+		if _, err := fmt.Fprintf(w, "%s %d\n", s, fg.Stacks[s]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Hottest returns the function with the most samples at the top of the stack
+// (i.e. where the most time was spent) and its number of samples.
+func (fg *FlameGraph) Hottest() (string, int) {
+	self := make(map[string]int)
+	for s, n := range fg.Stacks {
+		fn := s
+		if i := strings.LastIndex(s, ";"); i >= 0 {
+			fn = s[i+1:]
+		}
+		self[fn] += n
+	}
+	var (
+		name string
+		max  int
+	)
+	for fn, n := range self {
+		if n > max || (n == max && fn < name) {
+			name, max = fn, n
+		}
+	}
+	return name, max
+}
+
+const (
+	flameWidth       = 1200
+	flameFrameHeight = 16
+	flamePad         = 10
+	flameTitle       = 24
+	flameCharWidth   = 7
+)
+
+type flameNode struct {
+	name     string
+	samples  int
+	children map[string]*flameNode
+}
+
+// WriteSVG writes an SVG flame graph of the stacks, with the outermost
+// function at the bottom. Hovering over a frame shows its number of samples.
+func (fg *FlameGraph) WriteSVG(w io.Writer, title string) error {
+	root := &flameNode{name: "all", children: make(map[string]*flameNode)}
+	for s, n := range fg.Stacks {
+		root.samples += n
+		node := root
+		for _, fn := range strings.Split(s, ";") {
+			child, ok := node.children[fn]
+			if !ok {
+				child = &flameNode{name: fn, children: make(map[string]*flameNode)}
+				node.children[fn] = child
+			}
+			child.samples += n
+			node = child
+		}
+	}
+
+	depth := root.depth()
+	height := flameTitle + flamePad*2 + depth*flameFrameHeight
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<?xml version="1.0" standalone="no"?>`+"\n")
+	fmt.Fprintf(&b, `<svg version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg" font-family="monospace" font-size="12">`+"\n", flameWidth, height, flameWidth, height)
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="100%%" height="100%%" fill="#eeeeee"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="16">%s</text>`+"\n", flameWidth/2, flameTitle, html.EscapeString(title))
+	if root.samples > 0 {
+		scale := float64(flameWidth-flamePad*2) / float64(root.samples)
+		root.writeSVG(&b, flamePad, height-flamePad-flameFrameHeight, scale, root.samples)
+	}
+	fmt.Fprintf(&b, "</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (n *flameNode) depth() int {
+	var max int
+	for _, c := range n.children {
+		if d := c.depth(); d > max {
+			max = d
+		}
+	}
+	return max + 1
+}
+
+func (n *flameNode) writeSVG(b *strings.Builder, x float64, y int, scale float64, total int) {
+	width := float64(n.samples) * scale
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(n.name))
+	sum := h.Sum32()
+	fill := fmt.Sprintf("rgb(%d,%d,%d)", 205+sum%50, 80+(sum>>8)%130, (sum>>16)%55)
+
+	label := fmt.Sprintf("%s (%d samples, %.2f%%)", n.name, n.samples, 100*float64(n.samples)/float64(total))
+	fmt.Fprintf(b, `<g><title>%s</title><rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s" rx="2" ry="2"/>`, html.EscapeString(label), x, y, width, flameFrameHeight-1, fill)
+	if chars := int(width-6) / flameCharWidth; chars >= 3 {
+		name := n.name
+		if len(name) > chars {
+			name = name[:chars-2] + ".."
+		}
+		fmt.Fprintf(b, `<text x="%.1f" y="%d">%s</text>`, x+3, y+flameFrameHeight-4, html.EscapeString(name))
+	}
+	b.WriteString("</g>\n")
+
+	children := make([]*flameNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	for _, c := range children {
+		c.writeSVG(b, x, y-flameFrameHeight, scale, total)
+		x += float64(c.samples) * scale
+	}
+}
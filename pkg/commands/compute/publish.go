@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
@@ -20,15 +21,20 @@ type PublishCommand struct {
 	includeSrc       cmd.OptionalBool
 	lang             cmd.OptionalString
 	name             cmd.OptionalString
+	sbom             cmd.OptionalString
+	sbomFormat       cmd.OptionalString
 	skipVerification cmd.OptionalBool
 	timeout          cmd.OptionalInt
 
 	// Deploy fields
-	comment        cmd.OptionalString
-	domain         cmd.OptionalString
-	pkg            cmd.OptionalString
-	serviceName    cmd.OptionalServiceNameID
-	serviceVersion cmd.OptionalServiceVersion
+	comment         cmd.OptionalString
+	domain          cmd.OptionalString
+	logParams       []string
+	pkg             cmd.OptionalString
+	serviceName     cmd.OptionalServiceNameID
+	serviceVersion  cmd.OptionalServiceVersion
+	uploadTimeout   cmd.OptionalInt
+	verifySignature cmd.OptionalString
 }
 
 // NewPublishCommand returns a usable command registered under the parent.
@@ -44,8 +50,11 @@ func NewPublishCommand(parent cmd.Registerer, globals *config.Data, build *Build
 	c.CmdClause.Flag("domain", "The name of the domain associated to the package").Action(c.domain.Set).StringVar(&c.domain.Value)
 	c.CmdClause.Flag("include-source", "Include source code in built package").Action(c.includeSrc.Set).BoolVar(&c.includeSrc.Value)
 	c.CmdClause.Flag("language", "Language type").Action(c.lang.Set).StringVar(&c.lang.Value)
+	c.CmdClause.Flag("log-endpoint-param", "A parameter of a log endpoint in the fastly.toml [setup.log_endpoints], as <endpoint>.<parameter>=<value> (repeat flag per parameter)").StringsVar(&c.logParams)
 	c.CmdClause.Flag("name", "Package name").Action(c.name.Set).StringVar(&c.name.Value)
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').Action(c.pkg.Set).StringVar(&c.pkg.Value)
+	c.CmdClause.Flag("sbom", "Also write the package's software bill of materials to this file").Action(c.sbom.Set).StringVar(&c.sbom.Value)
+	c.CmdClause.Flag("sbom-format", "Format of the software bill of materials embedded in the package").HintOptions(SBOMFormats...).Action(c.sbomFormat.Set).EnumVar(&c.sbomFormat.Value, SBOMFormats...)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -66,6 +75,8 @@ func NewPublishCommand(parent cmd.Registerer, globals *config.Data, build *Build
 	})
 	c.CmdClause.Flag("skip-verification", "Skip verification steps and force build").Action(c.skipVerification.Set).BoolVar(&c.skipVerification.Value)
 	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the build compilation step").Action(c.timeout.Set).IntVar(&c.timeout.Value)
+	c.CmdClause.Flag("upload-timeout", fmt.Sprintf("Timeout, in seconds, for each attempt to upload the package (an upload that fails or times out is attempted up to %d times)", PackageUploadAttempts)).Action(c.uploadTimeout.Set).IntVar(&c.uploadTimeout.Value)
+	c.CmdClause.Flag("verify-signature", "Path to a minisign public key the package must be signed by (see 'compute pack --sign-key')").Action(c.verifySignature.Set).StringVar(&c.verifySignature.Value)
 
 	return &c
 }
@@ -88,6 +99,12 @@ func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if c.name.WasSet {
 		c.build.Flags.PackageName = c.name.Value
 	}
+	if c.sbom.WasSet {
+		c.build.Flags.SBOM = c.sbom.Value
+	}
+	if c.sbomFormat.WasSet {
+		c.build.Flags.SBOMFormat = c.sbomFormat.Value
+	}
 	if c.skipVerification.WasSet {
 		c.build.Flags.SkipVerification = c.skipVerification.Value
 	}
@@ -123,6 +140,15 @@ func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if c.comment.WasSet {
 		c.deploy.Comment = c.comment
 	}
+	if len(c.logParams) > 0 {
+		c.deploy.LogEndpointParams = c.logParams
+	}
+	if c.verifySignature.WasSet {
+		c.deploy.VerifySignature = c.verifySignature.Value
+	}
+	if c.uploadTimeout.WasSet {
+		c.deploy.UploadTimeout = c.uploadTimeout.Value
+	}
 	c.deploy.Manifest = c.manifest
 
 	err = c.deploy.Exec(in, out)
@@ -0,0 +1,386 @@
+package compute
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// replayIgnoreHeaders are the response headers that are expected to differ
+// between a recording and a replay, so aren't compared.
+var replayIgnoreHeaders = []string{
+	"Age",
+	"Connection",
+	"Content-Encoding",
+	"Content-Length",
+	"Date",
+	"Keep-Alive",
+	"Server-Timing",
+	"Transfer-Encoding",
+	"Via",
+	"X-Cache",
+	"X-Cache-Hits",
+	"X-Served-By",
+	"X-Timer",
+}
+
+// NewReplayCommand returns a usable command registered under the parent.
+func NewReplayCommand(parent cmd.Registerer, globals *config.Data) *ReplayCommand {
+	var c ReplayCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("replay", "Replay recorded requests against a local server and compare the responses")
+	c.CmdClause.Flag("addr", "The address of the local server (see 'compute serve --addr')").Default("127.0.0.1:7676").StringVar(&c.addr)
+	c.CmdClause.Flag("har", "Path to a HAR file of recorded requests and responses").StringVar(&c.har)
+	c.CmdClause.Flag("ignore-header", "A response header not to compare (repeat for multiple headers, in addition to headers such as Date and Age)").StringsVar(&c.ignoreHeaders)
+	c.CmdClause.Flag("ndjson", "Path to a file of recorded requests and responses, one JSON object per line").StringVar(&c.ndjson)
+	c.CmdClause.Flag("timeout", "Timeout, in seconds, for each request").Default("30").IntVar(&c.timeout)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// ReplayCommand replays recorded traffic against a local server.
+type ReplayCommand struct {
+	cmd.Base
+
+	addr          string
+	har           string
+	ignoreHeaders []string
+	json          bool
+	ndjson        string
+	timeout       int
+}
+
+// Exec implements the command interface.
+func (c *ReplayCommand) Exec(in io.Reader, out io.Writer) error {
+	var (
+		path  string
+		parse func(io.Reader) ([]ReplayEntry, error)
+	)
+	switch {
+	case c.har != "" && c.ndjson != "":
+		return fsterr.ErrInvalidReplayFlags
+	case c.har != "":
+		path, parse = c.har, ParseHAR
+	case c.ndjson != "":
+		path, parse = c.ndjson, ParseNDJSON
+	default:
+		return fsterr.ErrInvalidReplayFlags
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Path": path,
+		})
+		return fmt.Errorf("error reading recorded requests: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	entries, err := parse(f)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Path": path,
+		})
+		return err
+	}
+
+	ignore := make(map[string]bool)
+	for _, h := range append(replayIgnoreHeaders, c.ignoreHeaders...) {
+		ignore[http.CanonicalHeaderKey(h)] = true
+	}
+
+	// Redirects aren't followed so that they're compared like any other
+	// response.
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: time.Duration(c.timeout) * time.Second,
+	}
+
+	results := make([]ReplayResult, 0, len(entries))
+	var differed int
+	for _, e := range entries {
+		r := replay(client, c.addr, e, ignore)
+		if r.Error != "" || len(r.Differences) > 0 {
+			differed++
+		}
+		results = append(results, r)
+	}
+
+	if c.json {
+		data, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+	} else {
+		for _, r := range results {
+			displayReplayResult(out, r)
+		}
+		text.Break(out)
+	}
+
+	if differed > 0 {
+		err := fmt.Errorf("%d of %d responses differed from the recording", differed, len(results))
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if !c.json {
+		text.Success(out, "All %d responses matched the recording", len(results))
+	}
+	return nil
+}
+
+// ReplayEntry is a recorded request and, optionally, the response it received.
+type ReplayEntry struct {
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Body     string            `json:"body,omitempty"`
+	Response *ReplayResponse   `json:"response,omitempty"`
+}
+
+// ReplayResponse is a recorded response.
+type ReplayResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    *string           `json:"body,omitempty"`
+}
+
+// ReplayResult is the outcome of replaying a ReplayEntry.
+type ReplayResult struct {
+	Method      string   `json:"method"`
+	URL         string   `json:"url"`
+	Status      int      `json:"status,omitempty"`
+	Differences []string `json:"differences,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// ParseHAR reads the requests and responses from a HAR (HTTP Archive) file,
+// as exported by browser developer tools and most HTTP proxies.
+func ParseHAR(r io.Reader) ([]ReplayEntry, error) {
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					Method   string      `json:"method"`
+					URL      string      `json:"url"`
+					Headers  []harHeader `json:"headers"`
+					PostData *struct {
+						Text string `json:"text"`
+					} `json:"postData"`
+				} `json:"request"`
+				Response struct {
+					Status  int         `json:"status"`
+					Headers []harHeader `json:"headers"`
+					Content struct {
+						Text     *string `json:"text"`
+						Encoding string  `json:"encoding"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, fmt.Errorf("error parsing HAR file: %w", err)
+	}
+
+	entries := make([]ReplayEntry, 0, len(har.Log.Entries))
+	for i, he := range har.Log.Entries {
+		e := ReplayEntry{
+			Method:  he.Request.Method,
+			URL:     he.Request.URL,
+			Headers: harHeaders(he.Request.Headers),
+		}
+		if he.Request.PostData != nil {
+			e.Body = he.Request.PostData.Text
+		}
+
+		// A status of zero means the request didn't receive a response (e.g. it
+		// was blocked), so there's nothing to compare against.
+		if he.Response.Status != 0 {
+			e.Response = &ReplayResponse{
+				Status:  he.Response.Status,
+				Headers: harHeaders(he.Response.Headers),
+			}
+			if body := he.Response.Content.Text; body != nil {
+				if he.Response.Content.Encoding == "base64" {
+					b, err := base64.StdEncoding.DecodeString(*body)
+					if err != nil {
+						return nil, fmt.Errorf("error parsing HAR file: entry %d: invalid response body: %w", i+1, err)
+					}
+					s := string(b)
+					body = &s
+				}
+				e.Response.Body = body
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// harHeaders converts a list of HAR headers into a map, dropping the HTTP/2
+// pseudo-headers (e.g. :authority) which some browsers record.
+func harHeaders(hs []harHeader) map[string]string {
+	m := make(map[string]string, len(hs))
+	for _, h := range hs {
+		if strings.HasPrefix(h.Name, ":") {
+			continue
+		}
+		k := http.CanonicalHeaderKey(h.Name)
+		if v, ok := m[k]; ok {
+			m[k] = v + ", " + h.Value
+			continue
+		}
+		m[k] = h.Value
+	}
+	return m
+}
+
+// ParseNDJSON reads requests and responses from newline delimited JSON, where
+// each line is a ReplayEntry, e.g. generated from real-time logs. Blank lines
+// are ignored.
+func ParseNDJSON(r io.Reader) ([]ReplayEntry, error) {
+	var entries []ReplayEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e ReplayEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("error parsing line %d: %w", n, err)
+		}
+		if e.Method == "" {
+			e.Method = http.MethodGet
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading recorded requests: %w", err)
+	}
+	return entries, nil
+}
+
+// replay sends the recorded request to the local server at addr and compares
+// the response against the recorded response.
+//
+// The request keeps its original Host header, so the server behaves as it
+// would for the recorded domain.
+func replay(client *http.Client, addr string, e ReplayEntry, ignore map[string]bool) ReplayResult {
+	r := ReplayResult{Method: e.Method, URL: e.URL}
+
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		r.Error = fmt.Sprintf("invalid URL: %s", err)
+		return r
+	}
+	host := u.Host
+	u.Scheme = "http"
+	u.Host = addr
+
+	req, err := http.NewRequest(e.Method, u.String(), strings.NewReader(e.Body))
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+	if h := req.Header.Get("Host"); h != "" {
+		host = h
+	}
+	req.Host = host
+	// Responses are compared uncompressed, which is how they're recorded.
+	req.Header.Del("Accept-Encoding")
+	req.Header.Del("Content-Length")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	defer resp.Body.Close() // #nosec G307
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.Error = fmt.Sprintf("error reading response: %s", err)
+		return r
+	}
+	r.Status = resp.StatusCode
+
+	if e.Response == nil {
+		return r
+	}
+	if e.Response.Status != resp.StatusCode {
+		r.Differences = append(r.Differences, fmt.Sprintf("status: recorded %d, got %d", e.Response.Status, resp.StatusCode))
+	}
+
+	keys := make([]string, 0, len(e.Response.Headers))
+	for k := range e.Response.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, name := range keys {
+		k := http.CanonicalHeaderKey(name)
+		if ignore[k] {
+			continue
+		}
+		want := e.Response.Headers[name]
+		got, ok := resp.Header[k]
+		switch {
+		case !ok:
+			r.Differences = append(r.Differences, fmt.Sprintf("header %s: recorded %q, missing", k, want))
+		case strings.Join(got, ", ") != want:
+			r.Differences = append(r.Differences, fmt.Sprintf("header %s: recorded %q, got %q", k, want, strings.Join(got, ", ")))
+		}
+	}
+
+	if e.Response.Body != nil && *e.Response.Body != string(body) {
+		r.Differences = append(r.Differences, fmt.Sprintf("body: recorded %d bytes, got %d bytes", len(*e.Response.Body), len(body)))
+	}
+	return r
+}
+
+func displayReplayResult(out io.Writer, r ReplayResult) {
+	switch {
+	case r.Error != "":
+		text.Output(out, "%s %s %s: %s", text.BoldRed("✗"), r.Method, r.URL, r.Error)
+	case len(r.Differences) > 0:
+		text.Output(out, "%s %s %s (%d)", text.BoldRed("✗"), r.Method, r.URL, r.Status)
+		for _, d := range r.Differences {
+			text.Output(out, "\t%s", d)
+		}
+	default:
+		text.Output(out, "%s %s %s (%d)", text.BoldGreen("✓"), r.Method, r.URL, r.Status)
+	}
+}
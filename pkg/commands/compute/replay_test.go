@@ -0,0 +1,121 @@
+package compute_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("X-Host", r.Host)
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, "hello")
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "not found")
+		}
+	}))
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+
+	dir := t.TempDir()
+	har := filepath.Join(dir, "traffic.har")
+	if err := os.WriteFile(har, []byte(`{"log": {"entries": [
+		{
+			"request": {"method": "GET", "url": "https://www.example.com/", "headers": [{"name": ":authority", "value": "www.example.com"}]},
+			"response": {"status": 200, "headers": [{"name": "date", "value": "Sun, 01 Jan 2006 00:00:00 GMT"}, {"name": "x-host", "value": "www.example.com"}], "content": {"text": "aGVsbG8=", "encoding": "base64"}}
+		},
+		{
+			"request": {"method": "GET", "url": "https://www.example.com/old", "headers": []},
+			"response": {"status": 301, "headers": [{"name": "Location", "value": "/new"}], "content": {}}
+		}
+	]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ndjson := filepath.Join(dir, "requests.ndjson")
+	if err := os.WriteFile(ndjson, []byte(`{"url": "http://www.example.com/", "response": {"status": 200, "body": "hello"}}
+
+{"url": "http://www.example.com/missing", "response": {"status": 200, "headers": {"X-Host": "api.example.com"}, "body": "found"}}
+{"method": "POST", "url": "http://www.example.com/missing", "body": "data"}
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --har and --ndjson flags",
+			Args:      args("compute replay"),
+			WantError: "a single file of recorded requests is required",
+		},
+		{
+			Name:      "validate both --har and --ndjson flags",
+			Args:      args(fmt.Sprintf("compute replay --har %s --ndjson %s", har, ndjson)),
+			WantError: "a single file of recorded requests is required",
+		},
+		{
+			Name:      "validate missing file",
+			Args:      args(fmt.Sprintf("compute replay --har %s", filepath.Join(dir, "missing.har"))),
+			WantError: "error reading recorded requests",
+		},
+		{
+			Name: "success with HAR",
+			Args: args(fmt.Sprintf("compute replay --addr %s --har %s", addr, har)),
+			WantOutputs: []string{
+				"GET https://www.example.com/ (200)",
+				"GET https://www.example.com/old (301)",
+				"All 2 responses matched the recording",
+			},
+		},
+		{
+			Name:      "differences with NDJSON",
+			Args:      args(fmt.Sprintf("compute replay --addr %s --ndjson %s", addr, ndjson)),
+			WantError: "1 of 3 responses differed from the recording",
+			WantOutputs: []string{
+				"GET http://www.example.com/ (200)",
+				"GET http://www.example.com/missing (404)",
+				"status: recorded 200, got 404",
+				`header X-Host: recorded "api.example.com", got "www.example.com"`,
+				"body: recorded 5 bytes, got 9 bytes",
+				"POST http://www.example.com/missing (404)",
+			},
+		},
+		{
+			Name:       "success with JSON",
+			Args:       args(fmt.Sprintf("compute replay --addr %s --har %s --json", addr, har)),
+			WantOutput: `[{"method":"GET","url":"https://www.example.com/","status":200},{"method":"GET","url":"https://www.example.com/old","status":301}]`,
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, s := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+
+	t.Run("ignore header", func(t *testing.T) {
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(args(fmt.Sprintf("compute replay --addr %s --ndjson %s --ignore-header x-host", addr, ndjson)), &stdout)
+		err := app.Run(opts)
+		testutil.AssertErrorContains(t, err, "1 of 3 responses differed from the recording")
+		testutil.AssertStringDoesntContain(t, stdout.String(), "header X-Host")
+	})
+}
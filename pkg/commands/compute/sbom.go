@@ -0,0 +1,432 @@
+package compute
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/revision"
+	toml "github.com/pelletier/go-toml"
+)
+
+// SBOM formats supported by the --sbom-format flag.
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDX      = "spdx"
+)
+
+// SBOMFormats is the list of supported SBOM formats.
+var SBOMFormats = []string{SBOMFormatCycloneDX, SBOMFormatSPDX}
+
+// SBOMFilenames are the names of the SBOM files embedded in the bin directory
+// of a package, keyed by format.
+var SBOMFilenames = map[string]string{
+	SBOMFormatCycloneDX: "sbom.cdx.json",
+	SBOMFormatSPDX:      "sbom.spdx.json",
+}
+
+// sbomTools are the toolchain executables whose versions are recorded in the
+// SBOM for each language.
+var sbomTools = map[string][]string{
+	"assemblyscript": {"node", "npm"},
+	"javascript":     {"node", "npm"},
+	"rust":           {"rustc", "cargo"},
+}
+
+// SBOM is a software bill of materials for a Compute@Edge package.
+type SBOM struct {
+	// Name is the package name.
+	Name string
+	// Language is the package's source language.
+	Language string
+	// Components are the language dependencies compiled into the package.
+	Components []SBOMComponent
+	// Tools are the toolchain used to build the package.
+	Tools []SBOMTool
+	// Created is when the SBOM was generated.
+	Created time.Time
+	// ID uniquely identifies the SBOM, as a UUID.
+	ID string
+}
+
+// SBOMComponent is a single dependency of a package.
+type SBOMComponent struct {
+	Name    string
+	Version string
+	// PURL is the package URL identifying the dependency.
+	PURL string
+	// Algorithm is the hash algorithm of Hash, e.g. SHA-256.
+	Algorithm string
+	// Hash is the hex encoded hash of the dependency, if the lockfile has one.
+	Hash string
+}
+
+// SBOMTool is a tool used to build a package.
+type SBOMTool struct {
+	Name    string
+	Version string
+}
+
+// NewSBOM generates an SBOM for the package in the current directory, reading
+// its dependencies from the language's lockfile (Cargo.lock or
+// package-lock.json) and its toolchain versions from the local environment.
+// A missing lockfile results in an SBOM without dependencies.
+//
+// The creation time and ID are taken from clk, so they're reproducible when
+// the --deterministic flag is set.
+func NewSBOM(clk *clock.Clock, name, language string) (*SBOM, error) {
+	s := &SBOM{
+		Name:     name,
+		Language: language,
+		Created:  clk.Now().UTC(),
+		ID:       clk.ID(),
+		Tools:    []SBOMTool{{Name: "fastly", Version: strings.TrimPrefix(revision.AppVersion, "v")}},
+	}
+
+	var err error
+	switch language {
+	case "rust":
+		s.Components, err = cargoComponents("Cargo.lock")
+	case "javascript", "assemblyscript":
+		s.Components, err = npmComponents("package-lock.json")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bin := range sbomTools[language] {
+		if v := toolVersion(bin); v != "" {
+			s.Tools = append(s.Tools, SBOMTool{Name: bin, Version: v})
+		}
+	}
+	return s, nil
+}
+
+// Encode returns the SBOM as a JSON document in the given format.
+func (s *SBOM) Encode(format string) ([]byte, error) {
+	var doc interface{}
+	switch format {
+	case SBOMFormatCycloneDX:
+		doc = s.cycloneDX(s.ID)
+	case SBOMFormatSPDX:
+		doc = s.spdx(s.ID)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format '%s'", format)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// WriteSBOM generates an SBOM for the package and writes it to each of the
+// paths, in the given format.
+func WriteSBOM(clk *clock.Clock, name, language, format string, paths ...string) error {
+	s, err := NewSBOM(clk, name, language)
+	if err != nil {
+		return fmt.Errorf("error generating SBOM: %w", err)
+	}
+	data, err := s.Encode(format)
+	if err != nil {
+		return fmt.Errorf("error encoding SBOM: %w", err)
+	}
+	for _, p := range paths {
+		// #nosec G306
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			return fmt.Errorf("error writing SBOM: %w", err)
+		}
+	}
+	return nil
+}
+
+// cycloneDX returns the SBOM as a CycloneDX 1.4 document.
+func (s *SBOM) cycloneDX(id string) map[string]interface{} {
+	tools := make([]map[string]interface{}, 0, len(s.Tools))
+	for _, t := range s.Tools {
+		tools = append(tools, map[string]interface{}{"name": t.Name, "version": t.Version})
+	}
+	components := make([]map[string]interface{}, 0, len(s.Components))
+	for _, c := range s.Components {
+		component := map[string]interface{}{
+			"type":    "library",
+			"name":    c.Name,
+			"version": c.Version,
+			"purl":    c.PURL,
+		}
+		if c.Hash != "" {
+			component["hashes"] = []map[string]string{{"alg": c.Algorithm, "content": c.Hash}}
+		}
+		components = append(components, component)
+	}
+	return map[string]interface{}{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.4",
+		"serialNumber": "urn:uuid:" + id,
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": s.Created.Format(time.RFC3339),
+			"tools":     tools,
+			"component": map[string]interface{}{
+				"type": "application",
+				"name": s.Name,
+				"properties": []map[string]string{
+					{"name": "fastly:compute:language", "value": s.Language},
+				},
+			},
+		},
+		"components": components,
+	}
+}
+
+// spdx returns the SBOM as an SPDX 2.3 document.
+func (s *SBOM) spdx(id string) map[string]interface{} {
+	const root = "SPDXRef-Package-0"
+
+	creators := make([]string, 0, len(s.Tools))
+	for _, t := range s.Tools {
+		creators = append(creators, fmt.Sprintf("Tool: %s-%s", t.Name, t.Version))
+	}
+	packages := []map[string]interface{}{{
+		"name":                  s.Name,
+		"SPDXID":                root,
+		"downloadLocation":      "NOASSERTION",
+		"primaryPackagePurpose": "APPLICATION",
+	}}
+	relationships := []map[string]string{{
+		"spdxElementId":      "SPDXRef-DOCUMENT",
+		"relationshipType":   "DESCRIBES",
+		"relatedSpdxElement": root,
+	}}
+	for i, c := range s.Components {
+		ref := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		p := map[string]interface{}{
+			"name":             c.Name,
+			"SPDXID":           ref,
+			"versionInfo":      c.Version,
+			"downloadLocation": "NOASSERTION",
+			"externalRefs": []map[string]string{{
+				"referenceCategory": "PACKAGE-MANAGER",
+				"referenceType":     "purl",
+				"referenceLocator":  c.PURL,
+			}},
+		}
+		if c.Hash != "" {
+			p["checksums"] = []map[string]string{{
+				"algorithm":     strings.ReplaceAll(c.Algorithm, "-", ""),
+				"checksumValue": c.Hash,
+			}}
+		}
+		packages = append(packages, p)
+		relationships = append(relationships, map[string]string{
+			"spdxElementId":      root,
+			"relationshipType":   "DEPENDS_ON",
+			"relatedSpdxElement": ref,
+		})
+	}
+	return map[string]interface{}{
+		"spdxVersion":       "SPDX-2.3",
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"name":              s.Name,
+		"documentNamespace": fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s", url.PathEscape(s.Name), id),
+		"creationInfo": map[string]interface{}{
+			"created":  s.Created.Format(time.RFC3339),
+			"creators": creators,
+		},
+		"packages":      packages,
+		"relationships": relationships,
+	}
+}
+
+// cargoComponents reads the packages from a Cargo.lock file. The package
+// being built is listed without a source, so only packages with a source
+// (e.g. crates.io or git) are returned.
+func cargoComponents(path string) ([]SBOMComponent, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lock struct {
+		Package []struct {
+			Name     string `toml:"name"`
+			Version  string `toml:"version"`
+			Source   string `toml:"source"`
+			Checksum string `toml:"checksum"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+
+	var components []SBOMComponent
+	for _, p := range lock.Package {
+		if p.Source == "" {
+			continue
+		}
+		c := SBOMComponent{
+			Name:    p.Name,
+			Version: p.Version,
+			PURL:    fmt.Sprintf("pkg:cargo/%s@%s", p.Name, p.Version),
+		}
+		if p.Checksum != "" {
+			c.Algorithm, c.Hash = "SHA-256", p.Checksum
+		}
+		components = append(components, c)
+	}
+	sortComponents(components)
+	return components, nil
+}
+
+// npmComponents reads the production dependencies from a package-lock.json
+// file, supporting both the "packages" (lockfile v2 and v3) and
+// "dependencies" (lockfile v1) layouts.
+func npmComponents(path string) ([]SBOMComponent, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lock struct {
+		Packages     map[string]npmDependency `json:"packages"`
+		Dependencies map[string]npmDependency `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	var components []SBOMComponent
+	add := func(name string, d npmDependency) {
+		if d.Dev || name == "" || d.Version == "" || seen[name+"@"+d.Version] {
+			return
+		}
+		seen[name+"@"+d.Version] = true
+		c := SBOMComponent{
+			Name:    name,
+			Version: d.Version,
+			PURL:    fmt.Sprintf("pkg:npm/%s@%s", strings.Replace(name, "@", "%40", 1), d.Version),
+		}
+		c.Algorithm, c.Hash = npmIntegrity(d.Integrity)
+		components = append(components, c)
+	}
+
+	if len(lock.Packages) > 0 {
+		for key, d := range lock.Packages {
+			// The empty key is the package being built.
+			i := strings.LastIndex(key, "node_modules/")
+			if i < 0 {
+				continue
+			}
+			name := d.Name
+			if name == "" {
+				name = key[i+len("node_modules/"):]
+			}
+			add(name, d)
+		}
+	} else {
+		var walk func(deps map[string]npmDependency)
+		walk = func(deps map[string]npmDependency) {
+			for name, d := range deps {
+				add(name, d)
+				walk(d.Dependencies)
+			}
+		}
+		walk(lock.Dependencies)
+	}
+	sortComponents(components)
+	return components, nil
+}
+
+type npmDependency struct {
+	Name         string                   `json:"name"`
+	Version      string                   `json:"version"`
+	Integrity    string                   `json:"integrity"`
+	Dev          bool                     `json:"dev"`
+	Dependencies map[string]npmDependency `json:"dependencies"`
+}
+
+// npmIntegrity converts a subresource integrity value (e.g. sha512-<base64>)
+// into a hash algorithm and hex encoded hash.
+func npmIntegrity(integrity string) (algorithm, hash string) {
+	// Multiple hashes may be given, separated by whitespace.
+	fields := strings.Fields(integrity)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	alg, digest, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return "", ""
+	}
+	b, err := base64.StdEncoding.DecodeString(digest)
+	if err != nil {
+		return "", ""
+	}
+	switch alg {
+	case "sha1":
+		algorithm = "SHA-1"
+	case "sha256":
+		algorithm = "SHA-256"
+	case "sha384":
+		algorithm = "SHA-384"
+	case "sha512":
+		algorithm = "SHA-512"
+	default:
+		return "", ""
+	}
+	return algorithm, hex.EncodeToString(b)
+}
+
+func sortComponents(cs []SBOMComponent) {
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].Name != cs[j].Name {
+			return cs[i].Name < cs[j].Name
+		}
+		return cs[i].Version < cs[j].Version
+	})
+}
+
+// toolVersion returns the version reported by `<bin> --version`, or an empty
+// string if it can't be determined.
+//
+// Example outputs:
+// rustc 1.54.0 (a178d0322 2021-07-26)
+// v16.13.0
+func toolVersion(bin string) string {
+	out, err := exec.Command(bin, "--version").Output() // #nosec G204
+	if err != nil {
+		return ""
+	}
+	// Use the last line, as rustup may install a toolchain before the real
+	// command runs.
+	var line string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if l := strings.TrimSpace(scanner.Text()); l != "" {
+			line = l
+		}
+	}
+	fields := strings.Fields(line)
+	if len(fields) > 1 && fields[0] == bin {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[0], "v")
+}
@@ -42,6 +42,8 @@ type ServeCommand struct {
 	includeSrc       cmd.OptionalBool
 	lang             cmd.OptionalString
 	name             cmd.OptionalString
+	sbom             cmd.OptionalString
+	sbomFormat       cmd.OptionalString
 	skipVerification cmd.OptionalBool
 	timeout          cmd.OptionalInt
 
@@ -72,6 +74,8 @@ func NewServeCommand(parent cmd.Registerer, globals *config.Data, build *BuildCo
 	c.CmdClause.Flag("include-source", "Include source code in built package").Action(c.includeSrc.Set).BoolVar(&c.includeSrc.Value)
 	c.CmdClause.Flag("language", "Language type").Action(c.lang.Set).StringVar(&c.lang.Value)
 	c.CmdClause.Flag("name", "Package name").Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("sbom", "Also write the package's software bill of materials to this file").Action(c.sbom.Set).StringVar(&c.sbom.Value)
+	c.CmdClause.Flag("sbom-format", "Format of the software bill of materials embedded in the package").HintOptions(SBOMFormats...).Action(c.sbomFormat.Set).EnumVar(&c.sbomFormat.Value, SBOMFormats...)
 	c.CmdClause.Flag("skip-build", "Skip the build step").BoolVar(&c.skipBuild)
 	c.CmdClause.Flag("skip-verification", "Skip verification steps and force build").Action(c.skipVerification.Set).BoolVar(&c.skipVerification.Value)
 	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the build compilation step").Action(c.timeout.Set).IntVar(&c.timeout.Value)
@@ -140,6 +144,12 @@ func (c *ServeCommand) Build(in io.Reader, out io.Writer) error {
 	if c.name.WasSet {
 		c.build.Flags.PackageName = c.name.Value
 	}
+	if c.sbom.WasSet {
+		c.build.Flags.SBOM = c.sbom.Value
+	}
+	if c.sbomFormat.WasSet {
+		c.build.Flags.SBOMFormat = c.sbomFormat.Value
+	}
 	if c.skipVerification.WasSet {
 		c.build.Flags.SkipVerification = c.skipVerification.Value
 	}
@@ -410,8 +420,9 @@ func sourceDirectory(flag cmd.OptionalString, lang string, watch bool, out io.Wr
 	return defaultDir
 }
 
-// local spawns a subprocess that runs the compiled binary.
-func local(bin, srcDir, file, addr, env string, debug, watch, verbose bool, out io.Writer, errLog fsterr.LogInterface) error {
+// local spawns a subprocess that runs the compiled binary. Any extraArgs are
+// passed to Viceroy as additional flags.
+func local(bin, srcDir, file, addr, env string, debug, watch, verbose bool, out io.Writer, errLog fsterr.LogInterface, extraArgs ...string) error {
 	if env != "" {
 		env = "." + env
 	}
@@ -423,7 +434,8 @@ func local(bin, srcDir, file, addr, env string, debug, watch, verbose bool, out
 	}
 
 	manifest := filepath.Join(wd, fmt.Sprintf("fastly%s.toml", env))
-	args := []string{"-C", manifest, "--addr", addr, file}
+	args := append([]string{"-C", manifest, "--addr", addr}, extraArgs...)
+	args = append(args, file)
 
 	if debug {
 		args = append(args, "--debug")
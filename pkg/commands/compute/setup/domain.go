@@ -5,10 +5,10 @@ import (
 	"io"
 	"math/rand"
 	"regexp"
-	"time"
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/clock"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -26,12 +26,16 @@ type Domains struct {
 	// Public
 	APIClient      api.Interface
 	AcceptDefaults bool
+	Clock          *clock.Clock
 	PackageDomain  string
 	Progress       text.Progress
 	ServiceID      string
 	ServiceVersion int
 	Stdin          io.Reader
 	Stdout         io.Writer
+	// TopLevelDomain is the domain the default domain is a subdomain of, which
+	// defaults to edgecompute.app (i.e. the domain of Compute@Edge services).
+	TopLevelDomain string
 
 	// Private
 	available []*fastly.Domain
@@ -57,8 +61,12 @@ func (d *Domains) Configure() error {
 		return nil
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	defaultDomain := fmt.Sprintf("%s.%s", petname.Generate(3, "-"), defaultTopLevelDomain)
+	tld := d.TopLevelDomain
+	if tld == "" {
+		tld = defaultTopLevelDomain
+	}
+	rand.Seed(d.Clock.Now().UnixNano())
+	defaultDomain := fmt.Sprintf("%s.%s", petname.Generate(3, "-"), tld)
 
 	var (
 		domain string
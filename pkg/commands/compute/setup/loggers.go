@@ -1,34 +1,226 @@
 package setup
 
 import (
+	"fmt"
 	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // Loggers represents the service state related to log entries defined within
 // the fastly.toml [setup] configuration.
 //
+// Log endpoints whose provider is supported (see loggerProviders) and which
+// have parameters, either in the fastly.toml or via Params, are created. The
+// user is otherwise informed of the endpoints they need to create themselves.
+//
 // NOTE: It implements the setup.Interface interface.
 type Loggers struct {
-	Setup  map[string]*manifest.SetupLogger
-	Stdout io.Writer
+	// Public
+	APIClient      api.Interface
+	AcceptDefaults bool
+	Params         map[string]map[string]string // endpoint name -> parameter -> value
+	Progress       text.Progress
+	ServiceID      string
+	ServiceVersion int
+	Setup          map[string]*manifest.SetupLogger
+	Stdin          io.Reader
+	Stdout         io.Writer
+
+	// Private
+	required []Logger
 }
 
-// Logger represents the configuration parameters for creating a dictionary
+// Logger represents the configuration parameters for creating a log endpoint
 // via the API client.
 type Logger struct {
-	Provider string
+	Name       string
+	Provider   string
+	Parameters map[string]string
+}
+
+// loggerProvider describes how to create the log endpoints of a provider.
+type loggerProvider struct {
+	// required are the parameters the endpoint can't be created without.
+	required []string
+	// input returns the provider's (empty) create input.
+	input func() interface{}
+	// create calls the API with the populated input.
+	create func(c api.Interface, input interface{}) error
+}
+
+// loggerProviders are the log endpoint providers whose endpoints can be
+// created from the fastly.toml [setup] configuration. The parameters of an
+// endpoint are the API fields of its provider (e.g. url or dataset).
+var loggerProviders = map[string]loggerProvider{
+	"bigquery": {
+		required: []string{"dataset", "project_id", "secret_key", "table", "user"},
+		input:    func() interface{} { return &fastly.CreateBigQueryInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateBigQuery(i.(*fastly.CreateBigQueryInput))
+			return err
+		},
+	},
+	"datadog": {
+		required: []string{"token"},
+		input:    func() interface{} { return &fastly.CreateDatadogInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateDatadog(i.(*fastly.CreateDatadogInput))
+			return err
+		},
+	},
+	"gcs": {
+		required: []string{"bucket_name", "secret_key", "user"},
+		input:    func() interface{} { return &fastly.CreateGCSInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateGCS(i.(*fastly.CreateGCSInput))
+			return err
+		},
+	},
+	"honeycomb": {
+		required: []string{"dataset", "token"},
+		input:    func() interface{} { return &fastly.CreateHoneycombInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateHoneycomb(i.(*fastly.CreateHoneycombInput))
+			return err
+		},
+	},
+	"https": {
+		required: []string{"url"},
+		input:    func() interface{} { return &fastly.CreateHTTPSInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateHTTPS(i.(*fastly.CreateHTTPSInput))
+			return err
+		},
+	},
+	"loggly": {
+		required: []string{"token"},
+		input:    func() interface{} { return &fastly.CreateLogglyInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateLoggly(i.(*fastly.CreateLogglyInput))
+			return err
+		},
+	},
+	"newrelic": {
+		required: []string{"token"},
+		input:    func() interface{} { return &fastly.CreateNewRelicInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateNewRelic(i.(*fastly.CreateNewRelicInput))
+			return err
+		},
+	},
+	"s3": {
+		required: []string{"bucket_name"},
+		input:    func() interface{} { return &fastly.CreateS3Input{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateS3(i.(*fastly.CreateS3Input))
+			return err
+		},
+	},
+	"splunk": {
+		required: []string{"token", "url"},
+		input:    func() interface{} { return &fastly.CreateSplunkInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateSplunk(i.(*fastly.CreateSplunkInput))
+			return err
+		},
+	},
+	"sumologic": {
+		required: []string{"url"},
+		input:    func() interface{} { return &fastly.CreateSumologicInput{} },
+		create: func(c api.Interface, i interface{}) error {
+			_, err := c.CreateSumologic(i.(*fastly.CreateSumologicInput))
+			return err
+		},
+	},
+}
+
+// ParseLoggerParams parses the values of the --log-endpoint-param flag, each
+// of the form <endpoint>.<parameter>=<value>.
+func ParseLoggerParams(values []string) (map[string]map[string]string, error) {
+	params := make(map[string]map[string]string)
+	for _, v := range values {
+		kv := strings.SplitN(v, "=", 2)
+		name := strings.SplitN(kv[0], ".", 2)
+		if len(kv) != 2 || len(name) != 2 || name[0] == "" || name[1] == "" {
+			return nil, errors.RemediationError{
+				Inner:       fmt.Errorf("invalid --log-endpoint-param '%s'", v),
+				Remediation: "Provide the parameter as <endpoint>.<parameter>=<value>, e.g. --log-endpoint-param my_logs.url=https://example.com/logs",
+			}
+		}
+		if params[name[0]] == nil {
+			params[name[0]] = make(map[string]string)
+		}
+		params[name[0]][name[1]] = kv[1]
+	}
+	return params, nil
 }
 
 // Configure prompts the user for specific values related to the service resource.
 func (l *Loggers) Configure() error {
+	for name := range l.Params {
+		if _, ok := l.Setup[name]; !ok {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("--log-endpoint-param given for unknown log endpoint '%s'", name),
+				Remediation: "Define the log endpoint in the fastly.toml [setup.log_endpoints] configuration.",
+			}
+		}
+	}
+
+	names := make([]string, 0, len(l.Setup))
+	for name := range l.Setup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var manual []string
+	for _, name := range names {
+		settings := l.Setup[name]
+		if len(settings.Parameters) == 0 && len(l.Params[name]) == 0 {
+			manual = append(manual, name)
+			continue
+		}
+
+		provider, ok := loggerProviders[strings.ToLower(settings.Provider)]
+		if !ok {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("log endpoint '%s' has parameters but its provider '%s' can't be created from the fastly.toml", name, settings.Provider),
+				Remediation: fmt.Sprintf("Use one of the providers %s, or remove the parameters and create the endpoint with 'fastly logging <provider> create'.", strings.Join(loggerProviderNames(), ", ")),
+			}
+		}
+
+		params, err := l.configureParameters(name, settings, provider)
+		if err != nil {
+			return err
+		}
+		if err := setParameters(provider.input(), params); err != nil {
+			return fmt.Errorf("error configuring log endpoint '%s': %w", name, err)
+		}
+		l.required = append(l.required, Logger{
+			Name:       name,
+			Provider:   strings.ToLower(settings.Provider),
+			Parameters: params,
+		})
+	}
+
+	if len(manual) == 0 {
+		return nil
+	}
+
 	text.Break(l.Stdout)
 	text.Info(l.Stdout, "The package code requires the following log endpoints to be created.")
 	text.Break(l.Stdout)
 
-	for name, settings := range l.Setup {
+	for _, name := range manual {
+		settings := l.Setup[name]
 		text.Output(l.Stdout, "%s %s", text.Bold("Name:"), name)
 		if settings.Provider != "" {
 			text.Output(l.Stdout, "%s %s", text.Bold("Provider:"), settings.Provider)
@@ -45,8 +237,187 @@ func (l *Loggers) Configure() error {
 	return nil
 }
 
+// configureParameters returns the values of the endpoint's parameters: those
+// of the --log-endpoint-param flag, otherwise prompted for (defaulting to the
+// fastly.toml value).
+func (l *Loggers) configureParameters(name string, settings *manifest.SetupLogger, provider loggerProvider) (map[string]string, error) {
+	keys := make(map[string]bool)
+	for _, key := range provider.required {
+		keys[key] = true
+	}
+	for key := range settings.Parameters {
+		keys[key] = true
+	}
+	for key := range l.Params[name] {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	if !l.AcceptDefaults {
+		text.Break(l.Stdout)
+		text.Output(l.Stdout, "Configuring %s log endpoint '%s'", settings.Provider, name)
+		if settings.Description != "" {
+			text.Output(l.Stdout, settings.Description)
+		}
+	}
+
+	params := make(map[string]string)
+	for _, key := range sorted {
+		value, ok := l.Params[name][key]
+		if !ok {
+			var err error
+			value, err = l.promptParameter(key, settings.Parameters[key])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if value != "" {
+			params[key] = value
+		}
+	}
+
+	for _, key := range provider.required {
+		if params[key] == "" {
+			return nil, errors.RemediationError{
+				Inner:       fmt.Errorf("no value for the '%s' parameter of log endpoint '%s'", key, name),
+				Remediation: fmt.Sprintf("Provide a value in the fastly.toml or with --log-endpoint-param %s.%s=<value>.", name, key),
+			}
+		}
+	}
+	return params, nil
+}
+
+// promptParameter prompts for the value of a parameter, unless accepting the
+// fastly.toml defaults.
+func (l *Loggers) promptParameter(key string, p manifest.SetupLoggerParameter) (string, error) {
+	if l.AcceptDefaults {
+		return p.Value, nil
+	}
+
+	text.Break(l.Stdout)
+	text.Output(l.Stdout, "Set the '%s' parameter", key)
+	if p.Description != "" {
+		text.Output(l.Stdout, p.Description)
+	}
+	text.Break(l.Stdout)
+
+	prompt := text.BoldYellow("Value: ")
+	if p.Value != "" {
+		prompt = text.BoldYellow(fmt.Sprintf("Value: [%s] ", p.Value))
+	}
+	input := text.Input
+	if secretParameter(key) {
+		input = text.InputSecure
+	}
+	value, err := input(l.Stdout, prompt, l.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("error reading prompt input: %w", err)
+	}
+	if value == "" {
+		value = p.Value
+	}
+	return value, nil
+}
+
+// Create calls the relevant API to create the service resource(s).
+func (l *Loggers) Create() error {
+	if l.Progress == nil {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.Loggers"),
+			Remediation: errors.BugRemediation,
+		}
+	}
+
+	for _, logger := range l.required {
+		l.Progress.Step(fmt.Sprintf("Creating %s log endpoint '%s'...", logger.Provider, logger.Name))
+
+		provider := loggerProviders[logger.Provider]
+		input := provider.input()
+		v := reflect.ValueOf(input).Elem()
+		v.FieldByName("ServiceID").SetString(l.ServiceID)
+		v.FieldByName("ServiceVersion").SetInt(int64(l.ServiceVersion))
+		v.FieldByName("Name").SetString(logger.Name)
+		if err := setParameters(input, logger.Parameters); err != nil {
+			l.Progress.Fail()
+			return fmt.Errorf("error creating log endpoint: %w", err)
+		}
+
+		if err := provider.create(l.APIClient, input); err != nil {
+			l.Progress.Fail()
+			return fmt.Errorf("error creating log endpoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Predefined indicates if the service resource has been specified within the
 // fastly.toml file using a [setup] configuration block.
 func (l *Loggers) Predefined() bool {
 	return len(l.Setup) > 0
 }
+
+// setParameters sets the fields of a create input by their API names (i.e.
+// their url struct tags).
+func setParameters(input interface{}, params map[string]string) error {
+	v := reflect.ValueOf(input).Elem()
+	fields := make(map[string]reflect.Value)
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("url"), ",")[0]
+		if tag != "" && tag != "name" {
+			fields[tag] = v.Field(i)
+		}
+	}
+
+	for key, value := range params {
+		f, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown parameter '%s'", key)
+		}
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(value)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid '%s' parameter '%s': must be true or false", key, value)
+			}
+			f.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(value, 10, f.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("invalid '%s' parameter '%s': must be a number", key, value)
+			}
+			f.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseUint(value, 10, f.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("invalid '%s' parameter '%s': must be a positive number", key, value)
+			}
+			f.SetUint(n)
+		default:
+			return fmt.Errorf("unsupported parameter '%s'", key)
+		}
+	}
+	return nil
+}
+
+// secretParameter reports whether the parameter's value shouldn't be echoed
+// when prompted for.
+func secretParameter(key string) bool {
+	return key == "token" || strings.HasSuffix(key, "_key") || strings.Contains(key, "secret")
+}
+
+// loggerProviderNames returns the sorted names of the loggerProviders.
+func loggerProviderNames() []string {
+	names := make([]string, 0, len(loggerProviders))
+	for name := range loggerProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,230 @@
+package compute
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+)
+
+// SignatureExtension is appended to the path of a package to give the path of
+// its signature.
+const SignatureExtension = ".minisig"
+
+// Package signatures use the minisign format (https://jedisct1.github.io/minisign/)
+// so they can also be created and verified with the minisign tool.
+var (
+	sigAlgEd       = []byte("Ed") // signature of the file contents
+	sigAlgHashedEd = []byte("ED") // signature of the BLAKE2b-512 hash of the file
+	kdfAlgScrypt   = []byte("Sc")
+	kdfAlgNone     = []byte{0, 0}
+	chkAlgBlake2b  = []byte("B2")
+)
+
+// ErrSignatureMismatch is returned when a package signature isn't valid for
+// the package or public key.
+var ErrSignatureMismatch = errors.New("signature verification failed")
+
+// SigningKey is a minisign secret key.
+type SigningKey struct {
+	id     []byte
+	secret ed25519.PrivateKey
+}
+
+// ReadSigningKey reads a minisign secret key (e.g. created by `minisign -G`).
+// Keys are normally encrypted, in which case password is called to obtain
+// the password.
+func ReadSigningKey(path string, password func() (string, error)) (*SigningKey, error) {
+	data, err := readKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// sig_alg (2) kdf_alg (2) chk_alg (2) salt (32) opslimit (8) memlimit (8)
+	// followed by the (possibly encrypted) key id (8), secret key (64) and
+	// checksum (32).
+	if len(data) != 158 || !bytes.Equal(data[:2], sigAlgEd) || !bytes.Equal(data[4:6], chkAlgBlake2b) {
+		return nil, fmt.Errorf("unsupported signing key format in %s", path)
+	}
+	kdfAlg, salt, keynum := data[2:4], data[6:38], append([]byte{}, data[54:]...)
+
+	switch {
+	case bytes.Equal(kdfAlg, kdfAlgScrypt):
+		pw, err := password()
+		if err != nil {
+			return nil, err
+		}
+		n, r, p := scryptParams(binary.LittleEndian.Uint64(data[38:46]), binary.LittleEndian.Uint64(data[46:54]))
+		stream, err := scrypt.Key([]byte(pw), salt, n, r, p, len(keynum))
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting signing key: %w", err)
+		}
+		for i := range keynum {
+			keynum[i] ^= stream[i]
+		}
+	case !bytes.Equal(kdfAlg, kdfAlgNone):
+		return nil, fmt.Errorf("unsupported signing key encryption in %s", path)
+	}
+
+	k := &SigningKey{id: keynum[:8], secret: ed25519.PrivateKey(keynum[8:72])}
+	h, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(sigAlgEd)
+	h.Write(k.id)
+	h.Write(k.secret)
+	if !bytes.Equal(h.Sum(nil), keynum[72:]) {
+		return nil, fmt.Errorf("error decrypting signing key: incorrect password")
+	}
+	return k, nil
+}
+
+// Sign returns a minisign signature of the file at path.
+func (k *SigningKey) Sign(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	hash := blake2b.Sum512(data)
+
+	sig := make([]byte, 0, 74)
+	sig = append(sig, sigAlgHashedEd...)
+	sig = append(sig, k.id...)
+	sig = append(sig, ed25519.Sign(k.secret, hash[:])...)
+
+	trusted := fmt.Sprintf("timestamp:%d\tfile:%s\thashed", time.Now().Unix(), filepath.Base(path))
+	global := ed25519.Sign(k.secret, append(append([]byte{}, sig[10:]...), trusted...))
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "untrusted comment: signature from fastly CLI secret key\n")
+	fmt.Fprintf(&b, "%s\n", base64.StdEncoding.EncodeToString(sig))
+	fmt.Fprintf(&b, "trusted comment: %s\n", trusted)
+	fmt.Fprintf(&b, "%s\n", base64.StdEncoding.EncodeToString(global))
+	return b.Bytes(), nil
+}
+
+// VerifySignature checks the minisign signature at sigPath is a valid
+// signature of the file at path by the minisign public key at keyPath. The
+// signature's trusted comment is returned.
+func VerifySignature(path, sigPath, keyPath string) (string, error) {
+	key, err := readKeyFile(keyPath)
+	if err != nil {
+		return "", err
+	}
+	if len(key) != 42 || !bytes.Equal(key[:2], sigAlgEd) {
+		return "", fmt.Errorf("unsupported public key format in %s", keyPath)
+	}
+	keyID, pub := key[2:10], ed25519.PublicKey(key[10:])
+
+	lines, err := readLines(sigPath)
+	if err != nil {
+		return "", err
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return "", fmt.Errorf("invalid signature file %s", sigPath)
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 74 {
+		return "", fmt.Errorf("invalid signature file %s", sigPath)
+	}
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return "", fmt.Errorf("invalid signature file %s", sigPath)
+	}
+	if !bytes.Equal(sig[2:10], keyID) {
+		return "", fmt.Errorf("%w: the package was signed with a different key", ErrSignatureMismatch)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case bytes.Equal(sig[:2], sigAlgHashedEd):
+		hash := blake2b.Sum512(data)
+		data = hash[:]
+	case !bytes.Equal(sig[:2], sigAlgEd):
+		return "", fmt.Errorf("unsupported signature algorithm in %s", sigPath)
+	}
+	if !ed25519.Verify(pub, data, sig[10:]) {
+		return "", ErrSignatureMismatch
+	}
+
+	trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if !ed25519.Verify(pub, append(append([]byte{}, sig[10:]...), trusted...), global) {
+		return "", fmt.Errorf("%w: the trusted comment was modified", ErrSignatureMismatch)
+	}
+	return trusted, nil
+}
+
+// scryptParams derives the scrypt parameters from the libsodium opslimit and
+// memlimit values stored in a minisign secret key.
+func scryptParams(opslimit, memlimit uint64) (n, r, p int) {
+	if opslimit < 32768 {
+		opslimit = 32768
+	}
+	r = 8
+	var maxN uint64
+	if opslimit < memlimit/32 {
+		p = 1
+		maxN = opslimit / uint64(r*4)
+	} else {
+		maxN = memlimit / uint64(r*128)
+	}
+	logN := 1
+	for ; logN < 63; logN++ {
+		if uint64(1)<<logN > maxN/2 {
+			break
+		}
+	}
+	if p == 0 {
+		maxRP := (opslimit / 4) / (uint64(1) << logN)
+		if maxRP > 0x3fffffff {
+			maxRP = 0x3fffffff
+		}
+		p = int(maxRP) / r
+	}
+	return 1 << logN, r, p
+}
+
+// readKeyFile decodes the key from a minisign key file, which is the line
+// following the untrusted comment.
+func readKeyFile(path string) ([]byte, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "untrusted comment:") {
+		return nil, fmt.Errorf("invalid key file %s", path)
+	}
+	key, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	return key, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // #nosec G307
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	return lines, scanner.Err()
+}
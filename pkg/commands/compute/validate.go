@@ -8,6 +8,7 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/report"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/mholt/archiver/v3"
 )
@@ -18,6 +19,8 @@ func NewValidateCommand(parent cmd.Registerer, globals *config.Data) *ValidateCo
 	c.Globals = globals
 	c.CmdClause = parent.Command("validate", "Validate a Compute@Edge package")
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Required().Short('p').StringVar(&c.path)
+	c.CmdClause.Flag("report", "Write the validation findings as a report (junit, sarif)").HintOptions(report.Formats...).EnumVar(&c.report, report.Formats...)
+	c.CmdClause.Flag("report-file", "Path to write the --report output to (default: stdout)").StringVar(&c.reportFile)
 	return &c
 }
 
@@ -31,10 +34,37 @@ func (c *ValidateCommand) Exec(in io.Reader, out io.Writer) error {
 		return fmt.Errorf("error reading file path: %w", err)
 	}
 
-	if err := validate(p, nil); err != nil {
+	found, inspectErr := inspect(p, nil)
+	err = inspectErr
+	if err == nil {
+		err = requireFiles(found)
+	}
+	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Path": c.path,
 		})
+	}
+
+	// The report is written regardless of whether the package is valid, as
+	// the findings are most useful to CI when validation fails.
+	if c.report != "" {
+		r := packageReport(p, found, inspectErr)
+		if c.reportFile == "" {
+			if rerr := report.Write(out, c.report, r); rerr != nil {
+				return rerr
+			}
+			return err
+		}
+		if rerr := report.WriteFile(c.reportFile, c.report, r); rerr != nil {
+			c.Globals.ErrLog.AddWithContext(rerr, map[string]interface{}{
+				"Report File": c.reportFile,
+			})
+			return rerr
+		}
+		text.Info(out, "Wrote %s report to %s", c.report, c.reportFile)
+	}
+
+	if err != nil {
 		return err
 	}
 
@@ -45,12 +75,53 @@ func (c *ValidateCommand) Exec(in io.Reader, out io.Writer) error {
 // ValidateCommand validates a package archive.
 type ValidateCommand struct {
 	cmd.Base
-	path string
+	path       string
+	report     string
+	reportFile string
+}
+
+// packageReport converts the result of inspecting a package into a report.
+//
+// NOTE: If the package couldn't be opened then it's not possible to know which
+// of the required files it contains, so only the archive check is reported.
+func packageReport(path string, found map[string]bool, inspectErr error) report.Report {
+	r := report.Report{Command: "compute validate"}
+
+	archive := report.Finding{
+		Rule:     "package-archive",
+		Name:     "package is a readable tar.gz archive",
+		Location: path,
+	}
+	if inspectErr != nil {
+		archive.Failed = true
+		archive.Message = inspectErr.Error()
+	}
+	r.Findings = append(r.Findings, archive)
+	if found == nil {
+		return r
+	}
+
+	for _, name := range requiredFiles {
+		f := report.Finding{
+			Rule:     "package-required-file",
+			Name:     fmt.Sprintf("package contains %s", name),
+			Location: path,
+		}
+		if !found[name] {
+			f.Failed = true
+			f.Message = fmt.Sprintf("package must contain a %s file", name)
+		}
+		r.Findings = append(r.Findings, f)
+	}
+	return r
 }
 
 // FileValidator validates a file.
 type FileValidator func(archiver.File) error
 
+// requiredFiles is the list of files every package must contain.
+var requiredFiles = []string{"fastly.toml", "main.wasm"}
+
 // validate is a utility function to determine whether a package is valid.
 // It attemptes to unarchive and read a tar.gz file from a specfic path,
 // if successful, it then iterates through (streams) each file in the archive
@@ -61,22 +132,33 @@ type FileValidator func(archiver.File) error
 //
 // NOTE: This function is also called by the `deploy` command.
 func validate(path string, fileValidator FileValidator) error {
+	found, err := inspect(path, fileValidator)
+	if err != nil {
+		return err
+	}
+	return requireFiles(found)
+}
+
+// inspect streams each file in the package archive, recording which of the
+// required files were found. The returned map is nil if the archive couldn't
+// be opened.
+func inspect(path string, fileValidator FileValidator) (map[string]bool, error) {
 	file, err := os.Open(filepath.Clean(path))
 	if err != nil {
-		return fmt.Errorf("error reading package: %w", err)
+		return nil, fmt.Errorf("error reading package: %w", err)
 	}
 	defer file.Close() // #nosec G307
 
 	tar := archiver.NewTarGz()
 	err = tar.Open(file, 0)
 	if err != nil {
-		return fmt.Errorf("error unarchiving package: %w", err)
+		return nil, fmt.Errorf("error unarchiving package: %w", err)
 	}
 	defer tar.Close()
 
-	files := map[string]bool{
-		"fastly.toml": false,
-		"main.wasm":   false,
+	files := make(map[string]bool, len(requiredFiles))
+	for _, k := range requiredFiles {
+		files[k] = false
 	}
 
 	for {
@@ -85,7 +167,7 @@ func validate(path string, fileValidator FileValidator) error {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("error reading package: %w", err)
+			return files, fmt.Errorf("error reading package: %w", err)
 		}
 
 		for k := range files {
@@ -97,21 +179,25 @@ func validate(path string, fileValidator FileValidator) error {
 		if fileValidator != nil {
 			if err = fileValidator(f); err != nil {
 				f.Close()
-				return err
+				return files, err
 			}
 		}
 
 		err = f.Close()
 		if err != nil {
-			return fmt.Errorf("error closing file: %w", err)
+			return files, fmt.Errorf("error closing file: %w", err)
 		}
 	}
 
-	for k, found := range files {
-		if !found {
+	return files, nil
+}
+
+// requireFiles returns an error for the first required file that wasn't found.
+func requireFiles(found map[string]bool) error {
+	for _, k := range requiredFiles {
+		if !found[k] {
 			return fmt.Errorf("error validating package: package must contain a %s file", k)
 		}
 	}
-
 	return nil
 }
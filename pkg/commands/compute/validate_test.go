@@ -19,6 +19,18 @@ func TestValidate(t *testing.T) {
 			WantError:  "",
 			WantOutput: "Validated package",
 		},
+		{
+			Name:       "success with junit report",
+			Args:       args("compute validate --package pkg/package.tar.gz --report junit"),
+			WantError:  "",
+			WantOutput: `<testsuite name="compute validate" tests="3" failures="0">`,
+		},
+		{
+			Name:       "missing package with sarif report",
+			Args:       args("compute validate --package pkg/missing.tar.gz --report sarif"),
+			WantError:  "error reading package",
+			WantOutput: `"ruleId": "package-archive"`,
+		},
 	}
 	for _, testcase := range scenarios {
 		t.Run(testcase.Name, func(t *testing.T) {
@@ -0,0 +1,170 @@
+package curl_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/curl"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestCurl(t *testing.T) {
+	args := testutil.Args
+	headers := http.Header{
+		"Age":               []string{"120"},
+		"Cache-Control":     []string{"public, max-age=3600"},
+		"Content-Type":      []string{"text/html"},
+		"Fastly-Debug-Path": []string{"(D cache-lcy19238-LCY 1656000000) (F cache-ams21234-AMS 1656000000)"},
+		"X-Cache":           []string{"MISS, HIT"},
+		"X-Cache-Hits":      []string{"0, 3"},
+		"X-Served-By":       []string{"cache-ams21234-AMS, cache-lcy19238-LCY"},
+	}
+	for _, testcase := range []struct {
+		name        string
+		args        []string
+		api         mock.API
+		client      api.HTTPClient
+		wantError   string
+		wantOutputs []string
+	}{
+		{
+			name:      "missing url",
+			args:      args("curl"),
+			wantError: "error parsing arguments: required argument 'url' not provided",
+		},
+		{
+			name:      "invalid header",
+			args:      args("curl https://www.example.com/ -H nocolon"),
+			wantError: "invalid header 'nocolon'",
+		},
+		{
+			name:      "request error",
+			args:      args("curl https://www.example.com/"),
+			client:    mock.HTMLClient(nil, errors.New("connection refused")),
+			wantError: "error executing request: connection refused",
+		},
+		{
+			name:   "cache diagnostics",
+			args:   args("curl https://www.example.com/ --debug-cache"),
+			client: mock.HTMLClient(response(headers), nil),
+			wantOutputs: []string{
+				"HTTP/1.1 200 OK\n",
+				"NODE                POP  CACHE  HITS\n" +
+					"cache-ams21234-AMS  AMS  MISS   0\n" +
+					"cache-lcy19238-LCY  LCY  HIT    3\n",
+				"Fastly-Debug-Path  (D cache-lcy19238-LCY 1656000000) (F cache-ams21234-AMS 1656000000)\n",
+				"X-Cache            MISS, HIT\n",
+			},
+		},
+		{
+			name:      "unknown pop",
+			args:      args("--token x curl https://www.example.com/ --pop xyz"),
+			api:       mock.API{AllDatacentersFn: datacenters},
+			wantError: "unknown POP 'XYZ'",
+		},
+		{
+			name:   "served by another pop",
+			args:   args("--token x curl https://www.example.com/ --pop ams"),
+			api:    mock.API{AllDatacentersFn: datacenters},
+			client: mock.HTMLClient(response(headers), nil),
+			wantOutputs: []string{
+				"The request was served by the LCY POP rather than AMS.",
+			},
+		},
+		{
+			name:   "not served by fastly",
+			args:   args("curl https://www.example.com/"),
+			client: mock.HTMLClient(response(http.Header{}), nil),
+			wantOutputs: []string{
+				"The response has no X-Served-By header",
+			},
+		},
+		{
+			name:      "invalid ip",
+			args:      args("--token x curl https://www.example.com/ --ip 1.2.3"),
+			wantError: "invalid IP address '1.2.3'",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			opts.HTTPClient = testcase.client
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+func TestCurlIP(t *testing.T) {
+	var host string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.Header().Set("X-Served-By", "cache-ams21234-AMS")
+		w.Header().Set("X-Cache", "HIT")
+	}))
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	args := testutil.Args(fmt.Sprintf("--token x curl http://www.example.com:%s/ --ip 127.0.0.1", u.Port()))
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		AllIPsFn: func() (fastly.IPAddrs, fastly.IPAddrs, error) {
+			return fastly.IPAddrs{"151.101.0.0/16"}, fastly.IPAddrs{"2a04:4e40::/32"}, nil
+		},
+	})
+	if err := app.Run(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertString(t, "www.example.com:"+u.Port(), host)
+	testutil.AssertStringContains(t, stdout.String(), "127.0.0.1 isn't one of Fastly's public IPs")
+	testutil.AssertStringContains(t, stdout.String(), "cache-ams21234-AMS  AMS  HIT")
+}
+
+func TestServedBy(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Served-By", "cache-ams21234-AMS, cache-lcy19238-LCY")
+	h.Set("X-Cache", "MISS")
+	want := []curl.Node{
+		{Name: "cache-ams21234-AMS", POP: "AMS", Cache: "MISS"},
+		{Name: "cache-lcy19238-LCY", POP: "LCY"},
+	}
+	testutil.AssertEqual(t, want, curl.ServedBy(h))
+}
+
+func response(h http.Header) *http.Response {
+	return &http.Response{
+		Proto:      "HTTP/1.1",
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     h,
+		Body:       io.NopCloser(strings.NewReader("<html></html>")),
+	}
+}
+
+func datacenters() ([]fastly.Datacenter, error) {
+	return []fastly.Datacenter{
+		{Code: "AMS", Name: "Amsterdam"},
+		{Code: "LCY", Name: "London City"},
+	}, nil
+}
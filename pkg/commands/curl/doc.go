@@ -0,0 +1,3 @@
+// Package curl contains a command for making HTTP requests through Fastly and
+// displaying the cache diagnostics of the response.
+package curl
@@ -0,0 +1,291 @@
+package curl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// DebugHeaders are the response headers that describe how Fastly handled a
+// request. Any header prefixed with Fastly-Debug- is also displayed.
+var DebugHeaders = []string{
+	"Age",
+	"Cache-Control",
+	"Expires",
+	"Surrogate-Control",
+	"Surrogate-Key",
+	"Vary",
+	"Via",
+	"X-Cache",
+	"X-Cache-Hits",
+	"X-Served-By",
+	"X-Timer",
+}
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+
+	debugCache bool
+	headers    []string
+	ip         string
+	method     string
+	pop        string
+	url        string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("curl", "Make an HTTP request through Fastly and display the cache diagnostics of the response")
+	c.CmdClause.Arg("url", "URL to request").Required().StringVar(&c.url)
+	c.CmdClause.Flag("debug-cache", "Send a Fastly-Debug header so the response includes Fastly's cache debugging headers").BoolVar(&c.debugCache)
+	c.CmdClause.Flag("header", "Request header in the form 'Name: value' (repeat to send several headers)").Short('H').StringsVar(&c.headers)
+	c.CmdClause.Flag("ip", "Send the request to this IP address instead of resolving the URL's host (see 'fastly ip-list')").StringVar(&c.ip)
+	c.CmdClause.Flag("pop", "Code of the POP expected to serve the request, e.g. AMS (see 'fastly pops')").StringVar(&c.pop)
+	c.CmdClause.Flag("request", "HTTP method to use").Short('X').Default(http.MethodGet).StringVar(&c.method)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	req, err := http.NewRequest(strings.ToUpper(c.method), c.url, nil)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error constructing request: %w", err),
+			Remediation: "Check the URL is valid, e.g. https://www.example.com/",
+		}
+	}
+	req.Header.Set("User-Agent", useragent.Name)
+	for _, h := range c.headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid header '%s'", h),
+				Remediation: "Headers must be in the form 'Name: value'.",
+			}
+		}
+		req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	if c.debugCache {
+		req.Header.Set("Fastly-Debug", "1")
+	}
+
+	if c.pop != "" {
+		c.pop = strings.ToUpper(c.pop)
+		if err := c.validatePOP(); err != nil {
+			return err
+		}
+	}
+
+	client := c.Globals.HTTPClient
+	if c.ip != "" {
+		if err := c.validateIP(out); err != nil {
+			return err
+		}
+		client = spoofClient(c.ip)
+	}
+
+	start := c.Globals.Clock.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close() // #nosec G307
+	size, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	elapsed := c.Globals.Clock.Since(start)
+
+	fmt.Fprintf(out, "%s %s\n", resp.Proto, resp.Status)
+	text.Output(out, "%d byte body received in %s", size, elapsed.Round(time.Millisecond))
+
+	nodes := ServedBy(resp.Header)
+	if len(nodes) > 0 {
+		text.Break(out)
+		t := text.NewTable(out)
+		t.AddHeader("NODE", "POP", "CACHE", "HITS")
+		for _, n := range nodes {
+			t.AddLine(n.Name, n.POP, n.Cache, n.Hits)
+		}
+		t.Print()
+	}
+
+	names := debugHeaderNames(resp.Header, c.Globals.Verbose())
+	if len(names) > 0 {
+		text.Break(out)
+		t := text.NewTable(out)
+		t.AddHeader("HEADER", "VALUE")
+		for _, name := range names {
+			for _, v := range resp.Header.Values(name) {
+				t.AddLine(name, v)
+			}
+		}
+		t.Print()
+	}
+
+	if len(nodes) == 0 {
+		text.Warning(out, "The response has no X-Served-By header, so it doesn't appear to have been served by Fastly.")
+	} else if c.pop != "" && nodes[len(nodes)-1].POP != c.pop {
+		text.Warning(out, "The request was served by the %s POP rather than %s. Use --ip with an address of the %s POP to send the request there.", nodes[len(nodes)-1].POP, c.pop, c.pop)
+	}
+	if c.debugCache && len(nodes) > 0 && resp.Header.Get("Fastly-Debug-Path") == "" {
+		text.Info(out, "Fastly didn't return any Fastly-Debug headers. The service's VCL may remove them.")
+	}
+	return nil
+}
+
+// validatePOP checks the --pop flag is a known Fastly POP.
+func (c *RootCommand) validatePOP() error {
+	_, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+	dcs, err := c.Globals.APIClient.AllDatacenters()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	for _, dc := range dcs {
+		if dc.Code == c.pop {
+			return nil
+		}
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("unknown POP '%s'", c.pop),
+		Remediation: "Run 'fastly pops' to see the codes of Fastly's POPs.",
+	}
+}
+
+// validateIP checks the --ip flag is an IP address, and warns if it isn't one
+// of Fastly's public IPs.
+func (c *RootCommand) validateIP(out io.Writer) error {
+	ip := net.ParseIP(c.ip)
+	if ip == nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid IP address '%s'", c.ip),
+			Remediation: "Run 'fastly ip-list' to see Fastly's public IP ranges.",
+		}
+	}
+
+	_, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+	v4, v6, err := c.Globals.APIClient.AllIPs()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	for _, cidr := range append(v4, v6...) {
+		if _, n, err := net.ParseCIDR(cidr); err == nil && n.Contains(ip) {
+			return nil
+		}
+	}
+	text.Warning(out, "%s isn't one of Fastly's public IPs (see 'fastly ip-list').", c.ip)
+	return nil
+}
+
+// spoofClient returns a client that connects to ip regardless of the host in
+// the request URL. The URL's host is still used for the Host header and TLS
+// server name, like curl's --resolve option.
+func spoofClient(ip string) api.HTTPClient {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				_, port, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+				return dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			},
+		},
+	}
+}
+
+// Node describes how a Fastly cache node handled a request.
+type Node struct {
+	Name  string
+	POP   string
+	Cache string
+	Hits  string
+}
+
+// ServedBy returns the cache nodes listed by the X-Served-By header, matched
+// up with the corresponding X-Cache and X-Cache-Hits values. When a request
+// is shielded the shield node comes first and the edge node last.
+func ServedBy(h http.Header) []Node {
+	names := splitList(h.Get("X-Served-By"))
+	caches := splitList(h.Get("X-Cache"))
+	hits := splitList(h.Get("X-Cache-Hits"))
+
+	nodes := make([]Node, 0, len(names))
+	for i, name := range names {
+		n := Node{Name: name}
+		if j := strings.LastIndex(name, "-"); j >= 0 {
+			n.POP = name[j+1:]
+		}
+		if i < len(caches) {
+			n.Cache = caches[i]
+		}
+		if i < len(hits) {
+			n.Hits = hits[i]
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return parts
+}
+
+// debugHeaderNames returns the sorted names of the response headers to
+// display. All headers are displayed in verbose mode.
+func debugHeaderNames(h http.Header, verbose bool) []string {
+	var names []string
+	for name := range h {
+		if verbose || strings.HasPrefix(name, "Fastly-Debug-") {
+			names = append(names, name)
+		}
+	}
+	if !verbose {
+		for _, name := range DebugHeaders {
+			if _, ok := h[name]; ok {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,138 @@
+package dashboard_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/dashboard"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestDashboardRequiresTerminal(t *testing.T) {
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("dashboard"), &stdout)
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "the dashboard requires an interactive terminal")
+}
+
+type fakeSource struct{}
+
+func (fakeSource) Services() ([]dashboard.Service, error) {
+	return []dashboard.Service{
+		{ID: "123", Name: "api", Type: "vcl", ActiveVersion: 3},
+		{ID: "456", Name: "www", Type: "wasm", ActiveVersion: 7},
+	}, nil
+}
+
+func (fakeSource) Events(serviceID string) ([]dashboard.Event, error) {
+	return []dashboard.Event{
+		{Type: "version.activate", Description: "Activated version of " + serviceID, CreatedAt: testutil.Date},
+	}, nil
+}
+
+func (fakeSource) Detail(serviceID string) (dashboard.Detail, error) {
+	return dashboard.Detail{
+		Service:       dashboard.Service{ID: serviceID, Name: "www", Type: "wasm", ActiveVersion: 7},
+		LatestVersion: 8,
+		Domains:       []string{"www.example.com"},
+		Backends:      []string{"origin (origin.example.com:443)"},
+	}, nil
+}
+
+// update applies msg and then the results of any commands it returns.
+func update(m dashboard.Model, msg dashboard.Msg) dashboard.Model {
+	for msg != nil {
+		var cmd dashboard.Cmd
+		m, cmd = m.Update(msg)
+		msg = nil
+		if cmd != nil {
+			msg = cmd()
+		}
+	}
+	return m
+}
+
+func TestModel(t *testing.T) {
+	m := dashboard.NewModel(fakeSource{})
+	m = update(m, m.Init()())
+
+	view := m.View()
+	testutil.AssertStringContains(t, view, "2 services")
+	testutil.AssertStringContains(t, view, "> api")
+	testutil.AssertStringContains(t, view, "Activated version of 123")
+	testutil.AssertStringContains(t, view, "waiting for realtime stats")
+
+	m = update(m, dashboard.KeyMsg("down"))
+	view = m.View()
+	testutil.AssertStringContains(t, view, "> www")
+	testutil.AssertStringContains(t, view, "Activated version of 456")
+
+	// The cursor stops at the last service.
+	m = update(m, dashboard.KeyMsg("j"))
+	testutil.AssertEqual(t, 1, m.Selected)
+
+	// Stats for a service that isn't selected aren't shown, and a service
+	// keeps only the most recent samples.
+	m = update(m, dashboard.StatsMsg{ServiceID: "123", Requests: 99})
+	for i := 0; i < dashboard.MaxSamples+5; i++ {
+		m = update(m, dashboard.StatsMsg{ServiceID: "456", Requests: float64(i), Errors: 2})
+	}
+	testutil.AssertEqual(t, dashboard.MaxSamples, len(m.Requests["456"]))
+	testutil.AssertStringContains(t, m.View(), "64 req/s  2 5xx/s")
+
+	m = update(m, dashboard.KeyMsg("enter"))
+	view = m.View()
+	testutil.AssertStringContains(t, view, "Service www (456)")
+	testutil.AssertStringContains(t, view, "Latest version: 8")
+	testutil.AssertStringContains(t, view, "www.example.com")
+	testutil.AssertStringContains(t, view, "origin (origin.example.com:443)")
+
+	m = update(m, dashboard.KeyMsg("esc"))
+	testutil.AssertStringContains(t, m.View(), "> www")
+
+	// A refresh keeps the cursor on the same service.
+	m = update(m, dashboard.TickMsg{})
+	testutil.AssertEqual(t, 1, m.Selected)
+
+	m = update(m, dashboard.KeyMsg("q"))
+	testutil.AssertBool(t, true, m.Quitting)
+}
+
+func TestModelTruncatesToWidth(t *testing.T) {
+	m := dashboard.NewModel(fakeSource{})
+	m = update(m, m.Init()())
+	m.Width = 20
+	for _, line := range strings.Split(strings.TrimSuffix(m.View(), "\n"), "\n") {
+		if n := len([]rune(line)); n > 20 {
+			t.Errorf("line %q is %d runes wide, want at most 20", line, n)
+		}
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		want  []string
+	}{
+		{"q", []string{"q"}},
+		{"\x1b[A\x1b[B", []string{"up", "down"}},
+		{"\x1bOC", []string{"right"}},
+		{"\r", []string{"enter"}},
+		{"\x1b", []string{"esc"}},
+		{"\x1bq", []string{"esc", "q"}},
+		{"\x1b[15~j", []string{"j"}},
+		{"\x03\x7f", []string{"ctrl+c", "backspace"}},
+	} {
+		t.Run(testcase.input, func(t *testing.T) {
+			testutil.AssertEqual(t, testcase.want, dashboard.ParseKeys([]byte(testcase.input)))
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	testutil.AssertString(t, "", dashboard.Sparkline(nil))
+	testutil.AssertString(t, "▁▁▁", dashboard.Sparkline([]float64{5, 5, 5}))
+	testutil.AssertString(t, "▁▄█", dashboard.Sparkline([]float64{0, 50, 100}))
+}
@@ -0,0 +1,3 @@
+// Package dashboard contains an interactive terminal dashboard showing the
+// services in a Fastly account, their realtime traffic and recent events.
+package dashboard
@@ -0,0 +1,407 @@
+package dashboard
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSamples is the number of per-second stats samples kept for each service,
+// and so the width of its sparkline.
+const MaxSamples = 60
+
+// Service is a row in the dashboard's service list.
+type Service struct {
+	ID            string
+	Name          string
+	Type          string
+	ActiveVersion int
+}
+
+// Event is a recent audit log event for a service.
+type Event struct {
+	Type        string
+	Description string
+	CreatedAt   time.Time
+}
+
+// Detail is the describe view of a service.
+type Detail struct {
+	Service
+	Comment       string
+	LatestVersion int
+	UpdatedAt     *time.Time
+	Domains       []string
+	Backends      []string
+}
+
+// Source fetches the data displayed by the dashboard.
+type Source interface {
+	Services() ([]Service, error)
+	Events(serviceID string) ([]Event, error)
+	Detail(serviceID string) (Detail, error)
+}
+
+// Msg is an input to Model.Update, e.g. a key press or the result of a fetch.
+type Msg interface{}
+
+// Cmd performs I/O outside of Model.Update and returns its result as a Msg.
+type Cmd func() Msg
+
+// KeyMsg is a key press, e.g. "up", "enter" or "q" (see ParseKeys).
+type KeyMsg string
+
+// TickMsg asks the dashboard to refresh the service list and events.
+type TickMsg struct{}
+
+// ServicesMsg is the result of fetching the service list.
+type ServicesMsg struct {
+	Services []Service
+	Err      error
+}
+
+// EventsMsg is the result of fetching a service's events.
+type EventsMsg struct {
+	ServiceID string
+	Events    []Event
+	Err       error
+}
+
+// DetailMsg is the result of fetching a service's describe view.
+type DetailMsg struct {
+	Detail Detail
+	Err    error
+}
+
+// StatsMsg is a one second sample of a service's realtime stats.
+type StatsMsg struct {
+	ServiceID string
+	Requests  float64
+	Errors    float64
+	Err       error
+}
+
+// Model is the state of the dashboard. It's updated only by Update, and
+// rendered by View, so that it can be tested without a terminal.
+type Model struct {
+	Services []Service
+	Selected int
+	// Detail is set while the describe view of the selected service is open.
+	Detail   *Detail
+	Events   []Event
+	Requests map[string][]float64
+	Errors   map[string]float64
+	// Status is the most recent error, shown at the bottom of the screen.
+	Status   string
+	Width    int
+	Height   int
+	Quitting bool
+
+	source Source
+}
+
+// NewModel returns a Model that fetches its data from source.
+func NewModel(source Source) Model {
+	return Model{
+		Requests: make(map[string][]float64),
+		Errors:   make(map[string]float64),
+		source:   source,
+	}
+}
+
+// Init returns the Cmd that loads the service list.
+func (m Model) Init() Cmd {
+	return m.fetchServices
+}
+
+// SelectedService returns the service under the cursor.
+func (m Model) SelectedService() (Service, bool) {
+	if m.Selected < 0 || m.Selected >= len(m.Services) {
+		return Service{}, false
+	}
+	return m.Services[m.Selected], true
+}
+
+// Update applies msg to the model, returning the new model and any Cmd to run.
+func (m Model) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case KeyMsg:
+		return m.key(msg)
+
+	case TickMsg:
+		if m.Detail != nil {
+			return m, m.fetchDetail(m.Detail.ID)
+		}
+		return m, m.fetchServices
+
+	case ServicesMsg:
+		if msg.Err != nil {
+			m.Status = fmt.Sprintf("error listing services: %s", msg.Err)
+			return m, nil
+		}
+		// Keep the cursor on the same service if it still exists.
+		current, _ := m.SelectedService()
+		m.Services = msg.Services
+		m.Selected = 0
+		for i, s := range m.Services {
+			if s.ID == current.ID {
+				m.Selected = i
+			}
+		}
+		m.Status = ""
+		return m, m.fetchEvents()
+
+	case EventsMsg:
+		if s, ok := m.SelectedService(); !ok || s.ID != msg.ServiceID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.Status = fmt.Sprintf("error fetching events: %s", msg.Err)
+			return m, nil
+		}
+		m.Events = msg.Events
+		return m, nil
+
+	case DetailMsg:
+		if msg.Err != nil {
+			m.Status = fmt.Sprintf("error describing service: %s", msg.Err)
+			return m, nil
+		}
+		if s, ok := m.SelectedService(); ok && s.ID == msg.Detail.ID {
+			d := msg.Detail
+			m.Detail = &d
+		}
+		return m, nil
+
+	case StatsMsg:
+		if msg.Err != nil {
+			m.Status = fmt.Sprintf("error fetching stats: %s", msg.Err)
+			return m, nil
+		}
+		samples := append(m.Requests[msg.ServiceID], msg.Requests)
+		if len(samples) > MaxSamples {
+			samples = samples[len(samples)-MaxSamples:]
+		}
+		m.Requests[msg.ServiceID] = samples
+		m.Errors[msg.ServiceID] = msg.Errors
+		return m, nil
+	}
+	return m, nil
+}
+
+// key handles a key press.
+func (m Model) key(k KeyMsg) (Model, Cmd) {
+	switch k {
+	case "q", "ctrl+c":
+		m.Quitting = true
+		return m, nil
+	case "r":
+		return m.Update(TickMsg{})
+	}
+
+	if m.Detail != nil {
+		switch k {
+		case "esc", "backspace", "left", "h":
+			m.Detail = nil
+		}
+		return m, nil
+	}
+
+	prev := m.Selected
+	switch k {
+	case "up", "k":
+		m.Selected--
+	case "down", "j":
+		m.Selected++
+	case "home", "g":
+		m.Selected = 0
+	case "end", "G":
+		m.Selected = len(m.Services) - 1
+	case "enter", "right", "l":
+		if s, ok := m.SelectedService(); ok {
+			return m, m.fetchDetail(s.ID)
+		}
+		return m, nil
+	}
+	if m.Selected >= len(m.Services) {
+		m.Selected = len(m.Services) - 1
+	}
+	if m.Selected < 0 {
+		m.Selected = 0
+	}
+	if m.Selected != prev {
+		m.Events = nil
+		return m, m.fetchEvents()
+	}
+	return m, nil
+}
+
+func (m Model) fetchServices() Msg {
+	services, err := m.source.Services()
+	return ServicesMsg{Services: services, Err: err}
+}
+
+func (m Model) fetchEvents() Cmd {
+	s, ok := m.SelectedService()
+	if !ok {
+		return nil
+	}
+	return func() Msg {
+		events, err := m.source.Events(s.ID)
+		return EventsMsg{ServiceID: s.ID, Events: events, Err: err}
+	}
+}
+
+func (m Model) fetchDetail(serviceID string) Cmd {
+	return func() Msg {
+		d, err := m.source.Detail(serviceID)
+		return DetailMsg{Detail: d, Err: err}
+	}
+}
+
+// View renders the model.
+func (m Model) View() string {
+	var b strings.Builder
+	if m.Detail != nil {
+		m.viewDetail(&b)
+	} else {
+		m.viewList(&b)
+	}
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = truncate(l, m.Width)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (m Model) viewList(b *strings.Builder) {
+	fmt.Fprintf(b, "Fastly dashboard%s\n\n", pad(fmt.Sprintf("%d services", len(m.Services)), m.Width-len("Fastly dashboard")))
+	if len(m.Services) == 0 {
+		b.WriteString("  Loading services...\n")
+	}
+
+	// Scroll the list so that the selected service is always visible, leaving
+	// room for the stats, events and help.
+	rows := len(m.Services)
+	if m.Height > 0 {
+		rows = m.Height - 20
+		if rows < 3 {
+			rows = 3
+		}
+	}
+	start := 0
+	if m.Selected >= rows {
+		start = m.Selected - rows + 1
+	}
+	for i := start; i < len(m.Services) && i < start+rows; i++ {
+		s := m.Services[i]
+		cursor := " "
+		if i == m.Selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(b, "%s %-30s %-22s %-7s v%d\n", cursor, truncate(s.Name, 30), s.ID, s.Type, s.ActiveVersion)
+	}
+
+	if s, ok := m.SelectedService(); ok {
+		b.WriteString("\n")
+		m.viewStats(b, s.ID)
+		b.WriteString("\nRecent events\n")
+		if len(m.Events) == 0 {
+			b.WriteString("  No recent events\n")
+		}
+		for _, e := range m.Events {
+			fmt.Fprintf(b, "  %s  %-24s %s\n", e.CreatedAt.UTC().Format("2006-01-02 15:04"), e.Type, e.Description)
+		}
+	}
+
+	b.WriteString("\n↑/↓ select · enter describe · r refresh · q quit\n")
+	if m.Status != "" {
+		b.WriteString(m.Status + "\n")
+	}
+}
+
+func (m Model) viewDetail(b *strings.Builder) {
+	d := m.Detail
+	fmt.Fprintf(b, "Service %s (%s)\n\n", d.Name, d.ID)
+	fmt.Fprintf(b, "Type: %s\n", d.Type)
+	if d.Comment != "" {
+		fmt.Fprintf(b, "Comment: %s\n", d.Comment)
+	}
+	fmt.Fprintf(b, "Active version: %d\n", d.ActiveVersion)
+	fmt.Fprintf(b, "Latest version: %d\n", d.LatestVersion)
+	if d.UpdatedAt != nil {
+		fmt.Fprintf(b, "Updated: %s\n", d.UpdatedAt.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintf(b, "\nDomains (%d)\n", len(d.Domains))
+	for _, name := range d.Domains {
+		fmt.Fprintf(b, "  %s\n", name)
+	}
+	fmt.Fprintf(b, "\nBackends (%d)\n", len(d.Backends))
+	for _, name := range d.Backends {
+		fmt.Fprintf(b, "  %s\n", name)
+	}
+	b.WriteString("\n")
+	m.viewStats(b, d.ID)
+	b.WriteString("\nesc back · r refresh · q quit\n")
+	if m.Status != "" {
+		b.WriteString(m.Status + "\n")
+	}
+}
+
+func (m Model) viewStats(b *strings.Builder, serviceID string) {
+	samples := m.Requests[serviceID]
+	if len(samples) == 0 {
+		b.WriteString("Requests/s  waiting for realtime stats...\n")
+		return
+	}
+	fmt.Fprintf(b, "Requests/s  %s  %.0f req/s  %.0f 5xx/s\n", Sparkline(samples), samples[len(samples)-1], m.Errors[serviceID])
+}
+
+// sparks are the characters used to draw a sparkline, lowest first.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders samples as a line of block characters scaled between the
+// smallest and largest sample.
+func Sparkline(samples []float64) string {
+	if len(samples) == 0 {
+		return ""
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range samples {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	out := make([]rune, len(samples))
+	for i, v := range samples {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparks)-1))
+		}
+		out[i] = sparks[idx]
+	}
+	return string(out)
+}
+
+// truncate shortens s to width runes, or returns it as-is if width isn't
+// positive.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	if width == 1 {
+		return string(r[:1])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// pad right-aligns s in a field of width runes.
+func pad(s string, width int) string {
+	if width <= len(s) {
+		return "  " + s
+	}
+	return strings.Repeat(" ", width-len(s)) + s
+}
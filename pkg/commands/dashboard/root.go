@@ -0,0 +1,180 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"golang.org/x/term"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	events  int
+	refresh time.Duration
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("dashboard", "Browse services, realtime traffic and recent events in an interactive terminal dashboard")
+	c.CmdClause.Flag("events", "Number of recent events to show for the selected service").Default("10").IntVar(&c.events)
+	c.CmdClause.Flag("refresh", "How often to refresh the service list and events").Default("30s").DurationVar(&c.refresh)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	f, ok := in.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the dashboard requires an interactive terminal"),
+			Remediation: "Run the command in a terminal, or use `fastly service list`, `fastly stats realtime` and `fastly service activity` from scripts.",
+		}
+	}
+	if c.refresh <= 0 {
+		return fmt.Errorf("--refresh must be positive")
+	}
+
+	source := apiSource{client: c.Globals.APIClient, events: c.events}
+	err := run(c.Globals.Context, f, out, NewModel(source), c.stats, c.refresh)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	return nil
+}
+
+// stats streams the service's realtime stats until ctx is cancelled.
+func (c *RootCommand) stats(ctx context.Context, serviceID string, send func(Msg)) {
+	var timestamp uint64
+	for ctx.Err() == nil {
+		var envelope struct {
+			Timestamp uint64 `json:"timestamp"`
+			Data      []struct {
+				Aggregated struct {
+					Requests  float64 `json:"requests"`
+					Status5xx float64 `json:"status_5xx"`
+				} `json:"aggregated"`
+			} `json:"data"`
+		}
+		err := c.Globals.RTSClient.GetRealtimeStatsJSON(&fastly.GetRealtimeStatsInput{
+			ServiceID: serviceID,
+			Timestamp: timestamp,
+		}, &envelope)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			send(StatsMsg{ServiceID: serviceID, Err: err})
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		timestamp = envelope.Timestamp
+		for _, d := range envelope.Data {
+			send(StatsMsg{ServiceID: serviceID, Requests: d.Aggregated.Requests, Errors: d.Aggregated.Status5xx})
+		}
+	}
+}
+
+// apiSource is a Source backed by the Fastly API.
+type apiSource struct {
+	client api.Interface
+	events int
+}
+
+// Services implements Source.
+func (s apiSource) Services() ([]Service, error) {
+	services, err := s.client.ListServices(&fastly.ListServicesInput{})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Service, 0, len(services))
+	for _, svc := range services {
+		out = append(out, Service{
+			ID:            svc.ID,
+			Name:          svc.Name,
+			Type:          svc.Type,
+			ActiveVersion: int(svc.ActiveVersion),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// Events implements Source.
+func (s apiSource) Events(serviceID string) ([]Event, error) {
+	resp, err := s.client.GetAPIEvents(&fastly.GetAPIEventsFilterInput{
+		ServiceID:  serviceID,
+		MaxResults: s.events,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []Event
+	for _, e := range resp.Events {
+		if e.CreatedAt == nil {
+			continue
+		}
+		out = append(out, Event{Type: e.EventType, Description: e.Description, CreatedAt: *e.CreatedAt})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+// Detail implements Source.
+func (s apiSource) Detail(serviceID string) (Detail, error) {
+	svc, err := s.client.GetServiceDetails(&fastly.GetServiceInput{ID: serviceID})
+	if err != nil {
+		return Detail{}, err
+	}
+	d := Detail{
+		Service: Service{
+			ID:            svc.ID,
+			Name:          svc.Name,
+			Type:          svc.Type,
+			ActiveVersion: svc.ActiveVersion.Number,
+		},
+		Comment:       svc.Comment,
+		LatestVersion: svc.Version.Number,
+		UpdatedAt:     svc.UpdatedAt,
+	}
+
+	// Describe the active version, or the latest if none is active.
+	version := d.ActiveVersion
+	if version == 0 {
+		version = d.LatestVersion
+	}
+	snap := snapshot.New(s.client, serviceID, version)
+	if err := snap.Fetch("domain", "backend"); err != nil {
+		return Detail{}, err
+	}
+	domains, _ := snap.Domains()
+	for _, domain := range domains {
+		d.Domains = append(d.Domains, domain.Name)
+	}
+	backends, _ := snap.Backends()
+	for _, b := range backends {
+		d.Backends = append(d.Backends, fmt.Sprintf("%s (%s:%d)", b.Name, b.Address, b.Port))
+	}
+	return d, nil
+}
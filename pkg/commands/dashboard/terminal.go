@@ -0,0 +1,186 @@
+package dashboard
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Escape sequences used to draw the dashboard.
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	exitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+// keys maps the escape sequences sent by common terminals to key names.
+var keys = map[string]string{
+	"\x1b[A":  "up",
+	"\x1b[B":  "down",
+	"\x1b[C":  "right",
+	"\x1b[D":  "left",
+	"\x1b[H":  "home",
+	"\x1b[F":  "end",
+	"\x1bOA":  "up",
+	"\x1bOB":  "down",
+	"\x1bOC":  "right",
+	"\x1bOD":  "left",
+	"\x1b[1~": "home",
+	"\x1b[4~": "end",
+}
+
+// ParseKeys converts the bytes read from a terminal in raw mode into key
+// names. Unrecognised escape sequences are dropped.
+func ParseKeys(b []byte) []string {
+	var out []string
+	s := string(b)
+	for len(s) > 0 {
+		if s[0] == 0x1b {
+			if len(s) == 1 {
+				out = append(out, "esc")
+				break
+			}
+			matched := false
+			for seq, name := range keys {
+				if strings.HasPrefix(s, seq) {
+					out = append(out, name)
+					s = s[len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// Skip an unknown CSI sequence up to its final byte, or treat
+				// a lone escape followed by another key as esc.
+				if s[1] != '[' && s[1] != 'O' {
+					out = append(out, "esc")
+					s = s[1:]
+					continue
+				}
+				i := 2
+				for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+					i++
+				}
+				if i < len(s) {
+					i++
+				}
+				s = s[i:]
+			}
+			continue
+		}
+
+		switch s[0] {
+		case '\r', '\n':
+			out = append(out, "enter")
+		case 0x7f, 0x08:
+			out = append(out, "backspace")
+		case 0x03:
+			out = append(out, "ctrl+c")
+		default:
+			if s[0] >= 0x20 {
+				out = append(out, s[:1])
+			}
+		}
+		s = s[1:]
+	}
+	return out
+}
+
+// StatsFunc streams one second samples of a service's realtime stats to send
+// until ctx is cancelled.
+type StatsFunc func(ctx context.Context, serviceID string, send func(Msg))
+
+// run draws the dashboard on the terminal until the user quits or ctx is
+// cancelled.
+//
+// NOTE: The goroutine reading key presses can't be interrupted, so it's left
+// blocked on the terminal when the dashboard exits.
+func run(ctx context.Context, in *os.File, out io.Writer, m Model, stats StatsFunc, refresh time.Duration) error {
+	fd := int(in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	io.WriteString(out, enterAltScreen)
+	defer io.WriteString(out, exitAltScreen)
+
+	done := make(chan struct{})
+	defer close(done)
+	msgs := make(chan Msg, 64)
+	send := func(msg Msg) {
+		select {
+		case msgs <- msg:
+		case <-done:
+		}
+	}
+	exec := func(cmd Cmd) {
+		if cmd != nil {
+			go func() { send(cmd()) }()
+		}
+	}
+
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, k := range ParseKeys(buf[:n]) {
+				send(KeyMsg(k))
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	// Only the selected service's realtime stats are streamed.
+	var polling string
+	stopStats := func() {}
+	defer func() { stopStats() }()
+
+	exec(m.Init())
+	for {
+		if s, ok := m.SelectedService(); ok && s.ID != polling {
+			stopStats()
+			stopStats = startStats(ctx, stats, s.ID, send)
+			polling = s.ID
+		}
+
+		if w, h, err := term.GetSize(fd); err == nil {
+			m.Width, m.Height = w, h
+		}
+		io.WriteString(out, clearScreen+strings.ReplaceAll(m.View(), "\n", "\r\n"))
+
+		var msg Msg
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			msg = TickMsg{}
+		case msg = <-msgs:
+		}
+
+		var cmd Cmd
+		m, cmd = m.Update(msg)
+		if m.Quitting {
+			return nil
+		}
+		exec(cmd)
+	}
+}
+
+// startStats streams the service's stats in the background, returning the
+// function that stops it.
+func startStats(ctx context.Context, stats StatsFunc, serviceID string, send func(Msg)) context.CancelFunc {
+	ctx, cancel := context.WithCancel(ctx)
+	go stats(ctx, serviceID, send)
+	return cancel
+}
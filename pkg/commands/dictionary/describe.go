@@ -59,13 +59,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -145,7 +142,7 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 		text.Output(out, "Service ID: %s", dictionary.ServiceID)
 	}
 	text.Output(out, "Version: %d", dictionary.ServiceVersion)
-	text.PrintDictionary(out, "", dictionary)
+	text.PrintDictionary(out, "", dictionary, c.Globals.Formatter())
 
 	if c.Globals.Verbose() {
 		text.Output(out, "Digest: %s", info.Digest)
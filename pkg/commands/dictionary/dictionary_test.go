@@ -81,7 +81,7 @@ func TestDictionaryCreate(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				CreateDictionaryFn: createDictionaryOK,
 			},
-			wantOutput: createDictionaryOutput,
+			wantOutput: autoCloneOutput + createDictionaryOutput,
 		},
 		{
 			args: args("dictionary create --version 1 --service-id 123 --name denylist --write-only true --autoclone"),
@@ -90,7 +90,7 @@ func TestDictionaryCreate(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				CreateDictionaryFn: createDictionaryOK,
 			},
-			wantOutput: createDictionaryOutputWriteOnly,
+			wantOutput: autoCloneOutput + createDictionaryOutputWriteOnly,
 		},
 		{
 			args: args("dictionary create --version 1 --service-id 123 --name denylist --write-only fish --autoclone"),
@@ -98,7 +98,8 @@ func TestDictionaryCreate(t *testing.T) {
 				ListVersionsFn: testutil.ListVersions,
 				CloneVersionFn: testutil.CloneVersionResult(4),
 			},
-			wantError: "strconv.ParseBool: parsing \"fish\": invalid syntax",
+			wantError:  "strconv.ParseBool: parsing \"fish\": invalid syntax",
+			wantOutput: autoCloneOutput,
 		},
 		{
 			args: args("dictionary create --version 1 --service-id 123 --name denylist --autoclone"),
@@ -107,7 +108,8 @@ func TestDictionaryCreate(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				CreateDictionaryFn: createDictionaryDuplicate,
 			},
-			wantError: "Duplicate record",
+			wantError:  "Duplicate record",
+			wantOutput: autoCloneOutput,
 		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
@@ -140,7 +142,7 @@ func TestDeleteDictionary(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				DeleteDictionaryFn: deleteDictionaryOK,
 			},
-			wantOutput: deleteDictionaryOutput,
+			wantOutput: autoCloneOutput + deleteDictionaryOutput,
 		},
 		{
 			args: args("dictionary delete --service-id 123 --version 1 --name allowlist --autoclone"),
@@ -149,7 +151,8 @@ func TestDeleteDictionary(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				DeleteDictionaryFn: deleteDictionaryError,
 			},
-			wantError: errTest.Error(),
+			wantError:  errTest.Error(),
+			wantOutput: autoCloneOutput,
 		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
@@ -229,7 +232,8 @@ func TestUpdateDictionary(t *testing.T) {
 				ListVersionsFn: testutil.ListVersions,
 				CloneVersionFn: testutil.CloneVersionResult(4),
 			},
-			wantError: "error parsing arguments: required flag --new-name or --write-only not provided",
+			wantError:  "error parsing arguments: required flag --new-name or --write-only not provided",
+			wantOutput: autoCloneOutput,
 		},
 		{
 			args: args("dictionary update --service-id 123 --version 1 --name oldname --new-name dict-1 --autoclone"),
@@ -238,7 +242,7 @@ func TestUpdateDictionary(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				UpdateDictionaryFn: updateDictionaryNameOK,
 			},
-			wantOutput: updateDictionaryNameOutput,
+			wantOutput: autoCloneOutput + updateDictionaryNameOutput,
 		},
 		{
 			args: args("dictionary update --service-id 123 --version 1 --name oldname --new-name dict-1 --write-only true --autoclone"),
@@ -247,7 +251,7 @@ func TestUpdateDictionary(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				UpdateDictionaryFn: updateDictionaryNameOK,
 			},
-			wantOutput: updateDictionaryNameOutput,
+			wantOutput: autoCloneOutput + updateDictionaryNameOutput,
 		},
 		{
 			args: args("dictionary update --service-id 123 --version 1 --name oldname --write-only true --autoclone"),
@@ -256,7 +260,14 @@ func TestUpdateDictionary(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				UpdateDictionaryFn: updateDictionaryWriteOnlyOK,
 			},
-			wantOutput: updateDictionaryOutput,
+			wantOutput: autoCloneOutput + updateDictionaryOutput,
+		},
+		{
+			args: args("dictionary update --service-id 123 --version 1 --name oldname --new-name dict-1 --autoclone=dry-run"),
+			api: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			wantOutput: "Service version 1 is not editable, so --autoclone would clone it. No changes were made\n(--autoclone=dry-run).\n",
 		},
 		{
 			args: args("dictionary update -v --service-id 123 --version 1 --name oldname --new-name dict-1 --autoclone"),
@@ -274,8 +285,90 @@ func TestUpdateDictionary(t *testing.T) {
 				CloneVersionFn:     testutil.CloneVersionResult(4),
 				UpdateDictionaryFn: updateDictionaryError,
 			},
+			wantError:  errTest.Error(),
+			wantOutput: autoCloneOutput,
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, stdout.String())
+		})
+	}
+}
+
+func TestDictionaryLookup(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args       []string
+		api        mock.API
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("dictionary lookup --service-id 123 --name geo_map"),
+			wantError: "error parsing arguments: required flag --key not provided",
+		},
+		{
+			args: args("dictionary lookup --service-id 123 --name geo_map --key FR"),
+			api: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetDictionaryFn:     lookupDictionaryOK,
+				GetDictionaryItemFn: lookupDictionaryItemOK,
+			},
+			wantOutput: "geo_map[\"FR\"]: found \"eu-west\"\nResult: \"eu-west\" (from geo_map)\n",
+		},
+		{
+			args: args("dictionary lookup --service-id 123 --version 1 --name geo_map --name fallback_map --key DE"),
+			api: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetDictionaryFn:     lookupDictionaryOK,
+				GetDictionaryItemFn: lookupDictionaryItemOK,
+			},
+			wantOutput: "geo_map[\"DE\"]: not found\nfallback_map[\"DE\"]: found \"eu-central\"\nResult: \"eu-central\" (from fallback_map)\n",
+		},
+		{
+			args: args("dictionary lookup --service-id 123 --name geo_map --key US --default us-east"),
+			api: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetDictionaryFn:     lookupDictionaryOK,
+				GetDictionaryItemFn: lookupDictionaryItemOK,
+			},
+			wantOutput: "geo_map[\"US\"]: not found\nResult: \"us-east\" (default)\n",
+		},
+		{
+			args: args("dictionary lookup --service-id 123 --name geo_map --key US"),
+			api: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetDictionaryFn:     lookupDictionaryOK,
+				GetDictionaryItemFn: lookupDictionaryItemOK,
+			},
+			wantOutput: "geo_map[\"US\"]: not found\nResult: not set\n",
+		},
+		{
+			args: args("dictionary lookup --service-id 123 --name geo_map --key FR"),
+			api: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetDictionaryFn:     lookupDictionaryOK,
+				GetDictionaryItemFn: lookupDictionaryItemError,
+			},
 			wantError: errTest.Error(),
 		},
+		{
+			args:       args("dictionary lookup --name geo_map --name fallback_map --key de --default none --simulate-file testdata/lookup.yaml"),
+			wantOutput: "geo_map[\"de\"]: not found\nfallback_map[\"de\"]: found \"lowercase\"\nResult: \"lowercase\" (from fallback_map)\n",
+		},
+		{
+			args:       args("dictionary lookup --name geo_map --key DE --default none --simulate-file testdata/lookup.yaml --json"),
+			wantOutput: `{"key":"DE","steps":[{"dictionary":"geo_map","found":false}],"set":true,"value":"none","source":"default"}`,
+		},
+		{
+			args:      args("dictionary lookup --name missing --key DE --simulate-file testdata/lookup.yaml"),
+			wantError: "dictionary 'missing' not found in simulate file",
+		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
 			var stdout bytes.Buffer
@@ -435,9 +528,41 @@ func updateDictionaryError(i *fastly.UpdateDictionaryInput) (*fastly.Dictionary,
 	return nil, errTest
 }
 
+func lookupDictionaryOK(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+	return &fastly.Dictionary{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+		ID:             i.Name + "-id",
+	}, nil
+}
+
+func lookupDictionaryItemOK(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	items := map[string]map[string]string{
+		"geo_map-id":      {"FR": "eu-west"},
+		"fallback_map-id": {"DE": "eu-central"},
+	}
+	v, ok := items[i.DictionaryID][i.ItemKey]
+	if !ok {
+		return nil, &fastly.HTTPError{StatusCode: 404}
+	}
+	return &fastly.DictionaryItem{
+		ServiceID:    i.ServiceID,
+		DictionaryID: i.DictionaryID,
+		ItemKey:      i.ItemKey,
+		ItemValue:    v,
+	}, nil
+}
+
+func lookupDictionaryItemError(*fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	return nil, errTest
+}
+
 var errTest = errors.New("an expected error ocurred")
 var errFail = errors.New("this error should not be returned and indicates a failure in the code")
 
+var autoCloneOutput = "Service version 1 is not editable, so it was automatically cloned because --autoclone is\nenabled. Now operating on version 4.\n"
+
 var createDictionaryOutput = "\nSUCCESS: Created dictionary denylist (service 123 version 4)\n"
 var createDictionaryOutputWriteOnly = "\nSUCCESS: Created dictionary denylist as write-only (service 123 version 4)\n"
 var deleteDictionaryOutput = "\nSUCCESS: Deleted dictionary allowlist (service 123 version 4)\n"
@@ -523,3 +648,50 @@ Write Only: false
 Created (UTC): 2001-02-03 04:05
 Last edited (UTC): 2001-02-03 04:05
 `) + "\n"
+
+func TestDictionaryUsages(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListSnippetsFn: func(*fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+			return []*fastly.Snippet{
+				{Name: "geo", Type: fastly.SnippetTypeRecv, Content: "set req.http.X-Region = table.lookup(geo_map, client.geo.country_code, \"us\");"},
+			}, nil
+		},
+		ListVCLsFn: func(*fastly.ListVCLsInput) ([]*fastly.VCL, error) { return nil, nil },
+		ListConditionsFn: func(*fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+			return []*fastly.Condition{{Name: "is_eu", Statement: "table.contains(geo_map, client.geo.country_code)"}}, nil
+		},
+		GetGeneratedVCLFn: func(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+			return &fastly.VCL{Content: "sub vcl_recv {\n  if (table.contains(geo_map, client.geo.country_code)) {\n    set req.http.X-Region = table.lookup(geo_map, client.geo.country_code, \"us\");\n  }\n}"}, nil
+		},
+	}
+	testutil.RunScenarios(t, []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("dictionary usages --service-id 123"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate references",
+			API:  api,
+			Args: args("dictionary usages --service-id 123 --name geo_map"),
+			WantOutputs: []string{
+				"snippet    geo    1     set req.http.X-Region = table.lookup(geo_map, client.geo.country_code, \"us\");",
+				"condition  is_eu  1     table.contains(geo_map, client.geo.country_code)",
+			},
+		},
+		{
+			Name:       "validate unused dictionary",
+			API:        api,
+			Args:       args("dictionary usages --service-id 123 --name redirects"),
+			WantOutput: "No references to dictionary 'redirects' found in service 123 version 1",
+		},
+		{
+			Name:       "validate JSON output",
+			API:        api,
+			Args:       args("dictionary usages --service-id 123 --name geo_map --json"),
+			WantOutput: `{"dictionary":"geo_map","references":[{"kind":"snippet","name":"geo","line":1,`,
+		},
+	})
+}
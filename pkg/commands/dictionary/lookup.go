@@ -0,0 +1,254 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"gopkg.in/yaml.v2"
+)
+
+// LookupCommand looks up a key in a chain of edge dictionaries, either via the
+// Fastly API or against a local file that simulates the dictionaries.
+type LookupCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	dflt           cmd.OptionalString
+	json           bool
+	key            string
+	names          []string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	simulateFile   string
+}
+
+// NewLookupCommand returns a usable command registered under the parent.
+func NewLookupCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *LookupCommand {
+	var c LookupCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("lookup", "Look up a key in an edge dictionary the way table.lookup() does in VCL")
+	c.CmdClause.Flag("name", "Name of Dictionary (repeat to fall back to further dictionaries when the key isn't found)").Short('n').Required().StringsVar(&c.names)
+	c.CmdClause.Flag("key", "Dictionary item key").Required().StringVar(&c.key)
+	c.CmdClause.Flag("default", "Value to use when the key isn't found in any dictionary").Action(c.dflt.Set).StringVar(&c.dflt.Value)
+	c.CmdClause.Flag("simulate-file", "Path to a JSON or YAML file of dictionaries (name to key/value map) to look up instead of the API, or - for stdin").StringVar(&c.simulateFile)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *LookupCommand) Exec(in io.Reader, out io.Writer) error {
+	var (
+		fetch LookupFunc
+		err   error
+	)
+	if c.simulateFile != "" {
+		fetch, err = c.simulate()
+	} else {
+		fetch, err = c.remote(out)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := Lookup(c.names, c.key, c.dflt, fetch)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Dictionaries": c.names,
+			"Key":          c.key,
+		})
+		return err
+	}
+
+	if c.json {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	for _, s := range result.Steps {
+		if s.Found {
+			text.Output(out, "%s[%q]: found %q", s.Dictionary, result.Key, s.Value)
+			break
+		}
+		text.Output(out, "%s[%q]: not found", s.Dictionary, result.Key)
+	}
+	switch {
+	case result.Source == LookupSourceDefault:
+		text.Output(out, "Result: %q (default)", result.Value)
+	case result.Set:
+		text.Output(out, "Result: %q (from %s)", result.Value, result.Source)
+	default:
+		text.Output(out, "Result: not set")
+	}
+	return nil
+}
+
+// simulate returns a LookupFunc backed by the --simulate-file dictionaries.
+func (c *LookupCommand) simulate() (LookupFunc, error) {
+	f, err := cmd.Open(c.simulateFile, c.Globals.Stdin)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return nil, fmt.Errorf("error reading simulate file: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return nil, fmt.Errorf("error reading simulate file: %w", err)
+	}
+
+	// YAML is a superset of JSON so either format can be decoded.
+	var dictionaries map[string]map[string]string
+	if err := yaml.Unmarshal(data, &dictionaries); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing simulate file: %w", err),
+			Remediation: `The file should map dictionary names to their items, e.g. {"geo_map": {"DE": "eu"}}`,
+		}
+	}
+
+	return func(name, key string) (string, bool, error) {
+		d, ok := dictionaries[name]
+		if !ok {
+			return "", false, fmt.Errorf("dictionary '%s' not found in simulate file", name)
+		}
+		v, ok := d[key]
+		return v, ok, nil
+	}, nil
+}
+
+// remote returns a LookupFunc backed by the Fastly API.
+func (c *LookupCommand) remote(out io.Writer) (LookupFunc, error) {
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return nil, err
+	}
+
+	return func(name, key string) (string, bool, error) {
+		d, err := c.Globals.APIClient.GetDictionary(&fastly.GetDictionaryInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion.Number,
+			Name:           name,
+		})
+		if err != nil {
+			return "", false, err
+		}
+		item, err := c.Globals.APIClient.GetDictionaryItem(&fastly.GetDictionaryItemInput{
+			ServiceID:    serviceID,
+			DictionaryID: d.ID,
+			ItemKey:      key,
+		})
+		var httpErr *fastly.HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return item.ItemValue, true, nil
+	}, nil
+}
+
+// LookupFunc returns the value of key in the named dictionary, and whether
+// the key was found.
+type LookupFunc func(name, key string) (value string, found bool, err error)
+
+// LookupSourceDefault is the LookupResult source when the default was used.
+const LookupSourceDefault = "default"
+
+// LookupResult describes the outcome of a lookup chain.
+type LookupResult struct {
+	Key    string       `json:"key"`
+	Steps  []LookupStep `json:"steps"`
+	Set    bool         `json:"set"`
+	Value  string       `json:"value"`
+	Source string       `json:"source,omitempty"`
+}
+
+// LookupStep describes the lookup of a key in a single dictionary.
+type LookupStep struct {
+	Dictionary string `json:"dictionary"`
+	Found      bool   `json:"found"`
+	Value      string `json:"value,omitempty"`
+}
+
+// Lookup evaluates a chain of dictionary lookups the way nested VCL
+// table.lookup() calls would, e.g.
+//
+//	table.lookup(geo_map, "DE", table.lookup(fallback_map, "DE", "default"))
+//
+// Keys are matched exactly (they're case sensitive) and, when the key isn't
+// found in any dictionary, the result is the default or, without a default,
+// not set.
+func Lookup(names []string, key string, dflt cmd.OptionalString, fetch LookupFunc) (LookupResult, error) {
+	r := LookupResult{Key: key}
+	for _, name := range names {
+		v, found, err := fetch(name, key)
+		if err != nil {
+			return r, err
+		}
+		r.Steps = append(r.Steps, LookupStep{Dictionary: name, Found: found, Value: v})
+		if found {
+			r.Set = true
+			r.Value = v
+			r.Source = name
+			return r, nil
+		}
+	}
+	if dflt.WasSet {
+		r.Set = true
+		r.Value = dflt.Value
+		r.Source = LookupSourceDefault
+	}
+	return r, nil
+}
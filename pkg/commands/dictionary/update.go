@@ -66,6 +66,7 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -116,7 +117,7 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 
 	if c.Globals.Verbose() {
 		text.Output(out, "Version: %d", d.ServiceVersion)
-		text.PrintDictionary(out, "", d)
+		text.PrintDictionary(out, "", d, c.Globals.Formatter())
 	}
 
 	return nil
@@ -0,0 +1,122 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/vclusage"
+)
+
+// UsagesCommand reports where a dictionary is used, so that it can be safely
+// deleted or renamed.
+type UsagesCommand struct {
+	cmd.Base
+	json           bool
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewUsagesCommand returns a usable command registered under the parent.
+func NewUsagesCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *UsagesCommand {
+	var c UsagesCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("usages", "Report where a dictionary is looked up (e.g. table.lookup) in a service version's VCL")
+	c.CmdClause.Flag("name", "Name of Dictionary").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Usages describes where a dictionary is used.
+type Usages struct {
+	Dictionary string               `json:"dictionary"`
+	References []vclusage.Reference `json:"references"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *UsagesCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	sources, err := vclusage.Sources(c.Globals.APIClient, serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	u := Usages{
+		Dictionary: c.name,
+		References: vclusage.Find(sources, nil, vclusage.DictionaryPattern(c.name)),
+	}
+	if u.References == nil {
+		u.References = []vclusage.Reference{}
+	}
+
+	if c.json {
+		data, err := json.Marshal(u)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(u.References) == 0 {
+		text.Success(out, "No references to dictionary '%s' found in service %s version %d", c.name, serviceID, serviceVersion.Number)
+		return nil
+	}
+	vclusage.PrintReferences(out, u.References)
+	return nil
+}
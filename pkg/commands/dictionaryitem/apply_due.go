@@ -0,0 +1,113 @@
+package dictionaryitem
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ApplyDueCommand applies the scheduled dictionary item changes whose time
+// has passed.
+type ApplyDueCommand struct {
+	cmd.Base
+}
+
+// NewApplyDueCommand returns a usable command registered under the parent.
+func NewApplyDueCommand(parent cmd.Registerer, globals *config.Data) *ApplyDueCommand {
+	var c ApplyDueCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("apply-due", "Apply the scheduled dictionary item changes (see 'fastly dictionary-item schedule') whose time has passed")
+	c.SetMutating()
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ApplyDueCommand) Exec(in io.Reader, out io.Writer) error {
+	now := c.Globals.Clock.Now()
+
+	// Changes are applied in time order, so that when several changes to the
+	// same item are due the latest one wins.
+	changes := append(config.DictionaryChanges(nil), c.Globals.File.DictionaryChanges...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].At < changes[j].At
+	})
+
+	var remaining config.DictionaryChanges
+	var applied, failed int
+	for _, dc := range changes {
+		at, err := time.Parse(time.RFC3339, dc.At)
+		if err == nil && at.After(now) {
+			remaining = append(remaining, dc)
+			if c.Globals.Verbose() {
+				text.Output(out, "Pending: dictionary item '%s' at %s (dictionary: %s, service: %s)", dc.Key, c.Globals.Formatter().Time(at), dc.DictionaryID, dc.ServiceID)
+			}
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("invalid time '%s': %w", dc.At, err)
+		} else {
+			err = c.apply(dc)
+		}
+		if err != nil {
+			// The change is kept so that it's retried by the next run.
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Change ID":     dc.ID,
+				"Dictionary ID": dc.DictionaryID,
+				"Service ID":    dc.ServiceID,
+			})
+			text.Warning(out, "Unable to apply the change to dictionary item '%s' (dictionary: %s, service: %s): %s", dc.Key, dc.DictionaryID, dc.ServiceID, err)
+			remaining = append(remaining, dc)
+			failed++
+			continue
+		}
+		applied++
+		if dc.Delete {
+			text.Output(out, "Deleted dictionary item '%s' (dictionary: %s, service: %s)", dc.Key, dc.DictionaryID, dc.ServiceID)
+		} else {
+			text.Output(out, "Set dictionary item '%s' to '%s' (dictionary: %s, service: %s)", dc.Key, dc.Value, dc.DictionaryID, dc.ServiceID)
+		}
+	}
+
+	if applied > 0 {
+		c.Globals.File.DictionaryChanges = remaining
+		if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d of %d due dictionary item changes", failed, failed+applied)
+	}
+	text.Success(out, "Applied %d due dictionary item changes (%d pending)", applied, len(remaining))
+	return nil
+}
+
+// apply makes the change. The dictionary is resolved when the change is
+// applied, so a change scheduled with the dictionary's name still applies to
+// whichever dictionary has that name at the time.
+func (c *ApplyDueCommand) apply(dc *config.DictionaryChange) error {
+	resolver := cmd.DictionaryResolver(c.Globals.APIClient, dc.ServiceID)
+	return cmd.ResolveOnNotFound(dc.DictionaryID, resolver, func(id string) error {
+		if dc.Delete {
+			return c.Globals.APIClient.DeleteDictionaryItem(&fastly.DeleteDictionaryItemInput{
+				ServiceID:    dc.ServiceID,
+				DictionaryID: id,
+				ItemKey:      dc.Key,
+			})
+		}
+		_, err := c.Globals.APIClient.UpdateDictionaryItem(&fastly.UpdateDictionaryItemInput{
+			ServiceID:    dc.ServiceID,
+			DictionaryID: id,
+			ItemKey:      dc.Key,
+			ItemValue:    dc.Value,
+		})
+		return err
+	})
+}
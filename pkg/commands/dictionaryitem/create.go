@@ -36,7 +36,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.Input.DictionaryID)
+	c.CmdClause.Flag("dictionary-id", "Dictionary ID"+cmd.IDOrNameDesc).Required().StringVar(&c.Input.DictionaryID)
 	c.CmdClause.Flag("key", "Dictionary item key").Required().StringVar(&c.Input.ItemKey)
 	c.CmdClause.Flag("value", "Dictionary item value").Required().StringVar(&c.Input.ItemValue)
 	return &c
@@ -44,7 +44,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 
 // Exec invokes the application logic for the command.
 func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -54,7 +54,11 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 
 	c.Input.ServiceID = serviceID
 
-	_, err = c.Globals.APIClient.CreateDictionaryItem(&c.Input)
+	err = cmd.ResolveOnNotFound(c.Input.DictionaryID, cmd.DictionaryResolver(c.Globals.APIClient, serviceID), func(id string) error {
+		c.Input.DictionaryID = id
+		_, err := c.Globals.APIClient.CreateDictionaryItem(&c.Input)
+		return err
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -36,14 +36,14 @@ func NewDeleteCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.Input.DictionaryID)
+	c.CmdClause.Flag("dictionary-id", "Dictionary ID"+cmd.IDOrNameDesc).Required().StringVar(&c.Input.DictionaryID)
 	c.CmdClause.Flag("key", "Dictionary item key").Required().StringVar(&c.Input.ItemKey)
 	return &c
 }
 
 // Exec invokes the application logic for the command.
 func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -53,7 +53,10 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 
 	c.Input.ServiceID = serviceID
 
-	err = c.Globals.APIClient.DeleteDictionaryItem(&c.Input)
+	err = cmd.ResolveOnNotFound(c.Input.DictionaryID, cmd.DictionaryResolver(c.Globals.APIClient, serviceID), func(id string) error {
+		c.Input.DictionaryID = id
+		return c.Globals.APIClient.DeleteDictionaryItem(&c.Input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -7,7 +7,6 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
-	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -46,18 +45,14 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.Input.DictionaryID)
+	c.CmdClause.Flag("dictionary-id", "Dictionary ID"+cmd.IDOrNameDesc).Required().StringVar(&c.Input.DictionaryID)
 	c.CmdClause.Flag("key", "Dictionary item key").Required().StringVar(&c.Input.ItemKey)
 	return &c
 }
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -67,7 +62,12 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 
 	c.Input.ServiceID = serviceID
 
-	item, err := c.Globals.APIClient.GetDictionaryItem(&c.Input)
+	var item *fastly.DictionaryItem
+	err = cmd.ResolveOnNotFound(c.Input.DictionaryID, cmd.DictionaryResolver(c.Globals.APIClient, serviceID), func(id string) (err error) {
+		c.Input.DictionaryID = id
+		item, err = c.Globals.APIClient.GetDictionaryItem(&c.Input)
+		return err
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -87,6 +87,6 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 	if !c.Globals.Verbose() {
 		fmt.Fprintf(out, "\nService ID: %s\n", c.Input.ServiceID)
 	}
-	text.PrintDictionaryItem(out, "", item)
+	text.PrintDictionaryItem(out, "", item, c.Globals.Formatter())
 	return nil
 }
@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/commands/dictionaryitem"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
+	toml "github.com/pelletier/go-toml"
 )
 
 func TestDictionaryItemDescribe(t *testing.T) {
@@ -53,30 +59,7 @@ func TestDictionaryItemDescribe(t *testing.T) {
 	}
 }
 
-type mockDictionaryItemPaginator struct {
-	count         int
-	maxPages      int
-	numOfPages    int
-	requestedPage int
-	returnErr     bool
-}
-
-func (p *mockDictionaryItemPaginator) HasNext() bool {
-	if p.count > p.maxPages {
-		return false
-	}
-	p.count++
-	return true
-}
-
-func (p mockDictionaryItemPaginator) Remaining() int {
-	return 1
-}
-
-func (p *mockDictionaryItemPaginator) GetNext() (di []*fastly.DictionaryItem, err error) {
-	if p.returnErr {
-		err = testutil.Err
-	}
+func listDictionaryItemsPaginator(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
 	pageOne := fastly.DictionaryItem{
 		ServiceID:    "123",
 		DictionaryID: "456",
@@ -94,16 +77,7 @@ func (p *mockDictionaryItemPaginator) GetNext() (di []*fastly.DictionaryItem, er
 		UpdatedAt:    testutil.MustParseTimeRFC3339("2001-02-03T04:05:07Z"),
 		DeletedAt:    testutil.MustParseTimeRFC3339("2001-02-03T04:06:08Z"),
 	}
-	if p.count == 1 {
-		di = append(di, &pageOne)
-	}
-	if p.count == 2 {
-		di = append(di, &pageTwo)
-	}
-	if p.requestedPage > 0 && p.numOfPages == 1 {
-		p.count = p.maxPages + 1 // forces only one result to be displayed
-	}
-	return di, err
+	return testutil.NewPaginator(i.Page, []*fastly.DictionaryItem{&pageOne}, []*fastly.DictionaryItem{&pageTwo})
 }
 
 func TestDictionaryItemsList(t *testing.T) {
@@ -125,7 +99,9 @@ func TestDictionaryItemsList(t *testing.T) {
 		{
 			api: mock.API{
 				NewListDictionaryItemsPaginatorFn: func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
-					return &mockDictionaryItemPaginator{returnErr: true}
+					p := testutil.NewPaginator[*fastly.DictionaryItem](i.Page)
+					p.Err = testutil.Err
+					return p
 				},
 			},
 			args:      args("dictionary-item list --service-id 123 --dictionary-id 456"),
@@ -135,9 +111,7 @@ func TestDictionaryItemsList(t *testing.T) {
 		// setting --per-page 1 we expect the final output to display both items.
 		{
 			api: mock.API{
-				NewListDictionaryItemsPaginatorFn: func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
-					return &mockDictionaryItemPaginator{numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListDictionaryItemsPaginatorFn: listDictionaryItemsPaginator,
 			},
 			args:       args("dictionary-item list --service-id 123 --dictionary-id 456 --per-page 1"),
 			wantOutput: listDictionaryItemsOutput,
@@ -146,9 +120,7 @@ func TestDictionaryItemsList(t *testing.T) {
 		// displayed per page we expect only the first record to be displayed.
 		{
 			api: mock.API{
-				NewListDictionaryItemsPaginatorFn: func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
-					return &mockDictionaryItemPaginator{count: i.Page - 1, requestedPage: i.Page, numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListDictionaryItemsPaginatorFn: listDictionaryItemsPaginator,
 			},
 			args:       args("dictionary-item list --service-id 123 --dictionary-id 456 --page 1 --per-page 1"),
 			wantOutput: listDictionaryItemsPageOneOutput,
@@ -157,9 +129,7 @@ func TestDictionaryItemsList(t *testing.T) {
 		// displayed per page we expect only the second record to be displayed.
 		{
 			api: mock.API{
-				NewListDictionaryItemsPaginatorFn: func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
-					return &mockDictionaryItemPaginator{count: i.Page - 1, requestedPage: i.Page, numOfPages: i.PerPage, maxPages: 2}
-				},
+				NewListDictionaryItemsPaginatorFn: listDictionaryItemsPaginator,
 			},
 			args:       args("dictionary-item list --service-id 123 --dictionary-id 456 --page 2 --per-page 1"),
 			wantOutput: listDictionaryItemsPageTwoOutput,
@@ -235,6 +205,14 @@ func TestDictionaryItemUpdate(t *testing.T) {
 			api:        mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
 			wantOutput: updateDictionaryItemOutput,
 		},
+		{
+			args:      args("dictionary-item update --service-id 123 --dictionary-id 456 --edit --key foo --value bar"),
+			wantError: "error parsing arguments: the --edit flag is mutually exclusive with the --value flag",
+		},
+		{
+			args:      args("dictionary-item update --service-id 123 --dictionary-id 456 --edit"),
+			wantError: "error parsing arguments: the --edit flag requires the --key flag",
+		},
 		{
 			args:      args("dictionary-item update --service-id 123 --dictionary-id 456 --file filePath"),
 			fileData:  `{invalid": "json"}`,
@@ -258,7 +236,28 @@ func TestDictionaryItemUpdate(t *testing.T) {
 			args:       args("dictionary-item update --service-id 123 --dictionary-id 456 --file filePath"),
 			fileData:   dictionaryItemBatchModifyInputOK,
 			api:        mock.API{BatchModifyDictionaryItemsFn: batchModifyDictionaryItemsOK},
-			wantOutput: "\nSUCCESS: Made 4 modifications of Dictionary 456 on service 123\n",
+			wantOutput: "\nSUCCESS: Made 4 modifications of Dictionary 456 on service 123 (1 create, 1 update, 1 upsert, 1 delete)\n",
+		},
+		{
+			args:       args("dictionary-item update --service-id 123 --dictionary-id 456 --batch-file filePath"),
+			fileData:   `{"items":[{"op":"upsert","item_key":"a","item_value":"b"},{"op":"delete","item_key":"c"}]}`,
+			api:        mock.API{BatchModifyDictionaryItemsFn: batchModifyDictionaryItemsOK},
+			wantOutput: "\nSUCCESS: Made 2 modifications of Dictionary 456 on service 123 (1 upsert, 1 delete)\n",
+		},
+		{
+			args:      args("dictionary-item update --service-id 123 --dictionary-id 456 --batch-file filePath"),
+			fileData:  `{"items":[{"op":"upsert","item_key":"a","item_value":"b"},{"op":"remove","item_key":"c"}]}`,
+			wantError: "item 2: invalid 'op' 'remove' (must be one of create, update, upsert, delete)",
+		},
+		{
+			args:      args("dictionary-item update --service-id 123 --dictionary-id 456 --batch-file filePath"),
+			fileData:  `{"items":[{"op":"delete"}]}`,
+			wantError: "item 1: missing 'item_key'",
+		},
+		{
+			args:      args("dictionary-item update --service-id 123 --dictionary-id 456 --batch-file filePath"),
+			fileData:  `{"items":[]}`,
+			wantError: "missing 'items'",
 		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
@@ -320,6 +319,131 @@ func TestDictionaryItemDelete(t *testing.T) {
 	}
 }
 
+func TestDictionaryItemSchedule(t *testing.T) {
+	args := testutil.Args
+	due := &config.DictionaryChange{ID: "a", ServiceID: "123", DictionaryID: "456", Key: "flag_x", Value: "on", At: "2021-06-15T22:00:00Z"}
+	deleted := &config.DictionaryChange{ID: "b", ServiceID: "123", DictionaryID: "456", Key: "flag_y", Delete: true, At: "2021-06-15T21:00:00Z"}
+	pending := &config.DictionaryChange{ID: "c", ServiceID: "123", DictionaryID: "456", Key: "flag_x", Value: "off", At: "2021-07-01T09:00:00Z"}
+
+	scenarios := []struct {
+		testutil.TestScenario
+		changes     config.DictionaryChanges
+		wantChanges config.DictionaryChanges
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --value or --delete is required",
+				Args:      args("dictionary-item schedule --service-id 123 --dictionary-id 456 --key flag_x --at 2021-07-01T09:00Z"),
+				WantError: "exactly one of --value or --delete must be provided",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate invalid --at",
+				Args:      args("dictionary-item schedule --service-id 123 --dictionary-id 456 --key flag_x --value on --at tomorrow"),
+				WantError: "invalid time 'tomorrow'",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --at in the past",
+				Args:      args("dictionary-item schedule --service-id 123 --dictionary-id 456 --key flag_x --value on --at 2021-06-01T09:00Z"),
+				WantError: "has already passed",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate change is scheduled",
+				Args:       args("dictionary-item schedule --service-id 123 --dictionary-id 456 --key flag_x --value off --at 2021-07-01T09:00Z"),
+				WantOutput: "Scheduled dictionary item 'flag_x' to be set to 'off'",
+			},
+			wantChanges: config.DictionaryChanges{
+				{ID: "00000000-0000-4000-8000-000000000001", ServiceID: "123", DictionaryID: "456", Key: "flag_x", Value: "off", At: "2021-07-01T09:00:00Z", Added: "2021-06-15T23:00:00Z"},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate due changes are applied",
+				API: mock.API{
+					UpdateDictionaryItemFn: updateDictionaryItemOK,
+					DeleteDictionaryItemFn: deleteDictionaryItemOK,
+				},
+				Args: args("dictionary-item apply-due"),
+				WantOutputs: []string{
+					"Deleted dictionary item 'flag_y' (dictionary: 456, service: 123)",
+					"Set dictionary item 'flag_x' to 'on' (dictionary: 456, service: 123)",
+					"Applied 2 due dictionary item changes (1 pending)",
+				},
+			},
+			changes:     config.DictionaryChanges{pending, due, deleted},
+			wantChanges: config.DictionaryChanges{pending},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate failed changes are kept",
+				API: mock.API{
+					UpdateDictionaryItemFn: func(*fastly.UpdateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+						return nil, testutil.Err
+					},
+					DeleteDictionaryItemFn: deleteDictionaryItemOK,
+				},
+				Args:       args("dictionary-item apply-due"),
+				WantError:  "failed to apply 1 of 2 due dictionary item changes",
+				WantOutput: "Unable to apply the change to dictionary item 'flag_x'",
+			},
+			changes:     config.DictionaryChanges{pending, due, deleted},
+			wantChanges: config.DictionaryChanges{due, pending},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			opts.ConfigPath = configPath
+			opts.Clock = clock.Fixed(testutil.Date)
+			opts.ConfigFile = config.File{DictionaryChanges: testcase.changes}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+
+			if testcase.wantChanges != nil {
+				data, err := os.ReadFile(configPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var f config.File
+				if err := toml.Unmarshal(data, &f); err != nil {
+					t.Fatal(err)
+				}
+				testutil.AssertEqual(t, testcase.wantChanges, f.DictionaryChanges)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	loc := time.FixedZone("BST", 3600)
+	for value, want := range map[string]time.Time{
+		"2024-07-01T09:00Z":         time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+		"2024-07-01T09:00:00+02:00": time.Date(2024, 7, 1, 7, 0, 0, 0, time.UTC),
+		"2024-07-01 09:00":          time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC),
+	} {
+		have, err := dictionaryitem.ParseTime(value, loc)
+		testutil.AssertNoError(t, err)
+		if !have.Equal(want) {
+			t.Errorf("%s: want %s, have %s", value, want, have)
+		}
+	}
+	_, err := dictionaryitem.ParseTime("next tuesday", loc)
+	testutil.AssertErrorContains(t, err, "invalid time 'next tuesday'")
+}
+
 func describeDictionaryItemOK(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
 	return &fastly.DictionaryItem{
 		ServiceID:    i.ServiceID,
@@ -7,7 +7,6 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
-	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -19,6 +18,7 @@ type ListCommand struct {
 	manifest    manifest.Data
 	input       fastly.ListDictionaryItemsInput
 	json        bool
+	maxItems    int
 	serviceName cmd.OptionalServiceNameID
 }
 
@@ -28,7 +28,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.Globals = globals
 	c.manifest = data
 	c.CmdClause = parent.Command("list", "List items in a Fastly edge dictionary")
-	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.input.DictionaryID)
+	c.CmdClause.Flag("dictionary-id", "Dictionary ID"+cmd.IDOrNameDesc).Required().StringVar(&c.input.DictionaryID)
 	c.CmdClause.Flag("direction", "Direction in which to sort results").Default(cmd.PaginationDirection[0]).HintOptions(cmd.PaginationDirection...).EnumVar(&c.input.Direction, cmd.PaginationDirection...)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
@@ -36,6 +36,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
+	c.CmdClause.Flag("max-items", "Maximum number of dictionary items to list").IntVar(&c.maxItems)
 	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.input.Page)
 	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.input.PerPage)
 	c.RegisterFlag(cmd.StringFlagOpts{
@@ -56,11 +57,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -69,20 +66,32 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	c.input.ServiceID = serviceID
-	paginator := c.Globals.APIClient.NewListDictionaryItemsPaginator(&c.input)
 
 	var ds []*fastly.DictionaryItem
-	for paginator.HasNext() {
-		data, err := paginator.GetNext()
+	err = cmd.ResolveOnNotFound(c.input.DictionaryID, cmd.DictionaryResolver(c.Globals.APIClient, serviceID), func(id string) error {
+		c.input.DictionaryID = id
+		ds, err = cmd.Paginate(func(page int) cmd.Paginator[*fastly.DictionaryItem] {
+			input := c.input
+			input.Page = page
+			return c.Globals.APIClient.NewListDictionaryItemsPaginator(&input)
+		}, cmd.PaginateOpts{
+			Page:     c.input.Page,
+			MaxItems: c.maxItems,
+			Fetched: func(page, items int) {
+				c.Globals.Diagnostic(config.VerboseLevelTimings, "Fetched %d dictionary items (page %d)", items, page)
+			},
+		})
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
-				"Dictionary ID":   c.input.DictionaryID,
-				"Service ID":      serviceID,
-				"Remaining Pages": paginator.Remaining(),
+				"Dictionary ID": id,
+				"Service ID":    serviceID,
+				"Page":          c.input.Page,
 			})
-			return err
 		}
-		ds = append(ds, data...)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	if c.json {
@@ -99,7 +108,7 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 	for i, dictionary := range ds {
 		text.Output(out, "Item: %d/%d", i+1, len(ds))
-		text.PrintDictionaryItem(out, "\t", dictionary)
+		text.PrintDictionaryItem(out, "\t", dictionary, c.Globals.Formatter())
 		text.Break(out)
 	}
 
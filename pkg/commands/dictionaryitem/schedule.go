@@ -0,0 +1,134 @@
+package dictionaryitem
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ScheduleCommand records a dictionary item change to be applied later by
+// the apply-due command.
+type ScheduleCommand struct {
+	cmd.Base
+
+	at           string
+	delete       bool
+	dictionaryID string
+	key          string
+	manifest     manifest.Data
+	serviceName  cmd.OptionalServiceNameID
+	value        cmd.OptionalString
+}
+
+// NewScheduleCommand returns a usable command registered under the parent.
+func NewScheduleCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *ScheduleCommand {
+	var c ScheduleCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("schedule", "Schedule a change to a dictionary item, applied by 'fastly dictionary-item apply-due'")
+	c.CmdClause.Flag("at", "When to apply the change, e.g. 2024-07-01T09:00Z (local time if no zone is given)").Required().StringVar(&c.at)
+	c.CmdClause.Flag("delete", "Delete the item instead of setting its value").BoolVar(&c.delete)
+	c.CmdClause.Flag("dictionary-id", "Dictionary ID"+cmd.IDOrNameDesc).Required().StringVar(&c.dictionaryID)
+	c.CmdClause.Flag("key", "Dictionary item key").Required().StringVar(&c.key)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("value", "The value to set the item to").Action(c.value.Set).StringVar(&c.value.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ScheduleCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.delete == c.value.WasSet {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: exactly one of --value or --delete must be provided"),
+			Remediation: "Use --value to schedule the item being set, or --delete to schedule its deletion.",
+		}
+	}
+	if c.value.WasSet && c.value.Value == "" {
+		return fmt.Errorf("an empty value is not allowed for the '--value' flag")
+	}
+
+	at, err := ParseTime(c.at, c.Globals.Clock.Now().Location())
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       err,
+			Remediation: "Provide a time such as 2024-07-01T09:00Z, 2024-07-01T09:00:00+01:00 or 2024-07-01 09:00.",
+		}
+	}
+	now := c.Globals.Clock.Now()
+	if !at.After(now) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the time %s has already passed", c.Globals.Formatter().Time(at)),
+			Remediation: "Use 'fastly dictionary-item update' to change the item now.",
+		}
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	change := &config.DictionaryChange{
+		ID:           c.Globals.Clock.ID(),
+		ServiceID:    serviceID,
+		DictionaryID: c.dictionaryID,
+		Key:          c.key,
+		Value:        c.value.Value,
+		Delete:       c.delete,
+		At:           at.Format(time.RFC3339),
+		Added:        now.Format(time.RFC3339),
+	}
+	c.Globals.File.DictionaryChanges = append(c.Globals.File.DictionaryChanges, change)
+	if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	action := fmt.Sprintf("set to '%s'", change.Value)
+	if change.Delete {
+		action = "deleted"
+	}
+	text.Success(out, "Scheduled dictionary item '%s' to be %s at %s (dictionary: %s, service: %s)", c.key, action, c.Globals.Formatter().Time(at), c.dictionaryID, serviceID)
+	text.Info(out, "Scheduled changes are applied by running 'fastly dictionary-item apply-due' at or after that time, e.g. with 'fastly schedule add --cron \"* * * * *\" --command \"dictionary-item apply-due\"'.")
+	return nil
+}
+
+// timeLayouts are the layouts accepted by ParseTime, most specific first.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// ParseTime parses a time in one of the layouts of timeLayouts. A time
+// without a zone is in the given location.
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s'", value)
+}
@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -19,9 +19,11 @@ type UpdateCommand struct {
 
 	Input       fastly.UpdateDictionaryItemInput
 	InputBatch  fastly.BatchModifyDictionaryItemsInput
+	edit        bool
 	file        cmd.OptionalString
 	manifest    manifest.Data
 	serviceName cmd.OptionalServiceNameID
+	value       cmd.OptionalString
 }
 
 // NewUpdateCommand returns a usable command registered under the parent.
@@ -30,8 +32,11 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.Globals = globals
 	c.manifest = data
 	c.CmdClause = parent.Command("update", "Update or insert an item on a Fastly edge dictionary")
-	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.Input.DictionaryID)
-	c.CmdClause.Flag("file", "Batch update json file").Action(c.file.Set).StringVar(&c.file.Value)
+	c.CmdClause.Flag("dictionary-id", "Dictionary ID"+cmd.IDOrNameDesc).Required().StringVar(&c.Input.DictionaryID)
+	c.CmdClause.Flag("batch-file", `Path of a JSON batch patch file (or - for stdin) whose "items" are create, update, upsert or delete operations (op, item_key, item_value)`).Action(c.file.Set).StringVar(&c.file.Value)
+	// --file is the original name of --batch-file, kept for scripts.
+	c.CmdClause.Flag("file", "Batch update json file").Hidden().Action(c.file.Set).StringVar(&c.file.Value)
+	c.CmdClause.Flag("edit", cmd.EditFlagDesc).BoolVar(&c.edit)
 	c.CmdClause.Flag("key", "Dictionary item key").StringVar(&c.Input.ItemKey)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
@@ -45,13 +50,21 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("value", "Dictionary item value").StringVar(&c.Input.ItemValue)
+	c.CmdClause.Flag("value", "Dictionary item value").Action(c.value.Set).StringVar(&c.Input.ItemValue)
 	return &c
 }
 
 // Exec invokes the application logic for the command.
 func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	if err := cmd.CheckFlagRules(
+		cmd.ExclusiveFlags("edit", c.edit, "value", c.value.WasSet),
+		cmd.ExclusiveFlags("edit", c.edit, "batch-file", c.file.WasSet),
+		cmd.FlagRequires("edit", c.edit, c.Input.ItemKey != "", "the --key flag"),
+	); err != nil {
+		return err
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -72,11 +85,27 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 		return nil
 	}
 
+	if c.edit {
+		err := c.editValue(in, out)
+		if err == cmd.ErrEditCancelled {
+			return nil
+		}
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
 	if c.Input.ItemKey == "" || c.Input.ItemValue == "" {
 		return fmt.Errorf("an empty value is not allowed for either the '--key' or '--value' flags")
 	}
 
-	d, err := c.Globals.APIClient.UpdateDictionaryItem(&c.Input)
+	var d *fastly.DictionaryItem
+	err = cmd.ResolveOnNotFound(c.Input.DictionaryID, cmd.DictionaryResolver(c.Globals.APIClient, serviceID), func(id string) (err error) {
+		c.Input.DictionaryID = id
+		d, err = c.Globals.APIClient.UpdateDictionaryItem(&c.Input)
+		return err
+	})
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
@@ -84,16 +113,45 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 
 	text.Success(out, "Updated dictionary item (service %s)", d.ServiceID)
 	text.Break(out)
-	text.PrintDictionaryItem(out, "", d)
+	text.PrintDictionaryItem(out, "", d, c.Globals.Formatter())
+	return nil
+}
+
+// editValue fetches the current value of the dictionary item and opens it in
+// the user's editor, storing the edited value in the update input.
+func (c *UpdateCommand) editValue(in io.Reader, out io.Writer) error {
+	var d *fastly.DictionaryItem
+	resolver := cmd.DictionaryResolver(c.Globals.APIClient, c.Input.ServiceID)
+	err := cmd.ResolveOnNotFound(c.Input.DictionaryID, resolver, func(id string) (err error) {
+		d, err = c.Globals.APIClient.GetDictionaryItem(&fastly.GetDictionaryItemInput{
+			ServiceID:    c.Input.ServiceID,
+			DictionaryID: id,
+			ItemKey:      c.Input.ItemKey,
+		})
+		if err == nil {
+			c.Input.DictionaryID = id
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	value, err := cmd.Edit(d.ItemValue, "dictionary-item-*.txt", c.Globals, in, out)
+	if err != nil {
+		return err
+	}
+	c.Input.ItemValue = value
 	return nil
 }
 
 func (c *UpdateCommand) batchModify(out io.Writer) error {
-	jsonFile, err := os.Open(c.file.Value)
+	jsonFile, err := cmd.Open(c.file.Value, c.Globals.Stdin)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
 	}
+	defer jsonFile.Close() // #nosec G307
 
 	jsonBytes, err := io.ReadAll(jsonFile)
 	if err != nil {
@@ -107,16 +165,56 @@ func (c *UpdateCommand) batchModify(out io.Writer) error {
 		return err
 	}
 
-	if len(c.InputBatch.Items) == 0 {
-		return fmt.Errorf("item key not found in file %s", c.file.Value)
+	if err := validateBatch(c.InputBatch.Items); err != nil {
+		err = errors.RemediationError{
+			Inner:       fmt.Errorf("invalid batch file %s: %w", c.file.Value, err),
+			Remediation: "Consult the API documentation for the JSON format: https://developer.fastly.com/reference/api/dictionaries/dictionary-item/#bulk-update-dictionary-item",
+		}
+		c.Globals.ErrLog.Add(err)
+		return err
 	}
 
-	err = c.Globals.APIClient.BatchModifyDictionaryItems(&c.InputBatch)
+	resolver := cmd.DictionaryResolver(c.Globals.APIClient, c.InputBatch.ServiceID)
+	err = cmd.ResolveOnNotFound(c.InputBatch.DictionaryID, resolver, func(id string) error {
+		c.InputBatch.DictionaryID = id
+		c.Input.DictionaryID = id
+		return cmd.ApplyBatch(c.InputBatch.Items, func(items []*fastly.BatchDictionaryItem) error {
+			return c.Globals.APIClient.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+				ServiceID:    c.InputBatch.ServiceID,
+				DictionaryID: id,
+				Items:        items,
+			})
+		})
+	})
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
 	}
 
-	text.Success(out, "Made %d modifications of Dictionary %s on service %s", len(c.InputBatch.Items), c.Input.DictionaryID, c.InputBatch.ServiceID)
+	ops := make([]fastly.BatchOperation, len(c.InputBatch.Items))
+	for i, item := range c.InputBatch.Items {
+		ops[i] = item.Operation
+	}
+	text.Success(out, "Made %d modifications of Dictionary %s on service %s (%s)", len(c.InputBatch.Items), c.Input.DictionaryID, c.InputBatch.ServiceID, cmd.BatchSummary(ops))
+	return nil
+}
+
+// validateBatch checks every operation of a batch patch file before any are
+// sent, so that a mistake doesn't leave the dictionary partially modified.
+func validateBatch(items []*fastly.BatchDictionaryItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("missing 'items'")
+	}
+	for i, item := range items {
+		if item == nil {
+			return fmt.Errorf("item %d: missing operation", i+1)
+		}
+		if err := cmd.ValidateBatchOperation(item.Operation, cmd.BatchOperations...); err != nil {
+			return fmt.Errorf("item %d: %w", i+1, err)
+		}
+		if item.ItemKey == "" {
+			return fmt.Errorf("item %d: missing 'item_key'", i+1)
+		}
+	}
 	return nil
 }
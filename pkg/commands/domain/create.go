@@ -3,19 +3,19 @@ package domain
 import (
 	"io"
 
+	"github.com/fastly/cli/pkg/api/adapter"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // CreateCommand calls the Fastly API to create domains.
 type CreateCommand struct {
 	cmd.Base
 	manifest       manifest.Data
-	Input          fastly.CreateDomainInput
+	Input          adapter.CreateDomainInput
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	autoClone      cmd.OptionalAutoClone
@@ -27,7 +27,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.Globals = globals
 	c.manifest = data
 	c.CmdClause = parent.Command("create", "Create a domain on a Fastly service version").Alias("add")
-	c.CmdClause.Flag("name", "Domain name").Short('n').Required().StringVar(&c.Input.Name)
+	c.CmdClause.Flag("name", "Domain name").Short('n').Required().Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.Input.Name)
 	c.CmdClause.Flag("comment", "A descriptive note").StringVar(&c.Input.Comment)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
@@ -59,6 +59,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -76,7 +77,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	c.Input.ServiceID = serviceID
 	c.Input.ServiceVersion = serviceVersion.Number
 
-	d, err := c.Globals.APIClient.CreateDomain(&c.Input)
+	d, err := adapter.New(c.Globals.APIClient).Domains().Create(&c.Input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
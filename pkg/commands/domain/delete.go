@@ -3,19 +3,19 @@ package domain
 import (
 	"io"
 
+	"github.com/fastly/cli/pkg/api/adapter"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // DeleteCommand calls the Fastly API to delete domains.
 type DeleteCommand struct {
 	cmd.Base
 	manifest       manifest.Data
-	Input          fastly.DeleteDomainInput
+	Input          adapter.DomainInput
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	autoClone      cmd.OptionalAutoClone
@@ -58,6 +58,7 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -75,7 +76,7 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 	c.Input.ServiceID = serviceID
 	c.Input.ServiceVersion = serviceVersion.Number
 
-	if err := c.Globals.APIClient.DeleteDomain(&c.Input); err != nil {
+	if err := adapter.New(c.Globals.APIClient).Domains().Delete(&c.Input); err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
 			"Service Version": serviceVersion.Number,
@@ -237,6 +237,76 @@ func TestDomainDelete(t *testing.T) {
 	}
 }
 
+func TestDomainMigrate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Args:      args("domain migrate --domain www.test.com --to-service 456"),
+			WantError: "error parsing arguments: required flag --from-service not provided",
+		},
+		{
+			Args:      args("domain migrate --domain www.test.com --from-service 123 --to-service 123"),
+			WantError: "--from-service and --to-service are the same service",
+		},
+		{
+			Args: args("domain migrate --domain www.test.com --from-service 123 --to-service 456 --non-interactive"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetDomainFn:    getDomainOK,
+			},
+			WantError: "destructive operation requires confirmation",
+		},
+		{
+			Args: args("domain migrate --domain www.test.com --from-service 123 --to-service 456 --confirm-destructive"),
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				GetDomainFn:          getDomainOK,
+				CloneVersionFn:       testutil.CloneVersionResult(4),
+				CreateDomainFn:       createDomainOK,
+				ListTLSActivationsFn: listTLSActivations(""),
+			},
+			WantError: "no TLS certificate is activated for www.test.com",
+		},
+		{
+			Args: args("domain migrate --domain www.test.com --from-service 123 --to-service 456 --confirm-destructive"),
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				GetDomainFn:          getDomainOK,
+				CloneVersionFn:       testutil.CloneVersionResult(4),
+				CreateDomainFn:       createDomainOK,
+				ListTLSActivationsFn: listTLSActivations("*.test.com"),
+				ActivateVersionFn:    activateVersionFailFor("123"),
+				DeleteDomainFn:       deleteDomainOK,
+			},
+			WantError:  "error activating service 123 version 4: " + errTest.Error(),
+			WantOutput: "Reactivated service 456 version 1",
+		},
+		{
+			Args: args("domain migrate --domain www.test.com --from-service 123 --to-service 456 --confirm-destructive"),
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				GetDomainFn:          getDomainOK,
+				CloneVersionFn:       testutil.CloneVersionResult(4),
+				CreateDomainFn:       createDomainOK,
+				ListTLSActivationsFn: listTLSActivations("www.test.com"),
+				ActivateVersionFn:    activateVersionFailFor(""),
+				DeleteDomainFn:       deleteDomainOK,
+			},
+			WantOutput: "Migrated domain www.test.com from service 123 (version 4) to service 456 (version 4)",
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
 func TestDomainValidate(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -303,6 +373,38 @@ func TestDomainValidate(t *testing.T) {
 			Args:       args("domain validate --all --service-id 123 --token 123 --version 3"),
 			WantOutput: validateAllAPISuccess(),
 		},
+		{
+			Name: "validate --report junit",
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				ValidateAllDomainsFn: validateAllDomains,
+			},
+			Args:       args("domain validate --all --report junit --service-id 123 --token 123 --version 3"),
+			WantOutput: `<testcase classname="domain validate" name="bar.example.com DNS record"></testcase>`,
+		},
+		{
+			Name: "validate --report sarif",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				ValidateDomainFn: validateDomain,
+			},
+			Args:       args("domain validate --name foo.example.com --report sarif --service-id 123 --token 123 --version 3"),
+			WantOutput: `"id": "domain-dns"`,
+		},
+		{
+			Name: "validate --report with a failed domain",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ValidateDomainFn: func(i *fastly.ValidateDomainInput) (*fastly.DomainValidationResult, error) {
+					r, err := validateDomain(i)
+					r.Valid = false
+					return r, err
+				},
+			},
+			Args:       args("domain validate --name foo.example.com --report junit --service-id 123 --token 123 --version 3"),
+			WantError:  "1 of 1 domains failed validation",
+			WantOutput: `<failure message="the DNS record for foo.example.com does not point to Fastly (CNAME: foo)"`,
+		},
 		{
 			Name: "validate missing --autoclone flag is OK",
 			API: mock.API{
@@ -480,3 +582,27 @@ Name: bar.example.com
 Valid: true
 CNAME: bar`
 }
+
+// listTLSActivations returns an activation when the TLS domain filter matches
+// the given domain.
+func listTLSActivations(domain string) func(i *fastly.ListTLSActivationsInput) ([]*fastly.TLSActivation, error) {
+	return func(i *fastly.ListTLSActivationsInput) ([]*fastly.TLSActivation, error) {
+		if i.FilterTLSDomainID != domain {
+			return nil, nil
+		}
+		return []*fastly.TLSActivation{
+			{ID: "abc", Domain: &fastly.TLSDomain{ID: domain}},
+		}, nil
+	}
+}
+
+// activateVersionFailFor returns an error when activating a version of the
+// given service.
+func activateVersionFailFor(serviceID string) func(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	return func(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+		if i.ServiceID == serviceID {
+			return nil, errTest
+		}
+		return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Active: true}, nil
+	}
+}
@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/undo"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// MigrateCommand moves a domain from one service to another.
+type MigrateCommand struct {
+	cmd.Base
+	domain       string
+	from         string
+	skipTLSCheck bool
+	to           string
+}
+
+// NewMigrateCommand returns a usable command registered under the parent.
+func NewMigrateCommand(parent cmd.Registerer, globals *config.Data) *MigrateCommand {
+	var c MigrateCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("migrate", "Move a domain from one Fastly service to another, rolling back on failure")
+	c.SetMutating()
+	c.CmdClause.Flag("domain", "Domain name").Short('n').Required().Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.domain)
+	c.CmdClause.Flag("from-service", "ID of the service the domain is currently on").Required().StringVar(&c.from)
+	c.CmdClause.Flag("skip-tls-check", "Don't require a TLS certificate to be activated for the domain").BoolVar(&c.skipTLSCheck)
+	c.CmdClause.Flag("to-service", "ID of the service to move the domain to").Required().StringVar(&c.to)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+//
+// The domain is added to a new version of the target service, which is
+// activated before the domain is removed from a new version of the source
+// service, so that the domain is served throughout. If a step fails, the
+// service versions that were activated are rolled back.
+func (c *MigrateCommand) Exec(in io.Reader, out io.Writer) (err error) {
+	if c.from == c.to {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--from-service and --to-service are the same service"),
+			Remediation: "Set --to-service to the ID of the service to move the domain to.",
+		}
+	}
+
+	defer func() {
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Domain":       c.domain,
+				"From Service": c.from,
+				"To Service":   c.to,
+			})
+		}
+	}()
+
+	fromActive, err := c.activeVersion(c.from)
+	if err != nil {
+		return err
+	}
+	if fromActive == nil {
+		return fmt.Errorf("service %s has no active version", c.from)
+	}
+	d, err := c.Globals.APIClient.GetDomain(&fastly.GetDomainInput{
+		ServiceID:      c.from,
+		ServiceVersion: fromActive.Number,
+		Name:           c.domain,
+	})
+	if err != nil {
+		var httpErr *fastly.HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+			return fmt.Errorf("domain %s isn't on the active version (%d) of service %s", c.domain, fromActive.Number, c.from)
+		}
+		return err
+	}
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: c.to,
+	})
+	if err != nil {
+		return err
+	}
+	toActive, _ := cmd.GetActiveVersion(versions)
+	toBase := toActive
+	if toBase == nil {
+		toBase = latestVersion(versions)
+	}
+	if toBase == nil {
+		return fmt.Errorf("service %s has no versions", c.to)
+	}
+
+	text.Output(out, "Migrating domain %s from service %s to service %s:", c.domain, c.from, c.to)
+	text.Output(out, "\t1. Add the domain to a clone of service %s version %d", c.to, toBase.Number)
+	text.Output(out, "\t2. Check a TLS certificate is activated for the domain")
+	text.Output(out, "\t3. Activate the new version of service %s", c.to)
+	text.Output(out, "\t4. Remove the domain from a clone of service %s version %d", c.from, fromActive.Number)
+	text.Output(out, "\t5. Activate the new version of service %s", c.from)
+	text.Break(out)
+
+	cont, err := cmd.Confirm(cmd.ConfirmDestructive, "Are you sure you want to migrate the domain? [y/N] ", c.Globals, in, out)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return fsterr.ErrConfirmationDeclined
+	}
+	text.Break(out)
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	undoStack := undo.NewStack()
+	defer func() {
+		if err != nil {
+			progress.Fail()
+			if undoStack.Len() > 0 {
+				text.Break(out)
+				text.Warning(out, "Rolling back the migration")
+			}
+			undoStack.RunIfError(out, err)
+		}
+	}()
+
+	progress.Step(fmt.Sprintf("Adding domain to service %s...", c.to))
+	toVersion, err := c.Globals.APIClient.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      c.to,
+		ServiceVersion: toBase.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error cloning service %s version %d: %w", c.to, toBase.Number, err)
+	}
+	_, err = c.Globals.APIClient.CreateDomain(&fastly.CreateDomainInput{
+		ServiceID:      c.to,
+		ServiceVersion: toVersion.Number,
+		Name:           d.Name,
+		Comment:        d.Comment,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding domain to service %s version %d: %w", c.to, toVersion.Number, err)
+	}
+
+	if !c.skipTLSCheck {
+		progress.Step("Checking TLS coverage...")
+		if err = c.checkTLS(); err != nil {
+			return err
+		}
+	}
+
+	progress.Step(fmt.Sprintf("Activating service %s version %d...", c.to, toVersion.Number))
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      c.to,
+		ServiceVersion: toVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error activating service %s version %d: %w", c.to, toVersion.Number, err)
+	}
+	undoStack.Push(func() error {
+		if toActive != nil {
+			_, err := c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+				ServiceID:      c.to,
+				ServiceVersion: toActive.Number,
+			})
+			if err != nil {
+				return fmt.Errorf("error reactivating service %s version %d: %w", c.to, toActive.Number, err)
+			}
+			text.Output(out, "Reactivated service %s version %d", c.to, toActive.Number)
+			return nil
+		}
+		_, err := c.Globals.APIClient.DeactivateVersion(&fastly.DeactivateVersionInput{
+			ServiceID:      c.to,
+			ServiceVersion: toVersion.Number,
+		})
+		if err != nil {
+			return fmt.Errorf("error deactivating service %s version %d: %w", c.to, toVersion.Number, err)
+		}
+		text.Output(out, "Deactivated service %s version %d", c.to, toVersion.Number)
+		return nil
+	})
+
+	progress.Step(fmt.Sprintf("Removing domain from service %s...", c.from))
+	fromVersion, err := c.Globals.APIClient.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      c.from,
+		ServiceVersion: fromActive.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error cloning service %s version %d: %w", c.from, fromActive.Number, err)
+	}
+	err = c.Globals.APIClient.DeleteDomain(&fastly.DeleteDomainInput{
+		ServiceID:      c.from,
+		ServiceVersion: fromVersion.Number,
+		Name:           c.domain,
+	})
+	if err != nil {
+		return fmt.Errorf("error removing domain from service %s version %d: %w", c.from, fromVersion.Number, err)
+	}
+
+	progress.Step(fmt.Sprintf("Activating service %s version %d...", c.from, fromVersion.Number))
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      c.from,
+		ServiceVersion: fromVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error activating service %s version %d: %w", c.from, fromVersion.Number, err)
+	}
+
+	progress.Done()
+	text.Success(out, "Migrated domain %s from service %s (version %d) to service %s (version %d)", c.domain, c.from, fromVersion.Number, c.to, toVersion.Number)
+	return nil
+}
+
+// activeVersion returns the active version of the service, or nil if no
+// version is active.
+func (c *MigrateCommand) activeVersion(serviceID string) (*fastly.Version, error) {
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	v, _ := cmd.GetActiveVersion(versions)
+	return v, nil
+}
+
+// checkTLS returns an error unless a TLS certificate is activated for the
+// domain, or for a wildcard that matches it.
+func (c *MigrateCommand) checkTLS() error {
+	names := []string{c.domain}
+	if i := strings.Index(c.domain, "."); i >= 0 {
+		names = append(names, "*"+c.domain[i:])
+	}
+	for _, name := range names {
+		activations, err := c.Globals.APIClient.ListTLSActivations(&fastly.ListTLSActivationsInput{
+			FilterTLSDomainID: name,
+		})
+		if err != nil {
+			return fmt.Errorf("error checking TLS activations for %s: %w", name, err)
+		}
+		if len(activations) > 0 {
+			return nil
+		}
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("no TLS certificate is activated for %s", c.domain),
+		Remediation: "Activate a TLS certificate for the domain before migrating it, or use --skip-tls-check if it isn't served over HTTPS.",
+	}
+}
+
+func latestVersion(versions []*fastly.Version) *fastly.Version {
+	var latest *fastly.Version
+	for _, v := range versions {
+		if latest == nil || v.Number > latest.Number {
+			latest = v
+		}
+	}
+	return latest
+}
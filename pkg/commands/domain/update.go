@@ -4,19 +4,19 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/fastly/cli/pkg/api/adapter"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // UpdateCommand calls the Fastly API to update domains.
 type UpdateCommand struct {
 	cmd.Base
 	manifest       manifest.Data
-	input          fastly.UpdateDomainInput
+	input          adapter.UpdateDomainInput
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	autoClone      cmd.OptionalAutoClone
@@ -54,7 +54,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Dst:    &c.autoClone.Value,
 	})
 	c.CmdClause.Flag("name", "Domain name").Short('n').Required().StringVar(&c.input.Name)
-	c.CmdClause.Flag("new-name", "New domain name").Action(c.NewName.Set).StringVar(&c.NewName.Value)
+	c.CmdClause.Flag("new-name", "New domain name").Action(c.NewName.Set).Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.NewName.Value)
 	c.CmdClause.Flag("comment", "A descriptive note").Action(c.Comment.Set).StringVar(&c.Comment.Value)
 	return &c
 }
@@ -64,6 +64,7 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -87,13 +88,13 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	if c.NewName.WasSet {
-		c.input.NewName = fastly.String(c.NewName.Value)
+		c.input.NewName = adapter.String(c.NewName.Value)
 	}
 	if c.Comment.WasSet {
-		c.input.Comment = fastly.String(c.Comment.Value)
+		c.input.Comment = adapter.String(c.Comment.Value)
 	}
 
-	d, err := c.Globals.APIClient.UpdateDomain(&c.input)
+	d, err := adapter.New(c.Globals.APIClient).Domains().Update(&c.input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
@@ -8,6 +8,8 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/report"
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -29,6 +31,8 @@ func NewValidateCommand(parent cmd.Registerer, globals *config.Data, data manife
 	// Optional flags
 	c.CmdClause.Flag("all", "Checks the status of all domains' DNS records for a Service Version").Short('a').BoolVar(&c.all)
 	c.CmdClause.Flag("name", "The name of the domain associated with this service").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("report", "Write the validation findings as a report (junit, sarif)").HintOptions(report.Formats...).EnumVar(&c.report, report.Formats...)
+	c.CmdClause.Flag("report-file", "Path to write the --report output to (default: stdout)").StringVar(&c.reportFile)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -52,6 +56,8 @@ type ValidateCommand struct {
 	all            bool
 	manifest       manifest.Data
 	name           cmd.OptionalString
+	report         string
+	reportFile     string
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 }
@@ -66,6 +72,7 @@ func (c *ValidateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -92,6 +99,9 @@ func (c *ValidateCommand) Exec(in io.Reader, out io.Writer) error {
 			return err
 		}
 
+		if c.report != "" {
+			return c.writeReport(out, r)
+		}
 		c.printAll(out, r)
 		return nil
 	}
@@ -111,10 +121,56 @@ func (c *ValidateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	if c.report != "" {
+		return c.writeReport(out, []*fastly.DomainValidationResult{r})
+	}
 	c.print(out, r)
 	return nil
 }
 
+// writeReport renders the domain validation results as a report, either to
+// the --report-file or, if not set, to stdout. It returns an error if any
+// domain failed validation.
+func (c *ValidateCommand) writeReport(out io.Writer, rs []*fastly.DomainValidationResult) error {
+	r := report.Report{Command: "domain validate"}
+	for _, v := range rs {
+		f := report.Finding{
+			Rule:     "domain-dns",
+			Name:     fmt.Sprintf("%s DNS record", v.Metadata.Name),
+			Location: v.Metadata.Name,
+		}
+		if !v.Valid {
+			f.Failed = true
+			f.Message = fmt.Sprintf("the DNS record for %s does not point to Fastly", v.Metadata.Name)
+			if v.CName != "" {
+				f.Message = fmt.Sprintf("%s (CNAME: %s)", f.Message, v.CName)
+			}
+		}
+		r.Findings = append(r.Findings, f)
+	}
+
+	if c.reportFile == "" {
+		if err := report.Write(out, c.report, r); err != nil {
+			return err
+		}
+	} else {
+		if err := report.WriteFile(c.reportFile, c.report, r); err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Report File": c.reportFile,
+			})
+			return err
+		}
+		text.Info(out, "Wrote %s report to %s", c.report, c.reportFile)
+	}
+
+	// As with compute validate, a failed check fails the command so that CI
+	// doesn't need to parse the report.
+	if n := r.Failures(); n > 0 {
+		return fmt.Errorf("%d of %d domains failed validation", n, len(r.Findings))
+	}
+	return nil
+}
+
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
 func (c *ValidateCommand) constructInput(serviceID string, serviceVersion int) (*fastly.ValidateDomainInput, error) {
 	var input fastly.ValidateDomainInput
@@ -144,13 +200,13 @@ func (c *ValidateCommand) print(out io.Writer, r *fastly.DomainValidationResult)
 		fmt.Fprintf(out, "CNAME: %s\n", r.CName)
 	}
 	if r.Metadata.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", r.Metadata.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*r.Metadata.CreatedAt))
 	}
 	if r.Metadata.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", r.Metadata.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*r.Metadata.UpdatedAt))
 	}
 	if r.Metadata.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", r.Metadata.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*r.Metadata.DeletedAt))
 	}
 	fmt.Fprintf(out, "\n")
 }
@@ -180,13 +236,13 @@ func (c *ValidateCommand) printAll(out io.Writer, rs []*fastly.DomainValidationR
 			fmt.Fprintf(out, "CNAME: %s\n", r.CName)
 		}
 		if r.Metadata.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", r.Metadata.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*r.Metadata.CreatedAt))
 		}
 		if r.Metadata.UpdatedAt != nil {
-			fmt.Fprintf(out, "Updated at: %s\n", r.Metadata.UpdatedAt)
+			fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*r.Metadata.UpdatedAt))
 		}
 		if r.Metadata.DeletedAt != nil {
-			fmt.Fprintf(out, "Deleted at: %s\n", r.Metadata.DeletedAt)
+			fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*r.Metadata.DeletedAt))
 		}
 		fmt.Fprintf(out, "\n")
 	}
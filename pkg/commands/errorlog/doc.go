@@ -0,0 +1,3 @@
+// Package errorlog contains commands to browse the CLI's error log, which
+// records the errors, and their context, of past runs that failed.
+package errorlog
@@ -0,0 +1,136 @@
+package errorlog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+// errorLog is an error log recording three runs, a week, a day and an hour
+// before the test's current time.
+const errorLog = `
+COMMAND:
+fastly service list --token abc123
+
+TIMESTAMP:
+2022-01-01 12:00:00 +0000 UTC
+
+ERROR:
+Token abc123 is invalid
+
+FILE:
+/pkg/commands/service/list.go
+
+LINE:
+68
+
+------------------------------
+
+
+COMMAND:
+fastly backend create --version 3
+
+TIMESTAMP:
+2022-01-07 12:00:00.5 +0000 UTC m=+0.123
+
+ERROR:
+error parsing arguments: required flag --name not provided
+
+------------------------------
+
+
+COMMAND:
+fastly domain create --name example.com --version 2
+
+TIMESTAMP:
+2022-01-08 11:00:00 +0000 UTC
+
+ERROR:
+service version 2 is not editable
+
+FILE:
+/pkg/cmd/flags.go
+
+LINE:
+307
+
+
+  Service ID: 123
+
+  Service Version: 2
+
+------------------------------
+
+`
+
+func TestShow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	if err := os.WriteFile(path, []byte(errorLog), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	logPath := fsterr.LogPath
+	fsterr.LogPath = path
+	fsterr.Now = func() time.Time { return time.Date(2022, 1, 8, 12, 0, 0, 0, time.UTC) }
+	defer func() {
+		fsterr.LogPath = logPath
+		fsterr.Now = time.Now
+	}()
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "last run by default",
+			Args: args("errors show"),
+			WantOutputs: []string{
+				"fastly domain create --name example.com --version 2",
+				"  Error: service version 2 is not editable\n  At: /pkg/cmd/flags.go:307\n  Service ID: 123\n  Service Version: 2\n",
+			},
+		},
+		{
+			Name: "last runs with tokens redacted",
+			Args: args("errors show --last 3"),
+			WantOutputs: []string{
+				"fastly service list --token REDACTED",
+				"  Error: Token REDACTED is invalid",
+				"fastly backend create --version 3",
+				"fastly domain create --name example.com --version 2",
+			},
+		},
+		{
+			Name:       "runs since",
+			Args:       args("errors show --since 2d --json"),
+			WantOutput: `[{"command":"fastly backend create --version 3","time":"2022-01-07T12:00:00.5Z","errors":[{"time":"2022-01-07T12:00:00.5Z","error":"error parsing arguments: required flag --name not provided"}]},{"command":"fastly domain create --name example.com --version 2","time":"2022-01-08T11:00:00Z","errors":[{"time":"2022-01-08T11:00:00Z","error":"service version 2 is not editable","file":"/pkg/cmd/flags.go","line":307,"context":{"Service ID":"123","Service Version":"2"}}]}]`,
+		},
+		{
+			Name:       "no runs since",
+			Args:       args("errors show --since 30m --json"),
+			WantOutput: "[]",
+		},
+		{
+			Name:      "invalid --last",
+			Args:      args("errors show --last=-1"),
+			WantError: "invalid --last -1",
+		},
+	}
+	testutil.RunScenarios(t, scenarios)
+}
+
+func TestShowNoLog(t *testing.T) {
+	logPath := fsterr.LogPath
+	fsterr.LogPath = filepath.Join(t.TempDir(), "errors.log")
+	defer func() {
+		fsterr.LogPath = logPath
+	}()
+
+	testutil.RunScenarios(t, []testutil.TestScenario{
+		{
+			Name:       "no errors logged",
+			Args:       testutil.Args("errors show"),
+			WantOutput: "No errors have been logged.",
+		},
+	})
+}
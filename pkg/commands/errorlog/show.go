@@ -0,0 +1,121 @@
+package errorlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ShowCommand displays the errors logged by past runs of the CLI.
+type ShowCommand struct {
+	cmd.Base
+
+	json  bool
+	last  int
+	since string
+}
+
+// NewShowCommand returns a usable command registered under the parent.
+func NewShowCommand(parent cmd.Registerer, globals *config.Data) *ShowCommand {
+	var c ShowCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("show", "Show the errors, and their context, logged by past runs that failed. Tokens are redacted")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("last", "Number of most recent runs to show (default: 1, or all of those in the --since period)").IntVar(&c.last)
+	c.CmdClause.Flag("since", "Only show runs in this period before now, e.g. 1h or 7d").Action(cmd.Validate(cmd.ValidateLongDuration)).StringVar(&c.since)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ShowCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.last < 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --last %d", c.last),
+			Remediation: "Give the number of runs to show, of at least 1.",
+		}
+	}
+
+	runs, err := fsterr.ReadLog(fsterr.LogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading error log: %w", err)
+	}
+
+	if c.since != "" {
+		// The flag is validated when it's parsed.
+		since, _ := cmd.ParseLongDuration(c.since)
+		from := fsterr.Now().Add(-since)
+		var recent []fsterr.LoggedRun
+		for _, r := range runs {
+			if !r.Time.Before(from) {
+				recent = append(recent, r)
+			}
+		}
+		runs = recent
+	}
+	last := c.last
+	if last == 0 && c.since == "" {
+		last = 1
+	}
+	if last > 0 && len(runs) > last {
+		runs = runs[len(runs)-last:]
+	}
+
+	sanitized := make([]fsterr.LoggedRun, 0, len(runs))
+	for _, r := range runs {
+		sanitized = append(sanitized, r.Sanitize())
+	}
+
+	if c.json {
+		data, err := json.Marshal(sanitized)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(sanitized) == 0 {
+		text.Info(out, "No errors have been logged.")
+		return nil
+	}
+	for i, r := range sanitized {
+		if i > 0 {
+			text.Break(out)
+		}
+		display(r, out, c.Globals.Formatter())
+	}
+	return nil
+}
+
+// display writes a run's errors, with where they occurred and their context.
+func display(r fsterr.LoggedRun, out io.Writer, f text.Formatter) {
+	fmt.Fprintf(out, "%s (%s)\n", r.Command, f.Time(r.Time))
+	for _, e := range r.Errors {
+		text.Break(out)
+		fmt.Fprintf(out, "  Error: %s\n", strings.ReplaceAll(e.Error, "\n", "\n         "))
+		if e.File != "" {
+			fmt.Fprintf(out, "  At: %s:%d\n", e.File, e.Line)
+		}
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(out, "  %s: %s\n", k, e.Context[k])
+		}
+	}
+}
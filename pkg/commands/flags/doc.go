@@ -0,0 +1,3 @@
+// Package flags contains commands to manage feature flags stored as the items
+// of an edge dictionary.
+package flags
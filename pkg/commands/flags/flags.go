@@ -0,0 +1,98 @@
+package flags
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// DefaultDictionary is the name of the dictionary that holds the flags when
+// --dictionary isn't given.
+const DefaultDictionary = "feature_flags"
+
+// The values that a flag's dictionary item is set to. A flag that's being
+// ramped up has a percentage value such as "25%", which VCL can test with
+// e.g. randombool(std.atoi(table.lookup(feature_flags, "new_checkout")), 100).
+const (
+	ValueEnabled  = "true"
+	ValueDisabled = "false"
+)
+
+// The states of a flag.
+const (
+	StateEnabled  = "enabled"
+	StateDisabled = "disabled"
+	StateRamp     = "ramp"
+	StateInvalid  = "invalid"
+)
+
+// Flag is a feature flag and its state.
+type Flag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	State string `json:"state"`
+	// Percentage is the percentage of requests the flag is enabled for.
+	Percentage int `json:"percentage"`
+}
+
+// String describes the flag's state, e.g. "enabled" or "25% of requests".
+func (f Flag) String() string {
+	switch f.State {
+	case StateRamp:
+		return fmt.Sprintf("%d%% of requests", f.Percentage)
+	case StateInvalid:
+		return fmt.Sprintf("invalid value '%s'", f.Value)
+	}
+	return f.State
+}
+
+// Parse returns the flag for a dictionary item. Values other than those set
+// by the enable and disable commands are accepted where their meaning is
+// unambiguous (e.g. "on" and "off"), as teams often set them by hand.
+func Parse(name, value string) Flag {
+	f := Flag{Name: name, Value: value}
+	v := strings.ToLower(strings.TrimSpace(value))
+	switch v {
+	case ValueEnabled, "on", "yes", "1":
+		f.State, f.Percentage = StateEnabled, 100
+		return f
+	case ValueDisabled, "off", "no", "0":
+		f.State = StateDisabled
+		return f
+	}
+	if p, err := strconv.Atoi(strings.TrimSuffix(v, "%")); err == nil && strings.HasSuffix(v, "%") && p >= 0 && p <= 100 {
+		f.State, f.Percentage = StateRamp, p
+		return f
+	}
+	f.State = StateInvalid
+	return f
+}
+
+// flagName matches the names that are accepted for flags, so that they can
+// be used as dictionary keys and referred to from VCL without quoting issues.
+var flagName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidateName validates the name of a flag.
+func ValidateName(value string) error {
+	if !flagName.MatchString(value) {
+		return fmt.Errorf("must start with a letter or digit and contain only letters, digits, '_', '.' and '-'")
+	}
+	return nil
+}
+
+// dictionaryID returns the ID of the named dictionary in the service version.
+func dictionaryID(client api.Interface, serviceID string, version int, name string) (string, error) {
+	d, err := client.GetDictionary(&fastly.GetDictionaryInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting dictionary '%s' of service version %d: %w", name, version, err)
+	}
+	return d.ID, nil
+}
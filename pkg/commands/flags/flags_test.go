@@ -0,0 +1,188 @@
+package flags_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/flags"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestFlagsEnable(t *testing.T) {
+	args := testutil.Args
+	var value string
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		GetDictionaryFn: func(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+			if i.Name != flags.DefaultDictionary {
+				return nil, testutil.Err
+			}
+			return &fastly.Dictionary{ID: "456", Name: i.Name}, nil
+		},
+		GetDictionaryItemFn: func(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
+			if i.ItemKey == "new_flag" {
+				return nil, &fastly.HTTPError{StatusCode: 404}
+			}
+			return &fastly.DictionaryItem{ItemKey: i.ItemKey, ItemValue: "off"}, nil
+		},
+		UpdateDictionaryItemFn: func(i *fastly.UpdateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+			value = i.ItemValue
+			return &fastly.DictionaryItem{ItemKey: i.ItemKey, ItemValue: i.ItemValue}, nil
+		},
+	}
+
+	scenarios := []struct {
+		testutil.TestScenario
+		wantValue string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate invalid flag name",
+				Args:      args("flags enable --service-id 123 --name new/flag"),
+				WantError: "error parsing arguments: invalid --name 'new/flag': must start with a letter or digit",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate invalid --percentage",
+				Args:      args("flags enable --service-id 123 --name new_flag --percentage 150"),
+				WantError: "error parsing arguments: invalid --percentage '150': must be a whole number between 0 and 100",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate missing dictionary",
+				API:       api,
+				Args:      args("flags enable --service-id 123 --name new_flag --dictionary flags"),
+				WantError: "error getting dictionary 'flags' of service version 1",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate enabling a new flag",
+				API:        api,
+				Args:       args("flags enable --service-id 123 --name new_flag"),
+				WantOutput: "Flag 'new_flag' is now enabled (was: unset, service: 123)",
+			},
+			wantValue: "true",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate ramping up a flag",
+				API:        api,
+				Args:       args("flags enable --service-id 123 --name checkout --percentage 25"),
+				WantOutput: "Flag 'checkout' is now 25% of requests (was: disabled, service: 123)",
+			},
+			wantValue: "25%",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate disabling a flag",
+				API:        api,
+				Args:       args("flags disable --service-id 123 --name checkout"),
+				WantOutput: "Flag 'checkout' is now disabled",
+			},
+			wantValue: "false",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			value = ""
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			testutil.AssertString(t, testcase.wantValue, value)
+		})
+	}
+
+	t.Run("validate the change is logged", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "cli.log")
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(args("flags disable --service-id 123 --name checkout --reason incident --log-file "+logFile), &stdout)
+		opts.APIClient = mock.APIClient(api)
+		testutil.AssertNoError(t, app.Run(opts))
+		data, err := os.ReadFile(logFile)
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, string(data), `"msg":"feature flag changed"`)
+		testutil.AssertStringContains(t, string(data), `"flag":"checkout","from":"disabled"`)
+		testutil.AssertStringContains(t, string(data), `"reason":"incident"`)
+	})
+}
+
+func TestFlagsList(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		GetDictionaryFn: func(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+			return &fastly.Dictionary{ID: "456", Name: i.Name}, nil
+		},
+		NewListDictionaryItemsPaginatorFn: func(*fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
+			return &itemsPaginator{items: []*fastly.DictionaryItem{
+				{ItemKey: "search", ItemValue: "on"},
+				{ItemKey: "checkout", ItemValue: "25%"},
+				{ItemKey: "banner", ItemValue: "maybe"},
+			}}
+		},
+	}
+	testutil.RunScenarios(t, []testutil.TestScenario{
+		{
+			Name: "validate flags are listed",
+			API:  api,
+			Args: args("flags list --service-id 123"),
+			WantOutputs: []string{
+				"banner    invalid value 'maybe'  maybe",
+				"checkout  25% of requests        25%",
+				"search    enabled                on",
+				"1 flags have a value that isn't a boolean or a percentage",
+			},
+		},
+		{
+			Name:       "validate JSON output",
+			API:        api,
+			Args:       args("flags list --service-id 123 --json"),
+			WantOutput: `{"name":"checkout","value":"25%","state":"ramp","percentage":25}`,
+		},
+	})
+}
+
+func TestParse(t *testing.T) {
+	for value, want := range map[string]string{
+		"true":  flags.StateEnabled,
+		"ON":    flags.StateEnabled,
+		"0":     flags.StateDisabled,
+		"off":   flags.StateDisabled,
+		"50%":   flags.StateRamp,
+		"150%":  flags.StateInvalid,
+		"50":    flags.StateInvalid,
+		"maybe": flags.StateInvalid,
+	} {
+		testutil.AssertString(t, want, flags.Parse("x", value).State)
+	}
+}
+
+type itemsPaginator struct {
+	items []*fastly.DictionaryItem
+	done  bool
+}
+
+func (p *itemsPaginator) HasNext() bool {
+	return !p.done
+}
+
+func (p *itemsPaginator) Remaining() int {
+	return 0
+}
+
+func (p *itemsPaginator) GetNext() ([]*fastly.DictionaryItem, error) {
+	p.done = true
+	return p.items, nil
+}
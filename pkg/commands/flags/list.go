@@ -0,0 +1,146 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ListCommand lists the feature flags and their states.
+type ListCommand struct {
+	cmd.Base
+
+	dictionary     string
+	json           bool
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("list", "List the feature flags and their states")
+	c.CmdClause.Flag("dictionary", "Name of the dictionary that holds the flags").Default(DefaultDictionary).StringVar(&c.dictionary)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " whose dictionary holds the flags (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	dictionaryID, err := dictionaryID(c.Globals.APIClient, serviceID, serviceVersion.Number, c.dictionary)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Dictionary":      c.dictionary,
+		})
+		return err
+	}
+
+	paginator := c.Globals.APIClient.NewListDictionaryItemsPaginator(&fastly.ListDictionaryItemsInput{
+		ServiceID:    serviceID,
+		DictionaryID: dictionaryID,
+	})
+	flags := []Flag{}
+	for paginator.HasNext() {
+		data, err := paginator.GetNext()
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Dictionary ID":   dictionaryID,
+				"Remaining Pages": paginator.Remaining(),
+			})
+			return err
+		}
+		for _, item := range data {
+			flags = append(flags, Parse(item.ItemKey, item.ItemValue))
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+
+	if c.json {
+		data, err := json.Marshal(flags)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(flags) == 0 {
+		text.Info(out, "The dictionary '%s' has no flags. Use 'fastly flags enable' to add one.", c.dictionary)
+		return nil
+	}
+	t := text.NewTable(out)
+	t.AddHeader("FLAG", "STATE", "VALUE")
+	var invalid int
+	for _, f := range flags {
+		if f.State == StateInvalid {
+			invalid++
+		}
+		t.AddLine(f.Name, f.String(), f.Value)
+	}
+	t.Print()
+	if invalid > 0 {
+		text.Break(out)
+		text.Warning(out, "%d flags have a value that isn't a boolean or a percentage (e.g. 25%%). Use 'fastly flags enable' or 'fastly flags disable' to correct them.", invalid)
+	}
+	return nil
+}
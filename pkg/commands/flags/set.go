@@ -0,0 +1,168 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logger"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/kingpin"
+)
+
+// SetCommand enables or disables a feature flag.
+type SetCommand struct {
+	cmd.Base
+
+	dictionary     string
+	enable         bool
+	manifest       manifest.Data
+	name           string
+	percentage     cmd.OptionalInt
+	reason         string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewEnableCommand returns a usable command registered under the parent.
+func NewEnableCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *SetCommand {
+	c := newSetCommand(parent.Command("enable", "Enable a feature flag, optionally for a percentage of requests"), globals, data)
+	c.enable = true
+	c.CmdClause.Flag("percentage", "Only enable the flag for this percentage of requests (0-100), to ramp it up gradually").Action(c.percentage.Set).Action(cmd.Validate(cmd.ValidateRange(0, 100))).IntVar(&c.percentage.Value)
+	return c
+}
+
+// NewDisableCommand returns a usable command registered under the parent.
+func NewDisableCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *SetCommand {
+	return newSetCommand(parent.Command("disable", "Disable a feature flag"), globals, data)
+}
+
+func newSetCommand(clause *kingpin.CmdClause, globals *config.Data, data manifest.Data) *SetCommand {
+	var c SetCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = clause
+	c.CmdClause.Flag("dictionary", "Name of the dictionary that holds the flags").Default(DefaultDictionary).StringVar(&c.dictionary)
+	c.CmdClause.Flag("name", "Name of the flag").Short('n').Required().Action(cmd.Validate(ValidateName)).StringVar(&c.name)
+	c.CmdClause.Flag("reason", "Why the flag is being changed, recorded in the log (see --log-file)").StringVar(&c.reason)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " whose dictionary holds the flags (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *SetCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	dictionaryID, err := dictionaryID(c.Globals.APIClient, serviceID, serviceVersion.Number, c.dictionary)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Dictionary":      c.dictionary,
+		})
+		return fsterr.RemediationError{
+			Inner:       err,
+			Remediation: fmt.Sprintf("Create the dictionary with 'fastly dictionary create --name %s', or use --dictionary to name the dictionary that holds the flags.", c.dictionary),
+		}
+	}
+
+	previous := "unset"
+	item, err := c.Globals.APIClient.GetDictionaryItem(&fastly.GetDictionaryItemInput{
+		ServiceID:    serviceID,
+		DictionaryID: dictionaryID,
+		ItemKey:      c.name,
+	})
+	var httpErr *fastly.HTTPError
+	switch {
+	case errors.As(err, &httpErr) && httpErr.IsNotFound():
+	case err != nil:
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":    serviceID,
+			"Dictionary ID": dictionaryID,
+			"Flag":          c.name,
+		})
+		return err
+	default:
+		previous = Parse(item.ItemKey, item.ItemValue).String()
+	}
+
+	value := ValueDisabled
+	if c.enable {
+		value = ValueEnabled
+		if c.percentage.WasSet && c.percentage.Value < 100 {
+			value = fmt.Sprintf("%d%%", c.percentage.Value)
+		}
+	}
+	_, err = c.Globals.APIClient.UpdateDictionaryItem(&fastly.UpdateDictionaryItemInput{
+		ServiceID:    serviceID,
+		DictionaryID: dictionaryID,
+		ItemKey:      c.name,
+		ItemValue:    value,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":    serviceID,
+			"Dictionary ID": dictionaryID,
+			"Flag":          c.name,
+		})
+		return err
+	}
+
+	f := Parse(c.name, value)
+	profile, _ := c.Globals.CurrentProfile()
+	fields := logger.Fields{
+		"service_id": serviceID,
+		"dictionary": c.dictionary,
+		"flag":       c.name,
+		"from":       previous,
+		"to":         f.String(),
+		"profile":    profile,
+	}
+	if c.reason != "" {
+		fields["reason"] = c.reason
+	}
+	c.Globals.Logger.Info("feature flag changed", fields)
+
+	text.Success(out, "Flag '%s' is now %s (was: %s, service: %s)", c.name, f, previous, serviceID)
+	return nil
+}
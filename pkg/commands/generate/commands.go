@@ -0,0 +1,229 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// CommandsCommand prints the CLI commands that would recreate a service from
+// a service export.
+type CommandsCommand struct {
+	cmd.Base
+	activate bool
+	from     string
+}
+
+// NewCommandsCommand returns a usable command registered under the parent.
+func NewCommandsCommand(parent cmd.Registerer, globals *config.Data) *CommandsCommand {
+	var c CommandsCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("commands", "Print the CLI commands that would recreate a service from a service export")
+	c.CmdClause.Flag("from", "Path to a YAML, TOML or JSON service export, or - for stdin").Required().StringVar(&c.from)
+	c.CmdClause.Flag("activate", "Include a final command to activate the recreated service version").BoolVar(&c.activate)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CommandsCommand) Exec(in io.Reader, out io.Writer) error {
+	f, err := cmd.Open(c.from, c.Globals.Stdin)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error reading service export: %w", err),
+			Remediation: "Check the --from flag refers to a readable file.",
+		}
+	}
+	defer f.Close() // #nosec G307
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading service export: %w", err)
+	}
+
+	e, err := ParseExport(c.from, data)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	fmt.Fprintf(out, "# Commands to recreate service %s.\n", quote(e.Service.Name))
+	for _, line := range Commands(e, c.activate) {
+		fmt.Fprintln(out, line)
+	}
+	return nil
+}
+
+// Commands returns the CLI commands (and explanatory comments) that would
+// recreate the exported service. Resources that are created within a service
+// version target the latest version, which is the draft created alongside the
+// service.
+func Commands(e Export, activate bool) []string {
+	var lines []string
+	add := func(args ...string) {
+		lines = append(lines, "fastly "+strings.Join(args, " "))
+	}
+	svc := []string{"--service-name", quote(e.Service.Name)}
+	versioned := []string{"--service-name", quote(e.Service.Name), "--version", "latest"}
+
+	args := []string{"service", "create", "--name", quote(e.Service.Name)}
+	args = appendString(args, "--type", e.Service.Type)
+	args = appendString(args, "--comment", e.Service.Comment)
+	add(args...)
+
+	for _, d := range e.Domains {
+		args := append([]string{"domain", "create"}, versioned...)
+		args = append(args, "--name", quote(d.Name))
+		args = appendString(args, "--comment", d.Comment)
+		add(args...)
+	}
+
+	for _, h := range e.Healthchecks {
+		args := append([]string{"healthcheck", "create"}, versioned...)
+		args = append(args, "--name", quote(h.Name))
+		args = appendString(args, "--comment", h.Comment)
+		args = appendString(args, "--method", h.Method)
+		args = appendString(args, "--host", h.Host)
+		args = appendString(args, "--path", h.Path)
+		args = appendString(args, "--http-version", h.HTTPVersion)
+		args = appendInt(args, "--timeout", h.Timeout)
+		args = appendInt(args, "--check-interval", h.CheckInterval)
+		args = appendInt(args, "--expected-response", h.ExpectedResponse)
+		args = appendInt(args, "--window", h.Window)
+		args = appendInt(args, "--threshold", h.Threshold)
+		args = appendInt(args, "--initial", h.Initial)
+		add(args...)
+	}
+
+	for _, b := range e.Backends {
+		args := append([]string{"backend", "create"}, versioned...)
+		args = append(args, "--name", quote(b.Name), "--address", quote(b.Address))
+		args = appendString(args, "--comment", b.Comment)
+		args = appendInt(args, "--port", b.Port)
+		args = appendString(args, "--override-host", b.OverrideHost)
+		args = appendInt(args, "--connect-timeout", b.ConnectTimeout)
+		args = appendInt(args, "--first-byte-timeout", b.FirstByteTimeout)
+		args = appendInt(args, "--between-bytes-timeout", b.BetweenBytesTimeout)
+		args = appendInt(args, "--max-conn", b.MaxConn)
+		args = appendInt(args, "--weight", b.Weight)
+		args = appendString(args, "--shield", b.Shield)
+		args = appendString(args, "--healthcheck", b.Healthcheck)
+		args = appendString(args, "--request-condition", b.RequestCondition)
+		args = appendBool(args, "--use-ssl", b.UseSSL)
+		args = appendBool(args, "--ssl-check-cert", b.SSLCheckCert)
+		args = appendString(args, "--ssl-cert-hostname", b.SSLCertHostname)
+		args = appendString(args, "--ssl-sni-hostname", b.SSLSNIHostname)
+		args = appendString(args, "--min-tls-version", b.MinTLSVersion)
+		args = appendString(args, "--max-tls-version", b.MaxTLSVersion)
+		add(args...)
+	}
+
+	for _, a := range e.ACLs {
+		args := append([]string{"acl", "create"}, versioned...)
+		add(append(args, "--name", quote(a.Name))...)
+		if len(a.Entries) == 0 {
+			continue
+		}
+		v := idVariable("ACL_ID", a.Name)
+		lines = append(lines, fmt.Sprintf("# Set %s to the ID of the %s ACL created above.", v, quote(a.Name)))
+		for _, en := range a.Entries {
+			args := append([]string{"acl-entry", "create"}, svc...)
+			args = append(args, "--acl-id", `"$`+v+`"`, "--ip", quote(en.IP))
+			args = appendInt(args, "--subnet", en.Subnet)
+			args = appendBool(args, "--negated", en.Negated)
+			args = appendString(args, "--comment", en.Comment)
+			add(args...)
+		}
+	}
+
+	for _, d := range e.Dictionaries {
+		args := append([]string{"dictionary", "create"}, versioned...)
+		args = append(args, "--name", quote(d.Name))
+		if d.WriteOnly {
+			args = append(args, "--write-only", "true")
+		}
+		add(args...)
+		if len(d.Items) == 0 {
+			continue
+		}
+		v := idVariable("DICTIONARY_ID", d.Name)
+		lines = append(lines, fmt.Sprintf("# Set %s to the ID of the %s dictionary created above.", v, quote(d.Name)))
+		keys := make([]string, 0, len(d.Items))
+		for k := range d.Items {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			args := append([]string{"dictionary-item", "create"}, svc...)
+			add(append(args, "--dictionary-id", `"$`+v+`"`, "--key", quote(k), "--value", quote(d.Items[k]))...)
+		}
+	}
+
+	for _, v := range e.VCLs {
+		args := append([]string{"vcl", "custom", "create"}, versioned...)
+		args = append(args, "--name", quote(v.Name), "--content", quote(v.Content))
+		args = appendBool(args, "--main", v.Main)
+		add(args...)
+	}
+
+	for _, s := range e.Snippets {
+		args := append([]string{"vcl", "snippet", "create"}, versioned...)
+		args = append(args, "--name", quote(s.Name), "--type", quote(s.Type), "--content", quote(s.Content))
+		args = appendBool(args, "--dynamic", s.Dynamic)
+		args = appendInt(args, "--priority", s.Priority)
+		add(args...)
+	}
+
+	if activate {
+		add(append([]string{"service-version", "activate"}, versioned...)...)
+	}
+	return lines
+}
+
+func appendString(args []string, flag, value string) []string {
+	if value == "" {
+		return args
+	}
+	return append(args, flag, quote(value))
+}
+
+func appendInt(args []string, flag string, value int) []string {
+	if value == 0 {
+		return args
+	}
+	return append(args, flag, strconv.Itoa(value))
+}
+
+func appendBool(args []string, flag string, value bool) []string {
+	if !value {
+		return args
+	}
+	return append(args, flag)
+}
+
+// shellSafe matches values that don't need quoting in a POSIX shell.
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// quote returns value quoted for use as a single POSIX shell word.
+func quote(value string) string {
+	if shellSafe.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// idVariable returns the name of the shell variable used to hold the ID of
+// the named resource.
+func idVariable(prefix, name string) string {
+	return prefix + "_" + strings.Trim(nonIdentifier.ReplaceAllString(strings.ToUpper(name), "_"), "_")
+}
@@ -0,0 +1,4 @@
+// Package generate contains commands that produce content derived from the
+// configuration of a Fastly service, such as the sequence of CLI commands
+// required to recreate it.
+package generate
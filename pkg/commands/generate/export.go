@@ -0,0 +1,137 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	toml "github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Export describes the configuration of a service, as read from a YAML, TOML
+// or JSON service export.
+type Export struct {
+	Service      ExportService       `json:"service" toml:"service" yaml:"service"`
+	Domains      []ExportDomain      `json:"domains" toml:"domains" yaml:"domains"`
+	Healthchecks []ExportHealthcheck `json:"healthchecks" toml:"healthchecks" yaml:"healthchecks"`
+	Backends     []ExportBackend     `json:"backends" toml:"backends" yaml:"backends"`
+	ACLs         []ExportACL         `json:"acls" toml:"acls" yaml:"acls"`
+	Dictionaries []ExportDictionary  `json:"dictionaries" toml:"dictionaries" yaml:"dictionaries"`
+	VCLs         []ExportVCL         `json:"vcls" toml:"vcls" yaml:"vcls"`
+	Snippets     []ExportSnippet     `json:"snippets" toml:"snippets" yaml:"snippets"`
+}
+
+// ExportService describes the service itself.
+type ExportService struct {
+	Name    string `json:"name" toml:"name" yaml:"name"`
+	Type    string `json:"type" toml:"type" yaml:"type"`
+	Comment string `json:"comment" toml:"comment" yaml:"comment"`
+}
+
+// ExportDomain describes a domain.
+type ExportDomain struct {
+	Name    string `json:"name" toml:"name" yaml:"name"`
+	Comment string `json:"comment" toml:"comment" yaml:"comment"`
+}
+
+// ExportHealthcheck describes a healthcheck.
+type ExportHealthcheck struct {
+	Name             string `json:"name" toml:"name" yaml:"name"`
+	Comment          string `json:"comment" toml:"comment" yaml:"comment"`
+	Method           string `json:"method" toml:"method" yaml:"method"`
+	Host             string `json:"host" toml:"host" yaml:"host"`
+	Path             string `json:"path" toml:"path" yaml:"path"`
+	HTTPVersion      string `json:"http_version" toml:"http_version" yaml:"http_version"`
+	Timeout          int    `json:"timeout" toml:"timeout" yaml:"timeout"`
+	CheckInterval    int    `json:"check_interval" toml:"check_interval" yaml:"check_interval"`
+	ExpectedResponse int    `json:"expected_response" toml:"expected_response" yaml:"expected_response"`
+	Window           int    `json:"window" toml:"window" yaml:"window"`
+	Threshold        int    `json:"threshold" toml:"threshold" yaml:"threshold"`
+	Initial          int    `json:"initial" toml:"initial" yaml:"initial"`
+}
+
+// ExportBackend describes a backend.
+type ExportBackend struct {
+	Name                string `json:"name" toml:"name" yaml:"name"`
+	Comment             string `json:"comment" toml:"comment" yaml:"comment"`
+	Address             string `json:"address" toml:"address" yaml:"address"`
+	Port                int    `json:"port" toml:"port" yaml:"port"`
+	OverrideHost        string `json:"override_host" toml:"override_host" yaml:"override_host"`
+	ConnectTimeout      int    `json:"connect_timeout" toml:"connect_timeout" yaml:"connect_timeout"`
+	FirstByteTimeout    int    `json:"first_byte_timeout" toml:"first_byte_timeout" yaml:"first_byte_timeout"`
+	BetweenBytesTimeout int    `json:"between_bytes_timeout" toml:"between_bytes_timeout" yaml:"between_bytes_timeout"`
+	MaxConn             int    `json:"max_conn" toml:"max_conn" yaml:"max_conn"`
+	Weight              int    `json:"weight" toml:"weight" yaml:"weight"`
+	Shield              string `json:"shield" toml:"shield" yaml:"shield"`
+	Healthcheck         string `json:"healthcheck" toml:"healthcheck" yaml:"healthcheck"`
+	RequestCondition    string `json:"request_condition" toml:"request_condition" yaml:"request_condition"`
+	UseSSL              bool   `json:"use_ssl" toml:"use_ssl" yaml:"use_ssl"`
+	SSLCheckCert        bool   `json:"ssl_check_cert" toml:"ssl_check_cert" yaml:"ssl_check_cert"`
+	SSLCertHostname     string `json:"ssl_cert_hostname" toml:"ssl_cert_hostname" yaml:"ssl_cert_hostname"`
+	SSLSNIHostname      string `json:"ssl_sni_hostname" toml:"ssl_sni_hostname" yaml:"ssl_sni_hostname"`
+	MinTLSVersion       string `json:"min_tls_version" toml:"min_tls_version" yaml:"min_tls_version"`
+	MaxTLSVersion       string `json:"max_tls_version" toml:"max_tls_version" yaml:"max_tls_version"`
+}
+
+// ExportACL describes an ACL and its entries.
+type ExportACL struct {
+	Name    string           `json:"name" toml:"name" yaml:"name"`
+	Entries []ExportACLEntry `json:"entries" toml:"entries" yaml:"entries"`
+}
+
+// ExportACLEntry describes an ACL entry.
+type ExportACLEntry struct {
+	IP      string `json:"ip" toml:"ip" yaml:"ip"`
+	Subnet  int    `json:"subnet" toml:"subnet" yaml:"subnet"`
+	Negated bool   `json:"negated" toml:"negated" yaml:"negated"`
+	Comment string `json:"comment" toml:"comment" yaml:"comment"`
+}
+
+// ExportDictionary describes a dictionary and its items.
+type ExportDictionary struct {
+	Name      string            `json:"name" toml:"name" yaml:"name"`
+	WriteOnly bool              `json:"write_only" toml:"write_only" yaml:"write_only"`
+	Items     map[string]string `json:"items" toml:"items" yaml:"items"`
+}
+
+// ExportVCL describes a custom VCL file.
+type ExportVCL struct {
+	Name    string `json:"name" toml:"name" yaml:"name"`
+	Main    bool   `json:"main" toml:"main" yaml:"main"`
+	Content string `json:"content" toml:"content" yaml:"content"`
+}
+
+// ExportSnippet describes a VCL snippet.
+type ExportSnippet struct {
+	Name     string `json:"name" toml:"name" yaml:"name"`
+	Type     string `json:"type" toml:"type" yaml:"type"`
+	Dynamic  bool   `json:"dynamic" toml:"dynamic" yaml:"dynamic"`
+	Priority int    `json:"priority" toml:"priority" yaml:"priority"`
+	Content  string `json:"content" toml:"content" yaml:"content"`
+}
+
+// ParseExport decodes a service export. The format is chosen by the file
+// extension of path, defaulting to YAML (which is a superset of JSON).
+func ParseExport(path string, data []byte) (Export, error) {
+	var (
+		e   Export
+		err error
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(data, &e)
+	case ".json":
+		err = json.Unmarshal(data, &e)
+	default:
+		err = yaml.UnmarshalStrict(data, &e)
+	}
+	if err != nil {
+		return e, fmt.Errorf("error parsing service export: %w", err)
+	}
+	if e.Service.Name == "" {
+		return e, fmt.Errorf("error parsing service export: missing service name")
+	}
+	return e, nil
+}
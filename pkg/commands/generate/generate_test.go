@@ -0,0 +1,74 @@
+package generate_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestGenerateCommands(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --from flag",
+			Args:      args("generate commands"),
+			WantError: "error parsing arguments: required flag --from not provided",
+		},
+		{
+			Name:      "validate missing file",
+			Args:      args("generate commands --from testdata/missing.yaml"),
+			WantError: "error reading service export",
+		},
+		{
+			Name:      "validate unknown fields are rejected",
+			Args:      args("generate commands --from testdata/invalid.yaml"),
+			WantError: "error parsing service export",
+		},
+		{
+			Name:       "validate YAML export",
+			Args:       args("generate commands --from testdata/service-export.yaml"),
+			WantOutput: generateYAMLOutput,
+		},
+		{
+			Name:       "validate TOML export with activation",
+			Args:       args("generate commands --from testdata/service-export.toml --activate"),
+			WantOutput: generateTOMLOutput,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+var generateYAMLOutput = strings.TrimSpace(`
+# Commands to recreate service example.
+fastly service create --name example --type vcl --comment 'Example service'
+fastly domain create --service-name example --version latest --name www.example.com
+fastly healthcheck create --service-name example --version latest --name origin-check --host origin.example.com --path /healthz --expected-response 200
+fastly backend create --service-name example --version latest --name origin --address origin.example.com --port 443 --override-host origin.example.com --healthcheck origin-check --use-ssl
+fastly acl create --service-name example --version latest --name blocklist
+# Set ACL_ID_BLOCKLIST to the ID of the blocklist ACL created above.
+fastly acl-entry create --service-name example --acl-id "$ACL_ID_BLOCKLIST" --ip 192.0.2.0 --subnet 24 --comment 'test range'
+fastly dictionary create --service-name example --version latest --name redirects
+# Set DICTIONARY_ID_REDIRECTS to the ID of the redirects dictionary created above.
+fastly dictionary-item create --service-name example --dictionary-id "$DICTIONARY_ID_REDIRECTS" --key /old --value /new
+fastly vcl snippet create --service-name example --version latest --name set-header --type recv --content 'set req.http.X-Example = "1";' --priority 100
+`) + "\n"
+
+var generateTOMLOutput = strings.TrimSpace(`
+# Commands to recreate service example.
+fastly service create --name example
+fastly domain create --service-name example --version latest --name www.example.com
+fastly backend create --service-name example --version latest --name origin --address origin.example.com --port 443
+fastly service-version activate --service-name example --version latest
+`) + "\n"
@@ -0,0 +1,425 @@
+package gzip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Preset is a compression policy that can be applied to a service version.
+type Preset struct {
+	Name         string
+	ContentTypes []string
+	Extensions   []string
+}
+
+// Presets are the compression policies that can be applied with --apply-preset.
+var Presets = map[string]Preset{
+	// recommended mirrors the policy Fastly enables by default for text based
+	// content.
+	"recommended": {
+		Name: "recommended-compression",
+		ContentTypes: []string{
+			"application/javascript",
+			"application/json",
+			"application/vnd.ms-fontobject",
+			"application/x-font-opentype",
+			"application/x-font-truetype",
+			"application/x-font-ttf",
+			"application/x-javascript",
+			"application/xml",
+			"font/eot",
+			"font/opentype",
+			"font/otf",
+			"image/svg+xml",
+			"image/vnd.microsoft.icon",
+			"text/css",
+			"text/html",
+			"text/javascript",
+			"text/plain",
+			"text/xml",
+		},
+		Extensions: []string{"css", "eot", "html", "ico", "js", "json", "otf", "svg", "ttf"},
+	},
+}
+
+// precompressed are the content types and extensions of formats that are
+// already compressed, so compressing them again only costs CPU.
+var (
+	precompressedTypes = regexp.MustCompile(`^(audio/|video/|image/(png|jpeg|gif|webp|avif)$|font/woff2?$|application/(font-woff2?|zip|gzip|x-gzip|x-brotli|x-bzip2|x-7z-compressed)$)`)
+	precompressedExts  = regexp.MustCompile(`^(png|jpe?g|gif|webp|avif|mp3|mp4|webm|ogg|zip|gz|tgz|br|bz2|7z|woff2?)$`)
+)
+
+// brotliEnabled matches VCL that enables Brotli compression.
+var brotliEnabled = regexp.MustCompile(`beresp\.brotli\s*=\s*true`)
+
+// NewAuditCommand returns a usable command registered under the parent.
+func NewAuditCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *AuditCommand {
+	var c AuditCommand
+	c.CmdClause = parent.Command("audit", "Report which content types a service version compresses and flag conflicting configuration")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	presets := make([]string, 0, len(Presets))
+	for name := range Presets {
+		presets = append(presets, name)
+	}
+	sort.Strings(presets)
+	c.CmdClause.Flag("apply-preset", "Create or update a gzip configuration with the given compression policy").HintOptions(presets...).EnumVar(&c.preset, presets...)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// AuditCommand calls the Fastly API to audit the compression configuration of
+// a service version.
+type AuditCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	json           bool
+	manifest       manifest.Data
+	preset         string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *AuditCommand) Exec(in io.Reader, out io.Writer) error {
+	opts := cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  c.preset == "",
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	}
+	// An audit on its own doesn't modify the service version, so only clone
+	// it when a preset is going to be applied.
+	if c.preset != "" {
+		opts.AutoCloneFlag = c.autoClone
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(opts)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	report, err := c.audit(serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	if c.json {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+	} else {
+		c.print(out, serviceID, serviceVersion.Number, report)
+	}
+
+	if c.preset == "" {
+		return nil
+	}
+	return c.apply(out, serviceID, serviceVersion.Number, report.Policies)
+}
+
+// audit fetches the configuration of the service version and audits it.
+func (c *AuditCommand) audit(serviceID string, serviceVersion int) (Report, error) {
+	snap := snapshot.New(c.Globals.APIClient, serviceID, serviceVersion)
+	if err := snap.Fetch("gzip", "cache_setting", "snippet", "vcl"); err != nil {
+		return Report{}, err
+	}
+
+	gzips, err := snap.Gzips()
+	if err != nil {
+		return Report{}, err
+	}
+	cacheSettings, err := snap.CacheSettings()
+	if err != nil {
+		return Report{}, err
+	}
+
+	vcl := make(map[string]string)
+	snippets, err := snap.Snippets()
+	if err != nil {
+		return Report{}, err
+	}
+	for _, s := range snippets {
+		vcl[fmt.Sprintf("VCL snippet '%s'", s.Name)] = s.Content
+	}
+	vcls, err := snap.VCLs()
+	if err != nil {
+		return Report{}, err
+	}
+	for _, v := range vcls {
+		vcl[fmt.Sprintf("custom VCL '%s'", v.Name)] = v.Content
+	}
+
+	return Audit(gzips, cacheSettings, vcl), nil
+}
+
+// print displays the report in a human readable format.
+func (c *AuditCommand) print(out io.Writer, serviceID string, serviceVersion int, r Report) {
+	text.Output(out, "Compression audit of service %s version %d", serviceID, serviceVersion)
+	text.Break(out)
+
+	if len(r.Policies) > 0 {
+		tw := text.NewTable(out)
+		tw.AddHeader("NAME", "CONTENT TYPES", "EXTENSIONS", "CACHE CONDITION")
+		for _, g := range r.Policies {
+			tw.AddLine(g.Name, g.ContentTypes, g.Extensions, g.CacheCondition)
+		}
+		tw.Print()
+		text.Break(out)
+	}
+
+	tw := text.NewTable(out)
+	tw.AddHeader("CONTENT TYPE", "COMPRESSED BY")
+	for _, cv := range r.ContentTypes {
+		tw.AddLine(cv.Value, compressedBy(cv))
+	}
+	tw.Print()
+	text.Break(out)
+
+	tw = text.NewTable(out)
+	tw.AddHeader("EXTENSION", "COMPRESSED BY")
+	for _, cv := range r.Extensions {
+		tw.AddLine(cv.Value, compressedBy(cv))
+	}
+	tw.Print()
+	text.Break(out)
+
+	if len(r.Brotli) > 0 {
+		text.Output(out, "Brotli: enabled by %s", strings.Join(r.Brotli, ", "))
+	} else {
+		text.Output(out, "Brotli: not enabled (set beresp.brotli in vcl_fetch to enable it)")
+	}
+
+	if len(r.Issues) == 0 {
+		text.Success(out, "No issues found")
+		return
+	}
+	text.Warning(out, "Found %d potential issues:", len(r.Issues))
+	for _, i := range r.Issues {
+		fmt.Fprintf(out, "- %s\n", i)
+	}
+}
+
+// apply creates or updates the gzip configuration of the selected preset.
+func (c *AuditCommand) apply(out io.Writer, serviceID string, serviceVersion int, gzips []*fastly.Gzip) error {
+	p := Presets[c.preset]
+	contentTypes := strings.Join(p.ContentTypes, " ")
+	extensions := strings.Join(p.Extensions, " ")
+
+	var (
+		exists bool
+		err    error
+	)
+	for _, g := range gzips {
+		if g.Name == p.Name {
+			exists = true
+		}
+	}
+	if exists {
+		_, err = c.Globals.APIClient.UpdateGzip(&fastly.UpdateGzipInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           p.Name,
+			ContentTypes:   fastly.String(contentTypes),
+			Extensions:     fastly.String(extensions),
+		})
+	} else {
+		_, err = c.Globals.APIClient.CreateGzip(&fastly.CreateGzipInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           p.Name,
+			ContentTypes:   contentTypes,
+			Extensions:     extensions,
+		})
+	}
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+			"Preset":          c.preset,
+		})
+		return err
+	}
+
+	action := "Created"
+	if exists {
+		action = "Updated"
+	}
+	text.Success(out, "%s gzip configuration '%s' with the %s compression policy (service: %s, version: %d)", action, p.Name, c.preset, serviceID, serviceVersion)
+	return nil
+}
+
+func compressedBy(cv Coverage) string {
+	if len(cv.CompressedBy) == 0 {
+		return "-"
+	}
+	return strings.Join(cv.CompressedBy, ", ")
+}
+
+// Report describes the compression configuration of a service version.
+type Report struct {
+	Policies     []*fastly.Gzip `json:"policies"`
+	ContentTypes []Coverage     `json:"content_types"`
+	Extensions   []Coverage     `json:"extensions"`
+	Brotli       []string       `json:"brotli"`
+	Issues       []string       `json:"issues"`
+}
+
+// Coverage lists the gzip configurations that compress a content type or
+// file extension.
+type Coverage struct {
+	Value        string   `json:"value"`
+	CompressedBy []string `json:"compressed_by"`
+}
+
+// Audit reports which content types and extensions the gzip configurations
+// compress, compared with the recommended policy, and flags configuration
+// that is ineffective or conflicts with the cache settings. vcl maps a
+// description of each VCL source (snippet or custom VCL) to its content.
+func Audit(gzips []*fastly.Gzip, cacheSettings []*fastly.CacheSetting, vcl map[string]string) Report {
+	r := Report{Policies: gzips}
+	recommended := Presets["recommended"]
+
+	types := coverage(recommended.ContentTypes)
+	exts := coverage(recommended.Extensions)
+	for _, g := range gzips {
+		gTypes := strings.Fields(strings.ToLower(g.ContentTypes))
+		gExts := strings.Fields(strings.ToLower(strings.ReplaceAll(g.Extensions, ".", "")))
+
+		if len(gTypes) == 0 && len(gExts) == 0 {
+			r.Issues = append(r.Issues, fmt.Sprintf("gzip configuration '%s' has no content types or extensions, so it never compresses anything", g.Name))
+		}
+		for _, t := range gTypes {
+			types[t] = append(types[t], g.Name)
+			if precompressedTypes.MatchString(t) {
+				r.Issues = append(r.Issues, fmt.Sprintf("gzip configuration '%s' compresses %s, which is already compressed (this costs CPU and can make responses larger)", g.Name, t))
+			}
+		}
+		for _, e := range gExts {
+			exts[e] = append(exts[e], g.Name)
+			if precompressedExts.MatchString(e) {
+				r.Issues = append(r.Issues, fmt.Sprintf("gzip configuration '%s' compresses .%s files, which are already compressed (this costs CPU and can make responses larger)", g.Name, e))
+			}
+		}
+
+		for _, s := range cacheSettings {
+			if s.CacheCondition != "" && s.CacheCondition != g.CacheCondition {
+				continue
+			}
+			scope := fmt.Sprintf("responses matching cache condition '%s'", s.CacheCondition)
+			if s.CacheCondition == "" {
+				scope = "all responses"
+			}
+			switch s.Action {
+			case fastly.CacheSettingActionPass:
+				r.Issues = append(r.Issues, fmt.Sprintf("cache setting '%s' passes %s, so gzip configuration '%s' compresses them on every request instead of once when they're cached", s.Name, scope, g.Name))
+			case fastly.CacheSettingActionRestart:
+				r.Issues = append(r.Issues, fmt.Sprintf("cache setting '%s' restarts %s, so gzip configuration '%s' never applies to them", s.Name, scope, g.Name))
+			}
+		}
+	}
+
+	r.ContentTypes = coverageList(types)
+	r.Extensions = coverageList(exts)
+
+	var missing []string
+	for _, t := range recommended.ContentTypes {
+		if len(types[t]) == 0 {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		r.Issues = append(r.Issues, fmt.Sprintf("%d recommended content types aren't compressed: %s", len(missing), strings.Join(missing, ", ")))
+	}
+	for _, cv := range r.ContentTypes {
+		if len(cv.CompressedBy) > 1 {
+			r.Issues = append(r.Issues, fmt.Sprintf("%s is compressed by more than one gzip configuration (%s)", cv.Value, strings.Join(cv.CompressedBy, ", ")))
+		}
+	}
+
+	for source, content := range vcl {
+		if brotliEnabled.MatchString(content) {
+			r.Brotli = append(r.Brotli, source)
+		}
+	}
+	sort.Strings(r.Brotli)
+
+	return r
+}
+
+func coverage(values []string) map[string][]string {
+	m := make(map[string][]string, len(values))
+	for _, v := range values {
+		m[v] = nil
+	}
+	return m
+}
+
+func coverageList(m map[string][]string) []Coverage {
+	l := make([]Coverage, 0, len(m))
+	for v, by := range m {
+		l = append(l, Coverage{Value: v, CompressedBy: by})
+	}
+	sort.Slice(l, func(i, j int) bool {
+		return l[i].Value < l[j].Value
+	})
+	return l
+}
@@ -0,0 +1,3 @@
+// Package gzip contains commands for auditing the compression configuration
+// of a Fastly service version.
+package gzip
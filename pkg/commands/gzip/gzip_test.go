@@ -0,0 +1,201 @@
+package gzip_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/gzip"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestGzipAudit(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn:      testutil.ListVersions,
+		ListGzipsFn:         listGzips,
+		ListCacheSettingsFn: listCacheSettings,
+		ListSnippetsFn:      listSnippets,
+		ListVCLsFn:          listVCLs,
+	}
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("gzip audit --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate ListGzips API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListGzipsFn: func(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+					return nil, testutil.Err
+				},
+				// The configuration is fetched concurrently, so the other
+				// resources are still listed.
+				ListCacheSettingsFn: listCacheSettings,
+				ListSnippetsFn:      listSnippets,
+				ListVCLsFn:          listVCLs,
+			},
+			Args:      args("gzip audit --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate audit of an active version",
+			API:  api,
+			Args: args("gzip audit --service-id 123 --version 1"),
+			WantOutputs: []string{
+				"text/css                       default",
+				"text/plain                     -",
+				"image/png                      images",
+				"Brotli: enabled by VCL snippet 'compression'",
+				"Found 3 potential issues:",
+				"- gzip configuration 'images' compresses image/png, which is already compressed",
+				"- cache setting 'no-store' passes responses matching cache condition 'api', so gzip configuration 'images' compresses them on every request",
+			},
+		},
+		{
+			Name:      "validate --apply-preset requires an editable version",
+			API:       api,
+			Args:      args("gzip audit --service-id 123 --version 1 --apply-preset recommended"),
+			WantError: "service version 1 is not editable",
+		},
+		{
+			Name: "validate --apply-preset creates the gzip configuration in a clone",
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				ListGzipsFn:         listGzips,
+				ListCacheSettingsFn: listCacheSettings,
+				ListSnippetsFn:      listSnippets,
+				ListVCLsFn:          listVCLs,
+				CreateGzipFn: func(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+					if i.ServiceVersion != 4 || !strings.Contains(i.ContentTypes, "text/plain") {
+						return nil, testutil.Err
+					}
+					return &fastly.Gzip{Name: i.Name}, nil
+				},
+			},
+			Args:       args("gzip audit --service-id 123 --version 1 --apply-preset recommended --autoclone"),
+			WantOutput: "Created gzip configuration 'recommended-compression' with the recommended compression policy (service: 123, version: 4)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, s := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+func TestAudit(t *testing.T) {
+	recommended := gzip.Presets["recommended"]
+	for _, testcase := range []struct {
+		name          string
+		gzips         []*fastly.Gzip
+		cacheSettings []*fastly.CacheSetting
+		vcl           map[string]string
+		wantIssues    []string
+		wantBrotli    []string
+	}{
+		{
+			name: "recommended policy",
+			gzips: []*fastly.Gzip{
+				{Name: "recommended-compression", ContentTypes: strings.Join(recommended.ContentTypes, " "), Extensions: strings.Join(recommended.Extensions, " ")},
+			},
+		},
+		{
+			name:  "empty policy",
+			gzips: []*fastly.Gzip{{Name: "empty"}},
+			wantIssues: []string{
+				"gzip configuration 'empty' has no content types or extensions, so it never compresses anything",
+				"18 recommended content types aren't compressed: " + strings.Join(recommended.ContentTypes, ", "),
+			},
+		},
+		{
+			name: "duplicates, precompressed formats and restarts",
+			gzips: []*fastly.Gzip{
+				{Name: "a", ContentTypes: strings.Join(recommended.ContentTypes, " "), Extensions: ".woff2"},
+				{Name: "b", ContentTypes: "text/html", CacheCondition: "html"},
+			},
+			cacheSettings: []*fastly.CacheSetting{
+				{Name: "retry", Action: fastly.CacheSettingActionRestart},
+				{Name: "cache", Action: fastly.CacheSettingActionCache, CacheCondition: "html"},
+			},
+			vcl: map[string]string{
+				"custom VCL 'main'": "sub vcl_fetch {\n  set beresp.brotli = true;\n}",
+				"VCL snippet 'off'": "set beresp.brotli = false;",
+			},
+			wantIssues: []string{
+				"gzip configuration 'a' compresses .woff2 files, which are already compressed (this costs CPU and can make responses larger)",
+				"cache setting 'retry' restarts all responses, so gzip configuration 'a' never applies to them",
+				"cache setting 'retry' restarts all responses, so gzip configuration 'b' never applies to them",
+				"text/html is compressed by more than one gzip configuration (a, b)",
+			},
+			wantBrotli: []string{"custom VCL 'main'"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := gzip.Audit(testcase.gzips, testcase.cacheSettings, testcase.vcl)
+			testutil.AssertEqual(t, testcase.wantIssues, r.Issues)
+			testutil.AssertEqual(t, testcase.wantBrotli, r.Brotli)
+		})
+	}
+}
+
+func listGzips(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	return []*fastly.Gzip{
+		{
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Name:           "default",
+			ContentTypes:   "text/html text/css application/javascript",
+			Extensions:     "css js html",
+		},
+		{
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Name:           "images",
+			ContentTypes:   "image/png image/svg+xml",
+			CacheCondition: "api",
+		},
+	}, nil
+}
+
+func listCacheSettings(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	return []*fastly.CacheSetting{
+		{
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Name:           "no-store",
+			Action:         fastly.CacheSettingActionPass,
+			CacheCondition: "api",
+		},
+	}, nil
+}
+
+func listSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	return []*fastly.Snippet{
+		{
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Name:           "compression",
+			Content:        "if (beresp.http.Content-Type ~ \"^text/\") {\n  set beresp.brotli = true;\n}",
+		},
+	}, nil
+}
+
+func listVCLs(i *fastly.ListVCLsInput) ([]*fastly.VCL, error) {
+	return []*fastly.VCL{}, nil
+}
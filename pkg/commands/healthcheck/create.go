@@ -58,12 +58,12 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("name", "Healthcheck name").Short('n').Required().StringVar(&c.input.Name)
 	c.CmdClause.Flag("comment", "A descriptive note").StringVar(&c.input.Comment)
 	c.CmdClause.Flag("method", "Which HTTP method to use").StringVar(&c.input.Method)
-	c.CmdClause.Flag("host", "Which host to check").StringVar(&c.input.Host)
+	c.CmdClause.Flag("host", "Which host to check").Action(cmd.Validate(cmd.ValidateHostname)).StringVar(&c.input.Host)
 	c.CmdClause.Flag("path", "The path to check").StringVar(&c.input.Path)
 	c.CmdClause.Flag("http-version", "Whether to use version 1.0 or 1.1 HTTP").StringVar(&c.input.HTTPVersion)
 	c.CmdClause.Flag("timeout", "Timeout in milliseconds").Action(c.timeout.Set).UintVar(&c.timeout.Value)
 	c.CmdClause.Flag("check-interval", "How often to run the healthcheck in milliseconds").Action(c.checkInterval.Set).UintVar(&c.checkInterval.Value)
-	c.CmdClause.Flag("expected-response", "The status code expected from the host").Action(c.expectedResponse.Set).UintVar(&c.expectedResponse.Value)
+	c.CmdClause.Flag("expected-response", "The status code expected from the host").Action(c.expectedResponse.Set).Action(cmd.Validate(cmd.ValidateRange(100, 599))).UintVar(&c.expectedResponse.Value)
 	c.CmdClause.Flag("window", "The number of most recent healthcheck queries to keep for this healthcheck").Action(c.window.Set).UintVar(&c.window.Value)
 	c.CmdClause.Flag("threshold", "How many healthchecks must succeed to be considered healthy").Action(c.threshold.Set).UintVar(&c.threshold.Value)
 	c.CmdClause.Flag("initial", "When loading a config, the initial number of probes to be seen as OK").Action(c.initial.Set).UintVar(&c.initial.Value)
@@ -75,6 +75,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -0,0 +1,3 @@
+// Package limits contains commands to check a Fastly service against its
+// resource limits.
+package limits
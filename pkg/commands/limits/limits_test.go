@@ -0,0 +1,135 @@
+package limits_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/limits"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestLimitsStatus(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn:               testutil.ListVersions,
+		ListACLsFn:                   listACLsOK,
+		NewListACLEntriesPaginatorFn: newACLEntriesPaginator(850),
+		ListDictionariesFn:           listDictionariesOK,
+		GetDictionaryInfoFn:          getDictionaryInfoOK,
+		ListSnippetsFn:               listSnippetsOK,
+		ListConditionsFn:             listConditionsOK,
+		GetPackageFn:                 getPackageNotFound,
+	}
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate invalid --limit",
+			Args:      args("limits status --service-id 123 --limit widgets=10"),
+			WantError: "invalid --limit 'widgets=10'",
+		},
+		{
+			Name: "validate usage is reported",
+			Args: args("limits status --service-id 123"),
+			API:  api,
+			WantOutputs: []string{
+				"acl-entries       blocklist  850    1000   85%",
+				"dictionary-items  settings   12     1000   1%",
+				"snippets                     2      1000   0%",
+				"acl-entries (blocklist) is at 85% of the limit.",
+			},
+		},
+		{
+			Name:      "validate --strict",
+			Args:      args("limits status --service-id 123 --strict"),
+			API:       api,
+			WantError: "1 resources are at or above 80% of their limit",
+		},
+		{
+			Name:       "validate --limit overrides the default",
+			Args:       args("limits status --service-id 123 --strict --limit acl-entries=5000"),
+			API:        api,
+			WantOutput: "acl-entries       blocklist  850    5000   17%",
+		},
+		{
+			Name:       "validate JSON output",
+			Args:       args("limits status --service-id 123 --json"),
+			API:        api,
+			WantOutput: `{"resource":"acl-entries","name":"blocklist","count":850,"limit":1000}`,
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	l, err := limits.ParseLimits([]string{"snippets=2000"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, int64(2000), l[limits.ResourceSnippets])
+	testutil.AssertEqual(t, limits.DefaultLimits[limits.ResourceACLs], l[limits.ResourceACLs])
+
+	_, err = limits.ParseLimits([]string{"snippets=0"})
+	testutil.AssertErrorContains(t, err, "invalid --limit 'snippets=0'")
+}
+
+func listACLsOK(i *fastly.ListACLsInput) ([]*fastly.ACL, error) {
+	return []*fastly.ACL{{ID: "456", Name: "blocklist"}}, nil
+}
+
+// aclEntriesPaginator returns its entries in a single page.
+type aclEntriesPaginator struct {
+	entries []*fastly.ACLEntry
+	done    bool
+}
+
+func (p *aclEntriesPaginator) HasNext() bool {
+	return !p.done
+}
+
+func (p *aclEntriesPaginator) Remaining() int {
+	return 0
+}
+
+func (p *aclEntriesPaginator) GetNext() ([]*fastly.ACLEntry, error) {
+	p.done = true
+	return p.entries, nil
+}
+
+func newACLEntriesPaginator(n int) func(*fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
+	return func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
+		return &aclEntriesPaginator{entries: make([]*fastly.ACLEntry, n)}
+	}
+}
+
+func listDictionariesOK(i *fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+	return []*fastly.Dictionary{{ID: "789", Name: "settings"}}, nil
+}
+
+func getDictionaryInfoOK(i *fastly.GetDictionaryInfoInput) (*fastly.DictionaryInfo, error) {
+	return &fastly.DictionaryInfo{ItemCount: 12}, nil
+}
+
+func listSnippetsOK(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	return []*fastly.Snippet{{Name: "recv"}, {Name: "fetch"}}, nil
+}
+
+func listConditionsOK(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	return []*fastly.Condition{{Name: "is-api"}}, nil
+}
+
+func getPackageNotFound(i *fastly.GetPackageInput) (*fastly.Package, error) {
+	return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+}
@@ -0,0 +1,317 @@
+package limits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// The resources whose usage is checked.
+const (
+	ResourceACLs            = "acls"
+	ResourceACLEntries      = "acl-entries"
+	ResourceConditions      = "conditions"
+	ResourceDictionaries    = "dictionaries"
+	ResourceDictionaryItems = "dictionary-items"
+	ResourcePackageSize     = "package-size"
+	ResourceSnippets        = "snippets"
+)
+
+// DefaultLimits are the default limits of a Fastly account. ACL entries and
+// dictionary items are limited per container, package size is in bytes, and
+// the other resources are limited per service version.
+//
+// NOTE: Fastly support can raise the limits of an account, so they can be
+// overridden with the --limit flag.
+var DefaultLimits = map[string]int64{
+	ResourceACLs:            1000,
+	ResourceACLEntries:      1000,
+	ResourceConditions:      1000,
+	ResourceDictionaries:    1000,
+	ResourceDictionaryItems: 1000,
+	ResourcePackageSize:     compute.PackageSizeLimit,
+	ResourceSnippets:        1000,
+}
+
+// Usage is the number of a resource used against its limit.
+type Usage struct {
+	Resource string `json:"resource"`
+	// Name is the ACL or dictionary that the usage is for, if it's limited per
+	// container.
+	Name  string `json:"name,omitempty"`
+	Count int64  `json:"count"`
+	Limit int64  `json:"limit"`
+}
+
+// Percent returns the percentage of the limit that's used.
+func (u Usage) Percent() int64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return u.Count * 100 / u.Limit
+}
+
+// StatusCommand reports a service version's usage of its resource limits.
+type StatusCommand struct {
+	cmd.Base
+
+	json           bool
+	limits         []string
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	strict         bool
+	warnAt         int64
+}
+
+// NewStatusCommand returns a usable command registered under the parent.
+func NewStatusCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *StatusCommand {
+	var c StatusCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("status", "Report a service version's usage of resources against Fastly's limits")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("limit", "Override a limit raised for your account, in the form 'resource=limit' (repeat for multiple limits)").StringsVar(&c.limits)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("strict", "Exit with an error if any resource is approaching its limit").BoolVar(&c.strict)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'latest')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	c.CmdClause.Flag("warn-at", "Percentage of a limit at which to warn").Default("80").Int64Var(&c.warnAt)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *StatusCommand) Exec(in io.Reader, out io.Writer) error {
+	limits, err := ParseLimits(c.limits)
+	if err != nil {
+		return err
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	usages, err := c.usages(serviceID, serviceVersion.Number, limits)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	var warnings []Usage
+	for _, u := range usages {
+		if u.Percent() >= c.warnAt {
+			warnings = append(warnings, u)
+		}
+	}
+
+	if c.json {
+		data, err := json.Marshal(usages)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+	} else {
+		t := text.NewTable(out)
+		t.AddHeader("RESOURCE", "NAME", "COUNT", "LIMIT", "USED")
+		for _, u := range usages {
+			t.AddLine(u.Resource, u.Name, u.Count, u.Limit, fmt.Sprintf("%d%%", u.Percent()))
+		}
+		t.Print()
+		for _, u := range warnings {
+			text.Break(out)
+			text.Warning(out, "%s is at %d%% of the limit.", describe(u), u.Percent())
+		}
+	}
+
+	if c.strict && len(warnings) > 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("%d resources are at or above %d%% of their limit", len(warnings), c.warnAt),
+			Remediation: "Remove unused resources, or ask Fastly support to raise the limits and set them with --limit.",
+		}
+	}
+	return nil
+}
+
+// usages counts the resources of the service version.
+func (c *StatusCommand) usages(serviceID string, version int, limits map[string]int64) ([]Usage, error) {
+	var usages []Usage
+
+	acls, err := c.Globals.APIClient.ListACLs(&fastly.ListACLsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ACLs: %w", err)
+	}
+	usages = append(usages, Usage{Resource: ResourceACLs, Count: int64(len(acls)), Limit: limits[ResourceACLs]})
+	for _, a := range acls {
+		n, err := c.countACLEntries(serviceID, a.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing entries of ACL '%s': %w", a.Name, err)
+		}
+		usages = append(usages, Usage{Resource: ResourceACLEntries, Name: a.Name, Count: n, Limit: limits[ResourceACLEntries]})
+	}
+
+	dicts, err := c.Globals.APIClient.ListDictionaries(&fastly.ListDictionariesInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing dictionaries: %w", err)
+	}
+	usages = append(usages, Usage{Resource: ResourceDictionaries, Count: int64(len(dicts)), Limit: limits[ResourceDictionaries]})
+	for _, d := range dicts {
+		info, err := c.Globals.APIClient.GetDictionaryInfo(&fastly.GetDictionaryInfoInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			ID:             d.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting dictionary '%s': %w", d.Name, err)
+		}
+		usages = append(usages, Usage{Resource: ResourceDictionaryItems, Name: d.Name, Count: int64(info.ItemCount), Limit: limits[ResourceDictionaryItems]})
+	}
+
+	snippets, err := c.Globals.APIClient.ListSnippets(&fastly.ListSnippetsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snippets: %w", err)
+	}
+	usages = append(usages, Usage{Resource: ResourceSnippets, Count: int64(len(snippets)), Limit: limits[ResourceSnippets]})
+
+	conditions, err := c.Globals.APIClient.ListConditions(&fastly.ListConditionsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing conditions: %w", err)
+	}
+	usages = append(usages, Usage{Resource: ResourceConditions, Count: int64(len(conditions)), Limit: limits[ResourceConditions]})
+
+	// Only Compute@Edge services have a package.
+	pkg, err := c.Globals.APIClient.GetPackage(&fastly.GetPackageInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	var httpErr *fastly.HTTPError
+	switch {
+	case errors.As(err, &httpErr) && httpErr.IsNotFound():
+	case err != nil:
+		return nil, fmt.Errorf("error getting package: %w", err)
+	default:
+		usages = append(usages, Usage{Resource: ResourcePackageSize, Count: pkg.Metadata.Size, Limit: limits[ResourcePackageSize]})
+	}
+
+	return usages, nil
+}
+
+func (c *StatusCommand) countACLEntries(serviceID, aclID string) (int64, error) {
+	paginator := c.Globals.APIClient.NewListACLEntriesPaginator(&fastly.ListACLEntriesInput{
+		ServiceID: serviceID,
+		ACLID:     aclID,
+		PerPage:   100,
+	})
+	var n int64
+	for paginator.HasNext() {
+		data, err := paginator.GetNext()
+		if err != nil {
+			return 0, err
+		}
+		n += int64(len(data))
+	}
+	return n, nil
+}
+
+// ParseLimits returns the default limits with the overrides applied. Each
+// override is in the form 'resource=limit'.
+func ParseLimits(overrides []string) (map[string]int64, error) {
+	limits := make(map[string]int64, len(DefaultLimits))
+	for k, v := range DefaultLimits {
+		limits[k] = v
+	}
+	for _, o := range overrides {
+		k, v, ok := strings.Cut(o, "=")
+		k = strings.TrimSpace(k)
+		if _, known := DefaultLimits[k]; !ok || !known {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid --limit '%s'", o),
+				Remediation: fmt.Sprintf("Limits must be in the form 'resource=limit', where resource is one of: %s.", strings.Join(resources(), ", ")),
+			}
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid --limit '%s'", o),
+				Remediation: "The limit must be a whole number greater than zero.",
+			}
+		}
+		limits[k] = n
+	}
+	return limits, nil
+}
+
+func resources() []string {
+	rs := make([]string, 0, len(DefaultLimits))
+	for r := range DefaultLimits {
+		rs = append(rs, r)
+	}
+	sort.Strings(rs)
+	return rs
+}
+
+func describe(u Usage) string {
+	if u.Name != "" {
+		return fmt.Sprintf("%s (%s)", u.Resource, u.Name)
+	}
+	return u.Resource
+}
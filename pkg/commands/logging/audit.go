@@ -0,0 +1,531 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/report"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewAuditCommand returns a usable command registered under the parent.
+func NewAuditCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *AuditCommand {
+	var c AuditCommand
+	c.CmdClause = parent.Command("audit", "Audit the log formats of every logging endpoint of a service version")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("pii", "Flag formats that log sensitive fields (cookies, authorization headers, query strings and request bodies) and suggest scrubbed alternatives").BoolVar(&c.pii)
+	c.CmdClause.Flag("report", "Write the audit findings as a report (junit, sarif)").HintOptions(report.Formats...).EnumVar(&c.report, report.Formats...)
+	c.CmdClause.Flag("report-file", "Path to write the --report output to (default: stdout)").StringVar(&c.reportFile)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// AuditCommand calls the Fastly API to audit the log formats of a service
+// version.
+type AuditCommand struct {
+	cmd.Base
+
+	json           bool
+	manifest       manifest.Data
+	pii            bool
+	report         string
+	reportFile     string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *AuditCommand) Exec(in io.Reader, out io.Writer) error {
+	if !c.pii {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no audit was selected"),
+			Remediation: "Provide the --pii flag to audit the log formats for sensitive fields.",
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	endpoints, err := ListEndpoints(c.Globals.APIClient, serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	audits := make([]EndpointAudit, 0, len(endpoints))
+	for _, e := range endpoints {
+		findings, scrubbed := AuditPII(e.Format)
+		if len(findings) == 0 {
+			continue
+		}
+		audits = append(audits, EndpointAudit{
+			Endpoint:       e,
+			Findings:       findings,
+			ScrubbedFormat: scrubbed,
+		})
+	}
+
+	if c.report != "" {
+		return c.writeReport(out, endpoints, audits)
+	}
+
+	if c.json {
+		data, err := json.Marshal(audits)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	text.Output(out, "PII audit of the log formats of %d logging endpoints (service: %s, version: %d)", len(endpoints), serviceID, serviceVersion.Number)
+	text.Break(out)
+	if len(audits) == 0 {
+		text.Success(out, "No sensitive fields are logged")
+		return nil
+	}
+
+	tw := text.NewTable(out)
+	tw.AddHeader("TYPE", "NAME", "FIELD", "RISK", "SUGGESTION")
+	for _, a := range audits {
+		for _, f := range a.Findings {
+			tw.AddLine(a.Type, a.Name, f.Field, f.Risk, f.Suggestion)
+		}
+	}
+	tw.Print()
+	for _, a := range audits {
+		text.Break(out)
+		text.Output(out, "Scrubbed format for %s endpoint '%s' (set it with `fastly logging %s update --name %s --format`):", a.Type, a.Name, a.Type, a.Name)
+		fmt.Fprintln(out, a.ScrubbedFormat)
+	}
+	text.Break(out)
+	text.Warning(out, "Found sensitive fields in the log formats of %d logging endpoints.", len(audits))
+	return nil
+}
+
+// writeReport renders the audit of every endpoint as a report, either to the
+// --report-file or, if not set, to stdout. It returns an error if any endpoint
+// logs sensitive fields.
+func (c *AuditCommand) writeReport(out io.Writer, endpoints []Endpoint, audits []EndpointAudit) error {
+	failed := make(map[Endpoint]EndpointAudit, len(audits))
+	for _, a := range audits {
+		failed[a.Endpoint] = a
+	}
+
+	r := report.Report{Command: "logging audit"}
+	for _, e := range endpoints {
+		f := report.Finding{
+			Rule:     "logging-pii",
+			Name:     fmt.Sprintf("%s endpoint '%s' log format", e.Type, e.Name),
+			Location: fmt.Sprintf("%s/%s", e.Type, e.Name),
+		}
+		if a, ok := failed[e]; ok {
+			fields := make([]string, 0, len(a.Findings))
+			for _, finding := range a.Findings {
+				fields = append(fields, fmt.Sprintf("%s (%s)", finding.Field, finding.Risk))
+			}
+			f.Failed = true
+			f.Message = fmt.Sprintf("logs sensitive fields: %s; scrubbed format: %s", strings.Join(fields, ", "), a.ScrubbedFormat)
+		}
+		r.Findings = append(r.Findings, f)
+	}
+
+	if c.reportFile == "" {
+		if err := report.Write(out, c.report, r); err != nil {
+			return err
+		}
+	} else {
+		if err := report.WriteFile(c.reportFile, c.report, r); err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Report File": c.reportFile,
+			})
+			return err
+		}
+		text.Info(out, "Wrote %s report to %s", c.report, c.reportFile)
+	}
+
+	if n := r.Failures(); n > 0 {
+		return fmt.Errorf("found sensitive fields in the log formats of %d logging endpoints", n)
+	}
+	return nil
+}
+
+// Endpoint is the log format of a logging endpoint.
+type Endpoint struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Format string `json:"format"`
+}
+
+// EndpointAudit is the audit of the log format of a logging endpoint.
+type EndpointAudit struct {
+	Endpoint
+	Findings       []Finding `json:"findings"`
+	ScrubbedFormat string    `json:"scrubbed_format"`
+}
+
+// Finding is a sensitive field logged by a log format, with the expression to
+// log in its place.
+type Finding struct {
+	Field      string `json:"field"`
+	Risk       string `json:"risk"`
+	Suggestion string `json:"suggestion"`
+}
+
+// endpointLister lists the endpoints of one type of logging endpoint.
+type endpointLister func(client api.Interface, serviceID string, version int) ([]Endpoint, error)
+
+// endpointListers list each type of logging endpoint, in the order of their
+// commands.
+var endpointListers = []endpointLister{
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListBlobStorages(&fastly.ListBlobStoragesInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("azureblob", ls, err, func(l *fastly.BlobStorage) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListBigQueries(&fastly.ListBigQueriesInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("bigquery", ls, err, func(l *fastly.BigQuery) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListCloudfiles(&fastly.ListCloudfilesInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("cloudfiles", ls, err, func(l *fastly.Cloudfiles) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListDatadog(&fastly.ListDatadogInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("datadog", ls, err, func(l *fastly.Datadog) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListDigitalOceans(&fastly.ListDigitalOceansInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("digitalocean", ls, err, func(l *fastly.DigitalOcean) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListElasticsearch(&fastly.ListElasticsearchInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("elasticsearch", ls, err, func(l *fastly.Elasticsearch) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListFTPs(&fastly.ListFTPsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("ftp", ls, err, func(l *fastly.FTP) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListGCSs(&fastly.ListGCSsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("gcs", ls, err, func(l *fastly.GCS) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListPubsubs(&fastly.ListPubsubsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("googlepubsub", ls, err, func(l *fastly.Pubsub) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListHerokus(&fastly.ListHerokusInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("heroku", ls, err, func(l *fastly.Heroku) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListHoneycombs(&fastly.ListHoneycombsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("honeycomb", ls, err, func(l *fastly.Honeycomb) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListHTTPS(&fastly.ListHTTPSInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("https", ls, err, func(l *fastly.HTTPS) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListKafkas(&fastly.ListKafkasInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("kafka", ls, err, func(l *fastly.Kafka) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListKinesis(&fastly.ListKinesisInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("kinesis", ls, err, func(l *fastly.Kinesis) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListLogentries(&fastly.ListLogentriesInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("logentries", ls, err, func(l *fastly.Logentries) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListLoggly(&fastly.ListLogglyInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("loggly", ls, err, func(l *fastly.Loggly) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListLogshuttles(&fastly.ListLogshuttlesInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("logshuttle", ls, err, func(l *fastly.Logshuttle) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListNewRelic(&fastly.ListNewRelicInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("newrelic", ls, err, func(l *fastly.NewRelic) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListOpenstack(&fastly.ListOpenstackInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("openstack", ls, err, func(l *fastly.Openstack) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListPapertrails(&fastly.ListPapertrailsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("papertrail", ls, err, func(l *fastly.Papertrail) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListS3s(&fastly.ListS3sInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("s3", ls, err, func(l *fastly.S3) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListScalyrs(&fastly.ListScalyrsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("scalyr", ls, err, func(l *fastly.Scalyr) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListSFTPs(&fastly.ListSFTPsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("sftp", ls, err, func(l *fastly.SFTP) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListSplunks(&fastly.ListSplunksInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("splunk", ls, err, func(l *fastly.Splunk) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListSumologics(&fastly.ListSumologicsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("sumologic", ls, err, func(l *fastly.Sumologic) (string, string) { return l.Name, l.Format })
+	},
+	func(c api.Interface, id string, v int) ([]Endpoint, error) {
+		ls, err := c.ListSyslogs(&fastly.ListSyslogsInput{ServiceID: id, ServiceVersion: v})
+		return endpoints("syslog", ls, err, func(l *fastly.Syslog) (string, string) { return l.Name, l.Format })
+	},
+}
+
+// endpoints returns the name and format of each of the logging endpoints
+// listed by the API.
+func endpoints[T any](typ string, ls []T, err error, nameFormat func(T) (string, string)) ([]Endpoint, error) {
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s logging endpoints: %w", typ, err)
+	}
+	es := make([]Endpoint, 0, len(ls))
+	for _, l := range ls {
+		name, format := nameFormat(l)
+		es = append(es, Endpoint{Type: typ, Name: name, Format: format})
+	}
+	return es, nil
+}
+
+// ListEndpoints returns every logging endpoint of the service version, of
+// every type, ordered by type. The types are listed concurrently.
+func ListEndpoints(client api.Interface, serviceID string, version int) ([]Endpoint, error) {
+	lists := make([][]Endpoint, len(endpointListers))
+	err := snapshot.ForEach(len(endpointListers), snapshot.Concurrency, func(i int) error {
+		es, err := endpointListers[i](client, serviceID, version)
+		lists[i] = es
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var all []Endpoint
+	for _, es := range lists {
+		all = append(all, es...)
+	}
+	return all, nil
+}
+
+// The risks of the sensitive fields that AuditPII flags.
+const (
+	RiskAuthorization = "credentials"
+	RiskCookie        = "cookies"
+	RiskQueryString   = "query string"
+	RiskRequestBody   = "request body"
+	RiskSetCookie     = "session cookies"
+)
+
+// directive matches a log format directive: a VCL expression, a request or
+// response header, or one of the Apache style directives.
+var directive = regexp.MustCompile(`%%|%\{([^}]*)\}([a-zA-Z])|%>?([a-zA-Z])`)
+
+// credentialHeader matches the names of headers that carry credentials.
+var credentialHeader = regexp.MustCompile(`(?i)^(proxy-)?authorization$|api-?key|token|secret|password`)
+
+// sensitiveVCL matches the variables of a VCL expression that hold sensitive
+// fields. The full URL (and query string) variables are checked separately,
+// as their path variables are safe to log.
+var (
+	vclCookie     = regexp.MustCompile(`(?i)\b(?:be)?req\.http\.cookie\b(:[^\s,)]+)?`)
+	vclSetCookie  = regexp.MustCompile(`(?i)\b(?:be)?resp\.http\.set-cookie\b(:[^\s,)]+)?|\bobj\.http\.set-cookie\b`)
+	vclHeader     = regexp.MustCompile(`(?i)\b(?:be)?req\.http\.([a-z0-9_-]+)\b`)
+	vclURL        = regexp.MustCompile(`\b(?:be)?req\.url(?:\.qs)?\b`)
+	vclBody       = regexp.MustCompile(`\breq\.(?:body|postbody)(?:\.base64)?\b`)
+	vclQuerystrFn = regexp.MustCompile(`\bquerystring\.`)
+)
+
+// AuditPII returns the sensitive fields that a log format logs, and the format
+// with each of them replaced by its suggested alternative.
+//
+// Cookies, credentials and request bodies are replaced by whether they're set,
+// and full URLs by their path. A specific cookie (e.g. req.http.Cookie:name)
+// is allowed, as is a URL whose query string is filtered with a querystring
+// function.
+func AuditPII(format string) ([]Finding, string) {
+	var findings []Finding
+	scrubbed := directive.ReplaceAllStringFunc(format, func(d string) string {
+		m := directive.FindStringSubmatch(d)
+		switch {
+		case d == "%%":
+			return d
+		case m[3] != "":
+			return auditApacheDirective(d, m[3], &findings)
+		case m[2] == "i", m[2] == "o":
+			return auditHeaderDirective(d, m[1], m[2] == "i", &findings)
+		case m[2] == "V":
+			expr := auditExpression(m[1], &findings)
+			return "%{" + expr + "}V"
+		}
+		return d
+	})
+	return findings, scrubbed
+}
+
+// auditApacheDirective audits an Apache style directive, e.g. %r.
+func auditApacheDirective(d, verb string, findings *[]Finding) string {
+	switch verb {
+	case "r":
+		// The request line includes the query string.
+		*findings = append(*findings, Finding{Field: d, Risk: RiskQueryString, Suggestion: "%m %U %H"})
+		return "%m %U %H"
+	case "q":
+		s := `%{if(req.url.qs != "", "set", "-")}V`
+		*findings = append(*findings, Finding{Field: d, Risk: RiskQueryString, Suggestion: s})
+		return s
+	}
+	return d
+}
+
+// auditHeaderDirective audits a request (%{...}i) or response (%{...}o)
+// header directive.
+func auditHeaderDirective(d, header string, request bool, findings *[]Finding) string {
+	var risk, variable string
+	switch {
+	case request && strings.EqualFold(header, "cookie"):
+		risk, variable = RiskCookie, "req.http.Cookie"
+	case request && credentialHeader.MatchString(header):
+		risk, variable = RiskAuthorization, "req.http."+header
+	case !request && strings.EqualFold(header, "set-cookie"):
+		risk, variable = RiskSetCookie, "resp.http.Set-Cookie"
+	default:
+		return d
+	}
+	s := "%{" + isSet(variable) + "}V"
+	*findings = append(*findings, Finding{Field: d, Risk: risk, Suggestion: s})
+	return s
+}
+
+// auditExpression audits the VCL expression of a %{...}V directive,
+// returning it with the sensitive variables replaced.
+func auditExpression(expr string, findings *[]Finding) string {
+	add := func(field, risk, suggestion string) {
+		*findings = append(*findings, Finding{Field: field, Risk: risk, Suggestion: suggestion})
+	}
+
+	expr = vclSetCookie.ReplaceAllStringFunc(expr, func(v string) string {
+		add(v, RiskSetCookie, isSet(v))
+		return isSet(v)
+	})
+	expr = replaceUnlessSubfield(vclCookie, expr, func(v string) string {
+		add(v, RiskCookie, isSet(v)+" or "+v+":<name>")
+		return isSet(v)
+	})
+	expr = vclHeader.ReplaceAllStringFunc(expr, func(v string) string {
+		header := vclHeader.FindStringSubmatch(v)[1]
+		if strings.EqualFold(header, "cookie") || !credentialHeader.MatchString(header) {
+			return v
+		}
+		add(v, RiskAuthorization, isSet(v))
+		return isSet(v)
+	})
+	expr = vclBody.ReplaceAllStringFunc(expr, func(v string) string {
+		add(v, RiskRequestBody, isSet(v))
+		return isSet(v)
+	})
+	if !vclQuerystrFn.MatchString(expr) {
+		expr = replaceUnlessSubfield(vclURL, expr, func(v string) string {
+			s := v + ".path"
+			if strings.HasSuffix(v, ".qs") {
+				s = fmt.Sprintf(`if(%s != "", "set", "-")`, v)
+			}
+			add(v, RiskQueryString, s)
+			return s
+		})
+	}
+	return expr
+}
+
+// replaceUnlessSubfield replaces the matches of re in expr that aren't
+// followed by a subfield (e.g. req.http.Cookie:name or req.url.path).
+func replaceUnlessSubfield(re *regexp.Regexp, expr string, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(expr, -1) {
+		end := loc[1]
+		if len(loc) > 2 && loc[2] >= 0 {
+			continue
+		}
+		if end < len(expr) && (expr[end] == '.' || expr[end] == ':') {
+			continue
+		}
+		b.WriteString(expr[last:loc[0]])
+		b.WriteString(replace(expr[loc[0]:end]))
+		last = end
+	}
+	b.WriteString(expr[last:])
+	return b.String()
+}
+
+// isSet returns a VCL expression that logs whether the variable is set,
+// rather than its value.
+func isSet(variable string) string {
+	return fmt.Sprintf(`if(%s, "set", "-")`, variable)
+}
@@ -0,0 +1,194 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/logging"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestAuditPII(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		format       string
+		wantFields   []string
+		wantRisks    []string
+		wantScrubbed string
+	}{
+		{
+			name:         "safe format",
+			format:       `%h %t "%m %U" %>s %{req.url.path}V %{req.http.Cookie:lang}V 100%%`,
+			wantScrubbed: `%h %t "%m %U" %>s %{req.url.path}V %{req.http.Cookie:lang}V 100%%`,
+		},
+		{
+			name:         "apache directives",
+			format:       `%h "%r" %>s %q %{Cookie}i %{Authorization}i %{Set-Cookie}o %{User-Agent}i`,
+			wantFields:   []string{"%r", "%q", "%{Cookie}i", "%{Authorization}i", "%{Set-Cookie}o"},
+			wantRisks:    []string{logging.RiskQueryString, logging.RiskQueryString, logging.RiskCookie, logging.RiskAuthorization, logging.RiskSetCookie},
+			wantScrubbed: `%h "%m %U %H" %>s %{if(req.url.qs != "", "set", "-")}V %{if(req.http.Cookie, "set", "-")}V %{if(req.http.Authorization, "set", "-")}V %{if(resp.http.Set-Cookie, "set", "-")}V %{User-Agent}i`,
+		},
+		{
+			name:         "vcl expressions",
+			format:       `{"url":"%{json.escape(req.url)}V","cookie":"%{json.escape(req.http.Cookie)}V","key":"%{req.http.X-API-Key}V","set":"%{resp.http.Set-Cookie}V","body":"%{req.body}V"}`,
+			wantFields:   []string{"req.url", "req.http.Cookie", "req.http.X-API-Key", "resp.http.Set-Cookie", "req.body"},
+			wantRisks:    []string{logging.RiskQueryString, logging.RiskCookie, logging.RiskAuthorization, logging.RiskSetCookie, logging.RiskRequestBody},
+			wantScrubbed: `{"url":"%{json.escape(req.url.path)}V","cookie":"%{json.escape(if(req.http.Cookie, "set", "-"))}V","key":"%{if(req.http.X-API-Key, "set", "-")}V","set":"%{if(resp.http.Set-Cookie, "set", "-")}V","body":"%{if(req.body, "set", "-")}V"}`,
+		},
+		{
+			name:         "filtered query string",
+			format:       `%{querystring.filter_except(req.url, "page")}V %{bereq.url.path}V`,
+			wantScrubbed: `%{querystring.filter_except(req.url, "page")}V %{bereq.url.path}V`,
+		},
+		{
+			name:         "query string",
+			format:       `%{req.url.qs}V`,
+			wantFields:   []string{"req.url.qs"},
+			wantRisks:    []string{logging.RiskQueryString},
+			wantScrubbed: `%{if(req.url.qs != "", "set", "-")}V`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			findings, scrubbed := logging.AuditPII(tc.format)
+			var fields, risks []string
+			for _, f := range findings {
+				fields = append(fields, f.Field)
+				risks = append(risks, f.Risk)
+			}
+			testutil.AssertEqual(t, tc.wantFields, fields)
+			testutil.AssertEqual(t, tc.wantRisks, risks)
+			testutil.AssertString(t, tc.wantScrubbed, scrubbed)
+		})
+	}
+}
+
+func TestAudit(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --pii flag",
+			Args:      args("logging audit --service-id 123 --version 1"),
+			WantError: "no audit was selected",
+		},
+		{
+			Name: "validate list API error",
+			API: func() mock.API {
+				api := loggingAPI()
+				api.ListKafkasFn = func(i *fastly.ListKafkasInput) ([]*fastly.Kafka, error) {
+					return nil, testutil.Err
+				}
+				return api
+			}(),
+			Args:      args("logging audit --pii --service-id 123 --version 1"),
+			WantError: "error listing kafka logging endpoints: test error",
+		},
+		{
+			Name: "success without sensitive fields",
+			API:  loggingAPI(),
+			Args: args("logging audit --pii --service-id 123 --version 1"),
+			WantOutputs: []string{
+				"PII audit of the log formats of 0 logging endpoints (service: 123, version: 1)",
+				"No sensitive fields are logged",
+			},
+		},
+		{
+			Name: "success",
+			API: func() mock.API {
+				api := loggingAPI()
+				api.ListHTTPSFn = func(i *fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) {
+					return []*fastly.HTTPS{{Name: "web", Format: `%h "%r" %>s`}}, nil
+				}
+				api.ListSyslogsFn = func(i *fastly.ListSyslogsInput) ([]*fastly.Syslog, error) {
+					return []*fastly.Syslog{{Name: "safe", Format: `%h %U %>s`}}, nil
+				}
+				return api
+			}(),
+			Args: args("logging audit --pii --service-id 123 --version 1"),
+			WantOutputs: []string{
+				"PII audit of the log formats of 2 logging endpoints (service: 123, version: 1)",
+				"TYPE   NAME  FIELD  RISK          SUGGESTION",
+				"https  web   %r     query string  %m %U %H",
+				"Scrubbed format for https endpoint 'web'",
+				`%h "%m %U %H" %>s`,
+				"Found sensitive fields in the log formats of 1 logging endpoints.",
+			},
+		},
+		{
+			Name: "success with --json",
+			API: func() mock.API {
+				api := loggingAPI()
+				api.ListS3sFn = func(i *fastly.ListS3sInput) ([]*fastly.S3, error) {
+					return []*fastly.S3{{Name: "archive", Format: `%{req.http.Cookie}V`}}, nil
+				}
+				return api
+			}(),
+			Args:       args("logging audit --pii --service-id 123 --version 1 --json"),
+			WantOutput: `[{"type":"s3","name":"archive","format":"%{req.http.Cookie}V","findings":[{"field":"req.http.Cookie","risk":"cookies","suggestion":"if(req.http.Cookie, \"set\", \"-\") or req.http.Cookie:\u003cname\u003e"}],"scrubbed_format":"%{if(req.http.Cookie, \"set\", \"-\")}V"}]`,
+		},
+		{
+			Name: "success with --report",
+			API: func() mock.API {
+				api := loggingAPI()
+				api.ListSyslogsFn = func(i *fastly.ListSyslogsInput) ([]*fastly.Syslog, error) {
+					return []*fastly.Syslog{{Name: "safe", Format: `%h %U %>s`}}, nil
+				}
+				return api
+			}(),
+			Args:       args("logging audit --pii --service-id 123 --version 1 --report junit"),
+			WantOutput: `<testcase classname="logging audit" name="syslog endpoint &#39;safe&#39; log format"></testcase>`,
+		},
+		{
+			Name: "validate --report with sensitive fields",
+			API: func() mock.API {
+				api := loggingAPI()
+				api.ListHTTPSFn = func(i *fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) {
+					return []*fastly.HTTPS{{Name: "web", Format: `%h "%r" %>s`}}, nil
+				}
+				return api
+			}(),
+			Args:       args("logging audit --pii --service-id 123 --version 1 --report sarif"),
+			WantError:  "found sensitive fields in the log formats of 1 logging endpoints",
+			WantOutput: `"text": "logs sensitive fields: %r (query string); scrubbed format: %h \"%m %U %H\" %\u003es"`,
+		},
+	}
+	testutil.RunScenarios(t, scenarios)
+}
+
+// loggingAPI returns a mock API whose service version has no logging
+// endpoints.
+func loggingAPI() mock.API {
+	return mock.API{
+		ListVersionsFn:     testutil.ListVersions,
+		ListBlobStoragesFn: func(*fastly.ListBlobStoragesInput) ([]*fastly.BlobStorage, error) { return nil, nil },
+		ListBigQueriesFn:   func(*fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error) { return nil, nil },
+		ListCloudfilesFn:   func(*fastly.ListCloudfilesInput) ([]*fastly.Cloudfiles, error) { return nil, nil },
+		ListDatadogFn:      func(*fastly.ListDatadogInput) ([]*fastly.Datadog, error) { return nil, nil },
+		ListDigitalOceansFn: func(*fastly.ListDigitalOceansInput) ([]*fastly.DigitalOcean, error) {
+			return nil, nil
+		},
+		ListElasticsearchFn: func(*fastly.ListElasticsearchInput) ([]*fastly.Elasticsearch, error) {
+			return nil, nil
+		},
+		ListFTPsFn:        func(*fastly.ListFTPsInput) ([]*fastly.FTP, error) { return nil, nil },
+		ListGCSsFn:        func(*fastly.ListGCSsInput) ([]*fastly.GCS, error) { return nil, nil },
+		ListPubsubsFn:     func(*fastly.ListPubsubsInput) ([]*fastly.Pubsub, error) { return nil, nil },
+		ListHerokusFn:     func(*fastly.ListHerokusInput) ([]*fastly.Heroku, error) { return nil, nil },
+		ListHoneycombsFn:  func(*fastly.ListHoneycombsInput) ([]*fastly.Honeycomb, error) { return nil, nil },
+		ListHTTPSFn:       func(*fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) { return nil, nil },
+		ListKafkasFn:      func(*fastly.ListKafkasInput) ([]*fastly.Kafka, error) { return nil, nil },
+		ListKinesisFn:     func(*fastly.ListKinesisInput) ([]*fastly.Kinesis, error) { return nil, nil },
+		ListLogentriesFn:  func(*fastly.ListLogentriesInput) ([]*fastly.Logentries, error) { return nil, nil },
+		ListLogglyFn:      func(*fastly.ListLogglyInput) ([]*fastly.Loggly, error) { return nil, nil },
+		ListLogshuttlesFn: func(*fastly.ListLogshuttlesInput) ([]*fastly.Logshuttle, error) { return nil, nil },
+		ListNewRelicFn:    func(*fastly.ListNewRelicInput) ([]*fastly.NewRelic, error) { return nil, nil },
+		ListOpenstacksFn:  func(*fastly.ListOpenstackInput) ([]*fastly.Openstack, error) { return nil, nil },
+		ListPapertrailsFn: func(*fastly.ListPapertrailsInput) ([]*fastly.Papertrail, error) { return nil, nil },
+		ListS3sFn:         func(*fastly.ListS3sInput) ([]*fastly.S3, error) { return nil, nil },
+		ListScalyrsFn:     func(*fastly.ListScalyrsInput) ([]*fastly.Scalyr, error) { return nil, nil },
+		ListSFTPsFn:       func(*fastly.ListSFTPsInput) ([]*fastly.SFTP, error) { return nil, nil },
+		ListSplunksFn:     func(*fastly.ListSplunksInput) ([]*fastly.Splunk, error) { return nil, nil },
+		ListSumologicsFn:  func(*fastly.ListSumologicsInput) ([]*fastly.Sumologic, error) { return nil, nil },
+		ListSyslogsFn:     func(*fastly.ListSyslogsInput) ([]*fastly.Syslog, error) { return nil, nil },
+	}
+}
@@ -122,7 +122,6 @@ func TestUpdateBlobStorageInput(t *testing.T) {
 				SASToken:          fastly.String("new4"),
 				Path:              fastly.String("new5"),
 				Period:            fastly.Uint(3601),
-				GzipLevel:         fastly.Uint(0),
 				Format:            fastly.String("new6"),
 				FormatVersion:     fastly.Uint(3),
 				ResponseCondition: fastly.String("new7"),
@@ -130,7 +129,7 @@ func TestUpdateBlobStorageInput(t *testing.T) {
 				TimestampFormat:   fastly.String("new9"),
 				Placement:         fastly.String("new10"),
 				PublicKey:         fastly.String("new11"),
-				CompressionCodec:  fastly.String("new12"),
+				CompressionCodec:  fastly.String("zstd"),
 			},
 		},
 		{
@@ -356,7 +355,6 @@ func updateCommandAll() *azureblob.UpdateCommand {
 		SASToken:          cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new4"},
 		Path:              cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new5"},
 		Period:            cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3601},
-		GzipLevel:         cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 0},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new6"},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
 		ResponseCondition: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new7"},
@@ -364,7 +362,7 @@ func updateCommandAll() *azureblob.UpdateCommand {
 		TimestampFormat:   cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new9"},
 		Placement:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new10"},
 		PublicKey:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new11"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new12"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zstd"},
 	}
 }
 
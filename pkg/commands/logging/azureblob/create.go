@@ -1,10 +1,10 @@
 package azureblob
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -32,6 +32,7 @@ type CreateCommand struct {
 	GzipLevel         cmd.OptionalUint
 	MessageType       cmd.OptionalString
 	Format            cmd.OptionalString
+	FormatJSON        cmd.OptionalString
 	FormatVersion     cmd.OptionalUint
 	ResponseCondition cmd.OptionalString
 	TimestampFormat   cmd.OptionalString
@@ -77,6 +78,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("period", "How frequently log files are finalized so they can be available for reading (in seconds, default 3600)").Action(c.Period.Set).UintVar(&c.Period.Value)
 	c.CmdClause.Flag("gzip-level", "What level of GZIP encoding to have when dumping logs (default 0, no compression)").Action(c.GzipLevel.Set).UintVar(&c.GzipLevel.Value)
 	c.CmdClause.Flag("format", "Apache style log formatting").Action(c.Format.Set).StringVar(&c.Format.Value)
+	logging.RegisterJSONFormatFlag(c.CmdClause, &c.FormatJSON)
 	c.CmdClause.Flag("message-type", "How the message should be formatted. One of: classic (default), loggly, logplex or blank").Action(c.MessageType.Set).StringVar(&c.MessageType.Value)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (default) or 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
@@ -99,10 +101,8 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	input.AccountName = c.AccountName
 	input.SASToken = c.SASToken
 
-	// The following blocks enforces the mutual exclusivity of the
-	// CompressionCodec and GzipLevel flags.
-	if c.CompressionCodec.WasSet && c.GzipLevel.WasSet {
-		return nil, fmt.Errorf("error parsing arguments: the --compression-codec flag is mutually exclusive with the --gzip-level flag")
+	if err := cmd.CheckFlagRules(logging.CompressionRules(c.CompressionCodec, c.GzipLevel.WasSet, c.GzipLevel.Value)...); err != nil {
+		return nil, err
 	}
 
 	if c.Path.WasSet {
@@ -121,6 +121,14 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 		input.Format = c.Format.Value
 	}
 
+	if c.FormatJSON.WasSet {
+		format, err := logging.JSONFormat(c.Format, c.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = format
+	}
+
 	if c.FormatVersion.WasSet {
 		input.FormatVersion = c.FormatVersion.Value
 	}
@@ -142,7 +150,11 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	}
 
 	if c.PublicKey.WasSet {
-		input.PublicKey = c.PublicKey.Value
+		content, err := cmd.Content(c.PublicKey.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.PublicKey = content
 	}
 
 	if c.FileMaxBytes.WasSet {
@@ -161,6 +173,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.AutoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.Manifest,
 		Out:                out,
 		ServiceNameFlag:    c.ServiceName,
@@ -184,6 +197,19 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	err = logging.CheckVCLOnlyFlags(c.Globals.APIClient, serviceID, logging.VCLOnlyFlags{
+		FormatVersion:     c.FormatVersion.WasSet,
+		Placement:         c.Placement.WasSet,
+		ResponseCondition: c.ResponseCondition.WasSet,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
 	d, err := c.Globals.APIClient.CreateBlobStorage(input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
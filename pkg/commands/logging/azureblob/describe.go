@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -58,13 +59,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -112,6 +110,9 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 	fmt.Fprintf(out, "Period: %d\n", azureblob.Period)
 	fmt.Fprintf(out, "GZip level: %d\n", azureblob.GzipLevel)
 	fmt.Fprintf(out, "Format: %s\n", azureblob.Format)
+	if template, ok := logging.ParseJSONFormat(azureblob.Format); ok {
+		fmt.Fprintf(out, "Format template:\n%s\n", template)
+	}
 	fmt.Fprintf(out, "Format version: %d\n", azureblob.FormatVersion)
 	fmt.Fprintf(out, "Response condition: %s\n", azureblob.ResponseCondition)
 	fmt.Fprintf(out, "Message type: %s\n", azureblob.MessageType)
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -32,6 +33,7 @@ type UpdateCommand struct {
 	GzipLevel         cmd.OptionalUint
 	MessageType       cmd.OptionalString
 	Format            cmd.OptionalString
+	FormatJSON        cmd.OptionalString
 	FormatVersion     cmd.OptionalUint
 	ResponseCondition cmd.OptionalString
 	TimestampFormat   cmd.OptionalString
@@ -78,6 +80,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("period", "How frequently log files are finalized so they can be available for reading (in seconds, default 3600)").Action(c.Period.Set).UintVar(&c.Period.Value)
 	c.CmdClause.Flag("gzip-level", "What level of GZIP encoding to have when dumping logs (default 0, no compression)").Action(c.GzipLevel.Set).UintVar(&c.GzipLevel.Value)
 	c.CmdClause.Flag("format", "Apache style log formatting").Action(c.Format.Set).StringVar(&c.Format.Value)
+	logging.RegisterJSONFormatFlag(c.CmdClause, &c.FormatJSON)
 	c.CmdClause.Flag("message-type", "How the message should be formatted. One of: classic (default), loggly, logplex or blank").Action(c.MessageType.Set).StringVar(&c.MessageType.Value)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (default) or 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
@@ -97,6 +100,10 @@ func (c *UpdateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 		Name:           c.EndpointName,
 	}
 
+	if err := cmd.CheckFlagRules(logging.CompressionRules(c.CompressionCodec, c.GzipLevel.WasSet, c.GzipLevel.Value)...); err != nil {
+		return nil, err
+	}
+
 	// Set new values if set by user.
 	if c.NewName.WasSet {
 		input.NewName = fastly.String(c.NewName.Value)
@@ -130,6 +137,14 @@ func (c *UpdateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 		input.Format = fastly.String(c.Format.Value)
 	}
 
+	if c.FormatJSON.WasSet {
+		format, err := logging.JSONFormat(c.Format, c.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = fastly.String(format)
+	}
+
 	if c.FormatVersion.WasSet {
 		input.FormatVersion = fastly.Uint(c.FormatVersion.Value)
 	}
@@ -151,7 +166,11 @@ func (c *UpdateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	}
 
 	if c.PublicKey.WasSet {
-		input.PublicKey = fastly.String(c.PublicKey.Value)
+		content, err := cmd.Content(c.PublicKey.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.PublicKey = fastly.String(content)
 	}
 
 	if c.FileMaxBytes.WasSet {
@@ -170,6 +189,7 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.AutoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.Manifest,
 		Out:                out,
 		ServiceNameFlag:    c.ServiceName,
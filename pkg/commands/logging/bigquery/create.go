@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -32,6 +33,7 @@ type CreateCommand struct {
 	Placement         cmd.OptionalString
 	ResponseCondition cmd.OptionalString
 	Format            cmd.OptionalString
+	FormatJSON        cmd.OptionalString
 	FormatVersion     cmd.OptionalUint
 }
 
@@ -71,6 +73,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	})
 	c.CmdClause.Flag("template-suffix", "BigQuery table name suffix template").Action(c.Template.Set).StringVar(&c.Template.Value)
 	c.CmdClause.Flag("format", "Apache style log formatting. Must produce JSON that matches the schema of your BigQuery table").Action(c.Format.Set).StringVar(&c.Format.Value)
+	logging.RegisterJSONFormatFlag(c.CmdClause, &c.FormatJSON)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (the default, version 2 log format) or 1 (the version 1 log format). The logging call gets placed by default in vcl_log if format_version is set to 2 and in vcl_deliver if format_version is set to 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed, overriding any format_version default. Can be none or waf_debug. This field is not required and has no default value").Action(c.Placement.Set).StringVar(&c.Placement.Value)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
@@ -88,7 +91,11 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	input.Dataset = c.Dataset
 	input.User = c.User
 	input.Table = c.Table
-	input.SecretKey = c.SecretKey
+	content, err := cmd.Content(c.SecretKey, c.Globals.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	input.SecretKey = content
 
 	if c.Template.WasSet {
 		input.Template = c.Template.Value
@@ -98,6 +105,14 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 		input.Format = c.Format.Value
 	}
 
+	if c.FormatJSON.WasSet {
+		format, err := logging.JSONFormat(c.Format, c.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = format
+	}
+
 	if c.FormatVersion.WasSet {
 		input.FormatVersion = c.FormatVersion.Value
 	}
@@ -118,6 +133,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.AutoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.Manifest,
 		Out:                out,
 		ServiceNameFlag:    c.ServiceName,
@@ -141,6 +157,19 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	err = logging.CheckVCLOnlyFlags(c.Globals.APIClient, serviceID, logging.VCLOnlyFlags{
+		FormatVersion:     c.FormatVersion.WasSet,
+		Placement:         c.Placement.WasSet,
+		ResponseCondition: c.ResponseCondition.WasSet,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
 	d, err := c.Globals.APIClient.CreateBigQuery(input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
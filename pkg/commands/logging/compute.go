@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ComputeServiceType is the type of a Compute@Edge service.
+const ComputeServiceType = "wasm"
+
+// VCLOnlyFlags records which of the VCL-only flags of a logging endpoint
+// create command were set. A Compute@Edge service's program formats and sends
+// its own log lines, so these settings don't apply to its endpoints.
+type VCLOnlyFlags struct {
+	FormatVersion     bool
+	Placement         bool
+	ResponseCondition bool
+}
+
+// CheckVCLOnlyFlags returns an error if any of the VCL-only flags were set for
+// a logging endpoint of a Compute@Edge service, rather than leaving the API to
+// reject them. The service is only looked up when one of them was set.
+func CheckVCLOnlyFlags(client api.Interface, serviceID string, flags VCLOnlyFlags) error {
+	var set []string
+	if flags.FormatVersion {
+		set = append(set, "--format-version")
+	}
+	if flags.Placement {
+		set = append(set, "--placement")
+	}
+	if flags.ResponseCondition {
+		set = append(set, "--response-condition")
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	s, err := client.GetService(&fastly.GetServiceInput{ID: serviceID})
+	if err != nil {
+		return fmt.Errorf("error getting service %s: %w", serviceID, err)
+	}
+	if s.Type != ComputeServiceType {
+		return nil
+	}
+
+	verb := "applies"
+	if len(set) > 1 {
+		verb = "apply"
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("%s only %s to VCL services, and service %s is a Compute@Edge service", strings.Join(set, ", "), verb, serviceID),
+		Remediation: "Remove the flags. A Compute@Edge service's program formats and sends its own log lines (e.g. with the log-fastly crate), so the endpoint's format version, VCL placement and response condition aren't used.",
+	}
+}
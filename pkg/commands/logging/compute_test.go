@@ -0,0 +1,36 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/logging"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestCheckVCLOnlyFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		typ       string
+		flags     logging.VCLOnlyFlags
+		wantError string
+	}{
+		{name: "no flags", flags: logging.VCLOnlyFlags{}},
+		{name: "vcl service", typ: "vcl", flags: logging.VCLOnlyFlags{FormatVersion: true, Placement: true}},
+		{name: "compute service", typ: "wasm", flags: logging.VCLOnlyFlags{FormatVersion: true}, wantError: "--format-version only applies to VCL services, and service 123 is a Compute@Edge service"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Without any VCL-only flags the service isn't looked up, so the
+			// mock's GetServiceFn can be unset.
+			var client mock.API
+			if tc.typ != "" {
+				client.GetServiceFn = func(i *fastly.GetServiceInput) (*fastly.Service, error) {
+					return &fastly.Service{ID: i.ID, Type: tc.typ}, nil
+				}
+			}
+			err := logging.CheckVCLOnlyFlags(client, "123", tc.flags)
+			testutil.AssertErrorContains(t, err, tc.wantError)
+		})
+	}
+}
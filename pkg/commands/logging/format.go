@@ -0,0 +1,276 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/kingpin"
+)
+
+// JSONFormatFlagDesc is the description of the --json-format flag of the
+// logging endpoint create and update commands.
+const JSONFormatFlagDesc = "Path to a JSON log format template, with {{expression}} placeholders for VCL expressions, to validate and use in place of --format"
+
+// RegisterJSONFormatFlag registers the --json-format flag of a logging
+// endpoint create or update command, along with --format-json, the name it
+// was originally given, which is hidden but still accepted.
+func RegisterJSONFormatFlag(clause *kingpin.CmdClause, dst *cmd.OptionalString) {
+	clause.Flag("json-format", JSONFormatFlagDesc).Action(dst.Set).StringVar(&dst.Value)
+	clause.Flag("format-json", JSONFormatFlagDesc).Hidden().Action(dst.Set).StringVar(&dst.Value)
+}
+
+// FormatJSONRemediation explains how to write a JSON log format template.
+var FormatJSONRemediation = strings.Join([]string{
+	"A log format template is a JSON object whose string values may contain {{expression}} placeholders,",
+	"e.g. {\"url\": \"{{req.url}}\", \"status\": \"{{resp.status | raw}}\"}.",
+	"Placeholders are JSON escaped unless the whole value is a '| raw' placeholder, which is inserted unquoted.",
+}, " ")
+
+// rawPlaceholder matches a string value that's a single placeholder to be
+// inserted without quotes, e.g. a number or boolean.
+var rawPlaceholder = regexp.MustCompile(`^\{\{\s*(.+?)\s*\|\s*raw\s*\}\}$`)
+
+// JSONFormat reads the JSON log format template given by the --json-format
+// (or, for HTTPS endpoints, --format-json) flag and returns the log format it
+// renders to. The --format flag can't also be set.
+func JSONFormat(format, template cmd.OptionalString) (string, error) {
+	if format.WasSet {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("--format and a log format template can't be used together"),
+			Remediation: "Provide either the --format flag or the log format template flag.",
+		}
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as we require a user to configure their own environment.
+	/* #nosec */
+	data, err := os.ReadFile(template.Value)
+	if err != nil {
+		return "", fmt.Errorf("error reading log format template: %w", err)
+	}
+
+	f, err := RenderJSONFormat(data)
+	if err != nil {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid log format template %s: %w", template.Value, err),
+			Remediation: FormatJSONRemediation,
+		}
+	}
+	return f, nil
+}
+
+// RenderJSONFormat validates a JSON log format template and returns it as a
+// log format string. The template's structure is preserved, placeholders
+// become %{...}V directives and literal percent signs are escaped.
+func RenderJSONFormat(template []byte) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(template, &v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, col := position(template, syntaxErr.Offset)
+			return "", fmt.Errorf("line %d, column %d: %w", line, col, err)
+		}
+		return "", err
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return "", fmt.Errorf("the template must be a JSON object")
+	}
+
+	// The template is decoded token by token, rather than into a map, so the
+	// order of its keys is kept.
+	dec := json.NewDecoder(bytes.NewReader(template))
+	dec.UseNumber()
+
+	type container struct {
+		object bool
+		n      int
+	}
+	var (
+		b     strings.Builder
+		stack []container
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			b.WriteRune(rune(d))
+			continue
+		}
+
+		isKey := false
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			switch {
+			case top.object && top.n%2 == 1:
+				b.WriteByte(':')
+			case top.n > 0:
+				b.WriteByte(',')
+			}
+			isKey = top.object && top.n%2 == 0
+			top.n++
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			b.WriteRune(rune(t))
+			stack = append(stack, container{object: t == '{'})
+		case string:
+			if isKey {
+				b.WriteString(`"` + literal(t) + `"`)
+				continue
+			}
+			s, err := renderString(t)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+		case json.Number:
+			b.WriteString(t.String())
+		case bool:
+			b.WriteString(strconv.FormatBool(t))
+		case nil:
+			b.WriteString("null")
+		}
+	}
+	return b.String(), nil
+}
+
+// renderString returns a string value of the template as it appears in the
+// log format.
+func renderString(s string) (string, error) {
+	if m := rawPlaceholder.FindStringSubmatch(s); m != nil {
+		return "%{" + m[1] + "}V", nil
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for rest := s; ; {
+		i := strings.Index(rest, "{{")
+		if i < 0 {
+			b.WriteString(literal(rest))
+			break
+		}
+		j := strings.Index(rest[i:], "}}")
+		if j < 0 {
+			return "", fmt.Errorf("unterminated placeholder in %q", s)
+		}
+		expr := strings.TrimSpace(rest[i+2 : i+j])
+		switch {
+		case expr == "":
+			return "", fmt.Errorf("empty placeholder in %q", s)
+		case rawPlaceholder.MatchString(rest[i : i+j+2]):
+			return "", fmt.Errorf("a '| raw' placeholder must be the whole value, in %q", s)
+		}
+		b.WriteString(literal(rest[:i]))
+		b.WriteString("%{json.escape(" + expr + ")}V")
+		rest = rest[i+j+2:]
+	}
+	b.WriteByte('"')
+	return b.String(), nil
+}
+
+// literal returns s JSON escaped, without quotes, with its percent signs
+// escaped for the log format.
+func literal(s string) string {
+	return strings.ReplaceAll(escape(s), "%", "%%")
+}
+
+// ParseJSONFormat returns the JSON log format template of a log format
+// rendered by RenderJSONFormat, indented for display. It returns false if the
+// log format isn't a JSON object or contains directives a template can't.
+func ParseJSONFormat(format string) (string, bool) {
+	var (
+		b        strings.Builder
+		inString bool
+		escaped  bool
+	)
+	for i := 0; i < len(format); {
+		c := format[i]
+		if c == '%' {
+			if strings.HasPrefix(format[i:], "%%") {
+				b.WriteByte('%')
+				i += 2
+				continue
+			}
+			end := strings.Index(format[i:], "}V")
+			if !strings.HasPrefix(format[i:], "%{") || end < 0 {
+				return "", false
+			}
+			expr := format[i+2 : i+end]
+			i += end + 2
+			if inString {
+				if !strings.HasPrefix(expr, "json.escape(") || !strings.HasSuffix(expr, ")") {
+					return "", false
+				}
+				expr = strings.TrimSuffix(strings.TrimPrefix(expr, "json.escape("), ")")
+				b.WriteString("{{" + escape(expr) + "}}")
+			} else {
+				b.WriteString(`"{{` + escape(expr) + ` | raw}}"`)
+			}
+			continue
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		}
+		b.WriteByte(c)
+		i++
+	}
+
+	template := strings.TrimSpace(b.String())
+	if !strings.HasPrefix(template, "{") || !json.Valid([]byte(template)) {
+		return "", false
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(template), "", "  "); err != nil {
+		return "", false
+	}
+	return out.String(), true
+}
+
+// escape returns s JSON escaped, without quotes.
+func escape(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	quoted := strings.TrimSpace(b.String())
+	return quoted[1 : len(quoted)-1]
+}
+
+// position returns the line and column of the byte offset in data.
+func position(data []byte, offset int64) (line, col int) {
+	line, col = 1, 1
+	for i := int64(0); i < offset-1 && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
@@ -0,0 +1,99 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestRenderJSONFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		template  string
+		want      string
+		wantError string
+	}{
+		{
+			name:     "placeholders",
+			template: `{"url": "{{ req.url }}", "status": "{{resp.status | raw}}", "cache": {"state": "{{fastly_info.state}}"}}`,
+			want:     `{"url":"%{json.escape(req.url)}V","status":%{resp.status}V,"cache":{"state":"%{json.escape(fastly_info.state)}V"}}`,
+		},
+		{
+			name:     "literals are escaped",
+			template: `{"z": "100% \"quoted\" <b>", "a": [1, true, null], "host": "{{req.http.host}}:443"}`,
+			want:     `{"z":"100%% \"quoted\" <b>","a":[1,true,null],"host":"%{json.escape(req.http.host)}V:443"}`,
+		},
+		{
+			name:     "expressions are not escaped",
+			template: `{"time": "{{strftime({\"%Y-%m-%dT%H:%M:%S%z\"}, time.start)}}"}`,
+			want:     `{"time":"%{json.escape(strftime({"%Y-%m-%dT%H:%M:%S%z"}, time.start))}V"}`,
+		},
+		{
+			name:      "invalid JSON",
+			template:  "{\n  \"url\": \"{{req.url}}\",\n}",
+			wantError: "line 3, column 1",
+		},
+		{
+			name:      "not an object",
+			template:  `["{{req.url}}"]`,
+			wantError: "the template must be a JSON object",
+		},
+		{
+			name:      "unterminated placeholder",
+			template:  `{"url": "{{req.url"}`,
+			wantError: "unterminated placeholder",
+		},
+		{
+			name:      "raw placeholder inside a string",
+			template:  `{"status": "code {{resp.status | raw}}"}`,
+			wantError: "a '| raw' placeholder must be the whole value",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := logging.RenderJSONFormat([]byte(tc.template))
+			testutil.AssertErrorContains(t, err, tc.wantError)
+			testutil.AssertString(t, tc.want, have)
+		})
+	}
+}
+
+func TestParseJSONFormat(t *testing.T) {
+	template := `{"url": "{{req.url}}", "status": "{{resp.status | raw}}", "note": "100% \"ok\""}`
+	format, err := logging.RenderJSONFormat([]byte(template))
+	testutil.AssertNoError(t, err)
+
+	have, ok := logging.ParseJSONFormat(format)
+	testutil.AssertBool(t, true, ok)
+	testutil.AssertString(t, "{\n  \"url\": \"{{req.url}}\",\n  \"status\": \"{{resp.status | raw}}\",\n  \"note\": \"100% \\\"ok\\\"\"\n}", have)
+
+	again, err := logging.RenderJSONFormat([]byte(have))
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, format, again)
+
+	for _, format := range []string{
+		`%h %l %u %t "%r" %>s %b`,
+		`{"url":"%{req.url}V"}`,
+		`{"url":"%U"}`,
+	} {
+		_, ok := logging.ParseJSONFormat(format)
+		testutil.AssertBool(t, false, ok)
+	}
+}
+
+func TestRegisterJSONFormatFlag(t *testing.T) {
+	// --format-json is the flag's original name.
+	for _, flag := range []string{"--json-format", "--format-json"} {
+		t.Run(flag, func(t *testing.T) {
+			app := kingpin.New("fastly", "")
+			var template cmd.OptionalString
+			logging.RegisterJSONFormatFlag(app.Command("create", ""), &template)
+			_, err := app.Parse([]string{"create", flag, "format.json"})
+			testutil.AssertNoError(t, err)
+			testutil.AssertBool(t, true, template.WasSet)
+			testutil.AssertString(t, "format.json", template.Value)
+		})
+	}
+}
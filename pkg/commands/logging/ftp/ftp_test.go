@@ -137,12 +137,11 @@ func TestUpdateFTPInput(t *testing.T) {
 				Path:              fastly.String("new5"),
 				Period:            fastly.Uint(3601),
 				FormatVersion:     fastly.Uint(3),
-				GzipLevel:         fastly.Uint8(0),
 				Format:            fastly.String("new6"),
 				ResponseCondition: fastly.String("new7"),
 				TimestampFormat:   fastly.String("new8"),
 				Placement:         fastly.String("new9"),
-				CompressionCodec:  fastly.String("new11"),
+				CompressionCodec:  fastly.String("zstd"),
 			},
 		},
 		{
@@ -353,13 +352,12 @@ func updateCommandAll() *ftp.UpdateCommand {
 		PublicKey:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new10"},
 		Path:              cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new5"},
 		Period:            cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3601},
-		GzipLevel:         cmd.OptionalUint8{Optional: cmd.Optional{WasSet: true}, Value: 0},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new6"},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
 		ResponseCondition: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new7"},
 		TimestampFormat:   cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new8"},
 		Placement:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new9"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new11"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zstd"},
 	}
 }
 
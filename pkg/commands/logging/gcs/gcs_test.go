@@ -134,13 +134,12 @@ func TestUpdateGCSInput(t *testing.T) {
 				Path:              fastly.String("new5"),
 				Period:            fastly.Uint(3601),
 				FormatVersion:     fastly.Uint(3),
-				GzipLevel:         fastly.Uint8(0),
 				Format:            fastly.String("new6"),
 				ResponseCondition: fastly.String("new7"),
 				TimestampFormat:   fastly.String("new8"),
 				Placement:         fastly.String("new9"),
 				MessageType:       fastly.String("new10"),
-				CompressionCodec:  fastly.String("new11"),
+				CompressionCodec:  fastly.String("zstd"),
 			},
 		},
 		{
@@ -349,14 +348,13 @@ func updateCommandAll() *gcs.UpdateCommand {
 		SecretKey:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new4"},
 		Path:              cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new5"},
 		Period:            cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3601},
-		GzipLevel:         cmd.OptionalUint8{Optional: cmd.Optional{WasSet: true}, Value: 0},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new6"},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
 		ResponseCondition: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new7"},
 		TimestampFormat:   cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new8"},
 		Placement:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new9"},
 		MessageType:       cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new10"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new11"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zstd"},
 	}
 }
 
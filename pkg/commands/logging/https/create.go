@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -37,6 +38,7 @@ type CreateCommand struct {
 	Method            cmd.OptionalString
 	JSONFormat        cmd.OptionalString
 	Format            cmd.OptionalString
+	FormatJSON        cmd.OptionalString
 	FormatVersion     cmd.OptionalUint
 	Placement         cmd.OptionalString
 	ResponseCondition cmd.OptionalString
@@ -83,6 +85,9 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("tls-hostname", "The hostname used to verify the server's certificate. It can either be the Common Name or a Subject Alternative Name (SAN)").Action(c.TLSHostname.Set).StringVar(&c.TLSHostname.Value)
 	c.CmdClause.Flag("message-type", "How the message should be formatted. One of: classic (default), loggly, logplex or blank").Action(c.MessageType.Set).StringVar(&c.MessageType.Value)
 	c.CmdClause.Flag("format", "Apache style log formatting. Your log must produce valid JSON that HTTPS can ingest").Action(c.Format.Set).StringVar(&c.Format.Value)
+	// --json-format is the endpoint's JSON batching setting, so the log format
+	// template keeps its original --format-json flag.
+	c.CmdClause.Flag("format-json", logging.JSONFormatFlagDesc).Action(c.FormatJSON.Set).StringVar(&c.FormatJSON.Value)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (default) or 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed, overriding any format_version default. Can be none or waf_debug").Action(c.Placement.Set).StringVar(&c.Placement.Value)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
@@ -129,15 +134,27 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	}
 
 	if c.TLSCACert.WasSet {
-		input.TLSCACert = c.TLSCACert.Value
+		content, err := cmd.Content(c.TLSCACert.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.TLSCACert = content
 	}
 
 	if c.TLSClientCert.WasSet {
-		input.TLSClientCert = c.TLSClientCert.Value
+		content, err := cmd.Content(c.TLSClientCert.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.TLSClientCert = content
 	}
 
 	if c.TLSClientKey.WasSet {
-		input.TLSClientKey = c.TLSClientKey.Value
+		content, err := cmd.Content(c.TLSClientKey.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.TLSClientKey = content
 	}
 
 	if c.TLSHostname.WasSet {
@@ -148,6 +165,14 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 		input.Format = c.Format.Value
 	}
 
+	if c.FormatJSON.WasSet {
+		format, err := logging.JSONFormat(c.Format, c.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = format
+	}
+
 	if c.FormatVersion.WasSet {
 		input.FormatVersion = c.FormatVersion.Value
 	}
@@ -172,6 +197,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.AutoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.Manifest,
 		Out:                out,
 		ServiceNameFlag:    c.ServiceName,
@@ -192,6 +218,16 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	err = logging.CheckVCLOnlyFlags(c.Globals.APIClient, serviceID, logging.VCLOnlyFlags{
+		FormatVersion:     c.FormatVersion.WasSet,
+		Placement:         c.Placement.WasSet,
+		ResponseCondition: c.ResponseCondition.WasSet,
+	})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
 	d, err := c.Globals.APIClient.CreateHTTPS(input)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -3,6 +3,8 @@ package https_test
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -58,6 +60,53 @@ func TestHTTPSCreate(t *testing.T) {
 	}
 }
 
+func TestHTTPSCreateFormatJSON(t *testing.T) {
+	template := filepath.Join(t.TempDir(), "format.json")
+	if err := os.WriteFile(template, []byte(`{"url": "{{req.url}}", "status": "{{resp.status | raw}}"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var format string
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CreateHTTPSFn: func(i *fastly.CreateHTTPSInput) (*fastly.HTTPS, error) {
+			format = i.Format
+			return createHTTPSOK(i)
+		},
+	}
+
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args       []string
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("logging https create --service-id 123 --version 3 --name log --url example.com --format %h --format-json " + template),
+			wantError: "--format and a log format template can't be used together",
+		},
+		{
+			args:      args("logging https create --service-id 123 --version 3 --name log --url example.com --format-json " + template + ".missing"),
+			wantError: "error reading log format template",
+		},
+		{
+			args:       args("logging https create --service-id 123 --version 3 --name log --url example.com --format-json " + template),
+			wantOutput: "Created HTTPS logging endpoint log (service 123 version 3)",
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+
+	testutil.AssertString(t, `{"url":"%{json.escape(req.url)}V","status":%{resp.status}V}`, format)
+}
+
 func TestHTTPSList(t *testing.T) {
 	args := testutil.Args
 	for _, testcase := range []struct {
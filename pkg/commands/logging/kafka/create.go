@@ -1,10 +1,10 @@
 package kafka
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -34,6 +34,7 @@ type CreateCommand struct {
 	TLSClientKey      cmd.OptionalString
 	TLSHostname       cmd.OptionalString
 	Format            cmd.OptionalString
+	FormatJSON        cmd.OptionalString
 	FormatVersion     cmd.OptionalUint
 	Placement         cmd.OptionalString
 	ResponseCondition cmd.OptionalString
@@ -84,6 +85,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("tls-client-key", "The client private key used to make authenticated requests. Must be in PEM format").Action(c.TLSClientKey.Set).StringVar(&c.TLSClientKey.Value)
 	c.CmdClause.Flag("tls-hostname", "The hostname used to verify the server's certificate. It can either be the Common Name or a Subject Alternative Name (SAN)").Action(c.TLSHostname.Set).StringVar(&c.TLSHostname.Value)
 	c.CmdClause.Flag("format", "Apache style log formatting. Your log must produce valid JSON that Kafka can ingest").Action(c.Format.Set).StringVar(&c.Format.Value)
+	logging.RegisterJSONFormatFlag(c.CmdClause, &c.FormatJSON)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (default) or 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed, overriding any format_version default. Can be none or waf_debug").Action(c.Placement.Set).StringVar(&c.Placement.Value)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
@@ -100,12 +102,20 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*fastly.CreateKafkaInput, error) {
 	var input fastly.CreateKafkaInput
 
-	if c.UseSASL.WasSet && c.UseSASL.Value && (c.AuthMethod.Value == "" || c.User.Value == "" || c.Password.Value == "") {
-		return nil, fmt.Errorf("the --auth-method, --username, and --password flags must be present when using the --use-sasl flag")
-	}
-
-	if !c.UseSASL.Value && (c.AuthMethod.Value != "" || c.User.Value != "" || c.Password.Value != "") {
-		return nil, fmt.Errorf("the --auth-method, --username, and --password options are only valid when the --use-sasl flag is specified")
+	err := cmd.CheckFlagRules(
+		cmd.FlagRule{
+			Violated: c.UseSASL.WasSet && c.UseSASL.Value && (c.AuthMethod.Value == "" || c.User.Value == "" || c.Password.Value == ""),
+			Message:  "the --auth-method, --username, and --password flags must be present when using the --use-sasl flag",
+		},
+		cmd.FlagRule{
+			Violated: !c.UseSASL.Value && (c.AuthMethod.Value != "" || c.User.Value != "" || c.Password.Value != ""),
+			Message:  "the --auth-method, --username, and --password options are only valid when the --use-sasl flag is specified",
+		},
+		cmd.FlagOneOf("compression-codec", c.CompressionCodec.WasSet, c.CompressionCodec.Value, "gzip", "snappy", "lz4"),
+		cmd.FlagOneOf("required-acks", c.RequiredACKs.WasSet, c.RequiredACKs.Value, "1", "0", "-1"),
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	input.ServiceID = serviceID
@@ -127,15 +137,27 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	}
 
 	if c.TLSCACert.WasSet {
-		input.TLSCACert = c.TLSCACert.Value
+		content, err := cmd.Content(c.TLSCACert.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.TLSCACert = content
 	}
 
 	if c.TLSClientCert.WasSet {
-		input.TLSClientCert = c.TLSClientCert.Value
+		content, err := cmd.Content(c.TLSClientCert.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.TLSClientCert = content
 	}
 
 	if c.TLSClientKey.WasSet {
-		input.TLSClientKey = c.TLSClientKey.Value
+		content, err := cmd.Content(c.TLSClientKey.Value, c.Globals.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.TLSClientKey = content
 	}
 
 	if c.TLSHostname.WasSet {
@@ -146,6 +168,14 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 		input.Format = c.Format.Value
 	}
 
+	if c.FormatJSON.WasSet {
+		format, err := logging.JSONFormat(c.Format, c.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = format
+	}
+
 	if c.FormatVersion.WasSet {
 		input.FormatVersion = c.FormatVersion.Value
 	}
@@ -186,6 +216,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.AutoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.Manifest,
 		Out:                out,
 		ServiceNameFlag:    c.ServiceName,
@@ -206,6 +237,16 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	err = logging.CheckVCLOnlyFlags(c.Globals.APIClient, serviceID, logging.VCLOnlyFlags{
+		FormatVersion:     c.FormatVersion.WasSet,
+		Placement:         c.Placement.WasSet,
+		ResponseCondition: c.ResponseCondition.WasSet,
+	})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
 	d, err := c.Globals.APIClient.CreateKafka(input)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
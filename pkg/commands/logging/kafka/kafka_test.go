@@ -44,7 +44,7 @@ func TestCreateKafkaInput(t *testing.T) {
 				Topic:             "logs",
 				RequiredACKs:      "-1",
 				UseTLS:            true,
-				CompressionCodec:  "zippy",
+				CompressionCodec:  "snappy",
 				Format:            `%h %l %u %t "%r" %>s %b`,
 				FormatVersion:     2,
 				ResponseCondition: "Prevent default logging",
@@ -178,9 +178,9 @@ func TestUpdateKafkaInput(t *testing.T) {
 				NewName:           fastly.String("new1"),
 				Topic:             fastly.String("new2"),
 				Brokers:           fastly.String("new3"),
-				RequiredACKs:      fastly.String("new4"),
+				RequiredACKs:      fastly.String("0"),
 				UseTLS:            fastly.CBool(false),
-				CompressionCodec:  fastly.String("new5"),
+				CompressionCodec:  fastly.String("lz4"),
 				Placement:         fastly.String("new6"),
 				Format:            fastly.String("new7"),
 				FormatVersion:     fastly.Uint(3),
@@ -415,7 +415,7 @@ func createCommandAll() *kafka.CreateCommand {
 		Brokers:           "127.0.0.1,127.0.0.2",
 		UseTLS:            cmd.OptionalBool{Optional: cmd.Optional{WasSet: true}, Value: true},
 		RequiredACKs:      cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "-1"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zippy"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "snappy"},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: `%h %l %u %t "%r" %>s %b`},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 2},
 		ResponseCondition: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "Prevent default logging"},
@@ -590,8 +590,8 @@ func updateCommandAll() *kafka.UpdateCommand {
 		Topic:             cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new2"},
 		Brokers:           cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new3"},
 		UseTLS:            cmd.OptionalBool{Optional: cmd.Optional{WasSet: true}, Value: false},
-		RequiredACKs:      cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new4"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new5"},
+		RequiredACKs:      cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "0"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "lz4"},
 		Placement:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new6"},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new7"},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
@@ -706,7 +706,7 @@ func getKafkaSASL(i *fastly.GetKafkaInput) (*fastly.Kafka, error) {
 		Topic:             "logs",
 		RequiredACKs:      "-1",
 		UseTLS:            true,
-		CompressionCodec:  "zippy",
+		CompressionCodec:  "snappy",
 		Format:            `%h %l %u %t "%r" %>s %b`,
 		FormatVersion:     2,
 		ResponseCondition: "Prevent default logging",
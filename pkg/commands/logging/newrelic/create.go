@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -33,7 +34,8 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Action: c.autoClone.Set,
 		Dst:    &c.autoClone.Value,
 	})
-	c.CmdClause.Flag("format", "A Fastly log format string. Must produce valid JSON that New Relic Logs can ingest").StringVar(&c.format)
+	c.CmdClause.Flag("format", "A Fastly log format string. Must produce valid JSON that New Relic Logs can ingest").Action(c.format.Set).StringVar(&c.format.Value)
+	logging.RegisterJSONFormatFlag(c.CmdClause, &c.formatJSON)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint").UintVar(&c.formatVersion)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed").StringVar(&c.placement)
 	c.CmdClause.Flag("region", "The region to which to stream logs").StringVar(&c.region)
@@ -59,7 +61,8 @@ type CreateCommand struct {
 	cmd.Base
 
 	autoClone         cmd.OptionalAutoClone
-	format            string
+	format            cmd.OptionalString
+	formatJSON        cmd.OptionalString
 	formatVersion     uint
 	key               string
 	manifest          manifest.Data
@@ -76,6 +79,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -90,7 +94,24 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	err = logging.CheckVCLOnlyFlags(c.Globals.APIClient, serviceID, logging.VCLOnlyFlags{
+		FormatVersion:     c.formatVersion > 0,
+		Placement:         c.placement != "",
+		ResponseCondition: c.responseCondition.WasSet,
+	})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
 	input := c.constructInput(serviceID, serviceVersion.Number)
+	if c.formatJSON.WasSet {
+		input.Format, err = logging.JSONFormat(c.format, c.formatJSON)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
 
 	l, err := c.Globals.APIClient.CreateNewRelic(input)
 	if err != nil {
@@ -114,8 +135,8 @@ func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fa
 	input.ServiceVersion = serviceVersion
 	input.Token = c.key
 
-	if c.format != "" {
-		input.Format = c.format
+	if c.format.WasSet {
+		input.Format = c.format.Value
 	}
 	if c.formatVersion > 0 {
 		input.FormatVersion = c.formatVersion
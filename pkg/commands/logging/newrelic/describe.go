@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -64,13 +65,10 @@ type DescribeCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -129,19 +127,22 @@ func (c *DescribeCommand) print(out io.Writer, nr *fastly.NewRelic) error {
 	fmt.Fprintf(out, "Name: %s\n", nr.Name)
 	fmt.Fprintf(out, "Token: %s\n", nr.Token)
 	fmt.Fprintf(out, "Format: %s\n", nr.Format)
+	if template, ok := logging.ParseJSONFormat(nr.Format); ok {
+		fmt.Fprintf(out, "Format template:\n%s\n", template)
+	}
 	fmt.Fprintf(out, "Format Version: %d\n", nr.FormatVersion)
 	fmt.Fprintf(out, "Placement: %s\n", nr.Placement)
 	fmt.Fprintf(out, "Region: %s\n", nr.Region)
 	fmt.Fprintf(out, "Response Condition: %s\n\n", nr.ResponseCondition)
 
 	if nr.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", nr.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*nr.CreatedAt))
 	}
 	if nr.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", nr.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*nr.UpdatedAt))
 	}
 	if nr.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", nr.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*nr.DeletedAt))
 	}
 	return nil
 }
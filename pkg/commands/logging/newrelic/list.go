@@ -63,13 +63,10 @@ type ListCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -131,13 +128,13 @@ func (c *ListCommand) printVerbose(out io.Writer, serviceVersion int, ls []*fast
 		fmt.Fprintf(out, "\nResponse Condition: %s\n\n", l.ResponseCondition)
 
 		if l.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", l.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*l.CreatedAt))
 		}
 		if l.UpdatedAt != nil {
-			fmt.Fprintf(out, "Updated at: %s\n", l.UpdatedAt)
+			fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*l.UpdatedAt))
 		}
 		if l.DeletedAt != nil {
-			fmt.Fprintf(out, "Deleted at: %s\n", l.DeletedAt)
+			fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*l.DeletedAt))
 		}
 	}
 }
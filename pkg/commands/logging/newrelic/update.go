@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -34,6 +35,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Dst:    &c.autoClone.Value,
 	})
 	c.CmdClause.Flag("format", "A Fastly log format string. Must produce valid JSON that New Relic Logs can ingest").Action(c.format.Set).StringVar(&c.format.Value)
+	logging.RegisterJSONFormatFlag(c.CmdClause, &c.formatJSON)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint").Action(c.formatVersion.Set).UintVar(&c.formatVersion.Value)
 	c.CmdClause.Flag("key", "The Insert API key from the Account page of your New Relic account").Action(c.key.Set).StringVar(&c.key.Value)
 	c.CmdClause.Flag("new-name", "The name for the real-time logging configuration").Action(c.newName.Set).StringVar(&c.newName.Value)
@@ -62,6 +64,7 @@ type UpdateCommand struct {
 
 	autoClone         cmd.OptionalAutoClone
 	format            cmd.OptionalString
+	formatJSON        cmd.OptionalString
 	formatVersion     cmd.OptionalUint
 	key               cmd.OptionalString
 	manifest          manifest.Data
@@ -79,6 +82,7 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -94,6 +98,14 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	input := c.constructInput(serviceID, serviceVersion.Number)
+	if c.formatJSON.WasSet {
+		format, err := logging.JSONFormat(c.format, c.formatJSON)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		input.Format = fastly.String(format)
+	}
 
 	l, err := c.Globals.APIClient.UpdateNewRelic(input)
 	if err != nil {
@@ -124,7 +124,6 @@ func TestUpdateOpenstackInput(t *testing.T) {
 				URL:               fastly.String("new5"),
 				Path:              fastly.String("new6"),
 				Period:            fastly.Uint(3601),
-				GzipLevel:         fastly.Uint(0),
 				Format:            fastly.String("new7"),
 				FormatVersion:     fastly.Uint(3),
 				ResponseCondition: fastly.String("new8"),
@@ -132,7 +131,7 @@ func TestUpdateOpenstackInput(t *testing.T) {
 				TimestampFormat:   fastly.String("new10"),
 				Placement:         fastly.String("new11"),
 				PublicKey:         fastly.String("new12"),
-				CompressionCodec:  fastly.String("new13"),
+				CompressionCodec:  fastly.String("zstd"),
 			},
 		},
 		{
@@ -359,7 +358,6 @@ func updateCommandAll() *openstack.UpdateCommand {
 		URL:               cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new5"},
 		Path:              cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new6"},
 		Period:            cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3601},
-		GzipLevel:         cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 0},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new7"},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
 		ResponseCondition: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new8"},
@@ -367,7 +365,7 @@ func updateCommandAll() *openstack.UpdateCommand {
 		TimestampFormat:   cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new10"},
 		Placement:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new11"},
 		PublicKey:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new12"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new13"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zstd"},
 	}
 }
 
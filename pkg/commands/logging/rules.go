@@ -0,0 +1,17 @@
+package logging
+
+import "github.com/fastly/cli/pkg/cmd"
+
+// CompressionCodecs are the codecs that the file based logging endpoints can
+// compress logs with.
+var CompressionCodecs = []string{"zstd", "snappy", "gzip"}
+
+// CompressionRules returns the rules for the --compression-codec and
+// --gzip-level flags of a file based logging endpoint.
+func CompressionRules(codec cmd.OptionalString, gzipLevelSet bool, gzipLevel uint) []cmd.FlagRule {
+	return []cmd.FlagRule{
+		cmd.ExclusiveFlags("compression-codec", codec.WasSet, "gzip-level", gzipLevelSet),
+		cmd.FlagOneOf("compression-codec", codec.WasSet, codec.Value, CompressionCodecs...),
+		cmd.FlagRange("gzip-level", gzipLevelSet, gzipLevel, 0, 9),
+	}
+}
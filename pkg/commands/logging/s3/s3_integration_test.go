@@ -112,6 +112,25 @@ func TestS3Create(t *testing.T) {
 			},
 			wantError: "error parsing arguments: the --compression-codec flag is mutually exclusive with the --gzip-level flag",
 		},
+		{
+			args: args("logging s3 create --service-id 123 --version 1 --name log --bucket log --iam-role arn:aws:iam::123456789012:role/S3Access --placement none --response-condition errors --autoclone"),
+			api: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				GetServiceFn:   getServiceType("wasm"),
+			},
+			wantError: "--placement, --response-condition only apply to VCL services, and service 123 is a Compute@Edge service",
+		},
+		{
+			args: args("logging s3 create --service-id 123 --version 1 --name log --bucket log --iam-role arn:aws:iam::123456789012:role/S3Access --placement none --autoclone"),
+			api: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				GetServiceFn:   getServiceType("vcl"),
+				CreateS3Fn:     createS3OK,
+			},
+			wantOutput: "Created S3 logging endpoint log (service 123 version 4)",
+		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
 			var stdout bytes.Buffer
@@ -336,6 +355,12 @@ func createS3OK(i *fastly.CreateS3Input) (*fastly.S3, error) {
 	}, nil
 }
 
+func getServiceType(typ string) func(*fastly.GetServiceInput) (*fastly.Service, error) {
+	return func(i *fastly.GetServiceInput) (*fastly.Service, error) {
+		return &fastly.Service{ID: i.ID, Type: typ}, nil
+	}
+}
+
 func createS3Error(i *fastly.CreateS3Input) (*fastly.S3, error) {
 	return nil, errTest
 }
@@ -152,7 +152,6 @@ func TestUpdateS3Input(t *testing.T) {
 				Domain:                       fastly.String("new5"),
 				Path:                         fastly.String("new6"),
 				Period:                       fastly.Uint(3601),
-				GzipLevel:                    fastly.Uint(0),
 				Format:                       fastly.String("new7"),
 				FormatVersion:                fastly.Uint(3),
 				MessageType:                  fastly.String("new8"),
@@ -163,7 +162,7 @@ func TestUpdateS3Input(t *testing.T) {
 				ServerSideEncryption:         fastly.S3ServerSideEncryptionPtr(fastly.S3ServerSideEncryptionKMS),
 				ServerSideEncryptionKMSKeyID: fastly.String("new12"),
 				PublicKey:                    fastly.String("new13"),
-				CompressionCodec:             fastly.String("new14"),
+				CompressionCodec:             fastly.String("zstd"),
 			},
 		},
 		{
@@ -418,7 +417,6 @@ func updateCommandAll() *s3.UpdateCommand {
 		Domain:                       cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new5"},
 		Path:                         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new6"},
 		Period:                       cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3601},
-		GzipLevel:                    cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 0},
 		Format:                       cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new7"},
 		FormatVersion:                cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
 		MessageType:                  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new8"},
@@ -429,7 +427,7 @@ func updateCommandAll() *s3.UpdateCommand {
 		ServerSideEncryption:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: string(fastly.S3ServerSideEncryptionKMS)},
 		ServerSideEncryptionKMSKeyID: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new12"},
 		PublicKey:                    cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new13"},
-		CompressionCodec:             cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new14"},
+		CompressionCodec:             cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zstd"},
 	}
 }
 
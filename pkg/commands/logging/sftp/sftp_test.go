@@ -129,13 +129,12 @@ func TestUpdateSFTPInput(t *testing.T) {
 				Path:              fastly.String("new8"),
 				Period:            fastly.Uint(3601),
 				FormatVersion:     fastly.Uint(3),
-				GzipLevel:         fastly.Uint(0),
 				Format:            fastly.String("new9"),
 				ResponseCondition: fastly.String("new10"),
 				TimestampFormat:   fastly.String("new11"),
 				Placement:         fastly.String("new12"),
 				MessageType:       fastly.String("new13"),
-				CompressionCodec:  fastly.String("new14"),
+				CompressionCodec:  fastly.String("zstd"),
 			},
 		},
 		{
@@ -368,12 +367,11 @@ func updateCommandAll() *sftp.UpdateCommand {
 		Period:            cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3601},
 		Format:            cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new9"},
 		FormatVersion:     cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 3},
-		GzipLevel:         cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 0},
 		ResponseCondition: cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new10"},
 		TimestampFormat:   cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new11"},
 		Placement:         cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new12"},
 		MessageType:       cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new13"},
-		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "new14"},
+		CompressionCodec:  cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "zstd"},
 	}
 }
 
@@ -0,0 +1,208 @@
+package logtail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/text"
+)
+
+// logPrefixWidth is the width of the stream and request ID columns that
+// prefix each log line (see Log.String), used to align indented JSON.
+const logPrefixWidth = 20
+
+// severityKeys are the fields of a JSON log message checked, in order, for its
+// severity.
+var severityKeys = []string{"level", "severity", "lvl"}
+
+// formatter renders log messages for display, detecting messages that are JSON
+// documents so they can be indented, have fields extracted and be colorized
+// by their severity.
+type formatter struct {
+	// pretty indents messages that are JSON objects or arrays.
+	pretty bool
+	// paths are the --jq paths to extract from JSON messages.
+	paths []jqPath
+}
+
+// format returns the display form of l, including its stream and request ID.
+func (f formatter) format(l Log) string {
+	msg := strings.TrimSpace(l.Message)
+	if !isJSON(msg) {
+		return l.String()
+	}
+
+	var v interface{}
+	d := json.NewDecoder(strings.NewReader(msg))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return l.String()
+	}
+
+	switch {
+	case len(f.paths) > 0:
+		values := make([]string, len(f.paths))
+		for i, p := range f.paths {
+			values[i] = jqString(p.eval(v))
+		}
+		l.Message = strings.Join(values, " ")
+	case f.pretty:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(msg), strings.Repeat(" ", logPrefixWidth), "  "); err == nil {
+			l.Message = buf.String()
+		}
+	}
+
+	s := l.String()
+	switch severity(v) {
+	case "error":
+		return text.BoldRed(s)
+	case "warning":
+		return text.BoldYellow(s)
+	}
+	return s
+}
+
+// isJSON reports whether msg is a JSON object or array.
+func isJSON(msg string) bool {
+	if !strings.HasPrefix(msg, "{") && !strings.HasPrefix(msg, "[") {
+		return false
+	}
+	return json.Valid([]byte(msg))
+}
+
+// severity returns "error" or "warning" if the severity field of the decoded
+// JSON message v indicates so, or else an empty string.
+func severity(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	for _, k := range severityKeys {
+		s, ok := m[k].(string)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(s) {
+		case "alert", "crit", "critical", "emerg", "emergency", "err", "error", "fatal", "panic":
+			return "error"
+		case "warn", "warning":
+			return "warning"
+		}
+		return ""
+	}
+	return ""
+}
+
+// jqPath is a path to a value in a JSON document, e.g. .req.headers[0].
+//
+// Each step is either a string (an object key) or an int (an array index).
+type jqPath []interface{}
+
+// parseJQ parses a --jq expression: a comma-separated list of paths, each of
+// which is a sequence of .key, ."quoted key" and [index] steps. A path of
+// just . refers to the whole message.
+func parseJQ(expr string) ([]jqPath, error) {
+	var paths []jqPath
+	for _, s := range strings.Split(expr, ",") {
+		p, err := parseJQPath(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func parseJQPath(s string) (jqPath, error) {
+	if !strings.HasPrefix(s, ".") {
+		return nil, fmt.Errorf("path %q must start with '.'", s)
+	}
+	p := jqPath{}
+	if s == "." {
+		return p, nil
+	}
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".["):
+			s = s[1:]
+		case strings.HasPrefix(s, ".\""):
+			key, err := strconv.QuotedPrefix(s[1:])
+			if err != nil {
+				return nil, fmt.Errorf("unterminated key in %q", s)
+			}
+			k, _ := strconv.Unquote(key)
+			p = append(p, k)
+			s = s[1+len(key):]
+		case strings.HasPrefix(s, "."):
+			end := strings.IndexAny(s[1:], ".[")
+			if end < 0 {
+				end = len(s) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("missing key in %q", s)
+			}
+			p = append(p, s[1:1+end])
+			s = s[1+end:]
+		case strings.HasPrefix(s, "["):
+			end := strings.Index(s, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in %q", s)
+			}
+			i, err := strconv.Atoi(s[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index in %q", s)
+			}
+			p = append(p, i)
+			s = s[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", s)
+		}
+	}
+	return p, nil
+}
+
+// eval returns the value at path p of the decoded JSON document v, or nil if
+// there's no such value. As with jq, negative indexes count from the end of
+// an array.
+func (p jqPath) eval(v interface{}) interface{} {
+	for _, step := range p {
+		switch step := step.(type) {
+		case string:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			v = m[step]
+		case int:
+			a, ok := v.([]interface{})
+			if !ok {
+				return nil
+			}
+			if step < 0 {
+				step += len(a)
+			}
+			if step < 0 || step >= len(a) {
+				return nil
+			}
+			v = a[step]
+		}
+	}
+	return v
+}
+
+// jqString renders v as jq --raw-output would: strings without quotes and
+// anything else as compact JSON.
+func jqString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
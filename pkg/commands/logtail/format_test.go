@@ -0,0 +1,97 @@
+package logtail
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormat(t *testing.T) {
+	jq, err := parseJQ(`.msg, .req.url, .tags[-1], ."x-y", .missing`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		f    formatter
+		msg  string
+		want string
+	}{
+		{
+			name: "text",
+			f:    formatter{pretty: true},
+			msg:  "hello {world}",
+			want: "stdout | 12345678 | hello {world}",
+		},
+		{
+			name: "pretty",
+			f:    formatter{pretty: true},
+			msg:  `{"msg":"hi","n":[1,2]}`,
+			want: "stdout | 12345678 | {\n" +
+				`                      "msg": "hi",` + "\n" +
+				`                      "n": [` + "\n" +
+				"                        1,\n" +
+				"                        2\n" +
+				"                      ]\n" +
+				"                    }",
+		},
+		{
+			name: "not pretty",
+			f:    formatter{},
+			msg:  `{"msg":"hi"}`,
+			want: `stdout | 12345678 | {"msg":"hi"}`,
+		},
+		{
+			name: "jq",
+			f:    formatter{pretty: true, paths: jq},
+			msg:  `{"msg":"hi","req":{"url":"/a"},"tags":["a",{"b":1.50}],"x-y":true}`,
+			want: `stdout | 12345678 | hi /a {"b":1.50} true null`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			have := test.f.format(Log{Stream: "stdout", RequestID: "1234567890", Message: test.msg})
+			if diff := cmp.Diff(test.want, have); diff != "" {
+				t.Errorf("unexpected output (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseJQ(t *testing.T) {
+	paths, err := parseJQ(`., .a.b[2], .[0]."c.d"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []jqPath{{}, {"a", "b", 2}, {0, "c.d"}}
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +have):\n%s", diff)
+	}
+
+	for _, expr := range []string{"a", ".a..b", ".a[x]", ".a[1", `."a`} {
+		if _, err := parseJQ(expr); err == nil {
+			t.Errorf("want error parsing %q", expr)
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	for msg, want := range map[string]string{
+		`{"level":"ERROR"}`:              "error",
+		`{"severity":"warn"}`:            "warning",
+		`{"lvl":"info","level":"fatal"}`: "error",
+		`{"level":"debug"}`:              "",
+		`{"level":3}`:                    "",
+		`["error"]`:                      "",
+	} {
+		var v interface{}
+		if err := json.NewDecoder(strings.NewReader(msg)).Decode(&v); err != nil {
+			t.Fatal(err)
+		}
+		if have := severity(v); have != want {
+			t.Errorf("severity(%s): want %q, have %q", msg, want, have)
+		}
+	}
+}
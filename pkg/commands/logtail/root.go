@@ -17,8 +17,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/fastly/cli/pkg/api/transport"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -35,7 +37,8 @@ type RootCommand struct {
 	cfg         cfg
 	dieCh       chan struct{} // channel to end output/printing
 	doneCh      chan struct{} // channel to signal we've reached the end of the run
-	hClient     *http.Client  // TODO: this will go away when GET is in go-fastly
+	format      formatter
+	hClient     *http.Client // TODO: this will go away when GET is in go-fastly
 	manifest    manifest.Data
 	serviceName cmd.OptionalServiceNameID
 	token       string // TODO: this will go away when GET is in go-fastly
@@ -64,12 +67,14 @@ func NewRootCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.CmdClause.Flag("sort-buffer", "Duration of sort buffer for received logs").Default("1s").DurationVar(&c.cfg.sortBuffer)
 	c.CmdClause.Flag("search-padding", "Time beyond from/to to consider in searches").Default("2s").DurationVar(&c.cfg.searchPadding)
 	c.CmdClause.Flag("stream", "Output: stdout, stderr, both (default)").StringVar(&c.cfg.stream)
+	c.CmdClause.Flag("pretty", "Indent log messages that are JSON objects or arrays (use --no-pretty to print them as received)").Default("true").BoolVar(&c.cfg.pretty)
+	c.CmdClause.Flag("jq", "Print only these comma-separated fields of JSON log messages, e.g. '.level, .req.url, .tags[0]'").StringVar(&c.cfg.jq)
 	return &c
 }
 
 // Exec implements the command interface.
 func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -79,6 +84,17 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 
 	c.Input.ServiceID = serviceID
 
+	c.format.pretty = c.cfg.pretty
+	if c.cfg.jq != "" {
+		c.format.paths, err = parseJQ(c.cfg.jq)
+		if err != nil {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid --jq expression: %w", err),
+				Remediation: "Use paths of object keys and array indexes, e.g. --jq '.level, .req.url, .tags[0]'.",
+			}
+		}
+	}
+
 	c.Input.Kind = fastly.ManagedLoggingInstanceOutput
 	endpoint, _ := c.Globals.Endpoint()
 	c.cfg.path = fmt.Sprintf("%s/service/%s/log_stream/managed/instance_output", endpoint, c.Input.ServiceID)
@@ -87,7 +103,19 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 	c.batchCh = make(chan Batch)
 	c.doneCh = make(chan struct{})
 
+	// Log batches are streamed, so the client has no timeout, but it connects
+	// via the same proxy and CA file as the API client.
+	var rt http.RoundTripper
+	if c.Globals.Transport != nil {
+		rt = c.Globals.Transport
+	}
+	if c.Globals.TraceHTTP() {
+		rt = transport.Diagnostics(rt, true, c.Globals.Diagnostics)
+	}
 	c.hClient = http.DefaultClient
+	if rt != nil {
+		c.hClient = &http.Client{Transport: rt}
+	}
 	c.token, _ = c.Globals.Token()
 
 	// Adjust the from/to times if they are
@@ -109,7 +137,10 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 	// Start tailing the logs.
 	go c.tail(out)
 
-	<-sigs
+	select {
+	case <-sigs:
+	case <-c.Globals.Context.Done():
+	}
 	close(c.dieCh)
 
 	return nil
@@ -421,13 +452,13 @@ func (c *RootCommand) outputLoop(out io.Writer) {
 }
 
 // printLogs is a simple printer for Log slices, only printing requested
-// streams.
+// streams. JSON messages are formatted as requested by --pretty and --jq.
 func (c *RootCommand) printLogs(out io.Writer, logs []Log) {
 	if len(logs) > 0 {
 		filtered := filterStream(c.cfg.stream, logs)
 
 		for _, l := range filtered {
-			fmt.Fprintln(out, l.String())
+			fmt.Fprintln(out, c.format.format(l))
 		}
 	}
 }
@@ -473,6 +504,10 @@ type (
 		// customer wants to consume.
 		// Undefined == both stderr and stdout.
 		stream string
+		// pretty indents JSON messages.
+		pretty bool
+		// jq is the expression of the fields to print of JSON messages.
+		jq string
 	}
 
 	// Log defines the message envelope that compute@edge (C@E) wraps the
@@ -0,0 +1,138 @@
+package maintenance
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// DisableCommand calls the Fastly API to stop serving a maintenance page.
+type DisableCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+}
+
+// NewDisableCommand returns a usable command registered under the parent.
+func NewDisableCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DisableCommand {
+	var c DisableCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("disable", "Activate a new service version without the maintenance page")
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+//
+// NOTE: The maintenance page is removed from a clone of the active version
+// rather than reactivating the version it was enabled from, so that changes
+// made during maintenance are kept.
+func (c *DisableCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	active, err := activeVersion(c.Globals.APIClient, serviceID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+	on, err := enabled(c.Globals.APIClient, serviceID, active.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": active.Number,
+		})
+		return err
+	}
+	if !on {
+		return fmt.Errorf("maintenance mode isn't enabled for service %s (version %d)", serviceID, active.Number)
+	}
+
+	v, err := c.Globals.APIClient.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: active.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": active.Number,
+		})
+		return fmt.Errorf("error cloning service version: %w", err)
+	}
+
+	err = c.configure(serviceID, v.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": v.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Disabled maintenance mode for service %s (version %d)", serviceID, v.Number)
+	return nil
+}
+
+// configure removes the maintenance page from the service version and
+// activates it.
+func (c *DisableCommand) configure(serviceID string, version int) error {
+	_, err := c.Globals.APIClient.UpdateVersion(&fastly.UpdateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Comment:        fastly.String("Maintenance mode disabled"),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Globals.APIClient.DeleteResponseObject(&fastly.DeleteResponseObjectInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting maintenance response: %w", err)
+	}
+
+	err = c.Globals.APIClient.DeleteCondition(&fastly.DeleteConditionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting maintenance condition: %w", err)
+	}
+
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return fmt.Errorf("error activating service version %d: %w", version, err)
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package maintenance contains commands to put a Fastly service into, and take
+// it out of, maintenance mode.
+package maintenance
@@ -0,0 +1,191 @@
+package maintenance
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// defaultPage is served when no --message-file is given.
+const defaultPage = `<!DOCTYPE html>
+<html>
+<head><title>Down for maintenance</title></head>
+<body>
+<h1>Down for maintenance</h1>
+<p>We're performing scheduled maintenance and will be back shortly.</p>
+</body>
+</html>
+`
+
+// EnableCommand calls the Fastly API to serve a maintenance page.
+type EnableCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+
+	contentType string
+	messageFile string
+	status      uint
+}
+
+// NewEnableCommand returns a usable command registered under the parent.
+func NewEnableCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *EnableCommand {
+	var c EnableCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("enable", "Activate a new service version that responds to every request with a maintenance page")
+	c.CmdClause.Flag("content-type", "The Content-Type of the maintenance page").Default("text/html").StringVar(&c.contentType)
+	c.CmdClause.Flag("message-file", "Path to the maintenance page to serve (defaults to a simple HTML page)").StringVar(&c.messageFile)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("status", "The HTTP status code of the maintenance page").Default("503").UintVar(&c.status)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+//
+// The active version is cloned, and a response object served under a
+// condition that matches every request is added to the clone, which is then
+// activated.
+func (c *EnableCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	content := defaultPage
+	if c.messageFile != "" {
+		data, err := os.ReadFile(filepath.Clean(c.messageFile))
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Message File": c.messageFile,
+			})
+			return fmt.Errorf("error reading maintenance page: %w", err)
+		}
+		content = string(data)
+	}
+
+	active, err := activeVersion(c.Globals.APIClient, serviceID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+	on, err := enabled(c.Globals.APIClient, serviceID, active.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": active.Number,
+		})
+		return err
+	}
+	if on {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("maintenance mode is already enabled for service %s (version %d)", serviceID, active.Number),
+			Remediation: "Run 'fastly maintenance disable' to serve the service's content again.",
+		}
+	}
+
+	label := fmt.Sprintf("Every request to service %s will receive the maintenance page. Are you sure? [y/N] ", serviceID)
+	cont, err := cmd.Confirm(cmd.ConfirmDestructive, label, c.Globals, in, out)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return fsterr.ErrConfirmationDeclined
+	}
+
+	v, err := c.Globals.APIClient.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: active.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": active.Number,
+		})
+		return fmt.Errorf("error cloning service version: %w", err)
+	}
+
+	err = c.configure(serviceID, v.Number, content)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": v.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Enabled maintenance mode for service %s (version %d)", serviceID, v.Number)
+	text.Info(out, "Run 'fastly maintenance disable' to serve the service's content again.")
+	return nil
+}
+
+// configure adds the maintenance page to the service version and activates it.
+func (c *EnableCommand) configure(serviceID string, version int, content string) error {
+	_, err := c.Globals.APIClient.UpdateVersion(&fastly.UpdateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Comment:        fastly.String("Maintenance mode enabled"),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Globals.APIClient.CreateCondition(&fastly.CreateConditionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           Name,
+		Statement:      "true",
+		Type:           "REQUEST",
+	})
+	if err != nil {
+		return fmt.Errorf("error creating maintenance condition: %w", err)
+	}
+
+	_, err = c.Globals.APIClient.CreateResponseObject(&fastly.CreateResponseObjectInput{
+		ServiceID:        serviceID,
+		ServiceVersion:   version,
+		Name:             Name,
+		Status:           fastly.Uint(c.status),
+		Response:         http.StatusText(int(c.status)),
+		Content:          content,
+		ContentType:      c.contentType,
+		RequestCondition: Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating maintenance response: %w", err)
+	}
+
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return fmt.Errorf("error activating service version %d: %w", version, err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package maintenance_test
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/maintenance"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestMaintenanceEnable(t *testing.T) {
+	page := filepath.Join(t.TempDir(), "page.html")
+	if err := os.WriteFile(page, []byte("<h1>Back soon</h1>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var created *fastly.CreateResponseObjectInput
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --service-id flag",
+			Args:      args("maintenance enable"),
+			WantError: "error reading service: no service ID found",
+		},
+		{
+			Name: "validate already enabled",
+			Args: args("maintenance enable --service-id 123"),
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetResponseObjectFn: getResponseObjectOK,
+			},
+			WantError: "maintenance mode is already enabled for service 123 (version 1)",
+		},
+		{
+			Name: "validate confirmation is required",
+			Args: args("maintenance enable --service-id 123 --non-interactive"),
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetResponseObjectFn: getResponseObjectNotFound,
+			},
+			WantError: "destructive operation requires confirmation",
+		},
+		{
+			Name:      "validate missing --message-file",
+			Args:      args("maintenance enable --service-id 123 --message-file " + filepath.Join(t.TempDir(), "missing.html")),
+			WantError: "error reading maintenance page",
+		},
+		{
+			Name: "validate CreateResponseObject API error",
+			Args: args("maintenance enable --service-id 123 --confirm-destructive"),
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				GetResponseObjectFn:    getResponseObjectNotFound,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				UpdateVersionFn:        updateVersionOK,
+				CreateConditionFn:      createConditionOK,
+				CreateResponseObjectFn: createResponseObjectError,
+			},
+			WantError: "error creating maintenance response: " + testutil.Err.Error(),
+		},
+		{
+			Name: "validate maintenance page is enabled",
+			Args: args("maintenance enable --service-id 123 --confirm-destructive --message-file " + page),
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetResponseObjectFn: getResponseObjectNotFound,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				UpdateVersionFn:     updateVersionOK,
+				CreateConditionFn:   createConditionOK,
+				CreateResponseObjectFn: func(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+					created = i
+					return &fastly.ResponseObject{Name: i.Name}, nil
+				},
+				ActivateVersionFn: activateVersionOK,
+			},
+			WantOutput: "Enabled maintenance mode for service 123 (version 4)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+
+	if created == nil {
+		t.Fatal("expected a response object to be created")
+	}
+	testutil.AssertString(t, maintenance.Name, created.RequestCondition)
+	testutil.AssertString(t, "<h1>Back soon</h1>", created.Content)
+	testutil.AssertString(t, "Service Unavailable", created.Response)
+	testutil.AssertEqual(t, uint(http.StatusServiceUnavailable), *created.Status)
+}
+
+func TestMaintenanceDisable(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate not enabled",
+			Args: args("maintenance disable --service-id 123"),
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetResponseObjectFn: getResponseObjectNotFound,
+			},
+			WantError: "maintenance mode isn't enabled for service 123 (version 1)",
+		},
+		{
+			Name: "validate DeleteCondition API error",
+			Args: args("maintenance disable --service-id 123"),
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				GetResponseObjectFn:    getResponseObjectOK,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				UpdateVersionFn:        updateVersionOK,
+				DeleteResponseObjectFn: deleteResponseObjectOK,
+				DeleteConditionFn:      deleteConditionError,
+			},
+			WantError: "error deleting maintenance condition: " + testutil.Err.Error(),
+		},
+		{
+			Name: "validate maintenance page is disabled",
+			Args: args("maintenance disable --service-id 123"),
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				GetResponseObjectFn:    getResponseObjectOK,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				UpdateVersionFn:        updateVersionOK,
+				DeleteResponseObjectFn: deleteResponseObjectOK,
+				DeleteConditionFn:      deleteConditionOK,
+				ActivateVersionFn:      activateVersionOK,
+			},
+			WantOutput: "Disabled maintenance mode for service 123 (version 4)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func getResponseObjectOK(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error) {
+	return &fastly.ResponseObject{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+	}, nil
+}
+
+func getResponseObjectNotFound(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error) {
+	return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+}
+
+func updateVersionOK(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+	return &fastly.Version{
+		ServiceID: i.ServiceID,
+		Number:    i.ServiceVersion,
+		Comment:   *i.Comment,
+	}, nil
+}
+
+func createConditionOK(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	return &fastly.Condition{
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Name:           i.Name,
+		Statement:      i.Statement,
+		Type:           i.Type,
+	}, nil
+}
+
+func createResponseObjectError(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	return nil, testutil.Err
+}
+
+func deleteResponseObjectOK(i *fastly.DeleteResponseObjectInput) error {
+	return nil
+}
+
+func deleteConditionOK(i *fastly.DeleteConditionInput) error {
+	return nil
+}
+
+func deleteConditionError(i *fastly.DeleteConditionInput) error {
+	return testutil.Err
+}
+
+func activateVersionOK(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion}, nil
+}
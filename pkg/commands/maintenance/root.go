@@ -0,0 +1,68 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Name is the name of the condition and response object that serve the
+// maintenance page.
+const Name = "fastly-cli-maintenance"
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	// no flags
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("maintenance", "Serve a maintenance page in place of a Fastly service's content")
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	panic("unreachable")
+}
+
+// activeVersion returns the active version of the service.
+func activeVersion(client api.Interface, serviceID string) (*fastly.Version, error) {
+	versions, err := client.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	v, err := cmd.GetActiveVersion(versions)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the active version of service %s: %w", serviceID, err)
+	}
+	return v, nil
+}
+
+// enabled reports whether the service version serves the maintenance page.
+func enabled(client api.Interface, serviceID string, version int) (bool, error) {
+	_, err := client.GetResponseObject(&fastly.GetResponseObjectInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           Name,
+	})
+	var httpErr *fastly.HTTPError
+	if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,198 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The formats that checks can be generated in.
+const (
+	FormatTerraform = "terraform"
+	FormatCheckly   = "checkly"
+	FormatBlackbox  = "blackbox"
+)
+
+// Formats are the formats accepted by the --format flag.
+var Formats = []string{FormatTerraform, FormatCheckly, FormatBlackbox}
+
+// Check is an uptime check of one of a service's domains.
+type Check struct {
+	Domain string
+	URL    string
+}
+
+// Options are the settings shared by every check.
+type Options struct {
+	ServiceID      string
+	Interval       time.Duration
+	ExpectedStatus int
+	// Exporter is the address of the Prometheus blackbox exporter.
+	Exporter string
+}
+
+// Render returns the checks in the given format.
+func Render(format string, checks []Check, opts Options) (string, error) {
+	switch format {
+	case FormatTerraform:
+		return Terraform(checks, opts), nil
+	case FormatCheckly:
+		return Checkly(checks, opts)
+	case FormatBlackbox:
+		return Blackbox(checks, opts)
+	}
+	return "", fmt.Errorf("unsupported format '%s'", format)
+}
+
+var unsafeIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Terraform returns the checks as Datadog synthetic tests, for the Datadog
+// Terraform provider.
+func Terraform(checks []Check, opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Uptime checks for the domains of Fastly service %s, generated by 'fastly monitor generate'.\n", opts.ServiceID)
+	for _, c := range checks {
+		fmt.Fprintf(&b, `
+resource "datadog_synthetics_test" "%s" {
+  name    = %s
+  type    = "api"
+  subtype = "http"
+  status  = "live"
+  tags    = ["fastly_service:%s"]
+
+  locations = ["aws:us-east-1", "aws:eu-west-1", "aws:ap-northeast-1"]
+
+  request_definition {
+    method = "GET"
+    url    = %s
+  }
+
+  assertion {
+    type     = "statusCode"
+    operator = "is"
+    target   = "%d"
+  }
+
+  options_list {
+    tick_every = %d
+  }
+}
+`, unsafeIdentifier.ReplaceAllString(c.Domain, "_"), strconv.Quote(c.Domain), opts.ServiceID, strconv.Quote(c.URL), opts.ExpectedStatus, int(opts.Interval.Seconds()))
+	}
+	return b.String()
+}
+
+// ChecklyCheck is an API check, as accepted by the Checkly API.
+type ChecklyCheck struct {
+	Name      string         `json:"name"`
+	CheckType string         `json:"checkType"`
+	Activated bool           `json:"activated"`
+	Frequency int            `json:"frequency"`
+	Locations []string       `json:"locations"`
+	Tags      []string       `json:"tags"`
+	Request   ChecklyRequest `json:"request"`
+}
+
+// ChecklyRequest is the request made by a Checkly API check.
+type ChecklyRequest struct {
+	Method          string             `json:"method"`
+	URL             string             `json:"url"`
+	FollowRedirects bool               `json:"followRedirects"`
+	Assertions      []ChecklyAssertion `json:"assertions"`
+}
+
+// ChecklyAssertion is an assertion about the response to a Checkly check.
+type ChecklyAssertion struct {
+	Source     string `json:"source"`
+	Comparison string `json:"comparison"`
+	Target     string `json:"target"`
+}
+
+// Checkly returns the checks as a JSON array of Checkly API checks.
+func Checkly(checks []Check, opts Options) (string, error) {
+	// Checkly's frequency is in whole minutes.
+	frequency := int((opts.Interval + time.Minute - 1) / time.Minute)
+	cs := []ChecklyCheck{}
+	for _, c := range checks {
+		cs = append(cs, ChecklyCheck{
+			Name:      c.Domain,
+			CheckType: "API",
+			Activated: true,
+			Frequency: frequency,
+			Locations: []string{"us-east-1", "eu-west-1", "ap-northeast-1"},
+			Tags:      []string{"fastly", "fastly_service:" + opts.ServiceID},
+			Request: ChecklyRequest{
+				Method: "GET",
+				URL:    c.URL,
+				Assertions: []ChecklyAssertion{
+					{Source: "STATUS_CODE", Comparison: "EQUALS", Target: strconv.Itoa(opts.ExpectedStatus)},
+				},
+			},
+		})
+	}
+	data, err := json.MarshalIndent(cs, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+type blackboxConfig struct {
+	ScrapeConfigs []blackboxScrape `yaml:"scrape_configs"`
+}
+
+type blackboxScrape struct {
+	JobName        string              `yaml:"job_name"`
+	ScrapeInterval string              `yaml:"scrape_interval"`
+	MetricsPath    string              `yaml:"metrics_path"`
+	Params         map[string][]string `yaml:"params"`
+	StaticConfigs  []blackboxTargets   `yaml:"static_configs"`
+	RelabelConfigs []blackboxRelabel   `yaml:"relabel_configs"`
+}
+
+type blackboxTargets struct {
+	Targets []string          `yaml:"targets"`
+	Labels  map[string]string `yaml:"labels"`
+}
+
+type blackboxRelabel struct {
+	SourceLabels []string `yaml:"source_labels,omitempty"`
+	TargetLabel  string   `yaml:"target_label"`
+	Replacement  string   `yaml:"replacement,omitempty"`
+}
+
+// Blackbox returns the checks as a Prometheus scrape configuration that
+// probes each URL via the blackbox exporter's http_2xx module.
+func Blackbox(checks []Check, opts Options) (string, error) {
+	targets := []string{}
+	for _, c := range checks {
+		targets = append(targets, c.URL)
+	}
+	cfg := blackboxConfig{
+		ScrapeConfigs: []blackboxScrape{{
+			JobName:        "fastly-" + opts.ServiceID,
+			ScrapeInterval: fmt.Sprintf("%ds", int(opts.Interval.Seconds())),
+			MetricsPath:    "/probe",
+			Params:         map[string][]string{"module": {"http_2xx"}},
+			StaticConfigs: []blackboxTargets{{
+				Targets: targets,
+				Labels:  map[string]string{"fastly_service": opts.ServiceID},
+			}},
+			RelabelConfigs: []blackboxRelabel{
+				{SourceLabels: []string{"__address__"}, TargetLabel: "__param_target"},
+				{SourceLabels: []string{"__param_target"}, TargetLabel: "instance"},
+				{TargetLabel: "__address__", Replacement: opts.Exporter},
+			},
+		}},
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("# Uptime checks for the domains of Fastly service %s, generated by 'fastly monitor generate'.\n%s", opts.ServiceID, data), nil
+}
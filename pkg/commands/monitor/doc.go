@@ -0,0 +1,3 @@
+// Package monitor contains commands to generate the configuration of external
+// monitoring for a Fastly service.
+package monitor
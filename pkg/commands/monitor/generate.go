@@ -0,0 +1,139 @@
+package monitor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// GenerateCommand generates an uptime check for each domain of a service.
+type GenerateCommand struct {
+	cmd.Base
+
+	exporter       string
+	expectedStatus int
+	format         string
+	interval       time.Duration
+	manifest       manifest.Data
+	path           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewGenerateCommand returns a usable command registered under the parent.
+func NewGenerateCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *GenerateCommand {
+	var c GenerateCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("generate", "Generate an uptime check for each domain of a service version, for an external monitoring tool")
+	c.CmdClause.Flag("blackbox-exporter", "Address of the Prometheus blackbox exporter (blackbox format only)").Default("127.0.0.1:9115").StringVar(&c.exporter)
+	c.CmdClause.Flag("expected-status", "HTTP status the checks expect (not used by the blackbox format, whose http_2xx module accepts any 2xx)").Default("200").IntVar(&c.expectedStatus)
+	c.CmdClause.Flag("format", "Format of the checks: terraform (Datadog synthetic tests), checkly (Checkly API checks) or blackbox (Prometheus scrape config)").Default(FormatTerraform).EnumVar(&c.format, Formats...)
+	c.CmdClause.Flag("interval", "How often the checks run").Default("1m").Action(cmd.Validate(cmd.ValidateDuration)).DurationVar(&c.interval)
+	c.CmdClause.Flag("path", "Path requested by the checks").Default("/").StringVar(&c.path)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *GenerateCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	domains, err := c.Globals.APIClient.ListDomains(&fastly.ListDomainsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	path := c.path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	var checks []Check
+	var wildcards []string
+	for _, d := range domains {
+		// A wildcard domain has no single hostname to check.
+		if strings.Contains(d.Name, "*") {
+			wildcards = append(wildcards, d.Name)
+			continue
+		}
+		checks = append(checks, Check{Domain: d.Name, URL: "https://" + d.Name + path})
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Domain < checks[j].Domain
+	})
+	if len(checks) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("service %s version %d has no domains to check", serviceID, serviceVersion.Number),
+			Remediation: "Add a domain with 'fastly domain create', or use --version to select another version.",
+		}
+	}
+	// The warning isn't written to out, which may be redirected to a
+	// configuration file.
+	if len(wildcards) > 0 {
+		text.Warning(c.Globals.Diagnostics, "Skipped wildcard domains: %s", strings.Join(wildcards, ", "))
+	}
+
+	s, err := Render(c.format, checks, Options{
+		ServiceID:      serviceID,
+		Interval:       c.interval,
+		ExpectedStatus: c.expectedStatus,
+		Exporter:       c.exporter,
+	})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	fmt.Fprint(out, s)
+	return nil
+}
@@ -0,0 +1,57 @@
+package monitor_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestMonitorGenerate(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListDomainsFn: func(*fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+			return []*fastly.Domain{
+				{Name: "www.example.com"},
+				{Name: "*.example.com"},
+				{Name: "api.example.com"},
+			}, nil
+		},
+	}
+
+	testutil.RunScenarios(t, []testutil.TestScenario{
+		{
+			Name:      "validate invalid --format",
+			Args:      args("monitor generate --service-id 123 --format nagios"),
+			WantError: "error parsing arguments: enum value must be one of terraform,checkly,blackbox, got 'nagios'",
+		},
+		{
+			Name: "validate no domains",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListDomainsFn: func(*fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+					return []*fastly.Domain{{Name: "*.example.com"}}, nil
+				},
+			},
+			Args:      args("monitor generate --service-id 123"),
+			WantError: "service 123 version 1 has no domains to check",
+		},
+	})
+
+	for _, format := range []string{"terraform", "checkly", "blackbox"} {
+		t.Run(format, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			opts := testutil.NewRunOpts(args("monitor generate --service-id 123 --path health --interval 5m --format "+format), &stdout)
+			opts.Stderr = &stderr
+			opts.APIClient = mock.APIClient(api)
+			testutil.AssertNoError(t, app.Run(opts))
+			testutil.AssertGolden(t, filepath.Join("testdata", format+".golden"), stdout.String())
+			testutil.AssertStringContains(t, stderr.String(), "Skipped wildcard domains: *.example.com")
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package origin contains commands to change which backends serve a Fastly
+// service's requests.
+package origin
@@ -0,0 +1,210 @@
+package origin_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/origin"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestOriginSwitch(t *testing.T) {
+	var (
+		conditions []*fastly.CreateConditionInput
+		updates    = make(map[string]string)
+	)
+	createCondition := func(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+		conditions = append(conditions, i)
+		return &fastly.Condition{Name: i.Name, Statement: i.Statement}, nil
+	}
+	updateBackend := func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+		updates[i.Name] = *i.RequestCondition
+		return &fastly.Backend{Name: i.Name, RequestCondition: *i.RequestCondition}, nil
+	}
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --to flag",
+			Args:      args("origin switch --service-id 123 --from blue"),
+			WantError: "error parsing arguments: required flag --to not provided",
+		},
+		{
+			Name:      "validate same backend",
+			Args:      args("origin switch --service-id 123 --from blue --to blue"),
+			WantError: "--from and --to are the same backend",
+		},
+		{
+			Name:      "validate invalid --canary",
+			Args:      args("origin switch --service-id 123 --from blue --to green --canary 100"),
+			WantError: "invalid --canary 100",
+		},
+		{
+			Name: "validate missing backend",
+			Args: args("origin switch --service-id 123 --from blue --to purple"),
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn:   getBackend,
+			},
+			WantError: "error getting backend 'purple'",
+		},
+		{
+			Name: "validate confirmation is required",
+			Args: args("origin switch --service-id 123 --from blue --to green --non-interactive"),
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				GetBackendFn:         getBackend,
+				ListDirectorsFn:      listDirectorsOK,
+				GetDirectorBackendFn: getDirectorBackend("blue"),
+			},
+			WantError: "destructive operation requires confirmation",
+		},
+		{
+			Name: "validate --canary with a director",
+			Args: args("origin switch --service-id 123 --from blue --to green --canary 10"),
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				GetBackendFn:         getBackend,
+				ListDirectorsFn:      listDirectorsOK,
+				GetDirectorBackendFn: getDirectorBackend("blue"),
+			},
+			WantError: "--canary isn't supported for backend 'blue' as it belongs to a director",
+		},
+		{
+			Name: "validate director switch",
+			Args: args("origin switch --service-id 123 --from blue --to green --confirm-destructive"),
+			API: mock.API{
+				ListVersionsFn:          testutil.ListVersions,
+				GetBackendFn:            getBackend,
+				ListDirectorsFn:         listDirectorsOK,
+				GetDirectorBackendFn:    getDirectorBackend("blue"),
+				CloneVersionFn:          testutil.CloneVersionResult(4),
+				CreateDirectorBackendFn: createDirectorBackendOK,
+				DeleteDirectorBackendFn: deleteDirectorBackendOK,
+				ActivateVersionFn:       activateVersionOK,
+			},
+			WantOutput: "Routed the requests for backend blue to backend green (service 123 version 4)",
+		},
+		{
+			Name: "validate condition switch",
+			Args: args("origin switch --service-id 123 --from blue --to green --confirm-destructive"),
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetBackendFn:      getBackend,
+				ListDirectorsFn:   listDirectorsNone,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				GetConditionFn:    getCondition,
+				CreateConditionFn: createCondition,
+				UpdateBackendFn:   updateBackend,
+				DeleteConditionFn: deleteConditionNotFound,
+				ActivateVersionFn: activateVersionOK,
+			},
+			WantOutput: "Routed the requests for backend blue to backend green (service 123 version 4)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+
+	testutil.AssertEqual(t, map[string]string{"green": "is-api", "blue": origin.OffCondition}, updates)
+	testutil.AssertEqual(t, 1, len(conditions))
+	testutil.AssertString(t, "false", conditions[0].Statement)
+}
+
+func TestOriginSwitchCanary(t *testing.T) {
+	var condition *fastly.CreateConditionInput
+	var updated *fastly.UpdateBackendInput
+
+	var stdout bytes.Buffer
+	args := testutil.Args("origin switch --service-id 123 --from blue --to green --canary 10 --confirm-destructive")
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn:  testutil.ListVersions,
+		GetBackendFn:    getBackend,
+		ListDirectorsFn: listDirectorsNone,
+		CloneVersionFn:  testutil.CloneVersionResult(4),
+		GetConditionFn:  getCondition,
+		CreateConditionFn: func(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+			condition = i
+			return &fastly.Condition{Name: i.Name, Statement: i.Statement}, nil
+		},
+		UpdateBackendFn: func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+			updated = i
+			return &fastly.Backend{Name: i.Name}, nil
+		},
+		ActivateVersionFn: activateVersionOK,
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Routed 10% of the requests for backend blue to backend green (service 123 version 4)")
+
+	testutil.AssertString(t, origin.CanaryCondition, condition.Name)
+	testutil.AssertString(t, `(req.url ~ "^/api/") && randombool(10, 100)`, condition.Statement)
+	testutil.AssertEqual(t, 11, *condition.Priority)
+	testutil.AssertString(t, "green", updated.Name)
+	testutil.AssertString(t, origin.CanaryCondition, *updated.RequestCondition)
+}
+
+func getBackend(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+	switch i.Name {
+	case "blue":
+		return &fastly.Backend{Name: i.Name, RequestCondition: "is-api"}, nil
+	case "green":
+		return &fastly.Backend{Name: i.Name}, nil
+	}
+	return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+}
+
+func getCondition(i *fastly.GetConditionInput) (*fastly.Condition, error) {
+	if i.Name == "is-api" {
+		return &fastly.Condition{Name: i.Name, Statement: `req.url ~ "^/api/"`, Priority: 10}, nil
+	}
+	return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+}
+
+func deleteConditionNotFound(i *fastly.DeleteConditionInput) error {
+	return &fastly.HTTPError{StatusCode: http.StatusNotFound}
+}
+
+func listDirectorsOK(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+	return []*fastly.Director{{Name: "pool"}}, nil
+}
+
+func listDirectorsNone(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+	return nil, nil
+}
+
+// getDirectorBackend returns a GetDirectorBackendFn where only the named
+// backend belongs to the director.
+func getDirectorBackend(member string) func(*fastly.GetDirectorBackendInput) (*fastly.DirectorBackend, error) {
+	return func(i *fastly.GetDirectorBackendInput) (*fastly.DirectorBackend, error) {
+		if i.Backend != member {
+			return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+		}
+		return &fastly.DirectorBackend{Director: i.Director, Backend: i.Backend}, nil
+	}
+}
+
+func createDirectorBackendOK(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+	return &fastly.DirectorBackend{Director: i.Director, Backend: i.Backend}, nil
+}
+
+func deleteDirectorBackendOK(i *fastly.DeleteDirectorBackendInput) error {
+	return nil
+}
+
+func activateVersionOK(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion}, nil
+}
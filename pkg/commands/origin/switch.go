@@ -0,0 +1,360 @@
+package origin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const (
+	// CanaryCondition is the name of the request condition that routes a
+	// percentage of requests to the new backend during a canary switch.
+	CanaryCondition = "fastly-cli-origin-canary"
+
+	// OffCondition is the name of the request condition, which never matches,
+	// that's attached to the old backend after a switch.
+	OffCondition = "fastly-cli-origin-off"
+)
+
+// SwitchCommand routes the requests served by one backend to another.
+type SwitchCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+
+	canary int
+	from   string
+	to     string
+}
+
+// NewSwitchCommand returns a usable command registered under the parent.
+func NewSwitchCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *SwitchCommand {
+	var c SwitchCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("switch", "Activate a new service version that routes the requests for one backend to another")
+	c.SetMutating()
+	c.CmdClause.Flag("canary", "Only route this percentage of the requests to the new backend (1-99)").IntVar(&c.canary)
+	c.CmdClause.Flag("from", "Name of the backend that currently serves the requests").Required().StringVar(&c.from)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("to", "Name of the backend to route the requests to").Required().StringVar(&c.to)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+//
+// If the old backend belongs to directors then the new backend replaces it in
+// each of them. Otherwise the new backend takes the old backend's request
+// condition, and the old backend is given a condition that never matches.
+//
+// A canary switch instead gives the new backend a condition that matches the
+// percentage of the requests that the old backend's condition matches. Running
+// the command again without --canary completes the switch.
+func (c *SwitchCommand) Exec(in io.Reader, out io.Writer) (err error) {
+	if c.canary < 0 || c.canary > 99 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --canary %d", c.canary),
+			Remediation: "Set --canary to a whole number between 1 and 99, or omit it to route all of the requests.",
+		}
+	}
+	if c.from == c.to {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--from and --to are the same backend"),
+			Remediation: "Set --to to the name of the backend to route the requests to.",
+		}
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	defer func() {
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": serviceID,
+				"From":       c.from,
+				"To":         c.to,
+			})
+		}
+	}()
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return err
+	}
+	active, err := cmd.GetActiveVersion(versions)
+	if err != nil {
+		return fmt.Errorf("error finding the active version of service %s: %w", serviceID, err)
+	}
+
+	from, err := c.getBackend(serviceID, active.Number, c.from)
+	if err != nil {
+		return err
+	}
+	if _, err = c.getBackend(serviceID, active.Number, c.to); err != nil {
+		return err
+	}
+
+	directors, err := c.directors(serviceID, active.Number)
+	if err != nil {
+		return err
+	}
+	if len(directors) > 0 && c.canary > 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--canary isn't supported for backend '%s' as it belongs to a director", c.from),
+			Remediation: "Omit --canary to replace the backend in its directors.",
+		}
+	}
+
+	if c.canary > 0 {
+		text.Output(out, "Routing %d%% of the requests for backend '%s' to backend '%s'", c.canary, c.from, c.to)
+	} else {
+		text.Output(out, "Routing the requests for backend '%s' to backend '%s'", c.from, c.to)
+	}
+	cont, err := cmd.Confirm(cmd.ConfirmDestructive, "Are you sure you want to activate the change? [y/N] ", c.Globals, in, out)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return fsterr.ErrConfirmationDeclined
+	}
+
+	v, err := c.Globals.APIClient.CloneVersion(&fastly.CloneVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: active.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error cloning service version: %w", err)
+	}
+
+	switch {
+	case len(directors) > 0:
+		err = c.switchDirectors(serviceID, v.Number, directors)
+	case c.canary > 0:
+		err = c.switchCanary(serviceID, v.Number, from)
+	default:
+		err = c.switchConditions(serviceID, v.Number, from)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: v.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error activating service version %d: %w", v.Number, err)
+	}
+
+	if c.canary > 0 {
+		text.Success(out, "Routed %d%% of the requests for backend %s to backend %s (service %s version %d)", c.canary, c.from, c.to, serviceID, v.Number)
+		text.Info(out, "Run the command again without --canary to route all of the requests to backend %s.", c.to)
+		return nil
+	}
+	text.Success(out, "Routed the requests for backend %s to backend %s (service %s version %d)", c.from, c.to, serviceID, v.Number)
+	return nil
+}
+
+func (c *SwitchCommand) getBackend(serviceID string, version int, name string) (*fastly.Backend, error) {
+	b, err := c.Globals.APIClient.GetBackend(&fastly.GetBackendInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting backend '%s': %w", name, err)
+	}
+	return b, nil
+}
+
+// directors returns the names of the directors that the old backend belongs to.
+func (c *SwitchCommand) directors(serviceID string, version int) ([]string, error) {
+	ds, err := c.Globals.APIClient.ListDirectors(&fastly.ListDirectorsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing directors: %w", err)
+	}
+	var names []string
+	for _, d := range ds {
+		ok, err := c.inDirector(serviceID, version, d.Name, c.from)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			names = append(names, d.Name)
+		}
+	}
+	return names, nil
+}
+
+func (c *SwitchCommand) inDirector(serviceID string, version int, director, backend string) (bool, error) {
+	_, err := c.Globals.APIClient.GetDirectorBackend(&fastly.GetDirectorBackendInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Director:       director,
+		Backend:        backend,
+	})
+	var httpErr *fastly.HTTPError
+	if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error getting backend '%s' of director '%s': %w", backend, director, err)
+	}
+	return true, nil
+}
+
+// switchDirectors replaces the old backend with the new one in each director.
+func (c *SwitchCommand) switchDirectors(serviceID string, version int, directors []string) error {
+	for _, d := range directors {
+		ok, err := c.inDirector(serviceID, version, d, c.to)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			_, err = c.Globals.APIClient.CreateDirectorBackend(&fastly.CreateDirectorBackendInput{
+				ServiceID:      serviceID,
+				ServiceVersion: version,
+				Director:       d,
+				Backend:        c.to,
+			})
+			if err != nil {
+				return fmt.Errorf("error adding backend '%s' to director '%s': %w", c.to, d, err)
+			}
+		}
+		err = c.Globals.APIClient.DeleteDirectorBackend(&fastly.DeleteDirectorBackendInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			Director:       d,
+			Backend:        c.from,
+		})
+		if err != nil {
+			return fmt.Errorf("error removing backend '%s' from director '%s': %w", c.from, d, err)
+		}
+	}
+	return nil
+}
+
+// switchCanary routes a percentage of the requests the old backend's
+// condition matches to the new backend.
+func (c *SwitchCommand) switchCanary(serviceID string, version int, from *fastly.Backend) error {
+	statement := fmt.Sprintf("randombool(%d, 100)", c.canary)
+	var priority *int
+	if from.RequestCondition != "" {
+		fc, err := c.Globals.APIClient.GetCondition(&fastly.GetConditionInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			Name:           from.RequestCondition,
+		})
+		if err != nil {
+			return fmt.Errorf("error getting condition '%s': %w", from.RequestCondition, err)
+		}
+		statement = fmt.Sprintf("(%s) && %s", fc.Statement, statement)
+		// Conditions with a higher priority are evaluated later, and so take
+		// precedence when selecting a backend.
+		priority = fastly.Int(fc.Priority + 1)
+	}
+	if err := c.setCondition(serviceID, version, CanaryCondition, statement, priority); err != nil {
+		return err
+	}
+	return c.setRequestCondition(serviceID, version, c.to, CanaryCondition)
+}
+
+// switchConditions gives the new backend the old backend's request condition
+// and stops the old backend from being selected.
+func (c *SwitchCommand) switchConditions(serviceID string, version int, from *fastly.Backend) error {
+	if err := c.setCondition(serviceID, version, OffCondition, "false", nil); err != nil {
+		return err
+	}
+	if err := c.setRequestCondition(serviceID, version, c.to, from.RequestCondition); err != nil {
+		return err
+	}
+	if err := c.setRequestCondition(serviceID, version, c.from, OffCondition); err != nil {
+		return err
+	}
+
+	// The canary condition is no longer used once the switch is complete.
+	err := c.Globals.APIClient.DeleteCondition(&fastly.DeleteConditionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           CanaryCondition,
+	})
+	var httpErr *fastly.HTTPError
+	if err != nil && !(errors.As(err, &httpErr) && httpErr.IsNotFound()) {
+		return fmt.Errorf("error deleting condition '%s': %w", CanaryCondition, err)
+	}
+	return nil
+}
+
+// setCondition creates the request condition, or updates it if it exists.
+func (c *SwitchCommand) setCondition(serviceID string, version int, name, statement string, priority *int) error {
+	_, err := c.Globals.APIClient.GetCondition(&fastly.GetConditionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Name:           name,
+	})
+	var httpErr *fastly.HTTPError
+	switch {
+	case errors.As(err, &httpErr) && httpErr.IsNotFound():
+		_, err = c.Globals.APIClient.CreateCondition(&fastly.CreateConditionInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			Name:           name,
+			Statement:      statement,
+			Type:           "REQUEST",
+			Priority:       priority,
+		})
+	case err == nil:
+		_, err = c.Globals.APIClient.UpdateCondition(&fastly.UpdateConditionInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			Name:           name,
+			Statement:      fastly.String(statement),
+			Priority:       priority,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error setting condition '%s': %w", name, err)
+	}
+	return nil
+}
+
+func (c *SwitchCommand) setRequestCondition(serviceID string, version int, backend, condition string) error {
+	_, err := c.Globals.APIClient.UpdateBackend(&fastly.UpdateBackendInput{
+		ServiceID:        serviceID,
+		ServiceVersion:   version,
+		Name:             backend,
+		RequestCondition: fastly.String(condition),
+	})
+	if err != nil {
+		return fmt.Errorf("error updating backend '%s': %w", backend, err)
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ type CreateCommand struct {
 
 	clientFactory APIClientFactory
 	profile       string
+	serviceID     string
 }
 
 // NewCreateCommand returns a new command registered in the parent.
@@ -31,6 +32,7 @@ func NewCreateCommand(parent cmd.Registerer, cf APIClientFactory, globals *confi
 	c.Globals = globals
 	c.CmdClause = parent.Command("create", "Create user profile")
 	c.CmdClause.Arg("profile", "Profile to create (default 'user')").Default("user").Short('p').StringVar(&c.profile)
+	c.CmdClause.Flag("service-id", "Default service of the profile, for commands not given --service-id, FASTLY_SERVICE_ID or a fastly.toml service_id").StringVar(&c.serviceID)
 	c.clientFactory = cf
 	return &c
 }
@@ -88,14 +90,20 @@ func (c *CreateCommand) tokenFlow(profile string, def bool, in io.Reader, out io
 		}
 	}()
 
-	endpoint, _ := c.Globals.Endpoint()
+	// An endpoint given via the --api-endpoint flag (or environment) is
+	// recorded as the profile's API endpoint.
+	endpoint, source := c.Globals.ProfileEndpoint(nil)
+	profileEndpoint := ""
+	if source == config.SourceFlag || source == config.SourceEnvironment {
+		profileEndpoint = endpoint
+	}
 
 	user, err := c.validateToken(token, endpoint, progress)
 	if err != nil {
 		return err
 	}
 
-	c.updateInMemCfg(profile, user.Login, token, endpoint, def, progress)
+	c.updateInMemCfg(profile, user.Login, token, profileEndpoint, def, progress)
 
 	progress.Done()
 	return nil
@@ -162,15 +170,15 @@ func (c *CreateCommand) validateToken(token, endpoint string, progress text.Prog
 func (c *CreateCommand) updateInMemCfg(profileName, email, token, endpoint string, def bool, progress text.Progress) {
 	progress.Step("Persisting configuration...")
 
-	c.Globals.File.Fastly.APIEndpoint = endpoint
-
 	if c.Globals.File.Profiles == nil {
 		c.Globals.File.Profiles = make(config.Profiles)
 	}
 	c.Globals.File.Profiles[profileName] = &config.Profile{
-		Default: def,
-		Email:   email,
-		Token:   token,
+		APIEndpoint: endpoint,
+		Default:     def,
+		Email:       email,
+		ServiceID:   c.serviceID,
+		Token:       token,
 	}
 
 	// If the user wants the newly created profile to be their new default, then
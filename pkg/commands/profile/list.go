@@ -63,4 +63,10 @@ func display(k string, v *config.Profile, out io.Writer, style func(a ...interfa
 	text.Output(out, "%s: %t", style("Default"), v.Default)
 	text.Output(out, "%s: %s", style("Email"), v.Email)
 	text.Output(out, "%s: %s", style("Token"), v.Token)
+	if v.APIEndpoint != "" {
+		text.Output(out, "%s: %s", style("API endpoint"), v.APIEndpoint)
+	}
+	if v.ServiceID != "" {
+		text.Output(out, "%s: %s", style("Service ID"), v.ServiceID)
+	}
 }
@@ -84,6 +84,18 @@ func TestCreate(t *testing.T) {
 			},
 			Stdin: []string{"some_token"},
 		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate profile creation with a default service and endpoint",
+				Args: args("profile create bar --service-id 123 --endpoint https://api.example.com"),
+				API: mock.API{
+					GetTokenSelfFn: getToken,
+					GetUserFn:      getUser,
+				},
+				WantOutput: "Profile 'bar' created",
+			},
+			Stdin: []string{"some_token"},
+		},
 		{
 			TestScenario: testutil.TestScenario{
 				Name:      "validate profile duplication",
@@ -339,6 +351,24 @@ func TestList(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate listing a profile's default service and endpoint",
+				Args:       args("profile list"),
+				WantOutput: "foo\n\nDefault: true\nEmail: foo@example.com\nToken: 123\nAPI endpoint: https://api.example.com\nService ID: 456",
+			},
+			ConfigFile: config.File{
+				Profiles: config.Profiles{
+					"foo": &config.Profile{
+						APIEndpoint: "https://api.example.com",
+						Default:     true,
+						Email:       "foo@example.com",
+						ServiceID:   "456",
+						Token:       "123",
+					},
+				},
+			},
+		},
 		{
 			TestScenario: testutil.TestScenario{
 				Name:      "validate no profiles defined",
@@ -26,6 +26,7 @@ type UpdateCommand struct {
 
 	clientFactory APIClientFactory
 	profile       string
+	serviceID     cmd.OptionalString
 }
 
 // NewUpdateCommand returns a usable command registered under the parent.
@@ -34,6 +35,7 @@ func NewUpdateCommand(parent cmd.Registerer, cf APIClientFactory, globals *confi
 	c.Globals = globals
 	c.CmdClause = parent.Command("update", "Update user profile")
 	c.CmdClause.Arg("profile", "Profile to update (default 'user')").Default("user").Short('p').StringVar(&c.profile)
+	c.CmdClause.Flag("service-id", "Default service of the profile, for commands not given --service-id, FASTLY_SERVICE_ID or a fastly.toml service_id (empty to unset)").Action(c.serviceID.Set).StringVar(&c.serviceID.Value)
 	c.clientFactory = cf
 	return &c
 }
@@ -91,7 +93,19 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 		}
 	}()
 
-	endpoint, _ := c.Globals.Endpoint()
+	// An endpoint given via the --api-endpoint flag (or environment) is
+	// recorded as the profile's API endpoint.
+	endpoint, source := c.Globals.ProfileEndpoint(p)
+	if source == config.SourceFlag || source == config.SourceEnvironment {
+		opts = append(opts, func(p *config.Profile) {
+			p.APIEndpoint = endpoint
+		})
+	}
+	if c.serviceID.WasSet {
+		opts = append(opts, func(p *config.Profile) {
+			p.ServiceID = c.serviceID.Value
+		})
+	}
 
 	u, err := c.validateToken(token, endpoint, progress)
 	if err != nil {
@@ -2,10 +2,16 @@ package purge_test
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -36,7 +42,7 @@ func TestPurgeAll(t *testing.T) {
 					return nil, testutil.Err
 				},
 			},
-			Args:      args("purge --all --service-id 123 --token 456"),
+			Args:      args("purge --all --confirm-irreversible --service-id 123 --token 456"),
 			WantError: testutil.Err.Error(),
 		},
 		{
@@ -48,9 +54,14 @@ func TestPurgeAll(t *testing.T) {
 					}, nil
 				},
 			},
-			Args:       args("purge --all --service-id 123 --token 456"),
+			Args:       args("purge --all --confirm-irreversible --service-id 123 --token 456"),
 			WantOutput: "Purge all status: ok",
 		},
+		{
+			Name:      "validate --auto-yes doesn't confirm purge all",
+			Args:      args("purge --all --auto-yes --non-interactive --service-id 123 --token 456"),
+			WantError: "irreversible operation requires confirmation",
+		},
 	}
 
 	for _, testcase := range scenarios {
@@ -65,6 +76,76 @@ func TestPurgeAll(t *testing.T) {
 	}
 }
 
+func TestPurgeAllConfirmation(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		PurgeAllFn: func(i *fastly.PurgeAllInput) (*fastly.Purge, error) {
+			return &fastly.Purge{
+				Status: "ok",
+			}, nil
+		},
+	}
+	scenarios := []struct {
+		name       string
+		stdin      string
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:       "validate prompt is confirmed",
+			stdin:      "y",
+			wantOutput: "Purge all status: ok",
+		},
+		{
+			name:       "validate prompt is declined",
+			stdin:      "n",
+			wantError:  "operation cancelled by user",
+			wantOutput: "This cannot be undone",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(args("purge --all --service-id 123 --token 456"), &stdout)
+			opts.APIClient = mock.APIClient(api)
+			opts.Stdin = strings.NewReader(testcase.stdin)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
+func TestPurgeAllDryRunHooks(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+	touched := filepath.Join(t.TempDir(), "touched")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("purge --all --confirm-irreversible --service-id 123 --token 456 --dry-run"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		PurgeAllFn: func(i *fastly.PurgeAllInput) (*fastly.Purge, error) {
+			return &fastly.Purge{Status: "ok"}, nil
+		},
+	})
+	opts.ConfigFile.Hooks = config.Hooks{
+		"post-purge-all": {URL: ts.URL + "/hook", Command: "touch " + touched},
+	}
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: post-purge-all hook: POST "+ts.URL+"/hook\n")
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: post-purge-all hook: touch "+touched+"\n")
+	testutil.AssertEqual(t, 0, requests)
+	if _, err := os.Stat(touched); !os.IsNotExist(err) {
+		t.Errorf("want the hook command not to run, got %v", err)
+	}
+}
+
 func TestPurgeKeys(t *testing.T) {
 	var keys []string
 	args := testutil.Args
@@ -106,6 +187,22 @@ func TestPurgeKeys(t *testing.T) {
 			Args:       args("purge --file ./testdata/keys --service-id 123 --token 456"),
 			WantOutput: "KEY  ID\nbar  456\nbaz  789\nfoo  123\n",
 		},
+		{
+			Name: "validate PurgeKeys reads --file from stdin",
+			API: mock.API{
+				PurgeKeysFn: func(i *fastly.PurgeKeysInput) (map[string]string, error) {
+					keys = i.Keys
+					return map[string]string{"foo": "123", "bar": "456", "baz": "789"}, nil
+				},
+			},
+			Args:       args("purge --file - --service-id 123 --token 456"),
+			WantOutput: "KEY  ID\nbar  456\nbaz  789\nfoo  123\n",
+		},
+		{
+			Name:      "validate only one flag can read from stdin",
+			Args:      args("purge --file - --key - --service-id 123 --token 456"),
+			WantError: "only one flag can read from stdin, got: --file, --key",
+		},
 	}
 
 	for _, testcase := range scenarios {
@@ -113,6 +210,7 @@ func TestPurgeKeys(t *testing.T) {
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.Args, &stdout)
 			opts.APIClient = mock.APIClient(testcase.API)
+			opts.Stdin = strings.NewReader("foo\nbar\nbaz\n")
 			err := app.Run(opts)
 			testutil.AssertErrorContains(t, err, testcase.WantError)
 			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
@@ -4,13 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
 	"sort"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/hooks"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -66,7 +65,7 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 		return errors.ErrNoToken
 	}
 
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -88,7 +87,20 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 				Remediation: "The --soft flag should not be used with --all so retry command without it.",
 			}
 		}
-		err := c.purgeAll(serviceID, out)
+		label := fmt.Sprintf("Are you sure you want to purge all cached content for service %s? This cannot be undone. [y/N] ", serviceID)
+		cont, err := cmd.Confirm(cmd.ConfirmIrreversible, label, c.Globals, in, out)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": serviceID,
+				"All":        c.all,
+			})
+			return err
+		}
+		if !cont {
+			return errors.ErrConfirmationDeclined
+		}
+
+		err = c.purgeAll(serviceID, out)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID": serviceID,
@@ -148,11 +160,16 @@ func (c *RootCommand) purgeAll(serviceID string, out io.Writer) error {
 		return err
 	}
 	text.Success(out, "Purge all status: %s", p.Status)
+
+	hooks.Run(hooks.PostPurgeAll, hooks.Payload{
+		Command:   c.Name(),
+		ServiceID: serviceID,
+	}, c.Globals, out)
 	return nil
 }
 
 func (c *RootCommand) purgeKeys(serviceID string, out io.Writer) error {
-	keys, err := populateKeys(c.file, c.Globals.ErrLog)
+	keys, err := populateKeys(c.file, c.Globals.Stdin, c.Globals.ErrLog)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID": serviceID,
@@ -224,28 +241,18 @@ func (c *RootCommand) purgeURL(out io.Writer) error {
 	return nil
 }
 
-// populateKeys opens the given file path, initializes a scanner, and appends
-// each line of the file (expected to be a surrogate key) to a slice.
-func populateKeys(fpath string, errLog errors.LogInterface) (keys []string, err error) {
-	var (
-		file io.Reader
-		path string
-	)
-
-	if path, err = filepath.Abs(fpath); err == nil {
-		if _, err = os.Stat(path); err == nil {
-			// gosec flagged this:
-			// G304 (CWE-22): Potential file inclusion via variable
-			// Disabling as we trust the source of the fpath variable.
-			/* #nosec */
-			if file, err = os.Open(path); err == nil {
-				scanner := bufio.NewScanner(file)
-				for scanner.Scan() {
-					keys = append(keys, scanner.Text())
-				}
-				err = scanner.Err()
-			}
+// populateKeys opens the given file path (or stdin if it's "-"), initializes a
+// scanner, and appends each line of the file (expected to be a surrogate key)
+// to a slice.
+func populateKeys(fpath string, stdin io.Reader, errLog errors.LogInterface) (keys []string, err error) {
+	var file io.ReadCloser
+	if file, err = cmd.Open(fpath, stdin); err == nil {
+		defer file.Close() // #nosec G307
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			keys = append(keys, scanner.Text())
 		}
+		err = scanner.Err()
 	}
 
 	if err != nil {
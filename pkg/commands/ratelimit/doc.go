@@ -0,0 +1,3 @@
+// Package ratelimit contains a command to display the remaining API rate
+// limit of the authenticated token.
+package ratelimit
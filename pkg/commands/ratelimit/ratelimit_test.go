@@ -0,0 +1,133 @@
+package ratelimit_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestRateLimit(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		name       string
+		args       []string
+		observed   string
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:      "no token",
+			args:      args("rate-limit"),
+			observed:  "997",
+			wantError: "no token provided",
+		},
+		{
+			name:       "observed",
+			args:       args("--token=x --utc --iso8601 rate-limit"),
+			observed:   "997",
+			wantOutput: "Remaining: 997\nResets at: 2000-01-01T01:00:00Z (in 1h0m0s)\nObserved at: 2000-01-01T00:00:00Z (0s ago)\n",
+		},
+		{
+			name:       "observed for another token",
+			args:       args("--token=y rate-limit"),
+			observed:   "997",
+			wantOutput: "No rate limit has been observed for this token.",
+		},
+		{
+			name:       "not observed",
+			args:       args("--token=x rate-limit"),
+			wantOutput: "No rate limit has been observed for this token.",
+		},
+		{
+			name:       "json",
+			args:       args("--token=x rate-limit --json"),
+			observed:   "997",
+			wantOutput: `{"known":true,"remaining":997,"reset":"2000-01-01T01:00:00Z","observed_at":"2000-01-01T00:00:00Z"}`,
+		},
+		{
+			name:       "json not observed",
+			args:       args("--token=x rate-limit --json"),
+			wantOutput: `{"known":false}`,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if testcase.observed != "" {
+				observe(t, dir, "x", testcase.observed)
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.Clock = clock.Fixed(clock.Epoch)
+			opts.StateDir = dir
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
+// TestRateLimitObserved checks that the rate limit reported to another
+// command is displayed, along with how long ago it was observed.
+func TestRateLimitObserved(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(transport.RateLimitRemainingHeader, "42")
+		w.Header().Set(transport.RateLimitResetHeader, "946684900")
+		io.WriteString(w, `{"id":"123","name":"foo"}`)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service create --name foo --token x --api-endpoint "+ts.URL), &stdout)
+	opts.APIClient = app.FastlyAPIClient
+	opts.StateDir = dir
+	testutil.AssertNoError(t, app.Run(opts))
+
+	stdout.Reset()
+	opts = testutil.NewRunOpts(testutil.Args("--token=x --utc --iso8601 rate-limit"), &stdout)
+	opts.Clock = clock.Fixed(clock.Epoch.AddDate(1, 0, 0))
+	opts.StateDir = dir
+	testutil.AssertNoError(t, app.Run(opts))
+	testutil.AssertStringContains(t, stdout.String(), "Remaining: 42\n")
+	testutil.AssertStringContains(t, stdout.String(), "The limit has been reset since it was observed")
+}
+
+// observe records the rate limit reported by a response to a request made
+// with the token, resetting an hour after clock.Epoch.
+func observe(t *testing.T, dir, token, remaining string) {
+	t.Helper()
+	limits := transport.RateLimits{
+		Path: filepath.Join(dir, transport.RateLimitsFile),
+		Now:  clock.Fixed(clock.Epoch).Now,
+	}
+	client := limits.Client(rateLimitClient{remaining: remaining})
+	req, err := http.NewRequest(http.MethodPost, "https://api.fastly.com/service", nil)
+	testutil.AssertNoError(t, err)
+	req.Header.Set("Fastly-Key", token)
+	resp, err := client.Do(req)
+	testutil.AssertNoError(t, err)
+	resp.Body.Close()
+}
+
+// rateLimitClient responds with a rate limit that resets an hour after
+// clock.Epoch.
+type rateLimitClient struct {
+	remaining string
+}
+
+func (c rateLimitClient) Do(*http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(transport.RateLimitRemainingHeader, c.remaining)
+	rec.Header().Set(transport.RateLimitResetHeader, "946688400")
+	return rec.Result(), nil
+}
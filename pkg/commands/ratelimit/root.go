@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+
+	json bool
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("rate-limit", "Show the last observed API rate limit of the API token")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Status is the rate limit displayed by the command. Remaining, Reset and
+// ObservedAt are only set if a rate limit was observed for the token.
+type Status struct {
+	Known      bool       `json:"known"`
+	Remaining  *int       `json:"remaining,omitempty"`
+	Reset      *time.Time `json:"reset,omitempty"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
+}
+
+// Exec implements the command interface.
+//
+// The API only reports the rate limit in responses to requests that modify
+// the account, so rather than making a request the command displays the
+// limit most recently observed for the token by other commands.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	token, source := c.Globals.Token()
+	if source == config.SourceUndefined {
+		return errors.ErrNoToken
+	}
+
+	var (
+		rl transport.RateLimit
+		ok bool
+	)
+	if path := c.Globals.StatePath(transport.RateLimitsFile); path != "" {
+		rl, ok = transport.LoadRateLimit(path, token)
+	}
+
+	if c.json {
+		var status Status
+		if ok {
+			reset, observed := rl.Reset.UTC(), rl.Observed.UTC()
+			status = Status{Known: true, Remaining: &rl.Remaining, Reset: &reset, ObservedAt: &observed}
+		}
+		data, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if !ok {
+		text.Info(out, "No rate limit has been observed for this token. The API only reports it for requests that modify the account, so it's recorded when a command makes one.")
+		return nil
+	}
+	now := c.Globals.Clock.Now()
+	fmt.Fprintf(out, "Remaining: %d\n", rl.Remaining)
+	fmt.Fprintf(out, "Resets at: %s (in %s)\n", c.Globals.Formatter().Time(rl.Reset), rl.ResetIn(now))
+	fmt.Fprintf(out, "Observed at: %s (%s ago)\n", c.Globals.Formatter().Time(rl.Observed), now.Sub(rl.Observed).Round(time.Second))
+	if !rl.Reset.After(now) {
+		text.Break(out)
+		text.Info(out, "The limit has been reset since it was observed, so the full limit is likely to be available.")
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package route contains commands to inspect how a Fastly service routes
+// requests.
+package route
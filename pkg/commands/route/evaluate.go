@@ -0,0 +1,412 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/vclexpr"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Config is the configuration of a service version that affects routing.
+type Config struct {
+	Domains         []*fastly.Domain
+	Conditions      []*fastly.Condition
+	RequestSettings []*fastly.RequestSetting
+	Headers         []*fastly.Header
+	Backends        []*fastly.Backend
+	// Directors maps the name of each director to the names of its backends.
+	Directors map[string][]string
+	// Snippets and CustomVCL are the number of VCL snippets and custom VCL
+	// files, which aren't evaluated.
+	Snippets  int
+	CustomVCL int
+}
+
+// The results of evaluating a condition.
+const (
+	ResultTrue    = "true"
+	ResultFalse   = "false"
+	ResultUnknown = "unknown"
+)
+
+// ConditionResult is the result of evaluating a condition.
+type ConditionResult struct {
+	Name      string `json:"name"`
+	Statement string `json:"statement"`
+	Priority  int    `json:"priority"`
+	Result    string `json:"result"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Setting is a request setting that applies to the request.
+type Setting struct {
+	Name      string   `json:"name"`
+	Condition string   `json:"condition,omitempty"`
+	Result    string   `json:"result"`
+	Effects   []string `json:"effects,omitempty"`
+}
+
+// HeaderChange is a header whose condition was evaluated.
+type HeaderChange struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Action      string `json:"action"`
+	Destination string `json:"destination"`
+	Value       string `json:"value,omitempty"`
+	Condition   string `json:"condition,omitempty"`
+	Result      string `json:"result"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Selection explains which backend the request is routed to.
+type Selection struct {
+	Backend   string `json:"backend"`
+	Address   string `json:"address,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Shield    string `json:"shield,omitempty"`
+	// Directors are the directors the backend belongs to.
+	Directors []string `json:"directors,omitempty"`
+	// Alternatives are the backends that would be selected instead if a
+	// condition that couldn't be evaluated is true.
+	Alternatives []string `json:"alternatives,omitempty"`
+	// Defaults are the backends without a request condition, when more than
+	// one could be the default.
+	Defaults []string `json:"defaults,omitempty"`
+}
+
+// Preview is the result of evaluating a service's configuration against a
+// synthetic request.
+type Preview struct {
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Domain          string            `json:"domain,omitempty"`
+	RequestSettings []Setting         `json:"request_settings"`
+	Headers         []HeaderChange    `json:"headers"`
+	Backend         *Selection        `json:"backend,omitempty"`
+	Conditions      []ConditionResult `json:"conditions"`
+	Warnings        []string          `json:"warnings"`
+	// RequestHeaders are the request headers after the request settings and
+	// request headers have been applied.
+	RequestHeaders map[string]string `json:"request_headers"`
+}
+
+// evaluator evaluates conditions against a request, remembering the result
+// of the first evaluation of each.
+type evaluator struct {
+	req        *vclexpr.Request
+	conditions map[string]*fastly.Condition
+	results    map[string]*ConditionResult
+	order      []string
+}
+
+func (e *evaluator) eval(name string) (string, string) {
+	if name == "" {
+		return ResultTrue, ""
+	}
+	if r, ok := e.results[name]; ok {
+		return r.Result, r.Reason
+	}
+	c, ok := e.conditions[name]
+	if !ok {
+		r := &ConditionResult{Name: name, Result: ResultUnknown, Reason: "condition not found"}
+		e.results[name] = r
+		e.order = append(e.order, name)
+		return r.Result, r.Reason
+	}
+
+	r := &ConditionResult{Name: name, Statement: c.Statement, Priority: c.Priority}
+	ok, err := vclexpr.EvalBool(c.Statement, e.req)
+	switch {
+	case err != nil:
+		r.Result, r.Reason = ResultUnknown, err.Error()
+	case ok:
+		r.Result = ResultTrue
+	default:
+		r.Result = ResultFalse
+	}
+	e.results[name] = r
+	e.order = append(e.order, name)
+	return r.Result, r.Reason
+}
+
+// Evaluate previews how the service configuration handles the request. The
+// request is modified by the request settings and headers that apply to it.
+//
+// NOTE: The configuration is applied in the order Fastly's generated VCL
+// applies it in vcl_recv: request settings, then request headers (by
+// priority), then backend selection. Each condition is evaluated once, when
+// it's first used.
+func Evaluate(cfg Config, req *vclexpr.Request) Preview {
+	p := Preview{
+		Method:          req.Method,
+		URL:             req.URL.String(),
+		RequestSettings: []Setting{},
+		Headers:         []HeaderChange{},
+		Warnings:        []string{},
+	}
+	e := &evaluator{
+		req:        req,
+		conditions: map[string]*fastly.Condition{},
+		results:    map[string]*ConditionResult{},
+	}
+	for _, c := range cfg.Conditions {
+		e.conditions[c.Name] = c
+	}
+
+	host := strings.ToLower(req.URL.Hostname())
+	for _, d := range cfg.Domains {
+		if domainMatches(d.Name, host) {
+			p.Domain = d.Name
+			break
+		}
+	}
+	if p.Domain == "" {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("%s isn't a domain of the service, so Fastly wouldn't route the request to it", host))
+	}
+
+	for _, rs := range cfg.RequestSettings {
+		s := Setting{Name: rs.Name, Condition: rs.RequestCondition}
+		s.Result, _ = e.eval(rs.RequestCondition)
+		if s.Result != ResultFalse {
+			s.Effects = settingEffects(rs, req)
+			if s.Result == ResultTrue && rs.DefaultHost != "" {
+				req.SetHeader("Host", rs.DefaultHost)
+			}
+		}
+		p.RequestSettings = append(p.RequestSettings, s)
+	}
+
+	headers := append([]*fastly.Header(nil), cfg.Headers...)
+	sort.SliceStable(headers, func(i, j int) bool {
+		return headers[i].Priority < headers[j].Priority
+	})
+	for _, h := range headers {
+		p.Headers = append(p.Headers, applyHeader(e, h))
+	}
+
+	p.Backend = selectBackend(e, cfg)
+
+	for _, name := range e.order {
+		p.Conditions = append(p.Conditions, *e.results[name])
+	}
+	if cfg.Snippets > 0 || cfg.CustomVCL > 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("The service version has %d VCL snippets and %d custom VCL files, which aren't evaluated and may change the result", cfg.Snippets, cfg.CustomVCL))
+	}
+
+	p.RequestHeaders = map[string]string{}
+	for name, values := range req.Header {
+		p.RequestHeaders[name] = strings.Join(values, ", ")
+	}
+	return p
+}
+
+// domainMatches reports whether the host matches a domain, which may have a
+// leading wildcard (e.g. *.example.com).
+func domainMatches(domain, host string) bool {
+	domain = strings.ToLower(domain)
+	if strings.HasPrefix(domain, "*.") {
+		return strings.HasSuffix(host, domain[1:]) && len(host) > len(domain)-1
+	}
+	return domain == host
+}
+
+// settingEffects describes what a request setting does to the request.
+func settingEffects(rs *fastly.RequestSetting, req *vclexpr.Request) []string {
+	var effects []string
+	if rs.ForceSSL && req.URL.Scheme != "https" {
+		effects = append(effects, "redirects the request to HTTPS (force_ssl)")
+	}
+	if rs.Action != "" {
+		effects = append(effects, fmt.Sprintf("action: %s", rs.Action))
+	}
+	if rs.ForceMiss {
+		effects = append(effects, "forces a cache miss")
+	}
+	if rs.DefaultHost != "" {
+		effects = append(effects, fmt.Sprintf("sets the Host header to %s", rs.DefaultHost))
+	}
+	if rs.HashKeys != "" {
+		effects = append(effects, fmt.Sprintf("hash keys: %s", rs.HashKeys))
+	}
+	if rs.XForwardedFor != "" {
+		effects = append(effects, fmt.Sprintf("X-Forwarded-For: %s", rs.XForwardedFor))
+	}
+	return effects
+}
+
+// backref matches the \1 style back references of VCL regsub().
+var backref = regexp.MustCompile(`\\(\d)`)
+
+// applyHeader evaluates a header and, if it's a request header whose
+// condition is true, applies it to the request.
+func applyHeader(e *evaluator, h *fastly.Header) HeaderChange {
+	hc := HeaderChange{
+		Name:        h.Name,
+		Type:        string(h.Type),
+		Action:      string(h.Action),
+		Destination: h.Destination,
+		Condition:   h.RequestCondition,
+	}
+	switch h.Type {
+	case fastly.HeaderTypeCache, fastly.HeaderTypeResponse:
+		hc.Condition = h.ResponseCondition
+		if h.Type == fastly.HeaderTypeCache {
+			hc.Condition = h.CacheCondition
+		}
+		hc.Result, hc.Reason = ResultUnknown, "depends on the response"
+		return hc
+	}
+
+	hc.Result, hc.Reason = e.eval(h.RequestCondition)
+	if hc.Result != ResultTrue {
+		return hc
+	}
+
+	name := strings.TrimPrefix(h.Destination, "http.")
+	current, isSet := e.req.Header[http.CanonicalHeaderKey(name)]
+	if h.Action != fastly.HeaderActionDelete {
+		v, err := vclexpr.Eval(h.Source, e.req)
+		if err != nil {
+			hc.Result, hc.Reason = ResultUnknown, err.Error()
+			return hc
+		}
+		hc.Value = v.String()
+	}
+
+	// Fetch headers modify the request to the backend, not the client
+	// request that later conditions see.
+	if h.Type != fastly.HeaderTypeRequest {
+		return hc
+	}
+	switch h.Action {
+	case fastly.HeaderActionSet:
+		if h.IgnoreIfSet && isSet {
+			hc.Reason = "ignored as the header is already set"
+			return hc
+		}
+		e.req.SetHeader(name, hc.Value)
+	case fastly.HeaderActionAppend:
+		e.req.SetHeader(name, strings.Join(current, ", ")+hc.Value)
+	case fastly.HeaderActionDelete:
+		e.req.Header.Del(name)
+	case fastly.HeaderActionRegex, fastly.HeaderActionRegexRepeat:
+		re, err := regexp.Compile(h.Regex)
+		if err != nil {
+			hc.Result, hc.Reason = ResultUnknown, fmt.Sprintf("regular expression %q can't be evaluated locally", h.Regex)
+			return hc
+		}
+		sub := backref.ReplaceAllString(h.Substitution, "$${$1}")
+		if h.Action == fastly.HeaderActionRegexRepeat {
+			hc.Value = re.ReplaceAllString(hc.Value, sub)
+		} else if loc := re.FindStringSubmatchIndex(hc.Value); loc != nil {
+			hc.Value = hc.Value[:loc[0]] + string(re.ExpandString(nil, sub, hc.Value, loc)) + hc.Value[loc[1]:]
+		}
+		e.req.SetHeader(name, hc.Value)
+	}
+	return hc
+}
+
+// selectBackend applies the backends' request conditions in priority order.
+// Each matching condition assigns its backend, so the last match wins, and
+// the backends without a request condition are the default.
+func selectBackend(e *evaluator, cfg Config) *Selection {
+	if len(cfg.Backends) == 0 {
+		return nil
+	}
+
+	type candidate struct {
+		backend  *fastly.Backend
+		priority int
+	}
+	var conditional []candidate
+	var defaults []*fastly.Backend
+	for _, b := range cfg.Backends {
+		if b.RequestCondition == "" {
+			defaults = append(defaults, b)
+			continue
+		}
+		priority := 0
+		if c, ok := e.conditions[b.RequestCondition]; ok {
+			priority = c.Priority
+		}
+		conditional = append(conditional, candidate{b, priority})
+	}
+	sort.SliceStable(conditional, func(i, j int) bool {
+		return conditional[i].priority < conditional[j].priority
+	})
+
+	var selected *fastly.Backend
+	var unknown []string
+	for _, c := range conditional {
+		switch result, _ := e.eval(c.backend.RequestCondition); result {
+		case ResultTrue:
+			selected = c.backend
+			unknown = nil
+		case ResultUnknown:
+			unknown = append(unknown, c.backend.Name)
+		}
+	}
+
+	s := &Selection{Alternatives: unknown}
+	if selected == nil {
+		switch len(defaults) {
+		case 0:
+			s.Backend = "(none)"
+			return s
+		case 1:
+			selected = defaults[0]
+		default:
+			selected = defaults[0]
+			for _, d := range defaults {
+				s.Defaults = append(s.Defaults, d.Name)
+			}
+		}
+	}
+	s.Backend = selected.Name
+	s.Address = fmt.Sprintf("%s:%d", selected.Address, selected.Port)
+	s.Condition = selected.RequestCondition
+	s.Shield = selected.Shield
+	for name, members := range cfg.Directors {
+		for _, m := range members {
+			if m == selected.Name {
+				s.Directors = append(s.Directors, name)
+			}
+		}
+	}
+	sort.Strings(s.Directors)
+	return s
+}
+
+var (
+	vclDirector        = regexp.MustCompile(`(?s)\bdirector\s+([A-Za-z0-9_]+)\s+[a-z_]+\s*\{(.*?)\n\}`)
+	vclDirectorBackend = regexp.MustCompile(`\.backend\s*=\s*F_([A-Za-z0-9_]+)\s*;`)
+	vclUnsafe          = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// ParseDirectors returns the backends of each director defined in generated
+// VCL, mapping the VCL identifiers of the backends (e.g. F_my_origin) back to
+// their names.
+func ParseDirectors(vcl string, backends []*fastly.Backend) map[string][]string {
+	names := map[string]string{}
+	for _, b := range backends {
+		names[vclUnsafe.ReplaceAllString(b.Name, "_")] = b.Name
+	}
+	directors := map[string][]string{}
+	for _, m := range vclDirector.FindAllStringSubmatch(vcl, -1) {
+		if strings.HasPrefix(m[1], "autodirector_") {
+			continue
+		}
+		for _, b := range vclDirectorBackend.FindAllStringSubmatch(m[2], -1) {
+			name, ok := names[b[1]]
+			if !ok {
+				name = b[1]
+			}
+			directors[m[1]] = append(directors[m[1]], name)
+		}
+	}
+	return directors
+}
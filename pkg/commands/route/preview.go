@@ -0,0 +1,307 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/vclexpr"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// PreviewCommand explains how a service version would route a request, by
+// evaluating its configuration locally.
+type PreviewCommand struct {
+	cmd.Base
+	clientIP       string
+	headers        []string
+	json           bool
+	manifest       manifest.Data
+	method         string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	url            string
+}
+
+// NewPreviewCommand returns a usable command registered under the parent.
+func NewPreviewCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *PreviewCommand {
+	var c PreviewCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("preview", "Explain which backend a request would be routed to and which headers would be applied, without sending it")
+	c.CmdClause.Flag("url", "URL of the request (e.g. https://www.example.com/api/x)").Required().StringVar(&c.url)
+	c.CmdClause.Flag("client-ip", "IP address of the client (client.ip)").Default("127.0.0.1").StringVar(&c.clientIP)
+	c.CmdClause.Flag("header", "Request header in the form 'Name: value' (may be repeated)").Short('H').StringsVar(&c.headers)
+	c.CmdClause.Flag("method", "HTTP method of the request").Default("GET").StringVar(&c.method)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *PreviewCommand) Exec(in io.Reader, out io.Writer) error {
+	req, err := c.request()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	cfg, warnings, err := c.config(serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	p := Evaluate(cfg, req)
+	p.Warnings = append(warnings, p.Warnings...)
+
+	if c.json {
+		data, err := json.Marshal(p)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	printPreview(out, p)
+	return nil
+}
+
+// request builds the synthetic request from the flags.
+func (c *PreviewCommand) request() (*vclexpr.Request, error) {
+	req, err := vclexpr.NewRequest(c.url)
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       err,
+			Remediation: "Provide an absolute URL, e.g. --url https://www.example.com/api/x",
+		}
+	}
+	req.Method = strings.ToUpper(c.method)
+	if req.ClientIP = net.ParseIP(c.clientIP); req.ClientIP == nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid client IP '%s'", c.clientIP),
+			Remediation: "Provide an IPv4 or IPv6 address, e.g. --client-ip 192.0.2.1",
+		}
+	}
+	for _, h := range c.headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid header '%s'", h),
+				Remediation: "Provide headers in the form 'Name: value', e.g. --header 'Accept: text/html'",
+			}
+		}
+		req.SetHeader(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return req, nil
+}
+
+// config fetches the parts of the service version's configuration that affect
+// routing. The director memberships are read from the generated VCL, and if
+// it can't be read a warning is returned instead of an error.
+func (c *PreviewCommand) config(serviceID string, version int) (Config, []string, error) {
+	var warnings []string
+
+	s := snapshot.New(c.Globals.APIClient, serviceID, version)
+	if err := s.Fetch("domain", "backend", "condition", "header", "snippet", "vcl"); err != nil {
+		return Config{}, nil, err
+	}
+	// The resources were fetched above, so these don't make API requests.
+	domains, _ := s.Domains()
+	backends, _ := s.Backends()
+	conditions, _ := s.Conditions()
+	headers, _ := s.Headers()
+	snippets, _ := s.Snippets()
+	vcls, _ := s.VCLs()
+
+	settings, err := c.Globals.APIClient.ListRequestSettings(&fastly.ListRequestSettingsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("error listing request settings: %w", err)
+	}
+
+	cfg := Config{
+		Domains:         domains,
+		Conditions:      conditions,
+		RequestSettings: settings,
+		Headers:         headers,
+		Backends:        backends,
+		Snippets:        len(snippets),
+		CustomVCL:       len(vcls),
+	}
+
+	vcl, err := c.Globals.APIClient.GetGeneratedVCL(&fastly.GetGeneratedVCLInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Unable to read the generated VCL, so director membership isn't shown: %s", err))
+	} else {
+		cfg.Directors = ParseDirectors(vcl.Content, cfg.Backends)
+	}
+	return cfg, warnings, nil
+}
+
+func printPreview(out io.Writer, p Preview) {
+	text.Output(out, "Request: %s %s", p.Method, p.URL)
+	if p.Domain != "" {
+		text.Output(out, "Domain: %s", p.Domain)
+	}
+	text.Break(out)
+
+	if p.Backend != nil {
+		b := p.Backend
+		text.Output(out, "Backend: %s", text.Bold(b.Backend))
+		if b.Address != "" {
+			text.Output(out, "Address: %s", b.Address)
+		}
+		if b.Condition != "" {
+			text.Output(out, "Selected by condition: %s", b.Condition)
+		} else if b.Address != "" {
+			text.Output(out, "Selected as the default backend (no request condition)")
+		}
+		if len(b.Directors) > 0 {
+			text.Output(out, "Directors: %s", strings.Join(b.Directors, ", "))
+		}
+		if b.Shield != "" {
+			text.Output(out, "Shield: %s", b.Shield)
+		}
+		if len(b.Defaults) > 0 {
+			text.Warning(out, "The backends %s have no request condition, so which one is the default depends on the rest of the configuration (e.g. auto load balancing)", strings.Join(b.Defaults, ", "))
+		}
+		if len(b.Alternatives) > 0 {
+			text.Warning(out, "The conditions of %s couldn't be evaluated and may select a different backend", strings.Join(b.Alternatives, ", "))
+		}
+		text.Break(out)
+	}
+
+	var settings []Setting
+	for _, s := range p.RequestSettings {
+		if s.Result != ResultFalse {
+			settings = append(settings, s)
+		}
+	}
+	if len(settings) > 0 {
+		text.Output(out, "Request settings:")
+		for _, s := range settings {
+			note := ""
+			if s.Result == ResultUnknown {
+				note = " (if condition " + s.Condition + " is true)"
+			}
+			text.Output(out, "  %s%s", s.Name, note)
+			for _, e := range s.Effects {
+				text.Output(out, "    %s", e)
+			}
+		}
+		text.Break(out)
+	}
+
+	if len(p.Headers) > 0 {
+		text.Output(out, "Headers:")
+		t := text.NewTable(out)
+		t.AddHeader("NAME", "TYPE", "ACTION", "DESTINATION", "VALUE", "APPLIED")
+		for _, h := range p.Headers {
+			applied := "no"
+			switch h.Result {
+			case ResultTrue:
+				applied = "yes"
+				if h.Reason != "" {
+					applied = "no (" + h.Reason + ")"
+				}
+			case ResultUnknown:
+				applied = "? (" + h.Reason + ")"
+			}
+			t.AddLine(h.Name, h.Type, h.Action, h.Destination, h.Value, applied)
+		}
+		t.Print()
+		text.Break(out)
+	}
+
+	if len(p.Conditions) > 0 {
+		text.Output(out, "Conditions:")
+		t := text.NewTable(out)
+		t.AddHeader("NAME", "PRIORITY", "RESULT", "STATEMENT")
+		for _, c := range p.Conditions {
+			result := c.Result
+			if c.Result == ResultUnknown {
+				result = "? (" + c.Reason + ")"
+			}
+			t.AddLine(c.Name, c.Priority, result, c.Statement)
+		}
+		t.Print()
+		text.Break(out)
+	}
+
+	names := make([]string, 0, len(p.RequestHeaders))
+	for name := range p.RequestHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	text.Output(out, "Request headers after processing:")
+	for _, name := range names {
+		text.Output(out, "  %s: %s", name, p.RequestHeaders[name])
+	}
+
+	for _, w := range p.Warnings {
+		text.Break(out)
+		text.Warning(out, "%s", w)
+	}
+}
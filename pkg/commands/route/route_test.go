@@ -0,0 +1,151 @@
+package route_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/route"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/vclexpr"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestRoutePreview(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		ListDomainsFn: func(*fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+			return []*fastly.Domain{{Name: "www.example.com"}}, nil
+		},
+		ListBackendsFn: func(*fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			return []*fastly.Backend{
+				{Name: "origin", Address: "origin.example.com", Port: 443},
+				{Name: "api-origin", Address: "api.example.com", Port: 443, RequestCondition: "is_api", Shield: "lhr-uk"},
+			}, nil
+		},
+		ListConditionsFn: func(*fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+			return []*fastly.Condition{
+				{Name: "is_api", Statement: `req.url ~ "^/api/"`, Type: "REQUEST", Priority: 10},
+			}, nil
+		},
+		ListHeadersFn: func(*fastly.ListHeadersInput) ([]*fastly.Header, error) {
+			return []*fastly.Header{
+				{Name: "route", Type: fastly.HeaderTypeRequest, Action: fastly.HeaderActionSet, Destination: "http.X-Route", Source: `"api"`, RequestCondition: "is_api"},
+				{Name: "hsts", Type: fastly.HeaderTypeResponse, Action: fastly.HeaderActionSet, Destination: "http.Strict-Transport-Security", Source: `"max-age=300"`},
+			}, nil
+		},
+		ListSnippetsFn: func(*fastly.ListSnippetsInput) ([]*fastly.Snippet, error) { return nil, nil },
+		ListVCLsFn:     func(*fastly.ListVCLsInput) ([]*fastly.VCL, error) { return nil, nil },
+		ListRequestSettingsFn: func(*fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+			return []*fastly.RequestSetting{{Name: "tls", ForceSSL: true}}, nil
+		},
+		GetGeneratedVCLFn: func(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+			return &fastly.VCL{Content: "director api_pool random {\n  { .backend = F_api_origin; .weight = 100; }\n}\n"}, nil
+		},
+	}
+
+	testutil.RunScenarios(t, []testutil.TestScenario{
+		{
+			Name:      "validate missing --url flag",
+			Args:      args("route preview --service-id 123"),
+			WantError: "error parsing arguments: required flag --url not provided",
+		},
+		{
+			Name:      "validate relative URL",
+			Args:      args("route preview --service-id 123 --url /api/x"),
+			WantError: "absolute",
+		},
+		{
+			Name:      "validate invalid header",
+			Args:      args("route preview --service-id 123 --url https://www.example.com/ --header nocolon"),
+			WantError: "invalid header 'nocolon'",
+		},
+		{
+			Name: "validate conditional backend",
+			API:  api,
+			Args: args("route preview --service-id 123 --url https://www.example.com/api/x"),
+			WantOutputs: []string{
+				"Domain: www.example.com",
+				"Address: api.example.com:443",
+				"Selected by condition: is_api",
+				"Directors: api_pool",
+				"Shield: lhr-uk",
+				"X-Route: api",
+			},
+		},
+		{
+			Name: "validate default backend and force_ssl",
+			API:  api,
+			Args: args("route preview --service-id 123 --url http://www.example.com/home"),
+			WantOutputs: []string{
+				"Address: origin.example.com:443",
+				"Selected as the default backend (no request condition)",
+				"redirects the request to HTTPS (force_ssl)",
+			},
+		},
+		{
+			Name:       "validate unknown domain",
+			API:        api,
+			Args:       args("route preview --service-id 123 --url https://other.example.com/"),
+			WantOutput: "other.example.com isn't a domain of the service",
+		},
+		{
+			Name:       "validate JSON output",
+			API:        api,
+			Args:       args("route preview --service-id 123 --url https://www.example.com/api/x --json"),
+			WantOutput: `"backend":{"backend":"api-origin","address":"api.example.com:443","condition":"is_api","shield":"lhr-uk","directors":["api_pool"]}`,
+		},
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	cfg := route.Config{
+		Conditions: []*fastly.Condition{
+			{Name: "is_gb", Statement: `client.geo.country_code == "GB"`, Priority: 20},
+			{Name: "is_mobile", Statement: `req.http.User-Agent ~ "Mobile"`, Priority: 10},
+		},
+		Headers: []*fastly.Header{
+			{Name: "strip", Type: fastly.HeaderTypeRequest, Action: fastly.HeaderActionRegex, Destination: "http.X-Path", Source: "req.url.path", Regex: `^/v(\d+)/`, Substitution: `/version-\1/`, Priority: 20},
+			{Name: "keep", Type: fastly.HeaderTypeRequest, Action: fastly.HeaderActionSet, Destination: "http.Accept", Source: `"*/*"`, IgnoreIfSet: true, Priority: 10},
+		},
+		Backends: []*fastly.Backend{
+			{Name: "a", AutoLoadbalance: true},
+			{Name: "b", AutoLoadbalance: true},
+			{Name: "mobile", RequestCondition: "is_mobile"},
+			{Name: "gb", RequestCondition: "is_gb"},
+		},
+	}
+	req, err := vclexpr.NewRequest("https://www.example.com/v2/items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetHeader("Accept", "text/html")
+	req.SetHeader("User-Agent", "Mobile Safari")
+
+	p := route.Evaluate(cfg, req)
+
+	testutil.AssertString(t, "mobile", p.Backend.Backend)
+	testutil.AssertEqual(t, []string{"gb"}, p.Backend.Alternatives)
+	testutil.AssertString(t, "text/html", p.RequestHeaders["Accept"])
+	testutil.AssertString(t, "/version-2/items", p.RequestHeaders["X-Path"])
+	testutil.AssertString(t, route.ResultUnknown, p.Conditions[1].Result)
+
+	cfg.Backends = cfg.Backends[:2]
+	p = route.Evaluate(cfg, req)
+	testutil.AssertEqual(t, []string{"a", "b"}, p.Backend.Defaults)
+}
+
+func TestParseDirectors(t *testing.T) {
+	vcl := `director autodirector_ random {
+  { .backend = F_a; .weight = 100; }
+}
+
+director pool random {
+  .quorum = 50%;
+  { .backend = F_my_origin; .weight = 100; }
+  { .backend = F_b; .weight = 100; }
+}
+`
+	backends := []*fastly.Backend{{Name: "my-origin"}, {Name: "b"}}
+	testutil.AssertEqual(t, map[string][]string{"pool": {"my-origin", "b"}}, route.ParseDirectors(vcl, backends))
+}
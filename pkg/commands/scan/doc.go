@@ -0,0 +1,3 @@
+// Package scan contains commands to check a site served by Fastly for common
+// problems.
+package scan
@@ -0,0 +1,331 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/curl"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/useragent"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// The results of a header check.
+const (
+	ResultPass = "pass"
+	ResultWarn = "warn"
+	ResultFail = "fail"
+)
+
+// hstsMinMaxAge is the shortest HSTS max-age, in seconds, that passes.
+const hstsMinMaxAge = 180 * 24 * 60 * 60
+
+// HeaderCheck is the result of checking a security header of a response.
+type HeaderCheck struct {
+	Header  string `json:"header"`
+	Result  string `json:"result"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// Fix is the value of the header object that fixes a failed check, and
+	// FixHeader the header it sets if that isn't Header.
+	Fix       string `json:"-"`
+	FixHeader string `json:"-"`
+}
+
+// HeadersCommand requests a URL and grades the security headers of the
+// response.
+type HeadersCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	fix            bool
+	json           bool
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	url            string
+}
+
+// NewHeadersCommand returns a usable command registered under the parent.
+func NewHeadersCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *HeadersCommand {
+	var c HeadersCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("headers", "Grade the security headers of a response served by Fastly")
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("fix", "Offer to create header objects on the service that set the missing or weak headers").BoolVar(&c.fix)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("url", "URL to request").Required().StringVar(&c.url)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'latest')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *HeadersCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.json && c.fix {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--json and --fix can't be used together"),
+			Remediation: "Use --fix without --json to create the header objects.",
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.url, nil)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error constructing request: %w", err),
+			Remediation: "Check the URL is valid, e.g. https://www.example.com/",
+		}
+	}
+	req.Header.Set("User-Agent", useragent.Name)
+
+	resp, err := c.Globals.HTTPClient.Do(req)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	checks := CheckHeaders(resp.Header)
+	grade := Grade(checks)
+
+	if c.json {
+		data, err := json.Marshal(struct {
+			URL    string        `json:"url"`
+			Grade  string        `json:"grade"`
+			Checks []HeaderCheck `json:"checks"`
+		}{c.url, grade, checks})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("HEADER", "RESULT", "DETAILS")
+	for _, hc := range checks {
+		t.AddLine(hc.Header, strings.ToUpper(hc.Result), hc.Message)
+	}
+	t.Print()
+	text.Break(out)
+	text.Output(out, "Grade: %s", text.Bold(grade))
+
+	if len(curl.ServedBy(resp.Header)) == 0 {
+		text.Warning(out, "The response has no X-Served-By header, so it doesn't appear to have been served by Fastly.")
+	}
+
+	var fixes []HeaderCheck
+	for _, hc := range checks {
+		if hc.Result != ResultPass && hc.Fix != "" {
+			fixes = append(fixes, hc)
+		}
+	}
+	if !c.fix || len(fixes) == 0 {
+		return nil
+	}
+	return c.applyFixes(in, out, fixes)
+}
+
+// applyFixes creates a header object for each fix on the service version.
+func (c *HeadersCommand) applyFixes(in io.Reader, out io.Writer, fixes []HeaderCheck) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	text.Break(out)
+	text.Output(out, "The following response headers will be set on service %s version %d:", serviceID, serviceVersion.Number)
+	for _, hc := range fixes {
+		text.Output(out, "\t%s: %s", fixHeader(hc), hc.Fix)
+	}
+	text.Break(out)
+	cont, err := cmd.Confirm(cmd.ConfirmInformational, "Create the header objects? [y/N] ", c.Globals, in, out)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return nil
+	}
+
+	for _, hc := range fixes {
+		h := fixHeader(hc)
+		_, err := c.Globals.APIClient.CreateHeader(&fastly.CreateHeaderInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion.Number,
+			Name:           fmt.Sprintf("Set %s", h),
+			Action:         fastly.HeaderActionSet,
+			Type:           fastly.HeaderTypeResponse,
+			Destination:    "http." + h,
+			Source:         strconv.Quote(hc.Fix),
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Header":          h,
+			})
+			return fmt.Errorf("error creating header object for %s: %w", h, err)
+		}
+	}
+
+	text.Success(out, "Created %d header objects (service %s version %d)", len(fixes), serviceID, serviceVersion.Number)
+	text.Info(out, "Activate the service version, then run the scan again to check the headers.")
+	return nil
+}
+
+func fixHeader(hc HeaderCheck) string {
+	if hc.FixHeader != "" {
+		return hc.FixHeader
+	}
+	return hc.Header
+}
+
+var hstsMaxAge = regexp.MustCompile(`(?i)max-age\s*=\s*"?(\d+)`)
+
+// CheckHeaders checks the security headers of a response.
+func CheckHeaders(h http.Header) []HeaderCheck {
+	checks := make([]HeaderCheck, 0, 5)
+
+	hsts := HeaderCheck{Header: "Strict-Transport-Security", Value: h.Get("Strict-Transport-Security"), Fix: "max-age=31536000"}
+	switch m := hstsMaxAge.FindStringSubmatch(hsts.Value); {
+	case hsts.Value == "":
+		hsts.Result, hsts.Message = ResultFail, "Missing, so browsers can be downgraded to HTTP"
+	case m == nil:
+		hsts.Result, hsts.Message = ResultFail, "No max-age directive"
+	default:
+		if age, _ := strconv.Atoi(m[1]); age < hstsMinMaxAge {
+			hsts.Result, hsts.Message = ResultWarn, fmt.Sprintf("max-age of %d seconds is less than 180 days", age)
+		} else {
+			hsts.Result = ResultPass
+		}
+	}
+	checks = append(checks, hsts)
+
+	// A policy is specific to the site, so the fix is a report-only policy that
+	// can be tested before it's enforced.
+	csp := HeaderCheck{Header: "Content-Security-Policy", Value: h.Get("Content-Security-Policy"), Fix: "default-src 'self'", FixHeader: "Content-Security-Policy-Report-Only"}
+	switch lv := strings.ToLower(csp.Value); {
+	case csp.Value == "":
+		csp.Result, csp.Message = ResultFail, "Missing, so injected scripts aren't restricted"
+		if h.Get("Content-Security-Policy-Report-Only") != "" {
+			csp.Message += " (a report-only policy is set)"
+			csp.Fix = ""
+		}
+	case strings.Contains(lv, "'unsafe-inline'") || strings.Contains(lv, "'unsafe-eval'"):
+		csp.Result, csp.Message, csp.Fix = ResultWarn, "Allows 'unsafe-inline' or 'unsafe-eval'", ""
+	default:
+		csp.Result = ResultPass
+	}
+	checks = append(checks, csp)
+
+	xcto := HeaderCheck{Header: "X-Content-Type-Options", Value: h.Get("X-Content-Type-Options"), Fix: "nosniff"}
+	switch {
+	case xcto.Value == "":
+		xcto.Result, xcto.Message = ResultFail, "Missing, so browsers may sniff the content type"
+	case !strings.EqualFold(strings.TrimSpace(xcto.Value), "nosniff"):
+		xcto.Result, xcto.Message = ResultFail, "Must be 'nosniff'"
+	default:
+		xcto.Result = ResultPass
+	}
+	checks = append(checks, xcto)
+
+	xfo := HeaderCheck{Header: "X-Frame-Options", Value: h.Get("X-Frame-Options"), Fix: "SAMEORIGIN"}
+	switch {
+	case xfo.Value != "":
+		xfo.Result = ResultPass
+	case strings.Contains(strings.ToLower(csp.Value), "frame-ancestors"):
+		xfo.Result, xfo.Message, xfo.Fix = ResultPass, "Covered by the Content-Security-Policy frame-ancestors directive", ""
+	default:
+		xfo.Result, xfo.Message = ResultWarn, "Missing, so the site can be framed by other sites"
+	}
+	checks = append(checks, xfo)
+
+	rp := HeaderCheck{Header: "Referrer-Policy", Value: h.Get("Referrer-Policy"), Fix: "strict-origin-when-cross-origin"}
+	switch strings.ToLower(rp.Value) {
+	case "":
+		rp.Result, rp.Message = ResultWarn, "Missing, so the browser's default policy is used"
+	case "unsafe-url":
+		rp.Result, rp.Message = ResultWarn, "'unsafe-url' sends the full URL to other sites"
+	default:
+		rp.Result = ResultPass
+	}
+	checks = append(checks, rp)
+
+	return checks
+}
+
+// Grade returns a letter grade from A to F for the checks. A pass scores two
+// points and a warning one.
+func Grade(checks []HeaderCheck) string {
+	if len(checks) == 0 {
+		return "F"
+	}
+	var score int
+	for _, hc := range checks {
+		switch hc.Result {
+		case ResultPass:
+			score += 2
+		case ResultWarn:
+			score++
+		}
+	}
+	switch pct := score * 100 / (len(checks) * 2); {
+	case pct >= 90:
+		return "A"
+	case pct >= 70:
+		return "B"
+	case pct >= 50:
+		return "C"
+	case pct >= 30:
+		return "D"
+	}
+	return "F"
+}
@@ -0,0 +1,141 @@
+package scan_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/scan"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestScanHeaders(t *testing.T) {
+	var created []*fastly.CreateHeaderInput
+
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		header http.Header
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate missing --url flag",
+				Args:      args("scan headers"),
+				WantError: "error parsing arguments: required flag --url not provided",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --json with --fix",
+				Args:      args("scan headers --url https://www.example.com --json --fix"),
+				WantError: "--json and --fix can't be used together",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate weak headers",
+				Args: args("scan headers --url https://www.example.com"),
+				WantOutputs: []string{
+					"Strict-Transport-Security  WARN    max-age of 300 seconds is less than 180 days",
+					"Content-Security-Policy    FAIL    Missing, so injected scripts aren't restricted",
+					"X-Content-Type-Options     PASS",
+					"Grade: C",
+				},
+			},
+			header: weakHeaders(),
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate response not served by Fastly",
+				Args:       args("scan headers --url https://www.example.com"),
+				WantOutput: "doesn't appear to have been served by Fastly",
+			},
+			header: http.Header{},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate JSON output",
+				Args:       args("scan headers --url https://www.example.com --json"),
+				WantOutput: `"grade":"C"`,
+			},
+			header: weakHeaders(),
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate --fix creates header objects",
+				Args: args("scan headers --url https://www.example.com --fix --service-id 123 --version 3 --auto-yes"),
+				API: mock.API{
+					ListVersionsFn: testutil.ListVersions,
+					CreateHeaderFn: func(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+						created = append(created, i)
+						return &fastly.Header{Name: i.Name}, nil
+					},
+				},
+				WantOutputs: []string{
+					"Content-Security-Policy-Report-Only: default-src 'self'",
+					"Created 4 header objects (service 123 version 3)",
+				},
+			},
+			header: weakHeaders(),
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			opts.HTTPClient = mock.HTMLClient(&http.Response{
+				StatusCode: http.StatusOK,
+				Header:     testcase.header,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+
+	if len(created) != 4 {
+		t.Fatalf("want 4 header objects created, have %d", len(created))
+	}
+	testutil.AssertString(t, "http.Strict-Transport-Security", created[0].Destination)
+	testutil.AssertString(t, `"max-age=31536000"`, created[0].Source)
+	testutil.AssertEqual(t, fastly.HeaderTypeResponse, created[0].Type)
+}
+
+func TestGrade(t *testing.T) {
+	for _, tc := range []struct {
+		header http.Header
+		want   string
+	}{
+		{http.Header{}, "F"},
+		{weakHeaders(), "C"},
+		{
+			http.Header{
+				"Strict-Transport-Security": {"max-age=63072000; includeSubDomains"},
+				"Content-Security-Policy":   {"default-src 'self'; frame-ancestors 'none'"},
+				"X-Content-Type-Options":    {"nosniff"},
+				"Referrer-Policy":           {"no-referrer"},
+			},
+			"A",
+		},
+	} {
+		testutil.AssertString(t, tc.want, scan.Grade(scan.CheckHeaders(tc.header)))
+	}
+}
+
+func weakHeaders() http.Header {
+	return http.Header{
+		"Strict-Transport-Security": {"max-age=300"},
+		"X-Content-Type-Options":    {"nosniff"},
+		"X-Served-By":               {"cache-lcy19221-LCY"},
+	}
+}
@@ -0,0 +1,83 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// AddCommand adds a scheduled job.
+type AddCommand struct {
+	cmd.Base
+
+	command string
+	cron    string
+	name    string
+}
+
+// NewAddCommand returns a usable command registered under the parent.
+func NewAddCommand(parent cmd.Registerer, globals *config.Data) *AddCommand {
+	var c AddCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("add", "Add a CLI command to run on a cron schedule")
+	c.CmdClause.Flag("cron", "When to run the command, as a cron expression in local time (e.g. '0 3 * * *' for 3am every day)").Required().StringVar(&c.cron)
+	c.CmdClause.Flag("command", "The CLI command to run, without the leading 'fastly' (e.g. 'purge --all --service-id 123')").Required().StringVar(&c.command)
+	c.CmdClause.Flag("name", "The name of the job (defaults to job-N)").StringVar(&c.name)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *AddCommand) Exec(in io.Reader, out io.Writer) error {
+	cron, err := ParseCron(c.cron)
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       err,
+			Remediation: "Use the five field cron format of 'minute hour day-of-month month day-of-week', e.g. '0 3 * * *' for 3am every day.",
+		}
+	}
+
+	command := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(c.command), "fastly "))
+	if command == "" {
+		return fmt.Errorf("error parsing arguments: the --command flag must not be empty")
+	}
+
+	if c.Globals.File.Schedules == nil {
+		c.Globals.File.Schedules = make(config.Schedules)
+	}
+	name := c.name
+	if name == "" {
+		for n := 1; ; n++ {
+			name = fmt.Sprintf("job-%d", n)
+			if _, ok := c.Globals.File.Schedules[name]; !ok {
+				break
+			}
+		}
+	}
+	if _, ok := c.Globals.File.Schedules[name]; ok {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the scheduled job '%s' already exists", name),
+			Remediation: fmt.Sprintf("Use a different --name, or delete the job first with 'fastly schedule delete --name %s'.", name),
+		}
+	}
+
+	now := c.Globals.Clock.Now()
+	c.Globals.File.Schedules[name] = &config.Schedule{
+		Cron:    c.cron,
+		Command: command,
+		Added:   now.Format(time.RFC3339),
+	}
+	if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	text.Success(out, "Added scheduled job '%s', next run at %s", name, c.Globals.Formatter().Time(cron.Next(now)))
+	text.Info(out, "Jobs only run while 'fastly schedule run' is running, or when 'fastly schedule run --once' is run.")
+	return nil
+}
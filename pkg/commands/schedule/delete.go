@@ -0,0 +1,44 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// DeleteCommand deletes a scheduled job.
+type DeleteCommand struct {
+	cmd.Base
+
+	name string
+}
+
+// NewDeleteCommand returns a usable command registered under the parent.
+func NewDeleteCommand(parent cmd.Registerer, globals *config.Data) *DeleteCommand {
+	var c DeleteCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("delete", "Delete a scheduled job").Alias("remove")
+	c.CmdClause.Flag("name", "The name of the job").Short('n').Required().StringVar(&c.name)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
+	if _, ok := c.Globals.File.Schedules[c.name]; !ok {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the scheduled job '%s' does not exist", c.name),
+			Remediation: "Run 'fastly schedule list' to see the scheduled jobs.",
+		}
+	}
+	delete(c.Globals.File.Schedules, c.name)
+	if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	text.Success(out, "Deleted scheduled job '%s'", c.name)
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package schedule contains commands to run CLI commands on a cron schedule,
+// for environments without a scheduler of their own.
+package schedule
@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ListCommand lists the scheduled jobs.
+type ListCommand struct {
+	cmd.Base
+
+	json bool
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("list", "List the scheduled jobs")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Job describes a scheduled job.
+type Job struct {
+	Name       string `json:"name"`
+	Cron       string `json:"cron"`
+	Command    string `json:"command"`
+	NextRun    string `json:"next_run"`
+	LastRun    string `json:"last_run,omitempty"`
+	LastResult string `json:"last_result,omitempty"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	jobs := make([]Job, 0, len(c.Globals.File.Schedules))
+	for _, name := range names(c.Globals.File.Schedules) {
+		s := c.Globals.File.Schedules[name]
+		j := Job{
+			Name:       name,
+			Cron:       s.Cron,
+			Command:    s.Command,
+			LastRun:    s.LastRun,
+			LastResult: s.LastResult,
+		}
+		if cron, err := ParseCron(s.Cron); err != nil {
+			j.NextRun = "never (invalid cron expression)"
+		} else {
+			j.NextRun = c.Globals.Formatter().Time(nextRun(s, cron, c.Globals.Clock.Now()))
+		}
+		jobs = append(jobs, j)
+	}
+
+	if c.json {
+		data, err := json.Marshal(jobs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(jobs) == 0 {
+		text.Description(out, "No scheduled jobs. To add one, run", "fastly schedule add --cron '0 3 * * *' --command '<command>'")
+		return nil
+	}
+
+	tw := text.NewTable(out)
+	tw.AddHeader("NAME", "CRON", "COMMAND", "NEXT RUN", "LAST RUN", "LAST RESULT")
+	for _, j := range jobs {
+		tw.AddLine(j.Name, j.Cron, j.Command, j.NextRun, j.LastRun, j.LastResult)
+	}
+	tw.Print()
+	return nil
+}
+
+// names returns the names of the jobs in alphabetical order.
+func names(s config.Schedules) []string {
+	names := make([]string, 0, len(s))
+	for name, job := range s {
+		if job != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,164 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logger"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// Executable returns the path of the CLI binary that runs the jobs. It's a
+// variable so tests can run something else.
+var Executable = os.Executable
+
+// RunCommand runs the scheduled jobs when they're due.
+type RunCommand struct {
+	cmd.Base
+
+	once bool
+}
+
+// NewRunCommand returns a usable command registered under the parent.
+func NewRunCommand(parent cmd.Registerer, globals *config.Data) *RunCommand {
+	var c RunCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("run", "Run the scheduled jobs when they're due, until interrupted (e.g. with Ctrl-C)")
+	c.CmdClause.Flag("once", "Run the jobs that are due (including any missed since their last run) and exit, e.g. from a system scheduler").BoolVar(&c.once)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *RunCommand) Exec(in io.Reader, out io.Writer) error {
+	jobs := names(c.Globals.File.Schedules)
+	if len(jobs) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("there are no scheduled jobs"),
+			Remediation: "Add a job with 'fastly schedule add'.",
+		}
+	}
+
+	crons := make(map[string]Cron, len(jobs))
+	for _, name := range jobs {
+		cron, err := ParseCron(c.Globals.File.Schedules[name].Cron)
+		if err != nil {
+			return fmt.Errorf("error parsing scheduled job '%s': %w", name, err)
+		}
+		crons[name] = cron
+	}
+
+	if c.once {
+		ran, failed := c.runDue(out, jobs, crons)
+		if ran == 0 {
+			text.Info(out, "No scheduled jobs are due")
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d scheduled jobs failed", failed, ran)
+		}
+		return nil
+	}
+
+	text.Info(out, "Running %d scheduled jobs. Press Ctrl-C to stop.", len(jobs))
+	for {
+		now := c.Globals.Clock.Now()
+		var next time.Time
+		for _, name := range jobs {
+			t := nextRun(c.Globals.File.Schedules[name], crons[name], now)
+			if next.IsZero() || t.Before(next) {
+				next = t
+			}
+		}
+		if c.Globals.Verbose() {
+			text.Info(out, "Next job due at %s", c.Globals.Formatter().Time(next))
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-c.Globals.Context.Done():
+			return nil
+		}
+		c.runDue(out, jobs, crons)
+	}
+}
+
+// runDue runs the jobs that are due, recording the outcome of each in the
+// config file, and returns the number of jobs run and how many failed.
+func (c *RunCommand) runDue(out io.Writer, jobs []string, crons map[string]Cron) (ran, failed int) {
+	for _, name := range jobs {
+		s := c.Globals.File.Schedules[name]
+		now := c.Globals.Clock.Now()
+		if nextRun(s, crons[name], now).After(now) {
+			continue
+		}
+
+		ran++
+		text.Info(out, "%s: running job '%s': fastly %s", now.Format(time.RFC3339), name, s.Command)
+		err := runJob(s.Command, out)
+		s.LastRun = now.Format(time.RFC3339)
+		if err != nil {
+			failed++
+			s.LastResult = fmt.Sprintf("failed: %s", err)
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Job":     name,
+				"Command": s.Command,
+			})
+			text.Warning(out, "%s: job '%s' failed: %s", c.Globals.Clock.Now().Format(time.RFC3339), name, err)
+		} else {
+			s.LastResult = "succeeded"
+			c.Globals.Logger.Info("scheduled job succeeded", logger.Fields{
+				"job":     name,
+				"command": s.Command,
+			})
+			text.Success(out, "%s: job '%s' succeeded", c.Globals.Clock.Now().Format(time.RFC3339), name)
+		}
+
+		if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+			c.Globals.ErrLog.Add(err)
+			text.Warning(out, "Unable to record the result of job '%s': %s", name, err)
+		}
+	}
+	return ran, failed
+}
+
+// nextRun returns when the job is next due. A job that's been missed since it
+// last ran (or was added) is due at the time it was missed.
+func nextRun(s *config.Schedule, cron Cron, now time.Time) time.Time {
+	since := now
+	for _, v := range []string{s.LastRun, s.Added} {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			since = t.In(now.Location())
+			break
+		}
+	}
+	return cron.Next(since)
+}
+
+// runJob runs the CLI command via a subprocess shell, so the command can use
+// the same quoting as on the command line.
+func runJob(command string, out io.Writer) error {
+	exe, err := Executable()
+	if err != nil {
+		return fmt.Errorf("error determining executable path: %w", err)
+	}
+
+	name, args := "sh", []string{"-c", "'" + strings.ReplaceAll(exe, "'", `'\''`) + "' " + command}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd.exe", []string{"/C", `"` + exe + `" ` + command}
+	}
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the command is defined by the user in their own config.
+	/* #nosec */
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
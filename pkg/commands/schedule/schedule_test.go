@@ -0,0 +1,190 @@
+package schedule_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/commands/schedule"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+	toml "github.com/pelletier/go-toml"
+)
+
+func TestParseCron(t *testing.T) {
+	from := time.Date(2021, time.June, 15, 23, 0, 0, 0, time.UTC) // a Tuesday
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 3 * * *", time.Date(2021, time.June, 16, 3, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2021, time.June, 15, 23, 15, 0, 0, time.UTC)},
+		{"30 9-17/4 * * mon-fri", time.Date(2021, time.June, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2021, time.June, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, time.June, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,20 * fri", time.Date(2021, time.June, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := schedule.ParseCron(tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertEqual(t, tc.want, c.Next(from))
+		})
+	}
+
+	for expr, want := range map[string]string{
+		"0 3 * *":      "want 5 fields",
+		"60 * * * *":   "minute: invalid value '60': must be between 0 and 59",
+		"0 5-1 * * *":  "hour: invalid range '5-1'",
+		"*/0 * * * *":  "minute: invalid step '0'",
+		"0 0 31 feb *": "it never matches",
+	} {
+		_, err := schedule.ParseCron(expr)
+		testutil.AssertErrorContains(t, err, want)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	schedule.Executable = func() (string, error) { return "echo", nil }
+	defer func() {
+		schedule.Executable = os.Executable
+	}()
+
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		schedules     config.Schedules
+		wantSchedules config.Schedules
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate invalid cron expression",
+				Args:      []string{"schedule", "add", "--cron", "0 3 * *", "--command", "purge"},
+				WantError: "invalid cron expression '0 3 * *'",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate job is added with a default name",
+				Args:       []string{"schedule", "add", "--cron", "0 3 * * *", "--command", "fastly purge --all --service-id 123"},
+				WantOutput: "Added scheduled job 'job-2', next run at 2021-06-16 03:00:00 +0000 UTC",
+			},
+			schedules: config.Schedules{
+				"job-1": {Cron: "@daily", Command: "stats historical", Added: "2021-06-15T23:00:00Z"},
+			},
+			wantSchedules: config.Schedules{
+				"job-1": {Cron: "@daily", Command: "stats historical", Added: "2021-06-15T23:00:00Z"},
+				"job-2": {Cron: "0 3 * * *", Command: "purge --all --service-id 123", Added: "2021-06-15T23:00:00Z"},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate existing job name",
+				Args:      args("schedule add --cron @daily --command purge --name nightly"),
+				WantError: "the scheduled job 'nightly' already exists",
+			},
+			schedules: config.Schedules{
+				"nightly": {Cron: "@daily", Command: "stats historical"},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate list",
+				Args: args("schedule list"),
+				WantOutputs: []string{
+					"NAME     CRON       COMMAND           NEXT RUN                       LAST RUN              LAST RESULT",
+					"nightly  0 3 * * *  stats historical  2021-06-16 03:00:00 +0000 UTC  2021-06-15T03:00:00Z  succeeded",
+				},
+			},
+			schedules: config.Schedules{
+				"nightly": {Cron: "0 3 * * *", Command: "stats historical", Added: "2021-06-01T00:00:00Z", LastRun: "2021-06-15T03:00:00Z", LastResult: "succeeded"},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate delete",
+				Args:       args("schedule delete --name nightly"),
+				WantOutput: "Deleted scheduled job 'nightly'",
+			},
+			schedules: config.Schedules{
+				"nightly": {Cron: "0 3 * * *", Command: "stats historical"},
+			},
+			wantSchedules: config.Schedules{},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate run without jobs",
+				Args:      args("schedule run --once"),
+				WantError: "there are no scheduled jobs",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate run of due jobs",
+				Args: args("schedule run --once"),
+				WantOutputs: []string{
+					"running job 'missed': fastly purge --key 'nightly'",
+					"purge --key nightly",
+					"job 'missed' succeeded",
+				},
+			},
+			schedules: config.Schedules{
+				"missed":  {Cron: "0 3 * * *", Command: "purge --key 'nightly'", Added: "2021-06-14T00:00:00Z"},
+				"not-due": {Cron: "0 3 * * *", Command: "stats historical", Added: "2021-06-14T00:00:00Z", LastRun: "2021-06-15T03:00:00Z"},
+			},
+			wantSchedules: config.Schedules{
+				"missed":  {Cron: "0 3 * * *", Command: "purge --key 'nightly'", Added: "2021-06-14T00:00:00Z", LastRun: "2021-06-15T23:00:00Z", LastResult: "succeeded"},
+				"not-due": {Cron: "0 3 * * *", Command: "stats historical", Added: "2021-06-14T00:00:00Z", LastRun: "2021-06-15T03:00:00Z"},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate run with nothing due",
+				Args:       args("schedule run --once"),
+				WantOutput: "No scheduled jobs are due",
+			},
+			schedules: config.Schedules{
+				"nightly": {Cron: "0 3 * * *", Command: "stats historical", Added: "2021-06-15T22:00:00Z"},
+			},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.ConfigPath = configPath
+			opts.Clock = clock.Fixed(testutil.Date)
+			opts.ConfigFile = config.File{Schedules: testcase.schedules}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, want := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+
+			if testcase.wantSchedules != nil {
+				data, err := os.ReadFile(configPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var f config.File
+				if err := toml.Unmarshal(data, &f); err != nil {
+					t.Fatal(err)
+				}
+				if f.Schedules == nil {
+					f.Schedules = config.Schedules{}
+				}
+				testutil.AssertEqual(t, testcase.wantSchedules, f.Schedules)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package search contains commands to search for a string across the
+// resources of all services in a Fastly account.
+package search
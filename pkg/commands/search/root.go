@@ -0,0 +1,335 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Resources is the list of resource types that are searched.
+var Resources = []string{"domain", "backend", "snippet", "logging"}
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	json      bool
+	resources []string
+	term      string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("search", "Search service configuration across the account")
+	c.CmdClause.Arg("term", "The string to search for (case-insensitive)").Required().StringVar(&c.term)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("resource", "Limit the search to a resource type (domain, backend, snippet, logging)").HintOptions(Resources...).EnumsVar(&c.resources, Resources...)
+	return &c
+}
+
+// Match represents a resource field that contains the search term.
+type Match struct {
+	ServiceID      string `json:"service_id"`
+	ServiceName    string `json:"service_name"`
+	ServiceVersion int    `json:"service_version"`
+	Resource       string `json:"resource"`
+	Name           string `json:"name"`
+	Field          string `json:"field"`
+	Value          string `json:"value"`
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	paginator := c.Globals.APIClient.NewListServicesPaginator(&fastly.ListServicesInput{})
+
+	var services []*fastly.Service
+	for paginator.HasNext() {
+		data, err := paginator.GetNext()
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Remaining Pages": paginator.Remaining(),
+			})
+			return err
+		}
+		services = append(services, data...)
+		c.Globals.Diagnostic(config.VerboseLevelTimings, "Fetched %d services (%d total, %d pages remaining)", len(data), len(services), paginator.Remaining())
+	}
+
+	resources := c.resources
+	if len(resources) == 0 {
+		resources = Resources
+	}
+
+	// Services are searched concurrently, as each needs several API calls, but
+	// the results are reported in the order the services were listed.
+	results := make([][]Match, len(services))
+	searched := make([]bool, len(services))
+	failed := make([]string, len(services))
+	cache := snapshot.NewCache(c.Globals.APIClient)
+	err := snapshot.ForEach(len(services), snapshot.Concurrency, func(i int) error {
+		s := services[i]
+		version := searchableVersion(s)
+		if version == 0 {
+			searched[i] = true
+			return nil
+		}
+		snap := cache.Get(s.ID, version)
+		var matches []Match
+		for _, r := range resources {
+			fields, err := scanners[r](snap)
+			if err != nil {
+				failed[i] = r
+				return err
+			}
+			for _, f := range fields {
+				if contains(f.value, c.term) {
+					matches = append(matches, Match{
+						ServiceID:      s.ID,
+						ServiceName:    s.Name,
+						ServiceVersion: version,
+						Resource:       f.resource,
+						Name:           f.name,
+						Field:          f.field,
+						Value:          f.value,
+					})
+				}
+			}
+		}
+		results[i], searched[i] = matches, true
+		return nil
+	})
+	// If the search is interrupted, the matches in the services that were
+	// searched are still shown.
+	interrupted := err != nil && c.Globals.Context.Err() != nil
+	if err != nil && !interrupted {
+		for i, r := range failed {
+			if r != "" {
+				c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+					"Service ID":      services[i].ID,
+					"Service Version": searchableVersion(services[i]),
+					"Resource":        r,
+				})
+				break
+			}
+		}
+		return err
+	}
+
+	var (
+		matches []Match
+		count   int
+	)
+	for i, s := range services {
+		if !searched[i] {
+			continue
+		}
+		count++
+		if c.Globals.Verbose() {
+			if version := searchableVersion(s); version == 0 {
+				text.Info(out, "Skipping service %s (%s): no versions found", s.Name, s.ID)
+			} else {
+				text.Info(out, "Searched service %s (%s) version %d", s.Name, s.ID, version)
+			}
+		}
+		matches = append(matches, results[i]...)
+	}
+
+	if err := c.print(out, matches); err != nil {
+		return err
+	}
+	if interrupted {
+		if !c.json {
+			text.Break(out)
+			text.Warning(out, "Only %d of %d services were searched.", count, len(services))
+		}
+		return fsterr.ErrInterrupted
+	}
+	return nil
+}
+
+// print displays the matches.
+func (c *RootCommand) print(out io.Writer, matches []Match) error {
+	if c.json {
+		data, err := json.Marshal(matches)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if c.Globals.Verbose() {
+		text.Break(out)
+	}
+	if len(matches) == 0 {
+		text.Info(out, "No resources matched '%s'", c.term)
+		return nil
+	}
+
+	tw := text.NewTable(out)
+	tw.AddHeader("SERVICE NAME", "SERVICE ID", "VERSION", "RESOURCE", "NAME", "FIELD", "VALUE")
+	for _, m := range matches {
+		tw.AddLine(m.ServiceName, m.ServiceID, m.ServiceVersion, m.Resource, m.Name, m.Field, summarise(m.Value, c.term))
+	}
+	tw.Print()
+	return nil
+}
+
+// searchableVersion returns the active service version, falling back to the
+// most recent version for services that have never been activated.
+func searchableVersion(s *fastly.Service) int {
+	if s.ActiveVersion > 0 {
+		return int(s.ActiveVersion)
+	}
+	var latest int
+	for _, v := range s.Versions {
+		if v.Number > latest {
+			latest = v.Number
+		}
+	}
+	return latest
+}
+
+// contains reports whether term is within s, ignoring case.
+func contains(s, term string) bool {
+	return s != "" && strings.Contains(strings.ToLower(s), strings.ToLower(term))
+}
+
+// summarise reduces multi-line values (e.g. snippet content) to the first line
+// that contains the search term so the table output remains readable.
+func summarise(value, term string) string {
+	if !strings.Contains(value, "\n") {
+		return value
+	}
+	for _, line := range strings.Split(value, "\n") {
+		if contains(line, term) {
+			return strings.TrimSpace(line)
+		}
+	}
+	return value
+}
+
+// field is a searchable value belonging to a service resource.
+type field struct {
+	resource string
+	name     string
+	field    string
+	value    string
+}
+
+// scanner returns the searchable fields of a single resource type.
+type scanner func(snap *snapshot.Snapshot) ([]field, error)
+
+var scanners = map[string]scanner{
+	"domain":  scanDomains,
+	"backend": scanBackends,
+	"snippet": scanSnippets,
+	"logging": scanLogging,
+}
+
+func scanDomains(snap *snapshot.Snapshot) ([]field, error) {
+	domains, err := snap.Domains()
+	if err != nil {
+		return nil, err
+	}
+	var fields []field
+	for _, d := range domains {
+		fields = append(fields, field{"domain", d.Name, "name", d.Name})
+	}
+	return fields, nil
+}
+
+func scanBackends(snap *snapshot.Snapshot) ([]field, error) {
+	backends, err := snap.Backends()
+	if err != nil {
+		return nil, err
+	}
+	var fields []field
+	for _, b := range backends {
+		fields = append(fields,
+			field{"backend", b.Name, "address", b.Address},
+			field{"backend", b.Name, "override_host", b.OverrideHost},
+			field{"backend", b.Name, "ssl_cert_hostname", b.SSLCertHostname},
+			field{"backend", b.Name, "ssl_sni_hostname", b.SSLSNIHostname},
+		)
+	}
+	return fields, nil
+}
+
+func scanSnippets(snap *snapshot.Snapshot) ([]field, error) {
+	snippets, err := snap.Snippets()
+	if err != nil {
+		return nil, err
+	}
+	var fields []field
+	for _, s := range snippets {
+		fields = append(fields, field{"snippet", s.Name, "content", s.Content})
+	}
+	return fields, nil
+}
+
+// scanLogging searches the logging endpoints that reference a remote host.
+func scanLogging(snap *snapshot.Snapshot) ([]field, error) {
+	if err := snap.Fetch("https", "syslog", "splunk", "elasticsearch", "kafka"); err != nil {
+		return nil, err
+	}
+
+	var fields []field
+
+	https, err := snap.HTTPS()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range https {
+		fields = append(fields, field{"logging/https", l.Name, "url", l.URL})
+	}
+
+	syslogs, err := snap.Syslogs()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range syslogs {
+		fields = append(fields, field{"logging/syslog", l.Name, "address", l.Address})
+	}
+
+	splunks, err := snap.Splunks()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range splunks {
+		fields = append(fields, field{"logging/splunk", l.Name, "url", l.URL})
+	}
+
+	elasticsearch, err := snap.Elasticsearch()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range elasticsearch {
+		fields = append(fields, field{"logging/elasticsearch", l.Name, "url", l.URL})
+	}
+
+	kafkas, err := snap.Kafkas()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range kafkas {
+		fields = append(fields, field{"logging/kafka", l.Name, "brokers", l.Brokers})
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,189 @@
+package search_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestSearch(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing term argument",
+			Args:      args("search"),
+			WantError: "error parsing arguments: required argument 'term' not provided",
+		},
+		{
+			Name: "validate list services error",
+			Args: args("search origin.example.com"),
+			API: mock.API{
+				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
+					return &servicesPaginator{err: testutil.Err}
+				},
+			},
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate matches across resources",
+			Args: args("search ORIGIN.example.com"),
+			API: mock.API{
+				NewListServicesPaginatorFn: listServices,
+				ListDomainsFn:              listDomains,
+				ListBackendsFn:             listBackends,
+				ListSnippetsFn:             listSnippets,
+				ListHTTPSFn:                listHTTPS,
+				ListSyslogsFn:              listSyslogs,
+				ListSplunksFn:              listSplunks,
+				ListElasticsearchFn:        listElasticsearch,
+				ListKafkasFn:               listKafkas,
+			},
+			WantOutputs: []string{
+				"SERVICE NAME  SERVICE ID  VERSION  RESOURCE       NAME     FIELD    VALUE",
+				"Foo           123         2        backend        origin1  address  origin.example.com",
+				"Bar           456         1        snippet        proxy    content  set req.http.host = \"origin.example.com\";",
+				"Bar           456         1        logging/https  logs     url      https://origin.example.com/logs",
+			},
+		},
+		{
+			Name: "validate resource filter",
+			Args: args("search origin.example.com --resource domain"),
+			API: mock.API{
+				NewListServicesPaginatorFn: listServices,
+				ListDomainsFn:              listDomains,
+			},
+			WantOutput: "No resources matched 'origin.example.com'",
+		},
+		{
+			Name: "validate JSON output",
+			Args: args("search www.example.com --resource domain --json"),
+			API: mock.API{
+				NewListServicesPaginatorFn: listServices,
+				ListDomainsFn:              listDomains,
+			},
+			WantOutput: `[{"service_id":"123","service_name":"Foo","service_version":2,"resource":"domain","name":"www.example.com","field":"name","value":"www.example.com"}]`,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, s := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+// TestSearchInterrupted validates that the matches in the services searched
+// before the search was interrupted are shown.
+func TestSearchInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("search www.example.com --resource domain"), &stdout)
+	opts.Context = ctx
+	opts.APIClient = mock.APIClient(mock.API{
+		NewListServicesPaginatorFn: listServices,
+		ListDomainsFn: func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+			if i.ServiceID == "456" {
+				cancel()
+				return nil, context.Canceled
+			}
+			return listDomains(i)
+		},
+	})
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "the command was interrupted")
+	testutil.AssertStringContains(t, stdout.String(), "Foo           123         2        domain    www.example.com  name   www.example.com")
+	testutil.AssertStringContains(t, stdout.String(), "Only 2 of 3 services were searched.")
+}
+
+type servicesPaginator struct {
+	done bool
+	err  error
+}
+
+func (p *servicesPaginator) HasNext() bool {
+	return !p.done
+}
+
+func (p servicesPaginator) Remaining() int {
+	return 0
+}
+
+func (p *servicesPaginator) GetNext() ([]*fastly.Service, error) {
+	p.done = true
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []*fastly.Service{
+		{ID: "123", Name: "Foo", ActiveVersion: 2},
+		{ID: "456", Name: "Bar", Versions: []*fastly.Version{{Number: 1}}},
+		{ID: "789", Name: "Baz"},
+	}, nil
+}
+
+func listServices(i *fastly.ListServicesInput) fastly.PaginatorServices {
+	return &servicesPaginator{}
+}
+
+func listDomains(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	if i.ServiceID != "123" {
+		return nil, nil
+	}
+	return []*fastly.Domain{{Name: "www.example.com"}}, nil
+}
+
+func listBackends(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	if i.ServiceID != "123" {
+		return nil, nil
+	}
+	return []*fastly.Backend{
+		{Name: "origin1", Address: "origin.example.com"},
+		{Name: "origin2", Address: "other.example.com"},
+	}, nil
+}
+
+func listSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	if i.ServiceID != "456" {
+		return nil, nil
+	}
+	return []*fastly.Snippet{
+		{Name: "proxy", Content: "if (req.url ~ \"^/api\") {\n  set req.http.host = \"origin.example.com\";\n}"},
+	}, nil
+}
+
+func listHTTPS(i *fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) {
+	if i.ServiceID != "456" {
+		return nil, nil
+	}
+	return []*fastly.HTTPS{{Name: "logs", URL: "https://origin.example.com/logs"}}, nil
+}
+
+func listSyslogs(i *fastly.ListSyslogsInput) ([]*fastly.Syslog, error) {
+	return nil, nil
+}
+
+func listSplunks(i *fastly.ListSplunksInput) ([]*fastly.Splunk, error) {
+	return nil, nil
+}
+
+func listElasticsearch(i *fastly.ListElasticsearchInput) ([]*fastly.Elasticsearch, error) {
+	return nil, nil
+}
+
+func listKafkas(i *fastly.ListKafkasInput) ([]*fastly.Kafka, error) {
+	return nil, nil
+}
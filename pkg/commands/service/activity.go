@@ -0,0 +1,368 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ActivationEvents are the event types reported as activations. All other
+// service events are reported as configuration changes.
+var ActivationEvents = []string{"version.activate", "version.deactivate"}
+
+// ActivityCommand summarises recent events and traffic for one or all services.
+type ActivityCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	allServices bool
+	json        bool
+	serviceName cmd.OptionalServiceNameID
+	since       string
+}
+
+// NewActivityCommand returns a usable command registered under the parent.
+func NewActivityCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *ActivityCommand {
+	var c ActivityCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("activity", "Summarise recent activations, configuration changes and traffic for a Fastly service")
+	c.CmdClause.Flag("all-services", "Report on every service in the account").BoolVar(&c.allServices)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("since", "How far back to report, e.g. 7d, 2w or 12h").Default("7d").Action(cmd.Validate(cmd.ValidateLongDuration)).StringVar(&c.since)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ActivityCommand) Exec(in io.Reader, out io.Writer) error {
+	since, err := cmd.ParseLongDuration(c.since)
+	if err != nil {
+		return fmt.Errorf("invalid --since '%s': %w", c.since, err)
+	}
+	now := c.Globals.Clock.Now().UTC()
+	from := now.Add(-since)
+
+	services, err := c.services(out)
+	if err != nil {
+		return err
+	}
+
+	events, err := c.events(services, from)
+	if err != nil {
+		return err
+	}
+
+	reports := make([]Activity, 0, len(services))
+	for _, s := range services {
+		a := Activity{
+			ServiceID:   s.ID,
+			ServiceName: s.Name,
+			From:        from,
+			To:          now,
+		}
+		for _, e := range events[s.ID] {
+			if isActivation(e.Type) {
+				a.Activations = append(a.Activations, e)
+			} else {
+				a.Changes = append(a.Changes, e)
+			}
+		}
+		a.Current, err = c.traffic(s.ID, from, now)
+		if err != nil {
+			return err
+		}
+		a.Previous, err = c.traffic(s.ID, from.Add(-since), from)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, a)
+	}
+
+	if c.json {
+		data, err := json.Marshal(reports)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	var quiet []string
+	for _, a := range reports {
+		if c.allServices && a.Quiet() {
+			quiet = append(quiet, a.ServiceName)
+			continue
+		}
+		printActivity(out, a, c.since, c.Globals.Formatter())
+	}
+	if len(quiet) > 0 {
+		text.Info(out, "%d services had no activity or traffic: %s", len(quiet), strings.Join(quiet, ", "))
+	}
+	return nil
+}
+
+// services returns the services to report on, sorted by name.
+func (c *ActivityCommand) services(out io.Writer) ([]*fastly.Service, error) {
+	if c.allServices {
+		services, err := c.Globals.APIClient.ListServices(&fastly.ListServicesInput{})
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return nil, err
+		}
+		sort.Slice(services, func(i, j int) bool {
+			return services[i].Name < services[j].Name
+		})
+		return services, nil
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return nil, err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+	s, err := c.Globals.APIClient.GetService(&fastly.GetServiceInput{ID: serviceID})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return nil, err
+	}
+	return []*fastly.Service{s}, nil
+}
+
+// events returns the events created since from, grouped by service ID and
+// sorted oldest first.
+func (c *ActivityCommand) events(services []*fastly.Service, from time.Time) (map[string][]ActivityEvent, error) {
+	input := fastly.GetAPIEventsFilterInput{MaxResults: 100}
+	if !c.allServices {
+		input.ServiceID = services[0].ID
+	}
+
+	grouped := make(map[string][]ActivityEvent)
+	for input.PageNumber = 1; ; input.PageNumber++ {
+		resp, err := c.Globals.APIClient.GetAPIEvents(&input)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": input.ServiceID,
+				"Page":       input.PageNumber,
+			})
+			return nil, err
+		}
+		for _, e := range resp.Events {
+			if e.ServiceID == "" || e.CreatedAt == nil || e.CreatedAt.Before(from) {
+				continue
+			}
+			grouped[e.ServiceID] = append(grouped[e.ServiceID], ActivityEvent{
+				Type:        e.EventType,
+				Description: e.Description,
+				UserID:      e.UserID,
+				CreatedAt:   e.CreatedAt.UTC(),
+			})
+		}
+		if resp.Links.Next == "" || len(resp.Events) == 0 {
+			break
+		}
+	}
+
+	for _, events := range grouped {
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		})
+	}
+	return grouped, nil
+}
+
+// traffic returns the service's aggregated stats between from and to.
+func (c *ActivityCommand) traffic(serviceID string, from, to time.Time) (Traffic, error) {
+	by := "day"
+	if to.Sub(from) < 48*time.Hour {
+		by = "hour"
+	}
+	input := fastly.GetStatsInput{
+		Service: serviceID,
+		From:    strconv.FormatInt(from.Unix(), 10),
+		To:      strconv.FormatInt(to.Unix(), 10),
+		By:      by,
+	}
+
+	var envelope struct {
+		Status string `json:"status"`
+		Msg    string `json:"msg"`
+		Data   []struct {
+			Requests  uint64 `json:"requests"`
+			Hits      uint64 `json:"hits"`
+			Misses    uint64 `json:"miss"`
+			Status5xx uint64 `json:"status_5xx"`
+			Bandwidth uint64 `json:"bandwidth"`
+		} `json:"data"`
+	}
+	err := c.Globals.APIClient.GetStatsJSON(&input, &envelope)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+			"From":       input.From,
+			"To":         input.To,
+		})
+		return Traffic{}, err
+	}
+	if envelope.Status != "success" {
+		return Traffic{}, fmt.Errorf("non-success response: %s", envelope.Msg)
+	}
+
+	var t Traffic
+	for _, d := range envelope.Data {
+		t.Requests += d.Requests
+		t.Hits += d.Hits
+		t.Misses += d.Misses
+		t.Status5xx += d.Status5xx
+		t.Bandwidth += d.Bandwidth
+	}
+	return t, nil
+}
+
+// Activity summarises a service's events and traffic over a period, along
+// with its traffic over the period immediately before it.
+type Activity struct {
+	ServiceID   string          `json:"service_id"`
+	ServiceName string          `json:"service_name"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	Activations []ActivityEvent `json:"activations"`
+	Changes     []ActivityEvent `json:"changes"`
+	Current     Traffic         `json:"current"`
+	Previous    Traffic         `json:"previous"`
+}
+
+// Quiet reports whether the service had no events and no traffic.
+func (a Activity) Quiet() bool {
+	return len(a.Activations) == 0 && len(a.Changes) == 0 && a.Current.Requests == 0 && a.Previous.Requests == 0
+}
+
+// ActivityEvent is a single event recorded against a service.
+type ActivityEvent struct {
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Traffic is the aggregated stats of a service over a period.
+type Traffic struct {
+	Requests  uint64 `json:"requests"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Status5xx uint64 `json:"status_5xx"`
+	Bandwidth uint64 `json:"bandwidth"`
+}
+
+// HitRatio returns the percentage of cache lookups that were hits, and false
+// if there were no lookups.
+func (t Traffic) HitRatio() (float64, bool) {
+	if t.Hits+t.Misses == 0 {
+		return 0, false
+	}
+	return float64(t.Hits) / float64(t.Hits+t.Misses) * 100, true
+}
+
+func isActivation(eventType string) bool {
+	for _, t := range ActivationEvents {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func printActivity(out io.Writer, a Activity, since string, f text.Formatter) {
+	text.Output(out, "%s (%s), last %s", a.ServiceName, a.ServiceID, since)
+
+	text.Output(out, "Activations: %d", len(a.Activations))
+	for _, e := range a.Activations {
+		text.Output(out, "  %s  %s", f.UTCTime(e.CreatedAt), e.Description)
+	}
+
+	counts := make(map[string]int)
+	for _, e := range a.Changes {
+		counts[e.Type]++
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	summary := make([]string, 0, len(types))
+	for _, t := range types {
+		summary = append(summary, fmt.Sprintf("%s %d", t, counts[t]))
+	}
+	if len(summary) > 0 {
+		text.Output(out, "Configuration changes: %d (%s)", len(a.Changes), strings.Join(summary, ", "))
+	} else {
+		text.Output(out, "Configuration changes: 0")
+	}
+
+	text.Break(out)
+	t := text.NewTable(out)
+	t.AddHeader("METRIC", "PREVIOUS", "CURRENT", "CHANGE")
+	t.AddLine("Requests", a.Previous.Requests, a.Current.Requests, delta(a.Previous.Requests, a.Current.Requests))
+	prevRatio, prevOK := a.Previous.HitRatio()
+	curRatio, curOK := a.Current.HitRatio()
+	t.AddLine("Hit ratio", ratio(prevRatio, prevOK), ratio(curRatio, curOK), pointDelta(prevRatio, prevOK, curRatio, curOK))
+	t.AddLine("5xx", a.Previous.Status5xx, a.Current.Status5xx, delta(a.Previous.Status5xx, a.Current.Status5xx))
+	t.AddLine("Bandwidth", f.Bytes(a.Previous.Bandwidth), f.Bytes(a.Current.Bandwidth), delta(a.Previous.Bandwidth, a.Current.Bandwidth))
+	t.Print()
+	text.Break(out)
+}
+
+// delta formats the percentage change from prev to cur.
+func delta(prev, cur uint64) string {
+	if prev == 0 {
+		if cur == 0 {
+			return "0.0%"
+		}
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", (float64(cur)-float64(prev))/float64(prev)*100)
+}
+
+func ratio(r float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", r)
+}
+
+// pointDelta formats the change between two percentages in percentage points.
+func pointDelta(prev float64, prevOK bool, cur float64, curOK bool) string {
+	if !prevOK || !curOK {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1fpp", cur-prev)
+}
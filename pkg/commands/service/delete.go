@@ -45,7 +45,7 @@ func NewDeleteCommand(parent cmd.Registerer, globals *config.Data, data manifest
 
 // Exec invokes the application logic for the command.
 func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -55,6 +55,18 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 
 	c.Input.ID = serviceID
 
+	label := fmt.Sprintf("Are you sure you want to delete service %s? This cannot be undone. [y/N] ", serviceID)
+	cont, err := cmd.Confirm(cmd.ConfirmIrreversible, label, c.Globals, in, out)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+	if !cont {
+		return errors.ErrConfirmationDeclined
+	}
+
 	if c.force {
 		s, err := c.Globals.APIClient.GetServiceDetails(&fastly.GetServiceInput{
 			ID: serviceID,
@@ -11,7 +11,6 @@ import (
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/cli/pkg/time"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -53,11 +52,7 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -111,24 +106,24 @@ func (c *DescribeCommand) print(s *fastly.ServiceDetail, out io.Writer) error {
 	}
 	fmt.Fprintf(out, "Customer ID: %s\n", s.CustomerID)
 	if s.CreatedAt != nil {
-		fmt.Fprintf(out, "Created (UTC): %s\n", s.CreatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Created (UTC): %s\n", c.Globals.Formatter().UTCTime(*s.CreatedAt))
 	}
 	if s.UpdatedAt != nil {
-		fmt.Fprintf(out, "Last edited (UTC): %s\n", s.UpdatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Last edited (UTC): %s\n", c.Globals.Formatter().UTCTime(*s.UpdatedAt))
 	}
 	if s.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted (UTC): %s\n", s.DeletedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Deleted (UTC): %s\n", c.Globals.Formatter().UTCTime(*s.DeletedAt))
 	}
 	if s.ActiveVersion.Active {
 		fmt.Fprintf(out, "Active version:\n")
-		text.PrintVersion(out, "\t", &s.ActiveVersion)
+		text.PrintVersion(out, "\t", &s.ActiveVersion, c.Globals.Formatter())
 	} else {
 		fmt.Fprintf(out, "Active version: %s\n", activeVersion)
 	}
 	fmt.Fprintf(out, "Versions: %d\n", len(s.Versions))
 	for j, version := range s.Versions {
 		fmt.Fprintf(out, "\tVersion %d/%d\n", j+1, len(s.Versions))
-		text.PrintVersion(out, "\t\t", version)
+		text.PrintVersion(out, "\t\t", version, c.Globals.Formatter())
 	}
 	return nil
 }
@@ -7,17 +7,16 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
-	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/cli/pkg/time"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // ListCommand calls the Fastly API to list services.
 type ListCommand struct {
 	cmd.Base
-	input fastly.ListServicesInput
-	json  bool
+	input    fastly.ListServicesInput
+	json     bool
+	maxItems int
 }
 
 // NewListCommand returns a usable command registered under the parent.
@@ -32,6 +31,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
 		Dst:         &c.json,
 		Short:       'j',
 	})
+	c.CmdClause.Flag("max-items", "Maximum number of services to list").IntVar(&c.maxItems)
 	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.input.Page)
 	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.input.PerPage)
 	c.CmdClause.Flag("sort", "Field on which to sort").Default("created").StringVar(&c.input.Sort)
@@ -40,22 +40,23 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	paginator := c.Globals.APIClient.NewListServicesPaginator(&c.input)
-
-	var ss []*fastly.Service
-	for paginator.HasNext() {
-		data, err := paginator.GetNext()
-		if err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
-				"Remaining Pages": paginator.Remaining(),
-			})
-			return err
-		}
-		ss = append(ss, data...)
+	ss, err := cmd.Paginate(func(page int) cmd.Paginator[*fastly.Service] {
+		input := c.input
+		input.Page = page
+		return c.Globals.APIClient.NewListServicesPaginator(&input)
+	}, cmd.PaginateOpts{
+		Page:     c.input.Page,
+		MaxItems: c.maxItems,
+		Fetched: func(page, items int) {
+			c.Globals.Diagnostic(config.VerboseLevelTimings, "Fetched %d services (page %d)", items, page)
+		},
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Page":     c.input.Page,
+			"Per Page": c.input.PerPage,
+		})
+		return err
 	}
 
 	if !c.Globals.Verbose() {
@@ -73,7 +74,7 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 		for _, service := range ss {
 			updatedAt := "n/a"
 			if service.UpdatedAt != nil {
-				updatedAt = service.UpdatedAt.UTC().Format(time.Format)
+				updatedAt = c.Globals.Formatter().UTCTime(*service.UpdatedAt)
 			}
 
 			activeVersion := fmt.Sprint(service.ActiveVersion)
@@ -91,7 +92,7 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 
 	for i, service := range ss {
 		fmt.Fprintf(out, "Service %d/%d\n", i+1, len(ss))
-		text.PrintService(out, "\t", service)
+		text.PrintService(out, "\t", service, c.Globals.Formatter())
 		fmt.Fprintln(out)
 	}
 
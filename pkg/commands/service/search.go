@@ -37,6 +37,6 @@ func (c *SearchCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
-	text.PrintService(out, "", service)
+	text.PrintService(out, "", service, c.Globals.Formatter())
 	return nil
 }
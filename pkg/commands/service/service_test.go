@@ -2,14 +2,20 @@ package service_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
@@ -71,30 +77,7 @@ func TestServiceCreate(t *testing.T) {
 	}
 }
 
-type mockServicesPaginator struct {
-	count         int
-	maxPages      int
-	numOfPages    int
-	requestedPage int
-	returnErr     bool
-}
-
-func (p *mockServicesPaginator) HasNext() bool {
-	if p.count > p.maxPages {
-		return false
-	}
-	p.count++
-	return true
-}
-
-func (p mockServicesPaginator) Remaining() int {
-	return 1
-}
-
-func (p *mockServicesPaginator) GetNext() (ss []*fastly.Service, err error) {
-	if p.returnErr {
-		err = testutil.Err
-	}
+func listServicesPaginator(i *fastly.ListServicesInput) fastly.PaginatorServices {
 	pageOne := fastly.Service{
 		ID:            "123",
 		Name:          "Foo",
@@ -137,19 +120,7 @@ func (p *mockServicesPaginator) GetNext() (ss []*fastly.Service, err error) {
 		CustomerID:    "mycustomerid",
 		ActiveVersion: 1,
 	}
-	if p.count == 1 {
-		ss = append(ss, &pageOne)
-	}
-	if p.count == 2 {
-		ss = append(ss, &pageTwo)
-	}
-	if p.count == 3 {
-		ss = append(ss, &pageThree)
-	}
-	if p.requestedPage > 0 && p.numOfPages == 1 {
-		p.count = p.maxPages + 1 // forces only one result to be displayed
-	}
-	return ss, err
+	return testutil.NewPaginator(i.Page, []*fastly.Service{&pageOne}, []*fastly.Service{&pageTwo}, []*fastly.Service{&pageThree})
 }
 
 func TestServiceList(t *testing.T) {
@@ -163,7 +134,9 @@ func TestServiceList(t *testing.T) {
 		{
 			api: mock.API{
 				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
-					return &mockServicesPaginator{returnErr: true}
+					p := testutil.NewPaginator[*fastly.Service](i.Page)
+					p.Err = testutil.Err
+					return p
 				},
 			},
 			args:      args("service list"),
@@ -173,9 +146,7 @@ func TestServiceList(t *testing.T) {
 		// --per-page 1 we expect the final output to display both items.
 		{
 			api: mock.API{
-				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
-					return &mockServicesPaginator{numOfPages: i.PerPage, maxPages: 3}
-				},
+				NewListServicesPaginatorFn: listServicesPaginator,
 			},
 			args:       args("service list --per-page 1"),
 			wantOutput: listServicesShortOutput,
@@ -184,9 +155,7 @@ func TestServiceList(t *testing.T) {
 		// displayed per page we expect only the first record to be displayed.
 		{
 			api: mock.API{
-				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
-					return &mockServicesPaginator{count: i.Page - 1, requestedPage: i.Page, numOfPages: i.PerPage, maxPages: 3}
-				},
+				NewListServicesPaginatorFn: listServicesPaginator,
 			},
 			args:       args("service list --page 1 --per-page 1"),
 			wantOutput: listServicesShortOutputPageOne,
@@ -195,18 +164,23 @@ func TestServiceList(t *testing.T) {
 		// displayed per page we expect only the second record to be displayed.
 		{
 			api: mock.API{
-				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
-					return &mockServicesPaginator{count: i.Page - 1, requestedPage: i.Page, numOfPages: i.PerPage, maxPages: 3}
-				},
+				NewListServicesPaginatorFn: listServicesPaginator,
 			},
 			args:       args("service list --page 2 --per-page 1"),
 			wantOutput: listServicesShortOutputPageTwo,
 		},
+		// In the following test, we set --max-items 2 and as there's only one
+		// record per page we expect only the first two pages to be displayed.
 		{
 			api: mock.API{
-				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
-					return &mockServicesPaginator{maxPages: 3}
-				},
+				NewListServicesPaginatorFn: listServicesPaginator,
+			},
+			args:       args("service list --per-page 1 --max-items 2"),
+			wantOutput: listServicesShortOutputMaxItems,
+		},
+		{
+			api: mock.API{
+				NewListServicesPaginatorFn: listServicesPaginator,
 			},
 			args:       args("service list --verbose"),
 			wantOutput: listServicesVerboseOutput,
@@ -228,6 +202,7 @@ func TestServiceDescribe(t *testing.T) {
 	for _, testcase := range []struct {
 		args       []string
 		api        mock.API
+		profiles   config.Profiles
 		wantError  string
 		wantOutput string
 	}{
@@ -266,11 +241,29 @@ func TestServiceDescribe(t *testing.T) {
 			api:       mock.API{GetServiceDetailsFn: describeServiceError},
 			wantError: errTest.Error(),
 		},
+		{
+			args: args("service describe"),
+			api:  mock.API{GetServiceDetailsFn: describeServiceOK},
+			profiles: config.Profiles{
+				"work": &config.Profile{Default: true, Token: "123", ServiceID: "123"},
+			},
+			wantOutput: describeServiceShortOutput,
+		},
+		{
+			args: args("service describe --profile personal"),
+			api:  mock.API{GetServiceDetailsFn: describeServiceOK},
+			profiles: config.Profiles{
+				"personal": &config.Profile{Token: "456"},
+				"work":     &config.Profile{Default: true, Token: "123", ServiceID: "123"},
+			},
+			wantError: "error reading service: no service ID found",
+		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
 			opts.APIClient = mock.APIClient(testcase.api)
+			opts.ConfigFile.Profiles = testcase.profiles
 			err := app.Run(opts)
 			testutil.AssertErrorContains(t, err, testcase.wantError)
 			testutil.AssertString(t, testcase.wantOutput, stdout.String())
@@ -393,36 +386,41 @@ func TestServiceDelete(t *testing.T) {
 		expectEmptyServiceID bool
 	}{
 		{
-			args:      args("service delete"),
+			args:      args("service delete --confirm-irreversible"),
 			api:       mock.API{DeleteServiceFn: deleteServiceOK},
 			manifest:  "fastly-no-serviceid.toml",
 			wantError: "error reading service: no service ID found",
 		},
 		{
-			args:                 args("service delete"),
+			args:                 args("service delete --confirm-irreversible"),
 			api:                  mock.API{DeleteServiceFn: deleteServiceOK},
 			manifest:             "fastly-valid.toml",
 			wantOutput:           "Deleted service ID 123",
 			expectEmptyServiceID: true,
 		},
 		{
-			args:       args("service delete --service-id 001"),
+			args:       args("service delete --confirm-irreversible --service-id 001"),
 			api:        mock.API{DeleteServiceFn: deleteServiceOK},
 			wantOutput: "Deleted service ID 001",
 		},
 		{
-			args:                 args("service delete --service-id 001"),
+			args:                 args("service delete --confirm-irreversible --service-id 001"),
 			api:                  mock.API{DeleteServiceFn: deleteServiceOK},
 			manifest:             "fastly-valid.toml",
 			wantOutput:           "Deleted service ID 001",
 			expectEmptyServiceID: false,
 		},
 		{
-			args:      args("service delete --service-id 001"),
+			args:      args("service delete --confirm-irreversible --service-id 001"),
 			api:       mock.API{DeleteServiceFn: deleteServiceError},
 			manifest:  "fastly-valid.toml",
 			wantError: errTest.Error(),
 		},
+		{
+			args:      args("service delete --non-interactive --service-id 001"),
+			api:       mock.API{DeleteServiceFn: deleteServiceOK},
+			wantError: "irreversible operation requires confirmation",
+		},
 	} {
 		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
 			// We're going to chdir to an temp environment,
@@ -485,6 +483,75 @@ func TestServiceDelete(t *testing.T) {
 	}
 }
 
+func TestServiceActivity(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args       []string
+		api        mock.API
+		wantError  string
+		wantOutput []string
+	}{
+		{
+			args:      args("service activity --service-id 12345 --since 7x"),
+			wantError: "invalid --since '7x'",
+		},
+		{
+			args: args("service activity --service-id 12345"),
+			api: mock.API{
+				GetServiceFn:   getServiceOK,
+				GetAPIEventsFn: getAPIEventsOK,
+				GetStatsJSONFn: getActivityStatsOK,
+			},
+			wantOutput: []string{
+				"Foo (12345), last 7d",
+				"Activations: 1",
+				"Version 3 activated",
+				"Configuration changes: 2 (acl.update 1, version.update 1)",
+				"Requests   1000      1200     +20.0%",
+				"Hit ratio  80.0%     90.0%    +10.0pp",
+				"5xx        10        5        -50.0%",
+			},
+		},
+		{
+			args: args("service activity --all-services --since 2d"),
+			api: mock.API{
+				ListServicesFn: listServicesOK,
+				GetAPIEventsFn: getAPIEventsOK,
+				GetStatsJSONFn: func(i *fastly.GetStatsInput, o interface{}) error {
+					if i.Service != "12345" {
+						return json.Unmarshal([]byte(`{"status": "success", "data": []}`), o)
+					}
+					return getActivityStatsOK(i, o)
+				},
+			},
+			wantOutput: []string{
+				"Foo (12345), last 2d",
+				"2 services had no activity or traffic: Bar, Baz",
+			},
+		},
+		{
+			args: args("service activity --service-id 12345"),
+			api: mock.API{
+				GetServiceFn:   getServiceOK,
+				GetAPIEventsFn: getAPIEventsOK,
+				GetStatsJSONFn: func(*fastly.GetStatsInput, interface{}) error { return errTest },
+			},
+			wantError: errTest.Error(),
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
 var errTest = errors.New("fixture error")
 
 func createServiceOK(i *fastly.CreateServiceInput) (*fastly.Service, error) {
@@ -517,6 +584,12 @@ NAME  ID   TYPE  ACTIVE VERSION  LAST EDITED (UTC)
 Bar   456  wasm  1               2015-03-14 12:59
 `) + "\n"
 
+var listServicesShortOutputMaxItems = strings.TrimSpace(`
+NAME  ID   TYPE  ACTIVE VERSION  LAST EDITED (UTC)
+Foo   123  wasm  2               2010-11-15 19:01
+Bar   456  wasm  1               2015-03-14 12:59
+`) + "\n"
+
 var listServicesVerboseOutput = strings.TrimSpace(`
 Fastly API token not provided
 Fastly API endpoint: https://api.fastly.com
@@ -824,3 +897,116 @@ func deleteServiceOK(*fastly.DeleteServiceInput) error {
 func deleteServiceError(*fastly.DeleteServiceInput) error {
 	return errTest
 }
+
+func listServicesOK(*fastly.ListServicesInput) ([]*fastly.Service, error) {
+	return []*fastly.Service{
+		{ID: "789", Name: "Baz"},
+		{ID: "12345", Name: "Foo"},
+		{ID: "456", Name: "Bar"},
+	}, nil
+}
+
+func getAPIEventsOK(*fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	recent := time.Now().Add(-time.Hour)
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	return fastly.GetAPIEventsResponse{
+		Events: []*fastly.Event{
+			{ServiceID: "12345", EventType: "version.activate", Description: "Version 3 activated", CreatedAt: &recent},
+			{ServiceID: "12345", EventType: "version.update", Description: "Version 3 updated", CreatedAt: &recent},
+			{ServiceID: "12345", EventType: "acl.update", Description: "ACL updated", CreatedAt: &recent},
+			{ServiceID: "12345", EventType: "version.activate", Description: "Version 2 activated", CreatedAt: &old},
+		},
+	}, nil
+}
+
+// getActivityStatsOK returns more traffic for the current period, which ends
+// now, than for the previous period.
+func getActivityStatsOK(i *fastly.GetStatsInput, o interface{}) error {
+	to, err := strconv.ParseInt(i.To, 10, 64)
+	if err != nil {
+		return err
+	}
+	data := `[{"requests": 600, "hits": 400, "miss": 100, "status_5xx": 5, "bandwidth": 1000}, {"requests": 400, "hits": 400, "miss": 100, "status_5xx": 5, "bandwidth": 1000}]`
+	if time.Since(time.Unix(to, 0)) < time.Hour {
+		data = `[{"requests": 1200, "hits": 900, "miss": 100, "status_5xx": 5, "bandwidth": 3000}]`
+	}
+	return json.Unmarshal([]byte(`{"status": "success", "data": `+data+`}`), o)
+}
+
+func TestServiceSmokeTest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	specOK := filepath.Join(dir, "ok.yaml")
+	specFail := filepath.Join(dir, "fail.yaml")
+	for path, p := range map[string]string{specOK: "/ok", specFail: "/fail"} {
+		if err := os.WriteFile(path, []byte("scheme: http\ntests:\n  - name: check\n    path: "+p+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	listVersions := func(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+		return []*fastly.Version{
+			{ServiceID: i.ServiceID, Number: 1, Locked: true},
+			{ServiceID: i.ServiceID, Number: 2, Locked: true},
+			{ServiceID: i.ServiceID, Number: 3},
+			{ServiceID: i.ServiceID, Number: 4, Active: true, Locked: true},
+		}, nil
+	}
+	listDomains := func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+		return []*fastly.Domain{{Name: strings.TrimPrefix(ts.URL, "http://")}}, nil
+	}
+
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args          []string
+		wantError     string
+		wantOutput    []string
+		wantActivated []int
+	}{
+		{
+			args:      args("service smoke-test --service-id 123 --version active --spec " + filepath.Join(dir, "missing.yaml")),
+			wantError: "error reading smoke test spec",
+		},
+		{
+			args:       args("service smoke-test --service-id 123 --version active --spec " + specOK),
+			wantOutput: []string{"check  GET     " + ts.URL + "/ok  ok", "All smoke tests of service 123 version 4 passed"},
+		},
+		{
+			args:       args("service smoke-test --service-id 123 --version 3 --spec " + specFail + " --rollback-on-failure"),
+			wantError:  "1 of 1 smoke tests failed",
+			wantOutput: []string{"Version 3 isn't active, so it wasn't rolled back"},
+		},
+		{
+			args:          args("service smoke-test --service-id 123 --version active --spec " + specFail + " --rollback-on-failure"),
+			wantError:     "1 of 1 smoke tests failed",
+			wantOutput:    []string{"Rolled back service 123 from version 4 to version 2"},
+			wantActivated: []int{2},
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var activated []int
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ListVersionsFn: listVersions,
+				ListDomainsFn:  listDomains,
+				ActivateVersionFn: func(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+					activated = append(activated, i.ServiceVersion)
+					return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Active: true}, nil
+				},
+			})
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			testutil.AssertEqual(t, testcase.wantActivated, activated)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/adapter"
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/smoketest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// SmokeTestCommand runs the HTTP assertions of a spec file against the domains
+// of a service version.
+type SmokeTestCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	rollback       bool
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	spec           string
+	wait           time.Duration
+}
+
+// NewSmokeTestCommand returns a usable command registered under the parent.
+func NewSmokeTestCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *SmokeTestCommand {
+	var c SmokeTestCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("smoke-test", "Check the responses of a service version's domains against the assertions of a YAML spec file")
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+	c.CmdClause.Flag("spec", "Path to the YAML file of tests to run").Required().StringVar(&c.spec)
+	c.CmdClause.Flag("wait", "Retry failing tests for up to this long, e.g. while a version propagates (e.g. 2m)").DurationVar(&c.wait)
+	c.CmdClause.Flag("rollback-on-failure", "If any test fails and the version is active, activate the previously activated version").BoolVar(&c.rollback)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *SmokeTestCommand) Exec(in io.Reader, out io.Writer) error {
+	spec, err := smoketest.ReadSpec(c.spec)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       err,
+			Remediation: SmokeTestSpecRemediation,
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	err = SmokeTest(c.Globals, serviceID, serviceVersion.Number, spec, c.wait, out)
+	if err == nil || !c.rollback {
+		return err
+	}
+	if !serviceVersion.Active {
+		text.Warning(out, "Version %d isn't active, so it wasn't rolled back", serviceVersion.Number)
+		return err
+	}
+
+	versions, lerr := adapter.New(c.Globals.APIClient).Versions().List(&adapter.ListVersionsInput{ServiceID: serviceID})
+	if lerr != nil {
+		c.Globals.ErrLog.Add(lerr)
+		return fmt.Errorf("%w (and listing versions to roll back to failed: %v)", err, lerr)
+	}
+	prev := smoketest.PreviousVersion(versions, serviceVersion.Number)
+	if prev == nil {
+		text.Warning(out, "No version was activated before version %d, so it wasn't rolled back", serviceVersion.Number)
+		return err
+	}
+	return Rollback(c.Globals, serviceID, serviceVersion.Number, prev.Number, err, out)
+}
+
+// SmokeTestSpecRemediation describes the smoke test spec file format.
+const SmokeTestSpecRemediation = "The spec is a YAML file with a list of tests, e.g.\n\n" +
+	"tests:\n" +
+	"  - name: homepage\n" +
+	"    path: /\n" +
+	"    status: 200\n" +
+	"    response_headers:\n" +
+	"      cache-control: max-age\n" +
+	"    body_contains: Welcome\n\n" +
+	"Each test's path is requested from every domain of the service version (or the spec's 'domains'), unless the test has a 'url'."
+
+// SmokeTest runs spec against the domains of the given service version, and
+// displays the results.
+func SmokeTest(globals *config.Data, serviceID string, serviceVersion int, spec *smoketest.Spec, wait time.Duration, out io.Writer) error {
+	ds, err := adapter.New(globals.APIClient).Domains().List(&adapter.ListDomainsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+		})
+		return err
+	}
+	domains := make([]string, len(ds))
+	for i, d := range ds {
+		domains[i] = d.Name
+	}
+
+	var rt http.RoundTripper
+	if globals.TraceHTTP() {
+		rt = transport.Diagnostics(nil, true, globals.Diagnostics)
+	}
+	results := smoketest.Run(globals.Context, rt, spec, domains, wait)
+	if err := smoketest.Report(out, results); err != nil {
+		return err
+	}
+	text.Success(out, "All smoke tests of service %s version %d passed", serviceID, serviceVersion)
+	return nil
+}
+
+// Rollback activates version to in place of version from, after the failure
+// cause, which is returned along with any error activating the version.
+func Rollback(globals *config.Data, serviceID string, from, to int, cause error, out io.Writer) error {
+	_, err := adapter.New(globals.APIClient).Versions().Activate(&adapter.VersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: to,
+	})
+	if err != nil {
+		globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": to,
+		})
+		return fmt.Errorf("%w (and rolling back to version %d failed: %v)", cause, to, err)
+	}
+	text.Warning(out, "Rolled back service %s from version %d to version %d", serviceID, from, to)
+	return cause
+}
@@ -1,24 +1,32 @@
 package serviceversion
 
 import (
+	"fmt"
 	"io"
+	"time"
 
+	"github.com/fastly/cli/pkg/api/adapter"
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/service"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/hooks"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/smoketest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // ActivateCommand calls the Fastly API to activate a service version.
 type ActivateCommand struct {
 	cmd.Base
 	manifest       manifest.Data
-	Input          fastly.ActivateVersionInput
+	Input          adapter.VersionInput
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	autoClone      cmd.OptionalAutoClone
+	rollback       bool
+	smokeTest      string
+	wait           time.Duration
 }
 
 // NewActivateCommand returns a usable command registered under the parent.
@@ -49,14 +57,36 @@ func NewActivateCommand(parent cmd.Registerer, globals *config.Data, data manife
 		Action: c.autoClone.Set,
 		Dst:    &c.autoClone.Value,
 	})
+	c.CmdClause.Flag("smoke-test", "After activating, check the service's domains against the assertions of this YAML spec file (see 'fastly service smoke-test')").StringVar(&c.smokeTest)
+	c.CmdClause.Flag("wait", "Retry failing smoke tests for up to this long while the version propagates (e.g. 2m)").DurationVar(&c.wait)
+	c.CmdClause.Flag("rollback-on-failure", "If any smoke test fails, activate the previously active version again").BoolVar(&c.rollback)
 	return &c
 }
 
 // Exec invokes the application logic for the command.
 func (c *ActivateCommand) Exec(in io.Reader, out io.Writer) error {
+	var spec *smoketest.Spec
+	if c.smokeTest != "" {
+		var err error
+		spec, err = smoketest.ReadSpec(c.smokeTest)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return errors.RemediationError{
+				Inner:       err,
+				Remediation: service.SmokeTestSpecRemediation,
+			}
+		}
+	} else if c.wait > 0 || c.rollback {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("--wait and --rollback-on-failure require --smoke-test"),
+			Remediation: "Specify the smoke tests to run after activating, e.g. --smoke-test tests.yaml.",
+		}
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -74,7 +104,24 @@ func (c *ActivateCommand) Exec(in io.Reader, out io.Writer) error {
 	c.Input.ServiceID = serviceID
 	c.Input.ServiceVersion = serviceVersion.Number
 
-	ver, err := c.Globals.APIClient.ActivateVersion(&c.Input)
+	// The version to roll back to is the one that's active before activating.
+	var previous *adapter.Version
+	if c.rollback {
+		versions, err := adapter.New(c.Globals.APIClient).Versions().List(&adapter.ListVersionsInput{ServiceID: serviceID})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": serviceID,
+			})
+			return err
+		}
+		for _, v := range versions {
+			if v.Active {
+				previous = v
+			}
+		}
+	}
+
+	ver, err := adapter.New(c.Globals.APIClient).Versions().Activate(&c.Input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
@@ -84,5 +131,26 @@ func (c *ActivateCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 
 	text.Success(out, "Activated service %s version %d", ver.ServiceID, c.Input.ServiceVersion)
+
+	if spec != nil {
+		text.Break(out)
+		err := service.SmokeTest(c.Globals, serviceID, c.Input.ServiceVersion, spec, c.wait, out)
+		if err != nil {
+			if !c.rollback {
+				return err
+			}
+			if previous == nil || previous.Number == c.Input.ServiceVersion {
+				text.Warning(out, "No version was active before version %d, so it wasn't rolled back", c.Input.ServiceVersion)
+				return err
+			}
+			return service.Rollback(c.Globals, serviceID, c.Input.ServiceVersion, previous.Number, err, out)
+		}
+	}
+
+	hooks.Run(hooks.PostActivate, hooks.Payload{
+		Command:        c.Name(),
+		ServiceID:      ver.ServiceID,
+		ServiceVersion: c.Input.ServiceVersion,
+	}, c.Globals, out)
 	return nil
 }
@@ -1,21 +1,26 @@
 package serviceversion
 
 import (
+	"fmt"
 	"io"
+	"sort"
+	"strings"
 
+	"github.com/fastly/cli/pkg/api/adapter"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/snapshot"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // CloneCommand calls the Fastly API to clone a service version.
 type CloneCommand struct {
 	cmd.Base
 	manifest       manifest.Data
-	Input          fastly.CloneVersionInput
+	Input          adapter.VersionInput
+	fromService    string
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 }
@@ -44,14 +49,20 @@ func NewCloneCommand(parent cmd.Registerer, globals *config.Data, data manifest.
 		Dst:         &c.serviceVersion.Value,
 		Required:    true,
 	})
+	c.CmdClause.Flag("from-service", "Copy the configuration of --version of this service ID into a new draft version of the current service").StringVar(&c.fromService)
 	return &c
 }
 
 // Exec invokes the application logic for the command.
 func (c *CloneCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.fromService != "" {
+		return c.cloneFromService(out)
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -69,7 +80,7 @@ func (c *CloneCommand) Exec(in io.Reader, out io.Writer) error {
 	c.Input.ServiceID = serviceID
 	c.Input.ServiceVersion = serviceVersion.Number
 
-	ver, err := c.Globals.APIClient.CloneVersion(&c.Input)
+	ver, err := adapter.New(c.Globals.APIClient).Versions().Clone(&c.Input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
@@ -81,3 +92,98 @@ func (c *CloneCommand) Exec(in io.Reader, out io.Writer) error {
 	text.Success(out, "Cloned service %s version %d to version %d", ver.ServiceID, c.Input.ServiceVersion, ver.Number)
 	return nil
 }
+
+// cloneFromService copies the configuration of a version of a different
+// service into a new draft version of the current service.
+//
+// NOTE: The API can only clone a version within its own service, so the
+// current service's active (or latest) version is cloned and its resources are
+// then replaced with those of the source version.
+func (c *CloneCommand) cloneFromService(out io.Writer) error {
+	client := c.Globals.APIClient
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, client, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+	if serviceID == c.fromService {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("--from-service is the current service (%s)", serviceID),
+			Remediation: "To clone a version within the current service, omit --from-service.",
+		}
+	}
+
+	srcVersion, err := c.serviceVersion.Parse(c.fromService, client)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Source Service ID": c.fromService,
+		})
+		return err
+	}
+
+	base, err := (&cmd.OptionalServiceVersion{OptionalString: cmd.OptionalString{Value: "active"}}).Parse(serviceID, client)
+	if err != nil {
+		base, err = (&cmd.OptionalServiceVersion{OptionalString: cmd.OptionalString{Value: "latest"}}).Parse(serviceID, client)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": serviceID,
+			})
+			return err
+		}
+	}
+
+	c.Input.ServiceID = serviceID
+	c.Input.ServiceVersion = base.Number
+
+	draft, err := adapter.New(client).Versions().Clone(&c.Input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": base.Number,
+		})
+		return err
+	}
+	text.Success(out, "Cloned service %s version %d to version %d", serviceID, base.Number, draft.Number)
+
+	result, err := Replicate(client, snapshot.New(client, c.fromService, srcVersion.Number), snapshot.New(client, serviceID, draft.Number))
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Source Service ID":      c.fromService,
+			"Source Service Version": srcVersion.Number,
+			"Service ID":             serviceID,
+			"Service Version":        draft.Number,
+		})
+		return errors.RemediationError{
+			Inner:       err,
+			Remediation: fmt.Sprintf("Version %d of service %s has been partially updated and shouldn't be activated. Re-run the command to start again from a new draft version.", draft.Number, serviceID),
+		}
+	}
+
+	if c.Globals.Verbose() {
+		text.Break(out)
+		text.Output(out, "Removed: %s", summariseReplication(result.Removed))
+		text.Output(out, "Created: %s", summariseReplication(result.Created))
+	}
+	for _, name := range result.WriteOnly {
+		text.Warning(out, "The items of write-only dictionary '%s' can't be read, so it was created empty.", name)
+	}
+	text.Info(out, "Domains and logging endpoints are specific to each service and were not copied.")
+	text.Success(out, "Copied the configuration of service %s version %d to service %s version %d", c.fromService, srcVersion.Number, serviceID, draft.Number)
+	return nil
+}
+
+// summariseReplication formats the number of each type of resource.
+func summariseReplication(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	var parts []string
+	for resource, n := range counts {
+		parts = append(parts, fmt.Sprintf("%s=%d", resource, n))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
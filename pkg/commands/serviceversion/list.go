@@ -7,10 +7,8 @@ import (
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
-	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/fastly/cli/pkg/time"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -52,11 +50,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -87,7 +81,7 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 		tw := text.NewTable(out)
 		tw.AddHeader("NUMBER", "ACTIVE", "LAST EDITED (UTC)")
 		for _, version := range versions {
-			tw.AddLine(version.Number, version.Active, version.UpdatedAt.UTC().Format(time.Format))
+			tw.AddLine(version.Number, version.Active, c.Globals.Formatter().UTCTime(*version.UpdatedAt))
 		}
 		tw.Print()
 		return nil
@@ -96,7 +90,7 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 	fmt.Fprintf(out, "Versions: %d\n", len(versions))
 	for i, version := range versions {
 		fmt.Fprintf(out, "\tVersion %d/%d\n", i+1, len(versions))
-		text.PrintVersion(out, "\t\t", version)
+		text.PrintVersion(out, "\t\t", version, c.Globals.Formatter())
 	}
 	fmt.Fprintln(out)
 
@@ -0,0 +1,137 @@
+package serviceversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// PruneCommand calls the Fastly API to identify draft service versions that
+// can be pruned.
+//
+// NOTE: The Fastly API doesn't support deleting service versions, so the
+// command reports the versions that are safe to discard rather than removing
+// them.
+type PruneCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	json        bool
+	keep        int
+	olderThan   time.Duration
+	serviceName cmd.OptionalServiceNameID
+}
+
+// NewPruneCommand returns a usable command registered under the parent.
+func NewPruneCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *PruneCommand {
+	var c PruneCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("prune", "Identify old draft service versions that were never activated")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("keep", "Number of the most recent service versions to exclude").Default("20").Action(cmd.Validate(cmd.ValidateRange(0, 1<<30))).IntVar(&c.keep)
+	c.CmdClause.Flag("older-than", "Only include versions last edited longer ago than this duration (e.g. 720h)").Action(cmd.Validate(cmd.ValidateDuration)).DurationVar(&c.olderThan)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *PruneCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	prunable := PrunableVersions(versions, c.keep, c.olderThan, c.Globals.Clock.Now())
+
+	if c.json {
+		data, err := json.Marshal(prunable)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(prunable) == 0 {
+		text.Info(out, "No service versions can be pruned (%d versions, keeping the %d most recent).", len(versions), c.keep)
+		return nil
+	}
+
+	tw := text.NewTable(out)
+	tw.AddHeader("NUMBER", "LAST EDITED (UTC)", "COMMENT")
+	for _, v := range prunable {
+		var updated string
+		if v.UpdatedAt != nil {
+			updated = c.Globals.Formatter().UTCTime(*v.UpdatedAt)
+		}
+		tw.AddLine(v.Number, updated, v.Comment)
+	}
+	tw.Print()
+
+	text.Info(out, "%d of %d service versions are drafts that were never activated and can be pruned. The Fastly API doesn't support deleting service versions, so they have been left in place.", len(prunable), len(versions))
+	return nil
+}
+
+// PrunableVersions returns the draft versions (never activated or locked)
+// that aren't among the `keep` most recent versions and, when olderThan is
+// non-zero, were last edited more than olderThan before now.
+func PrunableVersions(versions []*fastly.Version, keep int, olderThan time.Duration, now time.Time) []*fastly.Version {
+	sorted := make([]*fastly.Version, len(versions))
+	copy(sorted, versions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Number > sorted[j].Number
+	})
+
+	var prunable []*fastly.Version
+	for i, v := range sorted {
+		if i < keep || v.Active || v.Locked || v.Deployed || v.Staging || v.Testing {
+			continue
+		}
+		if olderThan > 0 && v.UpdatedAt != nil && now.Sub(*v.UpdatedAt) < olderThan {
+			continue
+		}
+		prunable = append(prunable, v)
+	}
+
+	sort.Slice(prunable, func(i, j int) bool {
+		return prunable[i].Number < prunable[j].Number
+	})
+	return prunable
+}
@@ -0,0 +1,482 @@
+package serviceversion
+
+import (
+	"fmt"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ReplicatedResources are the types of resource that clone --from-service
+// copies from the source version, in the order they're created. They're
+// removed from the draft version in the reverse order, so that a resource is
+// removed before the resources it refers to (e.g. a backend before its health
+// check and conditions).
+//
+// NOTE: Domains and logging endpoints aren't copied, as they're specific to
+// each service (e.g. an environment's hostnames and log credentials).
+// Dictionaries and ACLs are only copied if the draft version doesn't already
+// have one with the same name, as their entries are typically also specific
+// to each service.
+var ReplicatedResources = []string{
+	"condition",
+	"healthcheck",
+	"backend",
+	"cache_setting",
+	"gzip",
+	"header",
+	"snippet",
+	"vcl",
+}
+
+// Replication summarises the changes made to the draft version.
+type Replication struct {
+	Created map[string]int
+	Removed map[string]int
+	// WriteOnly lists the write-only dictionaries that were created without
+	// their items, as the items of a write-only dictionary can't be read.
+	WriteOnly []string
+}
+
+// replicator makes the resources of a draft version match a source version,
+// which may belong to a different service.
+type replicator struct {
+	client api.Interface
+	src    *snapshot.Snapshot
+	dst    *snapshot.Snapshot
+	result Replication
+}
+
+// Replicate makes the replicated resources of the draft version (of the same
+// or a different service) match those of the source version, and copies the
+// dictionaries and ACLs the draft version is missing.
+func Replicate(client api.Interface, src, dst *snapshot.Snapshot) (Replication, error) {
+	r := replicator{
+		client: client,
+		src:    src,
+		dst:    dst,
+		result: Replication{Created: map[string]int{}, Removed: map[string]int{}},
+	}
+
+	resources := append([]string{"dictionary", "acl"}, ReplicatedResources...)
+	if err := src.Fetch(resources...); err != nil {
+		return r.result, fmt.Errorf("error reading service %s version %d: %w", src.ServiceID, src.Version, err)
+	}
+	if err := dst.Fetch(resources...); err != nil {
+		return r.result, fmt.Errorf("error reading service %s version %d: %w", dst.ServiceID, dst.Version, err)
+	}
+
+	for i := len(ReplicatedResources) - 1; i >= 0; i-- {
+		if err := r.remove(ReplicatedResources[i]); err != nil {
+			return r.result, err
+		}
+	}
+	for _, resource := range ReplicatedResources {
+		if err := r.create(resource); err != nil {
+			return r.result, err
+		}
+	}
+	if err := r.dictionaries(); err != nil {
+		return r.result, err
+	}
+	return r.result, r.acls()
+}
+
+// remove deletes the draft version's resources of the given type.
+func (r *replicator) remove(resource string) error {
+	sid, ver := r.dst.ServiceID, r.dst.Version
+	var names []string
+	var del func(name string) error
+
+	switch resource {
+	case "condition":
+		cs, _ := r.dst.Conditions()
+		for _, c := range cs {
+			names = append(names, c.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteCondition(&fastly.DeleteConditionInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "healthcheck":
+		hs, _ := r.dst.HealthChecks()
+		for _, h := range hs {
+			names = append(names, h.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteHealthCheck(&fastly.DeleteHealthCheckInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "backend":
+		bs, _ := r.dst.Backends()
+		for _, b := range bs {
+			names = append(names, b.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteBackend(&fastly.DeleteBackendInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "cache_setting":
+		cs, _ := r.dst.CacheSettings()
+		for _, c := range cs {
+			names = append(names, c.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteCacheSetting(&fastly.DeleteCacheSettingInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "gzip":
+		gs, _ := r.dst.Gzips()
+		for _, g := range gs {
+			names = append(names, g.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteGzip(&fastly.DeleteGzipInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "header":
+		hs, _ := r.dst.Headers()
+		for _, h := range hs {
+			names = append(names, h.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteHeader(&fastly.DeleteHeaderInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "snippet":
+		ss, _ := r.dst.Snippets()
+		for _, s := range ss {
+			names = append(names, s.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteSnippet(&fastly.DeleteSnippetInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	case "vcl":
+		vs, _ := r.dst.VCLs()
+		for _, v := range vs {
+			names = append(names, v.Name)
+		}
+		del = func(name string) error {
+			return r.client.DeleteVCL(&fastly.DeleteVCLInput{ServiceID: sid, ServiceVersion: ver, Name: name})
+		}
+	}
+
+	for _, name := range names {
+		if err := del(name); err != nil {
+			return fmt.Errorf("error removing %s '%s' from version %d: %w", resource, name, ver, err)
+		}
+		r.result.Removed[resource]++
+	}
+	return nil
+}
+
+// create copies the source version's resources of the given type to the
+// draft version.
+func (r *replicator) create(resource string) error {
+	sid, ver := r.dst.ServiceID, r.dst.Version
+	var err error
+	created := func(name string) bool {
+		if err != nil {
+			err = fmt.Errorf("error copying %s '%s' to version %d: %w", resource, name, ver, err)
+			return false
+		}
+		r.result.Created[resource]++
+		return true
+	}
+
+	switch resource {
+	case "condition":
+		cs, _ := r.src.Conditions()
+		for _, c := range cs {
+			_, err = r.client.CreateCondition(&fastly.CreateConditionInput{
+				ServiceID:      sid,
+				ServiceVersion: ver,
+				Name:           c.Name,
+				Statement:      c.Statement,
+				Type:           c.Type,
+				Priority:       fastly.Int(c.Priority),
+			})
+			if !created(c.Name) {
+				return err
+			}
+		}
+	case "healthcheck":
+		hs, _ := r.src.HealthChecks()
+		for _, h := range hs {
+			_, err = r.client.CreateHealthCheck(&fastly.CreateHealthCheckInput{
+				ServiceID:        sid,
+				ServiceVersion:   ver,
+				Name:             h.Name,
+				Comment:          h.Comment,
+				Method:           h.Method,
+				Host:             h.Host,
+				Path:             h.Path,
+				HTTPVersion:      h.HTTPVersion,
+				Timeout:          fastly.Uint(h.Timeout),
+				CheckInterval:    fastly.Uint(h.CheckInterval),
+				ExpectedResponse: fastly.Uint(h.ExpectedResponse),
+				Window:           fastly.Uint(h.Window),
+				Threshold:        fastly.Uint(h.Threshold),
+				Initial:          fastly.Uint(h.Initial),
+			})
+			if !created(h.Name) {
+				return err
+			}
+		}
+	case "backend":
+		bs, _ := r.src.Backends()
+		for _, b := range bs {
+			_, err = r.client.CreateBackend(&fastly.CreateBackendInput{
+				ServiceID:           sid,
+				ServiceVersion:      ver,
+				Name:                b.Name,
+				Comment:             b.Comment,
+				Address:             b.Address,
+				Port:                fastly.Uint(b.Port),
+				OverrideHost:        b.OverrideHost,
+				ConnectTimeout:      fastly.Uint(b.ConnectTimeout),
+				MaxConn:             fastly.Uint(b.MaxConn),
+				ErrorThreshold:      fastly.Uint(b.ErrorThreshold),
+				FirstByteTimeout:    fastly.Uint(b.FirstByteTimeout),
+				BetweenBytesTimeout: fastly.Uint(b.BetweenBytesTimeout),
+				AutoLoadbalance:     fastly.Compatibool(b.AutoLoadbalance),
+				Weight:              fastly.Uint(b.Weight),
+				RequestCondition:    b.RequestCondition,
+				HealthCheck:         b.HealthCheck,
+				Shield:              b.Shield,
+				UseSSL:              fastly.Compatibool(b.UseSSL),
+				SSLCheckCert:        fastly.Compatibool(b.SSLCheckCert),
+				SSLCACert:           b.SSLCACert,
+				SSLClientCert:       b.SSLClientCert,
+				SSLClientKey:        b.SSLClientKey,
+				SSLHostname:         b.SSLHostname,
+				SSLCertHostname:     b.SSLCertHostname,
+				SSLSNIHostname:      b.SSLSNIHostname,
+				MinTLSVersion:       b.MinTLSVersion,
+				MaxTLSVersion:       b.MaxTLSVersion,
+				SSLCiphers:          b.SSLCiphers,
+			})
+			if !created(b.Name) {
+				return err
+			}
+		}
+	case "cache_setting":
+		cs, _ := r.src.CacheSettings()
+		for _, c := range cs {
+			_, err = r.client.CreateCacheSetting(&fastly.CreateCacheSettingInput{
+				ServiceID:      sid,
+				ServiceVersion: ver,
+				Name:           c.Name,
+				Action:         c.Action,
+				TTL:            c.TTL,
+				StaleTTL:       c.StaleTTL,
+				CacheCondition: c.CacheCondition,
+			})
+			if !created(c.Name) {
+				return err
+			}
+		}
+	case "gzip":
+		gs, _ := r.src.Gzips()
+		for _, g := range gs {
+			_, err = r.client.CreateGzip(&fastly.CreateGzipInput{
+				ServiceID:      sid,
+				ServiceVersion: ver,
+				Name:           g.Name,
+				ContentTypes:   g.ContentTypes,
+				Extensions:     g.Extensions,
+				CacheCondition: g.CacheCondition,
+			})
+			if !created(g.Name) {
+				return err
+			}
+		}
+	case "header":
+		hs, _ := r.src.Headers()
+		for _, h := range hs {
+			_, err = r.client.CreateHeader(&fastly.CreateHeaderInput{
+				ServiceID:         sid,
+				ServiceVersion:    ver,
+				Name:              h.Name,
+				Action:            h.Action,
+				IgnoreIfSet:       fastly.Compatibool(h.IgnoreIfSet),
+				Type:              h.Type,
+				Destination:       h.Destination,
+				Source:            h.Source,
+				Regex:             h.Regex,
+				Substitution:      h.Substitution,
+				Priority:          fastly.Uint(h.Priority),
+				RequestCondition:  h.RequestCondition,
+				CacheCondition:    h.CacheCondition,
+				ResponseCondition: h.ResponseCondition,
+			})
+			if !created(h.Name) {
+				return err
+			}
+		}
+	case "snippet":
+		ss, _ := r.src.Snippets()
+		for _, s := range ss {
+			content := s.Content
+			// The content of a dynamic snippet isn't versioned, so it's read
+			// separately.
+			if s.Dynamic == 1 {
+				var ds *fastly.DynamicSnippet
+				ds, err = r.client.GetDynamicSnippet(&fastly.GetDynamicSnippetInput{ServiceID: r.src.ServiceID, ID: s.ID})
+				if err != nil {
+					return fmt.Errorf("error reading dynamic snippet '%s': %w", s.Name, err)
+				}
+				content = ds.Content
+			}
+			_, err = r.client.CreateSnippet(&fastly.CreateSnippetInput{
+				ServiceID:      sid,
+				ServiceVersion: ver,
+				Name:           s.Name,
+				Priority:       fastly.Int(s.Priority),
+				Dynamic:        s.Dynamic,
+				Content:        content,
+				Type:           s.Type,
+			})
+			if !created(s.Name) {
+				return err
+			}
+		}
+	case "vcl":
+		vs, _ := r.src.VCLs()
+		for _, v := range vs {
+			_, err = r.client.CreateVCL(&fastly.CreateVCLInput{
+				ServiceID:      sid,
+				ServiceVersion: ver,
+				Name:           v.Name,
+				Content:        v.Content,
+				Main:           v.Main,
+			})
+			if !created(v.Name) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dictionaries copies the source version's dictionaries, and their items, that
+// the draft version doesn't have.
+func (r *replicator) dictionaries() error {
+	existing := map[string]bool{}
+	ds, _ := r.dst.Dictionaries()
+	for _, d := range ds {
+		existing[d.Name] = true
+	}
+
+	ds, _ = r.src.Dictionaries()
+	for _, d := range ds {
+		if existing[d.Name] {
+			continue
+		}
+		var items []*fastly.BatchDictionaryItem
+		paginator := r.client.NewListDictionaryItemsPaginator(&fastly.ListDictionaryItemsInput{
+			ServiceID:    r.src.ServiceID,
+			DictionaryID: d.ID,
+		})
+		if d.WriteOnly {
+			r.result.WriteOnly = append(r.result.WriteOnly, d.Name)
+		}
+		for !d.WriteOnly && paginator.HasNext() {
+			page, err := paginator.GetNext()
+			if err != nil {
+				return fmt.Errorf("error reading the items of dictionary '%s': %w", d.Name, err)
+			}
+			for _, item := range page {
+				items = append(items, &fastly.BatchDictionaryItem{
+					Operation: fastly.CreateBatchOperation,
+					ItemKey:   item.ItemKey,
+					ItemValue: item.ItemValue,
+				})
+			}
+		}
+
+		dict, err := r.client.CreateDictionary(&fastly.CreateDictionaryInput{
+			ServiceID:      r.dst.ServiceID,
+			ServiceVersion: r.dst.Version,
+			Name:           d.Name,
+			WriteOnly:      fastly.Compatibool(d.WriteOnly),
+		})
+		if err != nil {
+			return fmt.Errorf("error copying dictionary '%s' to version %d: %w", d.Name, r.dst.Version, err)
+		}
+		for start := 0; start < len(items); start += fastly.BatchModifyMaximumOperations {
+			end := start + fastly.BatchModifyMaximumOperations
+			if end > len(items) {
+				end = len(items)
+			}
+			err := r.client.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+				ServiceID:    r.dst.ServiceID,
+				DictionaryID: dict.ID,
+				Items:        items[start:end],
+			})
+			if err != nil {
+				return fmt.Errorf("error copying the items of dictionary '%s': %w", d.Name, err)
+			}
+		}
+		r.result.Created["dictionary"]++
+	}
+	return nil
+}
+
+// acls copies the source version's ACLs, and their entries, that the draft
+// version doesn't have.
+func (r *replicator) acls() error {
+	existing := map[string]bool{}
+	as, _ := r.dst.ACLs()
+	for _, a := range as {
+		existing[a.Name] = true
+	}
+
+	as, _ = r.src.ACLs()
+	for _, a := range as {
+		if existing[a.Name] {
+			continue
+		}
+		var entries []*fastly.BatchACLEntry
+		paginator := r.client.NewListACLEntriesPaginator(&fastly.ListACLEntriesInput{
+			ServiceID: r.src.ServiceID,
+			ACLID:     a.ID,
+		})
+		for paginator.HasNext() {
+			page, err := paginator.GetNext()
+			if err != nil {
+				return fmt.Errorf("error reading the entries of ACL '%s': %w", a.Name, err)
+			}
+			for _, e := range page {
+				negated := fastly.Compatibool(e.Negated)
+				entries = append(entries, &fastly.BatchACLEntry{
+					Operation: fastly.CreateBatchOperation,
+					IP:        fastly.String(e.IP),
+					Subnet:    e.Subnet,
+					Negated:   &negated,
+					Comment:   fastly.String(e.Comment),
+				})
+			}
+		}
+
+		acl, err := r.client.CreateACL(&fastly.CreateACLInput{
+			ServiceID:      r.dst.ServiceID,
+			ServiceVersion: r.dst.Version,
+			Name:           a.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("error copying ACL '%s' to version %d: %w", a.Name, r.dst.Version, err)
+		}
+		for start := 0; start < len(entries); start += fastly.BatchModifyMaximumOperations {
+			end := start + fastly.BatchModifyMaximumOperations
+			if end > len(entries) {
+				end = len(entries)
+			}
+			err := r.client.BatchModifyACLEntries(&fastly.BatchModifyACLEntriesInput{
+				ServiceID: r.dst.ServiceID,
+				ACLID:     acl.ID,
+				Entries:   entries[start:end],
+			})
+			if err != nil {
+				return fmt.Errorf("error copying the entries of ACL '%s': %w", a.Name, err)
+			}
+		}
+		r.result.Created["acl"]++
+	}
+	return nil
+}
@@ -2,10 +2,17 @@ package serviceversion_test
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/serviceversion"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -55,6 +62,55 @@ func TestVersionClone(t *testing.T) {
 	}
 }
 
+func TestVersionCloneFromService(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args       []string
+		api        mock.API
+		wantError  string
+		wantOutput []string
+	}{
+		{
+			args:      args("service-version clone --service-id 123 --version 1 --from-service 123"),
+			wantError: "--from-service is the current service (123)",
+		},
+		{
+			args: args("service-version clone --service-id 123 --version 1 --from-service abc"),
+			api:  replicationAPI(nil),
+			wantOutput: []string{
+				"Cloned service 123 version 1 to version 4",
+				"Domains and logging endpoints are specific to each service and were not copied.",
+				"Copied the configuration of service abc version 1 to service 123 version 4",
+			},
+		},
+		{
+			args: args("service-version clone --service-id 123 --version 1 --from-service abc --verbose"),
+			api:  replicationAPI(nil),
+			wantOutput: []string{
+				"Removed: backend=1",
+				"Created: backend=1, condition=1, dictionary=1",
+			},
+		},
+		{
+			args:       args("service-version clone --service-id 123 --version 1 --from-service abc"),
+			api:        replicationAPI(testutil.Err),
+			wantError:  "error copying backend 'origin' to version 4",
+			wantOutput: []string{"Cloned service 123 version 1 to version 4"},
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, want := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
 func TestVersionList(t *testing.T) {
 	args := testutil.Args
 	for _, testcase := range []struct {
@@ -68,6 +124,11 @@ func TestVersionList(t *testing.T) {
 			api:        mock.API{ListVersionsFn: testutil.ListVersions},
 			wantOutput: listVersionsShortOutput,
 		},
+		{
+			args:       args("service-version list --service-id 123 --iso8601"),
+			api:        mock.API{ListVersionsFn: testutil.ListVersions},
+			wantOutput: listVersionsISO8601Output,
+		},
 		{
 			args:       args("service-version list --service-id 123 --verbose"),
 			api:        mock.API{ListVersionsFn: testutil.ListVersions},
@@ -201,6 +262,44 @@ func TestVersionActivate(t *testing.T) {
 	}
 }
 
+func TestVersionActivateDryRunHooks(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+	touched := filepath.Join(t.TempDir(), "touched")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version activate --service-id 123 --version 3 --dry-run"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn:    testutil.ListVersions,
+		ActivateVersionFn: activateVersionOK,
+	})
+	opts.ConfigFile.Hooks = config.Hooks{
+		"post-activate": {URL: ts.URL + "/hook", Command: "touch " + touched},
+	}
+	opts.ConfigFile.Profiles = config.Profiles{
+		"user": &config.Profile{
+			Default: true,
+			Token:   "123",
+			DeployMarkers: &config.DeployMarkers{
+				Grafana: &config.GrafanaMarker{APIKey: "gf-key", URL: ts.URL},
+			},
+		},
+	}
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: Grafana deploy marker\n")
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: post-activate hook: POST "+ts.URL+"/hook\n")
+	testutil.AssertStringContains(t, stdout.String(), "DRY RUN: post-activate hook: touch "+touched+"\n")
+	testutil.AssertEqual(t, 0, requests)
+	if _, err := os.Stat(touched); !os.IsNotExist(err) {
+		t.Errorf("want the hook command not to run, got %v", err)
+	}
+}
+
 func TestVersionDeactivate(t *testing.T) {
 	args := testutil.Args
 	for _, testcase := range []struct {
@@ -289,6 +388,87 @@ func TestVersionLock(t *testing.T) {
 	}
 }
 
+func TestVersionPrune(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args       []string
+		api        mock.API
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("service-version prune"),
+			wantError: "error reading service: no service ID found",
+		},
+		{
+			args:       args("service-version prune --service-id 123"),
+			api:        mock.API{ListVersionsFn: testutil.ListVersions},
+			wantOutput: "\nINFO: No service versions can be pruned (3 versions, keeping the 20 most recent).\n",
+		},
+		{
+			args:       args("service-version prune --service-id 123 --keep 0"),
+			api:        mock.API{ListVersionsFn: testutil.ListVersions},
+			wantOutput: pruneVersionsOutput,
+		},
+		{
+			args:       args("service-version prune --service-id 123 --keep 0 --older-than 720h"),
+			api:        mock.API{ListVersionsFn: testutil.ListVersions},
+			wantOutput: pruneVersionsOutput,
+		},
+		{
+			args:       args("service-version prune --service-id 123 --keep 0 --json"),
+			api:        mock.API{ListVersionsFn: testutil.ListVersions},
+			wantOutput: `[{"Number":3,"Comment":"","ServiceID":"123","Active":false,"Locked":false,"Deployed":false,"Staging":false,"Testing":false,"CreatedAt":null,"UpdatedAt":"2000-01-03T01:00:00Z","DeletedAt":null}]`,
+		},
+		{
+			args:      args("service-version prune --service-id 123 --older-than=-5m"),
+			wantError: "invalid --older-than '-5m': must be a positive duration",
+		},
+		{
+			args:      args("service-version prune --service-id 123"),
+			api:       mock.API{ListVersionsFn: testutil.ListVersionsError},
+			wantError: testutil.Err.Error(),
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, stdout.String())
+		})
+	}
+}
+
+func TestPrunableVersions(t *testing.T) {
+	now := time.Date(2000, 1, 10, 0, 0, 0, 0, time.UTC)
+	versions := []*fastly.Version{
+		{Number: 1, Active: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-01T00:00:00Z")},
+		{Number: 2, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-02T00:00:00Z")},
+		{Number: 3, Staging: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-03T00:00:00Z")},
+		{Number: 4, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-09T00:00:00Z")},
+		{Number: 5, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-09T12:00:00Z")},
+	}
+
+	numbers := func(vs []*fastly.Version) []int {
+		var n []int
+		for _, v := range vs {
+			n = append(n, v.Number)
+		}
+		return n
+	}
+
+	testutil.AssertEqual(t, []int{2, 4}, numbers(serviceversion.PrunableVersions(versions, 1, 0, now)))
+	testutil.AssertEqual(t, []int{2}, numbers(serviceversion.PrunableVersions(versions, 1, 72*time.Hour, now)))
+	testutil.AssertEqual(t, []int(nil), numbers(serviceversion.PrunableVersions(versions, 5, 0, now)))
+}
+
+var pruneVersionsOutput = "NUMBER  LAST EDITED (UTC)  COMMENT\n" +
+	"3       2000-01-03 01:00   \n" +
+	"\nINFO: 1 of 3 service versions are drafts that were never activated and can be pruned. " +
+	"The Fastly API doesn't support deleting service versions, so they have been left in place.\n"
+
 var listVersionsShortOutput = strings.TrimSpace(`
 NUMBER  ACTIVE  LAST EDITED (UTC)
 1       true    2000-01-01 01:00
@@ -296,6 +476,13 @@ NUMBER  ACTIVE  LAST EDITED (UTC)
 3       false   2000-01-03 01:00
 `) + "\n"
 
+var listVersionsISO8601Output = strings.TrimSpace(`
+NUMBER  ACTIVE  LAST EDITED (UTC)
+1       true    2000-01-01T01:00:00Z
+2       false   2000-01-02T01:00:00Z
+3       false   2000-01-03T01:00:00Z
+`) + "\n"
+
 var listVersionsVerboseOutput = strings.TrimSpace(`
 Fastly API token not provided
 Fastly API endpoint: https://api.fastly.com
@@ -391,3 +578,154 @@ func lockVersionOK(i *fastly.LockVersionInput) (*fastly.Version, error) {
 func lockVersionError(i *fastly.LockVersionInput) (*fastly.Version, error) {
 	return nil, testutil.Err
 }
+
+// replicationAPI returns a mock API where service abc has a condition, a
+// backend and a dictionary, and service 123 has a backend that
+// clone --from-service should remove. createBackendErr is returned when
+// creating a backend.
+func replicationAPI(createBackendErr error) mock.API {
+	return mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CloneVersionFn: testutil.CloneVersionResult(4),
+		ListConditionsFn: func(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+			if i.ServiceID == "abc" {
+				return []*fastly.Condition{{Name: "is_api", Statement: "req.url ~ \"^/api\"", Type: "REQUEST", Priority: 10}}, nil
+			}
+			return nil, nil
+		},
+		ListHealthChecksFn:  func(*fastly.ListHealthChecksInput) ([]*fastly.HealthCheck, error) { return nil, nil },
+		ListCacheSettingsFn: func(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) { return nil, nil },
+		ListGzipsFn:         func(*fastly.ListGzipsInput) ([]*fastly.Gzip, error) { return nil, nil },
+		ListHeadersFn:       func(*fastly.ListHeadersInput) ([]*fastly.Header, error) { return nil, nil },
+		ListSnippetsFn:      func(*fastly.ListSnippetsInput) ([]*fastly.Snippet, error) { return nil, nil },
+		ListVCLsFn:          func(*fastly.ListVCLsInput) ([]*fastly.VCL, error) { return nil, nil },
+		ListACLsFn:          func(*fastly.ListACLsInput) ([]*fastly.ACL, error) { return nil, nil },
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			if i.ServiceID == "abc" {
+				return []*fastly.Backend{{Name: "origin", Address: "origin.example.com", Port: 443, UseSSL: true}}, nil
+			}
+			return []*fastly.Backend{{Name: "old"}}, nil
+		},
+		ListDictionariesFn: func(i *fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+			if i.ServiceID == "abc" {
+				return []*fastly.Dictionary{{ID: "d1", Name: "flags"}}, nil
+			}
+			return nil, nil
+		},
+		DeleteBackendFn: func(i *fastly.DeleteBackendInput) error {
+			if i.ServiceID != "123" || i.ServiceVersion != 4 || i.Name != "old" {
+				return testutil.Err
+			}
+			return nil
+		},
+		CreateConditionFn: func(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+			return &fastly.Condition{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: i.Name}, nil
+		},
+		CreateBackendFn: func(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
+			if createBackendErr != nil {
+				return nil, createBackendErr
+			}
+			if i.ServiceID != "123" || i.Address != "origin.example.com" || !bool(i.UseSSL) {
+				return nil, testutil.Err
+			}
+			return &fastly.Backend{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: i.Name}, nil
+		},
+		CreateDictionaryFn: func(i *fastly.CreateDictionaryInput) (*fastly.Dictionary, error) {
+			return &fastly.Dictionary{ID: "d2", ServiceID: i.ServiceID, Name: i.Name}, nil
+		},
+		NewListDictionaryItemsPaginatorFn: func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
+			return &dictionaryItemPage{items: []*fastly.DictionaryItem{{ItemKey: "beta", ItemValue: "on"}}}
+		},
+		BatchModifyDictionaryItemsFn: func(i *fastly.BatchModifyDictionaryItemsInput) error {
+			if i.ServiceID != "123" || i.DictionaryID != "d2" || len(i.Items) != 1 || i.Items[0].ItemKey != "beta" {
+				return testutil.Err
+			}
+			return nil
+		},
+	}
+}
+
+// dictionaryItemPage is a paginator with a single page of items.
+type dictionaryItemPage struct {
+	items []*fastly.DictionaryItem
+	done  bool
+}
+
+func (p *dictionaryItemPage) HasNext() bool { return !p.done }
+
+func (p *dictionaryItemPage) Remaining() int { return 0 }
+
+func (p *dictionaryItemPage) GetNext() ([]*fastly.DictionaryItem, error) {
+	p.done = true
+	return p.items, nil
+}
+
+func TestVersionActivateSmokeTest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	specOK := filepath.Join(dir, "ok.yaml")
+	specFail := filepath.Join(dir, "fail.yaml")
+	for path, url := range map[string]string{specOK: ts.URL + "/ok", specFail: ts.URL + "/fail"} {
+		if err := os.WriteFile(path, []byte("tests:\n  - url: "+url+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	args := testutil.Args
+	for _, testcase := range []struct {
+		args          []string
+		wantError     string
+		wantOutput    []string
+		wantActivated []int
+	}{
+		{
+			args:      args("service-version activate --service-id 123 --version 3 --rollback-on-failure"),
+			wantError: "--wait and --rollback-on-failure require --smoke-test",
+		},
+		{
+			args:          args("service-version activate --service-id 123 --version 3 --smoke-test " + specOK + " --rollback-on-failure"),
+			wantOutput:    []string{"Activated service 123 version 3", "All smoke tests of service 123 version 3 passed"},
+			wantActivated: []int{3},
+		},
+		{
+			args:          args("service-version activate --service-id 123 --version 3 --smoke-test " + specFail),
+			wantError:     "1 of 1 smoke tests failed",
+			wantOutput:    []string{"FAIL: status 500, want 200"},
+			wantActivated: []int{3},
+		},
+		{
+			args:          args("service-version activate --service-id 123 --version 3 --smoke-test " + specFail + " --rollback-on-failure"),
+			wantError:     "1 of 1 smoke tests failed",
+			wantOutput:    []string{"Rolled back service 123 from version 3 to version 1"},
+			wantActivated: []int{3, 1},
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var activated []int
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListDomainsFn: func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+					return nil, nil
+				},
+				ActivateVersionFn: func(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+					activated = append(activated, i.ServiceVersion)
+					return activateVersionOK(i)
+				},
+			})
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			testutil.AssertEqual(t, testcase.wantActivated, activated)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// s3Scheme is the prefix of export destinations that are S3 buckets.
+const s3Scheme = "s3://"
+
+// ExportCommand writes historical stats to partitioned files, either locally
+// or in an S3 bucket.
+type ExportCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	dest        string
+	format      string
+	granularity string
+	last        string
+	region      string
+	serviceName cmd.OptionalServiceNameID
+}
+
+// NewExportCommand returns a new command registered under parent.
+func NewExportCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *ExportCommand {
+	var c ExportCommand
+	c.Globals = globals
+	c.manifest = data
+
+	c.CmdClause = parent.Command("export", "Export historical stats for a Fastly service to files partitioned by date, locally or in S3")
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	c.CmdClause.Flag("to", "Destination: a local directory or s3://bucket/prefix (credentials and region are read from the AWS_* environment variables)").Required().StringVar(&c.dest)
+	c.CmdClause.Flag("granularity", "Aggregation period (minute/hour/day)").Default("hour").EnumVar(&c.granularity, "minute", "hour", "day")
+	c.CmdClause.Flag("last", "How far back to export, e.g. 12h, 30d or 1y").Default("1d").StringVar(&c.last)
+	c.CmdClause.Flag("region", "Filter by region ('stats regions' to list)").StringVar(&c.region)
+	c.CmdClause.Flag("format", "File format (csv/json). JSON files have a stats block per line").Default("csv").EnumVar(&c.format, "csv", "json")
+
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *ExportCommand) Exec(in io.Reader, out io.Writer) error {
+	last, err := cmd.ParseLongDuration(c.last)
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --last: %w", err),
+			Remediation: "Specify how far back to export, e.g. --last 30d.",
+		}
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	write, err := c.writer()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	// Only complete periods are exported, so that re-running the export
+	// doesn't change files that were already written.
+	end := truncate(c.Globals.Clock.Now(), c.granularity)
+	start := truncate(end.Add(-last), c.granularity)
+
+	var rows, files int
+	for _, p := range partitions(start, end, c.granularity) {
+		blocks, err := c.fetch(serviceID, p)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": serviceID,
+				"From":       p.from,
+				"To":         p.to,
+			})
+			return err
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+
+		data, contentType, err := c.encode(serviceID, blocks)
+		if err != nil {
+			return err
+		}
+		key := fmt.Sprintf("service_id=%s/by=%s/%s/stats.%s", serviceID, c.granularity, p.name, c.format)
+		path, err := write(key, data, contentType)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID": serviceID,
+				"Key":        key,
+			})
+			return err
+		}
+		text.Output(out, "Wrote %s (%d rows)", path, len(blocks))
+		rows += len(blocks)
+		files++
+	}
+
+	if files == 0 {
+		text.Info(out, "No stats found for service %s from %s to %s", serviceID, c.Globals.Formatter().UTCTime(start), c.Globals.Formatter().UTCTime(end))
+		return nil
+	}
+	text.Success(out, "Exported %d rows of stats for service %s to %d files", rows, serviceID, files)
+	return nil
+}
+
+// writer returns a function that writes the file with the given key to the
+// destination, returning its full path.
+func (c *ExportCommand) writer() (func(key string, data []byte, contentType string) (string, error), error) {
+	if !strings.HasPrefix(c.dest, s3Scheme) {
+		return func(key string, data []byte, _ string) (string, error) {
+			path := filepath.Join(c.dest, filepath.FromSlash(key))
+			if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return "", fmt.Errorf("error creating directory: %w", err)
+			}
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				return "", fmt.Errorf("error writing file: %w", err)
+			}
+			return path, nil
+		}, nil
+	}
+
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(c.dest, s3Scheme), "/")
+	prefix = strings.Trim(prefix, "/")
+	if bucket == "" {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --to: no bucket in %q", c.dest),
+			Remediation: "Specify the destination bucket and an optional prefix, e.g. --to s3://bucket/prefix.",
+		}
+	}
+	u, err := newS3Uploader(c.Globals.HTTPClient, bucket, c.Globals.Clock.Now)
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       err,
+			Remediation: "Set the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY (and optionally AWS_SESSION_TOKEN and AWS_REGION) environment variables.",
+		}
+	}
+	return func(key string, data []byte, contentType string) (string, error) {
+		if prefix != "" {
+			key = prefix + "/" + key
+		}
+		return s3Scheme + bucket + "/" + key, u.Put(key, data, contentType)
+	}, nil
+}
+
+// fetch returns the stats blocks of the partition p.
+func (c *ExportCommand) fetch(serviceID string, p partition) ([]statsResponseData, error) {
+	var envelope statsResponse
+	err := c.Globals.APIClient.GetStatsJSON(&fastly.GetStatsInput{
+		Service: serviceID,
+		From:    strconv.FormatInt(p.from.Unix(), 10),
+		To:      strconv.FormatInt(p.to.Unix(), 10),
+		By:      c.granularity,
+		Region:  c.region,
+	}, &envelope)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.Status != statusSuccess {
+		return nil, fmt.Errorf("non-success response: %s", envelope.Msg)
+	}
+
+	// The API may include the block at the end of the range, which belongs
+	// to the next partition.
+	var blocks []statsResponseData
+	for _, b := range envelope.Data {
+		if t, ok := b["start_time"].(float64); ok && (int64(t) < p.from.Unix() || int64(t) >= p.to.Unix()) {
+			continue
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// encode renders blocks in the requested file format.
+func (c *ExportCommand) encode(serviceID string, blocks []statsResponseData) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if c.format == "json" {
+		if err := writeBlocksJSON(&buf, serviceID, blocks); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "application/x-ndjson", nil
+	}
+
+	// The columns are the union of the blocks' fields, so that every file has
+	// a consistent header even if a field is missing from some blocks.
+	fields := map[string]bool{}
+	for _, b := range blocks {
+		for k := range b {
+			fields[k] = true
+		}
+	}
+	delete(fields, "start_time")
+	columns := []string{"service_id", "start_time"}
+	rest := make([]string, 0, len(fields))
+	for k := range fields {
+		rest = append(rest, k)
+	}
+	sort.Strings(rest)
+	columns = append(columns, rest...)
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(columns); err != nil {
+		return nil, "", err
+	}
+	for _, b := range blocks {
+		record := []string{serviceID}
+		for _, k := range columns[1:] {
+			record = append(record, csvValue(b[k]))
+		}
+		if err := w.Write(record); err != nil {
+			return nil, "", err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), "text/csv", w.Error()
+}
+
+// csvValue renders a stats field as a CSV value.
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// partition is a range of time whose stats are written to the same file.
+type partition struct {
+	from time.Time
+	to   time.Time
+	// name is the partition's path segment, e.g. date=2022-06-15.
+	name string
+}
+
+// partitions splits the range from start to end into a partition per day, or
+// per month when the stats are aggregated by day.
+func partitions(start, end time.Time, granularity string) []partition {
+	var ps []partition
+	for from := start; from.Before(end); {
+		var (
+			to   time.Time
+			name string
+		)
+		if granularity == "day" {
+			to = time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			name = from.Format("month=2006-01")
+		} else {
+			to = time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC)
+			name = from.Format("date=2006-01-02")
+		}
+		if to.After(end) {
+			to = end
+		}
+		ps = append(ps, partition{from: from, to: to, name: name})
+		from = to
+	}
+	return ps
+}
+
+// truncate returns t, in UTC, rounded down to the start of its minute, hour
+// or day.
+func truncate(t time.Time, granularity string) time.Time {
+	t = t.UTC()
+	switch granularity {
+	case "minute":
+		return t.Truncate(time.Minute)
+	case "hour":
+		return t.Truncate(time.Hour)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,122 @@
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout bytes.Buffer
+	args := testutil.Args("stats export --service-id 123 --last 2d --to " + dir + " --deterministic")
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = mock.APIClient(mock.API{GetStatsJSONFn: getStatsJSONHourly})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Exported 4 rows of stats for service 123 to 2 files")
+
+	// The --deterministic clock is at 2000-01-01T00:00:00Z.
+	data, err := os.ReadFile(filepath.Join(dir, "service_id=123", "by=hour", "date=1999-12-30", "stats.csv"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "service_id,start_time,hits,requests\n123,946512000,,10\n123,946515600,5,1000000\n", string(data))
+	_, err = os.Stat(filepath.Join(dir, "service_id=123", "by=hour", "date=1999-12-31", "stats.csv"))
+	testutil.AssertNoError(t, err)
+}
+
+func TestExportS3(t *testing.T) {
+	objects := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/20000101/eu-west-1/s3/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		objects[r.Method+" "+r.URL.EscapedPath()] = string(body)
+	}))
+	defer ts.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_ENDPOINT_URL", ts.URL)
+
+	var stdout bytes.Buffer
+	args := testutil.Args("stats export --service-id 123 --last 1d --format json --to s3://bucket/fastly/ --deterministic")
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = mock.APIClient(mock.API{GetStatsJSONFn: getStatsJSONHourly})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Wrote s3://bucket/fastly/service_id=123/by=hour/date=1999-12-31/stats.json (2 rows)")
+	testutil.AssertEqual(t, map[string]string{
+		"PUT /bucket/fastly/service_id%3D123/by%3Dhour/date%3D1999-12-31/stats.json": "{\"requests\":10,\"start_time\":946598400}\n" +
+			"{\"hits\":5,\"requests\":1000000,\"start_time\":946602000}\n",
+	}, objects)
+}
+
+func TestExportErrors(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	for _, testcase := range []struct {
+		args      string
+		wantError string
+	}{
+		{
+			args:      "stats export --service-id 123 --last 30x --to out",
+			wantError: "invalid --last: must be a duration such as 12h, 90d, 2w or 1y",
+		},
+		{
+			args:      "stats export --service-id 123 --to s3://bucket",
+			wantError: "AWS credentials not found",
+		},
+		{
+			args:      "stats export --service-id 123 --to s3:///prefix",
+			wantError: "invalid --to: no bucket",
+		},
+	} {
+		t.Run(testcase.args, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(testcase.args), &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
+
+// getStatsJSONHourly returns two hourly blocks at the start of the requested
+// range, and a block at its end that belongs to the following range.
+func getStatsJSONHourly(i *fastly.GetStatsInput, o interface{}) error {
+	from, err := strconv.ParseInt(i.From, 10, 64)
+	if err != nil {
+		return err
+	}
+	to, err := strconv.ParseInt(i.To, 10, 64)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf(`{
+  "status": "success",
+  "meta": {"by": %q},
+  "data": [
+    {"start_time": %d, "requests": 10},
+    {"start_time": %d, "requests": 1000000, "hits": 5},
+    {"start_time": %d, "requests": 1}
+  ]
+}`, i.By, from, from+3600, to)
+	return json.Unmarshal([]byte(msg), o)
+}
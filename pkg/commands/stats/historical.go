@@ -8,6 +8,7 @@ import (
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -55,7 +56,7 @@ func NewHistoricalCommand(parent cmd.Registerer, globals *config.Data, data mani
 
 // Exec implements the command interface.
 func (c *HistoricalCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -89,7 +90,7 @@ func (c *HistoricalCommand) Exec(in io.Reader, out io.Writer) error {
 
 	default:
 		writeHeader(out, envelope.Meta)
-		err := writeBlocks(out, serviceID, envelope.Data)
+		err := writeBlocks(out, serviceID, envelope.Data, c.Globals.Formatter())
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID": serviceID,
@@ -108,9 +109,9 @@ func writeHeader(out io.Writer, meta statsResponseMeta) {
 	fmt.Fprintf(out, "---\n")
 }
 
-func writeBlocks(out io.Writer, service string, blocks []statsResponseData) error {
+func writeBlocks(out io.Writer, service string, blocks []statsResponseData, f text.Formatter) error {
 	for _, block := range blocks {
-		if err := fmtBlock(out, service, block); err != nil {
+		if err := fmtBlock(out, service, block, f); err != nil {
 			return err
 		}
 	}
@@ -25,6 +25,11 @@ func TestHistorical(t *testing.T) {
 			api:        mock.API{GetStatsJSONFn: getStatsJSONOK},
 			wantOutput: historicalOK,
 		},
+		{
+			args:       args("stats historical --service-id=123 --iso8601 --human-sizes"),
+			api:        mock.API{GetStatsJSONFn: getStatsJSONOK},
+			wantOutput: historicalFormattedOK,
+		},
 		{
 			args:      args("stats historical --service-id=123"),
 			api:       mock.API{GetStatsJSONFn: getStatsJSONError},
@@ -47,6 +52,16 @@ func TestHistorical(t *testing.T) {
 	}
 }
 
+var historicalFormattedOK = `Service ID:                                    123
+Start Time:                   1970-01-01T00:00:00Z
+--------------------------------------------------
+Hit Rate:                                    0.00%
+Avg Hit Time:                               0.00µs
+Avg Miss Time:                              0.00µs
+
+Request BW:                                    0 B
+  Headers:                                     0 B`
+
 var historicalOK = `From: Wed May 15 20:08:35 UTC 2013
 To: Thu May 16 20:08:35 UTC 2013
 By: day
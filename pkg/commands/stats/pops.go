@@ -0,0 +1,259 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// RealtimeRetention is how far back the Realtime Metrics API retains stats,
+// which is the only source of stats broken down by POP.
+const RealtimeRetention = 120 * time.Second
+
+// PopsCommand reports a service's traffic broken down by POP.
+type PopsCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	geoJSON     string
+	json        bool
+	last        time.Duration
+	metric      string
+	serviceName cmd.OptionalServiceNameID
+}
+
+// NewPopsCommand is the "stats pops" subcommand.
+func NewPopsCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *PopsCommand {
+	var c PopsCommand
+	c.Globals = globals
+	c.manifest = data
+
+	c.CmdClause = parent.Command("pops", "View a Fastly service's recent traffic broken down by POP")
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	c.CmdClause.Flag("geojson", "Write a GeoJSON FeatureCollection of the POPs and their traffic to this file, for plotting a heat map").StringVar(&c.geoJSON)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("last", "Period of traffic to report, up to 2m (the retention of realtime stats)").Default("1m").DurationVar(&c.last)
+	c.CmdClause.Flag("metric", "Stats field to report, e.g. requests, bandwidth, hits, miss or status_5xx").Default("requests").StringVar(&c.metric)
+
+	return &c
+}
+
+// PopTraffic is the value of a metric at a single POP.
+type PopTraffic struct {
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Value     float64 `json:"value"`
+	Share     float64 `json:"share"`
+}
+
+// Exec implements the command interface.
+func (c *PopsCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.last <= 0 || c.last > RealtimeRetention {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --last '%s': stats broken down by POP are only retained for %s", c.last, RealtimeRetention),
+			Remediation: "Use a --last of 2m or less, or 'fastly stats historical' for a service's overall stats over a longer period.",
+		}
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	// A timestamp of zero requests all the retained stats.
+	var envelope realtimeResponse
+	err = c.Globals.RTSClient.GetRealtimeStatsJSON(&fastly.GetRealtimeStatsInput{
+		ServiceID: serviceID,
+	}, &envelope)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	dcs, err := c.Globals.APIClient.AllDatacenters()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	pops, err := popsBreakdown(envelope.Data, c.metric, c.last, dcs)
+	if err != nil {
+		return err
+	}
+
+	if c.geoJSON != "" {
+		if err := writeGeoJSON(c.geoJSON, c.metric, pops); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error writing GeoJSON: %w", err)
+		}
+	}
+
+	if c.json {
+		data, err := json.Marshal(pops)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(pops) == 0 {
+		text.Info(out, "No traffic was recorded in the last %s", c.last)
+		return nil
+	}
+	t := text.NewTable(out)
+	t.AddHeader("POP", "NAME", strings.ToUpper(c.metric), "SHARE")
+	for _, p := range pops {
+		t.AddLine(p.Code, p.Name, formatValue(c.metric, p.Value, c.Globals.Formatter()), fmt.Sprintf("%.1f%%", p.Share))
+	}
+	t.Print()
+	if c.geoJSON != "" {
+		text.Break(out)
+		text.Success(out, "Wrote GeoJSON to %s", c.geoJSON)
+	}
+	return nil
+}
+
+// popsBreakdown sums the metric for each POP over the blocks recorded in the
+// last period (relative to the most recent block), sorted by value with the
+// busiest POP first. POPs without any of the metric are omitted.
+func popsBreakdown(blocks []realtimeResponseData, metric string, last time.Duration, dcs []fastly.Datacenter) ([]PopTraffic, error) {
+	var latest float64
+	for _, b := range blocks {
+		if b.Recorded > latest {
+			latest = b.Recorded
+		}
+	}
+	cutoff := latest - last.Seconds()
+
+	totals := make(map[string]float64)
+	var total float64
+	for _, b := range blocks {
+		if b.Recorded <= cutoff {
+			continue
+		}
+		for pop, stats := range b.Datacenter {
+			v, ok := stats[metric]
+			if !ok {
+				continue
+			}
+			n, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("stats field '%s' isn't a number", metric)
+			}
+			totals[pop] += n
+			total += n
+		}
+	}
+
+	byCode := make(map[string]fastly.Datacenter, len(dcs))
+	for _, dc := range dcs {
+		byCode[dc.Code] = dc
+	}
+
+	pops := make([]PopTraffic, 0, len(totals))
+	for code, v := range totals {
+		if v == 0 {
+			continue
+		}
+		dc := byCode[code]
+		pops = append(pops, PopTraffic{
+			Code:      code,
+			Name:      dc.Name,
+			Latitude:  dc.Coordinates.Latitude,
+			Longitude: dc.Coordinates.Longtitude,
+			Value:     v,
+			Share:     v / total * 100,
+		})
+	}
+	sort.Slice(pops, func(i, j int) bool {
+		if pops[i].Value != pops[j].Value {
+			return pops[i].Value > pops[j].Value
+		}
+		return pops[i].Code < pops[j].Code
+	})
+	return pops, nil
+}
+
+// writeGeoJSON writes the POPs as a GeoJSON FeatureCollection of points.
+func writeGeoJSON(path, metric string, pops []PopTraffic) error {
+	type geometry struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string                 `json:"type"`
+		Geometry   geometry               `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	collection := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: []feature{}}
+
+	for _, p := range pops {
+		collection.Features = append(collection.Features, feature{
+			Type: "Feature",
+			// GeoJSON positions are longitude first.
+			Geometry: geometry{Type: "Point", Coordinates: [2]float64{p.Longitude, p.Latitude}},
+			Properties: map[string]interface{}{
+				"pop":    p.Code,
+				"name":   p.Name,
+				"metric": metric,
+				"value":  p.Value,
+				"share":  p.Share,
+			},
+		})
+	}
+
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+	// #nosec G306
+	return os.WriteFile(path, data, 0o644)
+}
+
+// formatValue formats byte metrics using f.
+func formatValue(metric string, v float64, f text.Formatter) string {
+	switch metric {
+	case "bandwidth", "body_size", "header_size", "req_body_bytes", "req_header_bytes", "resp_body_bytes", "resp_header_bytes":
+		return f.Bytes(uint64(v))
+	}
+	return fmt.Sprintf("%.0f", v)
+}
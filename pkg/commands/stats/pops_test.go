@@ -0,0 +1,128 @@
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestPops(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{AllDatacentersFn: allDatacentersOK}
+	rts := mock.RealtimeStats{GetRealtimeStatsJSONFn: getRealtimeStatsOK}
+
+	for _, testcase := range []struct {
+		args       []string
+		rts        mock.RealtimeStats
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("stats pops --service-id 123 --last 1h"),
+			wantError: "invalid --last '1h0m0s': stats broken down by POP are only retained for 2m0s",
+		},
+		{
+			args: args("stats pops --service-id 123"),
+			rts:  rts,
+			wantOutput: `POP  NAME       REQUESTS  SHARE
+LHR  London     75        75.0%
+AMS  Amsterdam  25        25.0%
+`,
+		},
+		{
+			args: args("stats pops --service-id 123 --last 2m --metric bandwidth"),
+			rts:  rts,
+			wantOutput: `POP  NAME       BANDWIDTH  SHARE
+LHR  London     3000       60.0%
+AMS  Amsterdam  2000       40.0%
+`,
+		},
+		{
+			args:       args("stats pops --service-id 123 --json"),
+			rts:        rts,
+			wantOutput: `[{"code":"LHR","name":"London","latitude":51.5,"longitude":-0.1,"value":75,"share":75},{"code":"AMS","name":"Amsterdam","latitude":52.3,"longitude":4.9,"value":25,"share":25}]`,
+		},
+		{
+			args: args("stats pops --service-id 123"),
+			rts: mock.RealtimeStats{
+				GetRealtimeStatsJSONFn: func(*fastly.GetRealtimeStatsInput, interface{}) error {
+					return errTest
+				},
+			},
+			wantError: errTest.Error(),
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			opts.RTSClient = mock.RTSClient(testcase.rts)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, stdout.String())
+		})
+	}
+}
+
+func TestPopsGeoJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pops.geojson")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("stats pops --service-id 123 --geojson "+path), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{AllDatacentersFn: allDatacentersOK})
+	opts.RTSClient = mock.RTSClient(mock.RealtimeStats{GetRealtimeStatsJSONFn: getRealtimeStatsOK})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Wrote GeoJSON to "+path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Type     string
+		Features []struct {
+			Geometry struct {
+				Type        string
+				Coordinates []float64
+			}
+			Properties map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "FeatureCollection", collection.Type)
+	testutil.AssertEqual(t, 2, len(collection.Features))
+	testutil.AssertEqual(t, []float64{-0.1, 51.5}, collection.Features[0].Geometry.Coordinates)
+	testutil.AssertEqual(t, "LHR", collection.Features[0].Properties["pop"])
+	testutil.AssertEqual(t, 75.0, collection.Features[0].Properties["value"])
+}
+
+func allDatacentersOK() ([]fastly.Datacenter, error) {
+	return []fastly.Datacenter{
+		{Code: "AMS", Name: "Amsterdam", Coordinates: fastly.Coordinates{Latitude: 52.3, Longtitude: 4.9}},
+		{Code: "LHR", Name: "London", Coordinates: fastly.Coordinates{Latitude: 51.5, Longtitude: -0.1}},
+	}, nil
+}
+
+// getRealtimeStatsOK returns a block recorded 90 seconds before the latest,
+// which is excluded from the default --last of 1m.
+func getRealtimeStatsOK(i *fastly.GetRealtimeStatsInput, o interface{}) error {
+	return json.Unmarshal([]byte(`{
+  "Timestamp": 1000,
+  "Data": [
+    {"recorded": 910, "datacenter": {"AMS": {"requests": 100, "bandwidth": 1000}}},
+    {"recorded": 970, "datacenter": {"AMS": {"requests": 10, "bandwidth": 500}, "LHR": {"requests": 50, "bandwidth": 2000}}},
+    {"recorded": 1000, "datacenter": {"AMS": {"requests": 15, "bandwidth": 500}, "LHR": {"requests": 25, "bandwidth": 1000}}}
+  ]
+}`), o)
+}
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 
@@ -48,7 +49,7 @@ func NewRealtimeCommand(parent cmd.Registerer, globals *config.Data, data manife
 
 // Exec implements the command interface.
 func (c *RealtimeCommand) Exec(in io.Reader, out io.Writer) error {
-	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog, c.Globals.ProfileServiceID())
 	if err != nil {
 		return err
 	}
@@ -58,7 +59,7 @@ func (c *RealtimeCommand) Exec(in io.Reader, out io.Writer) error {
 
 	switch c.formatFlag {
 	case "json":
-		if err := loopJSON(c.Globals.RTSClient, serviceID, out); err != nil {
+		if err := loopJSON(c.Globals.Context, c.Globals.RTSClient, serviceID, out); err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID": serviceID,
 			})
@@ -66,7 +67,7 @@ func (c *RealtimeCommand) Exec(in io.Reader, out io.Writer) error {
 		}
 
 	default:
-		if err := loopText(c.Globals.RTSClient, serviceID, out); err != nil {
+		if err := loopText(c.Globals.Context, c.Globals.RTSClient, serviceID, out, c.Globals.Formatter()); err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID": serviceID,
 			})
@@ -77,7 +78,7 @@ func (c *RealtimeCommand) Exec(in io.Reader, out io.Writer) error {
 	return nil
 }
 
-func loopJSON(client api.RealtimeStatsInterface, service string, out io.Writer) error {
+func loopJSON(ctx context.Context, client api.RealtimeStatsInterface, service string, out io.Writer) error {
 	var timestamp uint64
 	for {
 		var envelope struct {
@@ -85,10 +86,13 @@ func loopJSON(client api.RealtimeStatsInterface, service string, out io.Writer)
 			Data      []json.RawMessage `json:"data"`
 		}
 
-		err := client.GetRealtimeStatsJSON(&fastly.GetRealtimeStatsInput{
+		err := getRealtimeStats(ctx, client, &fastly.GetRealtimeStatsInput{
 			ServiceID: service,
 			Timestamp: timestamp,
 		}, &envelope)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			text.Error(out, "fetching stats: %w", err)
 			continue
@@ -102,15 +106,18 @@ func loopJSON(client api.RealtimeStatsInterface, service string, out io.Writer)
 	}
 }
 
-func loopText(client api.RealtimeStatsInterface, service string, out io.Writer) error {
+func loopText(ctx context.Context, client api.RealtimeStatsInterface, service string, out io.Writer, f text.Formatter) error {
 	var timestamp uint64
 	for {
 		var envelope realtimeResponse
 
-		err := client.GetRealtimeStatsJSON(&fastly.GetRealtimeStatsInput{
+		err := getRealtimeStats(ctx, client, &fastly.GetRealtimeStatsInput{
 			ServiceID: service,
 			Timestamp: timestamp,
 		}, &envelope)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			text.Error(out, "fetching stats: %w", err)
 			continue
@@ -126,10 +133,28 @@ func loopText(client api.RealtimeStatsInterface, service string, out io.Writer)
 			agg["start_time"] = block.Recorded
 			delete(agg, "miss_histogram")
 
-			if err := fmtBlock(out, service, agg); err != nil {
+			if err := fmtBlock(out, service, agg, f); err != nil {
 				text.Error(out, "formatting stats: %w", err)
 				continue
 			}
 		}
 	}
 }
+
+// getRealtimeStats fetches the stats, or returns as soon as ctx is cancelled.
+//
+// NOTE: The realtime stats client doesn't expose its HTTP client, so its
+// requests can't be bound to ctx. A cancelled request is left to finish in the
+// background, and its result discarded.
+func getRealtimeStats(ctx context.Context, client api.RealtimeStatsInterface, input *fastly.GetRealtimeStatsInput, dst interface{}) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- client.GetRealtimeStatsJSON(input, dst)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,197 @@
+package stats
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// s3Credentials are the AWS credentials used to sign S3 requests.
+type s3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// s3Uploader writes objects to an S3 bucket (or an S3 compatible store) using
+// AWS Signature Version 4 requests.
+type s3Uploader struct {
+	bucket      string
+	client      api.HTTPClient
+	credentials s3Credentials
+	// endpoint is the base URL of an S3 compatible store, whose objects are
+	// addressed by path (i.e. <endpoint>/<bucket>/<key>). When empty, the
+	// regional AWS endpoint of the bucket is used.
+	endpoint string
+	now      func() time.Time
+	region   string
+}
+
+// newS3Uploader returns an uploader for bucket configured by the standard AWS
+// environment variables.
+func newS3Uploader(client api.HTTPClient, bucket string, now func() time.Time) (*s3Uploader, error) {
+	u := &s3Uploader{
+		bucket: bucket,
+		client: client,
+		credentials: s3Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+		endpoint: strings.TrimSuffix(os.Getenv("AWS_ENDPOINT_URL"), "/"),
+		now:      now,
+		region:   os.Getenv("AWS_REGION"),
+	}
+	if u.region == "" {
+		u.region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if u.region == "" {
+		u.region = "us-east-1"
+	}
+	if u.credentials.AccessKeyID == "" || u.credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("AWS credentials not found")
+	}
+	return u, nil
+}
+
+// Put writes data to the object key.
+func (u *s3Uploader) Put(key string, data []byte, contentType string) error {
+	var (
+		host string
+		path string
+	)
+	if u.endpoint != "" {
+		e, err := url.Parse(u.endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid AWS_ENDPOINT_URL: %w", err)
+		}
+		host = e.Host
+		path = strings.TrimSuffix(e.Path, "/") + "/" + u.bucket + "/" + key
+		path = e.Scheme + "://" + host + s3EscapePath(path)
+	} else {
+		host = fmt.Sprintf("%s.s3.%s.amazonaws.com", u.bucket, u.region)
+		path = "https://" + host + s3EscapePath("/"+key)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	signS3Request(req, data, u.region, u.credentials, u.now())
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error writing s3://%s/%s: %s: %s", u.bucket, key, resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// signS3Request adds an AWS Signature Version 4 Authorization header to req,
+// whose body is payload, signing its Host header and any Date and X-Amz-*
+// headers.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signS3Request(req *http.Request, payload []byte, region string, creds s3Credentials, t time.Time) {
+	t = t.UTC()
+	date := t.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", t.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if k == "date" || strings.HasPrefix(k, "x-amz-") {
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", k, headers[k])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		t.Format("20060102T150405Z"),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// s3EscapePath escapes each segment of an object path as S3 requires, i.e.
+// everything but unreserved characters is percent-encoded.
+func s3EscapePath(path string) string {
+	segs := strings.Split(path, "/")
+	for i, s := range segs {
+		var b strings.Builder
+		for _, c := range []byte(s) {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		segs[i] = b.String()
+	}
+	return strings.Join(segs, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -6,6 +6,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/mitchellh/mapstructure"
 )
@@ -36,7 +37,7 @@ Requests:           {{ .RequestCount }}
 
 `))
 
-func fmtBlock(out io.Writer, service string, block statsResponseData) error {
+func fmtBlock(out io.Writer, service string, block statsResponseData, f text.Formatter) error {
 	var agg fastly.Stats
 	if err := mapstructure.Decode(block, &agg); err != nil {
 		return err
@@ -52,17 +53,17 @@ func fmtBlock(out io.Writer, service string, block statsResponseData) error {
 
 	values := map[string]string{
 		"ServiceID":   fmt.Sprintf("%30s", service),
-		"StartTime":   fmt.Sprintf("%30s", startTime),
+		"StartTime":   fmt.Sprintf("%30s", f.Time(startTime)),
 		"HitRate":     fmt.Sprintf("%29.2f%%", hitRate*100),
 		"AvgHitTime":  fmt.Sprintf("%28.2f\u00b5s", agg.HitsTime*1000),
 		"AvgMissTime": fmt.Sprintf("%28.2f\u00b5s", agg.MissTime*1000),
 
-		"RequestBytes":        fmt.Sprintf("%30d", agg.RequestHeaderBytes+agg.RequestBodyBytes),
-		"RequestHeaderBytes":  fmt.Sprintf("%30d", agg.RequestHeaderBytes),
-		"RequestBodyBytes":    fmt.Sprintf("%30d", agg.RequestBodyBytes),
-		"ResponseBytes":       fmt.Sprintf("%30d", agg.ResponseHeaderBytes+agg.ResponseBodyBytes),
-		"ResponseHeaderBytes": fmt.Sprintf("%30d", agg.ResponseHeaderBytes),
-		"ResponseBodyBytes":   fmt.Sprintf("%30d", agg.ResponseBodyBytes),
+		"RequestBytes":        fmt.Sprintf("%30s", f.Bytes(agg.RequestHeaderBytes+agg.RequestBodyBytes)),
+		"RequestHeaderBytes":  fmt.Sprintf("%30s", f.Bytes(agg.RequestHeaderBytes)),
+		"RequestBodyBytes":    fmt.Sprintf("%30s", f.Bytes(agg.RequestBodyBytes)),
+		"ResponseBytes":       fmt.Sprintf("%30s", f.Bytes(agg.ResponseHeaderBytes+agg.ResponseBodyBytes)),
+		"ResponseHeaderBytes": fmt.Sprintf("%30s", f.Bytes(agg.ResponseHeaderBytes)),
+		"ResponseBodyBytes":   fmt.Sprintf("%30s", f.Bytes(agg.ResponseBodyBytes)),
 
 		"RequestCount": fmt.Sprintf("%30d", agg.Requests),
 		"Hits":         fmt.Sprintf("%30d", agg.Hits),
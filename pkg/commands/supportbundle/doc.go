@@ -0,0 +1,3 @@
+// Package supportbundle contains a command that gathers diagnostic information
+// about the CLI into an archive that can be attached to a support ticket.
+package supportbundle
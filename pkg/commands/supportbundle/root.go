@@ -0,0 +1,181 @@
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/transport"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+	toml "github.com/pelletier/go-toml"
+)
+
+// ErrorLogEntries is the number of most recent error log records included in
+// the bundle.
+const ErrorLogEntries = 20
+
+// errorLogSeparator is the line written by fsterr.LogEntries.Persist after
+// each recorded command.
+const errorLogSeparator = "------------------------------"
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	file string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("support-bundle", "Generate an archive of diagnostic information for Fastly support")
+	c.CmdClause.Flag("file", "Path to write the tar.gz archive to (default: fastly-support-bundle-<TIMESTAMP>.tar.gz)").StringVar(&c.file)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	now := fsterr.Now().UTC()
+
+	dst := c.file
+	if dst == "" {
+		dst = fmt.Sprintf("fastly-support-bundle-%s.tar.gz", now.Format("20060102T150405Z"))
+	}
+
+	files, err := c.gather(now)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	if err := write(dst, files, now); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Output": dst,
+		})
+		return err
+	}
+
+	text.Success(out, "Created support bundle %s", dst)
+	text.Break(out)
+	text.Output(out, "The bundle has had API tokens and other credentials redacted, but please review its contents before attaching it to a support ticket.")
+	return nil
+}
+
+// bundleFile is a file to be written into the bundle archive.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// gather collects the contents of the bundle.
+func (c *RootCommand) gather(now time.Time) ([]bundleFile, error) {
+	var files []bundleFile
+
+	var version bytes.Buffer
+	fmt.Fprintf(&version, "Generated: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&version, "Fastly CLI version: %s (%s)\n", revision.AppVersion, revision.GitCommit)
+	fmt.Fprintf(&version, "Built with %s\n", revision.GoVersion)
+	fmt.Fprintf(&version, "Running on %s/%s (%s)\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	files = append(files, bundleFile{name: "version.txt", data: version.Bytes()})
+
+	var cfg bytes.Buffer
+	if err := toml.NewEncoder(&cfg).Encode(c.Globals.File.Redacted()); err != nil {
+		return nil, fmt.Errorf("error encoding configuration: %w", err)
+	}
+	files = append(files, bundleFile{name: "config.toml", data: cfg.Bytes()})
+
+	errorLog, err := recentErrors(fsterr.LogPath, ErrorLogEntries)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, bundleFile{name: "errors.log", data: []byte(errorLog)})
+
+	requests, err := transport.ReadLog(transport.LogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading request log: %w", err)
+	}
+	var data []byte
+	if len(requests) > 0 {
+		data = []byte(strings.Join(requests, "\n") + "\n")
+	}
+	files = append(files, bundleFile{name: "failed-requests.log", data: data})
+
+	return files, nil
+}
+
+// recentErrors returns the last n command records from the error log, with any
+// API tokens redacted.
+func recentErrors(logPath string, n int) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(logPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error reading error log: %w", err)
+	}
+
+	records := strings.SplitAfter(string(data), errorLogSeparator)
+	var nonEmpty []string
+	for _, r := range records {
+		if strings.TrimSpace(r) != "" {
+			nonEmpty = append(nonEmpty, r)
+		}
+	}
+	if len(nonEmpty) > n {
+		nonEmpty = nonEmpty[len(nonEmpty)-n:]
+	}
+
+	return fsterr.FilterToken(strings.Join(nonEmpty, "")), nil
+}
+
+// write creates a tar.gz archive at dst containing the given files.
+func write(dst string, files []bundleFile, modTime time.Time) (err error) {
+	f, err := os.OpenFile(filepath.Clean(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("error creating support bundle: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("error closing support bundle: %w", cerr)
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	for _, file := range files {
+		hdr := &tar.Header{
+			Name:    path.Join("fastly-support-bundle", file.name),
+			Mode:    0o600,
+			Size:    int64(len(file.data)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("error writing support bundle: %w", err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return fmt.Errorf("error writing support bundle: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("error writing support bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error writing support bundle: %w", err)
+	}
+	return nil
+}
@@ -15,27 +15,56 @@ import (
 	fstruntime "github.com/fastly/cli/pkg/runtime"
 )
 
+// Release channels that the CLI can be updated from.
+const (
+	// ChannelStable is the channel of the latest release.
+	ChannelStable = "stable"
+	// ChannelBeta is the channel of the highest release, including
+	// pre-releases.
+	ChannelBeta = "beta"
+)
+
+// Channels is the list of supported release channels.
+var Channels = []string{ChannelStable, ChannelBeta}
+
 // Check if the CLI can be updated.
 func Check(ctx context.Context, currentVersion string, cliVersioner Versioner) (current, latest semver.Version, shouldUpdate bool, err error) {
+	return CheckChannel(ctx, currentVersion, ChannelStable, cliVersioner)
+}
+
+// CheckChannel is like Check, but compares the current version against the
+// latest version of the given release channel.
+func CheckChannel(ctx context.Context, currentVersion, channel string, cliVersioner Versioner) (current, latest semver.Version, shouldUpdate bool, err error) {
 	current, err = semver.Parse(strings.TrimPrefix(currentVersion, "v"))
 	if err != nil {
 		return current, latest, false, fmt.Errorf("error reading current version: %w", err)
 	}
 
-	latest, err = cliVersioner.LatestVersion(ctx)
+	switch channel {
+	case ChannelBeta:
+		latest, err = cliVersioner.LatestPrerelease(ctx)
+	default:
+		latest, err = cliVersioner.LatestVersion(ctx)
+	}
 	if err != nil {
 		return current, latest, false, fmt.Errorf("error fetching latest version: %w", err)
 	}
 
+	SetAsset(cliVersioner, latest)
+
+	return current, latest, latest.GT(current), nil
+}
+
+// SetAsset configures the versioner to download the release asset of the
+// given version for the current OS and architecture.
+func SetAsset(cliVersioner Versioner, version semver.Version) {
 	// TODO: change goreleaser to produce .tar.gz for CLI on Windows
 	archiveFormat := ".tar.gz"
 	if fstruntime.Windows {
 		archiveFormat = ".zip"
 	}
-	asset := fmt.Sprintf(DefaultAssetFormat, cliVersioner.BinaryName(), latest, runtime.GOOS, runtime.GOARCH, archiveFormat)
+	asset := fmt.Sprintf(DefaultAssetFormat, cliVersioner.BinaryName(), version, runtime.GOOS, runtime.GOARCH, archiveFormat)
 	cliVersioner.SetAsset(asset)
-
-	return current, latest, latest.GT(current), nil
 }
 
 type checkResult struct {
@@ -6,7 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/blang/semver"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
@@ -20,8 +22,11 @@ import (
 // It should be installed under the primary root command.
 type RootCommand struct {
 	cmd.Base
+	channel        string
 	cliVersioner   Versioner
 	configFilePath string
+	rollback       bool
+	version        string
 }
 
 // NewRootCommand returns a new command registered in the parent.
@@ -31,28 +36,57 @@ func NewRootCommand(parent cmd.Registerer, configFilePath string, cliVersioner V
 	c.CmdClause = parent.Command("update", "Update the CLI to the latest version")
 	c.cliVersioner = cliVersioner
 	c.configFilePath = configFilePath
+	c.CmdClause.Flag("channel", "Release channel to update from: stable (the default) or beta, which includes pre-releases").HintOptions(Channels...).EnumVar(&c.channel, Channels...)
+	c.CmdClause.Flag("rollback", "Restore the binary that the last update replaced").BoolVar(&c.rollback)
+	c.CmdClause.Flag("version", "Update (or downgrade) to a pinned version, e.g. 4.3.0").StringVar(&c.version)
 	return &c
 }
 
+// executable returns the path of the running binary, and is replaced by tests.
+var executable = os.Executable
+
+// PreviousPath returns the path at which the binary that an update replaces is
+// kept, for the binary at the given path.
+func PreviousPath(path string) string {
+	return path + ".previous"
+}
+
 // Exec implements the command interface.
 func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
-	current, latest, shouldUpdate, err := Check(context.Background(), revision.AppVersion, c.cliVersioner)
+	if c.rollback && (c.channel != "" || c.version != "") {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("--rollback can't be used with --channel or --version"),
+			Remediation: "Run `fastly update --rollback` on its own to restore the previous binary.",
+		}
+	}
+	if c.channel != "" && c.version != "" {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("--channel and --version are mutually exclusive"),
+			Remediation: "Use --version to pin a release, or --channel to follow the latest release of a channel.",
+		}
+	}
+	if c.rollback {
+		return c.rollbackBinary(out)
+	}
+
+	current, latest, shouldUpdate, err := c.check()
 	if err != nil {
-		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
-			"App version": revision.AppVersion,
-		})
-		return fmt.Errorf("error checking for latest version: %w", err)
+		return err
 	}
 
 	text.Break(out)
 	text.Output(out, "Current version: %s", current)
-	text.Output(out, "Latest version: %s", latest)
+	if c.version != "" {
+		text.Output(out, "Pinned version: %s", latest)
+	} else {
+		text.Output(out, "Latest version: %s", latest)
+	}
 	text.Break(out)
 
 	progress := text.NewProgress(out, c.Globals.Verbose())
 	progress.Step("Updating versioning information...")
 
-	err = c.Globals.File.Load(c.Globals.File.CLI.RemoteConfig, config.FilePath, c.Globals.HTTPClient)
+	err = c.Globals.File.Load(c.Globals.File.CLI.RemoteConfig, c.configFilePath, c.Globals.HTTPClient)
 	if err != nil {
 		progress.Fail()
 		return errors.RemediationError{
@@ -67,7 +101,7 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 		return nil
 	}
 
-	progress.Step("Fetching latest release...")
+	progress.Step(fmt.Sprintf("Fetching release %s...", latest))
 	latestPath, err := c.cliVersioner.Download(context.Background(), latest)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
@@ -75,16 +109,111 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 			"Latest CLI version":  latest,
 		})
 		progress.Fail()
-		return fmt.Errorf("error downloading latest release: %w", err)
+		return fmt.Errorf("error downloading release %s: %w", latest, err)
 	}
 	defer os.RemoveAll(latestPath)
 
 	progress.Step("Replacing binary...")
-	execPath, err := os.Executable()
+	currentPath, err := c.executablePath()
+	if err != nil {
+		progress.Fail()
+		return err
+	}
+
+	if err := replaceBinary(latestPath, currentPath); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Executable (source)":      latestPath,
+			"Executable (destination)": currentPath,
+		})
+		progress.Fail()
+		return err
+	}
+
+	progress.Done()
+
+	text.Success(out, "Updated %s to %s.", currentPath, latest)
+	text.Info(out, "The previous binary was kept at %s. Run `fastly update --rollback` to restore it.", PreviousPath(currentPath))
+	return nil
+}
+
+// check returns the current version and the version to update to, which is
+// either the pinned version or the latest version of the release channel.
+func (c *RootCommand) check() (current, latest semver.Version, shouldUpdate bool, err error) {
+	if c.version == "" {
+		current, latest, shouldUpdate, err = CheckChannel(context.Background(), revision.AppVersion, c.channel, c.cliVersioner)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"App version": revision.AppVersion,
+				"Channel":     c.channel,
+			})
+			return current, latest, false, fmt.Errorf("error checking for latest version: %w", err)
+		}
+		return current, latest, shouldUpdate, nil
+	}
+
+	current, err = semver.Parse(strings.TrimPrefix(revision.AppVersion, "v"))
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"App version": revision.AppVersion,
+		})
+		return current, latest, false, fmt.Errorf("error reading current version: %w", err)
+	}
+	latest, err = semver.Parse(strings.TrimPrefix(c.version, "v"))
 	if err != nil {
+		return current, latest, false, errors.RemediationError{
+			Inner:       fmt.Errorf("invalid version '%s': %w", c.version, err),
+			Remediation: "Provide a semantic version, e.g. --version 4.3.0",
+		}
+	}
+	SetAsset(c.cliVersioner, latest)
+	return current, latest, !latest.Equals(current), nil
+}
+
+// rollbackBinary swaps the running binary with the one that the last update
+// replaced, so that running it again undoes the rollback.
+func (c *RootCommand) rollbackBinary(out io.Writer) error {
+	currentPath, err := c.executablePath()
+	if err != nil {
+		return err
+	}
+
+	previousPath := PreviousPath(currentPath)
+	if _, err := os.Stat(previousPath); err != nil {
+		if os.IsNotExist(err) {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("no previous binary found at %s", previousPath),
+				Remediation: "A previous binary is only kept once `fastly update` has replaced the CLI. Run `fastly update --version <version>` to install a specific version instead.",
+			}
+		}
 		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading previous binary: %w", err)
+	}
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	progress.Step("Restoring previous binary...")
+
+	if err := replaceBinary(previousPath, currentPath); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Executable (source)":      previousPath,
+			"Executable (destination)": currentPath,
+		})
 		progress.Fail()
-		return fmt.Errorf("error determining executable path: %w", err)
+		return err
+	}
+
+	progress.Done()
+
+	text.Success(out, "Rolled back %s to the previous binary.", currentPath)
+	text.Info(out, "Run `fastly update --rollback` again to undo the rollback.")
+	return nil
+}
+
+// executablePath returns the absolute path of the running binary.
+func (c *RootCommand) executablePath() (string, error) {
+	execPath, err := executable()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return "", fmt.Errorf("error determining executable path: %w", err)
 	}
 
 	currentPath, err := filepath.Abs(execPath)
@@ -92,9 +221,23 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Executable path": execPath,
 		})
-		progress.Fail()
-		return fmt.Errorf("error determining absolute target path: %w", err)
+		return "", fmt.Errorf("error determining absolute target path: %w", err)
+	}
+	return currentPath, nil
+}
+
+// replaceBinary moves the binary at src to dst, keeping the binary that it
+// replaces at PreviousPath(dst) so that it can be restored by a rollback.
+func replaceBinary(src, dst string) error {
+	previousPath := PreviousPath(dst)
+
+	// The current binary is copied aside first, so that a failure to replace it
+	// doesn't lose the binary previously kept (which may be src itself).
+	backupPath := previousPath + "~"
+	if err := copyBinary(dst, backupPath); err != nil {
+		return fmt.Errorf("error keeping the current binary: %w", err)
 	}
+	defer os.Remove(backupPath)
 
 	// Windows does not permit removing a running executable, however it will
 	// permit renaming it! So we first rename the running executable and then we
@@ -105,25 +248,31 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 	// Reference:
 	// https://github.com/golang/go/issues/21997#issuecomment-331744930
 	if fstruntime.Windows {
-		if err := os.Rename(execPath, execPath+"~"); err != nil {
-			os.Remove(execPath + "~")
+		if err := os.Rename(dst, dst+"~"); err != nil {
+			os.Remove(dst + "~")
 		}
 	}
 
-	if err := os.Rename(latestPath, currentPath); err != nil {
-		if err := filesystem.CopyFile(latestPath, currentPath); err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
-				"Executable (source)":      latestPath,
-				"Executable (destination)": currentPath,
-			})
-			progress.Fail()
-
-			return fmt.Errorf("error moving latest binary in place: %w", err)
+	if err := os.Rename(src, dst); err != nil {
+		if err := filesystem.CopyFile(src, dst); err != nil {
+			return fmt.Errorf("error moving binary in place: %w", err)
 		}
 	}
 
-	progress.Done()
-
-	text.Success(out, "Updated %s to %s.", currentPath, latest)
+	if err := os.Rename(backupPath, previousPath); err != nil {
+		return fmt.Errorf("error keeping the previous binary: %w", err)
+	}
 	return nil
 }
+
+// copyBinary copies the binary at src to dst, preserving its file mode.
+func copyBinary(src, dst string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := filesystem.CopyFile(src, dst); err != nil {
+		return err
+	}
+	return os.Chmod(dst, fi.Mode())
+}
@@ -0,0 +1,148 @@
+package update
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/revision"
+)
+
+func TestUpdate(t *testing.T) {
+	for _, testcase := range []struct {
+		name         string
+		command      RootCommand
+		noPrevious   bool
+		downloaded   string
+		wantError    string
+		wantOutput   string
+		wantCurrent  string
+		wantPrevious string
+	}{
+		{
+			name:         "pinned version",
+			command:      RootCommand{version: "v1.2.3"},
+			downloaded:   "new",
+			wantOutput:   "Pinned version: 1.2.3",
+			wantCurrent:  "new",
+			wantPrevious: "current",
+		},
+		{
+			name:         "pinned current version",
+			command:      RootCommand{version: strings.TrimPrefix(revision.AppVersion, "v")},
+			wantOutput:   "No update required.",
+			wantCurrent:  "current",
+			wantPrevious: "previous",
+		},
+		{
+			name:      "invalid pinned version",
+			command:   RootCommand{version: "latest"},
+			wantError: "invalid version 'latest'",
+		},
+		{
+			name:      "--channel with --version",
+			command:   RootCommand{channel: ChannelBeta, version: "1.2.3"},
+			wantError: "--channel and --version are mutually exclusive",
+		},
+		{
+			name:      "--rollback with --version",
+			command:   RootCommand{rollback: true, version: "1.2.3"},
+			wantError: "--rollback can't be used with --channel or --version",
+		},
+		{
+			name:         "rollback",
+			command:      RootCommand{rollback: true},
+			wantOutput:   "Rolled back",
+			wantCurrent:  "previous",
+			wantPrevious: "current",
+		},
+		{
+			name:       "rollback without previous binary",
+			command:    RootCommand{rollback: true},
+			noPrevious: true,
+			wantError:  "no previous binary found",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			current := filepath.Join(dir, "fastly")
+			writeFile(t, current, "current")
+			if !testcase.noPrevious {
+				writeFile(t, PreviousPath(current), "previous")
+			}
+
+			versioner := mock.Versioner{BinaryFilename: "fastly"}
+			if testcase.downloaded != "" {
+				versioner.DownloadOK = true
+				versioner.DownloadedFile = filepath.Join(dir, "download")
+				writeFile(t, versioner.DownloadedFile, testcase.downloaded)
+			}
+
+			defer func(orig func() (string, error)) { executable = orig }(executable)
+			executable = func() (string, error) { return current, nil }
+
+			var out bytes.Buffer
+			c := testcase.command
+			c.cliVersioner = versioner
+			c.configFilePath = filepath.Join(dir, "config.toml")
+			c.Globals = &config.Data{
+				ErrLog: fsterr.MockLog{},
+				HTTPClient: mock.HTMLClient(&http.Response{
+					Body:       io.NopCloser(strings.NewReader("")),
+					StatusCode: http.StatusOK,
+				}, nil),
+			}
+
+			err := c.Exec(nil, &out)
+			if testcase.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), testcase.wantError) {
+					t.Fatalf("want error %q, have %v", testcase.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !strings.Contains(out.String(), testcase.wantOutput) {
+				t.Errorf("want output containing %q, have %q", testcase.wantOutput, out.String())
+			}
+			if want, have := testcase.wantCurrent, readFile(t, current); want != have {
+				t.Errorf("current binary: want %q, have %q", want, have)
+			}
+			if want, have := testcase.wantPrevious, readFile(t, PreviousPath(current)); want != have {
+				t.Errorf("previous binary: want %q, have %q", want, have)
+			}
+
+			fi, err := os.Stat(current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fi.Mode().Perm()&0100 == 0 {
+				t.Errorf("want an executable binary, have mode %s", fi.Mode())
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
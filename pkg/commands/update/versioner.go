@@ -32,6 +32,7 @@ type Versioner interface {
 	BinaryName() string
 	Download(context.Context, semver.Version) (filename string, err error)
 	LatestVersion(context.Context) (semver.Version, error)
+	LatestPrerelease(context.Context) (semver.Version, error)
 	SetAsset(name string)
 }
 
@@ -107,6 +108,35 @@ func (g GitHub) LatestVersion(ctx context.Context) (semver.Version, error) {
 	return semver.Parse(strings.TrimPrefix(release.GetName(), "v"))
 }
 
+// LatestPrerelease calls the GitHub API to return the highest published
+// release as a semver, including pre-releases (which the latest release
+// excludes).
+func (g GitHub) LatestPrerelease(ctx context.Context) (semver.Version, error) {
+	var latest semver.Version
+	releases, _, err := g.client.ListReleases(ctx, g.org, g.repo, &github.ListOptions{
+		PerPage: 100,
+	})
+	if err != nil {
+		return latest, err
+	}
+	for _, release := range releases {
+		if release.GetDraft() {
+			continue
+		}
+		v, err := semver.Parse(strings.TrimPrefix(release.GetName(), "v"))
+		if err != nil {
+			continue
+		}
+		if v.GT(latest) {
+			latest = v
+		}
+	}
+	if latest.Equals(semver.Version{}) {
+		return latest, fmt.Errorf("no releases found")
+	}
+	return latest, nil
+}
+
 // Download implements the Versioner interface.
 //
 // Downloading, unarchiving and changing the file modes is done inside a temporary
@@ -130,3 +130,37 @@ func TestDownloadArchiveExtract(t *testing.T) {
 		})
 	}
 }
+
+type releasesClient struct {
+	mockClient
+	releases []*github.RepositoryRelease
+}
+
+func (c releasesClient) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return c.releases, &github.Response{}, nil
+}
+
+// TestLatestPrerelease validates that the highest published release is
+// returned, including pre-releases but not drafts.
+func TestLatestPrerelease(t *testing.T) {
+	release := func(name string, draft bool) *github.RepositoryRelease {
+		return &github.RepositoryRelease{Name: &name, Draft: &draft}
+	}
+	gh := GitHub{
+		client: releasesClient{releases: []*github.RepositoryRelease{
+			release("v1.3.0", true),
+			release("v1.2.0", false),
+			release("v1.3.0-beta.2", false),
+			release("not a version", false),
+			release("v1.3.0-beta.1", false),
+		}},
+	}
+
+	have, err := gh.LatestPrerelease(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := semver.MustParse("1.3.0-beta.2"); !want.Equals(have) {
+		t.Fatalf("want: %s, have: %s", want, have)
+	}
+}
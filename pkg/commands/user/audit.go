@@ -0,0 +1,186 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// LoginEvent is the event type recorded when a user logs in.
+const LoginEvent = "user.login"
+
+// NewAuditCommand returns a usable command registered under the parent.
+func NewAuditCommand(parent cmd.Registerer, globals *config.Data) *AuditCommand {
+	var c AuditCommand
+	c.CmdClause = parent.Command("audit", "Report the 2FA status, role, last login and API token count of a customer's users")
+	c.Globals = globals
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagCustomerIDName,
+		Description: cmd.FlagCustomerIDDesc,
+		Dst:         &c.customerID.Value,
+		Action:      c.customerID.Set,
+	})
+	c.CmdClause.Flag("fail-if-no-2fa", "Exit with an error if any user doesn't have two-factor authentication enabled").BoolVar(&c.failIfNo2FA)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// AuditCommand calls the Fastly API to report on the security posture of a
+// customer's users.
+type AuditCommand struct {
+	cmd.Base
+
+	customerID  cmd.OptionalCustomerID
+	failIfNo2FA bool
+	json        bool
+}
+
+// UserAudit describes the security posture of a single user.
+type UserAudit struct {
+	ID                   string     `json:"id"`
+	Login                string     `json:"login"`
+	Name                 string     `json:"name"`
+	Role                 string     `json:"role"`
+	Locked               bool       `json:"locked"`
+	TwoFactorAuthEnabled bool       `json:"two_factor_auth_enabled"`
+	LastLogin            *time.Time `json:"last_login"`
+	Tokens               int        `json:"tokens"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *AuditCommand) Exec(in io.Reader, out io.Writer) error {
+	_, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	customerID, err := cmd.CustomerID(&c.customerID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	users, err := c.Globals.APIClient.ListCustomerUsers(&fastly.ListCustomerUsersInput{CustomerID: customerID})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return err
+	}
+
+	tokens, err := c.Globals.APIClient.ListCustomerTokens(&fastly.ListCustomerTokensInput{CustomerID: customerID})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Customer ID": customerID,
+		})
+		return err
+	}
+	tokenCounts := make(map[string]int)
+	for _, t := range tokens {
+		tokenCounts[t.UserID]++
+	}
+
+	logins, err := c.lastLogins(customerID)
+	if err != nil {
+		return err
+	}
+
+	audits := make([]UserAudit, 0, len(users))
+	var no2FA []string
+	for _, u := range users {
+		audits = append(audits, UserAudit{
+			ID:                   u.ID,
+			Login:                u.Login,
+			Name:                 u.Name,
+			Role:                 u.Role,
+			Locked:               u.Locked,
+			TwoFactorAuthEnabled: u.TwoFactorAuthEnabled,
+			LastLogin:            logins[u.ID],
+			Tokens:               tokenCounts[u.ID],
+		})
+		if !u.TwoFactorAuthEnabled {
+			no2FA = append(no2FA, u.Login)
+		}
+	}
+	sort.Slice(audits, func(i, j int) bool {
+		return audits[i].Login < audits[j].Login
+	})
+	sort.Strings(no2FA)
+
+	if c.json {
+		data, err := json.Marshal(audits)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+	} else {
+		t := text.NewTable(out)
+		t.AddHeader("LOGIN", "ROLE", "2FA", "LOCKED", "LAST LOGIN", "TOKENS")
+		for _, a := range audits {
+			lastLogin := "unknown"
+			if a.LastLogin != nil {
+				lastLogin = c.Globals.Formatter().Time(*a.LastLogin)
+			}
+			t.AddLine(a.Login, a.Role, a.TwoFactorAuthEnabled, a.Locked, lastLogin, a.Tokens)
+		}
+		t.Print()
+		text.Break(out)
+		text.Output(out, "%d of %d users don't have two-factor authentication enabled", len(no2FA), len(audits))
+	}
+
+	if c.failIfNo2FA && len(no2FA) > 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("users without two-factor authentication: %s", strings.Join(no2FA, ", ")),
+			Remediation: "Ask these users to enable two-factor authentication, or require it for the account in the Fastly web interface.",
+		}
+	}
+	return nil
+}
+
+// lastLogins returns the time of each user's most recent login event, keyed
+// by user ID. Users with no recorded login are absent.
+func (c *AuditCommand) lastLogins(customerID string) (map[string]*time.Time, error) {
+	input := fastly.GetAPIEventsFilterInput{
+		CustomerID: customerID,
+		EventType:  LoginEvent,
+		MaxResults: 100,
+	}
+
+	logins := make(map[string]*time.Time)
+	for input.PageNumber = 1; ; input.PageNumber++ {
+		resp, err := c.Globals.APIClient.GetAPIEvents(&input)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Customer ID": customerID,
+				"Page":        input.PageNumber,
+			})
+			return nil, err
+		}
+		for _, e := range resp.Events {
+			if e.CreatedAt == nil {
+				continue
+			}
+			if last, ok := logins[e.UserID]; !ok || e.CreatedAt.After(*last) {
+				logins[e.UserID] = e.CreatedAt
+			}
+		}
+		if resp.Links.Next == "" || len(resp.Events) == 0 {
+			break
+		}
+	}
+	return logins, nil
+}
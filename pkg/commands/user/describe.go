@@ -101,12 +101,12 @@ func (c *DescribeCommand) print(out io.Writer, r *fastly.User) {
 	fmt.Fprintf(out, "Two Factor Setup Required: %t\n\n", r.TwoFactorSetupRequired)
 
 	if r.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", r.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*r.CreatedAt))
 	}
 	if r.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", r.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*r.UpdatedAt))
 	}
 	if r.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", r.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*r.DeletedAt))
 	}
 }
@@ -49,9 +49,6 @@ func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
 	if s == config.SourceUndefined {
 		return fsterr.ErrNoToken
 	}
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
 	if err := c.customerID.Parse(); err != nil {
 		return err
 	}
@@ -103,13 +100,13 @@ func (c *ListCommand) printVerbose(out io.Writer, us []*fastly.User) {
 		fmt.Fprintf(out, "Two Factor Setup Required: %t\n\n", u.TwoFactorSetupRequired)
 
 		if u.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", u.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*u.CreatedAt))
 		}
 		if u.UpdatedAt != nil {
-			fmt.Fprintf(out, "Updated at: %s\n", u.UpdatedAt)
+			fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*u.UpdatedAt))
 		}
 		if u.DeletedAt != nil {
-			fmt.Fprintf(out, "Deleted at: %s\n", u.DeletedAt)
+			fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*u.DeletedAt))
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/errors"
@@ -316,6 +317,64 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestAudit(t *testing.T) {
+	args := testutil.Args
+	auditAPI := mock.API{
+		GetCurrentUserFn:     getCurrentUser,
+		ListCustomerUsersFn:  listUsers,
+		ListCustomerTokensFn: listAuditTokens,
+		GetAPIEventsFn:       listLoginEvents,
+	}
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --token flag",
+			Args:      args("user audit"),
+			WantError: errors.ErrNoToken.Inner.Error(),
+		},
+		{
+			Name: "validate ListCustomerTokens API error",
+			API: mock.API{
+				GetCurrentUserFn:    getCurrentUser,
+				ListCustomerUsersFn: listUsers,
+				ListCustomerTokensFn: func(i *fastly.ListCustomerTokensInput) ([]*fastly.Token, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("user audit --token 123"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name:       "validate audit report",
+			API:        auditAPI,
+			Args:       args("user audit --customer-id abc --token 123"),
+			WantOutput: auditOutput(),
+		},
+		{
+			Name:       "validate audit report with --json",
+			API:        auditAPI,
+			Args:       args("user audit --json --token 123"),
+			WantOutput: `{"id":"current123","login":"bar@example.com","name":"bar","role":"superuser","locked":false,"two_factor_auth_enabled":false,"last_login":null,"tokens":1}`,
+		},
+		{
+			Name:      "validate --fail-if-no-2fa",
+			API:       auditAPI,
+			Args:      args("user audit --fail-if-no-2fa --token 123"),
+			WantError: "users without two-factor authentication: bar@example.com",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
 func getUser(i *fastly.GetUserInput) (*fastly.User, error) {
 	t := testutil.Date
 
@@ -368,6 +427,33 @@ func listUsers(i *fastly.ListCustomerUsersInput) ([]*fastly.User, error) {
 	return vs, nil
 }
 
+func listAuditTokens(i *fastly.ListCustomerTokensInput) ([]*fastly.Token, error) {
+	return []*fastly.Token{
+		{ID: "a", UserID: "123"},
+		{ID: "b", UserID: "123"},
+		{ID: "c", UserID: "current123"},
+	}, nil
+}
+
+func listLoginEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	earlier := testutil.Date.Add(-time.Hour)
+	return fastly.GetAPIEventsResponse{
+		Events: []*fastly.Event{
+			{UserID: "123", EventType: i.EventType, CreatedAt: &earlier},
+			{UserID: "123", EventType: i.EventType, CreatedAt: &testutil.Date},
+		},
+	}, nil
+}
+
+func auditOutput() string {
+	return `LOGIN            ROLE       2FA    LOCKED  LAST LOGIN                     TOKENS
+bar@example.com  superuser  false  false   unknown                        1
+foo@example.com  user       true   true    2021-06-15 23:00:00 +0000 UTC  2
+
+1 of 2 users don't have two-factor authentication enabled
+`
+}
+
 func describeUserOutput() string {
 	return `
 ID: 123
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/vcl"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -19,7 +20,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.manifest = data
 
 	// Required flags
-	c.CmdClause.Flag("content", "VCL passed as file path or content, e.g. $(< main.vcl)").Required().StringVar(&c.content)
+	c.CmdClause.Flag("content", "VCL passed as file path, content, or - for stdin, e.g. $(< main.vcl)").Required().StringVar(&c.content)
 	c.CmdClause.Flag("name", "The name of the VCL").Required().StringVar(&c.name)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagVersionName,
@@ -29,6 +30,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	})
 
 	// Optional flags
+	c.CmdClause.Flag("allow-secrets", vcl.AllowSecretsFlagDesc).BoolVar(&c.allowSecrets)
 	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
 		Action: c.autoClone.Set,
 		Dst:    &c.autoClone.Value,
@@ -54,6 +56,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 type CreateCommand struct {
 	cmd.Base
 
+	allowSecrets   bool
 	autoClone      cmd.OptionalAutoClone
 	content        string
 	main           cmd.OptionalBool
@@ -65,9 +68,21 @@ type CreateCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
+	// The content is read once, as it may be read from stdin.
+	content, err := cmd.Content(c.content, c.Globals.Stdin)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if err := vcl.CheckSecrets(content, c.allowSecrets, out); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -82,7 +97,7 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
-	input := c.constructInput(serviceID, serviceVersion.Number)
+	input := c.constructInput(serviceID, serviceVersion.Number, content)
 
 	v, err := c.Globals.APIClient.CreateVCL(input)
 	if err != nil {
@@ -98,10 +113,10 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 }
 
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
-func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fastly.CreateVCLInput {
+func (c *CreateCommand) constructInput(serviceID string, serviceVersion int, content string) *fastly.CreateVCLInput {
 	var input fastly.CreateVCLInput
 
-	input.Content = cmd.Content(c.content)
+	input.Content = content
 	input.Name = c.name
 	input.ServiceID = serviceID
 	input.ServiceVersion = serviceVersion
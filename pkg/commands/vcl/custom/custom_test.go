@@ -270,6 +270,20 @@ func TestVCLCustomDescribe(t *testing.T) {
 			Args:       args("vcl custom describe --name foobar --service-id 123 --version 1"),
 			WantOutput: "\nService ID: 123\nService Version: 1\n\nName: foobar\nMain: true\nContent: \n# some vcl content\n\nCreated at: 2021-06-15 23:00:00 +0000 UTC\nUpdated at: 2021-06-15 23:00:00 +0000 UTC\nDeleted at: 2021-06-15 23:00:00 +0000 UTC\n",
 		},
+		{
+			Name: "validate --highlight flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetVCLFn:       getVCL,
+			},
+			Args:       args("vcl custom describe --name foobar --service-id 123 --version 3 --highlight"),
+			WantOutput: "Content: \n1 | # some vcl content\n\nCreated at:",
+		},
+		{
+			Name:      "validate --raw flag is mutually exclusive with --json",
+			Args:      args("vcl custom describe --name foobar --service-id 123 --version 3 --raw --json"),
+			WantError: "the --raw flag is mutually exclusive with the --json and --highlight flags",
+		},
 	}
 
 	for _, testcase := range scenarios {
@@ -284,6 +298,19 @@ func TestVCLCustomDescribe(t *testing.T) {
 	}
 }
 
+func TestVCLCustomDescribeRaw(t *testing.T) {
+	var stdout bytes.Buffer
+	args := testutil.Args("vcl custom describe --name foobar --service-id 123 --version 3 --raw --verbose")
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		GetVCLFn:       getVCL,
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "# some vcl content", stdout.String())
+}
+
 func TestVCLCustomList(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -424,6 +451,11 @@ func TestVCLCustomUpdate(t *testing.T) {
 			Args:       args("vcl custom update --content updated --name foobar --service-id 123 --version 3"),
 			WantOutput: "Updated custom VCL 'foobar' (service: 123, version: 3)",
 		},
+		{
+			Name:      "validate --content with a secret",
+			Args:      args("vcl custom update --content ./testdata/secret.vcl --name foobar --service-id 123 --version 3"),
+			WantError: "the VCL appears to contain 1 secrets",
+		},
 		{
 			Name: "validate --autoclone results in cloned service version",
 			API: mock.API{
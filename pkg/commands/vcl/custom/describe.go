@@ -9,6 +9,7 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -29,12 +30,18 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	})
 
 	// Optional Flags
+	c.CmdClause.Flag("highlight", "Display the content with syntax highlighting and line numbers").BoolVar(&c.highlight)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
 		Dst:         &c.json,
 		Short:       'j',
 	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagRawName,
+		Description: cmd.FlagRawDesc,
+		Dst:         &c.raw,
+	})
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -55,22 +62,25 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 type DescribeCommand struct {
 	cmd.Base
 
+	highlight      bool
 	json           bool
 	manifest       manifest.Data
 	name           string
+	raw            bool
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 }
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
+	if c.raw && (c.json || c.highlight) {
+		return fmt.Errorf("error parsing arguments: the --raw flag is mutually exclusive with the --json and --highlight flags")
 	}
 
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -124,6 +134,10 @@ func (c *DescribeCommand) print(out io.Writer, v *fastly.VCL) error {
 		fmt.Fprint(out, string(data))
 		return nil
 	}
+	if c.raw {
+		fmt.Fprint(out, v.Content)
+		return nil
+	}
 
 	if !c.Globals.Verbose() {
 		fmt.Fprintf(out, "\nService ID: %s\n", v.ServiceID)
@@ -131,15 +145,23 @@ func (c *DescribeCommand) print(out io.Writer, v *fastly.VCL) error {
 	fmt.Fprintf(out, "Service Version: %d\n\n", v.ServiceVersion)
 	fmt.Fprintf(out, "Name: %s\n", v.Name)
 	fmt.Fprintf(out, "Main: %t\n", v.Main)
-	fmt.Fprintf(out, "Content: \n%s\n\n", v.Content)
+	fmt.Fprintf(out, "Content: \n%s\n", c.content(v.Content))
 	if v.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", v.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*v.CreatedAt))
 	}
 	if v.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", v.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*v.UpdatedAt))
 	}
 	if v.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", v.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*v.DeletedAt))
 	}
 	return nil
 }
+
+// content returns the VCL content for display, highlighted when requested.
+func (c *DescribeCommand) content(vcl string) string {
+	if c.highlight {
+		return text.HighlightVCL(vcl)
+	}
+	return vcl + "\n"
+}
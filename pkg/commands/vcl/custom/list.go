@@ -63,13 +63,10 @@ type ListCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
-	}
-
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -126,13 +123,13 @@ func (c *ListCommand) printVerbose(out io.Writer, serviceVersion int, vs []*fast
 		fmt.Fprintf(out, "Main: %t\n", v.Main)
 		fmt.Fprintf(out, "Content: \n%s\n\n", v.Content)
 		if v.CreatedAt != nil {
-			fmt.Fprintf(out, "Created at: %s\n", v.CreatedAt)
+			fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*v.CreatedAt))
 		}
 		if v.UpdatedAt != nil {
-			fmt.Fprintf(out, "Updated at: %s\n", v.UpdatedAt)
+			fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*v.UpdatedAt))
 		}
 		if v.DeletedAt != nil {
-			fmt.Fprintf(out, "Deleted at: %s\n", v.DeletedAt)
+			fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*v.DeletedAt))
 		}
 	}
 }
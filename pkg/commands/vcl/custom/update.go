@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/vcl"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -29,12 +30,14 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	})
 
 	// Optional flags
+	c.CmdClause.Flag("allow-secrets", vcl.AllowSecretsFlagDesc).BoolVar(&c.allowSecrets)
 	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
 		Action: c.autoClone.Set,
 		Dst:    &c.autoClone.Value,
 	})
 	c.CmdClause.Flag("new-name", "New name for the VCL").Action(c.newName.Set).StringVar(&c.newName.Value)
-	c.CmdClause.Flag("content", "VCL passed as file path or content, e.g. $(< main.vcl)").Action(c.content.Set).StringVar(&c.content.Value)
+	c.CmdClause.Flag("edit", cmd.EditFlagDesc).BoolVar(&c.edit)
+	c.CmdClause.Flag("content", "VCL passed as file path, content, or - for stdin, e.g. $(< main.vcl)").Action(c.content.Set).StringVar(&c.content.Value)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -55,8 +58,10 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 type UpdateCommand struct {
 	cmd.Base
 
+	allowSecrets   bool
 	autoClone      cmd.OptionalAutoClone
 	content        cmd.OptionalString
+	edit           bool
 	manifest       manifest.Data
 	name           string
 	newName        cmd.OptionalString
@@ -66,9 +71,29 @@ type UpdateCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := cmd.CheckFlagRules(cmd.ExclusiveFlags("edit", c.edit, "content", c.content.WasSet)); err != nil {
+		return err
+	}
+
+	// The content is read once, as it may be read from stdin.
+	var content string
+	if c.content.WasSet {
+		var err error
+		content, err = cmd.Content(c.content.Value, c.Globals.Stdin)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		if err := vcl.CheckSecrets(content, c.allowSecrets, out); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -83,7 +108,22 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
-	input, err := c.constructInput(serviceID, serviceVersion.Number)
+	if c.edit {
+		content, err = c.editContent(serviceID, serviceVersion.Number, in, out)
+		if err == cmd.ErrEditCancelled {
+			return nil
+		}
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+			})
+			return err
+		}
+		c.content.WasSet = true
+	}
+
+	input, err := c.constructInput(serviceID, serviceVersion.Number, content)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
@@ -109,8 +149,30 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	return nil
 }
 
+// editContent fetches the current content of the VCL and opens it in the
+// user's editor, returning the edited content.
+func (c *UpdateCommand) editContent(serviceID string, serviceVersion int, in io.Reader, out io.Writer) (string, error) {
+	v, err := c.Globals.APIClient.GetVCL(&fastly.GetVCLInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Name:           c.name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	content, err := cmd.Edit(v.Content, "custom-*.vcl", c.Globals, in, out)
+	if err != nil {
+		return "", err
+	}
+	if err := vcl.CheckSecrets(content, c.allowSecrets, out); err != nil {
+		return "", err
+	}
+	return content, nil
+}
+
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
-func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) (*fastly.UpdateVCLInput, error) {
+func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int, content string) (*fastly.UpdateVCLInput, error) {
 	var input fastly.UpdateVCLInput
 
 	input.Name = c.name
@@ -118,13 +180,13 @@ func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) (*f
 	input.ServiceVersion = serviceVersion
 
 	if !c.newName.WasSet && !c.content.WasSet {
-		return nil, fmt.Errorf("error parsing arguments: must provide either --new-name or --content to update the VCL")
+		return nil, fmt.Errorf("error parsing arguments: must provide either --new-name, --content or --edit to update the VCL")
 	}
 	if c.newName.WasSet {
 		input.NewName = fastly.String(c.newName.Value)
 	}
 	if c.content.WasSet {
-		input.Content = fastly.String(cmd.Content(c.content.Value))
+		input.Content = fastly.String(content)
 	}
 
 	return &input, nil
@@ -0,0 +1,96 @@
+package vcl
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// AllowSecretsFlagDesc is the description of the --allow-secrets flag of the
+// commands that upload VCL.
+const AllowSecretsFlagDesc = "Upload the VCL even if it appears to contain secrets such as API tokens or private keys"
+
+// Secret is a likely secret found in VCL.
+type Secret struct {
+	Kind string
+	Line int
+	// Redacted is the start of the match, so the secret can be found without
+	// it being displayed.
+	Redacted string
+}
+
+// secretPatterns match the secrets that are checked for. The secret group of a
+// pattern is the part of the match that's redacted, and a pattern without one
+// matches only text that's safe to display.
+//
+// NOTE: The patterns favour likely secrets over every possible secret, as a
+// false positive blocks an upload until --allow-secrets is given.
+var secretPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"private key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	{"AWS access key ID", regexp.MustCompile(`\b(?P<secret>(AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws.{0,20}(secret|key).{0,20}"(?P<secret>[0-9a-zA-Z/+]{40})"`)},
+	{"GitHub token", regexp.MustCompile(`\b(?P<secret>gh[pousr]_[A-Za-z0-9]{36,})\b`)},
+	{"Slack token", regexp.MustCompile(`\b(?P<secret>xox[abprs]-[A-Za-z0-9-]{10,})`)},
+	{"Google API key", regexp.MustCompile(`\b(?P<secret>AIza[0-9A-Za-z_-]{35})\b`)},
+	{"Stripe key", regexp.MustCompile(`\b(?P<secret>[rs]k_live_[0-9a-zA-Z]{24,})\b`)},
+	{"JSON web token", regexp.MustCompile(`\b(?P<secret>eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})`)},
+	{"credentials in an Authorization header", regexp.MustCompile(`(?i)"(Basic|Bearer) (?P<secret>[A-Za-z0-9+/=._-]{16,})"`)},
+	{"token in a header", regexp.MustCompile(`(?i)\.http\.[\w-]*(api-?key|fastly-key|secret|password|token)[\w-]*\s*=\s*"(?P<secret>[^"\s]{12,})"`)},
+}
+
+// ScanSecrets returns the likely secrets in the VCL.
+func ScanSecrets(content string) []Secret {
+	var secrets []Secret
+	for i, line := range strings.Split(content, "\n") {
+		for _, p := range secretPatterns {
+			if m := p.pattern.FindStringSubmatchIndex(line); m != nil {
+				secrets = append(secrets, Secret{Kind: p.kind, Line: i + 1, Redacted: redact(line, m, p.pattern.SubexpIndex("secret"))})
+				// A line is only reported once, as a secret can match more than
+				// one pattern.
+				break
+			}
+		}
+	}
+	return secrets
+}
+
+// CheckSecrets returns an error if the VCL appears to contain secrets, unless
+// they're allowed, after listing the secrets found.
+func CheckSecrets(content string, allow bool, out io.Writer) error {
+	secrets := ScanSecrets(content)
+	if len(secrets) == 0 {
+		return nil
+	}
+	for _, s := range secrets {
+		text.Warning(out, "Line %d: possible %s (%s)", s.Line, s.Kind, s.Redacted)
+	}
+	if allow {
+		return nil
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("the VCL appears to contain %d secrets", len(secrets)),
+		Remediation: "VCL is stored with every service version and can be read by anyone with access to the service. Store secrets in a write-only dictionary instead (see 'fastly dictionary create --write-only'), or use --allow-secrets if they aren't secrets.",
+	}
+}
+
+// redact returns the match m of a pattern in line, with all but the start of
+// the pattern's secret group masked.
+func redact(line string, m []int, secret int) string {
+	match := line[m[0]:m[1]]
+	if secret < 0 || m[2*secret] < 0 {
+		return match
+	}
+	start, end := m[2*secret]-m[0], m[2*secret+1]-m[0]
+	const visible = 4
+	if end-start > visible {
+		start += visible
+	}
+	return match[:start] + "****" + match[end:]
+}
@@ -0,0 +1,478 @@
+package snippet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// DefaultPriority is the priority Fastly assigns to a snippet when one isn't
+// specified.
+const DefaultPriority = 100
+
+// phases orders the snippet locations by when they execute within a request.
+// Locations that aren't inlined into a request subroutine (init, none) are
+// omitted.
+var phases = map[string]int{
+	"recv":    1,
+	"hash":    2,
+	"hit":     3,
+	"miss":    3,
+	"pass":    3,
+	"fetch":   4,
+	"error":   5,
+	"deliver": 6,
+	"log":     7,
+}
+
+var (
+	vclSub      = regexp.MustCompile(`\bsub\s+([A-Za-z0-9_]+)\s*\{`)
+	vclCall     = regexp.MustCompile(`\bcall\s+([A-Za-z0-9_]+)\s*;`)
+	vclTarget   = regexp.MustCompile(`(?i)\b(set|add|unset|remove|declare\s+local)\s+((?:req|bereq|beresp|resp|obj)\.http\.[A-Za-z0-9_-]+|var\.[A-Za-z0-9_]+)`)
+	vclVariable = regexp.MustCompile(`(?i)\b(?:(?:req|bereq|beresp|resp|obj)\.http\.[A-Za-z0-9_-]+|var\.[A-Za-z0-9_]+)`)
+	vclHeader   = regexp.MustCompile(`^\s*(?:#|//)\s*(type|priority)\s*:\s*(\S+)`)
+	vclNoise    = regexp.MustCompile(`(?s)/\*.*?\*/|\{".*?"\}|"[^"\n]*"|#[^\n]*|//[^\n]*`)
+)
+
+// AnalyzeCommand parses VCL snippets on disk and reports how they interact
+// once they're combined into the generated VCL.
+type AnalyzeCommand struct {
+	cmd.Base
+	dir  string
+	json bool
+}
+
+// NewAnalyzeCommand returns a usable command registered under the parent.
+func NewAnalyzeCommand(parent cmd.Registerer, globals *config.Data) *AnalyzeCommand {
+	var c AnalyzeCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("analyze", "Analyze local VCL snippets for subroutine, variable and ordering problems")
+	c.CmdClause.Flag("dir", "Directory containing .vcl snippet files").Default(".").StringVar(&c.dir)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *AnalyzeCommand) Exec(in io.Reader, out io.Writer) error {
+	snippets, err := ReadSnippets(c.dir)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Directory": c.dir,
+		})
+		return err
+	}
+	if len(snippets) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no .vcl files found in %s", c.dir),
+			Remediation: "Use the --dir flag to specify the directory containing your VCL snippets.",
+		}
+	}
+
+	a := Analyze(snippets)
+
+	if c.json {
+		data, err := json.Marshal(a)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	text.Output(out, "Analyzed %d snippets in %s", len(snippets), c.dir)
+	text.Break(out)
+
+	if len(a.Subroutines) > 0 {
+		tw := text.NewTable(out)
+		tw.AddHeader("SUBROUTINE", "DEFINED IN", "CALLED FROM")
+		for _, u := range a.Subroutines {
+			tw.AddLine(u.Name, list(u.DefinedIn), list(u.CalledFrom))
+		}
+		tw.Print()
+		text.Break(out)
+	}
+
+	if len(a.Variables) > 0 {
+		tw := text.NewTable(out)
+		tw.AddHeader("VARIABLE", "SET IN", "READ IN")
+		for _, u := range a.Variables {
+			tw.AddLine(u.Name, list(u.SetIn), list(u.ReadIn))
+		}
+		tw.Print()
+	}
+
+	if len(a.Issues) == 0 {
+		text.Success(out, "No issues found")
+		return nil
+	}
+	text.Warning(out, "Found %d potential issues:", len(a.Issues))
+	for _, i := range a.Issues {
+		fmt.Fprintf(out, "- %s\n", i)
+	}
+	return nil
+}
+
+func list(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// LocalSnippet is a VCL snippet read from disk.
+//
+// The snippet type and priority are read from `# type: recv` and
+// `# priority: 10` comments, falling back to a type prefix in the file name
+// (e.g. recv_normalize.vcl) and the default priority.
+type LocalSnippet struct {
+	Name     string
+	Type     string
+	Priority int
+	Content  string
+}
+
+// ReadSnippets reads the .vcl files in dir.
+func ReadSnippets(dir string) ([]LocalSnippet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.vcl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var snippets []LocalSnippet
+	for _, p := range paths {
+		data, err := os.ReadFile(filepath.Clean(p))
+		if err != nil {
+			return nil, fmt.Errorf("error reading snippet: %w", err)
+		}
+		s := LocalSnippet{
+			Name:     strings.TrimSuffix(filepath.Base(p), ".vcl"),
+			Type:     "none",
+			Priority: DefaultPriority,
+			Content:  string(data),
+		}
+		for _, loc := range Locations {
+			if strings.HasPrefix(s.Name, loc+"_") || strings.HasPrefix(s.Name, loc+"-") {
+				s.Type = loc
+			}
+		}
+		for _, line := range strings.Split(s.Content, "\n") {
+			m := vclHeader.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			switch m[1] {
+			case "type":
+				s.Type = m[2]
+			case "priority":
+				if n, err := strconv.Atoi(m[2]); err == nil {
+					s.Priority = n
+				}
+			}
+		}
+		snippets = append(snippets, s)
+	}
+	return snippets, nil
+}
+
+// Analysis describes how a set of snippets interact.
+type Analysis struct {
+	Subroutines []Usage  `json:"subroutines"`
+	Variables   []Usage  `json:"variables"`
+	Issues      []string `json:"issues"`
+}
+
+// Usage records which snippets define/set and call/read a subroutine or
+// variable.
+type Usage struct {
+	Name       string   `json:"name"`
+	DefinedIn  []string `json:"defined_in,omitempty"`
+	CalledFrom []string `json:"called_from,omitempty"`
+	SetIn      []string `json:"set_in,omitempty"`
+	ReadIn     []string `json:"read_in,omitempty"`
+}
+
+// parsed is the subroutine and variable usage found in a single snippet.
+type parsed struct {
+	LocalSnippet
+	defines  []string
+	calls    []string
+	sets     map[string]bool
+	declares map[string]bool
+	reads    map[string]bool
+}
+
+// Analyze reports the subroutines defined vs called, the variables set vs
+// read, and any hazards caused by the order the snippets execute in.
+func Analyze(snippets []LocalSnippet) Analysis {
+	var ps []parsed
+	for _, s := range snippets {
+		ps = append(ps, parse(s))
+	}
+
+	var a Analysis
+	subs := map[string]*Usage{}
+	vars := map[string]*Usage{}
+	usage := func(m map[string]*Usage, name string) *Usage {
+		key := strings.ToLower(name)
+		if m[key] == nil {
+			m[key] = &Usage{Name: name}
+		}
+		return m[key]
+	}
+
+	for _, p := range ps {
+		for _, d := range p.defines {
+			u := usage(subs, d)
+			u.DefinedIn = appendUnique(u.DefinedIn, p.Name)
+		}
+		for _, c := range p.calls {
+			u := usage(subs, c)
+			u.CalledFrom = appendUnique(u.CalledFrom, p.Name)
+		}
+		for v := range p.sets {
+			u := usage(vars, v)
+			u.SetIn = appendUnique(u.SetIn, p.Name)
+		}
+		for v := range p.reads {
+			u := usage(vars, v)
+			u.ReadIn = appendUnique(u.ReadIn, p.Name)
+		}
+	}
+
+	a.Subroutines = sortedUsages(subs)
+	a.Variables = sortedUsages(vars)
+
+	for _, u := range a.Subroutines {
+		switch {
+		case len(u.DefinedIn) == 0:
+			a.Issues = append(a.Issues, fmt.Sprintf("subroutine %s is called from %s but isn't defined in any snippet", u.Name, list(u.CalledFrom)))
+		case len(u.CalledFrom) == 0 && !strings.HasPrefix(u.Name, "vcl_"):
+			a.Issues = append(a.Issues, fmt.Sprintf("subroutine %s is defined in %s but never called", u.Name, list(u.DefinedIn)))
+		case len(u.DefinedIn) > 1:
+			a.Issues = append(a.Issues, fmt.Sprintf("subroutine %s is defined more than once (%s)", u.Name, list(u.DefinedIn)))
+		}
+	}
+	for _, u := range a.Variables {
+		if !isLocal(u.Name) {
+			continue
+		}
+		switch {
+		case len(u.SetIn) == 0:
+			a.Issues = append(a.Issues, fmt.Sprintf("%s is read in %s but never set", u.Name, list(u.ReadIn)))
+		case len(u.ReadIn) == 0:
+			a.Issues = append(a.Issues, fmt.Sprintf("%s is set in %s but never read", u.Name, list(u.SetIn)))
+		}
+	}
+
+	a.Issues = append(a.Issues, orderingHazards(ps)...)
+	return a
+}
+
+// orderingHazards reports variables read by a snippet before the snippet that
+// sets them has executed, local variables used without being declared in the
+// same subroutine, and snippets whose relative order is ambiguous because
+// they share a type and priority.
+func orderingHazards(ps []parsed) []string {
+	var exec []parsed
+	for _, p := range ps {
+		if _, ok := phases[p.Type]; ok {
+			exec = append(exec, p)
+		}
+	}
+	sort.SliceStable(exec, func(i, j int) bool {
+		if phases[exec[i].Type] != phases[exec[j].Type] {
+			return phases[exec[i].Type] < phases[exec[j].Type]
+		}
+		return exec[i].Priority < exec[j].Priority
+	})
+
+	var issues []string
+	for i, r := range exec {
+		for _, v := range keys(r.reads, r.sets) {
+			if isLocal(v) && !declaredIn(exec, r.Type, v) {
+				issues = append(issues, fmt.Sprintf("%s is used in %s (%s) but isn't declared in any %s snippet", v, r.Name, r.Type, r.Type))
+			}
+		}
+		for _, v := range keys(r.reads) {
+			if r.sets[v] || setBefore(exec[:i], r, v) {
+				continue
+			}
+			for _, s := range exec[i+1:] {
+				if !s.sets[v] || !shares(r, s, v) {
+					continue
+				}
+				if s.Type == r.Type && s.Priority == r.Priority {
+					continue // reported as an ambiguous order below
+				}
+				issues = append(issues, fmt.Sprintf("%s (%s, priority %d) reads %s before %s (%s, priority %d) sets it", r.Name, r.Type, r.Priority, v, s.Name, s.Type, s.Priority))
+			}
+		}
+	}
+
+	for i, a := range exec {
+		for _, b := range exec[i+1:] {
+			if a.Type != b.Type || a.Priority != b.Priority {
+				continue
+			}
+			if v := conflict(a, b); v != "" {
+				issues = append(issues, fmt.Sprintf("%s and %s (%s, priority %d) both use %s and one sets it, but their relative order isn't determined by priority", a.Name, b.Name, a.Type, a.Priority, v))
+			}
+		}
+	}
+	return issues
+}
+
+// shares reports whether v refers to the same variable in both snippets.
+// Local variables are scoped to a single subroutine, and only request headers
+// persist across the request phases.
+func shares(a, b parsed, v string) bool {
+	if a.Type == b.Type {
+		return true
+	}
+	return strings.HasPrefix(strings.ToLower(v), "req.http.")
+}
+
+func setBefore(earlier []parsed, r parsed, v string) bool {
+	for _, p := range earlier {
+		if p.sets[v] && shares(p, r, v) && (p.Type != r.Type || p.Priority != r.Priority) {
+			return true
+		}
+	}
+	return false
+}
+
+func declaredIn(ps []parsed, typ, v string) bool {
+	for _, p := range ps {
+		if p.Type == typ && p.declares[v] {
+			return true
+		}
+	}
+	return false
+}
+
+// conflict returns a variable one snippet sets and the other sets or reads.
+func conflict(a, b parsed) string {
+	var names []string
+	for v := range a.sets {
+		if b.sets[v] || b.reads[v] {
+			names = append(names, v)
+		}
+	}
+	for v := range b.sets {
+		if a.reads[v] {
+			names = append(names, v)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+func parse(s LocalSnippet) parsed {
+	p := parsed{
+		LocalSnippet: s,
+		sets:         map[string]bool{},
+		declares:     map[string]bool{},
+		reads:        map[string]bool{},
+	}
+
+	// Comments and string literals can't reference subroutines or variables.
+	src := vclNoise.ReplaceAllStringFunc(s.Content, func(m string) string {
+		return strings.Repeat(" ", len(m))
+	})
+
+	for _, m := range vclSub.FindAllStringSubmatch(src, -1) {
+		p.defines = appendUnique(p.defines, m[1])
+	}
+	for _, m := range vclCall.FindAllStringSubmatch(src, -1) {
+		p.calls = appendUnique(p.calls, m[1])
+	}
+
+	targets := map[int]bool{}
+	for _, m := range vclTarget.FindAllStringSubmatchIndex(src, -1) {
+		stmt := strings.ToLower(strings.Fields(src[m[2]:m[3]])[0])
+		name := canonical(src[m[4]:m[5]])
+		targets[m[4]] = true
+		switch stmt {
+		case "set", "add":
+			p.sets[name] = true
+		case "declare":
+			p.declares[name] = true
+		}
+	}
+	for _, m := range vclVariable.FindAllStringIndex(src, -1) {
+		if !targets[m[0]] {
+			p.reads[canonical(src[m[0]:m[1]])] = true
+		}
+	}
+	return p
+}
+
+// canonical normalises a variable name so header names, which are case
+// insensitive, compare equal.
+func canonical(name string) string {
+	if strings.Contains(strings.ToLower(name), ".http.") {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// keys returns the sorted union of the keys in the given sets.
+func keys(sets ...map[string]bool) []string {
+	var names []string
+	for _, set := range sets {
+		for k := range set {
+			names = appendUnique(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isLocal(name string) bool {
+	return strings.HasPrefix(name, "var.")
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
+
+func sortedUsages(m map[string]*Usage) []Usage {
+	us := make([]Usage, 0, len(m))
+	for _, u := range m {
+		sort.Strings(u.DefinedIn)
+		sort.Strings(u.CalledFrom)
+		sort.Strings(u.SetIn)
+		sort.Strings(u.ReadIn)
+		us = append(us, *u)
+	}
+	sort.Slice(us, func(i, j int) bool {
+		return us[i].Name < us[j].Name
+	})
+	return us
+}
@@ -0,0 +1,151 @@
+package snippet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewBlameCommand returns a usable command registered under the parent.
+func NewBlameCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *BlameCommand {
+	var c BlameCommand
+	c.CmdClause = parent.Command("blame", "Find the service version in which a VCL snippet last changed")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "The name of the VCL snippet").Required().StringVar(&c.name)
+
+	// Optional flags
+	c.CmdClause.Flag("concurrency", "Number of service versions to fetch at once").Default(strconv.Itoa(cmd.BlameConcurrency)).IntVar(&c.concurrency)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " to walk back from (defaults to 'latest')",
+		Dst:         &c.serviceVersion.Value,
+	})
+
+	return &c
+}
+
+// BlameCommand calls the Fastly API to find when a VCL snippet last changed.
+type BlameCommand struct {
+	cmd.Base
+
+	concurrency    int
+	json           bool
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *BlameCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	r, err := cmd.Blame(versions, serviceVersion.Number, c.fetch(serviceID), c.concurrency)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Name":            c.name,
+		})
+		return err
+	}
+
+	if c.json {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	cmd.DisplayBlame(out, "Snippet", c.name, r, c.Globals.Formatter())
+	if r.Fields["dynamic"] == "1" {
+		text.Info(out, "The content of a dynamic snippet isn't versioned, so only its type and priority were compared.")
+	}
+	return nil
+}
+
+// fetch returns the fields of the snippet in a service version. The content
+// of a dynamic snippet is updated outside of versions, so it's omitted.
+func (c *BlameCommand) fetch(serviceID string) cmd.BlameFetchFunc {
+	return func(version int) (map[string]string, bool, error) {
+		s, err := c.Globals.APIClient.GetSnippet(&fastly.GetSnippetInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			Name:           c.name,
+		})
+		var httpErr *fastly.HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		fields := map[string]string{
+			"dynamic":  strconv.Itoa(s.Dynamic),
+			"priority": strconv.Itoa(s.Priority),
+			"type":     string(s.Type),
+		}
+		if s.Dynamic == 0 {
+			fields["content"] = s.Content
+		}
+		return fields, true, nil
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -29,6 +30,7 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 
 	// Optional Flags
 	c.CmdClause.Flag("dynamic", "Whether the VCL snippet is dynamic or versioned").Action(c.dynamic.Set).BoolVar(&c.dynamic.Value)
+	c.CmdClause.Flag("highlight", "Display the content with syntax highlighting and line numbers").BoolVar(&c.highlight)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
@@ -36,6 +38,11 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 		Short:       'j',
 	})
 	c.CmdClause.Flag("name", "The name of the VCL snippet").StringVar(&c.name)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagRawName,
+		Description: cmd.FlagRawDesc,
+		Dst:         &c.raw,
+	})
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -48,7 +55,7 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("snippet-id", "Alphanumeric string identifying a VCL Snippet").StringVar(&c.snippetID)
+	c.CmdClause.Flag("snippet-id", "Alphanumeric string identifying a VCL Snippet"+cmd.IDOrNameDesc).StringVar(&c.snippetID)
 
 	return &c
 }
@@ -58,9 +65,11 @@ type DescribeCommand struct {
 	cmd.Base
 
 	dynamic        cmd.OptionalBool
+	highlight      bool
 	json           bool
 	manifest       manifest.Data
 	name           string
+	raw            bool
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	snippetID      string
@@ -68,13 +77,14 @@ type DescribeCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
-		return fsterr.ErrInvalidVerboseJSONCombo
+	if c.raw && (c.json || c.highlight) {
+		return fmt.Errorf("error parsing arguments: the --raw flag is mutually exclusive with the --json and --highlight flags")
 	}
 
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -98,7 +108,13 @@ func (c *DescribeCommand) Exec(in io.Reader, out io.Writer) error {
 			})
 			return err
 		}
-		v, err := c.Globals.APIClient.GetDynamicSnippet(input)
+		var v *fastly.DynamicSnippet
+		resolver := cmd.SnippetResolver(c.Globals.APIClient, serviceID, serviceVersion.Number)
+		err = cmd.ResolveOnNotFound(input.ID, resolver, func(id string) (err error) {
+			input.ID = id
+			v, err = c.Globals.APIClient.GetDynamicSnippet(input)
+			return err
+		})
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID":      serviceID,
@@ -176,15 +192,19 @@ func (c *DescribeCommand) printDynamic(out io.Writer, ds *fastly.DynamicSnippet)
 		fmt.Fprint(out, string(data))
 		return nil
 	}
+	if c.raw {
+		fmt.Fprint(out, ds.Content)
+		return nil
+	}
 
 	fmt.Fprintf(out, "\nService ID: %s\n", ds.ServiceID)
 	fmt.Fprintf(out, "ID: %s\n", ds.ID)
-	fmt.Fprintf(out, "Content: \n%s\n", ds.Content)
+	fmt.Fprintf(out, "Content: \n%s", c.content(ds.Content))
 	if ds.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", ds.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*ds.CreatedAt))
 	}
 	if ds.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", ds.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*ds.UpdatedAt))
 	}
 	return nil
 }
@@ -199,6 +219,10 @@ func (c *DescribeCommand) print(out io.Writer, s *fastly.Snippet) error {
 		fmt.Fprint(out, string(data))
 		return nil
 	}
+	if c.raw {
+		fmt.Fprint(out, s.Content)
+		return nil
+	}
 
 	if !c.Globals.Verbose() {
 		fmt.Fprintf(out, "\nService ID: %s\n", s.ServiceID)
@@ -210,15 +234,23 @@ func (c *DescribeCommand) print(out io.Writer, s *fastly.Snippet) error {
 	fmt.Fprintf(out, "Priority: %d\n", s.Priority)
 	fmt.Fprintf(out, "Dynamic: %t\n", cmd.IntToBool(s.Dynamic))
 	fmt.Fprintf(out, "Type: %s\n", s.Type)
-	fmt.Fprintf(out, "Content: \n%s\n", s.Content)
+	fmt.Fprintf(out, "Content: \n%s", c.content(s.Content))
 	if s.CreatedAt != nil {
-		fmt.Fprintf(out, "Created at: %s\n", s.CreatedAt)
+		fmt.Fprintf(out, "Created at: %s\n", c.Globals.Formatter().Time(*s.CreatedAt))
 	}
 	if s.UpdatedAt != nil {
-		fmt.Fprintf(out, "Updated at: %s\n", s.UpdatedAt)
+		fmt.Fprintf(out, "Updated at: %s\n", c.Globals.Formatter().Time(*s.UpdatedAt))
 	}
 	if s.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted at: %s\n", s.DeletedAt)
+		fmt.Fprintf(out, "Deleted at: %s\n", c.Globals.Formatter().Time(*s.DeletedAt))
 	}
 	return nil
 }
+
+// content returns the VCL content for display, highlighted when requested.
+func (c *DescribeCommand) content(vcl string) string {
+	if c.highlight {
+		return text.HighlightVCL(vcl)
+	}
+	return vcl + "\n"
+}
@@ -2,9 +2,14 @@ package snippet_test
 
 import (
 	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/vcl/snippet"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -180,6 +185,52 @@ func TestVCLSnippetCreate(t *testing.T) {
 	}
 }
 
+func TestVCLSnippetCreateSecrets(t *testing.T) {
+	var content string
+	api := mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CreateSnippetFn: func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+			content = i.Content
+			return &fastly.Snippet{
+				Name:           i.Name,
+				ServiceID:      i.ServiceID,
+				ServiceVersion: i.ServiceVersion,
+				Type:           i.Type,
+			}, nil
+		},
+	}
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate secrets are refused",
+			Args:      args("vcl snippet create --content ./testdata/secret.vcl --name foo --type recv --service-id 123 --version 3"),
+			API:       api,
+			WantError: "the VCL appears to contain 1 secrets",
+		},
+		{
+			Name:       "validate --allow-secrets",
+			Args:       args("vcl snippet create --content ./testdata/secret.vcl --name foo --type recv --service-id 123 --version 3 --allow-secrets"),
+			API:        api,
+			WantOutput: "Created VCL snippet 'foo'",
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			content = ""
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			testutil.AssertStringContains(t, stdout.String(), `Line 1: possible token in a header (.http.Fastly-Key = "0123****")`)
+			if testcase.WantError == "" {
+				testutil.AssertStringContains(t, content, "Fastly-Key")
+			}
+		})
+	}
+}
 func TestVCLSnippetDelete(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -254,6 +305,64 @@ func TestVCLSnippetDelete(t *testing.T) {
 	}
 }
 
+func TestVCLSnippetBlame(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("vcl snippet blame --service-id 123"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate snippet missing from the starting version",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSnippetFn:   getSnippetFromVersion(4),
+			},
+			Args:      args("vcl snippet blame --name foobar --service-id 123"),
+			WantError: "resource not found in service version 3",
+		},
+		{
+			Name: "validate snippet created in a later version",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSnippetFn:   getSnippetFromVersion(2),
+			},
+			Args:       args("vcl snippet blame --name foobar --service-id 123"),
+			WantOutput: "Snippet 'foobar' was created in version 2 (2000-01-02 01:00",
+		},
+		{
+			Name: "validate snippet unchanged since the first version",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSnippetFn:   getSnippet,
+			},
+			Args:       args("vcl snippet blame --name foobar --service-id 123 --concurrency 1"),
+			WantOutput: "Snippet 'foobar' is unchanged since version 1",
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSnippetFn:   getSnippetFromVersion(3),
+			},
+			Args:       args("vcl snippet blame --name foobar --service-id 123 --json"),
+			WantOutput: `"version":3,`,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
 func TestVCLSnippetDescribe(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -541,6 +650,86 @@ func TestVCLSnippetUpdate(t *testing.T) {
 	}
 }
 
+func TestVCLSnippetUpdateEdit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script requires a POSIX shell")
+	}
+	editor := filepath.Join(t.TempDir(), "editor")
+	if err := os.WriteFile(editor, []byte("#!/bin/sh\necho '# edited vcl content' > \"$1\"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+
+	var content string
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate --edit and --content are mutually exclusive",
+			Args:      args("vcl snippet update --content inline_vcl --edit --name foo --service-id 123 --version 3"),
+			WantError: "error parsing arguments: the --edit flag is mutually exclusive with the --content flag",
+		},
+		{
+			Name: "validate versioned snippet is edited",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSnippetFn:   getSnippet,
+				UpdateSnippetFn: func(i *fastly.UpdateSnippetInput) (*fastly.Snippet, error) {
+					content = *i.Content
+					return &fastly.Snippet{
+						Name:           i.Name,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+						Type:           "recv",
+					}, nil
+				},
+			},
+			Args: args("vcl snippet update --auto-yes --edit --name foo --service-id 123 --version 3"),
+			WantOutputs: []string{
+				"-# some vcl content",
+				"+# edited vcl content",
+				"Updated VCL snippet 'foo'",
+			},
+		},
+		{
+			Name: "validate dynamic snippet is edited",
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetDynamicSnippetFn: getDynamicSnippet,
+				UpdateDynamicSnippetFn: func(i *fastly.UpdateDynamicSnippetInput) (*fastly.DynamicSnippet, error) {
+					content = *i.Content
+					return &fastly.DynamicSnippet{
+						ID:        i.ID,
+						ServiceID: i.ServiceID,
+					}, nil
+				},
+			},
+			Args: args("vcl snippet update --auto-yes --dynamic --edit --service-id 123 --snippet-id 456 --version 3"),
+			WantOutputs: []string{
+				"+# edited vcl content",
+				"Updated dynamic VCL snippet '456' (service: 123)",
+			},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		content = ""
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			for _, s := range testcase.WantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			if testcase.WantError == "" {
+				testutil.AssertString(t, "# edited vcl content", content)
+			}
+		})
+	}
+}
+
 func getSnippet(i *fastly.GetSnippetInput) (*fastly.Snippet, error) {
 	t := testutil.Date
 
@@ -560,6 +749,17 @@ func getSnippet(i *fastly.GetSnippetInput) (*fastly.Snippet, error) {
 	}, nil
 }
 
+// getSnippetFromVersion returns a function which only finds the snippet in the
+// given service version onwards.
+func getSnippetFromVersion(version int) func(i *fastly.GetSnippetInput) (*fastly.Snippet, error) {
+	return func(i *fastly.GetSnippetInput) (*fastly.Snippet, error) {
+		if i.ServiceVersion < version {
+			return nil, &fastly.HTTPError{StatusCode: http.StatusNotFound}
+		}
+		return getSnippet(i)
+	}
+}
+
 func getDynamicSnippet(i *fastly.GetDynamicSnippetInput) (*fastly.DynamicSnippet, error) {
 	t := testutil.Date
 
@@ -607,3 +807,129 @@ func listSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
 	}
 	return vs, nil
 }
+
+func TestVCLSnippetAnalyze(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate directory without snippets",
+			Args:      args("vcl snippet analyze --dir testdata/missing"),
+			WantError: "no .vcl files found in testdata/missing",
+		},
+		{
+			Name:       "validate subroutine and variable usage",
+			Args:       args("vcl snippet analyze --dir testdata/analyze"),
+			WantOutput: "missing_sub    -           recv_route\nnormalize      helpers     recv_geo\nunused_helper  helpers     -\n",
+		},
+		{
+			Name:       "validate issues are reported",
+			Args:       args("vcl snippet analyze --dir testdata/analyze"),
+			WantOutput: analyzeIssuesOutput,
+		},
+		{
+			Name:       "validate JSON output",
+			Args:       args("vcl snippet analyze --dir testdata/analyze --json"),
+			WantOutput: `{"name":"req.http.x-geo","set_in":["recv_geo"],"read_in":["deliver_debug","recv_route"]}`,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestAnalyzeAmbiguousOrder(t *testing.T) {
+	a := snippet.Analyze([]snippet.LocalSnippet{
+		{Name: "a", Type: "recv", Priority: 100, Content: `set req.http.X-Flag = "1";`},
+		{Name: "b", Type: "recv", Priority: 100, Content: `if (req.http.X-Flag) { set var.x = "1"; }`},
+		{Name: "c", Type: "fetch", Priority: 10, Content: `set beresp.http.X-Flag = req.http.X-Flag;`},
+	})
+	testutil.AssertEqual(t, []string{
+		"var.x is set in b but never read",
+		"var.x is used in b (recv) but isn't declared in any recv snippet",
+		"a and b (recv, priority 100) both use req.http.x-flag and one sets it, but their relative order isn't determined by priority",
+	}, a.Issues)
+}
+
+var analyzeIssuesOutput = `
+WARNING: Found 4 potential issues:
+- subroutine missing_sub is called from recv_route but isn't defined in any snippet
+- subroutine unused_helper is defined in helpers but never called
+- var.unused is set in recv_geo but never read
+- recv_route (recv, priority 10) reads req.http.x-geo before recv_geo (recv, priority 50) sets it
+`
+
+func TestVCLSnippetUsages(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn:    testutil.ListVersions,
+		ListSnippetsFn:    listUsageSnippets,
+		ListVCLsFn:        listUsageVCLs,
+		ListConditionsFn:  func(*fastly.ListConditionsInput) ([]*fastly.Condition, error) { return nil, nil },
+		GetGeneratedVCLFn: getUsageGeneratedVCL,
+	}
+	testutil.RunScenarios(t, []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("vcl snippet usages --service-id 123"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name:      "validate unknown snippet",
+			API:       api,
+			Args:      args("vcl snippet usages --service-id 123 --name missing"),
+			WantError: "snippet 'missing' not found in service 123 version 1",
+		},
+		{
+			Name: "validate include and call references",
+			API:  api,
+			Args: args("vcl snippet usages --service-id 123 --name helpers"),
+			WantOutputs: []string{
+				"vcl   main  3     include \"snippet::helpers\";",
+				"vcl   main  4     call normalize_path;",
+			},
+		},
+		{
+			Name:       "validate snippet inserted by macro",
+			API:        api,
+			Args:       args("vcl snippet usages --service-id 123 --name geo"),
+			WantOutput: "Snippet 'geo' is inserted into vcl_recv by the #FASTLY recv macro.",
+		},
+		{
+			Name:       "validate unused snippet",
+			API:        api,
+			Args:       args("vcl snippet usages --service-id 123 --name unused"),
+			WantOutput: "No references to snippet 'unused' found in service 123 version 1",
+		},
+		{
+			Name:       "validate JSON output",
+			API:        api,
+			Args:       args("vcl snippet usages --service-id 123 --name helpers --json"),
+			WantOutput: `{"snippet":"helpers","type":"init","subroutines":["normalize_path"],"macro":false,"references":[{"kind":"vcl","name":"main","line":3,"text":"include \"snippet::helpers\";"}`,
+		},
+	})
+}
+
+func listUsageSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	return []*fastly.Snippet{
+		{Name: "geo", Type: fastly.SnippetTypeRecv, Content: "set req.http.X-Region = client.geo.country_code;"},
+		{Name: "helpers", Type: fastly.SnippetTypeInit, Content: "sub normalize_path {\n  set req.url = std.tolower(req.url);\n}"},
+		{Name: "unused", Type: fastly.SnippetTypeNone, Content: "set req.http.X-Unused = \"1\";"},
+	}, nil
+}
+
+func listUsageVCLs(i *fastly.ListVCLsInput) ([]*fastly.VCL, error) {
+	return []*fastly.VCL{
+		{Name: "main", Main: true, Content: "sub vcl_recv {\n#FASTLY recv\n  include \"snippet::helpers\";\n  call normalize_path;\n}"},
+	}, nil
+}
+
+func getUsageGeneratedVCL(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+	return &fastly.VCL{Content: "sub normalize_path {\n  set req.url = std.tolower(req.url);\n}\nsub vcl_recv {\n  set req.http.X-Region = client.geo.country_code;\n  call normalize_path;\n}"}, nil
+}
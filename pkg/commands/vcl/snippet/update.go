@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/vcl"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -28,12 +29,14 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	})
 
 	// Optional flags
+	c.CmdClause.Flag("allow-secrets", vcl.AllowSecretsFlagDesc).BoolVar(&c.allowSecrets)
 	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
 		Action: c.autoClone.Set,
 		Dst:    &c.autoClone.Value,
 	})
-	c.CmdClause.Flag("content", "VCL snippet passed as file path or content, e.g. $(< snippet.vcl)").Action(c.content.Set).StringVar(&c.content.Value)
+	c.CmdClause.Flag("content", "VCL snippet passed as file path, content, or - for stdin, e.g. $(< snippet.vcl)").Action(c.content.Set).StringVar(&c.content.Value)
 	c.CmdClause.Flag("dynamic", "Whether the VCL snippet is dynamic or versioned").Action(c.dynamic.Set).BoolVar(&c.dynamic.Value)
+	c.CmdClause.Flag("edit", cmd.EditFlagDesc).BoolVar(&c.edit)
 	c.CmdClause.Flag("name", "The name of the VCL snippet to update").StringVar(&c.name)
 	c.CmdClause.Flag("new-name", "New name for the VCL snippet").Action(c.newName.Set).StringVar(&c.newName.Value)
 	c.CmdClause.Flag("priority", "Priority determines execution order. Lower numbers execute first").Short('p').Action(c.priority.Set).IntVar(&c.priority.Value)
@@ -49,7 +52,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
-	c.CmdClause.Flag("snippet-id", "Alphanumeric string identifying a VCL Snippet").StringVar(&c.snippetID)
+	c.CmdClause.Flag("snippet-id", "Alphanumeric string identifying a VCL Snippet"+cmd.IDOrNameDesc).StringVar(&c.snippetID)
 
 	// NOTE: Locations is defined in the same snippet package inside create.go
 	c.CmdClause.Flag("type", "The location in generated VCL where the snippet should be placed").HintOptions(Locations...).Action(c.location.Set).EnumVar(&c.location.Value, Locations...)
@@ -61,9 +64,11 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 type UpdateCommand struct {
 	cmd.Base
 
+	allowSecrets   bool
 	autoClone      cmd.OptionalAutoClone
 	content        cmd.OptionalString
 	dynamic        cmd.OptionalBool
+	edit           bool
 	location       cmd.OptionalString
 	manifest       manifest.Data
 	name           string
@@ -76,9 +81,29 @@ type UpdateCommand struct {
 
 // Exec invokes the application logic for the command.
 func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := cmd.CheckFlagRules(cmd.ExclusiveFlags("edit", c.edit, "content", c.content.WasSet)); err != nil {
+		return err
+	}
+
+	// The content is read once, as it may be read from stdin.
+	var content string
+	if c.content.WasSet {
+		var err error
+		content, err = cmd.Content(c.content.Value, c.Globals.Stdin)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		if err := vcl.CheckSecrets(content, c.allowSecrets, out); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.autoClone,
 		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
 		Manifest:           c.manifest,
 		Out:                out,
 		ServiceNameFlag:    c.serviceName,
@@ -93,8 +118,23 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	if c.edit {
+		content, err = c.editContent(serviceID, serviceVersion.Number, in, out)
+		if err == cmd.ErrEditCancelled {
+			return nil
+		}
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+			})
+			return err
+		}
+		c.content.WasSet = true
+	}
+
 	if c.dynamic.WasSet {
-		input, err := c.constructDynamicInput(serviceID, serviceVersion.Number)
+		input, err := c.constructDynamicInput(serviceID, serviceVersion.Number, content)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID":      serviceID,
@@ -102,7 +142,13 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 			})
 			return err
 		}
-		v, err := c.Globals.APIClient.UpdateDynamicSnippet(input)
+		var v *fastly.DynamicSnippet
+		resolver := cmd.SnippetResolver(c.Globals.APIClient, serviceID, serviceVersion.Number)
+		err = cmd.ResolveOnNotFound(input.ID, resolver, func(id string) (err error) {
+			input.ID = id
+			v, err = c.Globals.APIClient.UpdateDynamicSnippet(input)
+			return err
+		})
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 				"Service ID":      serviceID,
@@ -114,7 +160,7 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 		return nil
 	}
 
-	input, err := c.constructInput(serviceID, serviceVersion.Number)
+	input, err := c.constructInput(serviceID, serviceVersion.Number, content)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
 			"Service ID":      serviceID,
@@ -134,8 +180,57 @@ func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
 	return nil
 }
 
+// editContent fetches the current content of the VCL snippet and opens it in
+// the user's editor, returning the edited content.
+func (c *UpdateCommand) editContent(serviceID string, serviceVersion int, in io.Reader, out io.Writer) (string, error) {
+	var current string
+	if c.dynamic.WasSet {
+		if c.snippetID == "" {
+			return "", fmt.Errorf("error parsing arguments: must provide --snippet-id to update a dynamic VCL snippet")
+		}
+		var s *fastly.DynamicSnippet
+		resolver := cmd.SnippetResolver(c.Globals.APIClient, serviceID, serviceVersion)
+		err := cmd.ResolveOnNotFound(c.snippetID, resolver, func(id string) (err error) {
+			s, err = c.Globals.APIClient.GetDynamicSnippet(&fastly.GetDynamicSnippetInput{
+				ServiceID: serviceID,
+				ID:        id,
+			})
+			if err == nil {
+				c.snippetID = id
+			}
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		current = s.Content
+	} else {
+		if c.name == "" {
+			return "", fmt.Errorf("error parsing arguments: must provide --name to update a versioned VCL snippet")
+		}
+		s, err := c.Globals.APIClient.GetSnippet(&fastly.GetSnippetInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           c.name,
+		})
+		if err != nil {
+			return "", err
+		}
+		current = s.Content
+	}
+
+	content, err := cmd.Edit(current, "snippet-*.vcl", c.Globals, in, out)
+	if err != nil {
+		return "", err
+	}
+	if err := vcl.CheckSecrets(content, c.allowSecrets, out); err != nil {
+		return "", err
+	}
+	return content, nil
+}
+
 // constructDynamicInput transforms values parsed from CLI flags into an object to be used by the API client library.
-func (c *UpdateCommand) constructDynamicInput(serviceID string, serviceVersion int) (*fastly.UpdateDynamicSnippetInput, error) {
+func (c *UpdateCommand) constructDynamicInput(serviceID string, serviceVersion int, content string) (*fastly.UpdateDynamicSnippetInput, error) {
 	var input fastly.UpdateDynamicSnippetInput
 
 	input.ID = c.snippetID
@@ -149,14 +244,14 @@ func (c *UpdateCommand) constructDynamicInput(serviceID string, serviceVersion i
 		return nil, fmt.Errorf("error parsing arguments: must provide --snippet-id to update a dynamic VCL snippet")
 	}
 	if c.content.WasSet {
-		input.Content = fastly.String(cmd.Content(c.content.Value))
+		input.Content = fastly.String(content)
 	}
 
 	return &input, nil
 }
 
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
-func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) (*fastly.UpdateSnippetInput, error) {
+func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int, content string) (*fastly.UpdateSnippetInput, error) {
 	var input fastly.UpdateSnippetInput
 
 	input.Name = c.name
@@ -176,7 +271,7 @@ func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) (*f
 		input.Priority = fastly.Int(c.priority.Value)
 	}
 	if c.content.WasSet {
-		input.Content = fastly.String(cmd.Content(c.content.Value))
+		input.Content = fastly.String(content)
 	}
 	if c.location.WasSet {
 		location := fastly.SnippetType(c.location.Value)
@@ -0,0 +1,163 @@
+package snippet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/vclusage"
+)
+
+// UsagesCommand reports where a VCL snippet is used, so that it can be safely
+// deleted or renamed.
+type UsagesCommand struct {
+	cmd.Base
+	json           bool
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewUsagesCommand returns a usable command registered under the parent.
+func NewUsagesCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *UsagesCommand {
+	var c UsagesCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("usages", "Report where a VCL snippet is included, or its subroutines called, in a service version's VCL")
+	c.CmdClause.Flag("name", "The name of the VCL snippet").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to 'active')",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// Usages describes where a snippet is used.
+type Usages struct {
+	Snippet     string               `json:"snippet"`
+	Type        string               `json:"type"`
+	Subroutines []string             `json:"subroutines,omitempty"`
+	Macro       bool                 `json:"macro"`
+	References  []vclusage.Reference `json:"references"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *UsagesCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.serviceVersion.Value == "" {
+		c.serviceVersion.Value = "active"
+	}
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Globals:            c.Globals,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	sources, err := vclusage.Sources(c.Globals.APIClient, serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	u, err := FindUsages(sources, c.name)
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("%w in service %s version %d", err, serviceID, serviceVersion.Number),
+			Remediation: "Use `fastly vcl snippet list` to list the snippets in the service version.",
+		}
+	}
+
+	if c.json {
+		data, err := json.Marshal(u)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if u.Macro {
+		text.Info(out, "Snippet '%s' is inserted into vcl_%s by the #FASTLY %s macro.", u.Snippet, u.Type, u.Type)
+	}
+	if len(u.References) == 0 {
+		if !u.Macro {
+			text.Success(out, "No references to snippet '%s' found in service %s version %d", u.Snippet, serviceID, serviceVersion.Number)
+		}
+		return nil
+	}
+	vclusage.PrintReferences(out, u.References)
+	return nil
+}
+
+// FindUsages reports where the named snippet is included, where the
+// subroutines it defines are called, and whether a #FASTLY macro inserts it.
+func FindUsages(sources []vclusage.Source, name string) (Usages, error) {
+	var snippet *vclusage.Source
+	for i, s := range sources {
+		if s.Kind == vclusage.KindSnippet && s.Name == name {
+			snippet = &sources[i]
+		}
+	}
+	if snippet == nil {
+		return Usages{}, fmt.Errorf("snippet '%s' not found", name)
+	}
+
+	u := Usages{
+		Snippet:     name,
+		Type:        snippet.Type,
+		Subroutines: vclusage.Subroutines(snippet.Content),
+		Macro:       vclusage.MacroInserted(sources, snippet.Type),
+	}
+	patterns := []*regexp.Regexp{vclusage.IncludePattern(name)}
+	for _, sub := range u.Subroutines {
+		patterns = append(patterns, vclusage.CallPattern(sub))
+	}
+	u.References = vclusage.Find(sources, func(s vclusage.Source) bool {
+		return s.Kind == vclusage.KindSnippet && s.Name == name
+	}, patterns...)
+	if u.References == nil {
+		u.References = []vclusage.Reference{}
+	}
+	return u, nil
+}
@@ -0,0 +1,3 @@
+// Package waf contains commands for managing legacy Web Application
+// Firewalls (WAF), their rules, rule exclusions and firewall versions.
+package waf
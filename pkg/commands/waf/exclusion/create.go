@@ -0,0 +1,79 @@
+package exclusion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, globals *config.Data) *CreateCommand {
+	var c CreateCommand
+	c.CmdClause = parent.Command("create", "Create a rule exclusion on a legacy WAF firewall version").Alias("add")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+	c.CmdClause.Flag("name", "Name of the rule exclusion").Required().StringVar(&c.name)
+	c.CmdClause.Flag("condition", "VCL condition that requests must match for the exclusion to apply").Required().StringVar(&c.condition)
+
+	// Optional flags
+	c.CmdClause.Flag("exclusion-type", "Whether to exclude specific rules or skip the firewall entirely").Default(fastly.WAFRuleExclusionTypeRule).HintOptions(Types...).EnumVar(&c.exclusionType, Types...)
+	c.CmdClause.Flag("modsec-id", "ModSecurity ID of a rule to exclude (repeat to exclude several rules)").IntsVar(&c.modSecIDs)
+
+	return &c
+}
+
+// CreateCommand calls the Fastly API to create an appropriate resource.
+type CreateCommand struct {
+	cmd.Base
+
+	condition       string
+	exclusionType   string
+	firewallID      string
+	firewallVersion string
+	modSecIDs       []int
+	name            string
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := validate(c.exclusionType, c.modSecIDs); err != nil {
+		return err
+	}
+
+	v, err := waf.EditableFirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	e, err := c.Globals.APIClient.CreateWAFRuleExclusion(&fastly.CreateWAFRuleExclusionInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		WAFRuleExclusion: &fastly.WAFRuleExclusion{
+			Name:          fastly.String(c.name),
+			ExclusionType: fastly.String(c.exclusionType),
+			Condition:     fastly.String(c.condition),
+			Rules:         rules(c.modSecIDs),
+		},
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created rule exclusion %d '%s' (firewall: %s, version: %d)", intValue(e.Number), c.name, c.firewallID, v.Number)
+	return nil
+}
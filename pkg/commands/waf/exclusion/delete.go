@@ -0,0 +1,63 @@
+package exclusion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDeleteCommand returns a usable command registered under the parent.
+func NewDeleteCommand(parent cmd.Registerer, globals *config.Data) *DeleteCommand {
+	var c DeleteCommand
+	c.CmdClause = parent.Command("delete", "Delete a rule exclusion from a legacy WAF firewall version").Alias("remove")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+	c.CmdClause.Flag("number", "Number of the rule exclusion to delete").Required().IntVar(&c.number)
+
+	return &c
+}
+
+// DeleteCommand calls the Fastly API to delete an appropriate resource.
+type DeleteCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+	number          int
+}
+
+// Exec invokes the application logic for the command.
+func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.EditableFirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	err = c.Globals.APIClient.DeleteWAFRuleExclusion(&fastly.DeleteWAFRuleExclusionInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		Number:           c.number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+			"Number":           c.number,
+		})
+		return err
+	}
+
+	text.Success(out, "Deleted rule exclusion %d (firewall: %s, version: %d)", c.number, c.firewallID, v.Number)
+	return nil
+}
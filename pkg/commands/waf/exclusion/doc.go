@@ -0,0 +1,3 @@
+// Package exclusion contains commands for managing the rule exclusions of a
+// legacy WAF firewall version.
+package exclusion
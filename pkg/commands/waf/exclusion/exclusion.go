@@ -0,0 +1,86 @@
+package exclusion
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Types are the supported exclusion types. A 'rule' exclusion stops the
+// listed rules from running when the condition matches, while a 'waf'
+// exclusion skips the firewall entirely.
+var Types = []string{fastly.WAFRuleExclusionTypeRule, fastly.WAFRuleExclusionTypeWAF}
+
+// rules returns the WAF rule relations for the given ModSecurity IDs.
+func rules(modSecIDs []int) []*fastly.WAFRule {
+	rs := make([]*fastly.WAFRule, 0, len(modSecIDs))
+	for _, id := range modSecIDs {
+		rs = append(rs, &fastly.WAFRule{ID: strconv.Itoa(id), ModSecID: id})
+	}
+	return rs
+}
+
+// modSecIDs returns the ModSecurity IDs of the rules as a comma separated list.
+func modSecIDs(rs []*fastly.WAFRule) string {
+	ids := make([]string, 0, len(rs))
+	for _, r := range rs {
+		ids = append(ids, strconv.Itoa(r.ModSecID))
+	}
+	return strings.Join(ids, ", ")
+}
+
+// validate checks the rules listed by an exclusion are consistent with its type.
+func validate(exclusionType string, modSecIDs []int) error {
+	switch {
+	case exclusionType == fastly.WAFRuleExclusionTypeRule && len(modSecIDs) == 0:
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("a 'rule' exclusion requires at least one rule"),
+			Remediation: "Provide the rules to exclude with --modsec-id, or use --exclusion-type=waf to skip the firewall entirely.",
+		}
+	case exclusionType == fastly.WAFRuleExclusionTypeWAF && len(modSecIDs) > 0:
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("a 'waf' exclusion can't list rules"),
+			Remediation: "Remove the --modsec-id flags, or use --exclusion-type=rule to exclude specific rules.",
+		}
+	}
+	return nil
+}
+
+// find returns the exclusion with the given number.
+func find(client api.Interface, firewallID string, firewallVersion, number int) (*fastly.WAFRuleExclusion, error) {
+	resp, err := client.ListAllWAFRuleExclusions(&fastly.ListAllWAFRuleExclusionsInput{
+		WAFID:            firewallID,
+		WAFVersionNumber: firewallVersion,
+		Include:          []string{"waf_rules"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range resp.Items {
+		if e.Number != nil && *e.Number == number {
+			return e, nil
+		}
+	}
+	return nil, fsterr.RemediationError{
+		Inner:       fmt.Errorf("rule exclusion %d not found (firewall: %s, version: %d)", number, firewallID, firewallVersion),
+		Remediation: "Run 'fastly waf exclusion list' to see the rule exclusions of the firewall version.",
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,220 @@
+package exclusion_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestExclusionList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListAllWAFRuleExclusions API error",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				ListAllWAFRuleExclusionsFn: func(i *fastly.ListAllWAFRuleExclusionsInput) (*fastly.WAFRuleExclusionResponse, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("waf exclusion list --firewall-id waf-1 --firewall-version active"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListAllWAFRuleExclusions API success",
+			API: mock.API{
+				ListAllWAFVersionsFn:       listWAFVersions,
+				ListAllWAFRuleExclusionsFn: listWAFRuleExclusions,
+			},
+			Args: args("waf exclusion list --firewall-id waf-1 --firewall-version active"),
+			WantOutput: "NUMBER  NAME       TYPE  CONDITION                 MODSEC IDS\n" +
+				"1       admin      rule  req.url ~ \"^/admin\"       1010090, 2029718\n" +
+				"2       allowlist  waf   client.ip ~ internal_ips  \n",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestExclusionCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --condition flag",
+			Args:      args("waf exclusion create --firewall-id waf-1 --firewall-version latest --name admin"),
+			WantError: "error parsing arguments: required flag --condition not provided",
+		},
+		{
+			Name:      "validate rule exclusion without rules",
+			Args:      args("waf exclusion create --firewall-id waf-1 --firewall-version latest --name admin --condition true"),
+			WantError: "a 'rule' exclusion requires at least one rule",
+		},
+		{
+			Name:      "validate waf exclusion with rules",
+			Args:      args("waf exclusion create --firewall-id waf-1 --firewall-version latest --name admin --condition true --exclusion-type waf --modsec-id 1010090"),
+			WantError: "a 'waf' exclusion can't list rules",
+		},
+		{
+			Name: "validate CreateWAFRuleExclusion API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				CreateWAFRuleExclusionFn: func(i *fastly.CreateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error) {
+					e := i.WAFRuleExclusion
+					if i.WAFVersionNumber != 2 || *e.ExclusionType != "rule" || len(e.Rules) != 1 || e.Rules[0].ID != "1010090" {
+						return nil, testutil.Err
+					}
+					e.Number = fastly.Int(3)
+					return e, nil
+				},
+			},
+			Args:       args("waf exclusion create --firewall-id waf-1 --firewall-version latest --name admin --condition true --modsec-id 1010090"),
+			WantOutput: "Created rule exclusion 3 'admin' (firewall: waf-1, version: 2)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestExclusionUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate unknown exclusion number",
+			API: mock.API{
+				ListAllWAFVersionsFn:       listWAFVersions,
+				ListAllWAFRuleExclusionsFn: listWAFRuleExclusions,
+			},
+			Args:      args("waf exclusion update --firewall-id waf-1 --firewall-version latest --number 9 --name other"),
+			WantError: "rule exclusion 9 not found (firewall: waf-1, version: 2)",
+		},
+		{
+			Name: "validate UpdateWAFRuleExclusion API success",
+			API: mock.API{
+				ListAllWAFVersionsFn:       listWAFVersions,
+				ListAllWAFRuleExclusionsFn: listWAFRuleExclusions,
+				UpdateWAFRuleExclusionFn: func(i *fastly.UpdateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error) {
+					e := i.WAFRuleExclusion
+					// The existing condition and rules are kept.
+					if i.Number != 1 || *e.Name != "admin-panel" || *e.Condition != `req.url ~ "^/admin"` || len(e.Rules) != 2 {
+						return nil, testutil.Err
+					}
+					return e, nil
+				},
+			},
+			Args:       args("waf exclusion update --firewall-id waf-1 --firewall-version latest --number 1 --name admin-panel"),
+			WantOutput: "Updated rule exclusion 1 'admin-panel' (firewall: waf-1, version: 2)",
+		},
+		{
+			Name: "validate changing the exclusion type drops the rules",
+			API: mock.API{
+				ListAllWAFVersionsFn:       listWAFVersions,
+				ListAllWAFRuleExclusionsFn: listWAFRuleExclusions,
+				UpdateWAFRuleExclusionFn: func(i *fastly.UpdateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error) {
+					if e := i.WAFRuleExclusion; *e.ExclusionType != "waf" || len(e.Rules) != 0 {
+						return nil, testutil.Err
+					}
+					return i.WAFRuleExclusion, nil
+				},
+			},
+			Args:       args("waf exclusion update --firewall-id waf-1 --firewall-version latest --number 1 --exclusion-type waf"),
+			WantOutput: "Updated rule exclusion 1 'admin' (firewall: waf-1, version: 2)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestExclusionDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate locked firewall version",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+			},
+			Args:      args("waf exclusion delete --firewall-id waf-1 --firewall-version 1 --number 1"),
+			WantError: "firewall version 1 is locked",
+		},
+		{
+			Name: "validate DeleteWAFRuleExclusion API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				DeleteWAFRuleExclusionFn: func(i *fastly.DeleteWAFRuleExclusionInput) error {
+					return nil
+				},
+			},
+			Args:       args("waf exclusion delete --firewall-id waf-1 --firewall-version 2 --number 1"),
+			WantOutput: "Deleted rule exclusion 1 (firewall: waf-1, version: 2)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func listWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	return &fastly.WAFVersionResponse{Items: []*fastly.WAFVersion{
+		{Number: 1, Active: true, Locked: true},
+		{Number: 2},
+	}}, nil
+}
+
+func listWAFRuleExclusions(i *fastly.ListAllWAFRuleExclusionsInput) (*fastly.WAFRuleExclusionResponse, error) {
+	return &fastly.WAFRuleExclusionResponse{Items: []*fastly.WAFRuleExclusion{
+		{
+			Number:        fastly.Int(1),
+			Name:          fastly.String("admin"),
+			ExclusionType: fastly.String("rule"),
+			Condition:     fastly.String(`req.url ~ "^/admin"`),
+			Rules: []*fastly.WAFRule{
+				{ID: "1010090", ModSecID: 1010090},
+				{ID: "2029718", ModSecID: 2029718},
+			},
+		},
+		{
+			Number:        fastly.Int(2),
+			Name:          fastly.String("allowlist"),
+			ExclusionType: fastly.String("waf"),
+			Condition:     fastly.String("client.ip ~ internal_ips"),
+		},
+	}}, nil
+}
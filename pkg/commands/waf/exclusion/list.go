@@ -0,0 +1,90 @@
+package exclusion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List the rule exclusions of a legacy WAF firewall version")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+
+	return &c
+}
+
+// ListCommand calls the Fastly API to list appropriate resources.
+type ListCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+	json            bool
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.FirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	resp, err := c.Globals.APIClient.ListAllWAFRuleExclusions(&fastly.ListAllWAFRuleExclusionsInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		Include:          []string{"waf_rules"},
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+		})
+		return err
+	}
+
+	if c.json {
+		data, err := json.Marshal(resp.Items)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(resp.Items) == 0 {
+		text.Info(out, "No rule exclusions found for firewall %s version %d.", c.firewallID, v.Number)
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("NUMBER", "NAME", "TYPE", "CONDITION", "MODSEC IDS")
+	for _, e := range resp.Items {
+		t.AddLine(intValue(e.Number), stringValue(e.Name), stringValue(e.ExclusionType), stringValue(e.Condition), modSecIDs(e.Rules))
+	}
+	t.Print()
+	return nil
+}
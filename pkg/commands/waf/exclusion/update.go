@@ -0,0 +1,110 @@
+package exclusion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, globals *config.Data) *UpdateCommand {
+	var c UpdateCommand
+	c.CmdClause = parent.Command("update", "Update a rule exclusion on a legacy WAF firewall version")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+	c.CmdClause.Flag("number", "Number of the rule exclusion to update").Required().IntVar(&c.number)
+
+	// Optional flags
+	c.CmdClause.Flag("name", "New name for the rule exclusion").Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("condition", "New VCL condition for the rule exclusion").Action(c.condition.Set).StringVar(&c.condition.Value)
+	c.CmdClause.Flag("exclusion-type", "Whether to exclude specific rules or skip the firewall entirely").Action(c.exclusionType.Set).HintOptions(Types...).EnumVar(&c.exclusionType.Value, Types...)
+	c.CmdClause.Flag("modsec-id", "ModSecurity ID of a rule to exclude, replacing the existing rules (repeat to exclude several rules)").IntsVar(&c.modSecIDs)
+
+	return &c
+}
+
+// UpdateCommand calls the Fastly API to update an appropriate resource.
+type UpdateCommand struct {
+	cmd.Base
+
+	condition       cmd.OptionalString
+	exclusionType   cmd.OptionalString
+	firewallID      string
+	firewallVersion string
+	modSecIDs       []int
+	name            cmd.OptionalString
+	number          int
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.EditableFirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	// The API replaces the whole exclusion, so the changes are applied on top
+	// of the existing exclusion.
+	e, err := find(c.Globals.APIClient, c.firewallID, v.Number, c.number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+			"Number":           c.number,
+		})
+		return err
+	}
+
+	if c.name.WasSet {
+		e.Name = fastly.String(c.name.Value)
+	}
+	if c.condition.WasSet {
+		e.Condition = fastly.String(c.condition.Value)
+	}
+	if c.exclusionType.WasSet {
+		e.ExclusionType = fastly.String(c.exclusionType.Value)
+		if c.exclusionType.Value == fastly.WAFRuleExclusionTypeWAF && len(c.modSecIDs) == 0 {
+			e.Rules = nil
+		}
+	}
+	if len(c.modSecIDs) > 0 {
+		e.Rules = rules(c.modSecIDs)
+	}
+
+	ids := make([]int, 0, len(e.Rules))
+	for _, r := range e.Rules {
+		ids = append(ids, r.ModSecID)
+	}
+	if err := validate(stringValue(e.ExclusionType), ids); err != nil {
+		return err
+	}
+
+	_, err = c.Globals.APIClient.UpdateWAFRuleExclusion(&fastly.UpdateWAFRuleExclusionInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		Number:           c.number,
+		WAFRuleExclusion: e,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+			"Number":           c.number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated rule exclusion %d '%s' (firewall: %s, version: %d)", c.number, stringValue(e.Name), c.firewallID, v.Number)
+	return nil
+}
@@ -0,0 +1,79 @@
+package waf
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const (
+	// FlagFirewallIDName is the flag name.
+	FlagFirewallIDName = "firewall-id"
+	// FlagFirewallIDDesc is the flag description.
+	FlagFirewallIDDesc = "Alphanumeric string identifying a legacy WAF firewall (see 'fastly waf list')"
+	// FlagFirewallVersionName is the flag name.
+	FlagFirewallVersionName = "firewall-version"
+	// FlagFirewallVersionDesc is the flag description.
+	FlagFirewallVersionDesc = "'latest', 'active', or the number of a specific firewall version"
+)
+
+// FirewallVersion returns the firewall version identified by value, which is
+// either 'latest', 'active' or a firewall version number.
+func FirewallVersion(client api.Interface, firewallID, value string) (*fastly.WAFVersion, error) {
+	resp, err := client.ListAllWAFVersions(&fastly.ListAllWAFVersionsInput{
+		WAFID: firewallID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing firewall versions: %w", err)
+	}
+
+	var (
+		found  *fastly.WAFVersion
+		number int
+	)
+	if value != "latest" && value != "active" {
+		number, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid firewall version '%s'", value),
+				Remediation: "Specify 'latest', 'active', or the number of a specific firewall version.",
+			}
+		}
+	}
+	for _, v := range resp.Items {
+		switch {
+		case value == "latest" && (found == nil || v.Number > found.Number):
+			found = v
+		case value == "active" && v.Active:
+			found = v
+		case number != 0 && v.Number == number:
+			found = v
+		}
+	}
+	if found == nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("firewall version '%s' not found for firewall %s", value, firewallID),
+			Remediation: "Run 'fastly waf version list' to see the available firewall versions.",
+		}
+	}
+	return found, nil
+}
+
+// EditableFirewallVersion is like FirewallVersion but returns an error if the
+// firewall version is locked, as only unlocked versions can be modified.
+func EditableFirewallVersion(client api.Interface, firewallID, value string) (*fastly.WAFVersion, error) {
+	v, err := FirewallVersion(client, firewallID, value)
+	if err != nil {
+		return nil, err
+	}
+	if v.Locked {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("firewall version %d is locked", v.Number),
+			Remediation: "Run 'fastly waf version clone' to create an editable copy of the firewall version.",
+		}
+	}
+	return v, nil
+}
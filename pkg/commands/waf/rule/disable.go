@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDisableCommand returns a usable command registered under the parent.
+func NewDisableCommand(parent cmd.Registerer, globals *config.Data) *DisableCommand {
+	var c DisableCommand
+	c.CmdClause = parent.Command("disable", "Disable rules on a legacy WAF firewall version")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+	c.CmdClause.Flag("modsec-id", "ModSecurity ID of the rule to disable (repeat to disable several rules)").Required().IntsVar(&c.modSecIDs)
+
+	return &c
+}
+
+// DisableCommand calls the Fastly API to remove rules from a firewall version.
+type DisableCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+	modSecIDs       []int
+}
+
+// Exec invokes the application logic for the command.
+func (c *DisableCommand) Exec(in io.Reader, out io.Writer) error {
+	if len(c.modSecIDs) > fastly.WAFBatchModifyMaximumOperations {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("too many rules: %d", len(c.modSecIDs)),
+			Remediation: fmt.Sprintf("Disable at most %d rules at a time.", fastly.WAFBatchModifyMaximumOperations),
+		}
+	}
+
+	v, err := waf.EditableFirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	rules := make([]*fastly.WAFActiveRule, 0, len(c.modSecIDs))
+	for _, id := range c.modSecIDs {
+		rules = append(rules, &fastly.WAFActiveRule{ModSecID: id})
+	}
+
+	err = c.Globals.APIClient.DeleteWAFActiveRules(&fastly.DeleteWAFActiveRulesInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		Rules:            rules,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+			"ModSec IDs":       c.modSecIDs,
+		})
+		return err
+	}
+
+	text.Success(out, "Disabled %d rule(s) (firewall: %s, version: %d)", len(rules), c.firewallID, v.Number)
+	return nil
+}
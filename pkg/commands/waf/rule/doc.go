@@ -0,0 +1,3 @@
+// Package rule contains commands for managing the rules enabled on a legacy
+// WAF firewall version.
+package rule
@@ -0,0 +1,90 @@
+package rule
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Statuses are the statuses a rule can be enabled with.
+var Statuses = []string{"log", "block", "score"}
+
+// NewEnableCommand returns a usable command registered under the parent.
+func NewEnableCommand(parent cmd.Registerer, globals *config.Data) *EnableCommand {
+	var c EnableCommand
+	c.CmdClause = parent.Command("enable", "Enable rules on a legacy WAF firewall version, or change the status of enabled rules")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+	c.CmdClause.Flag("modsec-id", "ModSecurity ID of the rule to enable (repeat to enable several rules)").Required().IntsVar(&c.modSecIDs)
+
+	// Optional flags
+	c.CmdClause.Flag("revision", "Revision of the rule to enable (defaults to the latest revision)").IntVar(&c.revision)
+	c.CmdClause.Flag("status", "Whether the rules log, block or contribute to the anomaly score of matching requests").Default("log").HintOptions(Statuses...).EnumVar(&c.status, Statuses...)
+
+	return &c
+}
+
+// EnableCommand calls the Fastly API to enable rules on a firewall version.
+type EnableCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+	modSecIDs       []int
+	revision        int
+	status          string
+}
+
+// Exec invokes the application logic for the command.
+func (c *EnableCommand) Exec(in io.Reader, out io.Writer) error {
+	if len(c.modSecIDs) > fastly.WAFBatchModifyMaximumOperations {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("too many rules: %d", len(c.modSecIDs)),
+			Remediation: fmt.Sprintf("Enable at most %d rules at a time.", fastly.WAFBatchModifyMaximumOperations),
+		}
+	}
+
+	v, err := waf.EditableFirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	rules := make([]*fastly.WAFActiveRule, 0, len(c.modSecIDs))
+	for _, id := range c.modSecIDs {
+		rules = append(rules, &fastly.WAFActiveRule{
+			ModSecID: id,
+			Revision: c.revision,
+			Status:   c.status,
+		})
+	}
+
+	_, err = c.Globals.APIClient.CreateWAFActiveRules(&fastly.CreateWAFActiveRulesInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		Rules:            rules,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+			"ModSec IDs":       c.modSecIDs,
+		})
+		return err
+	}
+
+	text.Success(out, "Enabled %d rule(s) with status '%s' (firewall: %s, version: %d)", len(rules), c.status, c.firewallID, v.Number)
+	return nil
+}
@@ -0,0 +1,98 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List the rules enabled on a legacy WAF firewall version")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("status", "Only list rules with the given status").HintOptions(Statuses...).EnumVar(&c.status, Statuses...)
+
+	return &c
+}
+
+// ListCommand calls the Fastly API to list appropriate resources.
+type ListCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+	json            bool
+	status          string
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.FirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	resp, err := c.Globals.APIClient.ListAllWAFActiveRules(&fastly.ListAllWAFActiveRulesInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+		FilterStatus:     c.status,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+		})
+		return err
+	}
+
+	rules := resp.Items
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ModSecID < rules[j].ModSecID
+	})
+
+	if c.json {
+		data, err := json.Marshal(rules)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	if len(rules) == 0 {
+		text.Info(out, "No rules are enabled on firewall %s version %d.", c.firewallID, v.Number)
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("MODSEC ID", "STATUS", "REVISION", "LATEST REVISION", "OUTDATED")
+	for _, r := range rules {
+		t.AddLine(r.ModSecID, r.Status, r.Revision, r.LatestRevision, r.Outdated)
+	}
+	t.Print()
+	return nil
+}
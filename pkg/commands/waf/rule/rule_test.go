@@ -0,0 +1,161 @@
+package rule_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestRuleList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --firewall-id flag",
+			Args:      args("waf rule list --firewall-version active"),
+			WantError: "error parsing arguments: required flag --firewall-id not provided",
+		},
+		{
+			Name: "validate ListAllWAFActiveRules API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				ListAllWAFActiveRulesFn: func(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error) {
+					if i.WAFVersionNumber != 1 || i.FilterStatus != "block" {
+						return nil, testutil.Err
+					}
+					return &fastly.WAFActiveRuleResponse{Items: []*fastly.WAFActiveRule{
+						{ModSecID: 2029718, Status: "block", Revision: 1, LatestRevision: 2, Outdated: true},
+						{ModSecID: 1010090, Status: "block", Revision: 3, LatestRevision: 3},
+					}}, nil
+				},
+			},
+			Args: args("waf rule list --firewall-id waf-1 --firewall-version active --status block"),
+			WantOutput: "MODSEC ID  STATUS  REVISION  LATEST REVISION  OUTDATED\n" +
+				"1010090    block   3         3                false\n" +
+				"2029718    block   1         2                true\n",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRuleEnable(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --modsec-id flag",
+			Args:      args("waf rule enable --firewall-id waf-1 --firewall-version latest"),
+			WantError: "error parsing arguments: required flag --modsec-id not provided",
+		},
+		{
+			Name:      "validate invalid --status flag",
+			Args:      args("waf rule enable --firewall-id waf-1 --firewall-version latest --modsec-id 1010090 --status drop"),
+			WantError: "enum value must be one of log,block,score, got 'drop'",
+		},
+		{
+			Name: "validate locked firewall version",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+			},
+			Args:      args("waf rule enable --firewall-id waf-1 --firewall-version active --modsec-id 1010090"),
+			WantError: "firewall version 1 is locked",
+		},
+		{
+			Name: "validate CreateWAFActiveRules API error",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				CreateWAFActiveRulesFn: func(i *fastly.CreateWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("waf rule enable --firewall-id waf-1 --firewall-version latest --modsec-id 1010090"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateWAFActiveRules API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				CreateWAFActiveRulesFn: func(i *fastly.CreateWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+					if i.WAFVersionNumber != 2 || len(i.Rules) != 2 || i.Rules[1].ModSecID != 2029718 || i.Rules[1].Status != "block" {
+						return nil, testutil.Err
+					}
+					return i.Rules, nil
+				},
+			},
+			Args:       args("waf rule enable --firewall-id waf-1 --firewall-version latest --modsec-id 1010090 --modsec-id 2029718 --status block"),
+			WantOutput: "Enabled 2 rule(s) with status 'block' (firewall: waf-1, version: 2)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRuleDisable(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate DeleteWAFActiveRules API error",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				DeleteWAFActiveRulesFn: func(i *fastly.DeleteWAFActiveRulesInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("waf rule disable --firewall-id waf-1 --firewall-version 2 --modsec-id 1010090"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteWAFActiveRules API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				DeleteWAFActiveRulesFn: func(i *fastly.DeleteWAFActiveRulesInput) error {
+					if i.WAFVersionNumber != 2 || len(i.Rules) != 1 || i.Rules[0].ModSecID != 1010090 {
+						return testutil.Err
+					}
+					return nil
+				},
+			},
+			Args:       args("waf rule disable --firewall-id waf-1 --firewall-version 2 --modsec-id 1010090"),
+			WantOutput: "Disabled 1 rule(s) (firewall: waf-1, version: 2)",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func listWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	return &fastly.WAFVersionResponse{Items: []*fastly.WAFVersion{
+		{Number: 1, Active: true, Locked: true},
+		{Number: 2},
+	}}, nil
+}
@@ -0,0 +1,118 @@
+package waf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestWAFList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("waf list --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate ListWAFs API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListWAFsFn: func(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("waf list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListWAFs API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListWAFsFn: func(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+					return &fastly.WAFResponse{Items: []*fastly.WAF{
+						{
+							ID:                "waf-1",
+							ServiceID:         i.FilterService,
+							ServiceVersion:    i.FilterVersion,
+							PrefetchCondition: "WAF_Prefetch",
+							Response:          "WAF_Response",
+						},
+					}}, nil
+				},
+			},
+			Args:       args("waf list --service-id 123 --version 1"),
+			WantOutput: "waf-1  123         1        false     WAF_Prefetch        WAF_Response",
+		},
+		{
+			Name: "validate ListWAFs API success with no firewalls",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListWAFsFn: func(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+					return &fastly.WAFResponse{}, nil
+				},
+			},
+			Args:       args("waf list --service-id 123 --version 1"),
+			WantOutput: "No legacy WAF firewalls found for service 123 version 1.",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestFirewallVersion(t *testing.T) {
+	client := mock.API{
+		ListAllWAFVersionsFn: func(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+			return &fastly.WAFVersionResponse{Items: []*fastly.WAFVersion{
+				{Number: 1, Locked: true},
+				{Number: 2, Locked: true, Active: true},
+				{Number: 3},
+			}}, nil
+		},
+	}
+
+	for _, testcase := range []struct {
+		value      string
+		editable   bool
+		wantNumber int
+		wantError  string
+	}{
+		{value: "latest", wantNumber: 3},
+		{value: "active", wantNumber: 2},
+		{value: "1", wantNumber: 1},
+		{value: "3", editable: true, wantNumber: 3},
+		{value: "2", editable: true, wantError: "firewall version 2 is locked"},
+		{value: "4", wantError: "firewall version '4' not found for firewall waf-1"},
+		{value: "next", wantError: "invalid firewall version 'next'"},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			var (
+				v   *fastly.WAFVersion
+				err error
+			)
+			if testcase.editable {
+				v, err = waf.EditableFirewallVersion(client, "waf-1", testcase.value)
+			} else {
+				v, err = waf.FirewallVersion(client, "waf-1", testcase.value)
+			}
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantError == "" && v.Number != testcase.wantNumber {
+				t.Errorf("want version %d, have %d", testcase.wantNumber, v.Number)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package wafversion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewCloneCommand returns a usable command registered under the parent.
+func NewCloneCommand(parent cmd.Registerer, globals *config.Data) *CloneCommand {
+	var c CloneCommand
+	c.CmdClause = parent.Command("clone", "Clone a legacy WAF firewall version to create an editable copy")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+
+	return &c
+}
+
+// CloneCommand calls the Fastly API to clone a firewall version.
+type CloneCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+}
+
+// Exec invokes the application logic for the command.
+func (c *CloneCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.FirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	clone, err := c.Globals.APIClient.CloneWAFVersion(&fastly.CloneWAFVersionInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Cloned firewall %s version %d to version %d", c.firewallID, v.Number, clone.Number)
+	return nil
+}
@@ -0,0 +1,61 @@
+package wafversion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDeployCommand returns a usable command registered under the parent.
+func NewDeployCommand(parent cmd.Registerer, globals *config.Data) *DeployCommand {
+	var c DeployCommand
+	c.CmdClause = parent.Command("deploy", "Deploy a legacy WAF firewall version, making it the active version").Alias("activate")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+
+	return &c
+}
+
+// DeployCommand calls the Fastly API to deploy a firewall version.
+type DeployCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+}
+
+// Exec invokes the application logic for the command.
+func (c *DeployCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.FirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	err = c.Globals.APIClient.DeployWAFVersion(&fastly.DeployWAFVersionInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+		})
+		return err
+	}
+
+	// Deployment happens asynchronously.
+	text.Success(out, "Deployment of firewall %s version %d started", c.firewallID, v.Number)
+	text.Info(out, "Run 'fastly waf version list --firewall-id %s' to follow the deployment status.", c.firewallID)
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package wafversion contains commands for managing and deploying the
+// versions of a legacy WAF firewall.
+package wafversion
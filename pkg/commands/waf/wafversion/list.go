@@ -0,0 +1,81 @@
+package wafversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List the versions of a legacy WAF firewall")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+
+	return &c
+}
+
+// ListCommand calls the Fastly API to list appropriate resources.
+type ListCommand struct {
+	cmd.Base
+
+	firewallID string
+	json       bool
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	resp, err := c.Globals.APIClient.ListAllWAFVersions(&fastly.ListAllWAFVersionsInput{
+		WAFID: c.firewallID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID": c.firewallID,
+		})
+		return err
+	}
+
+	versions := resp.Items
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Number < versions[j].Number
+	})
+
+	if c.json {
+		data, err := json.Marshal(versions)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("NUMBER", "ACTIVE", "LOCKED", "DEPLOYMENT STATUS", "LAST EDITED (UTC)", "COMMENT")
+	for _, v := range versions {
+		var updated string
+		if v.UpdatedAt != nil {
+			updated = c.Globals.Formatter().UTCTime(*v.UpdatedAt)
+		}
+		t.AddLine(v.Number, v.Active, v.Locked, v.LastDeploymentStatus, updated, v.Comment)
+	}
+	t.Print()
+	return nil
+}
@@ -0,0 +1,59 @@
+package wafversion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/waf"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewLockCommand returns a usable command registered under the parent.
+func NewLockCommand(parent cmd.Registerer, globals *config.Data) *LockCommand {
+	var c LockCommand
+	c.CmdClause = parent.Command("lock", "Lock a legacy WAF firewall version so it can no longer be modified")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag(waf.FlagFirewallIDName, waf.FlagFirewallIDDesc).Required().StringVar(&c.firewallID)
+	c.CmdClause.Flag(waf.FlagFirewallVersionName, waf.FlagFirewallVersionDesc).Required().StringVar(&c.firewallVersion)
+
+	return &c
+}
+
+// LockCommand calls the Fastly API to lock a firewall version.
+type LockCommand struct {
+	cmd.Base
+
+	firewallID      string
+	firewallVersion string
+}
+
+// Exec invokes the application logic for the command.
+func (c *LockCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := waf.FirewallVersion(c.Globals.APIClient, c.firewallID, c.firewallVersion)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": c.firewallVersion,
+		})
+		return err
+	}
+
+	_, err = c.Globals.APIClient.LockWAFVersion(&fastly.LockWAFVersionInput{
+		WAFID:            c.firewallID,
+		WAFVersionNumber: v.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Firewall ID":      c.firewallID,
+			"Firewall Version": v.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Locked firewall %s version %d", c.firewallID, v.Number)
+	return nil
+}
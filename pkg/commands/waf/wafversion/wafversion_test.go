@@ -0,0 +1,165 @@
+package wafversion_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestVersionList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --firewall-id flag",
+			Args:      args("waf version list"),
+			WantError: "error parsing arguments: required flag --firewall-id not provided",
+		},
+		{
+			Name: "validate ListAllWAFVersions API error",
+			API: mock.API{
+				ListAllWAFVersionsFn: func(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("waf version list --firewall-id waf-1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListAllWAFVersions API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+			},
+			Args: args("waf version list --firewall-id waf-1"),
+			WantOutput: "NUMBER  ACTIVE  LOCKED  DEPLOYMENT STATUS  LAST EDITED (UTC)  COMMENT\n" +
+				"1       true    true    completed          2021-06-15 23:00   initial\n" +
+				"2       false   false                      2021-06-16 09:30   \n",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestVersionClone(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate CloneWAFVersion API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				CloneWAFVersionFn: func(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error) {
+					return &fastly.WAFVersion{Number: 3}, nil
+				},
+			},
+			Args:       args("waf version clone --firewall-id waf-1 --firewall-version active"),
+			WantOutput: "Cloned firewall waf-1 version 1 to version 3",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestVersionLock(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate LockWAFVersion API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				LockWAFVersionFn: func(i *fastly.LockWAFVersionInput) (*fastly.WAFVersion, error) {
+					return &fastly.WAFVersion{Number: i.WAFVersionNumber, Locked: true}, nil
+				},
+			},
+			Args:       args("waf version lock --firewall-id waf-1 --firewall-version latest"),
+			WantOutput: "Locked firewall waf-1 version 2",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestVersionDeploy(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate DeployWAFVersion API error",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				DeployWAFVersionFn: func(i *fastly.DeployWAFVersionInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("waf version deploy --firewall-id waf-1 --firewall-version 2"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeployWAFVersion API success",
+			API: mock.API{
+				ListAllWAFVersionsFn: listWAFVersions,
+				DeployWAFVersionFn: func(i *fastly.DeployWAFVersionInput) error {
+					return nil
+				},
+			},
+			Args:       args("waf version deploy --firewall-id waf-1 --firewall-version 2"),
+			WantOutput: "Deployment of firewall waf-1 version 2 started",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func listWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	return &fastly.WAFVersionResponse{Items: []*fastly.WAFVersion{
+		{
+			Number:    2,
+			UpdatedAt: testutil.MustParseTimeRFC3339("2021-06-16T09:30:00Z"),
+		},
+		{
+			Number:               1,
+			Active:               true,
+			Locked:               true,
+			Comment:              "initial",
+			LastDeploymentStatus: "completed",
+			UpdatedAt:            testutil.MustParseTimeRFC3339("2021-06-15T23:00:00Z"),
+		},
+	}}, nil
+}
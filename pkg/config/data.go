@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -8,14 +10,18 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/clock"
 	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/interpolate"
+	"github.com/fastly/cli/pkg/logger"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/revision"
 	"github.com/fastly/cli/pkg/text"
@@ -105,6 +111,44 @@ type Data struct {
 	Output   io.Writer
 	Path     string
 
+	// Diagnostics is where verbose diagnostics (e.g. API timings and HTTP
+	// traces) are written. It is kept separate from Output so diagnostics can
+	// coexist with structured (--json) output.
+	Diagnostics io.Writer
+
+	// Context is cancelled when the user interrupts the CLI (e.g. with
+	// Ctrl-C). The API and HTTP clients are bound to it, so only commands that
+	// wait on something else (e.g. a loop or a subprocess) need to check it.
+	Context context.Context
+
+	// Clock is the source of the current time and of generated IDs. It's nil,
+	// meaning the system clock, unless --deterministic is set.
+	Clock *clock.Clock
+
+	// Logger writes structured diagnostic logs when --log-file or --log-level
+	// is set. Otherwise it's nil, which discards every record.
+	Logger *logger.Logger
+
+	// Mutating is whether the command being run modifies the Fastly account
+	// (see cmd.Command), for the checks and middleware that depend on it.
+	Mutating bool
+
+	// StateDir is where the CLI keeps state between invocations (e.g. the
+	// last API rate limit observed for each token). It's empty, so no state
+	// is kept, unless set by the caller of app.Run.
+	StateDir string
+
+	// Stdin is the input read by flags given "-" (see cmd.Content).
+	Stdin io.Reader
+
+	// AutoCloneDryRun is set when --autoclone=dry-run was given, so commands
+	// report whether a clone would be needed instead of making one.
+	AutoCloneDryRun bool
+
+	// Transport is what the API clients connect with, when a proxy or CA file
+	// is configured (see Network). It's nil otherwise, meaning the default.
+	Transport *http.Transport
+
 	// Custom interfaces
 	ErrLog     fsterr.LogInterface
 	APIClient  api.Interface
@@ -112,6 +156,15 @@ type Data struct {
 	RTSClient  api.RealtimeStatsInterface
 }
 
+// StatePath returns the path of the named state file, or an empty string if
+// no state is kept.
+func (d *Data) StatePath(name string) string {
+	if d.StateDir == "" {
+		return ""
+	}
+	return filepath.Join(d.StateDir, name)
+}
+
 // Token yields the Fastly API token.
 func (d *Data) Token() (string, Source) {
 	if d.Flag.Token != "" {
@@ -147,13 +200,210 @@ func (d *Data) Token() (string, Source) {
 	return "", SourceUndefined
 }
 
+// CurrentProfile yields the name and data of the profile in use. The profile is
+// resolved using the same priority order as Token: the fastly.toml manifest,
+// then the --profile flag, and lastly the default profile.
+//
+// NOTE: An empty name and nil Profile are returned if no profile is found.
+func (d *Data) CurrentProfile() (string, *Profile) {
+	for _, name := range []string{d.Manifest.File.Profile, d.Flag.Profile} {
+		if name == "" {
+			continue
+		}
+		if p, ok := d.File.Profiles[name]; ok {
+			return name, p
+		}
+	}
+	for k, v := range d.File.Profiles {
+		if v.Default {
+			return k, v
+		}
+	}
+	return "", nil
+}
+
+// ProfileServiceID yields the default service of the current profile, used
+// when a command isn't otherwise given a service.
+func (d *Data) ProfileServiceID() string {
+	if _, p := d.CurrentProfile(); p != nil {
+		return p.ServiceID
+	}
+	return ""
+}
+
+// ReadOnly indicates whether commands are prevented from making changes to the
+// Fastly account, either via the --read-only flag or the read_only setting of
+// the current profile.
+func (d *Data) ReadOnly() bool {
+	if d.Flag.ReadOnly {
+		return true
+	}
+	_, p := d.CurrentProfile()
+	return p != nil && p.ReadOnly
+}
+
+// DefaultRetryAttempts is the number of times a rate limited or failed API
+// request is retried if neither the --retry flag nor the config file say
+// otherwise.
+const DefaultRetryAttempts = 3
+
+// RetryAttempts yields the maximum number of times an API request that was
+// rate limited or failed with a transient server error is retried. The
+// --retry flag overrides the config file's [retry] setting.
+func (d *Data) RetryAttempts() int {
+	if d.Flag.RetrySet {
+		return d.Flag.Retry
+	}
+	if d.File.Retry.Attempts != nil {
+		return *d.File.Retry.Attempts
+	}
+	return DefaultRetryAttempts
+}
+
+// CacheTTL yields how long the responses of read-only API calls are cached
+// for, according to the config file's [cache] setting. Zero, the default,
+// means responses aren't cached.
+//
+// NOTE: The --no-cache flag isn't taken into account, as a command run with
+// it must still remove cached responses that it makes stale.
+func (d *Data) CacheTTL() (time.Duration, error) {
+	if d.File.Cache.TTL == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(d.File.Cache.TTL)
+	if err != nil || ttl < 0 {
+		return 0, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid cache ttl '%s' in the config file", d.File.Cache.TTL),
+			Remediation: "Set the ttl of the [cache] section of the config file to a duration such as \"30s\" or \"5m\" ('fastly config --location' displays its path).",
+		}
+	}
+	return ttl, nil
+}
+
+// Network yields how the CLI connects to the API. The --proxy and --ca-file
+// flags override the config file's [network] settings.
+func (d *Data) Network() Network {
+	n := d.File.Network
+	if d.Flag.Proxy != "" {
+		n.Proxy = d.Flag.Proxy
+	}
+	if d.Flag.CAFile != "" {
+		n.CAFile = d.Flag.CAFile
+	}
+	return n
+}
+
+// Formatter yields how timestamps and byte quantities are rendered in human
+// readable output, according to the --utc, --iso8601 and --human-sizes flags.
+func (d *Data) Formatter() text.Formatter {
+	return text.Formatter{
+		UTC:        d.Flag.UTC,
+		ISO8601:    d.Flag.ISO8601,
+		HumanSizes: d.Flag.HumanSizes,
+	}
+}
+
+// APITimeout yields how long an API request may take, where zero means there's
+// no limit. The --api-timeout flag overrides the config file's [network]
+// api_timeout setting.
+func (d *Data) APITimeout() time.Duration {
+	if d.Flag.APITimeout > 0 {
+		return time.Duration(d.Flag.APITimeout) * time.Second
+	}
+	return time.Duration(d.File.Network.APITimeout) * time.Second
+}
+
+// DefaultUploadEncoding is the content encoding large request bodies are
+// compressed with if the config file doesn't say otherwise.
+const DefaultUploadEncoding = "gzip"
+
+// UploadEncodings are the valid values of the config file's [upload]
+// compression setting.
+var UploadEncodings = []string{"gzip", "br", "none"}
+
+// UploadEncoding yields the content encoding that large request bodies are
+// compressed with, according to the config file's [upload] setting.
+func (d *Data) UploadEncoding() (string, error) {
+	c := d.File.Upload.Compression
+	if c == "" {
+		return DefaultUploadEncoding, nil
+	}
+	for _, e := range UploadEncodings {
+		if c == e {
+			return c, nil
+		}
+	}
+	return "", fsterr.RemediationError{
+		Inner:       fmt.Errorf("invalid upload compression '%s' in the config file", c),
+		Remediation: fmt.Sprintf("Set the compression of the [upload] section of the config file to one of: %s ('fastly config --location' displays its path).", strings.Join(UploadEncodings, ", ")),
+	}
+}
+
+// MiddlewareEnabled indicates whether the named command middleware (see
+// cmd.RegisterMiddleware) runs, according to the middleware setting of the
+// current profile. If the profile doesn't set it then def is returned.
+func (d *Data) MiddlewareEnabled(name string, def bool) bool {
+	_, p := d.CurrentProfile()
+	if p == nil {
+		return def
+	}
+	if enabled, ok := p.Middleware[name]; ok {
+		return enabled
+	}
+	return def
+}
+
 // Verbose yields the verbose flag, which can only be set via flags.
 func (d *Data) Verbose() bool {
 	return d.Flag.Verbose
 }
 
+const (
+	// VerboseLevelTimings is the verbose level (-vv) at which API call timings
+	// and pagination details are displayed.
+	VerboseLevelTimings = 2
+
+	// VerboseLevelTrace is the verbose level (-vvv) at which sanitized HTTP
+	// request and response traces are displayed.
+	VerboseLevelTrace = 3
+)
+
+// VerboseLevel yields the number of times the verbose flag was provided.
+func (d *Data) VerboseLevel() int {
+	return d.Flag.VerboseLevel
+}
+
+// TraceHTTP indicates whether sanitized traces of the CLI's HTTP requests are
+// written to the Diagnostics writer, either via --debug-http or -vvv.
+func (d *Data) TraceHTTP() bool {
+	return d.Flag.DebugHTTP || d.VerboseLevel() >= VerboseLevelTrace
+}
+
+// Diagnostic writes a message to the Diagnostics writer if the verbose level
+// is at least the given level.
+func (d *Data) Diagnostic(level int, format string, args ...interface{}) {
+	if d.Diagnostics == nil || d.Flag.VerboseLevel < level {
+		return
+	}
+	fmt.Fprintf(d.Diagnostics, strings.TrimRight(format, "\r\n")+"\n", args...)
+}
+
 // Endpoint yields the API endpoint.
 func (d *Data) Endpoint() (string, Source) {
+	_, p := d.CurrentProfile()
+	return d.ProfileEndpoint(p)
+}
+
+// ProfileEndpoint yields the API endpoint used with the given profile, which
+// may be nil. A profile's api_endpoint is overridden by the --api-endpoint flag
+// and environment variable, and overrides the config file's default. Any
+// trailing slash is removed, so paths can be appended to the endpoint.
+func (d *Data) ProfileEndpoint(p *Profile) (string, Source) {
+	endpoint, source := d.profileEndpoint(p)
+	return strings.TrimRight(endpoint, "/"), source
+}
+
+func (d *Data) profileEndpoint(p *Profile) (string, Source) {
 	if d.Flag.Endpoint != "" {
 		return d.Flag.Endpoint, SourceFlag
 	}
@@ -162,6 +412,10 @@ func (d *Data) Endpoint() (string, Source) {
 		return d.Env.Endpoint, SourceEnvironment
 	}
 
+	if p != nil && p.APIEndpoint != "" {
+		return p.APIEndpoint, SourceFile
+	}
+
 	if d.File.Fastly.APIEndpoint != DefaultEndpoint && d.File.Fastly.APIEndpoint != "" {
 		return d.File.Fastly.APIEndpoint, SourceFile
 	}
@@ -183,6 +437,14 @@ var FilePath = func() string {
 	panic("unable to deduce user config dir or user home dir")
 }()
 
+// CacheDir is the location of the on-disk cache of API responses.
+var CacheDir = func() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "fastly", "api")
+	}
+	return filepath.Join(filepath.Dir(FilePath), "cache")
+}()
+
 // DefaultEndpoint is the default Fastly API endpoint.
 const DefaultEndpoint = "https://api.fastly.com"
 
@@ -203,13 +465,25 @@ type LegacyUser struct {
 
 // File represents our dynamic application toml configuration.
 type File struct {
-	CLI           CLI                 `toml:"cli"`
-	ConfigVersion int                 `toml:"config_version"`
-	Fastly        Fastly              `toml:"fastly"`
-	Language      Language            `toml:"language"`
-	Profiles      Profiles            `toml:"profile"`
-	StarterKits   StarterKitLanguages `toml:"starter-kits"`
-	Viceroy       Viceroy             `toml:"viceroy"`
+	Aliases           Aliases             `toml:"aliases,omitempty"`
+	CLI               CLI                 `toml:"cli"`
+	Cache             Cache               `toml:"cache,omitempty"`
+	ConfigVersion     int                 `toml:"config_version"`
+	Confirm           Confirm             `toml:"confirm,omitempty"`
+	DictionaryChanges DictionaryChanges   `toml:"dictionary_change,omitempty"`
+	Fastly            Fastly              `toml:"fastly"`
+	Hooks             Hooks               `toml:"hooks,omitempty"`
+	Language          Language            `toml:"language"`
+	Manifest          Manifest            `toml:"manifest,omitempty"`
+	Network           Network             `toml:"network,omitempty"`
+	OutputPresets     OutputPresets       `toml:"output,omitempty"`
+	Profiles          Profiles            `toml:"profile"`
+	Retry             Retry               `toml:"retry,omitempty"`
+	Schedules         Schedules           `toml:"schedule,omitempty"`
+	Services          Services            `toml:"service,omitempty"`
+	StarterKits       StarterKitLanguages `toml:"starter-kits"`
+	Upload            Upload              `toml:"upload,omitempty"`
+	Viceroy           Viceroy             `toml:"viceroy"`
 
 	// We store off a possible legacy configuration so that we can later extract
 	// the relevant email and token values that may pre-exist.
@@ -224,6 +498,10 @@ type File struct {
 	// Store off copy of the static application configuration that has been
 	// embedded into the compiled CLI binary.
 	static []byte `toml:",omitempty"`
+
+	// templates records the values that contained environment variable or
+	// command references, so they can be restored by Write.
+	templates interpolate.Templates
 }
 
 // Fastly represents fastly specific configuration.
@@ -231,6 +509,13 @@ type Fastly struct {
 	APIEndpoint string `toml:"api_endpoint"`
 }
 
+// Cache represents the on-disk cache of the responses of read-only API calls
+// (see Data.CacheTTL).
+type Cache struct {
+	// TTL is how long a response is cached for, e.g. "5m".
+	TTL string `toml:"ttl,omitempty"`
+}
+
 // CLI represents CLI specific configuration.
 type CLI struct {
 	RemoteConfig string `toml:"remote_config"`
@@ -239,12 +524,153 @@ type CLI struct {
 	Version      string `toml:"version"`
 }
 
+// Aliases represents user-defined commands, mapping a name to the command
+// line it runs, e.g. prod-snippets = "vcl snippet list --version latest".
+//
+// Arguments given after the name of an alias are appended to its command
+// line. An alias can't replace a built-in command, and isn't expanded within
+// another alias.
+type Aliases map[string]string
+
+// Confirm represents which levels of Yes/No confirmation prompts should be
+// answered automatically.
+//
+// NOTE: There is deliberately no setting for irreversible operations, which
+// must always be confirmed explicitly with the --confirm-irreversible flag.
+type Confirm struct {
+	Destructive   bool `toml:"destructive"`
+	Informational bool `toml:"informational"`
+}
+
+// Manifest represents how fastly.toml manifests are read.
+type Manifest struct {
+	// Commands enables command references ($(command)) in the values of a
+	// fastly.toml manifest. It's off by default as every command reads the
+	// manifest, which is often part of a project cloned from elsewhere, so
+	// its commands would otherwise be run without any confirmation.
+	Commands bool `toml:"commands,omitempty"`
+}
+
+// OutputPresets represents named table layouts, keyed by name, that can be
+// used to render the output of commands (see --preset).
+type OutputPresets map[string]OutputPreset
+
+// OutputPreset represents the columns, and the order of the rows, of a table.
+type OutputPreset struct {
+	// Columns are the paths of the fields displayed, e.g. versions.0.number.
+	Columns []string `toml:"columns"`
+	// Sort is the path of the field the rows are sorted by, prefixed with '-'
+	// to sort in descending order.
+	Sort string `toml:"sort,omitempty"`
+}
+
+// Network represents how the CLI connects to the API (see Data.Network),
+// e.g. from behind a corporate proxy that intercepts TLS connections.
+type Network struct {
+	// Proxy is the URL of the proxy that requests are sent via.
+	Proxy string `toml:"proxy,omitempty"`
+	// CAFile is the path of a PEM file of root certificates that are trusted
+	// in addition to the system's.
+	CAFile string `toml:"ca_file,omitempty"`
+	// APITimeout is how long, in seconds, an API request may take, where
+	// zero means there's no limit.
+	APITimeout int `toml:"api_timeout,omitempty"`
+}
+
+// Retry represents how API requests that are rate limited or fail with a
+// transient server error are retried (see Data.RetryAttempts).
+type Retry struct {
+	// Attempts is the maximum number of retries, where zero disables them.
+	Attempts *int `toml:"attempts,omitempty"`
+}
+
+// Upload represents how large request bodies (e.g. packages) are sent to the
+// API (see Data.UploadEncoding).
+type Upload struct {
+	// Compression is the content encoding used to compress request bodies:
+	// gzip (the default), br or none.
+	Compression string `toml:"compression,omitempty"`
+}
+
+// Services represents the settings of individual services, keyed by service
+// ID (e.g. [service.SU1Z0isxPaozGVKXdv0eY]). They're applied to the commands
+// that operate on the service (see cmd.ServiceConfigArgs), so that services
+// owned by different teams can have different defaults.
+type Services map[string]*Service
+
+// Service represents the settings of a service. Each is a default that the
+// equivalent flag overrides.
+type Service struct {
+	// Version is the version that commands use when --version isn't given:
+	// 'latest', 'active' or the number of a specific version.
+	Version string `toml:"version,omitempty"`
+	// Protected requires commands that modify the service to be confirmed,
+	// either interactively or with --confirm-destructive.
+	Protected bool `toml:"protected,omitempty"`
+	// Output is the format the output of commands is rendered in, as if by
+	// --output: table, json or yaml.
+	Output string `toml:"output,omitempty"`
+	// Profile is the profile whose token is used, as if by --profile.
+	Profile string `toml:"profile,omitempty"`
+}
+
 // User represents user specific configuration.
 type User struct {
 	Token string `toml:"token"`
 	Email string `toml:"email"`
 }
 
+// Hooks represents the notification hooks keyed by event name (e.g.
+// [hooks.post-activate]).
+type Hooks map[string]*Hook
+
+// Hook represents a notification triggered after a command succeeds.
+//
+// The URL receives an HTTP POST of a JSON payload describing the event, while
+// the Command is executed via a subprocess shell with the same payload
+// provided on stdin.
+type Hook struct {
+	Command string `toml:"command"`
+	URL     string `toml:"url"`
+}
+
+// Schedules represents the scheduled jobs keyed by name (e.g.
+// [schedule.nightly-purge]).
+type Schedules map[string]*Schedule
+
+// Schedule represents a CLI command run on a cron schedule by the 'fastly
+// schedule run' command.
+//
+// The times are in RFC 3339 format. A job that has never run is due at the
+// first time its schedule matches after it was added.
+type Schedule struct {
+	Cron       string `toml:"cron"`
+	Command    string `toml:"command"`
+	Added      string `toml:"added"`
+	LastRun    string `toml:"last_run,omitempty"`
+	LastResult string `toml:"last_result,omitempty"`
+}
+
+// DictionaryChanges represents the dictionary item changes scheduled by the
+// 'fastly dictionary-item schedule' command (e.g. [[dictionary_change]]).
+type DictionaryChanges []*DictionaryChange
+
+// DictionaryChange represents a change to a dictionary item that's applied by
+// the 'fastly dictionary-item apply-due' command once its time has passed.
+//
+// The times are in RFC 3339 format. The item is deleted if Delete is set, and
+// otherwise created or updated with the Value.
+type DictionaryChange struct {
+	ID           string `toml:"id"`
+	ServiceID    string `toml:"service_id"`
+	DictionaryID string `toml:"dictionary_id"`
+	Key          string `toml:"key"`
+	Value        string `toml:"value,omitempty"`
+	Delete       bool   `toml:"delete,omitempty"`
+	At           string `toml:"at"`
+	Added        string `toml:"added"`
+}
+
 // Viceroy represents viceroy specific configuration.
 type Viceroy struct {
 	LastChecked   string `toml:"last_checked"`
@@ -286,9 +712,35 @@ type Profiles map[string]*Profile
 
 // Profile represents a specific profile account.
 type Profile struct {
-	Default bool   `toml:"default"`
-	Email   string `toml:"email"`
-	Token   string `toml:"token"`
+	APIEndpoint   string          `toml:"api_endpoint,omitempty"`
+	Default       bool            `toml:"default"`
+	DeployMarkers *DeployMarkers  `toml:"deploy_markers,omitempty"`
+	Email         string          `toml:"email"`
+	Middleware    map[string]bool `toml:"middleware,omitempty"`
+	ReadOnly      bool            `toml:"read_only,omitempty"`
+	ServiceID     string          `toml:"service_id,omitempty"`
+	Token         string          `toml:"token"`
+}
+
+// DeployMarkers represents the observability integrations that should record
+// a deployment event whenever a service version is activated.
+type DeployMarkers struct {
+	Datadog *DatadogMarker `toml:"datadog,omitempty"`
+	Grafana *GrafanaMarker `toml:"grafana,omitempty"`
+}
+
+// DatadogMarker represents the configuration for emitting Datadog events.
+type DatadogMarker struct {
+	APIKey string   `toml:"api_key"`
+	Site   string   `toml:"site"` // e.g. datadoghq.com, datadoghq.eu
+	Tags   []string `toml:"tags"`
+}
+
+// GrafanaMarker represents the configuration for emitting Grafana annotations.
+type GrafanaMarker struct {
+	APIKey string   `toml:"api_key"`
+	Tags   []string `toml:"tags"`
+	URL    string   `toml:"url"`
 }
 
 // StarterKitLanguages represents language specific starter kits.
@@ -445,11 +897,32 @@ func (f *File) Read(path string, in io.Reader, out io.Writer, errLog fsterr.LogI
 		data = f.static
 	}
 
+	var templates interpolate.Templates
+	if readErr == nil {
+		var err error
+		// The commands of hooks and schedules are run by a shell later on, so
+		// their references are left for it to expand.
+		data, templates, err = interpolate.TOML(data, interpolate.Options{
+			Commands: true,
+			Skip:     []string{"hooks", "schedule"},
+		})
+		if err != nil {
+			errLog.Add(err)
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("error reading configuration file (%s): %w", path, err),
+				Remediation: "Check the environment variable and command references (${NAME} and $(command)) in the configuration file.",
+			}
+		}
+	}
+
 	// NOTE: In an attempt to prevent unexpected changes to the in-memory data
 	// representation we lock any operation that would cause the in-memory data
 	// to be updated.
 	mutex.Lock()
 	unmarshalErr := toml.Unmarshal(data, f)
+	if unmarshalErr == nil {
+		f.templates = templates
+	}
 	mutex.Unlock()
 
 	if unmarshalErr != nil {
@@ -581,7 +1054,15 @@ func (f *File) Write(path string) error {
 	if err != nil {
 		return fmt.Errorf("error creating config file: %w", err)
 	}
-	if err := toml.NewEncoder(fp).Encode(f); err != nil {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(f); err != nil {
+		return fmt.Errorf("error writing to config file: %w", err)
+	}
+	data, err := f.templates.Restore(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error writing to config file: %w", err)
+	}
+	if _, err := fp.Write(data); err != nil {
 		return fmt.Errorf("error writing to config file: %w", err)
 	}
 	if err := fp.Close(); err != nil {
@@ -591,30 +1072,201 @@ func (f *File) Write(path string) error {
 	return nil
 }
 
+// Redacted returns a copy of the configuration with all credentials (API
+// tokens, integration API keys, proxy credentials, hook commands and URLs, and
+// alias command lines, which often include a --token) replaced so it can be
+// safely shared, e.g. with Fastly support.
+func (f File) Redacted() File {
+	const redacted = "REDACTED"
+
+	r := f
+	r.static = nil
+
+	if f.LegacyUser.Token != "" {
+		r.LegacyUser.Token = redacted
+	}
+
+	if f.Network.Proxy != "" {
+		r.Network.Proxy = redactProxy(f.Network.Proxy, redacted)
+	}
+
+	if f.Aliases != nil {
+		r.Aliases = make(Aliases, len(f.Aliases))
+		for name := range f.Aliases {
+			r.Aliases[name] = redacted
+		}
+	}
+
+	if f.Profiles != nil {
+		r.Profiles = make(Profiles, len(f.Profiles))
+		for name, p := range f.Profiles {
+			if p == nil {
+				continue
+			}
+			cp := *p
+			if cp.Token != "" {
+				cp.Token = redacted
+			}
+			if m := p.DeployMarkers; m != nil {
+				cm := DeployMarkers{}
+				if m.Datadog != nil {
+					dd := *m.Datadog
+					dd.APIKey = redacted
+					cm.Datadog = &dd
+				}
+				if m.Grafana != nil {
+					g := *m.Grafana
+					g.APIKey = redacted
+					cm.Grafana = &g
+				}
+				cp.DeployMarkers = &cm
+			}
+			r.Profiles[name] = &cp
+		}
+	}
+
+	if f.Hooks != nil {
+		r.Hooks = make(Hooks, len(f.Hooks))
+		for event, h := range f.Hooks {
+			if h == nil {
+				continue
+			}
+			ch := Hook{}
+			if h.Command != "" {
+				ch.Command = redacted
+			}
+			if h.URL != "" {
+				ch.URL = redacted
+			}
+			r.Hooks[event] = &ch
+		}
+	}
+
+	if f.Schedules != nil {
+		r.Schedules = make(Schedules, len(f.Schedules))
+		for name, s := range f.Schedules {
+			if s == nil {
+				continue
+			}
+			cs := *s
+			if cs.Command != "" {
+				cs.Command = redacted
+			}
+			r.Schedules[name] = &cs
+		}
+	}
+
+	if f.DictionaryChanges != nil {
+		r.DictionaryChanges = make(DictionaryChanges, 0, len(f.DictionaryChanges))
+		for _, dc := range f.DictionaryChanges {
+			if dc == nil {
+				continue
+			}
+			cdc := *dc
+			if cdc.Value != "" {
+				cdc.Value = redacted
+			}
+			r.DictionaryChanges = append(r.DictionaryChanges, &cdc)
+		}
+	}
+
+	return r
+}
+
+// redactProxy replaces the credentials (userinfo) of a proxy URL. A value
+// that can't be parsed is replaced entirely, as it may still contain them.
+func redactProxy(proxy, redacted string) string {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return redacted
+	}
+	if u.User != nil {
+		u.User = url.User(redacted)
+	}
+	return u.String()
+}
+
 // Environment represents all of the configuration parameters that can come
 // from environment variables.
 type Environment struct {
 	Token    string
 	Endpoint string
+	// Path is the list of directories searched for plugins (PATH).
+	Path string
+	// NonInteractive is set when FASTLY_NONINTERACTIVE is set to anything
+	// other than a false value (e.g. 0 or false).
+	NonInteractive bool
+	// Tracing is the configuration of the OpenTelemetry tracing of the CLI's
+	// execution, read from the standard OTEL_* env vars.
+	Tracing TracingEnvironment
+}
+
+// TracingEnvironment represents the OpenTelemetry env vars that configure the
+// export of the spans of the CLI's execution.
+type TracingEnvironment struct {
+	Endpoint       string
+	TracesEndpoint string
+	Headers        string
+	Protocol       string
+	ServiceName    string
+	Disabled       bool
+	Parent         string
 }
 
 // Read populates the fields from the provided environment.
 func (e *Environment) Read(state map[string]string) {
 	e.Token = state[env.Token]
 	e.Endpoint = state[env.Endpoint]
+	e.Path = state["PATH"]
+	if v := state[env.NonInteractive]; v != "" {
+		b, err := strconv.ParseBool(v)
+		e.NonInteractive = err != nil || b
+	}
+	e.Tracing = TracingEnvironment{
+		Endpoint:       state[env.OTLPEndpoint],
+		TracesEndpoint: state[env.OTLPTracesEndpoint],
+		Headers:        state[env.OTLPHeaders],
+		Protocol:       state[env.OTLPProtocol],
+		ServiceName:    state[env.OTelServiceName],
+		Parent:         state[env.TraceParent],
+	}
+	e.Tracing.Disabled, _ = strconv.ParseBool(state[env.OTelSDKDisabled])
 }
 
 // Flag represents all of the configuration parameters that can be set with
 // explicit flags. Consumers should bind their flag values to these fields
 // directly.
 type Flag struct {
-	AcceptDefaults bool
-	AutoYes        bool
-	Endpoint       string
-	NonInteractive bool
-	Profile        string
-	Token          string
-	Verbose        bool
+	AcceptDefaults      bool
+	APITimeout          int
+	AutoYes             bool
+	CAFile              string
+	ConfirmDestructive  bool
+	ConfirmIrreversible bool
+	DebugHTTP           bool
+	Deterministic       bool
+	DryRun              bool
+	Endpoint            string
+	HumanSizes          bool
+	ISO8601             bool
+	LogFile             string
+	LogLevel            string
+	NoCache             bool
+	NonInteractive      bool
+	Output              string
+	Preset              string
+	Profile             string
+	Proxy               string
+	ReadOnly            bool
+	Record              string
+	Replay              string
+	Retry               int
+	RetrySet            bool
+	Timings             bool
+	Token               string
+	UTC                 bool
+	Verbose             bool
+	VerboseLevel        int
 }
 
 // This suggests our embedded config is unexpectedly faulty and so we should
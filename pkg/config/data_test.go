@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
@@ -413,3 +414,217 @@ func TestValidConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpoint(t *testing.T) {
+	profiles := config.Profiles{
+		"staging": &config.Profile{Default: true, APIEndpoint: "https://api.staging.example.com"},
+		"prod":    &config.Profile{},
+	}
+	for _, testcase := range []struct {
+		name       string
+		flag       config.Flag
+		env        config.Environment
+		wantURL    string
+		wantSource config.Source
+	}{
+		{
+			name:       "profile endpoint",
+			wantURL:    "https://api.staging.example.com",
+			wantSource: config.SourceFile,
+		},
+		{
+			name:       "profile without an endpoint",
+			flag:       config.Flag{Profile: "prod"},
+			wantURL:    "https://api.example.com",
+			wantSource: config.SourceFile,
+		},
+		{
+			name:       "environment overrides the profile",
+			env:        config.Environment{Endpoint: "http://localhost:8080"},
+			wantURL:    "http://localhost:8080",
+			wantSource: config.SourceEnvironment,
+		},
+		{
+			name:       "flag overrides the profile",
+			flag:       config.Flag{Endpoint: "http://localhost:9090"},
+			wantURL:    "http://localhost:9090",
+			wantSource: config.SourceFlag,
+		},
+		{
+			name:       "trailing slash is removed",
+			flag:       config.Flag{Endpoint: "http://localhost:9090/"},
+			wantURL:    "http://localhost:9090",
+			wantSource: config.SourceFlag,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			d := config.Data{Flag: testcase.flag, Env: testcase.env}
+			d.File.Profiles = profiles
+			d.File.Fastly.APIEndpoint = "https://api.example.com"
+			url, source := d.Endpoint()
+			testutil.AssertString(t, testcase.wantURL, url)
+			testutil.AssertEqual(t, testcase.wantSource, source)
+		})
+	}
+}
+
+// TestConfigInterpolation validates environment variable references in the
+// config file are expanded when read, but are preserved when the config is
+// written back to disk.
+func TestConfigInterpolation(t *testing.T) {
+	t.Setenv("FASTLY_TEST_TOKEN", "123")
+
+	b, err := os.ReadFile(filepath.Join("testdata", "config.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = append(b, []byte(`
+[profile.user]
+default = true
+email = "testing@fastly.com"
+token = "${FASTLY_TEST_TOKEN}"
+
+[hooks.notify]
+command = "echo $(date) ${FASTLY_TEST_UNSET}"
+`)...)
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, b, config.FilePermissions); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		f   config.File
+		out bytes.Buffer
+	)
+	if err := f.Read(configPath, strings.NewReader(""), &out, fsterr.Log); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "123", f.Profiles["user"].Token)
+	// Hook commands are left for the shell that runs them to expand.
+	testutil.AssertString(t, "echo $(date) ${FASTLY_TEST_UNSET}", f.Hooks["notify"].Command)
+
+	tree, err := toml.LoadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, "${FASTLY_TEST_TOKEN}", tree.GetPath([]string{"profile", "user", "token"}))
+
+	os.Unsetenv("FASTLY_TEST_TOKEN")
+	err = f.Read(configPath, strings.NewReader(""), &out, fsterr.Log)
+	testutil.AssertErrorContains(t, err, "environment variable FASTLY_TEST_TOKEN is not set")
+}
+
+func TestRetryAttempts(t *testing.T) {
+	five := 5
+	for _, testcase := range []struct {
+		name string
+		data config.Data
+		want int
+	}{
+		{
+			name: "default",
+			want: config.DefaultRetryAttempts,
+		},
+		{
+			name: "config file",
+			data: config.Data{File: config.File{Retry: config.Retry{Attempts: &five}}},
+			want: 5,
+		},
+		{
+			name: "flag overrides config file",
+			data: config.Data{
+				File: config.File{Retry: config.Retry{Attempts: &five}},
+				Flag: config.Flag{Retry: 0, RetrySet: true},
+			},
+			want: 0,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertEqual(t, testcase.want, testcase.data.RetryAttempts())
+		})
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		ttl       string
+		want      time.Duration
+		wantError string
+	}{
+		{
+			name: "disabled by default",
+		},
+		{
+			name: "config file",
+			ttl:  "5m",
+			want: 5 * time.Minute,
+		},
+		{
+			name:      "invalid",
+			ttl:       "soon",
+			wantError: "invalid cache ttl 'soon' in the config file",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			d := config.Data{File: config.File{Cache: config.Cache{TTL: testcase.ttl}}}
+			ttl, err := d.CacheTTL()
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.want, ttl)
+		})
+	}
+}
+
+func TestEnvironmentNonInteractive(t *testing.T) {
+	for value, want := range map[string]bool{
+		"":      false,
+		"0":     false,
+		"false": false,
+		"1":     true,
+		"true":  true,
+		"yes":   true,
+	} {
+		var e config.Environment
+		e.Read(map[string]string{"FASTLY_NONINTERACTIVE": value})
+		if e.NonInteractive != want {
+			t.Errorf("FASTLY_NONINTERACTIVE=%q: want %t, have %t", value, want, e.NonInteractive)
+		}
+	}
+}
+
+func TestUploadEncoding(t *testing.T) {
+	for _, testcase := range []struct {
+		compression string
+		want        string
+		wantError   string
+	}{
+		{want: config.DefaultUploadEncoding},
+		{compression: "br", want: "br"},
+		{compression: "none", want: "none"},
+		{compression: "zstd", wantError: "invalid upload compression 'zstd' in the config file"},
+	} {
+		d := config.Data{File: config.File{Upload: config.Upload{Compression: testcase.compression}}}
+		have, err := d.UploadEncoding()
+		testutil.AssertErrorContains(t, err, testcase.wantError)
+		testutil.AssertString(t, testcase.want, have)
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	d := config.Data{File: config.File{Network: config.Network{Proxy: "http://file.example.com", CAFile: "file.pem"}}}
+	testutil.AssertEqual(t, config.Network{Proxy: "http://file.example.com", CAFile: "file.pem"}, d.Network())
+
+	d.Flag.Proxy = "http://flag.example.com"
+	testutil.AssertEqual(t, config.Network{Proxy: "http://flag.example.com", CAFile: "file.pem"}, d.Network())
+}
+
+func TestAPITimeout(t *testing.T) {
+	var d config.Data
+	testutil.AssertEqual(t, time.Duration(0), d.APITimeout())
+
+	d.File.Network.APITimeout = 60
+	testutil.AssertEqual(t, time.Minute, d.APITimeout())
+
+	d.Flag.APITimeout = 5
+	testutil.AssertEqual(t, 5*time.Second, d.APITimeout())
+}
@@ -16,4 +16,49 @@ const (
 
 	// CustomerID is the env var we look in for a Customer ID.
 	CustomerID = "FASTLY_CUSTOMER_ID"
+
+	// NonInteractive is the env var we look in to run the CLI
+	// non-interactively, as if --non-interactive was set.
+	NonInteractive = "FASTLY_NONINTERACTIVE"
+
+	// SigningKeyPassword is the env var we look in for the password of the
+	// key used to sign Compute@Edge packages.
+	// gosec flagged this:
+	// G101 (CWE-798): Potential hardcoded credentials
+	// Disabling as we use the value in the command help output.
+	/* #nosec */
+	SigningKeyPassword = "FASTLY_SIGNING_KEY_PASSWORD"
+
+	// Session is the env var we look in for an identifier of the shell
+	// session, which --autoclone reuses clones within. It's only needed if the
+	// session can't be identified from the shell process (e.g. in CI).
+	Session = "FASTLY_SESSION"
+
+	// OTLPEndpoint is the standard OpenTelemetry env var we look in for the
+	// OTLP/HTTP endpoint that the spans of the CLI's execution are exported to.
+	OTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// OTLPTracesEndpoint is the standard OpenTelemetry env var we look in for
+	// the URL spans are exported to, which takes precedence over OTLPEndpoint.
+	OTLPTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	// OTLPHeaders is the standard OpenTelemetry env var we look in for the
+	// headers sent with exported spans (key=value pairs, comma separated).
+	OTLPHeaders = "OTEL_EXPORTER_OTLP_HEADERS"
+
+	// OTLPProtocol is the standard OpenTelemetry env var we look in for the
+	// OTLP transport. Only the HTTP transports are supported.
+	OTLPProtocol = "OTEL_EXPORTER_OTLP_PROTOCOL"
+
+	// OTelServiceName is the standard OpenTelemetry env var we look in for the
+	// service.name of exported spans.
+	OTelServiceName = "OTEL_SERVICE_NAME"
+
+	// OTelSDKDisabled is the standard OpenTelemetry env var that disables
+	// tracing when set to true.
+	OTelSDKDisabled = "OTEL_SDK_DISABLED"
+
+	// TraceParent is the env var we look in for the W3C traceparent of the
+	// span (e.g. a pipeline step) that the CLI's spans are children of.
+	TraceParent = "TRACEPARENT"
 )
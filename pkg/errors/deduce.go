@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -13,21 +14,24 @@ import (
 // Deduce attempts to deduce a RemediationError from a plain error. If the error
 // is already a RemediationError it is returned directly. Certain deep error
 // types, like a Fastly SDK HTTPError, are detected and converted in appropriate
-// cases to e.g. AuthRemediation. If no specific remediation can be suggested, a
-// remediation to file a bug is used.
+// cases to e.g. AuthRemediation (see LookupHint). If no specific remediation can
+// be suggested, a remediation to file a bug is used.
 func Deduce(err error) RemediationError {
-	var re RemediationError
+	var (
+		re        RemediationError
+		httpError *fastly.HTTPError
+	)
 	if errors.As(err, &re) {
-		return re // assume the useful suggestion is already baked-in
+		// Assume the useful suggestion is already baked-in, unless there isn't
+		// one for the API error it wraps.
+		if re.Remediation == "" && errors.As(re.Inner, &httpError) {
+			re.Remediation, _ = LookupHint(httpError)
+		}
+		return re
 	}
 
-	var httpError *fastly.HTTPError
 	if errors.As(err, &httpError) {
-		var remediation string
-		switch httpError.StatusCode {
-		case http.StatusUnauthorized:
-			remediation = AuthRemediation
-		}
+		remediation, _ := LookupHint(httpError)
 		return RemediationError{Inner: SimplifyFastlyError(*httpError), Remediation: remediation}
 	}
 
@@ -35,6 +39,11 @@ func Deduce(err error) RemediationError {
 		return RemediationError{Inner: err, Remediation: HostRemediation}
 	}
 
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return RemediationError{Inner: err, Remediation: TimeoutRemediation}
+	}
+
 	if t, ok := err.(interface{ Temporary() bool }); ok && t.Temporary() {
 		return RemediationError{Inner: err, Remediation: NetworkRemediation}
 	}
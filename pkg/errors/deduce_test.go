@@ -3,6 +3,7 @@ package errors_test
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"testing"
 
@@ -18,6 +19,7 @@ func TestDeduce(t *testing.T) {
 		http503         = &fastly.HTTPError{StatusCode: http.StatusInternalServerError}
 		http401         = &fastly.HTTPError{StatusCode: http.StatusUnauthorized}
 		wrappedNotExist = fmt.Errorf("couldn't do the thing: %w", os.ErrNotExist)
+		timeout         = &url.Error{Op: "Get", URL: "https://api.fastly.com/service", Err: isTimeout{}}
 	)
 
 	for _, testcase := range []struct {
@@ -50,6 +52,11 @@ func TestDeduce(t *testing.T) {
 			input: wrappedNotExist,
 			want:  errors.RemediationError{Inner: wrappedNotExist, Remediation: errors.HostRemediation},
 		},
+		{
+			name:  "timeout",
+			input: timeout,
+			want:  errors.RemediationError{Inner: timeout, Remediation: errors.TimeoutRemediation},
+		},
 		{
 			name:  "temporary network error",
 			input: isTemporary{fmt.Errorf("baz")},
@@ -67,3 +74,9 @@ func TestDeduce(t *testing.T) {
 type isTemporary struct{ error }
 
 func (isTemporary) Temporary() bool { return true }
+
+type isTimeout struct{}
+
+func (isTimeout) Error() string   { return "timeout" }
+func (isTimeout) Timeout() bool   { return true }
+func (isTimeout) Temporary() bool { return true }
@@ -30,6 +30,27 @@ var ErrNoToken = RemediationError{
 	Remediation: AuthRemediation,
 }
 
+// ErrReadOnly means a command tried to modify the Fastly account while running
+// in read-only mode.
+var ErrReadOnly = RemediationError{
+	Inner:       fmt.Errorf("read-only mode: refusing to make changes to the Fastly account"),
+	Remediation: ReadOnlyRemediation,
+}
+
+// ErrNonInteractive means a command needed input that can't be prompted for,
+// as the CLI is running non-interactively.
+var ErrNonInteractive = RemediationError{
+	Inner:       fmt.Errorf("input is required, but the CLI is running non-interactively"),
+	Remediation: NonInteractiveRemediation,
+}
+
+// ErrInterrupted means the user interrupted the command (e.g. with Ctrl-C)
+// before it finished.
+var ErrInterrupted = RemediationError{
+	Inner:       fmt.Errorf("the command was interrupted"),
+	Remediation: "Any results shown may be incomplete, and the command's changes may have only been partly made. Check the state of the affected resources before running the command again.",
+}
+
 // ErrNoServiceID means no --service-id or service_id package manifest value has
 // been provided.
 var ErrNoServiceID = RemediationError{
@@ -37,6 +58,14 @@ var ErrNoServiceID = RemediationError{
 	Remediation: ServiceIDRemediation,
 }
 
+// ErrServiceNameNotFound means no service has the name given by
+// --service-name.
+var ErrServiceNameNotFound = errors.New("error matching service name with available services")
+
+// ErrVersionNotFound means the service has no version with the number given by
+// --version.
+var ErrVersionNotFound = errors.New("specified service version not found")
+
 // ErrNoCustomerID means no --customer-id or FASTLY_CUSTOMER_ID environment
 // variable found.
 var ErrNoCustomerID = RemediationError{
@@ -93,6 +122,11 @@ var ErrParsingManifest = RemediationError{
 // walking the directory tree.
 var ErrStopWalk = errors.New("stop directory walking")
 
+// ErrAutoCloneDryRun means --autoclone=dry-run reported whether a clone was
+// needed and the command should stop without making any changes. It isn't
+// reported as an error.
+var ErrAutoCloneDryRun = errors.New("autoclone dry run complete")
+
 // ErrInvalidArchive means the package archive didn't contain a recognised
 // directory structure.
 var ErrInvalidArchive = RemediationError{
@@ -107,9 +141,15 @@ var ErrBuildStopped = RemediationError{
 	Remediation: "Remove or update the custom [scripts.build] in the fastly.toml manifest.",
 }
 
-// ErrInvalidVerboseJSONCombo means the user provided both a --verbose and
-// --json flag which are mutally exclusive behaviours.
-var ErrInvalidVerboseJSONCombo = RemediationError{
-	Inner:       fmt.Errorf("invalid flag combination, --verbose and --json"),
-	Remediation: "Use either --verbose or --json, not both.",
+// ErrConfirmationDeclined means the user answered no to a confirmation prompt.
+var ErrConfirmationDeclined = RemediationError{
+	Inner:       fmt.Errorf("operation cancelled by user"),
+	Remediation: "Rerun the command and confirm the prompt to continue.",
+}
+
+// ErrInvalidReplayFlags means neither or both of the --har and --ndjson flags
+// were given to `compute replay`.
+var ErrInvalidReplayFlags = RemediationError{
+	Inner:       fmt.Errorf("a single file of recorded requests is required"),
+	Remediation: "Provide either the --har or the --ndjson flag.",
 }
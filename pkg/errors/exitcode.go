@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// The exit codes of the CLI, which tell scripts what class of failure a
+// command had. They're documented in the CLI's help (see ExitCodes), so their
+// values must not change.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK = 0
+	// ExitError means the command failed for a reason without a more specific
+	// exit code.
+	ExitError = 1
+	// ExitValidation means the command's arguments or input were invalid, so
+	// it failed without making any changes.
+	ExitValidation = 2
+	// ExitAuth means the API token was missing, invalid or lacked permission.
+	ExitAuth = 3
+	// ExitNotFound means a resource the command needed doesn't exist.
+	ExitNotFound = 4
+	// ExitAPI means the API failed to handle the request, with a 5xx status,
+	// or couldn't be reached. The command may succeed if it's run again.
+	ExitAPI = 5
+	// ExitLocalIO means a local file or directory couldn't be read or written.
+	ExitLocalIO = 6
+	// ExitInterrupted means the user interrupted the command, which by
+	// convention is 128 plus the number of SIGINT.
+	ExitInterrupted = 130
+)
+
+// ExitCodes describes each exit code, in the order they're documented.
+var ExitCodes = []struct {
+	Code        int
+	Description string
+}{
+	{ExitOK, "Success"},
+	{ExitError, "Error without a more specific exit code"},
+	{ExitValidation, "Invalid arguments or input"},
+	{ExitAuth, "Missing, invalid or unauthorized API token"},
+	{ExitNotFound, "Resource not found"},
+	{ExitAPI, "API error (5xx) or API unreachable; may succeed if retried"},
+	{ExitLocalIO, "Local file or directory couldn't be read or written"},
+	{ExitInterrupted, "Interrupted"},
+}
+
+// ExitCode returns the exit code for the error a command returned, which is
+// ExitOK if it's nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	// The sentinel RemediationErrors are compared by their inner error, which
+	// is also matched when a command wraps it with a different remediation.
+	switch {
+	case errors.Is(err, ErrInterrupted.Inner):
+		return ExitInterrupted
+	case errors.Is(err, ErrNoToken.Inner):
+		return ExitAuth
+	case errors.Is(err, ErrNoServiceID.Inner),
+		errors.Is(err, ErrNoCustomerID.Inner),
+		errors.Is(err, ErrNoID.Inner),
+		errors.Is(err, ErrReadOnly.Inner),
+		errors.Is(err, ErrNonInteractive.Inner),
+		errors.Is(err, ErrInvalidReplayFlags.Inner),
+		errors.Is(err, ErrIncompatibleServeFlags.Inner):
+		return ExitValidation
+	case errors.Is(err, ErrServiceNameNotFound),
+		errors.Is(err, ErrVersionNotFound):
+		return ExitNotFound
+	case errors.Is(err, ErrReadingManifest.Inner),
+		errors.Is(err, ErrParsingManifest.Inner):
+		return ExitLocalIO
+	}
+
+	var httpError *fastly.HTTPError
+	if errors.As(err, &httpError) {
+		switch code := httpError.StatusCode; {
+		case code == http.StatusUnauthorized, code == http.StatusForbidden:
+			return ExitAuth
+		case code == http.StatusNotFound:
+			return ExitNotFound
+		case code == http.StatusBadRequest, code == http.StatusUnprocessableEntity:
+			return ExitValidation
+		case code >= 500:
+			return ExitAPI
+		}
+		return ExitError
+	}
+
+	// The API isn't reached through a local file, so a path error is local
+	// I/O, whereas any other network error means the API is unreachable.
+	var pathError *fs.PathError
+	if errors.As(err, &pathError) || errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return ExitLocalIO
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ExitAPI
+	}
+
+	// Invalid arguments are reported, by kingpin and the commands' own
+	// validation, with a common prefix rather than a common error.
+	if strings.HasPrefix(err.Error(), "error parsing arguments:") {
+		return ExitValidation
+	}
+	return ExitError
+}
@@ -0,0 +1,116 @@
+package errors_test
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestExitCode(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input error
+		want  int
+	}{
+		{
+			name:  "nil",
+			input: nil,
+			want:  errors.ExitOK,
+		},
+		{
+			name:  "plain error",
+			input: fmt.Errorf("foo"),
+			want:  errors.ExitError,
+		},
+		{
+			name:  "interrupted",
+			input: errors.ErrInterrupted,
+			want:  errors.ExitInterrupted,
+		},
+		{
+			name:  "no token",
+			input: errors.ErrNoToken,
+			want:  errors.ExitAuth,
+		},
+		{
+			name:  "API 401",
+			input: fmt.Errorf("error fetching: %w", &fastly.HTTPError{StatusCode: http.StatusUnauthorized}),
+			want:  errors.ExitAuth,
+		},
+		{
+			name:  "API 403 with a remediation",
+			input: errors.RemediationError{Inner: &fastly.HTTPError{StatusCode: http.StatusForbidden}},
+			want:  errors.ExitAuth,
+		},
+		{
+			name:  "API 404",
+			input: &fastly.HTTPError{StatusCode: http.StatusNotFound},
+			want:  errors.ExitNotFound,
+		},
+		{
+			name:  "API 422",
+			input: &fastly.HTTPError{StatusCode: http.StatusUnprocessableEntity},
+			want:  errors.ExitValidation,
+		},
+		{
+			name:  "API 409",
+			input: &fastly.HTTPError{StatusCode: http.StatusConflict},
+			want:  errors.ExitError,
+		},
+		{
+			name:  "API 503",
+			input: &fastly.HTTPError{StatusCode: http.StatusServiceUnavailable},
+			want:  errors.ExitAPI,
+		},
+		{
+			name:  "API unreachable",
+			input: &url.Error{Op: "Get", URL: "https://api.fastly.com/service", Err: isTimeout{}},
+			want:  errors.ExitAPI,
+		},
+		{
+			name:  "service name not found",
+			input: errors.ErrServiceNameNotFound,
+			want:  errors.ExitNotFound,
+		},
+		{
+			name:  "version not found",
+			input: fmt.Errorf("%w: 5", errors.ErrVersionNotFound),
+			want:  errors.ExitNotFound,
+		},
+		{
+			name:  "no service ID",
+			input: errors.ErrNoServiceID,
+			want:  errors.ExitValidation,
+		},
+		{
+			name:  "read-only with a different remediation",
+			input: errors.RemediationError{Inner: fmt.Errorf("%w: foo", errors.ErrReadOnly.Inner)},
+			want:  errors.ExitValidation,
+		},
+		{
+			name:  "invalid arguments",
+			input: fmt.Errorf("error parsing arguments: required flag --version not provided"),
+			want:  errors.ExitValidation,
+		},
+		{
+			name:  "file not found",
+			input: &fs.PathError{Op: "open", Path: "fastly.toml", Err: fs.ErrNotExist},
+			want:  errors.ExitLocalIO,
+		},
+		{
+			name:  "permission denied",
+			input: fmt.Errorf("error writing file: %w", fs.ErrPermission),
+			want:  errors.ExitLocalIO,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertEqual(t, testcase.want, errors.ExitCode(testcase.input))
+		})
+	}
+}
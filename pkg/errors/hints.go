@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Hint maps an error returned by the Fastly API to remediation text, so that
+// every command suggests the same next step for the same problem.
+type Hint struct {
+	// StatusCode is the HTTP status code the hint applies to. Zero matches any
+	// status code.
+	StatusCode int
+	// Contains are case-insensitive substrings of the API's error codes,
+	// titles and details, any of which match. An empty list matches any message.
+	Contains []string
+	// Remediation is the suggestion displayed to the user.
+	Remediation string
+}
+
+// Match reports whether the hint applies to the API error.
+func (h Hint) Match(httpError *fastly.HTTPError) bool {
+	if h.StatusCode != 0 && h.StatusCode != httpError.StatusCode {
+		return false
+	}
+	if len(h.Contains) == 0 {
+		return true
+	}
+	var msgs []string
+	for _, e := range httpError.Errors {
+		msgs = append(msgs, e.Code, e.Title, e.Detail)
+	}
+	msg := strings.ToLower(strings.Join(msgs, "\n"))
+	for _, s := range h.Contains {
+		if strings.Contains(msg, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenExpiredRemediation suggests replacing an expired API token.
+var TokenExpiredRemediation = strings.Join([]string{
+	"Your Fastly API token has expired.",
+	"Create a new token via `fastly auth-token create` or the Fastly web interface,",
+	"then store it with `fastly profile update`.",
+}, " ")
+
+// TokenScopeRemediation suggests using a token with a broader scope.
+var TokenScopeRemediation = strings.Join([]string{
+	"Your Fastly API token doesn't have the scope or permissions required for this operation",
+	"(e.g. a 'purge_select' token can't modify a service).",
+	"Check the token's scope via `fastly auth-token describe` and use a token with the 'global' scope,",
+	"or ask an account superuser or engineer to grant you access to the service.",
+}, " ")
+
+// LockedVersionRemediation suggests cloning a locked service version.
+var LockedVersionRemediation = strings.Join([]string{
+	"The service version is locked (or active) and can't be modified.",
+	"Clone it via `fastly service-version clone` and modify the new version,",
+	"or pass the --autoclone flag to clone it automatically.",
+}, " ")
+
+// EntitlementRemediation suggests asking Fastly to enable a feature.
+var EntitlementRemediation = strings.Join([]string{
+	"This feature isn't enabled for your Fastly account.",
+	"Contact your account manager or Fastly support (support@fastly.com) to enable it.",
+}, " ")
+
+// Hints are the built-in remediation hints, checked in order. The first hint
+// that matches an API error is used.
+var Hints = []Hint{
+	{StatusCode: http.StatusUnauthorized, Contains: []string{"expired"}, Remediation: TokenExpiredRemediation},
+	{StatusCode: http.StatusUnauthorized, Remediation: AuthRemediation},
+	{Contains: []string{"entitlement", "not entitled", "feature is not enabled", "not enabled for your account"}, Remediation: EntitlementRemediation},
+	{Contains: []string{"version is locked", "locked version", "version locked", "cannot modify active", "can't modify active"}, Remediation: LockedVersionRemediation},
+	{StatusCode: http.StatusForbidden, Remediation: TokenScopeRemediation},
+}
+
+var (
+	registeredHints []Hint
+	hintsMutex      sync.Mutex
+)
+
+// RegisterHint adds a remediation hint that's checked before the built-in
+// Hints, so a package can provide more specific guidance for the errors of
+// the API endpoints it uses.
+func RegisterHint(h Hint) {
+	hintsMutex.Lock()
+	defer hintsMutex.Unlock()
+	registeredHints = append(registeredHints, h)
+}
+
+// LookupHint returns the remediation for the API error, if a registered or
+// built-in hint matches it.
+func LookupHint(httpError *fastly.HTTPError) (string, bool) {
+	hintsMutex.Lock()
+	hints := append(append([]Hint{}, registeredHints...), Hints...)
+	hintsMutex.Unlock()
+
+	for _, h := range hints {
+		if h.Match(httpError) {
+			return h.Remediation, true
+		}
+	}
+	return "", false
+}
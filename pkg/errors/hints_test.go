@@ -0,0 +1,76 @@
+package errors_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestLookupHint(t *testing.T) {
+	apiError := func(status int, title, detail string) *fastly.HTTPError {
+		return &fastly.HTTPError{
+			StatusCode: status,
+			Errors:     []*fastly.ErrorObject{{Title: title, Detail: detail}},
+		}
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		input *fastly.HTTPError
+		want  string
+	}{
+		{
+			name:  "expired token",
+			input: apiError(http.StatusUnauthorized, "Unauthorized", "Token has expired"),
+			want:  errors.TokenExpiredRemediation,
+		},
+		{
+			name:  "invalid token",
+			input: apiError(http.StatusUnauthorized, "Unauthorized", ""),
+			want:  errors.AuthRemediation,
+		},
+		{
+			name:  "token scope",
+			input: apiError(http.StatusForbidden, "Forbidden", "Insufficient permissions"),
+			want:  errors.TokenScopeRemediation,
+		},
+		{
+			name:  "locked version",
+			input: apiError(http.StatusConflict, "Bad request", "Version is locked"),
+			want:  errors.LockedVersionRemediation,
+		},
+		{
+			name:  "missing entitlement",
+			input: apiError(http.StatusForbidden, "Forbidden", "Your account is not entitled to use this feature"),
+			want:  errors.EntitlementRemediation,
+		},
+		{
+			name:  "no hint",
+			input: apiError(http.StatusInternalServerError, "Internal Server Error", ""),
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			have, _ := errors.LookupHint(testcase.input)
+			testutil.AssertString(t, testcase.want, have)
+		})
+	}
+}
+
+func TestRegisterHint(t *testing.T) {
+	input := &fastly.HTTPError{
+		StatusCode: http.StatusForbidden,
+		Errors:     []*fastly.ErrorObject{{Title: "Forbidden", Detail: "Reticulation quota exceeded"}},
+	}
+	errors.RegisterHint(errors.Hint{
+		Contains:    []string{"reticulation quota"},
+		Remediation: "Reticulate fewer splines.",
+	})
+
+	// Registered hints take precedence over the built-in hints, and are used
+	// by Deduce for RemediationErrors that have no remediation of their own.
+	have := errors.Deduce(errors.RemediationError{Inner: input})
+	testutil.AssertString(t, "Reticulate fewer splines.", have.Remediation)
+}
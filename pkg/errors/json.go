@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// JSONError wraps the error of a command that was asked to render its output
+// as JSON (via its --json flag or --output=json), so that the error is
+// reported as JSON too (see StructuredError) instead of free text.
+type JSONError struct {
+	Err error
+}
+
+// Unwrap returns the wrapped error.
+func (e JSONError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the wrapped error's string.
+func (e JSONError) Error() string {
+	return e.Err.Error()
+}
+
+// StructuredError is the machine-readable form of an error.
+type StructuredError struct {
+	// Code is the exit code of the CLI (see ExitCode).
+	Code int `json:"code"`
+	// Message is the error, as it's displayed to users.
+	Message string `json:"message"`
+	// APIStatus is the HTTP status of the failed API request, if any.
+	APIStatus int `json:"api_status,omitempty"`
+	// RequestID is the ID of the failed API request, if any, which Fastly
+	// support can use to find it.
+	RequestID string `json:"request_id,omitempty"`
+	// Remediation suggests how to resolve the error.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// NewStructuredError returns the structured form of err. The status and ID of
+// the last API request that failed are given by the caller, as they're
+// recorded by the API client's transport, and only the status of an API error
+// that err wraps takes priority.
+func NewStructuredError(err error, apiStatus int, requestID string) StructuredError {
+	re := Deduce(err)
+	s := StructuredError{
+		Code:        ExitCode(err),
+		APIStatus:   apiStatus,
+		RequestID:   requestID,
+		Remediation: re.Remediation,
+	}
+	if re.Inner != nil {
+		s.Message = re.Inner.Error()
+	}
+	var httpError *fastly.HTTPError
+	if errors.As(err, &httpError) {
+		s.APIStatus = httpError.StatusCode
+	}
+	return s
+}
+
+// Print writes the error to w as a JSON object with a single "error" field.
+func (s StructuredError) Print(w io.Writer) {
+	// Remediations contain URLs, which shouldn't be escaped for HTML.
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(struct {
+		Error StructuredError `json:"error"`
+	}{s})
+}
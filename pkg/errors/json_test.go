@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestStructuredError(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		err       error
+		apiStatus int
+		requestID string
+		want      string
+	}{
+		{
+			name: "plain error",
+			err:  fmt.Errorf("foo"),
+			want: `{"error":{"code":1,"message":"foo","remediation":"` + errors.BugRemediation + `"}}`,
+		},
+		{
+			name: "remediation error",
+			err:  errors.ErrNoToken,
+			want: `{"error":{"code":3,"message":"no token provided","remediation":"` + errors.AuthRemediation + `"}}`,
+		},
+		{
+			name:      "API error",
+			err:       fmt.Errorf("error listing versions: %w", &fastly.HTTPError{StatusCode: http.StatusNotFound}),
+			apiStatus: http.StatusServiceUnavailable,
+			requestID: "req-123",
+			want:      `{"error":{"code":4,"message":"the Fastly API returned 404 Not Found","api_status":404,"request_id":"req-123"}}`,
+		},
+		{
+			name:      "failed API request",
+			err:       fmt.Errorf("error from API: 503 Service Unavailable"),
+			apiStatus: http.StatusServiceUnavailable,
+			requestID: "req-456",
+			want:      `{"error":{"code":1,"message":"error from API: 503 Service Unavailable","api_status":503,"request_id":"req-456","remediation":"` + errors.BugRemediation + `"}}`,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			errors.NewStructuredError(testcase.err, testcase.apiStatus, testcase.requestID).Print(&buf)
+			testutil.AssertString(t, testcase.want+"\n", buf.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -53,6 +54,9 @@ type LogEntries []LogEntry
 
 // Add adds a new log entry.
 func (l *LogEntries) Add(err error) {
+	if errors.Is(err, ErrAutoCloneDryRun) {
+		return
+	}
 	logMutex.Lock()
 	*l = append(*l, createLogEntry(err))
 	logMutex.Unlock()
@@ -60,6 +64,9 @@ func (l *LogEntries) Add(err error) {
 
 // AddWithContext adds a new log entry with extra contextual data.
 func (l *LogEntries) AddWithContext(err error, ctx map[string]interface{}) {
+	if errors.Is(err, ErrAutoCloneDryRun) {
+		return
+	}
 	le := createLogEntry(err)
 	le.Context = ctx
 
@@ -200,11 +207,23 @@ func createLogEntry(err error) LogEntry {
 		Err:  err,
 	}
 
-	_, file, line, ok := runtime.Caller(2)
-	if ok {
-		le.Caller = map[string]interface{}{
-			"FILE": file[strings.Index(file, "/pkg/"):],
-			"LINE": line,
+	// The caller is the first frame outside of an Add method, so entries added
+	// via a type that wraps LogEntries still record where the error occurred.
+	pcs := make([]uintptr, 8)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasSuffix(frame.Function, ".Add") && !strings.HasSuffix(frame.Function, ".AddWithContext") {
+			if i := strings.Index(frame.File, "/pkg/"); i >= 0 {
+				le.Caller = map[string]interface{}{
+					"FILE": frame.File[i:],
+					"LINE": frame.Line,
+				}
+			}
+			break
+		}
+		if !more {
+			break
 		}
 	}
 
@@ -206,3 +206,47 @@ func TestLogPersistLogRotation(t *testing.T) {
 
 	testutil.AssertEqual(t, wanttrim, havetrim)
 }
+
+func TestReadLog(t *testing.T) {
+	runs, err := errors.ReadLog(filepath.Join("testdata", "errors-expected.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, 2, len(runs))
+	testutil.AssertString(t, "fastly command two --example", runs[1].Command)
+	testutil.AssertEqual(t, 4, len(runs[1].Errors))
+	testutil.AssertEqual(t, errors.LoggedError{
+		Error:   "qux",
+		File:    "/pkg/errors/log_test.go",
+		Line:    76,
+		Context: map[string]string{"beep": "boop", "nums": "123", "this": "that"},
+	}, runs[1].Errors[3])
+
+	// Entries round trip through Persist, including their time (which has a
+	// monotonic clock reading when it's the current time) and errors spanning
+	// several lines.
+	path := filepath.Join(t.TempDir(), "errors.log")
+	now := time.Now()
+	errors.Now = func() time.Time { return now }
+	defer func() { errors.Now = time.Now }()
+
+	le := new(errors.LogEntries)
+	le.Add(fmt.Errorf("error parsing arguments: 2 problems with the flags:\n\n\t- foo\n\t- bar"))
+	le.AddWithContext(fmt.Errorf("Token abc123 is invalid"), map[string]interface{}{"Service ID": "123"})
+	if err := le.Persist(path, []string{"service", "list", "--token", "abc123"}); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err = errors.ReadLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, 1, len(runs))
+	testutil.AssertEqual(t, true, runs[0].Time.Equal(now))
+	testutil.AssertString(t, "error parsing arguments: 2 problems with the flags:\n\n\t- foo\n\t- bar", runs[0].Errors[0].Error)
+	testutil.AssertEqual(t, map[string]string{"Service ID": "123"}, runs[0].Errors[1].Context)
+
+	sanitized := runs[0].Sanitize()
+	testutil.AssertString(t, "fastly service list --token REDACTED", sanitized.Command)
+	testutil.AssertString(t, "Token REDACTED is invalid", sanitized.Errors[1].Error)
+}
@@ -0,0 +1,156 @@
+package errors
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logSeparator ends the record of each run in the error log.
+const logSeparator = "------------------------------"
+
+// logTimeLayout is the layout of the timestamps written by Persist, which are
+// formatted by time.Time.String.
+const logTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// LoggedRun is the record, read from the error log, of a CLI run that logged
+// errors.
+type LoggedRun struct {
+	Command string        `json:"command"`
+	Time    time.Time     `json:"time"`
+	Errors  []LoggedError `json:"errors"`
+}
+
+// LoggedError is an error logged by a run, with where it occurred and the
+// context it was logged with (see LogEntries.AddWithContext).
+type LoggedError struct {
+	Time    time.Time         `json:"time"`
+	Error   string            `json:"error"`
+	File    string            `json:"file,omitempty"`
+	Line    int               `json:"line,omitempty"`
+	Context map[string]string `json:"context,omitempty"`
+}
+
+// Sanitize returns the run with any tokens in its command, errors and context
+// redacted (see FilterToken).
+func (r LoggedRun) Sanitize() LoggedRun {
+	s := LoggedRun{Command: FilterToken(r.Command), Time: r.Time}
+	for _, e := range r.Errors {
+		se := e
+		se.Error = FilterToken(e.Error)
+		if e.Context != nil {
+			se.Context = make(map[string]string, len(e.Context))
+			for k, v := range e.Context {
+				se.Context[k] = FilterToken(v)
+			}
+		}
+		s.Errors = append(s.Errors, se)
+	}
+	return s
+}
+
+// ReadLog returns the runs recorded in the error log at logPath (see Persist),
+// oldest first.
+func ReadLog(logPath string) ([]LoggedRun, error) {
+	f, err := os.Open(filepath.Clean(logPath))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // #nosec G307
+
+	var (
+		runs    []LoggedRun
+		run     *LoggedRun
+		entry   *LoggedError
+		section string
+	)
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case line == logSeparator:
+			run, entry, section = nil, nil, ""
+			continue
+		case line == "COMMAND:", line == "TIMESTAMP:", line == "ERROR:", line == "FILE:", line == "LINE:":
+			section = strings.TrimSuffix(line, ":")
+			if section == "COMMAND" {
+				runs = append(runs, LoggedRun{})
+				run, entry = &runs[len(runs)-1], nil
+			}
+			if section == "TIMESTAMP" && run != nil {
+				run.Errors = append(run.Errors, LoggedError{})
+				entry = &run.Errors[len(run.Errors)-1]
+			}
+			continue
+		}
+		if run == nil {
+			continue
+		}
+
+		// Context is indented, and follows the error's other sections.
+		if k, v, ok := strings.Cut(strings.TrimPrefix(line, "  "), ": "); ok && entry != nil && strings.HasPrefix(line, "  ") && section != "COMMAND" {
+			if entry.Context == nil {
+				entry.Context = make(map[string]string)
+			}
+			entry.Context[k] = v
+			section = ""
+			continue
+		}
+
+		switch section {
+		case "COMMAND":
+			if line != "" {
+				run.Command = line
+			}
+		case "TIMESTAMP":
+			if line != "" && entry != nil {
+				entry.Time = parseLogTime(line)
+				if run.Time.IsZero() {
+					run.Time = entry.Time
+				}
+			}
+		case "ERROR":
+			// An error can span several lines, including blank ones.
+			if entry != nil {
+				if entry.Error == "" {
+					entry.Error = line
+				} else {
+					entry.Error += "\n" + line
+				}
+			}
+		case "FILE":
+			if line != "" && entry != nil {
+				entry.File = line
+			}
+		case "LINE":
+			if line != "" && entry != nil {
+				entry.Line, _ = strconv.Atoi(line)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range runs {
+		for j := range runs[i].Errors {
+			runs[i].Errors[j].Error = strings.TrimSpace(runs[i].Errors[j].Error)
+		}
+	}
+	return runs, nil
+}
+
+// parseLogTime parses a timestamp written by Persist, dropping the monotonic
+// clock reading that time.Time.String appends. It returns the zero time if
+// the timestamp can't be parsed.
+func parseLogTime(s string) time.Time {
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	t, _ := time.Parse(logTimeLayout, s)
+	return t
+}
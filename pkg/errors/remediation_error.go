@@ -62,6 +62,12 @@ var NetworkRemediation = strings.Join([]string{
 	"Please verify your network connection and DNS configuration, and try again.",
 }, " ")
 
+// TimeoutRemediation explains how to allow API requests more time.
+var TimeoutRemediation = strings.Join([]string{
+	"The request took longer than the API timeout.",
+	"Increase it with the --api-timeout flag or the [network] api_timeout setting of the config file (0 means no timeout).",
+}, " ")
+
 // HostRemediation suggests there might be an issue with the local host.
 var HostRemediation = strings.Join([]string{
 	"This error may be caused by a problem with your host environment, for example",
@@ -149,5 +155,18 @@ var ComputeBuildRemediation = strings.Join([]string{
 // free trial feature flag.
 var ComputeTrialRemediation = "For more help with this error see fastly.help/cli/ecp-feature"
 
+// ReadOnlyRemediation explains how read-only mode is enabled.
+var ReadOnlyRemediation = strings.Join([]string{
+	"Read-only mode is enabled by the --read-only flag or the read_only setting of the current profile.",
+	"Use a profile without read_only = true to make changes.",
+}, " ")
+
+// NonInteractiveRemediation explains how to provide input that would otherwise
+// be prompted for.
+var NonInteractiveRemediation = strings.Join([]string{
+	"Provide the value with the command's flags instead.",
+	"Non-interactive mode is enabled by the --non-interactive flag or the FASTLY_NONINTERACTIVE environment variable.",
+}, " ")
+
 // ProfileRemediation suggests no profiles exist.
 var ProfileRemediation = "Run `fastly profile create <NAME>` to create a profile, or `fastly profile list` to view available profiles (at least one profile should be set as 'default')."
@@ -0,0 +1,5 @@
+// Package hooks triggers user-defined notification hooks, configured in the
+// CLI application configuration file, after selected commands succeed. It also
+// records deploy markers in the observability integrations (e.g. Datadog,
+// Grafana) configured for the current profile.
+package hooks
@@ -0,0 +1,153 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+const (
+	// PostActivate is triggered after a service version is activated.
+	PostActivate = "post-activate"
+	// PostDeploy is triggered after a Compute@Edge package is deployed.
+	PostDeploy = "post-deploy"
+	// PostPurgeAll is triggered after all content for a service is purged.
+	PostPurgeAll = "post-purge-all"
+)
+
+// Events is the list of supported hook events.
+var Events = []string{PostActivate, PostDeploy, PostPurgeAll}
+
+// Payload is the JSON document sent to a hook.
+type Payload struct {
+	CLIVersion     string `json:"cli_version"`
+	Command        string `json:"command"`
+	Event          string `json:"event"`
+	ServiceID      string `json:"service_id,omitempty"`
+	ServiceVersion int    `json:"service_version,omitempty"`
+	Timestamp      string `json:"timestamp"`
+}
+
+// Run triggers the hook configured for the given event, if any, along with
+// any deploy markers configured for the current profile.
+//
+// When --dry-run is set, the hook and markers are only displayed, as they'd
+// otherwise act on a change that wasn't made.
+//
+// NOTE: The command that triggered the hook has already succeeded, so a
+// failing hook is reported as a warning rather than returned as an error.
+func Run(event string, p Payload, globals *config.Data, out io.Writer) {
+	p.CLIVersion = revision.AppVersion
+	p.Event = event
+	p.Timestamp = globals.Clock.Now().UTC().Format(time.RFC3339)
+
+	if event == PostActivate || event == PostDeploy {
+		markers(p, globals, out)
+	}
+
+	hook, ok := globals.File.Hooks[event]
+	if !ok || hook == nil {
+		return
+	}
+
+	if globals.Flag.DryRun {
+		if hook.URL != "" {
+			fmt.Fprintf(out, "DRY RUN: %s hook: POST %s\n", event, hook.URL)
+		}
+		if hook.Command != "" {
+			fmt.Fprintf(out, "DRY RUN: %s hook: %s\n", event, hook.Command)
+		}
+		return
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		globals.ErrLog.Add(err)
+		return
+	}
+
+	if hook.URL != "" {
+		if err := post(hook.URL, data, nil, globals.HTTPClient); err != nil {
+			globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Event": event,
+				"URL":   hook.URL,
+			})
+			text.Warning(out, "The %s hook request failed: %s", event, err)
+		} else if globals.Verbose() {
+			text.Info(out, "Triggered %s hook: %s", event, hook.URL)
+		}
+	}
+
+	if hook.Command != "" {
+		if err := command(hook.Command, data, p, out); err != nil {
+			globals.ErrLog.AddWithContext(err, map[string]interface{}{
+				"Event":   event,
+				"Command": hook.Command,
+			})
+			text.Warning(out, "The %s hook command failed: %s", event, err)
+		} else if globals.Verbose() {
+			text.Info(out, "Triggered %s hook: %s", event, hook.Command)
+		}
+	}
+}
+
+// post sends the JSON data to the given URL.
+func post(url string, data []byte, headers map[string]string, c api.HTTPClient) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", useragent.Name)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("expected a 2xx response, received '%s'", resp.Status)
+	}
+	return nil
+}
+
+// command executes the hook command via a subprocess shell, providing the
+// payload on stdin and its key fields as environment variables.
+func command(c string, data []byte, p Payload, out io.Writer) error {
+	name, args := "sh", []string{"-c", c}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd.exe", []string{"/C", c}
+	}
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the command is defined by the user in their own config.
+	/* #nosec */
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(),
+		"FASTLY_HOOK_EVENT="+p.Event,
+		"FASTLY_HOOK_COMMAND="+p.Command,
+		"FASTLY_HOOK_SERVICE_ID="+p.ServiceID,
+		fmt.Sprintf("FASTLY_HOOK_SERVICE_VERSION=%d", p.ServiceVersion),
+	)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
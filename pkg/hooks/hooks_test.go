@@ -0,0 +1,203 @@
+package hooks_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/clock"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/hooks"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+type mockHTTPClient struct {
+	req    *http.Request
+	reqs   []*http.Request
+	body   []byte
+	status int
+}
+
+func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.req = req
+	c.reqs = append(c.reqs, req)
+	c.body, _ = io.ReadAll(req.Body)
+	return &http.Response{
+		Body:       io.NopCloser(strings.NewReader("")),
+		Status:     http.StatusText(c.status),
+		StatusCode: c.status,
+	}, nil
+}
+
+func TestRunURL(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		event      string
+		status     int
+		wantCalled bool
+		wantOutput string
+	}{
+		{
+			name:       "hook triggered",
+			event:      hooks.PostActivate,
+			status:     http.StatusOK,
+			wantCalled: true,
+		},
+		{
+			name:       "hook not configured for event",
+			event:      hooks.PostPurgeAll,
+			status:     http.StatusOK,
+			wantCalled: false,
+		},
+		{
+			name:       "hook failure is reported as a warning",
+			event:      hooks.PostActivate,
+			status:     http.StatusInternalServerError,
+			wantCalled: true,
+			wantOutput: "The post-activate hook request failed",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var out bytes.Buffer
+			client := &mockHTTPClient{status: testcase.status}
+			globals := &config.Data{
+				ErrLog:     fsterr.MockLog{},
+				HTTPClient: client,
+				File: config.File{
+					Hooks: config.Hooks{
+						hooks.PostActivate: {URL: "https://example.com/hook"},
+					},
+				},
+			}
+
+			hooks.Run(testcase.event, hooks.Payload{
+				Command:        "service-version activate",
+				ServiceID:      "123",
+				ServiceVersion: 2,
+			}, globals, &out)
+
+			testutil.AssertBool(t, testcase.wantCalled, client.req != nil)
+			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+			if !testcase.wantCalled {
+				return
+			}
+
+			testutil.AssertString(t, "https://example.com/hook", client.req.URL.String())
+			testutil.AssertString(t, "application/json", client.req.Header.Get("Content-Type"))
+
+			var p hooks.Payload
+			if err := json.Unmarshal(client.body, &p); err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertString(t, hooks.PostActivate, p.Event)
+			testutil.AssertString(t, "service-version activate", p.Command)
+			testutil.AssertString(t, "123", p.ServiceID)
+			testutil.AssertEqual(t, 2, p.ServiceVersion)
+		})
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook command uses a POSIX shell")
+	}
+
+	var out bytes.Buffer
+	globals := &config.Data{
+		ErrLog: fsterr.MockLog{},
+		File: config.File{
+			Hooks: config.Hooks{
+				hooks.PostDeploy: {Command: `echo "$FASTLY_HOOK_EVENT $FASTLY_HOOK_SERVICE_ID" && cat`},
+			},
+		},
+	}
+
+	hooks.Run(hooks.PostDeploy, hooks.Payload{
+		Command:   "compute deploy",
+		ServiceID: "123",
+	}, globals, &out)
+
+	testutil.AssertStringContains(t, out.String(), "post-deploy 123\n")
+	testutil.AssertStringContains(t, out.String(), `"event":"post-deploy"`)
+}
+
+func TestRunMarkers(t *testing.T) {
+	var out bytes.Buffer
+	client := &mockHTTPClient{status: http.StatusAccepted}
+	globals := &config.Data{
+		Clock:      clock.Fixed(clock.Epoch),
+		ErrLog:     fsterr.MockLog{},
+		HTTPClient: client,
+		File: config.File{
+			Profiles: config.Profiles{
+				"user": &config.Profile{
+					Default: true,
+					DeployMarkers: &config.DeployMarkers{
+						Datadog: &config.DatadogMarker{APIKey: "dd-key", Site: "datadoghq.eu"},
+						Grafana: &config.GrafanaMarker{APIKey: "gf-key", URL: "https://grafana.example.com/"},
+					},
+				},
+			},
+		},
+	}
+
+	hooks.Run(hooks.PostPurgeAll, hooks.Payload{ServiceID: "123"}, globals, &out)
+	testutil.AssertEqual(t, 0, len(client.reqs))
+
+	hooks.Run(hooks.PostActivate, hooks.Payload{
+		Command:        "service-version activate",
+		ServiceID:      "123",
+		ServiceVersion: 2,
+	}, globals, &out)
+
+	testutil.AssertEqual(t, 2, len(client.reqs))
+	testutil.AssertString(t, "https://api.datadoghq.eu/api/v1/events", client.reqs[0].URL.String())
+	testutil.AssertString(t, "dd-key", client.reqs[0].Header.Get("DD-API-KEY"))
+	testutil.AssertString(t, "https://grafana.example.com/api/annotations", client.reqs[1].URL.String())
+	testutil.AssertString(t, "Bearer gf-key", client.reqs[1].Header.Get("Authorization"))
+	testutil.AssertStringContains(t, string(client.body), "fastly_service_version:2")
+	testutil.AssertStringContains(t, string(client.body), `"time":946684800000`)
+	testutil.AssertString(t, "", out.String())
+}
+
+func TestRunDryRun(t *testing.T) {
+	var out bytes.Buffer
+	client := &mockHTTPClient{status: http.StatusOK}
+	globals := &config.Data{
+		ErrLog:     fsterr.MockLog{},
+		HTTPClient: client,
+		File: config.File{
+			Hooks: config.Hooks{
+				hooks.PostActivate: {URL: "https://example.com/hook", Command: "notify-deploy"},
+			},
+			Profiles: config.Profiles{
+				"user": &config.Profile{
+					Default: true,
+					DeployMarkers: &config.DeployMarkers{
+						Datadog: &config.DatadogMarker{APIKey: "dd-key"},
+						Grafana: &config.GrafanaMarker{APIKey: "gf-key", URL: "https://grafana.example.com"},
+					},
+				},
+			},
+		},
+	}
+	globals.Flag.DryRun = true
+
+	hooks.Run(hooks.PostActivate, hooks.Payload{
+		Command:        "service-version activate",
+		ServiceID:      "123",
+		ServiceVersion: 2,
+	}, globals, &out)
+
+	testutil.AssertEqual(t, 0, len(client.reqs))
+	testutil.AssertString(t, `DRY RUN: Datadog deploy marker
+DRY RUN: Grafana deploy marker
+DRY RUN: post-activate hook: POST https://example.com/hook
+DRY RUN: post-activate hook: notify-deploy
+`, out.String())
+}
@@ -0,0 +1,110 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// DefaultDatadogSite is the Datadog site used when a profile doesn't specify one.
+const DefaultDatadogSite = "datadoghq.com"
+
+// markers emits a deployment event to each observability integration
+// configured for the current profile.
+func markers(p Payload, globals *config.Data, out io.Writer) {
+	_, profile := globals.CurrentProfile()
+	if profile == nil || profile.DeployMarkers == nil {
+		return
+	}
+
+	title := fmt.Sprintf("Fastly service %s version %d activated", p.ServiceID, p.ServiceVersion)
+	body := fmt.Sprintf("Activated via `fastly %s` (CLI %s)", p.Command, p.CLIVersion)
+	tags := []string{
+		"source:fastly",
+		"fastly_service_id:" + p.ServiceID,
+		fmt.Sprintf("fastly_service_version:%d", p.ServiceVersion),
+	}
+
+	if dd := profile.DeployMarkers.Datadog; dd != nil {
+		record("Datadog", globals, out, func() error {
+			return datadog(dd, title, body, tags, globals)
+		})
+	}
+	if g := profile.DeployMarkers.Grafana; g != nil {
+		record("Grafana", globals, out, func() error {
+			return grafana(g, title, body, tags, globals)
+		})
+	}
+}
+
+// record emits a deploy marker with fn and reports the outcome, unless
+// --dry-run is set, in which case the marker is only displayed.
+func record(integration string, globals *config.Data, out io.Writer, fn func() error) {
+	if globals.Flag.DryRun {
+		fmt.Fprintf(out, "DRY RUN: %s deploy marker\n", integration)
+		return
+	}
+	report(integration, fn(), globals, out)
+}
+
+// datadog creates an event via the Datadog Events API.
+func datadog(m *config.DatadogMarker, title, body string, tags []string, globals *config.Data) error {
+	site := m.Site
+	if site == "" {
+		site = DefaultDatadogSite
+	}
+	data, err := json.Marshal(struct {
+		AlertType      string   `json:"alert_type"`
+		SourceTypeName string   `json:"source_type_name"`
+		Tags           []string `json:"tags"`
+		Text           string   `json:"text"`
+		Title          string   `json:"title"`
+	}{
+		AlertType:      "info",
+		SourceTypeName: "fastly",
+		Tags:           append(tags, m.Tags...),
+		Text:           body,
+		Title:          title,
+	})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("https://api.%s/api/v1/events", site)
+	return post(url, data, map[string]string{"DD-API-KEY": m.APIKey}, globals.HTTPClient)
+}
+
+// grafana creates an annotation via the Grafana HTTP API.
+func grafana(m *config.GrafanaMarker, title, body string, tags []string, globals *config.Data) error {
+	data, err := json.Marshal(struct {
+		Tags []string `json:"tags"`
+		Text string   `json:"text"`
+		Time int64    `json:"time"`
+	}{
+		Tags: append(tags, m.Tags...),
+		Text: fmt.Sprintf("%s\n%s", title, body),
+		Time: globals.Clock.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(m.URL, "/") + "/api/annotations"
+	return post(url, data, map[string]string{"Authorization": "Bearer " + m.APIKey}, globals.HTTPClient)
+}
+
+// report displays the outcome of emitting a deploy marker.
+func report(integration string, err error, globals *config.Data, out io.Writer) {
+	if err != nil {
+		globals.ErrLog.AddWithContext(err, map[string]interface{}{
+			"Integration": integration,
+		})
+		text.Warning(out, "Failed to record %s deploy marker: %s", integration, err)
+		return
+	}
+	if globals.Verbose() {
+		text.Info(out, "Recorded %s deploy marker", integration)
+	}
+}
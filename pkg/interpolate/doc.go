@@ -0,0 +1,9 @@
+// Package interpolate expands environment variable (${NAME}) and command
+// ($(command)) references in the string values of the TOML files read by the
+// CLI, so values such as service IDs and tokens can be injected per
+// environment.
+//
+// The original references are remembered, so that when a file is written
+// back to disk the references are preserved rather than being replaced by the
+// values they expanded to.
+package interpolate
@@ -0,0 +1,279 @@
+package interpolate
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	toml "github.com/pelletier/go-toml"
+)
+
+// Expand returns s with the following references replaced:
+//
+//	${NAME}           the value of the environment variable NAME, which must be set
+//	${NAME:-default}  the value of NAME, or default if NAME is unset or empty
+//	$(command)        the output of the command, minus any trailing newlines
+//	$${ and $$(       a literal ${ or $(
+//
+// Commands are run by the system shell. Any other use of $ is left as-is.
+func Expand(s string) (string, error) {
+	return expand(s, true)
+}
+
+// ExpandEnv is like Expand but leaves command references as-is, so that it
+// can't run anything.
+func ExpandEnv(s string) (string, error) {
+	return expand(s, false)
+}
+
+func expand(s string, commands bool) (string, error) {
+	if !strings.Contains(s, "${") && !strings.Contains(s, "$(") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		switch next := s[i+1]; {
+		case next == '$' && i+2 < len(s) && (s[i+2] == '{' || s[i+2] == '('):
+			// Escaped reference: skip the first $ and copy the rest verbatim.
+			sb.WriteByte('$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end == -1 {
+				return "", fmt.Errorf("unterminated ${ in '%s'", s)
+			}
+			v, err := variable(s[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+			i += 2 + end
+		case next == '(' && commands:
+			end := closingParen(s[i+2:])
+			if end == -1 {
+				return "", fmt.Errorf("unterminated $( in '%s'", s)
+			}
+			v, err := command(s[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+			i += 2 + end
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// variable resolves an environment variable reference, with an optional
+// default value (NAME:-default).
+func variable(ref string) (string, error) {
+	name, def, hasDefault := strings.Cut(ref, ":-")
+	if name == "" {
+		return "", fmt.Errorf("missing environment variable name in '${%s}'", ref)
+	}
+	v, ok := os.LookupEnv(name)
+	if hasDefault && v == "" {
+		return def, nil
+	}
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return v, nil
+}
+
+// closingParen returns the index of the parenthesis that closes a command
+// reference, allowing for nested parentheses, or -1 if there isn't one.
+func closingParen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// command runs the command via the system shell and returns its output.
+func command(c string) (string, error) {
+	name, args := "sh", []string{"-c", c}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd.exe", []string{"/C", c}
+	}
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the command is defined by the user in their own files.
+	/* #nosec */
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running command '%s': %w", c, err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// Templates records the original value of every string in a TOML document
+// that contained references, keyed by the value's path.
+type Templates map[string]Template
+
+// Template is an original, unexpanded value and the value it expanded to.
+type Template struct {
+	Raw      string
+	Expanded string
+}
+
+// Options control which references TOML expands.
+type Options struct {
+	// Commands enables command references ($(command)). Without it they're
+	// left as-is, so that reading a file from an untrusted source (e.g. a
+	// cloned project's fastly.toml) can't run anything.
+	Commands bool
+	// Skip are the top-level tables whose values are left as-is, as they hold
+	// shell commands that are run later and expand their own references.
+	Skip []string
+}
+
+// TOML expands the references in every string value of the TOML document,
+// except those in the tables skipped by opts.
+//
+// The document is returned unmodified (along with nil Templates) if it
+// doesn't contain any references, or if it can't be parsed, in which case
+// it's left to the caller's decoder to report the problem.
+func TOML(data []byte, opts Options) ([]byte, Templates, error) {
+	tree, err := toml.LoadBytes(data)
+	if err != nil {
+		return data, nil, nil
+	}
+
+	templates := Templates{}
+	err = walk(tree, "", func(path, v string) (string, error) {
+		if opts.skipped(path) {
+			return v, nil
+		}
+		expanded, err := expand(v, opts.Commands)
+		if err != nil {
+			return "", fmt.Errorf("error interpolating '%s': %w", path, err)
+		}
+		if expanded != v {
+			templates[path] = Template{Raw: v, Expanded: expanded}
+		}
+		return expanded, nil
+	})
+	if err != nil {
+		return data, nil, err
+	}
+	if len(templates) == 0 {
+		return data, nil, nil
+	}
+
+	data, err = tree.Marshal()
+	if err != nil {
+		return data, nil, err
+	}
+	return data, templates, nil
+}
+
+// skipped reports whether the value at path is in a skipped table.
+func (o Options) skipped(path string) bool {
+	table := path
+	if i := strings.IndexAny(path, ".["); i != -1 {
+		table = path[:i]
+	}
+	for _, s := range o.Skip {
+		if table == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Restore replaces any value in the TOML document that's unchanged from what
+// it expanded to with its original reference, so the document can be written
+// back to disk without persisting the expanded values.
+func (t Templates) Restore(data []byte) ([]byte, error) {
+	if len(t) == 0 {
+		return data, nil
+	}
+
+	tree, err := toml.LoadBytes(data)
+	if err != nil {
+		return data, err
+	}
+
+	err = walk(tree, "", func(path, v string) (string, error) {
+		if tmpl, ok := t[path]; ok && tmpl.Expanded == v {
+			return tmpl.Raw, nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return data, err
+	}
+	return tree.Marshal()
+}
+
+// walk calls fn for every string value in the tree, replacing the value with
+// the one returned.
+func walk(tree *toml.Tree, prefix string, fn func(path, v string) (string, error)) error {
+	for _, key := range tree.Keys() {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		v, err := walkValue(tree.GetPath([]string{key}), path, fn)
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			tree.SetPath([]string{key}, v)
+		}
+	}
+	return nil
+}
+
+// walkValue calls fn for every string in the value, returning the value to
+// replace it with (or nil if it doesn't need replacing).
+func walkValue(value interface{}, path string, fn func(path, v string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return fn(path, v)
+	case *toml.Tree:
+		return nil, walk(v, path, fn)
+	case []*toml.Tree:
+		for i, t := range v {
+			if err := walk(t, path+"["+strconv.Itoa(i)+"]", fn); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			r, err := walkValue(e, path+"["+strconv.Itoa(i)+"]", fn)
+			if err != nil {
+				return nil, err
+			}
+			if r != nil {
+				v[i] = r
+			}
+		}
+		return v, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,116 @@
+package interpolate_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/fastly/cli/pkg/interpolate"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestExpand(t *testing.T) {
+	t.Setenv("FASTLY_TEST_SERVICE_ID", "123")
+	t.Setenv("FASTLY_TEST_EMPTY", "")
+
+	for _, testcase := range []struct {
+		in        string
+		want      string
+		wantError string
+		shell     bool
+	}{
+		{in: "plain value", want: "plain value"},
+		{in: "${FASTLY_TEST_SERVICE_ID}", want: "123"},
+		{in: "id-${FASTLY_TEST_SERVICE_ID}-x", want: "id-123-x"},
+		{in: "${FASTLY_TEST_EMPTY}", want: ""},
+		{in: "${FASTLY_TEST_EMPTY:-default}", want: "default"},
+		{in: "${FASTLY_TEST_UNSET:-default}", want: "default"},
+		{in: "${FASTLY_TEST_SERVICE_ID:-default}", want: "123"},
+		{in: "$${FASTLY_TEST_SERVICE_ID} and $$(echo hi)", want: "${FASTLY_TEST_SERVICE_ID} and $(echo hi)"},
+		{in: "$HOME $ and $$", want: "$HOME $ and $$"},
+		{in: "${FASTLY_TEST_UNSET}", wantError: "environment variable FASTLY_TEST_UNSET is not set"},
+		{in: "${}", wantError: "missing environment variable name"},
+		{in: "${FASTLY_TEST_SERVICE_ID", wantError: "unterminated ${"},
+		{in: "$(echo hello)", want: "hello", shell: true},
+		{in: "$(echo $(echo nested))", want: "nested", shell: true},
+		{in: "$(exit 1)", wantError: "error running command 'exit 1'", shell: true},
+		{in: "$(echo", wantError: "unterminated $("},
+	} {
+		t.Run(testcase.in, func(t *testing.T) {
+			if testcase.shell && runtime.GOOS == "windows" {
+				t.Skip("command uses POSIX shell syntax")
+			}
+			have, err := interpolate.Expand(testcase.in)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.want, have)
+		})
+	}
+}
+
+func TestTOML(t *testing.T) {
+	t.Setenv("FASTLY_TEST_SERVICE_ID", "123")
+	t.Setenv("FASTLY_TEST_HOST", "example.com")
+
+	in := `name = "app"
+service_id = "${FASTLY_TEST_SERVICE_ID}"
+
+[setup.backends.origin]
+  address = "${FASTLY_TEST_HOST}"
+  ports = ["${FASTLY_TEST_PORT:-443}"]
+`
+	data, templates, err := interpolate.TOML([]byte(in), interpolate.Options{})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, interpolate.Templates{
+		"service_id":                     {Raw: "${FASTLY_TEST_SERVICE_ID}", Expanded: "123"},
+		"setup.backends.origin.address":  {Raw: "${FASTLY_TEST_HOST}", Expanded: "example.com"},
+		"setup.backends.origin.ports[0]": {Raw: "${FASTLY_TEST_PORT:-443}", Expanded: "443"},
+	}, templates)
+	testutil.AssertStringContains(t, string(data), `service_id = "123"`)
+	testutil.AssertStringContains(t, string(data), `address = "example.com"`)
+	testutil.AssertStringContains(t, string(data), `ports = ["443"]`)
+
+	// Values that are unchanged are restored to their references, while those
+	// that have been modified are kept.
+	modified := []byte(`name = "app"
+service_id = "456"
+
+[setup.backends.origin]
+  address = "example.com"
+  ports = ["443"]
+`)
+	restored, err := templates.Restore(modified)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(restored), `service_id = "456"`)
+	testutil.AssertStringContains(t, string(restored), `address = "${FASTLY_TEST_HOST}"`)
+	testutil.AssertStringContains(t, string(restored), `ports = ["${FASTLY_TEST_PORT:-443}"]`)
+
+	t.Run("no references", func(t *testing.T) {
+		data, templates, err := interpolate.TOML([]byte(`name = "app"`), interpolate.Options{})
+		testutil.AssertNoError(t, err)
+		testutil.AssertString(t, `name = "app"`, string(data))
+		testutil.AssertEqual(t, interpolate.Templates(nil), templates)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, _, err := interpolate.TOML([]byte(`[profile.user]
+token = "${FASTLY_TEST_UNSET}"`), interpolate.Options{})
+		testutil.AssertErrorContains(t, err, "error interpolating 'profile.user.token': environment variable FASTLY_TEST_UNSET is not set")
+	})
+	t.Run("options", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("command uses POSIX shell syntax")
+		}
+		in := []byte(`name = "$(echo app)"
+
+[scripts]
+  build = "cargo build --bin $(basename ${FASTLY_TEST_UNSET})"
+`)
+		data, _, err := interpolate.TOML(in, interpolate.Options{Skip: []string{"scripts"}})
+		testutil.AssertNoError(t, err)
+		testutil.AssertString(t, string(in), string(data))
+
+		data, _, err = interpolate.TOML(in, interpolate.Options{Commands: true, Skip: []string{"scripts"}})
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, string(data), `name = "app"`)
+		testutil.AssertStringContains(t, string(data), `build = "cargo build --bin $(basename ${FASTLY_TEST_UNSET})"`)
+	})
+}
@@ -0,0 +1,3 @@
+// Package logger writes structured logs of the CLI's internals, so automation
+// that runs the CLI has diagnostic logs with timestamps and request IDs.
+package logger
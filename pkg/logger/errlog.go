@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// ErrLog returns a fsterr.LogInterface that adds errors to next and also
+// writes them to l at the error level, along with where they occurred and any
+// context.
+func ErrLog(next fsterr.LogInterface, l *Logger) fsterr.LogInterface {
+	return &errLog{next: next, logger: l}
+}
+
+type errLog struct {
+	next   fsterr.LogInterface
+	logger *Logger
+}
+
+// Add implements the fsterr.LogInterface interface.
+func (e *errLog) Add(err error) {
+	e.next.Add(err)
+	e.logger.Error(err.Error(), Fields{"caller": caller()})
+}
+
+// AddWithContext implements the fsterr.LogInterface interface.
+func (e *errLog) AddWithContext(err error, ctx map[string]interface{}) {
+	e.next.AddWithContext(err, ctx)
+	e.logger.Error(err.Error(), Fields{"caller": caller(), "context": ctx})
+}
+
+// Persist implements the fsterr.LogInterface interface.
+func (e *errLog) Persist(logPath string, args []string) error {
+	return e.next.Persist(logPath, args)
+}
+
+// caller returns the location of the code that called the errLog method.
+func caller() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return ""
+	}
+	if i := strings.Index(file, "/pkg/"); i >= 0 {
+		file = file[i+1:]
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log record.
+type Level int
+
+// The levels of log records, in increasing severity.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Levels are the names of the levels, as accepted by --log-level.
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return Levels[l]
+}
+
+// ParseLevel returns the level with the given name.
+func ParseLevel(s string) (Level, error) {
+	for i, name := range Levels {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log level '%s': must be one of %s", s, strings.Join(Levels, ", "))
+}
+
+// Fields are the structured data of a log record.
+type Fields map[string]interface{}
+
+// Now is exposed so that tests can control the time of log records.
+var Now = time.Now
+
+// Logger writes log records at or above its level as JSON, one record per
+// line. Every record includes the time, level, message and the ID of the CLI
+// run, so the records of concurrent runs can be told apart.
+//
+// A nil *Logger is valid and discards every record, so callers don't need to
+// check whether logging is enabled. A Logger is safe for concurrent use.
+type Logger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+	runID string
+}
+
+// New returns a logger that writes the records at or above level to w.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{w: w, level: level, runID: newRunID()}
+}
+
+// RunID returns the ID included in every record.
+func (l *Logger) RunID() string {
+	if l == nil {
+		return ""
+	}
+	return l.runID
+}
+
+// Enabled reports whether records at level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return l != nil && level >= l.level
+}
+
+// Debug writes a record at the debug level.
+func (l *Logger) Debug(msg string, fields Fields) {
+	l.Log(LevelDebug, msg, fields)
+}
+
+// Info writes a record at the info level.
+func (l *Logger) Info(msg string, fields Fields) {
+	l.Log(LevelInfo, msg, fields)
+}
+
+// Warn writes a record at the warn level.
+func (l *Logger) Warn(msg string, fields Fields) {
+	l.Log(LevelWarn, msg, fields)
+}
+
+// Error writes a record at the error level.
+func (l *Logger) Error(msg string, fields Fields) {
+	l.Log(LevelError, msg, fields)
+}
+
+// Log writes a record at the given level, if it's enabled. The time, level,
+// message and run ID come first, followed by the fields in alphabetical
+// order. Fields that can't be encoded as JSON are written as strings.
+func (l *Logger) Log(level Level, msg string, fields Fields) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeField(&buf, "time", Now().UTC().Format(time.RFC3339Nano), true)
+	writeField(&buf, "level", level.String(), false)
+	writeField(&buf, "msg", msg, false)
+	writeField(&buf, "run_id", l.runID, false)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeField(&buf, k, fields[k], false)
+	}
+	buf.WriteString("}\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w.Write(buf.Bytes()) // #nosec G104 (a failed write can't be logged)
+}
+
+func writeField(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(',')
+	}
+	k, _ := json.Marshal(key)
+	buf.Write(k)
+	buf.WriteByte(':')
+
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(v)
+}
+
+// newRunID returns a random ID for a CLI run.
+func newRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,91 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logger"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestLog(t *testing.T) {
+	logger.Now = func() time.Time { return testutil.Date }
+	defer func() { logger.Now = time.Now }()
+
+	var buf bytes.Buffer
+	l := logger.New(&buf, logger.LevelInfo)
+	l.Debug("skipped", nil)
+	l.Info("hello", logger.Fields{"b": 1, "a": errors.New("boom")})
+
+	want := `{"time":"2021-06-15T23:00:00Z","level":"info","msg":"hello","run_id":"` + l.RunID() + `","a":"boom","b":1}` + "\n"
+	testutil.AssertString(t, want, buf.String())
+
+	var nilLogger *logger.Logger
+	nilLogger.Error("discarded", nil)
+	testutil.AssertBool(t, false, nilLogger.Enabled(logger.LevelError))
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := logger.ParseLevel("WARN")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, logger.LevelWarn, level)
+
+	_, err = logger.ParseLevel("trace")
+	testutil.AssertErrorContains(t, err, "invalid log level 'trace'")
+}
+
+func TestErrLog(t *testing.T) {
+	var buf bytes.Buffer
+	entries := new(fsterr.LogEntries)
+	log := logger.ErrLog(entries, logger.New(&buf, logger.LevelInfo))
+	log.AddWithContext(errors.New("boom"), map[string]interface{}{"ID": "123"})
+
+	testutil.AssertEqual(t, 1, len(*entries))
+	if file := (*entries)[0].Caller["FILE"]; file != "/pkg/logger/logger_test.go" {
+		t.Errorf("want the entry's caller to be the test, got: %v", file)
+	}
+
+	var record map[string]interface{}
+	testutil.AssertNoError(t, json.Unmarshal(buf.Bytes(), &record))
+	testutil.AssertString(t, "error", record["level"].(string))
+	testutil.AssertString(t, "boom", record["msg"].(string))
+	if !strings.HasPrefix(record["caller"].(string), "pkg/logger/logger_test.go:") {
+		t.Errorf("want the record's caller to be the test, got: %v", record["caller"])
+	}
+}
+
+func TestTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Fastly-Request-ID", "abc123")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	l := logger.New(&buf, logger.LevelWarn)
+	c := &http.Client{Transport: l.Transport(nil)}
+	for _, path := range []string{"/found", "/missing"} {
+		resp, err := c.Get(ts.URL + path)
+		testutil.AssertNoError(t, err)
+		resp.Body.Close()
+	}
+
+	// Only the failed request is at or above the warn level.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	testutil.AssertEqual(t, 1, len(lines))
+	var record map[string]interface{}
+	testutil.AssertNoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	testutil.AssertString(t, "API request failed", record["msg"].(string))
+	testutil.AssertString(t, "abc123", record["request_id"].(string))
+	testutil.AssertEqual(t, float64(http.StatusNotFound), record["status"])
+	testutil.AssertString(t, "GET", record["method"].(string))
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/transport"
+)
+
+// Transport returns a http.RoundTripper that logs each request made via next:
+// successful requests at the debug level, and failed requests at the warn
+// level. The records include the request ID the API assigned, so they can be
+// correlated with Fastly's own logs.
+//
+// If next is nil then http.DefaultTransport is used.
+func (l *Logger) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &loggingTransport{do: next.RoundTrip, logger: l}
+}
+
+// Client returns an api.HTTPClient that logs each request made via c, in the
+// same way as Transport.
+func (l *Logger) Client(c api.HTTPClient) api.HTTPClient {
+	return &loggingTransport{do: c.Do, logger: l}
+}
+
+type loggingTransport struct {
+	do     func(*http.Request) (*http.Response, error)
+	logger *Logger
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.do(req)
+
+	fields := Fields{
+		"method":      req.Method,
+		"url":         req.URL.Host + req.URL.Path,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err
+		t.logger.Warn("API request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	for _, h := range transport.RequestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			fields["request_id"] = id
+			break
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.logger.Warn("API request failed", fields)
+	} else {
+		t.logger.Debug("API request", fields)
+	}
+	return resp, err
+}
+
+// Do implements the api.HTTPClient interface.
+func (t *loggingTransport) Do(req *http.Request) (*http.Response, error) {
+	return t.RoundTrip(req)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/interpolate"
 	"github.com/fastly/cli/pkg/text"
 	toml "github.com/pelletier/go-toml"
 )
@@ -52,6 +53,10 @@ const (
 	// SourceFlag indicates the parameter came from an explicit flag.
 	SourceFlag
 
+	// SourceProfile indicates the parameter came from the current profile of
+	// the CLI config, e.g. its default service.
+	SourceProfile
+
 	// SpecIntro informs the user of what the manifest file is for.
 	SpecIntro = "This file describes a Fastly Compute@Edge package. To learn more visit:"
 
@@ -211,10 +216,16 @@ type File struct {
 	ServiceID       string      `toml:"service_id"`
 	Setup           Setup       `toml:"setup,omitempty"`
 
+	// commands enables command references ($(command)) in the manifest's
+	// values (see SetCommands).
+	commands  bool
 	errLog    fsterr.LogInterface
 	exists    bool
 	output    io.Writer
 	readError error
+	// templates records the values that contained environment variable or
+	// command references, so they can be restored by Write.
+	templates interpolate.Templates
 }
 
 // Scripts represents custom operations.
@@ -252,7 +263,16 @@ type SetupDictionaryItems struct {
 
 // SetupLogger represents a '[setup.log_endpoints.<T>]' instance.
 type SetupLogger struct {
-	Provider string `toml:"provider,omitempty"`
+	Provider    string                          `toml:"provider,omitempty"`
+	Description string                          `toml:"description,omitempty"`
+	Parameters  map[string]SetupLoggerParameter `toml:"parameters,omitempty"`
+}
+
+// SetupLoggerParameter represents a '[setup.log_endpoints.<T>.parameters]'
+// instance, a setting of the provider's endpoint (e.g. url or dataset).
+type SetupLoggerParameter struct {
+	Value       string `toml:"value,omitempty"`
+	Description string `toml:"description,omitempty"`
 }
 
 // LocalServer represents a list of backends that should be mocked as per the
@@ -301,6 +321,13 @@ func (f *File) SetErrLog(errLog fsterr.LogInterface) {
 	f.errLog = errLog
 }
 
+// SetCommands sets whether command references ($(command)) in the manifest's
+// values are run when it's read. Environment variable references are always
+// expanded.
+func (f *File) SetCommands(enabled bool) {
+	f.commands = enabled
+}
+
 // SetOutput sets the output stream for any messages.
 func (f *File) SetOutput(output io.Writer) {
 	f.output = output
@@ -358,6 +385,20 @@ func (f *File) Read(path string) (err error) {
 		return err
 	}
 
+	// The build scripts are run by a shell later on, so their references are
+	// left for it to expand.
+	data, f.templates, err = interpolate.TOML(data, interpolate.Options{
+		Commands: f.commands,
+		Skip:     []string{"scripts"},
+	})
+	if err != nil {
+		f.errLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("failed to parse the fastly.toml manifest: %w", err),
+			Remediation: "Check the environment variable and command references (${NAME} and $(command)) in the fastly.toml manifest. Command references are only run if the CLI config file's [manifest] commands setting is enabled.",
+		}
+	}
+
 	err = toml.Unmarshal(data, f)
 	if err != nil {
 		f.errLog.Add(err)
@@ -542,7 +583,15 @@ func (f *File) Write(path string) error {
 		return err
 	}
 
-	if err := toml.NewEncoder(fp).Encode(f); err != nil {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(f); err != nil {
+		return err
+	}
+	data, err := f.templates.Restore(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if _, err := fp.Write(data); err != nil {
 		return err
 	}
 
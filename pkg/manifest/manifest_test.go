@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
@@ -299,3 +300,62 @@ func TestManifestPersistsLocalServerSection(t *testing.T) {
 		t.Fatal("testing section between original and updated fastly.toml do not match")
 	}
 }
+
+func TestManifestInterpolation(t *testing.T) {
+	t.Setenv("FASTLY_TEST_SERVICE_ID", "123")
+	t.Setenv("FASTLY_TEST_BACKEND", "example.com")
+
+	fpath := filepath.Join(t.TempDir(), manifest.Filename)
+	err := os.WriteFile(fpath, []byte(`manifest_version = 2
+name = "interpolated"
+service_id = "${FASTLY_TEST_SERVICE_ID}"
+authors = ["$(echo someone)"]
+
+[scripts]
+  build = "cargo build --release --bin $(basename ${FASTLY_TEST_BIN})"
+
+[setup.backends.origin]
+  address = "${FASTLY_TEST_BACKEND}"
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m manifest.File
+	m.SetErrLog(fsterr.Log)
+	if err := m.Read(fpath); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "123", m.ServiceID)
+	testutil.AssertString(t, "example.com", m.Setup.Backends["origin"].Address)
+	// Build scripts are left for the shell that runs them to expand, and
+	// command references are only run if they're enabled.
+	testutil.AssertString(t, "cargo build --release --bin $(basename ${FASTLY_TEST_BIN})", m.Scripts.Build)
+	testutil.AssertEqual(t, []string{"$(echo someone)"}, m.Authors)
+
+	// Writing the manifest back to disk must preserve the references for any
+	// values that weren't changed.
+	m.Name = "renamed"
+	if err := m.Write(fpath); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := toml.LoadFile(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, "renamed", tree.Get("name"))
+	testutil.AssertEqual(t, "${FASTLY_TEST_SERVICE_ID}", tree.Get("service_id"))
+	testutil.AssertEqual(t, "${FASTLY_TEST_BACKEND}", tree.GetPath([]string{"setup", "backends", "origin", "address"}))
+
+	if runtime.GOOS != "windows" {
+		m.SetCommands(true)
+		if err := m.Read(fpath); err != nil {
+			t.Fatal(err)
+		}
+		testutil.AssertEqual(t, []string{"someone"}, m.Authors)
+	}
+
+	os.Unsetenv("FASTLY_TEST_SERVICE_ID")
+	err = m.Read(fpath)
+	testutil.AssertErrorContains(t, err, "error interpolating 'service_id': environment variable FASTLY_TEST_SERVICE_ID is not set")
+}
@@ -48,6 +48,15 @@ type API struct {
 	UpdateHealthCheckFn func(*fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error)
 	DeleteHealthCheckFn func(*fastly.DeleteHealthCheckInput) error
 
+	CreateGzipFn func(*fastly.CreateGzipInput) (*fastly.Gzip, error)
+	ListGzipsFn  func(*fastly.ListGzipsInput) ([]*fastly.Gzip, error)
+	UpdateGzipFn func(*fastly.UpdateGzipInput) (*fastly.Gzip, error)
+	DeleteGzipFn func(*fastly.DeleteGzipInput) error
+
+	CreateCacheSettingFn func(*fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error)
+	ListCacheSettingsFn  func(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error)
+	DeleteCacheSettingFn func(*fastly.DeleteCacheSettingInput) error
+
 	GetPackageFn    func(*fastly.GetPackageInput) (*fastly.Package, error)
 	UpdatePackageFn func(*fastly.UpdatePackageInput) (*fastly.Package, error)
 
@@ -219,13 +228,16 @@ type API struct {
 	GetRegionsFn   func() (*fastly.RegionsResponse, error)
 	GetStatsJSONFn func(i *fastly.GetStatsInput, dst interface{}) error
 
+	GetAPIEventsFn func(*fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error)
+
 	CreateManagedLoggingFn func(*fastly.CreateManagedLoggingInput) (*fastly.ManagedLogging, error)
 
-	CreateVCLFn func(*fastly.CreateVCLInput) (*fastly.VCL, error)
-	ListVCLsFn  func(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
-	GetVCLFn    func(*fastly.GetVCLInput) (*fastly.VCL, error)
-	UpdateVCLFn func(*fastly.UpdateVCLInput) (*fastly.VCL, error)
-	DeleteVCLFn func(*fastly.DeleteVCLInput) error
+	CreateVCLFn       func(*fastly.CreateVCLInput) (*fastly.VCL, error)
+	ListVCLsFn        func(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
+	GetVCLFn          func(*fastly.GetVCLInput) (*fastly.VCL, error)
+	GetGeneratedVCLFn func(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error)
+	UpdateVCLFn       func(*fastly.UpdateVCLInput) (*fastly.VCL, error)
+	DeleteVCLFn       func(*fastly.DeleteVCLInput) error
 
 	CreateSnippetFn        func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error)
 	ListSnippetsFn         func(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error)
@@ -235,11 +247,33 @@ type API struct {
 	UpdateDynamicSnippetFn func(i *fastly.UpdateDynamicSnippetInput) (*fastly.DynamicSnippet, error)
 	DeleteSnippetFn        func(i *fastly.DeleteSnippetInput) error
 
+	ListConditionsFn  func(i *fastly.ListConditionsInput) ([]*fastly.Condition, error)
+	CreateConditionFn func(i *fastly.CreateConditionInput) (*fastly.Condition, error)
+	GetConditionFn    func(i *fastly.GetConditionInput) (*fastly.Condition, error)
+	UpdateConditionFn func(i *fastly.UpdateConditionInput) (*fastly.Condition, error)
+	DeleteConditionFn func(i *fastly.DeleteConditionInput) error
+
+	ListDirectorsFn         func(i *fastly.ListDirectorsInput) ([]*fastly.Director, error)
+	ListRequestSettingsFn   func(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error)
+	CreateDirectorBackendFn func(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error)
+	GetDirectorBackendFn    func(i *fastly.GetDirectorBackendInput) (*fastly.DirectorBackend, error)
+	DeleteDirectorBackendFn func(i *fastly.DeleteDirectorBackendInput) error
+
+	CreateHeaderFn func(i *fastly.CreateHeaderInput) (*fastly.Header, error)
+	ListHeadersFn  func(i *fastly.ListHeadersInput) ([]*fastly.Header, error)
+	DeleteHeaderFn func(i *fastly.DeleteHeaderInput) error
+
+	CreateResponseObjectFn func(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error)
+	GetResponseObjectFn    func(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error)
+	DeleteResponseObjectFn func(i *fastly.DeleteResponseObjectInput) error
+
 	PurgeFn     func(i *fastly.PurgeInput) (*fastly.Purge, error)
 	PurgeKeyFn  func(i *fastly.PurgeKeyInput) (*fastly.Purge, error)
 	PurgeKeysFn func(i *fastly.PurgeKeysInput) (map[string]string, error)
 	PurgeAllFn  func(i *fastly.PurgeAllInput) (*fastly.Purge, error)
 
+	EdgeCheckFn func(i *fastly.EdgeCheckInput) ([]*fastly.EdgeCheck, error)
+
 	CreateACLFn func(i *fastly.CreateACLInput) (*fastly.ACL, error)
 	DeleteACLFn func(i *fastly.DeleteACLInput) error
 	GetACLFn    func(i *fastly.GetACLInput) (*fastly.ACL, error)
@@ -275,6 +309,25 @@ type API struct {
 	ListCustomerTokensFn func(i *fastly.ListCustomerTokensInput) ([]*fastly.Token, error)
 	ListTokensFn         func() ([]*fastly.Token, error)
 
+	ListWAFsFn func(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error)
+
+	ListAllWAFActiveRulesFn func(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error)
+	CreateWAFActiveRulesFn  func(i *fastly.CreateWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error)
+	DeleteWAFActiveRulesFn  func(i *fastly.DeleteWAFActiveRulesInput) error
+
+	ListAllWAFRuleExclusionsFn func(i *fastly.ListAllWAFRuleExclusionsInput) (*fastly.WAFRuleExclusionResponse, error)
+	CreateWAFRuleExclusionFn   func(i *fastly.CreateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error)
+	UpdateWAFRuleExclusionFn   func(i *fastly.UpdateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error)
+	DeleteWAFRuleExclusionFn   func(i *fastly.DeleteWAFRuleExclusionInput) error
+
+	ListAllWAFVersionsFn func(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error)
+	CloneWAFVersionFn    func(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error)
+	LockWAFVersionFn     func(i *fastly.LockWAFVersionInput) (*fastly.WAFVersion, error)
+	DeployWAFVersionFn   func(i *fastly.DeployWAFVersionInput) error
+
+	ListTLSActivationsFn   func(i *fastly.ListTLSActivationsInput) ([]*fastly.TLSActivation, error)
+	ListTLSSubscriptionsFn func(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error)
+
 	NewListACLEntriesPaginatorFn      func(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries
 	NewListDictionaryItemsPaginatorFn func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems
 	NewListServicesPaginatorFn        func(i *fastly.ListServicesInput) fastly.PaginatorServices
@@ -450,6 +503,41 @@ func (m API) DeleteHealthCheck(i *fastly.DeleteHealthCheckInput) error {
 	return m.DeleteHealthCheckFn(i)
 }
 
+// CreateGzip implements Interface.
+func (m API) CreateGzip(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+	return m.CreateGzipFn(i)
+}
+
+// ListGzips implements Interface.
+func (m API) ListGzips(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	return m.ListGzipsFn(i)
+}
+
+// UpdateGzip implements Interface.
+func (m API) UpdateGzip(i *fastly.UpdateGzipInput) (*fastly.Gzip, error) {
+	return m.UpdateGzipFn(i)
+}
+
+// DeleteGzip implements Interface.
+func (m API) DeleteGzip(i *fastly.DeleteGzipInput) error {
+	return m.DeleteGzipFn(i)
+}
+
+// CreateCacheSetting implements Interface.
+func (m API) CreateCacheSetting(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+	return m.CreateCacheSettingFn(i)
+}
+
+// ListCacheSettings implements Interface.
+func (m API) ListCacheSettings(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	return m.ListCacheSettingsFn(i)
+}
+
+// DeleteCacheSetting implements Interface.
+func (m API) DeleteCacheSetting(i *fastly.DeleteCacheSettingInput) error {
+	return m.DeleteCacheSettingFn(i)
+}
+
 // GetPackage implements Interface.
 func (m API) GetPackage(i *fastly.GetPackageInput) (*fastly.Package, error) {
 	return m.GetPackageFn(i)
@@ -1155,6 +1243,11 @@ func (m API) GetStatsJSON(i *fastly.GetStatsInput, dst interface{}) error {
 	return m.GetStatsJSONFn(i, dst)
 }
 
+// GetAPIEvents implements Interface.
+func (m API) GetAPIEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	return m.GetAPIEventsFn(i)
+}
+
 // CreateManagedLogging implements Interface.
 func (m API) CreateManagedLogging(i *fastly.CreateManagedLoggingInput) (*fastly.ManagedLogging, error) {
 	return m.CreateManagedLoggingFn(i)
@@ -1175,6 +1268,11 @@ func (m API) GetVCL(i *fastly.GetVCLInput) (*fastly.VCL, error) {
 	return m.GetVCLFn(i)
 }
 
+// GetGeneratedVCL implements Interface.
+func (m API) GetGeneratedVCL(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+	return m.GetGeneratedVCLFn(i)
+}
+
 // UpdateVCL implements Interface.
 func (m API) UpdateVCL(i *fastly.UpdateVCLInput) (*fastly.VCL, error) {
 	return m.UpdateVCLFn(i)
@@ -1220,6 +1318,86 @@ func (m API) DeleteSnippet(i *fastly.DeleteSnippetInput) error {
 	return m.DeleteSnippetFn(i)
 }
 
+// ListConditions implements Interface.
+func (m API) ListConditions(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	return m.ListConditionsFn(i)
+}
+
+// CreateCondition implements Interface.
+func (m API) CreateCondition(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	return m.CreateConditionFn(i)
+}
+
+// GetCondition implements Interface.
+func (m API) GetCondition(i *fastly.GetConditionInput) (*fastly.Condition, error) {
+	return m.GetConditionFn(i)
+}
+
+// UpdateCondition implements Interface.
+func (m API) UpdateCondition(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+	return m.UpdateConditionFn(i)
+}
+
+// DeleteCondition implements Interface.
+func (m API) DeleteCondition(i *fastly.DeleteConditionInput) error {
+	return m.DeleteConditionFn(i)
+}
+
+// ListDirectors implements Interface.
+func (m API) ListDirectors(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+	return m.ListDirectorsFn(i)
+}
+
+// ListRequestSettings implements Interface.
+func (m API) ListRequestSettings(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+	return m.ListRequestSettingsFn(i)
+}
+
+// CreateDirectorBackend implements Interface.
+func (m API) CreateDirectorBackend(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+	return m.CreateDirectorBackendFn(i)
+}
+
+// GetDirectorBackend implements Interface.
+func (m API) GetDirectorBackend(i *fastly.GetDirectorBackendInput) (*fastly.DirectorBackend, error) {
+	return m.GetDirectorBackendFn(i)
+}
+
+// DeleteDirectorBackend implements Interface.
+func (m API) DeleteDirectorBackend(i *fastly.DeleteDirectorBackendInput) error {
+	return m.DeleteDirectorBackendFn(i)
+}
+
+// CreateHeader implements Interface.
+func (m API) CreateHeader(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+	return m.CreateHeaderFn(i)
+}
+
+// ListHeaders implements Interface.
+func (m API) ListHeaders(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	return m.ListHeadersFn(i)
+}
+
+// DeleteHeader implements Interface.
+func (m API) DeleteHeader(i *fastly.DeleteHeaderInput) error {
+	return m.DeleteHeaderFn(i)
+}
+
+// CreateResponseObject implements Interface.
+func (m API) CreateResponseObject(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	return m.CreateResponseObjectFn(i)
+}
+
+// GetResponseObject implements Interface.
+func (m API) GetResponseObject(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error) {
+	return m.GetResponseObjectFn(i)
+}
+
+// DeleteResponseObject implements Interface.
+func (m API) DeleteResponseObject(i *fastly.DeleteResponseObjectInput) error {
+	return m.DeleteResponseObjectFn(i)
+}
+
 // Purge implements Interface.
 func (m API) Purge(i *fastly.PurgeInput) (*fastly.Purge, error) {
 	return m.PurgeFn(i)
@@ -1240,6 +1418,11 @@ func (m API) PurgeAll(i *fastly.PurgeAllInput) (*fastly.Purge, error) {
 	return m.PurgeAllFn(i)
 }
 
+// EdgeCheck implements Interface.
+func (m API) EdgeCheck(i *fastly.EdgeCheckInput) ([]*fastly.EdgeCheck, error) {
+	return m.EdgeCheckFn(i)
+}
+
 // CreateACL implements Interface.
 func (m API) CreateACL(i *fastly.CreateACLInput) (*fastly.ACL, error) {
 	return m.CreateACLFn(i)
@@ -1390,6 +1573,76 @@ func (m API) ListTokens() ([]*fastly.Token, error) {
 	return m.ListTokensFn()
 }
 
+// ListWAFs implements Interface.
+func (m API) ListWAFs(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+	return m.ListWAFsFn(i)
+}
+
+// ListAllWAFActiveRules implements Interface.
+func (m API) ListAllWAFActiveRules(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error) {
+	return m.ListAllWAFActiveRulesFn(i)
+}
+
+// CreateWAFActiveRules implements Interface.
+func (m API) CreateWAFActiveRules(i *fastly.CreateWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+	return m.CreateWAFActiveRulesFn(i)
+}
+
+// DeleteWAFActiveRules implements Interface.
+func (m API) DeleteWAFActiveRules(i *fastly.DeleteWAFActiveRulesInput) error {
+	return m.DeleteWAFActiveRulesFn(i)
+}
+
+// ListAllWAFRuleExclusions implements Interface.
+func (m API) ListAllWAFRuleExclusions(i *fastly.ListAllWAFRuleExclusionsInput) (*fastly.WAFRuleExclusionResponse, error) {
+	return m.ListAllWAFRuleExclusionsFn(i)
+}
+
+// CreateWAFRuleExclusion implements Interface.
+func (m API) CreateWAFRuleExclusion(i *fastly.CreateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error) {
+	return m.CreateWAFRuleExclusionFn(i)
+}
+
+// UpdateWAFRuleExclusion implements Interface.
+func (m API) UpdateWAFRuleExclusion(i *fastly.UpdateWAFRuleExclusionInput) (*fastly.WAFRuleExclusion, error) {
+	return m.UpdateWAFRuleExclusionFn(i)
+}
+
+// DeleteWAFRuleExclusion implements Interface.
+func (m API) DeleteWAFRuleExclusion(i *fastly.DeleteWAFRuleExclusionInput) error {
+	return m.DeleteWAFRuleExclusionFn(i)
+}
+
+// ListAllWAFVersions implements Interface.
+func (m API) ListAllWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	return m.ListAllWAFVersionsFn(i)
+}
+
+// CloneWAFVersion implements Interface.
+func (m API) CloneWAFVersion(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error) {
+	return m.CloneWAFVersionFn(i)
+}
+
+// LockWAFVersion implements Interface.
+func (m API) LockWAFVersion(i *fastly.LockWAFVersionInput) (*fastly.WAFVersion, error) {
+	return m.LockWAFVersionFn(i)
+}
+
+// DeployWAFVersion implements Interface.
+func (m API) DeployWAFVersion(i *fastly.DeployWAFVersionInput) error {
+	return m.DeployWAFVersionFn(i)
+}
+
+// ListTLSActivations implements Interface.
+func (m API) ListTLSActivations(i *fastly.ListTLSActivationsInput) ([]*fastly.TLSActivation, error) {
+	return m.ListTLSActivationsFn(i)
+}
+
+// ListTLSSubscriptions implements Interface.
+func (m API) ListTLSSubscriptions(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error) {
+	return m.ListTLSSubscriptionsFn(i)
+}
+
 // NewListACLEntriesPaginator implements Interface.
 func (m API) NewListACLEntriesPaginator(i *fastly.ListACLEntriesInput) fastly.PaginatorACLEntries {
 	return m.NewListACLEntriesPaginatorFn(i)
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 // APIClient takes a mock.API and returns an app.ClientFactory that uses that
@@ -14,6 +15,24 @@ func APIClient(a API) func(string, string) (api.Interface, error) {
 	}
 }
 
+// RTSClient takes a mock.RealtimeStats and returns an app.RTSClientFactory
+// that uses that mock, ignoring the token. It should only be used for tests.
+func RTSClient(r RealtimeStats) func(string) (api.RealtimeStatsInterface, error) {
+	return func(token string) (api.RealtimeStatsInterface, error) {
+		return r, nil
+	}
+}
+
+// RealtimeStats is a mock implementation of api.RealtimeStatsInterface.
+type RealtimeStats struct {
+	GetRealtimeStatsJSONFn func(i *fastly.GetRealtimeStatsInput, dst interface{}) error
+}
+
+// GetRealtimeStatsJSON implements RealtimeStatsInterface.
+func (m RealtimeStats) GetRealtimeStatsJSON(i *fastly.GetRealtimeStatsInput, dst interface{}) error {
+	return m.GetRealtimeStatsJSONFn(i, dst)
+}
+
 type mockHTTPClient struct {
 	res *http.Response
 	err error
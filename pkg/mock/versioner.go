@@ -11,6 +11,7 @@ import (
 // Versioner mocks the update.Versioner interface.
 type Versioner struct {
 	Version        string
+	Prerelease     string // version returned by LatestPrerelease, if set
 	Error          error
 	BinaryFilename string // name of compiled binary
 	Local          string // name to use for binary once extracted
@@ -26,6 +27,18 @@ func (v Versioner) LatestVersion(context.Context) (semver.Version, error) {
 	return semver.Parse(strings.TrimPrefix(v.Version, "v"))
 }
 
+// LatestPrerelease returns the parsed prerelease field, falling back to the
+// version field, or error if it's non-nil.
+func (v Versioner) LatestPrerelease(ctx context.Context) (semver.Version, error) {
+	if v.Prerelease == "" {
+		return v.LatestVersion(ctx)
+	}
+	if v.Error != nil {
+		return semver.Version{}, v.Error
+	}
+	return semver.Parse(strings.TrimPrefix(v.Prerelease, "v"))
+}
+
 // Download is a no-op.
 func (v Versioner) Download(context.Context, semver.Version) (filename string, err error) {
 	if v.DownloadOK {
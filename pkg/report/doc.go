@@ -0,0 +1,4 @@
+// Package report renders the findings of validation commands as machine
+// readable reports (JUnit XML, SARIF) so they can be consumed by CI test
+// dashboards and code-scanning tools.
+package report
@@ -0,0 +1,252 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fastly/cli/pkg/revision"
+)
+
+const (
+	// FormatJUnit is a JUnit XML report.
+	FormatJUnit = "junit"
+	// FormatSARIF is a SARIF v2.1.0 JSON report.
+	FormatSARIF = "sarif"
+)
+
+// Formats is a list of supported report formats.
+var Formats = []string{FormatJUnit, FormatSARIF}
+
+// Finding represents the outcome of a single validation check.
+type Finding struct {
+	// Rule identifies the check that was performed (e.g. required-file).
+	Rule string
+	// Name is a human readable name for the check.
+	Name string
+	// Message describes the failure. It is ignored unless Failed is true.
+	Message string
+	// Location is the file or resource the check was performed against.
+	Location string
+	// Failed indicates whether the check failed.
+	Failed bool
+}
+
+// Report is a collection of findings produced by a single command.
+type Report struct {
+	// Command is the name of the command that produced the findings (e.g.
+	// "compute validate").
+	Command  string
+	Findings []Finding
+}
+
+// Failures returns the number of failed findings.
+func (r Report) Failures() int {
+	var n int
+	for _, f := range r.Findings {
+		if f.Failed {
+			n++
+		}
+	}
+	return n
+}
+
+// Write renders the report to w in the given format.
+func Write(w io.Writer, format string, r Report) error {
+	switch format {
+	case FormatJUnit:
+		return writeJUnit(w, r)
+	case FormatSARIF:
+		return writeSARIF(w, r)
+	}
+	return fmt.Errorf("unsupported report format: %s", format)
+}
+
+// WriteFile renders the report to the file at path in the given format.
+func WriteFile(path, format string, r Report) (err error) {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("error creating report file: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("error closing report file: %w", cerr)
+		}
+	}()
+	return Write(f, format, r)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, r Report) error {
+	suite := junitTestSuite{
+		Name:     r.Command,
+		Tests:    len(r.Findings),
+		Failures: r.Failures(),
+	}
+	for _, f := range r.Findings {
+		tc := junitTestCase{
+			ClassName: r.Command,
+			Name:      f.Name,
+		}
+		if f.Failed {
+			tc.Failure = &junitFailure{
+				Message: f.Message,
+				Type:    f.Rule,
+				Body:    f.Location,
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	doc := junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// writeSARIF renders every rule that was checked but only the failed findings
+// as results, as code-scanning tools treat each result as an alert.
+func writeSARIF(w io.Writer, r Report) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "fastly",
+				InformationURI: "https://github.com/fastly/cli",
+				Version:        revision.AppVersion,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range r.Findings {
+		if !seen[f.Rule] {
+			seen[f.Rule] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               f.Rule,
+				ShortDescription: sarifMessage{Text: f.Rule},
+			})
+		}
+		if !f.Failed {
+			continue
+		}
+		result := sarifResult{
+			RuleID:  f.Rule,
+			Level:   "error",
+			Message: sarifMessage{Text: f.Message},
+		}
+		if f.Location != "" {
+			result.Locations = []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: f.Location},
+					},
+				},
+			}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
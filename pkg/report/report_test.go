@@ -0,0 +1,91 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fastly/cli/pkg/report"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+var fixture = report.Report{
+	Command: "compute validate",
+	Findings: []report.Finding{
+		{
+			Rule:     "package-required-file",
+			Name:     "package contains fastly.toml",
+			Location: "pkg/package.tar.gz",
+		},
+		{
+			Rule:     "package-required-file",
+			Name:     "package contains main.wasm",
+			Message:  "package must contain a main.wasm file",
+			Location: "pkg/package.tar.gz",
+			Failed:   true,
+		},
+	},
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.Write(&buf, report.FormatJUnit, fixture); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, junitOutput, buf.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.Write(&buf, report.FormatSARIF, fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Level   string `json:"level"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertString(t, "2.1.0", log.Version)
+	testutil.AssertEqual(t, 1, len(log.Runs))
+	testutil.AssertEqual(t, 1, len(log.Runs[0].Tool.Driver.Rules))
+	testutil.AssertEqual(t, 1, len(log.Runs[0].Results))
+	testutil.AssertString(t, "package-required-file", log.Runs[0].Results[0].RuleID)
+	testutil.AssertString(t, "error", log.Runs[0].Results[0].Level)
+	testutil.AssertString(t, "package must contain a main.wasm file", log.Runs[0].Results[0].Message.Text)
+}
+
+func TestWriteUnsupported(t *testing.T) {
+	var buf bytes.Buffer
+	err := report.Write(&buf, "html", fixture)
+	testutil.AssertErrorContains(t, err, "unsupported report format: html")
+}
+
+var junitOutput = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1">
+  <testsuite name="compute validate" tests="2" failures="1">
+    <testcase classname="compute validate" name="package contains fastly.toml"></testcase>
+    <testcase classname="compute validate" name="package contains main.wasm">
+      <failure message="package must contain a main.wasm file" type="package-required-file">pkg/package.tar.gz</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
@@ -0,0 +1,4 @@
+// Package smoketest runs HTTP assertions, described by a YAML spec file,
+// against the domains of a service, e.g. to check a version after it's
+// activated.
+package smoketest
@@ -0,0 +1,272 @@
+package smoketest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/adapter"
+	"github.com/fastly/cli/pkg/text"
+	"gopkg.in/yaml.v2"
+)
+
+// RetryInterval is how long to wait before retrying failed tests.
+var RetryInterval = 5 * time.Second
+
+// Timeout is the time limit of each test's request.
+const Timeout = 10 * time.Second
+
+// maxBodySize is the size of the start of a response body that's searched by
+// a body_contains assertion.
+const maxBodySize = 1 << 20
+
+// Spec is a list of tests, read from a YAML file such as:
+//
+//	scheme: https
+//	tests:
+//	  - name: homepage
+//	    path: /
+//	    status: 200
+//	    response_headers:
+//	      cache-control: max-age
+//	  - name: redirect
+//	    path: /old
+//	    request_headers:
+//	      accept: text/html
+//	    status: 301
+//	    response_headers:
+//	      location: /new
+//	  - url: https://www.example.com/health
+//	    body_contains: OK
+type Spec struct {
+	// Domains are the domains the tests' paths are requested from. It
+	// defaults to the domains of the service version being tested.
+	Domains []string `yaml:"domains"`
+	// Scheme is the scheme of the URLs requested (default: https).
+	Scheme string `yaml:"scheme"`
+	Tests  []Test `yaml:"tests"`
+}
+
+// Test is a request and the assertions made about its response.
+type Test struct {
+	Name string `yaml:"name"`
+	// Method is the request method (default: GET).
+	Method string `yaml:"method"`
+	// Path is requested from each of the spec's domains.
+	Path string `yaml:"path"`
+	// URL is requested instead of Path, regardless of the spec's domains.
+	URL            string            `yaml:"url"`
+	RequestHeaders map[string]string `yaml:"request_headers"`
+	// Status is the expected response status (default: 200).
+	Status int `yaml:"status"`
+	// ResponseHeaders are the expected response headers. Each value must be
+	// contained in the header, so an empty value only requires the header to
+	// be present.
+	ResponseHeaders map[string]string `yaml:"response_headers"`
+	// BodyContains is text the response body must contain.
+	BodyContains string `yaml:"body_contains"`
+}
+
+// ReadSpec reads and validates the spec in the YAML file at path.
+func ReadSpec(path string) (*Spec, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as the spec is a file provided by the user.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading smoke test spec: %w", err)
+	}
+	var s Spec
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, fmt.Errorf("error parsing smoke test spec %s: %w", path, err)
+	}
+	if len(s.Tests) == 0 {
+		return nil, fmt.Errorf("smoke test spec %s has no tests", path)
+	}
+	for i, t := range s.Tests {
+		if (t.Path == "") == (t.URL == "") {
+			return nil, fmt.Errorf("smoke test %d of %s must have either a path or a url", i+1, path)
+		}
+	}
+	return &s, nil
+}
+
+// Result is the outcome of a test's request.
+type Result struct {
+	Test   string
+	Method string
+	URL    string
+	// Err describes why the test failed, or is nil if it passed.
+	Err error
+}
+
+// Run runs the tests of s, requesting their paths from domains unless the
+// spec lists its own. Failed tests are retried every RetryInterval until they
+// pass or wait has elapsed (so a wait of zero runs the tests once).
+//
+// The requests are made via transport, or http.DefaultTransport if it's nil,
+// and redirects aren't followed so that their status can be asserted.
+func Run(ctx context.Context, transport http.RoundTripper, s *Spec, domains []string, wait time.Duration) []Result {
+	if len(s.Domains) > 0 {
+		domains = s.Domains
+	}
+	scheme := s.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout:   Timeout,
+		Transport: transport,
+	}
+
+	// Each test is run once per domain, unless it has its own URL.
+	var (
+		results []Result
+		tests   []Test
+	)
+	for i, t := range s.Tests {
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		method := t.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		urls := []string{t.URL}
+		if t.URL == "" {
+			urls = nil
+			for _, d := range domains {
+				// A wildcard domain doesn't identify a host to request.
+				if strings.Contains(d, "*") {
+					continue
+				}
+				urls = append(urls, scheme+"://"+d+t.Path)
+			}
+		}
+		for _, u := range urls {
+			results = append(results, Result{Test: name, Method: method, URL: u, Err: errNotRun})
+			tests = append(tests, t)
+		}
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		failed := 0
+		for i := range results {
+			if results[i].Err == nil {
+				continue
+			}
+			results[i].Err = check(ctx, client, tests[i], results[i])
+			if results[i].Err != nil {
+				failed++
+			}
+		}
+		if failed == 0 || time.Now().Add(RetryInterval).After(deadline) {
+			return results
+		}
+		select {
+		case <-ctx.Done():
+			return results
+		case <-time.After(RetryInterval):
+		}
+	}
+}
+
+// errNotRun is the result of a test that hasn't been run yet.
+var errNotRun = errors.New("not run")
+
+// check makes the request of test t, as described by r, and returns an error
+// describing the first of its assertions that failed.
+func check(ctx context.Context, client *http.Client, t Test, r Result) error {
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range t.RequestHeaders {
+		if strings.EqualFold(k, "host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	want := t.Status
+	if want == 0 {
+		want = http.StatusOK
+	}
+	if resp.StatusCode != want {
+		return fmt.Errorf("status %d, want %d", resp.StatusCode, want)
+	}
+	for k, v := range t.ResponseHeaders {
+		values, ok := resp.Header[http.CanonicalHeaderKey(k)]
+		if !ok {
+			return fmt.Errorf("no %s header", k)
+		}
+		if !strings.Contains(strings.Join(values, ", "), v) {
+			return fmt.Errorf("%s header is %q, want it to contain %q", k, strings.Join(values, ", "), v)
+		}
+	}
+	if t.BodyContains != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		if err != nil {
+			return fmt.Errorf("error reading body: %w", err)
+		}
+		if !strings.Contains(string(body), t.BodyContains) {
+			return fmt.Errorf("body doesn't contain %q", t.BodyContains)
+		}
+	}
+	return nil
+}
+
+// Report displays results and returns an error if any of them failed.
+func Report(out io.Writer, results []Result) error {
+	var failed int
+	t := text.NewTable(out)
+	t.AddHeader("TEST", "METHOD", "URL", "RESULT")
+	for _, r := range results {
+		result := "ok"
+		if r.Err != nil {
+			result = "FAIL: " + r.Err.Error()
+			failed++
+		}
+		t.AddLine(r.Test, r.Method, r.URL, result)
+	}
+	t.Print()
+
+	if len(results) == 0 {
+		return fmt.Errorf("no smoke tests were run, as there were no domains to test")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d smoke tests failed", failed, len(results))
+	}
+	return nil
+}
+
+// PreviousVersion returns the version to roll back to from version current:
+// the most recent version before it that's locked (i.e. was activated), or
+// nil if there's none.
+func PreviousVersion(versions []*adapter.Version, current int) *adapter.Version {
+	var prev *adapter.Version
+	for _, v := range versions {
+		if v.Number < current && v.Locked && (prev == nil || v.Number > prev.Number) {
+			prev = v
+		}
+	}
+	return prev
+}
@@ -0,0 +1,132 @@
+package smoketest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/adapter"
+	"github.com/fastly/cli/pkg/smoketest"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestReadSpec(t *testing.T) {
+	dir := t.TempDir()
+	for _, testcase := range []struct {
+		name      string
+		spec      string
+		wantError string
+	}{
+		{
+			name: "valid",
+			spec: "tests:\n  - path: /\n  - url: https://example.com/\n",
+		},
+		{
+			name:      "no tests",
+			spec:      "scheme: http\n",
+			wantError: "has no tests",
+		},
+		{
+			name:      "path and url",
+			spec:      "tests:\n  - path: /\n    url: https://example.com/\n",
+			wantError: "smoke test 1 of",
+		},
+		{
+			name:      "unknown field",
+			spec:      "tests:\n  - path: /\n    code: 200\n",
+			wantError: "field code not found",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			path := filepath.Join(dir, testcase.name+".yaml")
+			if err := os.WriteFile(path, []byte(testcase.spec), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := smoketest.ReadSpec(path)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/":
+			w.Header().Set("Cache-Control", "public, max-age=60")
+			w.Write([]byte("Welcome, " + r.Header.Get("X-Name")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	spec := &smoketest.Spec{
+		Scheme: "http",
+		Tests: []smoketest.Test{
+			{
+				Name:            "homepage",
+				Path:            "/",
+				RequestHeaders:  map[string]string{"X-Name": "Fastly"},
+				ResponseHeaders: map[string]string{"cache-control": "max-age=60"},
+				BodyContains:    "Welcome, Fastly",
+			},
+			{Name: "redirect", Path: "/old", Status: http.StatusMovedPermanently, ResponseHeaders: map[string]string{"Location": "/new"}},
+			{Name: "header", Path: "/", ResponseHeaders: map[string]string{"Cache-Control": "private"}},
+			{Name: "missing", URL: ts.URL + "/missing"},
+		},
+	}
+	results := smoketest.Run(context.Background(), nil, spec, []string{host, "*.example.com"}, 0)
+
+	var have []string
+	for _, r := range results {
+		msg := "ok"
+		if r.Err != nil {
+			msg = r.Err.Error()
+		}
+		have = append(have, r.Test+" "+strings.TrimPrefix(r.URL, ts.URL)+": "+msg)
+	}
+	testutil.AssertEqual(t, []string{
+		"homepage /: ok",
+		"redirect /old: ok",
+		`header /: Cache-Control header is "public, max-age=60", want it to contain "private"`,
+		"missing /missing: status 404, want 200",
+	}, have)
+}
+
+func TestRunRetries(t *testing.T) {
+	defer func(d time.Duration) { smoketest.RetryInterval = d }(smoketest.RetryInterval)
+	smoketest.RetryInterval = time.Millisecond
+
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	spec := &smoketest.Spec{Tests: []smoketest.Test{{URL: ts.URL}}}
+	results := smoketest.Run(context.Background(), nil, spec, nil, time.Minute)
+	testutil.AssertNoError(t, results[0].Err)
+	testutil.AssertEqual(t, 3, requests)
+}
+
+func TestPreviousVersion(t *testing.T) {
+	versions := []*adapter.Version{
+		{Number: 1, Locked: true},
+		{Number: 2, Locked: true},
+		{Number: 3},
+		{Number: 4, Locked: true, Active: true},
+	}
+	testutil.AssertEqual(t, 2, smoketest.PreviousVersion(versions, 4).Number)
+	testutil.AssertEqual(t, (*adapter.Version)(nil), smoketest.PreviousVersion(versions, 1))
+}
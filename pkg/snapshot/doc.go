@@ -0,0 +1,3 @@
+// Package snapshot fetches the configuration of service versions for commands
+// that look at many resources at once.
+package snapshot
@@ -0,0 +1,361 @@
+package snapshot
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Concurrency is the default number of API requests made at once.
+const Concurrency = 8
+
+// Snapshot is the configuration of a service version. Each type of resource is
+// fetched from the API the first time it's needed and then remembered, so
+// commands that look at the same resources more than once don't repeat API
+// calls. A Snapshot is safe for concurrent use.
+type Snapshot struct {
+	ServiceID string
+	Version   int
+
+	client api.Interface
+	mu     sync.Mutex
+	calls  map[string]*call
+}
+
+// call is a memoized API call.
+type call struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// New returns a snapshot of the service version.
+func New(client api.Interface, serviceID string, version int) *Snapshot {
+	return &Snapshot{
+		ServiceID: serviceID,
+		Version:   version,
+		client:    client,
+		calls:     make(map[string]*call),
+	}
+}
+
+// Resources are the types of resource that Fetch accepts.
+var Resources = []string{
+	"acl",
+	"backend",
+	"cache_setting",
+	"condition",
+	"dictionary",
+	"domain",
+	"elasticsearch",
+	"gzip",
+	"header",
+	"healthcheck",
+	"https",
+	"kafka",
+	"snippet",
+	"splunk",
+	"syslog",
+	"vcl",
+}
+
+// fetchers fetch each type of resource into the snapshot.
+var fetchers = map[string]func(s *Snapshot) error{
+	"acl":           func(s *Snapshot) error { _, err := s.ACLs(); return err },
+	"backend":       func(s *Snapshot) error { _, err := s.Backends(); return err },
+	"cache_setting": func(s *Snapshot) error { _, err := s.CacheSettings(); return err },
+	"condition":     func(s *Snapshot) error { _, err := s.Conditions(); return err },
+	"dictionary":    func(s *Snapshot) error { _, err := s.Dictionaries(); return err },
+	"domain":        func(s *Snapshot) error { _, err := s.Domains(); return err },
+	"elasticsearch": func(s *Snapshot) error { _, err := s.Elasticsearch(); return err },
+	"gzip":          func(s *Snapshot) error { _, err := s.Gzips(); return err },
+	"header":        func(s *Snapshot) error { _, err := s.Headers(); return err },
+	"healthcheck":   func(s *Snapshot) error { _, err := s.HealthChecks(); return err },
+	"https":         func(s *Snapshot) error { _, err := s.HTTPS(); return err },
+	"kafka":         func(s *Snapshot) error { _, err := s.Kafkas(); return err },
+	"snippet":       func(s *Snapshot) error { _, err := s.Snippets(); return err },
+	"splunk":        func(s *Snapshot) error { _, err := s.Splunks(); return err },
+	"syslog":        func(s *Snapshot) error { _, err := s.Syslogs(); return err },
+	"vcl":           func(s *Snapshot) error { _, err := s.VCLs(); return err },
+}
+
+// Fetch fetches the given types of resource concurrently, so that later calls
+// for them return immediately.
+func (s *Snapshot) Fetch(resources ...string) error {
+	for _, r := range resources {
+		if _, ok := fetchers[r]; !ok {
+			return fmt.Errorf("unknown resource type '%s'", r)
+		}
+	}
+	return ForEach(len(resources), Concurrency, func(i int) error {
+		if err := fetchers[resources[i]](s); err != nil {
+			return fmt.Errorf("error fetching %s resources: %w", resources[i], err)
+		}
+		return nil
+	})
+}
+
+// fetch returns the result of f, calling it only the first time the resource
+// is fetched.
+func (s *Snapshot) fetch(resource string, f func() (interface{}, error)) (interface{}, error) {
+	s.mu.Lock()
+	c, ok := s.calls[resource]
+	if !ok {
+		c = &call{}
+		s.calls[resource] = c
+	}
+	s.mu.Unlock()
+
+	c.once.Do(func() {
+		c.value, c.err = f()
+	})
+	return c.value, c.err
+}
+
+// ACLs returns the ACLs of the service version.
+func (s *Snapshot) ACLs() ([]*fastly.ACL, error) {
+	v, err := s.fetch("acl", func() (interface{}, error) {
+		return s.client.ListACLs(&fastly.ListACLsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.ACL), nil
+}
+
+// Backends returns the backends of the service version.
+func (s *Snapshot) Backends() ([]*fastly.Backend, error) {
+	v, err := s.fetch("backend", func() (interface{}, error) {
+		return s.client.ListBackends(&fastly.ListBackendsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Backend), nil
+}
+
+// CacheSettings returns the cache settings of the service version.
+func (s *Snapshot) CacheSettings() ([]*fastly.CacheSetting, error) {
+	v, err := s.fetch("cache_setting", func() (interface{}, error) {
+		return s.client.ListCacheSettings(&fastly.ListCacheSettingsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.CacheSetting), nil
+}
+
+// Conditions returns the conditions of the service version.
+func (s *Snapshot) Conditions() ([]*fastly.Condition, error) {
+	v, err := s.fetch("condition", func() (interface{}, error) {
+		return s.client.ListConditions(&fastly.ListConditionsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Condition), nil
+}
+
+// Dictionaries returns the dictionaries of the service version.
+func (s *Snapshot) Dictionaries() ([]*fastly.Dictionary, error) {
+	v, err := s.fetch("dictionary", func() (interface{}, error) {
+		return s.client.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Dictionary), nil
+}
+
+// Domains returns the domains of the service version.
+func (s *Snapshot) Domains() ([]*fastly.Domain, error) {
+	v, err := s.fetch("domain", func() (interface{}, error) {
+		return s.client.ListDomains(&fastly.ListDomainsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Domain), nil
+}
+
+// Elasticsearch returns the Elasticsearch logging endpoints of the service
+// version.
+func (s *Snapshot) Elasticsearch() ([]*fastly.Elasticsearch, error) {
+	v, err := s.fetch("elasticsearch", func() (interface{}, error) {
+		return s.client.ListElasticsearch(&fastly.ListElasticsearchInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Elasticsearch), nil
+}
+
+// Gzips returns the gzip configurations of the service version.
+func (s *Snapshot) Gzips() ([]*fastly.Gzip, error) {
+	v, err := s.fetch("gzip", func() (interface{}, error) {
+		return s.client.ListGzips(&fastly.ListGzipsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Gzip), nil
+}
+
+// Headers returns the headers of the service version.
+func (s *Snapshot) Headers() ([]*fastly.Header, error) {
+	v, err := s.fetch("header", func() (interface{}, error) {
+		return s.client.ListHeaders(&fastly.ListHeadersInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Header), nil
+}
+
+// HealthChecks returns the healthchecks of the service version.
+func (s *Snapshot) HealthChecks() ([]*fastly.HealthCheck, error) {
+	v, err := s.fetch("healthcheck", func() (interface{}, error) {
+		return s.client.ListHealthChecks(&fastly.ListHealthChecksInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.HealthCheck), nil
+}
+
+// HTTPS returns the HTTPS logging endpoints of the service version.
+func (s *Snapshot) HTTPS() ([]*fastly.HTTPS, error) {
+	v, err := s.fetch("https", func() (interface{}, error) {
+		return s.client.ListHTTPS(&fastly.ListHTTPSInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.HTTPS), nil
+}
+
+// Kafkas returns the Kafka logging endpoints of the service version.
+func (s *Snapshot) Kafkas() ([]*fastly.Kafka, error) {
+	v, err := s.fetch("kafka", func() (interface{}, error) {
+		return s.client.ListKafkas(&fastly.ListKafkasInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Kafka), nil
+}
+
+// Snippets returns the VCL snippets of the service version.
+func (s *Snapshot) Snippets() ([]*fastly.Snippet, error) {
+	v, err := s.fetch("snippet", func() (interface{}, error) {
+		return s.client.ListSnippets(&fastly.ListSnippetsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Snippet), nil
+}
+
+// Splunks returns the Splunk logging endpoints of the service version.
+func (s *Snapshot) Splunks() ([]*fastly.Splunk, error) {
+	v, err := s.fetch("splunk", func() (interface{}, error) {
+		return s.client.ListSplunks(&fastly.ListSplunksInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Splunk), nil
+}
+
+// Syslogs returns the syslog logging endpoints of the service version.
+func (s *Snapshot) Syslogs() ([]*fastly.Syslog, error) {
+	v, err := s.fetch("syslog", func() (interface{}, error) {
+		return s.client.ListSyslogs(&fastly.ListSyslogsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.Syslog), nil
+}
+
+// VCLs returns the custom VCL files of the service version.
+func (s *Snapshot) VCLs() ([]*fastly.VCL, error) {
+	v, err := s.fetch("vcl", func() (interface{}, error) {
+		return s.client.ListVCLs(&fastly.ListVCLsInput{ServiceID: s.ServiceID, ServiceVersion: s.Version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*fastly.VCL), nil
+}
+
+// Cache hands out one snapshot per service version, so commands that compare
+// versions (or revisit them) share the resources already fetched. A Cache is
+// safe for concurrent use.
+type Cache struct {
+	client    api.Interface
+	mu        sync.Mutex
+	snapshots map[cacheKey]*Snapshot
+}
+
+type cacheKey struct {
+	serviceID string
+	version   int
+}
+
+// NewCache returns an empty cache.
+func NewCache(client api.Interface) *Cache {
+	return &Cache{
+		client:    client,
+		snapshots: make(map[cacheKey]*Snapshot),
+	}
+}
+
+// Get returns the snapshot of the service version.
+func (c *Cache) Get(serviceID string, version int) *Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := cacheKey{serviceID, version}
+	s, ok := c.snapshots[k]
+	if !ok {
+		s = New(c.client, serviceID, version)
+		c.snapshots[k] = s
+	}
+	return s
+}
+
+// ForEach calls f with each index from 0 to n-1, running up to concurrency
+// calls at once. Once every call has returned, the error of the lowest index
+// that failed is returned, so the result doesn't depend on scheduling.
+func ForEach(n, concurrency int, f func(i int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, n)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
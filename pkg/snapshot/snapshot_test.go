@@ -0,0 +1,77 @@
+package snapshot_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/snapshot"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestSnapshot(t *testing.T) {
+	var calls int32
+	client := mock.API{
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			atomic.AddInt32(&calls, 1)
+			testutil.AssertString(t, "123", i.ServiceID)
+			testutil.AssertEqual(t, 2, i.ServiceVersion)
+			return []*fastly.Backend{{Name: "origin"}}, nil
+		},
+		ListDomainsFn: func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+			return nil, testutil.Err
+		},
+	}
+
+	cache := snapshot.NewCache(client)
+	s := cache.Get("123", 2)
+	if cache.Get("123", 2) != s {
+		t.Fatal("want the same snapshot for the same service version")
+	}
+	if cache.Get("123", 3) == s {
+		t.Fatal("want a different snapshot for a different service version")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			backends, err := s.Backends()
+			if err != nil || len(backends) != 1 {
+				t.Errorf("want one backend, have %v (%v)", backends, err)
+			}
+		}()
+	}
+	wg.Wait()
+	testutil.AssertErrorContains(t, s.Fetch("backend"), "")
+	testutil.AssertEqual(t, int32(1), atomic.LoadInt32(&calls))
+
+	testutil.AssertErrorContains(t, s.Fetch("backend", "domain"), "error fetching domain resources: "+testutil.Err.Error())
+	testutil.AssertErrorContains(t, s.Fetch("widget"), "unknown resource type 'widget'")
+}
+
+func TestForEach(t *testing.T) {
+	var running, peak int32
+	err := snapshot.ForEach(20, 3, func(i int) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		if i == 7 || i == 15 {
+			return fmt.Errorf("failed %d", i)
+		}
+		return nil
+	})
+	testutil.AssertErrorContains(t, err, "failed 7")
+	if peak > 3 {
+		t.Fatalf("want at most 3 concurrent calls, have %d", peak)
+	}
+}
@@ -2,6 +2,8 @@ package testutil
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -111,7 +113,10 @@ func AssertPathContentFlag(flag string, wantError string, args []string, fixture
 			if a == fmt.Sprintf("--%s", flag) {
 				want := args[i+1]
 				if want == fmt.Sprintf("./testdata/%s", fixture) {
-					want = cmd.Content(want)
+					var err error
+					if want, err = cmd.Content(want, nil); err != nil {
+						t.Fatal(err)
+					}
 				}
 				if content != want {
 					t.Errorf("wanted %s, have %s", want, content)
@@ -121,3 +126,35 @@ func AssertPathContentFlag(flag string, wantError string, args []string, fixture
 		}
 	}
 }
+
+// UpdateGolden is whether AssertGolden updates golden files, rather than
+// comparing against them. It's enabled by setting FASTLY_UPDATE_GOLDEN, e.g.
+//
+//	FASTLY_UPDATE_GOLDEN=1 go test ./...
+var UpdateGolden = os.Getenv("FASTLY_UPDATE_GOLDEN") != ""
+
+// AssertGolden fatals a test if have doesn't match the content of the golden
+// file at path. If UpdateGolden is set, the file is written with have instead.
+func AssertGolden(t *testing.T, path, have string) {
+	t.Helper()
+	if UpdateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(have), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as the path is determined by the test.
+	/* #nosec */
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading golden file (set FASTLY_UPDATE_GOLDEN to create it): %v", err)
+	}
+	AssertString(t, string(want), have)
+}
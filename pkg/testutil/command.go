@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/kingpin"
+)
+
+// CommandFactory constructs a command beneath parent, in the same way as the
+// constructors of the CLI's own commands (e.g. backend.NewCreateCommand).
+type CommandFactory func(parent cmd.Registerer, globals *config.Data) cmd.Command
+
+// RunCommand runs a command that isn't registered with app.Run (e.g. one from
+// a fork or an extension of the CLI) with the scenario's arguments and mock
+// API, and returns its output. The arguments start with the command's name,
+// and may include the --token and --verbose global flags.
+func RunCommand(newCommand CommandFactory, scenario TestScenario) (string, error) {
+	var stdout bytes.Buffer
+	globals := config.Data{
+		Env:         scenario.Env,
+		ErrLog:      errors.MockLog{},
+		HTTPClient:  http.DefaultClient,
+		Output:      &stdout,
+		Diagnostics: io.Discard,
+		Context:     context.Background(),
+		Path:        "/dev/null",
+	}
+
+	a := kingpin.New("fastly", "")
+	a.Writers(io.Discard, io.Discard)
+	a.Terminate(nil)
+	a.Flag("token", "").Short('t').StringVar(&globals.Flag.Token)
+	a.Flag("verbose", "").Short('v').CounterVar(&globals.Flag.VerboseLevel)
+	command := newCommand(a, &globals)
+
+	name, err := a.Parse(scenario.Args)
+	if err != nil {
+		return stdout.String(), err
+	}
+	if name != command.Name() && !strings.HasPrefix(name, command.Name()+" ") {
+		return stdout.String(), fmt.Errorf("command '%s' isn't '%s'", name, command.Name())
+	}
+	globals.Flag.Verbose = globals.Flag.VerboseLevel > 0
+
+	globals.APIClient, err = mock.APIClient(scenario.API)("", "")
+	if err != nil {
+		return stdout.String(), err
+	}
+
+	err = command.Exec(strings.NewReader(""), &stdout)
+	return stdout.String(), err
+}
+
+// RunCommandScenarios runs each scenario as a subtest, via RunCommand, and
+// validates the result (see TestScenario.Assert).
+func RunCommandScenarios(t *testing.T, newCommand CommandFactory, scenarios []TestScenario) {
+	t.Helper()
+	for _, testcase := range scenarios {
+		testcase := testcase
+		t.Run(testcase.Name, func(t *testing.T) {
+			output, err := RunCommand(newCommand, testcase)
+			testcase.Assert(t, output, err)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package testutil_test
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestRunCommandScenarios(t *testing.T) {
+	testutil.RunCommandScenarios(t, newVersionsCommand, []testutil.TestScenario{
+		{
+			Name:      "validate missing --service-id flag",
+			Args:      testutil.Args("versions"),
+			WantError: "required flag --service-id not provided",
+		},
+		{
+			Name:      "validate unknown command",
+			Args:      testutil.Args("other"),
+			WantError: "expected command but got other",
+		},
+		{
+			Name: "validate API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersionsError,
+			},
+			Args:      testutil.Args("versions --service-id 123"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate output",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:       testutil.Args("versions --service-id 123"),
+			WantOutput: "Version 1",
+			WantGolden: filepath.Join("testdata", "versions.golden"),
+		},
+	})
+}
+
+// versionsCommand is an example of a command that isn't part of the CLI.
+type versionsCommand struct {
+	cmd.Base
+	serviceID string
+}
+
+func newVersionsCommand(parent cmd.Registerer, globals *config.Data) cmd.Command {
+	var c versionsCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("versions", "List the versions of a service")
+	c.CmdClause.Flag("service-id", "Service ID").Required().StringVar(&c.serviceID)
+	return &c
+}
+
+func (c *versionsCommand) Exec(in io.Reader, out io.Writer) error {
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{ServiceID: c.serviceID})
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		fmt.Fprintf(out, "Version %d (active: %t)\n", v.Number, v.Active)
+	}
+	return nil
+}
@@ -1,2 +1,8 @@
 // Package testutil provides helpers for unit tests.
+//
+// The helpers are also intended for the authors of commands that extend the
+// CLI (e.g. in a fork), so those commands can be tested in the same way as the
+// CLI's own: scenarios are run against a mock API (see RunScenarios and
+// RunCommandScenarios), and their output is compared against expected output
+// or golden files (see AssertGolden).
 package testutil
@@ -0,0 +1,49 @@
+package testutil
+
+// Paginator is a mock of the API client's paginators, which returns the given
+// pages of a list in the same way.
+type Paginator[T any] struct {
+	// Err, if set, is returned by every call to GetNext.
+	Err error
+
+	consumed bool
+	current  int
+	pages    [][]T
+	start    int
+}
+
+// NewPaginator returns a paginator of pages that starts at the given page, as
+// set by a list input's Page field (where 0 is the first page).
+func NewPaginator[T any](page int, pages ...[]T) *Paginator[T] {
+	return &Paginator[T]{pages: pages, start: page}
+}
+
+// HasNext returns whether there are more pages.
+func (p *Paginator[T]) HasNext() bool {
+	return !p.consumed || p.Remaining() > 0
+}
+
+// Remaining returns the number of pages after the current one.
+func (p *Paginator[T]) Remaining() int {
+	if p.current >= len(p.pages) {
+		return 0
+	}
+	return len(p.pages) - p.current
+}
+
+// GetNext returns the next page, which is empty if it's past the last one.
+func (p *Paginator[T]) GetNext() ([]T, error) {
+	if !p.consumed && p.start > 0 {
+		p.current = p.start
+	} else {
+		p.current++
+	}
+	p.consumed = true
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if p.current > len(p.pages) {
+		return nil, nil
+	}
+	return p.pages[p.current-1], nil
+}
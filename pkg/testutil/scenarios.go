@@ -1,6 +1,13 @@
 package testutil
 
-import "github.com/fastly/cli/pkg/mock"
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+)
 
 // TestScenario represents a standard test case to be validated.
 type TestScenario struct {
@@ -10,4 +17,42 @@ type TestScenario struct {
 	WantError   string
 	WantOutput  string
 	WantOutputs []string
+
+	// Env is the environment the scenario is run with.
+	Env config.Environment
+	// WantGolden is the path of a file (typically in testdata) whose content
+	// the output must match exactly (see AssertGolden).
+	WantGolden string
+}
+
+// RunScenarios runs each scenario as a subtest, via app.Run with the
+// scenario's mock API, and validates the result (see TestScenario.Assert).
+func RunScenarios(t *testing.T, scenarios []TestScenario) {
+	t.Helper()
+	for _, testcase := range scenarios {
+		testcase := testcase
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			opts.Env = testcase.Env
+			err := app.Run(opts)
+			testcase.Assert(t, stdout.String(), err)
+		})
+	}
+}
+
+// Assert fatals a test if the output and error of running the scenario aren't
+// what it wants. The error must contain WantError (or be nil if WantError is
+// empty), and the output must contain WantOutput and each of WantOutputs.
+func (s TestScenario) Assert(t *testing.T, output string, err error) {
+	t.Helper()
+	AssertErrorContains(t, err, s.WantError)
+	AssertStringContains(t, output, s.WantOutput)
+	for _, want := range s.WantOutputs {
+		AssertStringContains(t, output, want)
+	}
+	if s.WantGolden != "" {
+		AssertGolden(t, s.WantGolden, output)
+	}
 }
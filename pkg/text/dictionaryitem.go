@@ -4,28 +4,28 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/fastly/cli/pkg/time"
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/segmentio/textio"
 )
 
 // PrintDictionaryItem pretty prints a fastly.DictionaryInfo structure in verbose
 // format to a given io.Writer. Consumers can provide a prefix string which
-// will be used as a prefix to each line, useful for indentation.
-func PrintDictionaryItem(out io.Writer, prefix string, d *fastly.DictionaryItem) {
+// will be used as a prefix to each line, useful for indentation. Timestamps
+// are rendered using f.
+func PrintDictionaryItem(out io.Writer, prefix string, d *fastly.DictionaryItem, f Formatter) {
 	out = textio.NewPrefixWriter(out, prefix)
 
 	fmt.Fprintf(out, "Dictionary ID: %s\n", d.DictionaryID)
 	fmt.Fprintf(out, "Item Key: %s\n", d.ItemKey)
 	fmt.Fprintf(out, "Item Value: %s\n", d.ItemValue)
 	if d.CreatedAt != nil {
-		fmt.Fprintf(out, "Created (UTC): %s\n", d.CreatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Created (UTC): %s\n", f.UTCTime(*d.CreatedAt))
 	}
 	if d.UpdatedAt != nil {
-		fmt.Fprintf(out, "Last edited (UTC): %s\n", d.UpdatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Last edited (UTC): %s\n", f.UTCTime(*d.UpdatedAt))
 	}
 	if d.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted (UTC): %s\n", d.DeletedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Deleted (UTC): %s\n", f.UTCTime(*d.DeletedAt))
 	}
 }
 
@@ -23,7 +23,7 @@ func TestPrintDictionaryItem(t *testing.T) {
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			text.PrintDictionaryItem(&buf, "", testcase.dictionaryItem)
+			text.PrintDictionaryItem(&buf, "", testcase.dictionaryItem, text.Formatter{})
 			testutil.AssertString(t, testcase.wantOutput, buf.String())
 		})
 	}
@@ -0,0 +1,60 @@
+package text
+
+import (
+	"fmt"
+	"time"
+
+	fsttime "github.com/fastly/cli/pkg/time"
+)
+
+// Formatter controls how timestamps and byte quantities are rendered in
+// human readable output.
+type Formatter struct {
+	// UTC converts timestamps to UTC before they are formatted.
+	UTC bool
+	// ISO8601 renders timestamps using RFC 3339 (a profile of ISO 8601).
+	ISO8601 bool
+	// HumanSizes renders byte quantities using binary units (KiB, MiB, GiB).
+	HumanSizes bool
+}
+
+// Time formats t in its own location using fsttime.Layout, unless the
+// formatter requests UTC or ISO 8601 output.
+func (f Formatter) Time(t time.Time) string {
+	if f.UTC {
+		t = t.UTC()
+	}
+	return f.format(t, fsttime.Layout)
+}
+
+// UTCTime formats t in UTC using the abbreviated format of the Fastly web UI,
+// unless the formatter requests ISO 8601 output. It's used for fields that
+// are labelled as UTC.
+func (f Formatter) UTCTime(t time.Time) string {
+	return f.format(t.UTC(), fsttime.Format)
+}
+
+func (f Formatter) format(t time.Time, layout string) string {
+	if f.ISO8601 {
+		layout = time.RFC3339
+	}
+	return t.Format(layout)
+}
+
+// Bytes formats n as a plain integer, or as a binary multiple (e.g. 1.5 GiB)
+// when the formatter requests human readable sizes.
+func (f Formatter) Bytes(n uint64) string {
+	if !f.HumanSizes {
+		return fmt.Sprintf("%d", n)
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
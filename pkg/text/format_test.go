@@ -0,0 +1,67 @@
+package text_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestFormatterTime(t *testing.T) {
+	ts := time.Date(2021, time.June, 15, 23, 30, 5, 0, time.FixedZone("BST", 60*60))
+
+	for _, testcase := range []struct {
+		name      string
+		formatter text.Formatter
+		wantTime  string
+		wantUTC   string
+	}{
+		{
+			name:     "default",
+			wantTime: "2021-06-15 23:30:05 +0100 BST",
+			wantUTC:  "2021-06-15 22:30",
+		},
+		{
+			name:      "utc",
+			formatter: text.Formatter{UTC: true},
+			wantTime:  "2021-06-15 22:30:05 +0000 UTC",
+			wantUTC:   "2021-06-15 22:30",
+		},
+		{
+			name:      "iso8601",
+			formatter: text.Formatter{ISO8601: true},
+			wantTime:  "2021-06-15T23:30:05+01:00",
+			wantUTC:   "2021-06-15T22:30:05Z",
+		},
+		{
+			name:      "utc iso8601",
+			formatter: text.Formatter{UTC: true, ISO8601: true},
+			wantTime:  "2021-06-15T22:30:05Z",
+			wantUTC:   "2021-06-15T22:30:05Z",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertString(t, testcase.wantTime, testcase.formatter.Time(ts))
+			testutil.AssertString(t, testcase.wantUTC, testcase.formatter.UTCTime(ts))
+		})
+	}
+}
+
+func TestFormatterBytes(t *testing.T) {
+	for _, testcase := range []struct {
+		n     uint64
+		plain string
+		human string
+	}{
+		{n: 0, plain: "0", human: "0 B"},
+		{n: 1023, plain: "1023", human: "1023 B"},
+		{n: 1024, plain: "1024", human: "1.0 KiB"},
+		{n: 1536, plain: "1536", human: "1.5 KiB"},
+		{n: 5 * 1024 * 1024, plain: "5242880", human: "5.0 MiB"},
+		{n: 3 << 30, plain: "3221225472", human: "3.0 GiB"},
+	} {
+		testutil.AssertString(t, testcase.plain, text.Formatter{}.Bytes(testcase.n))
+		testutil.AssertString(t, testcase.human, text.Formatter{HumanSizes: true}.Bytes(testcase.n))
+	}
+}
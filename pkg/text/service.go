@@ -4,15 +4,15 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/fastly/cli/pkg/time"
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/segmentio/textio"
 )
 
 // PrintService pretty prints a fastly.Service structure in verbose format
 // to a given io.Writer. Consumers can provide a prefix string which will
-// be used as a prefix to each line, useful for indentation.
-func PrintService(out io.Writer, prefix string, s *fastly.Service) {
+// be used as a prefix to each line, useful for indentation. Timestamps are
+// rendered using f.
+func PrintService(out io.Writer, prefix string, s *fastly.Service, f Formatter) {
 	out = textio.NewPrefixWriter(out, prefix)
 
 	fmt.Fprintf(out, "ID: %s\n", s.ID)
@@ -23,26 +23,27 @@ func PrintService(out io.Writer, prefix string, s *fastly.Service) {
 	}
 	fmt.Fprintf(out, "Customer ID: %s\n", s.CustomerID)
 	if s.CreatedAt != nil {
-		fmt.Fprintf(out, "Created (UTC): %s\n", s.CreatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Created (UTC): %s\n", f.UTCTime(*s.CreatedAt))
 	}
 	if s.UpdatedAt != nil {
-		fmt.Fprintf(out, "Last edited (UTC): %s\n", s.UpdatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Last edited (UTC): %s\n", f.UTCTime(*s.UpdatedAt))
 	}
 	if s.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted (UTC): %s\n", s.DeletedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Deleted (UTC): %s\n", f.UTCTime(*s.DeletedAt))
 	}
 	fmt.Fprintf(out, "Active version: %d\n", s.ActiveVersion)
 	fmt.Fprintf(out, "Versions: %d\n", len(s.Versions))
 	for j, version := range s.Versions {
 		fmt.Fprintf(out, "\tVersion %d/%d\n", j+1, len(s.Versions))
-		PrintVersion(out, "\t\t", version)
+		PrintVersion(out, "\t\t", version, f)
 	}
 }
 
 // PrintVersion pretty prints a fastly.Version structure in verbose format to a
 // given io.Writer. Consumers can provide a prefix string which will be used
-// as a prefix to each line, useful for indentation.
-func PrintVersion(out io.Writer, indent string, v *fastly.Version) {
+// as a prefix to each line, useful for indentation. Timestamps are rendered
+// using f.
+func PrintVersion(out io.Writer, indent string, v *fastly.Version, f Formatter) {
 	out = textio.NewPrefixWriter(out, indent)
 
 	fmt.Fprintf(out, "Number: %d\n", v.Number)
@@ -56,12 +57,12 @@ func PrintVersion(out io.Writer, indent string, v *fastly.Version) {
 	fmt.Fprintf(out, "Staging: %v\n", v.Staging)
 	fmt.Fprintf(out, "Testing: %v\n", v.Testing)
 	if v.CreatedAt != nil {
-		fmt.Fprintf(out, "Created (UTC): %s\n", v.CreatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Created (UTC): %s\n", f.UTCTime(*v.CreatedAt))
 	}
 	if v.UpdatedAt != nil {
-		fmt.Fprintf(out, "Last edited (UTC): %s\n", v.UpdatedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Last edited (UTC): %s\n", f.UTCTime(*v.UpdatedAt))
 	}
 	if v.DeletedAt != nil {
-		fmt.Fprintf(out, "Deleted (UTC): %s\n", v.DeletedAt.UTC().Format(time.Format))
+		fmt.Fprintf(out, "Deleted (UTC): %s\n", f.UTCTime(*v.DeletedAt))
 	}
 }
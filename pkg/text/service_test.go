@@ -31,7 +31,7 @@ func TestPrintService(t *testing.T) {
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			text.PrintService(&buf, testcase.prefix, testcase.service)
+			text.PrintService(&buf, testcase.prefix, testcase.service, text.Formatter{})
 			testutil.AssertString(t, testcase.wantOutput, buf.String())
 		})
 	}
@@ -59,7 +59,7 @@ func TestPrintVersion(t *testing.T) {
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			text.PrintVersion(&buf, testcase.prefix, testcase.version)
+			text.PrintVersion(&buf, testcase.prefix, testcase.version, text.Formatter{})
 			testutil.AssertString(t, testcase.wantOutput, buf.String())
 		})
 	}
@@ -0,0 +1,163 @@
+package text
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+var (
+	vclComment = color.New(color.FgHiBlack).SprintFunc()
+	vclKeyword = color.New(color.Bold, color.FgMagenta).SprintFunc()
+	vclNumber  = color.New(color.FgYellow).SprintFunc()
+	vclString  = color.New(color.FgGreen).SprintFunc()
+	vclVar     = color.New(color.FgCyan).SprintFunc()
+)
+
+// vclKeywords is the set of VCL statements and reserved words that are
+// highlighted as keywords.
+var vclKeywords = map[string]bool{
+	"acl":         true,
+	"add":         true,
+	"backend":     true,
+	"call":        true,
+	"declare":     true,
+	"director":    true,
+	"else":        true,
+	"elseif":      true,
+	"elsif":       true,
+	"error":       true,
+	"esi":         true,
+	"false":       true,
+	"goto":        true,
+	"if":          true,
+	"import":      true,
+	"include":     true,
+	"local":       true,
+	"log":         true,
+	"penaltybox":  true,
+	"ratecounter": true,
+	"remove":      true,
+	"restart":     true,
+	"return":      true,
+	"set":         true,
+	"sub":         true,
+	"synthetic":   true,
+	"table":       true,
+	"true":        true,
+	"unset":       true,
+}
+
+// vclVariablePrefixes identifies identifiers that refer to VCL variables.
+var vclVariablePrefixes = []string{
+	"bereq.", "beresp.", "client.", "fastly.", "fastly_info.", "geoip.",
+	"obj.", "req.", "resp.", "server.", "stale.", "time.", "tls.", "var.",
+}
+
+// HighlightVCL renders VCL source with syntax highlighting and line numbers.
+// Colours are only emitted when the output supports them (see
+// github.com/fatih/color) so the line numbered result is safe to pipe.
+func HighlightVCL(src string) string {
+	var b strings.Builder
+	emit := func(s string, paint func(a ...interface{}) string) {
+		// Colour each line of a multi-line token separately so the escape
+		// sequences never wrap a line number.
+		for i, line := range strings.Split(s, "\n") {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			if line == "" {
+				continue
+			}
+			if paint == nil {
+				b.WriteString(line)
+				continue
+			}
+			b.WriteString(paint(line))
+		}
+	}
+
+	r := []rune(src)
+	for i := 0; i < len(r); {
+		start := i
+		switch {
+		case r[i] == '#' || (r[i] == '/' && i+1 < len(r) && r[i+1] == '/'):
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+			emit(string(r[start:i]), vclComment)
+		case r[i] == '/' && i+1 < len(r) && r[i+1] == '*':
+			i = scanUntil(r, i+2, "*/")
+			emit(string(r[start:i]), vclComment)
+		case r[i] == '{' && i+1 < len(r) && r[i+1] == '"':
+			i = scanUntil(r, i+2, "\"}")
+			emit(string(r[start:i]), vclString)
+		case r[i] == '"':
+			i++
+			for i < len(r) && r[i] != '"' && r[i] != '\n' {
+				i++
+			}
+			if i < len(r) && r[i] == '"' {
+				i++
+			}
+			emit(string(r[start:i]), vclString)
+		case unicode.IsDigit(r[i]):
+			for i < len(r) && (unicode.IsLetter(r[i]) || unicode.IsDigit(r[i]) || r[i] == '.') {
+				i++
+			}
+			emit(string(r[start:i]), vclNumber)
+		case unicode.IsLetter(r[i]) || r[i] == '_':
+			for i < len(r) && (unicode.IsLetter(r[i]) || unicode.IsDigit(r[i]) || strings.ContainsRune("_.-:", r[i])) {
+				i++
+			}
+			word := string(r[start:i])
+			switch {
+			case vclKeywords[word]:
+				emit(word, vclKeyword)
+			case isVCLVariable(word):
+				emit(word, vclVar)
+			default:
+				emit(word, nil)
+			}
+		default:
+			i++
+			emit(string(r[start:i]), nil)
+		}
+	}
+
+	return numberLines(b.String())
+}
+
+// scanUntil returns the index immediately after the terminator, or the end
+// of the input if the terminator isn't found.
+func scanUntil(r []rune, i int, terminator string) int {
+	t := []rune(terminator)
+	for ; i+len(t) <= len(r); i++ {
+		if string(r[i:i+len(t)]) == terminator {
+			return i + len(t)
+		}
+	}
+	return len(r)
+}
+
+func isVCLVariable(word string) bool {
+	for _, p := range vclVariablePrefixes {
+		if strings.HasPrefix(word, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// numberLines prefixes each line of s with a right-aligned line number.
+func numberLines(s string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	width := len(fmt.Sprint(len(lines)))
+	var b strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&b, "%*d | %s\n", width, i+1, line)
+	}
+	return b.String()
+}
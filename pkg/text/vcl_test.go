@@ -0,0 +1,44 @@
+package text_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fatih/color"
+)
+
+func TestHighlightVCL(t *testing.T) {
+	src := strings.Join([]string{
+		`sub vcl_recv {`,
+		`  /* multi`,
+		`     line */`,
+		`  set req.http.X-Foo = "bar"; # comment`,
+		`}`,
+	}, "\n")
+
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+
+	color.NoColor = true
+	testutil.AssertString(t, strings.Join([]string{
+		`1 | sub vcl_recv {`,
+		`2 |   /* multi`,
+		`3 |      line */`,
+		`4 |   set req.http.X-Foo = "bar"; # comment`,
+		`5 | }`,
+	}, "\n")+"\n", text.HighlightVCL(src))
+
+	color.NoColor = false
+	out := text.HighlightVCL(src)
+	for _, want := range []string{
+		"1 | \x1b[1;35msub\x1b[0m vcl_recv {",
+		// Each line of a multi-line comment is coloured separately.
+		"2 |   \x1b[90m/* multi\x1b[0m\n3 | \x1b[90m     line */\x1b[0m",
+		"\x1b[36mreq.http.X-Foo\x1b[0m",
+		"\x1b[32m\"bar\"\x1b[0m",
+	} {
+		testutil.AssertStringContains(t, out, want)
+	}
+}
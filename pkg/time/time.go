@@ -3,3 +3,8 @@ package time
 // Format is a format string for time.Format that reflects what the Fastly web
 // UI uses.
 const Format = "2006-01-02 15:04"
+
+// Layout is the default format string for timestamps in detailed output. It
+// matches the representation of time.Time.String() without the monotonic
+// clock reading.
+const Layout = "2006-01-02 15:04:05 -0700 MST"
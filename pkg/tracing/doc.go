@@ -0,0 +1,4 @@
+// Package tracing records spans of the CLI's execution and exports them via
+// OTLP, so pipelines that run the CLI can see where its time goes in their
+// tracing backend.
+package tracing
@@ -0,0 +1,296 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultServiceName is the service.name of the exported spans, unless
+// OTEL_SERVICE_NAME is set.
+const DefaultServiceName = "fastly-cli"
+
+// ExportTimeout is how long exporting the spans may take, so that an
+// unreachable collector doesn't hold up the CLI.
+const ExportTimeout = 5 * time.Second
+
+// The kinds of span, as defined by OTLP.
+const (
+	KindInternal = 1
+	KindClient   = 3
+)
+
+// The status codes of a span, as defined by OTLP.
+const (
+	statusCodeUnset = 0
+	statusCodeError = 2
+)
+
+// traceparentRegExp matches a W3C traceparent, capturing the trace ID and the
+// ID of the parent span.
+var traceparentRegExp = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// Config is the configuration of a Tracer, typically read from the standard
+// OpenTelemetry environment variables (see config.Environment).
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP traces receiver the spans are sent
+	// to, e.g. http://localhost:4318/v1/traces.
+	Endpoint string
+	// Headers are sent with the spans, e.g. to authenticate with the backend.
+	Headers map[string]string
+	// ServiceName is the service.name of the spans. If it's empty then
+	// DefaultServiceName is used.
+	ServiceName string
+	// Parent is the W3C traceparent of the span that the CLI's spans are
+	// children of, e.g. that of a pipeline step. If it's empty or invalid then
+	// the CLI's spans start a new trace.
+	Parent string
+	// Version is the version of the CLI, recorded on every span.
+	Version string
+}
+
+// Endpoint returns the URL of the OTLP/HTTP traces receiver: tracesEndpoint
+// as is, otherwise the /v1/traces path of endpoint. If neither is set then
+// it's empty.
+func Endpoint(endpoint, tracesEndpoint string) string {
+	if tracesEndpoint != "" {
+		return tracesEndpoint
+	}
+	if endpoint == "" {
+		return ""
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+}
+
+// ParseHeaders parses headers in the format of OTEL_EXPORTER_OTLP_HEADERS,
+// i.e. comma separated key=value pairs whose values are URL encoded.
+func ParseHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid header '%s': must be key=value", pair)
+		}
+		v, err := url.QueryUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid header '%s': %w", pair, err)
+		}
+		headers[strings.TrimSpace(k)] = v
+	}
+	return headers, nil
+}
+
+// Tracer records spans and exports them to the configured endpoint.
+//
+// A nil *Tracer is valid and records nothing, so callers don't need to check
+// whether tracing is enabled. A Tracer is safe for concurrent use.
+type Tracer struct {
+	// Client sends the spans. If nil then http.DefaultClient is used.
+	Client *http.Client
+
+	config   Config
+	traceID  string
+	parentID string
+
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// New returns a tracer that exports its spans as configured.
+func New(config Config) *Tracer {
+	t := &Tracer{config: config}
+	if m := traceparentRegExp.FindStringSubmatch(config.Parent); m != nil {
+		t.traceID, t.parentID = m[1], m[2]
+	} else {
+		t.traceID = newID(16)
+	}
+	return t
+}
+
+// TraceID returns the ID of the trace the spans belong to.
+func (t *Tracer) TraceID() string {
+	if t == nil {
+		return ""
+	}
+	return t.traceID
+}
+
+// Start returns a new span, which is recorded once it ends. If parent is nil
+// then the span is a child of the configured parent, if any.
+func (t *Tracer) Start(name string, kind int, parent *Span) *Span {
+	if t == nil {
+		return nil
+	}
+	s := &Span{
+		tracer:     t,
+		id:         newID(8),
+		parentID:   t.parentID,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+	if parent != nil {
+		s.parentID = parent.id
+	}
+	return s
+}
+
+// Export sends the spans that have ended to the configured endpoint.
+func (t *Tracer) Export(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(t.request(spans))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ExportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) // #nosec G104 (drained so the connection can be reused)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("exporting spans to %s: unexpected status '%s'", t.config.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// request returns the OTLP/JSON export request of the spans.
+func (t *Tracer) request(spans []*Span) exportRequest {
+	serviceName := t.config.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+
+	data := make([]spanData, 0, len(spans))
+	for _, s := range spans {
+		data = append(data, s.data())
+	}
+	return exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: attributes(map[string]interface{}{
+					"service.name":    serviceName,
+					"service.version": t.config.Version,
+				}),
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: "github.com/fastly/cli", Version: t.config.Version},
+				Spans: data,
+			}},
+		}},
+	}
+}
+
+// Span is an operation of the CLI, such as a command or an API request.
+//
+// A nil *Span is valid and records nothing.
+type Span struct {
+	tracer   *Tracer
+	id       string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+
+	mu         sync.Mutex
+	attributes map[string]interface{}
+	end        time.Time
+	err        error
+}
+
+// SetAttribute records an attribute of the span, whose value is a string,
+// int or bool.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+}
+
+// End records the span, which failed if err isn't nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.end = time.Now()
+	s.err = err
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s)
+}
+
+func (s *Span) data() spanData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := spanData{
+		TraceID:           s.tracer.traceID,
+		SpanID:            s.id,
+		ParentSpanID:      s.parentID,
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        attributes(s.attributes),
+		Status:            status{Code: statusCodeUnset},
+	}
+	if s.err != nil {
+		d.Status = status{Code: statusCodeError, Message: s.err.Error()}
+	}
+	return d
+}
+
+// newID returns a random ID of n bytes, hex encoded.
+func newID(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%0*x", n*2, time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,152 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/tracing"
+)
+
+// exported is the part of an OTLP/JSON export request that the tests check.
+type exported struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []attribute `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string      `json:"traceId"`
+				SpanID       string      `json:"spanId"`
+				ParentSpanID string      `json:"parentSpanId"`
+				Name         string      `json:"name"`
+				Kind         int         `json:"kind"`
+				Attributes   []attribute `json:"attributes"`
+				Status       struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type attribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+		IntValue    string `json:"intValue"`
+	} `json:"value"`
+}
+
+func TestTracer(t *testing.T) {
+	var (
+		body   []byte
+		header http.Header
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer collector.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Fastly-Request-Id", "abc")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	tracer := tracing.New(tracing.Config{
+		Endpoint: collector.URL + "/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer xyz"},
+		Parent:   "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		Version:  "1.2.3",
+	})
+	testutil.AssertString(t, "0af7651916cd43dd8448eb211c80319c", tracer.TraceID())
+
+	root := tracer.Start("fastly service list", tracing.KindInternal, nil)
+	c := &http.Client{Transport: tracer.Transport(root, nil)}
+	for _, path := range []string{"/service", "/missing"} {
+		resp, err := c.Get(api.URL + path)
+		testutil.AssertNoError(t, err)
+		resp.Body.Close()
+	}
+	root.End(errors.New("kaboom"))
+
+	testutil.AssertNoError(t, tracer.Export(context.Background()))
+	testutil.AssertString(t, "Bearer xyz", header.Get("Authorization"))
+	testutil.AssertString(t, "application/json", header.Get("Content-Type"))
+
+	var req exported
+	testutil.AssertNoError(t, json.Unmarshal(body, &req))
+	testutil.AssertEqual(t, 1, len(req.ResourceSpans))
+	testutil.AssertEqual(t, "service.name", req.ResourceSpans[0].Resource.Attributes[0].Key)
+	testutil.AssertEqual(t, tracing.DefaultServiceName, req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	testutil.AssertEqual(t, 3, len(spans))
+	for _, s := range spans {
+		testutil.AssertString(t, "0af7651916cd43dd8448eb211c80319c", s.TraceID)
+	}
+
+	// Spans are exported in the order they end.
+	get, missing, cmd := spans[0], spans[1], spans[2]
+	testutil.AssertString(t, "fastly service list", cmd.Name)
+	testutil.AssertString(t, "b7ad6b7169203331", cmd.ParentSpanID)
+	testutil.AssertEqual(t, tracing.KindInternal, cmd.Kind)
+	testutil.AssertEqual(t, 2, cmd.Status.Code)
+	testutil.AssertString(t, "kaboom", cmd.Status.Message)
+
+	testutil.AssertString(t, "HTTP GET", get.Name)
+	testutil.AssertString(t, cmd.SpanID, get.ParentSpanID)
+	testutil.AssertEqual(t, tracing.KindClient, get.Kind)
+	testutil.AssertEqual(t, 0, get.Status.Code)
+	attrs := map[string]string{}
+	for _, a := range get.Attributes {
+		attrs[a.Key] = a.Value.StringValue + a.Value.IntValue
+	}
+	testutil.AssertEqual(t, map[string]string{
+		"fastly.request_id": "abc",
+		"http.method":       "GET",
+		"http.status_code":  "200",
+		"http.url":          api.URL + "/service",
+	}, attrs)
+
+	testutil.AssertEqual(t, 2, missing.Status.Code)
+	testutil.AssertString(t, "404 Not Found", missing.Status.Message)
+
+	// The spans are only exported once.
+	body = nil
+	testutil.AssertNoError(t, tracer.Export(context.Background()))
+	testutil.AssertEqual(t, []byte(nil), body)
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *tracing.Tracer
+	span := tracer.Start("fastly version", tracing.KindInternal, nil)
+	span.SetAttribute("cli.command", "version")
+	span.End(nil)
+	testutil.AssertNoError(t, tracer.Export(context.Background()))
+}
+
+func TestEndpoint(t *testing.T) {
+	testutil.AssertString(t, "", tracing.Endpoint("", ""))
+	testutil.AssertString(t, "http://localhost:4318/v1/traces", tracing.Endpoint("http://localhost:4318/", ""))
+	testutil.AssertString(t, "http://collector/traces", tracing.Endpoint("http://localhost:4318", "http://collector/traces"))
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := tracing.ParseHeaders("api-key=secret, Authorization=Basic%20abc")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, map[string]string{"api-key": "secret", "Authorization": "Basic abc"}, headers)
+
+	_, err = tracing.ParseHeaders("api-key")
+	testutil.AssertErrorContains(t, err, "invalid header 'api-key'")
+}
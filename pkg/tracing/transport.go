@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/fastly/cli/pkg/api/transport"
+)
+
+// Transport returns a http.RoundTripper that records a client span, a child
+// of parent, for each request made via next. The spans include the request ID
+// the API assigned, so they can be correlated with Fastly's own logs.
+//
+// If next is nil then http.DefaultTransport is used.
+func (t *Tracer) Transport(parent *Span, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &tracingTransport{next: next, parent: parent, tracer: t}
+}
+
+type tracingTransport struct {
+	next   http.RoundTripper
+	parent *Span
+	tracer *Tracer
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	span := t.tracer.Start("HTTP "+req.Method, KindClient, t.parent)
+	span.SetAttribute("http.method", req.Method)
+	span.SetAttribute("http.url", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.End(err)
+		return resp, err
+	}
+
+	span.SetAttribute("http.status_code", resp.StatusCode)
+	for _, h := range transport.RequestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			span.SetAttribute("fastly.request_id", id)
+			break
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.End(&statusError{resp.Status})
+	} else {
+		span.End(nil)
+	}
+	return resp, nil
+}
+
+// statusError is the error of a span whose request failed with a HTTP error
+// status.
+type statusError struct {
+	status string
+}
+
+func (e *statusError) Error() string {
+	return e.status
+}
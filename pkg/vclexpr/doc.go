@@ -0,0 +1,9 @@
+// Package vclexpr evaluates the VCL expressions used by service configuration
+// (e.g. condition statements and header sources) against a synthetic request,
+// so that a service's routing can be previewed without sending traffic.
+//
+// Only the subset of VCL that can be evaluated from the request alone is
+// supported. Expressions that depend on anything else (e.g. geolocation,
+// randomness or edge dictionaries) fail with an error wrapping
+// ErrUnsupported.
+package vclexpr
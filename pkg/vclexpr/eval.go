@@ -0,0 +1,216 @@
+package vclexpr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Eval evaluates the expression against the request.
+func Eval(expr string, r *Request) (Value, error) {
+	n, err := parse(expr)
+	if err != nil {
+		return Value{}, fmt.Errorf("error parsing %q: %w", expr, err)
+	}
+	return r.eval(n)
+}
+
+// EvalBool evaluates the expression as a condition.
+func EvalBool(expr string, r *Request) (bool, error) {
+	v, err := Eval(expr, r)
+	if err != nil {
+		return false, err
+	}
+	return v.Truthy(), nil
+}
+
+func (r *Request) eval(n *node) (Value, error) {
+	switch n.op {
+	case "str":
+		return String(n.text), nil
+	case "num":
+		f, err := strconv.ParseFloat(n.text, 64)
+		if err != nil {
+			return Value{}, err
+		}
+		return Number(f), nil
+	case "bool":
+		return Bool(n.text == "true"), nil
+	case "var":
+		return r.variable(n.text)
+	case "call":
+		return r.call(n)
+	case "!":
+		v, err := r.eval(n.args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		return Bool(!v.Truthy()), nil
+	case "&&", "||":
+		return r.logical(n)
+	case "concat":
+		var b strings.Builder
+		for _, arg := range n.args {
+			v, err := r.eval(arg)
+			if err != nil {
+				return Value{}, err
+			}
+			b.WriteString(v.String())
+		}
+		return String(b.String()), nil
+	case "~", "!~":
+		return r.match(n)
+	}
+	return r.compare(n)
+}
+
+// logical evaluates && and ||. If one side can't be evaluated then the result
+// is still known if the other side decides it.
+func (r *Request) logical(n *node) (Value, error) {
+	decides := n.op == "||" // the operand value that decides the result
+	left, lerr := r.eval(n.args[0])
+	if lerr == nil && left.Truthy() == decides {
+		return Bool(decides), nil
+	}
+	right, rerr := r.eval(n.args[1])
+	if rerr == nil && right.Truthy() == decides {
+		return Bool(decides), nil
+	}
+	if lerr != nil {
+		return Value{}, lerr
+	}
+	if rerr != nil {
+		return Value{}, rerr
+	}
+	return Bool(!decides), nil
+}
+
+func (r *Request) match(n *node) (Value, error) {
+	if n.args[1].op != "str" {
+		// e.g. client.ip ~ some_acl
+		return Value{}, unsupported("matching against %s", n.args[1].text)
+	}
+	re, err := regexp.Compile(n.args[1].text)
+	if err != nil {
+		return Value{}, unsupported("regular expression %q (%s)", n.args[1].text, err)
+	}
+	left, err := r.eval(n.args[0])
+	if err != nil {
+		return Value{}, err
+	}
+	matched := left.Kind != KindNotSet && re.MatchString(left.String())
+	return Bool(matched == (n.op == "~")), nil
+}
+
+func (r *Request) compare(n *node) (Value, error) {
+	left, err := r.eval(n.args[0])
+	if err != nil {
+		return Value{}, err
+	}
+	right, err := r.eval(n.args[1])
+	if err != nil {
+		return Value{}, err
+	}
+
+	ln, lok := left.number()
+	rn, rok := right.number()
+	switch n.op {
+	case "==", "!=":
+		var equal bool
+		switch {
+		case left.Kind == KindNotSet || right.Kind == KindNotSet:
+			equal = false
+		case left.Kind == KindBool || right.Kind == KindBool:
+			equal = left.Truthy() == right.Truthy()
+		case lok && rok && (left.Kind == KindNumber || right.Kind == KindNumber):
+			equal = ln == rn
+		default:
+			equal = left.String() == right.String()
+		}
+		return Bool(equal == (n.op == "==")), nil
+	}
+
+	if !lok || !rok {
+		return Bool(false), nil
+	}
+	switch n.op {
+	case "<":
+		return Bool(ln < rn), nil
+	case ">":
+		return Bool(ln > rn), nil
+	case "<=":
+		return Bool(ln <= rn), nil
+	case ">=":
+		return Bool(ln >= rn), nil
+	}
+	return Value{}, fmt.Errorf("unknown operator %s", n.op)
+}
+
+// call evaluates the supported VCL functions.
+func (r *Request) call(n *node) (Value, error) {
+	args := make([]Value, len(n.args))
+	for i, a := range n.args {
+		v, err := r.eval(a)
+		if err != nil {
+			return Value{}, err
+		}
+		args[i] = v
+	}
+	want := func(count int) error {
+		if len(args) != count {
+			return fmt.Errorf("%s takes %d arguments, got %d", n.text, count, len(args))
+		}
+		return nil
+	}
+
+	switch strings.ToLower(n.text) {
+	case "std.tolower":
+		if err := want(1); err != nil {
+			return Value{}, err
+		}
+		return String(strings.ToLower(args[0].String())), nil
+	case "std.toupper":
+		if err := want(1); err != nil {
+			return Value{}, err
+		}
+		return String(strings.ToUpper(args[0].String())), nil
+	case "std.strlen":
+		if err := want(1); err != nil {
+			return Value{}, err
+		}
+		return Number(float64(len(args[0].String()))), nil
+	case "std.prefixof":
+		if err := want(2); err != nil {
+			return Value{}, err
+		}
+		return Bool(strings.HasPrefix(args[0].String(), args[1].String())), nil
+	case "std.suffixof":
+		if err := want(2); err != nil {
+			return Value{}, err
+		}
+		return Bool(strings.HasSuffix(args[0].String(), args[1].String())), nil
+	case "querystring.get":
+		if err := want(2); err != nil {
+			return Value{}, err
+		}
+		u, err := url.Parse(args[0].String())
+		if err != nil {
+			return NotSet, nil
+		}
+		values, ok := u.Query()[args[1].String()]
+		if !ok {
+			return NotSet, nil
+		}
+		return String(values[0]), nil
+	}
+	return Value{}, unsupported("function %s()", n.text)
+}
+
+// IsUnsupported reports whether err is due to an expression that can't be
+// evaluated locally.
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrUnsupported)
+}
@@ -0,0 +1,268 @@
+package vclexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// token is a lexical token of an expression.
+type token struct {
+	kind string // "str", "num", "ident" or the operator itself
+	text string
+}
+
+// operators are matched longest first.
+var operators = []string{"==", "!=", "!~", "&&", "||", "<=", ">=", "~", "!", "(", ")", ",", "<", ">", "+"}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], `{"`):
+			end := strings.Index(src[i+2:], `"}`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated long string")
+			}
+			tokens = append(tokens, token{"str", src[i+2 : i+2+end]})
+			i += end + 4
+		case c == '"':
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, token{"str", decodeString(src[i+1 : i+1+end])})
+			i += end + 2
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(src) && (src[j] >= '0' && src[j] <= '9' || src[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{"num", src[i:j]})
+			i = j
+		case isIdentStart(c):
+			j := i
+			for j < len(src) && isIdent(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{"ident", src[i:j]})
+			i = j
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, token{op, op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isIdent allows the characters of variable names such as
+// req.http.X-Forwarded-For.
+func isIdent(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9' || c == '.' || c == '-'
+}
+
+// decodeString decodes the %XX escapes of a VCL string literal.
+func decodeString(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// node is a parsed expression.
+type node struct {
+	op   string // "str", "num", "bool", "var", "call", "!", "concat" or a binary operator
+	text string
+	args []*node
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses a VCL expression, returning an error if it isn't well formed.
+func parse(src string) (*node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].kind
+	}
+	return ""
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *parser) or() (*node, error) {
+	return p.binary(p.and, "||")
+}
+
+func (p *parser) and() (*node, error) {
+	return p.binary(p.not, "&&")
+}
+
+func (p *parser) binary(operand func() (*node, error), op string) (*node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == op {
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &node{op: op, args: []*node{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) not() (*node, error) {
+	if p.peek() == "!" {
+		p.next()
+		n, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &node{op: "!", args: []*node{n}}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (*node, error) {
+	left, err := p.concat()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "~", "!~", "<", ">", "<=", ">=":
+		p.next()
+		right, err := p.concat()
+		if err != nil {
+			return nil, err
+		}
+		return &node{op: op, args: []*node{left, right}}, nil
+	}
+	return left, nil
+}
+
+// concat parses string concatenation, which VCL writes as juxtaposition or
+// with +.
+func (p *parser) concat() (*node, error) {
+	first, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	parts := []*node{first}
+	for {
+		switch p.peek() {
+		case "+":
+			p.next()
+		case "str", "ident":
+		default:
+			if len(parts) == 1 {
+				return first, nil
+			}
+			return &node{op: "concat", args: parts}, nil
+		}
+		n, err := p.primary()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, n)
+	}
+}
+
+func (p *parser) primary() (*node, error) {
+	switch p.peek() {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "(":
+		p.next()
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.next()
+		return n, nil
+	case "str":
+		return &node{op: "str", text: p.next().text}, nil
+	case "num":
+		return &node{op: "num", text: p.next().text}, nil
+	case "ident":
+		t := p.next()
+		switch strings.ToLower(t.text) {
+		case "true", "false":
+			return &node{op: "bool", text: strings.ToLower(t.text)}, nil
+		}
+		if p.peek() != "(" {
+			return &node{op: "var", text: t.text}, nil
+		}
+		p.next()
+		call := &node{op: "call", text: t.text}
+		for p.peek() != ")" {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if p.peek() == "," {
+				p.next()
+			} else if p.peek() != ")" {
+				return nil, fmt.Errorf("expected , or ) in call to %s", t.text)
+			}
+		}
+		p.next()
+		return call, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", p.next().text)
+}
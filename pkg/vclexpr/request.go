@@ -0,0 +1,77 @@
+package vclexpr
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Request is the synthetic request expressions are evaluated against.
+type Request struct {
+	Method   string
+	URL      *url.URL
+	Header   http.Header
+	ClientIP net.IP
+}
+
+// NewRequest returns a GET request for the URL, with its Host header set.
+func NewRequest(rawURL string) (*Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, &url.Error{Op: "parse", URL: rawURL, Err: errAbsoluteURL}
+	}
+	r := &Request{
+		Method:   http.MethodGet,
+		URL:      u,
+		Header:   http.Header{},
+		ClientIP: net.IPv4(127, 0, 0, 1),
+	}
+	r.Header.Set("Host", u.Host)
+	return r, nil
+}
+
+// variable returns the value of a VCL variable.
+func (r *Request) variable(name string) (Value, error) {
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "req.http.") {
+		values, ok := r.Header[http.CanonicalHeaderKey(name[len("req.http."):])]
+		if !ok {
+			return NotSet, nil
+		}
+		return String(strings.Join(values, ", ")), nil
+	}
+
+	switch lower {
+	case "req.url":
+		return String(r.URL.RequestURI()), nil
+	case "req.url.path":
+		return String(r.URL.EscapedPath()), nil
+	case "req.url.qs":
+		return String(r.URL.RawQuery), nil
+	case "req.url.basename":
+		return String(path.Base(r.URL.EscapedPath())), nil
+	case "req.url.dirname":
+		return String(path.Dir(r.URL.EscapedPath())), nil
+	case "req.url.ext":
+		return String(strings.TrimPrefix(path.Ext(r.URL.EscapedPath()), ".")), nil
+	case "req.method", "req.request":
+		return String(r.Method), nil
+	case "req.protocol":
+		return String(r.URL.Scheme), nil
+	case "req.is_ssl":
+		return Bool(r.URL.Scheme == "https"), nil
+	case "client.ip":
+		return String(r.ClientIP.String()), nil
+	}
+	return Value{}, unsupported("variable %s", name)
+}
+
+// SetHeader sets a request header, as a header of type request would.
+func (r *Request) SetHeader(name, value string) {
+	r.Header.Set(name, value)
+}
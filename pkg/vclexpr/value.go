@@ -0,0 +1,91 @@
+package vclexpr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrUnsupported is wrapped by the errors for expressions that can't be
+// evaluated locally.
+var ErrUnsupported = errors.New("can't be evaluated locally")
+
+var errAbsoluteURL = errors.New("must be an absolute URL, e.g. https://www.example.com/")
+
+func unsupported(format string, args ...interface{}) error {
+	return fmt.Errorf("%s %w", fmt.Sprintf(format, args...), ErrUnsupported)
+}
+
+// Kind is the type of a Value.
+type Kind int
+
+// The kinds of Value.
+const (
+	KindNotSet Kind = iota
+	KindString
+	KindBool
+	KindNumber
+)
+
+// Value is the result of evaluating an expression.
+type Value struct {
+	Kind Kind
+	s    string
+	b    bool
+	n    float64
+}
+
+// NotSet is the value of a header that isn't set.
+var NotSet = Value{Kind: KindNotSet}
+
+// String returns a string Value.
+func String(s string) Value { return Value{Kind: KindString, s: s} }
+
+// Bool returns a boolean Value.
+func Bool(b bool) Value { return Value{Kind: KindBool, b: b} }
+
+// Number returns a numeric Value.
+func Number(n float64) Value { return Value{Kind: KindNumber, n: n} }
+
+// String converts the value to a string, as VCL does when assigning it to a
+// header. A value that's not set converts to the empty string.
+func (v Value) String() string {
+	switch v.Kind {
+	case KindString:
+		return v.s
+	case KindBool:
+		if v.b {
+			return "1"
+		}
+		return "0"
+	case KindNumber:
+		return strconv.FormatFloat(v.n, 'f', -1, 64)
+	}
+	return ""
+}
+
+// Truthy converts the value to a boolean, as VCL does in an if statement: a
+// string is true if it's set.
+func (v Value) Truthy() bool {
+	switch v.Kind {
+	case KindString:
+		return true
+	case KindBool:
+		return v.b
+	case KindNumber:
+		return v.n != 0
+	}
+	return false
+}
+
+// number converts the value to a number, if possible.
+func (v Value) number() (float64, bool) {
+	switch v.Kind {
+	case KindNumber:
+		return v.n, true
+	case KindString:
+		n, err := strconv.ParseFloat(v.s, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
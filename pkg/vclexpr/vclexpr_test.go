@@ -0,0 +1,82 @@
+package vclexpr_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/vclexpr"
+)
+
+func TestEvalBool(t *testing.T) {
+	r, err := vclexpr.NewRequest("https://www.example.com/api/v1/users.json?limit=10")
+	testutil.AssertNoError(t, err)
+	r.Header.Set("X-Debug", "1")
+
+	for _, testcase := range []struct {
+		expr      string
+		want      bool
+		wantError string
+	}{
+		{expr: `req.url ~ "^/api/"`, want: true},
+		{expr: `req.url.path == "/api/v1/users.json"`, want: true},
+		{expr: `req.url.qs == "limit=10"`, want: true},
+		{expr: `req.url.ext == "json" && req.url.basename == "users.json"`, want: true},
+		{expr: `req.http.host == "www.example.com"`, want: true},
+		{expr: `req.http.Host ~ "(?i)EXAMPLE"`, want: true},
+		{expr: `req.http.X-Debug`, want: true},
+		{expr: `!req.http.X-Missing`, want: true},
+		{expr: `req.http.X-Missing == ""`, want: false},
+		{expr: `req.http.X-Missing !~ "foo"`, want: true},
+		{expr: `req.method == "POST" || req.is_ssl`, want: true},
+		{expr: `req.protocol != "https"`, want: false},
+		{expr: `(req.url ~ "^/static" || req.url ~ "^/img") && req.method == "GET"`, want: false},
+		{expr: `std.tolower(req.http.host) == "www.example.com"`, want: true},
+		{expr: `std.prefixof(req.url, "/api")`, want: true},
+		{expr: `querystring.get(req.url, "limit") == "10"`, want: true},
+		{expr: `std.strlen(req.http.X-Debug) >= 1`, want: true},
+		{expr: `client.ip == "127.0.0.1"`, want: true},
+		// An unsupported operand doesn't matter if the other decides the result.
+		{expr: `req.url ~ "^/static" && client.geo.country_code == "DE"`, want: false},
+		{expr: `req.url ~ "^/api" || randombool(1, 100)`, want: true},
+		{expr: `client.geo.country_code == "DE"`, wantError: "variable client.geo.country_code can't be evaluated locally"},
+		{expr: `client.ip ~ internal`, wantError: "matching against internal can't be evaluated locally"},
+		{expr: `randombool(1, 100)`, wantError: "function randombool() can't be evaluated locally"},
+		{expr: `req.url ==`, wantError: "unexpected end of expression"},
+	} {
+		t.Run(testcase.expr, func(t *testing.T) {
+			got, err := vclexpr.EvalBool(testcase.expr, r)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantError == "" {
+				testutil.AssertBool(t, testcase.want, got)
+			}
+		})
+	}
+}
+
+func TestEvalString(t *testing.T) {
+	r, err := vclexpr.NewRequest("http://example.com/a?b=c")
+	testutil.AssertNoError(t, err)
+
+	for _, testcase := range []struct {
+		expr string
+		want string
+	}{
+		{expr: `"plain"`, want: "plain"},
+		{expr: `{"long "string""}`, want: `long "string"`},
+		{expr: `"a%20b"`, want: "a b"},
+		{expr: `"proto=" req.protocol`, want: "proto=http"},
+		{expr: `"host=" + req.http.host + ";"`, want: "host=example.com;"},
+		{expr: `req.http.X-Missing`, want: ""},
+	} {
+		t.Run(testcase.expr, func(t *testing.T) {
+			v, err := vclexpr.Eval(testcase.expr, r)
+			testutil.AssertNoError(t, err)
+			testutil.AssertString(t, testcase.want, v.String())
+		})
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	_, err := vclexpr.NewRequest("/relative")
+	testutil.AssertErrorContains(t, err, "must be an absolute URL")
+}
@@ -0,0 +1,3 @@
+// Package vclusage finds where VCL snippets, subroutines and dictionaries are
+// referenced in the VCL of a service version.
+package vclusage
@@ -0,0 +1,225 @@
+package vclusage
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// The kinds of Source, in the order they're scanned.
+const (
+	KindSnippet   = "snippet"
+	KindVCL       = "vcl"
+	KindCondition = "condition"
+	KindGenerated = "generated"
+)
+
+// Source is a piece of VCL belonging to a service version.
+type Source struct {
+	Kind    string
+	Name    string
+	Content string
+	// Type is the location of a snippet, e.g. recv or init.
+	Type string
+	// Main reports whether a custom VCL is the main VCL.
+	Main bool
+}
+
+// Reference is a line of VCL that refers to a resource.
+type Reference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	Line int    `json:"line,omitempty"`
+	Text string `json:"text"`
+}
+
+// Sources returns the snippets, custom VCLs and conditions of a service
+// version, followed by its generated VCL.
+func Sources(client api.Interface, serviceID string, version int) ([]Source, error) {
+	var sources []Source
+
+	snippets, err := client.ListSnippets(&fastly.ListSnippetsInput{ServiceID: serviceID, ServiceVersion: version})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snippets: %w", err)
+	}
+	for _, s := range snippets {
+		content := s.Content
+		// The content of a dynamic snippet isn't versioned, so it's read
+		// separately.
+		if s.Dynamic == 1 {
+			ds, err := client.GetDynamicSnippet(&fastly.GetDynamicSnippetInput{ServiceID: serviceID, ID: s.ID})
+			if err != nil {
+				return nil, fmt.Errorf("error reading dynamic snippet '%s': %w", s.Name, err)
+			}
+			content = ds.Content
+		}
+		sources = append(sources, Source{Kind: KindSnippet, Name: s.Name, Type: string(s.Type), Content: content})
+	}
+
+	vcls, err := client.ListVCLs(&fastly.ListVCLsInput{ServiceID: serviceID, ServiceVersion: version})
+	if err != nil {
+		return nil, fmt.Errorf("error listing custom VCL: %w", err)
+	}
+	for _, v := range vcls {
+		sources = append(sources, Source{Kind: KindVCL, Name: v.Name, Content: v.Content, Main: v.Main})
+	}
+
+	conditions, err := client.ListConditions(&fastly.ListConditionsInput{ServiceID: serviceID, ServiceVersion: version})
+	if err != nil {
+		return nil, fmt.Errorf("error listing conditions: %w", err)
+	}
+	for _, c := range conditions {
+		sources = append(sources, Source{Kind: KindCondition, Name: c.Name, Content: c.Statement})
+	}
+
+	generated, err := client.GetGeneratedVCL(&fastly.GetGeneratedVCLInput{ServiceID: serviceID, ServiceVersion: version})
+	if err != nil {
+		return nil, fmt.Errorf("error reading generated VCL: %w", err)
+	}
+	sources = append(sources, Source{Kind: KindGenerated, Name: "generated", Content: generated.Content})
+	return sources, nil
+}
+
+// comments matches VCL comments, and the string literals that may contain
+// comment markers (e.g. URLs), so that the latter can be kept.
+var comments = regexp.MustCompile(`(?s)\{".*?"\}|"[^"\n]*"|/\*.*?\*/|#[^\n]*|//[^\n]*`)
+
+// StripComments blanks out the comments in VCL, preserving line numbers.
+func StripComments(vcl string) string {
+	return comments.ReplaceAllStringFunc(vcl, func(m string) string {
+		if strings.HasPrefix(m, `"`) || strings.HasPrefix(m, `{"`) {
+			return m
+		}
+		return strings.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return ' '
+		}, m)
+	})
+}
+
+// Find returns the lines of the sources that match any of the patterns.
+//
+// The generated VCL includes the content of the snippets, custom VCL and
+// conditions, so only the matching lines of generated VCL that don't contain a
+// line matched in one of the other sources are returned. Lines in sources for which skip returns true
+// (e.g. the resource being looked up) aren't returned, but are still excluded
+// from the generated VCL.
+func Find(sources []Source, skip func(Source) bool, patterns ...*regexp.Regexp) []Reference {
+	var refs []Reference
+	seen := map[string]int{}
+	for _, s := range sources {
+		lines := strings.Split(StripComments(s.Content), "\n")
+		for i, line := range lines {
+			if !matches(line, patterns) {
+				continue
+			}
+			text := strings.TrimSpace(line)
+			if s.Kind == KindGenerated {
+				if attributed(seen, text) {
+					continue
+				}
+			} else {
+				seen[text]++
+			}
+			if skip != nil && skip(s) {
+				continue
+			}
+			refs = append(refs, Reference{Kind: s.Kind, Name: s.Name, Line: i + 1, Text: text})
+		}
+	}
+	return refs
+}
+
+// attributed reports whether the line of generated VCL contains a line seen in
+// one of the other sources, consuming it so that each line is matched once.
+func attributed(seen map[string]int, line string) bool {
+	for text, n := range seen {
+		if n > 0 && strings.Contains(line, text) {
+			seen[text]--
+			return true
+		}
+	}
+	return false
+}
+
+func matches(line string, patterns []*regexp.Regexp) bool {
+	for _, p := range patterns {
+		if p.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// DictionaryPattern matches the table functions (e.g. table.lookup and
+// table.contains) called with the named dictionary.
+func DictionaryPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`\btable\.[a-z_]+\s*\(\s*` + regexp.QuoteMeta(name) + `\s*[,)]`)
+}
+
+// IncludePattern matches the include statement for the named snippet.
+func IncludePattern(snippet string) *regexp.Regexp {
+	return regexp.MustCompile(`\binclude\s+"snippet::` + regexp.QuoteMeta(snippet) + `"`)
+}
+
+// CallPattern matches call statements for the named subroutine.
+func CallPattern(sub string) *regexp.Regexp {
+	return regexp.MustCompile(`\bcall\s+` + regexp.QuoteMeta(sub) + `\s*;`)
+}
+
+var subroutine = regexp.MustCompile(`\bsub\s+([A-Za-z0-9_]+)\s*\{`)
+
+// Subroutines returns the names of the subroutines defined in the VCL, other
+// than the built-in vcl_ subroutines.
+func Subroutines(vcl string) []string {
+	var names []string
+	for _, m := range subroutine.FindAllStringSubmatch(StripComments(vcl), -1) {
+		if !strings.HasPrefix(m[1], "vcl_") {
+			names = append(names, m[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MacroInserted reports whether a snippet of the given type is inserted into
+// the VCL by a #FASTLY macro, i.e. whether the service has no custom VCL or
+// its main VCL contains the macro for the snippet's type.
+func MacroInserted(sources []Source, snippetType string) bool {
+	if snippetType == "" || snippetType == "none" {
+		return false
+	}
+	main := ""
+	custom := false
+	for _, s := range sources {
+		if s.Kind == KindVCL {
+			custom = true
+			if s.Main {
+				main = s.Content
+			}
+		}
+	}
+	if !custom {
+		return true
+	}
+	macro := regexp.MustCompile(`(?mi)^\s*#\s*FASTLY\s+` + regexp.QuoteMeta(snippetType) + `\b`)
+	return macro.MatchString(main)
+}
+
+// PrintReferences writes the references as a table.
+func PrintReferences(out io.Writer, refs []Reference) {
+	tw := text.NewTable(out)
+	tw.AddHeader("KIND", "NAME", "LINE", "REFERENCE")
+	for _, r := range refs {
+		tw.AddLine(r.Kind, r.Name, r.Line, r.Text)
+	}
+	tw.Print()
+}
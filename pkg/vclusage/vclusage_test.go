@@ -0,0 +1,86 @@
+package vclusage_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/vclusage"
+)
+
+var sources = []vclusage.Source{
+	{
+		Kind:    vclusage.KindSnippet,
+		Name:    "geo",
+		Type:    "recv",
+		Content: "set req.http.X-Region = table.lookup(geo_map, client.geo.country_code, \"us\");\n",
+	},
+	{
+		Kind:    vclusage.KindSnippet,
+		Name:    "helpers",
+		Type:    "init",
+		Content: "sub normalize_path {\n  set req.url = std.tolower(req.url);\n}\n",
+	},
+	{
+		Kind: vclusage.KindVCL,
+		Name: "main",
+		Main: true,
+		Content: "sub vcl_recv {\n#FASTLY recv\n  include \"snippet::helpers\";\n" +
+			"  # table.lookup(geo_map, \"commented out\")\n  call normalize_path;\n}\n",
+	},
+	{
+		Kind:    vclusage.KindCondition,
+		Name:    "is_eu",
+		Content: "table.contains(geo_map, client.geo.country_code)",
+	},
+	{
+		Kind: vclusage.KindGenerated,
+		Name: "generated",
+		Content: "sub vcl_recv {\n" +
+			"  set req.http.X-Region = table.lookup(geo_map, client.geo.country_code, \"us\");\n" +
+			"  if (table.contains(geo_map, client.geo.country_code)) {\n" +
+			"    set req.http.X-Tier = table.lookup(geo_map, \"tier\");\n" +
+			"  }\n}\n",
+	},
+}
+
+func TestFind(t *testing.T) {
+	refs := vclusage.Find(sources, nil, vclusage.DictionaryPattern("geo_map"))
+	testutil.AssertEqual(t, []vclusage.Reference{
+		{Kind: "snippet", Name: "geo", Line: 1, Text: `set req.http.X-Region = table.lookup(geo_map, client.geo.country_code, "us");`},
+		{Kind: "condition", Name: "is_eu", Line: 1, Text: "table.contains(geo_map, client.geo.country_code)"},
+		{Kind: "generated", Name: "generated", Line: 4, Text: `set req.http.X-Tier = table.lookup(geo_map, "tier");`},
+	}, refs)
+
+	// Skipped sources aren't reported, but still aren't reported again as
+	// generated VCL.
+	refs = vclusage.Find(sources, func(s vclusage.Source) bool { return s.Kind == vclusage.KindCondition }, vclusage.DictionaryPattern("geo_map"))
+	testutil.AssertEqual(t, 2, len(refs))
+
+	refs = vclusage.Find(sources, nil, vclusage.DictionaryPattern("geo"))
+	testutil.AssertEqual(t, 0, len(refs))
+}
+
+func TestFindIncludesAndCalls(t *testing.T) {
+	refs := vclusage.Find(sources, nil, vclusage.IncludePattern("helpers"), vclusage.CallPattern("normalize_path"))
+	testutil.AssertEqual(t, []vclusage.Reference{
+		{Kind: "vcl", Name: "main", Line: 3, Text: `include "snippet::helpers";`},
+		{Kind: "vcl", Name: "main", Line: 5, Text: "call normalize_path;"},
+	}, refs)
+}
+
+func TestSubroutines(t *testing.T) {
+	testutil.AssertEqual(t, []string{"normalize_path"}, vclusage.Subroutines(sources[1].Content+sources[2].Content))
+}
+
+func TestMacroInserted(t *testing.T) {
+	testutil.AssertBool(t, true, vclusage.MacroInserted(sources, "recv"))
+	testutil.AssertBool(t, false, vclusage.MacroInserted(sources, "deliver"))
+	testutil.AssertBool(t, false, vclusage.MacroInserted(sources, "none"))
+	testutil.AssertBool(t, true, vclusage.MacroInserted(sources[:1], "deliver"))
+}
+
+func TestStripComments(t *testing.T) {
+	in := "set req.url = \"http://example.com\"; # comment\n/* multi\nline */ call foo;"
+	want := "set req.url = \"http://example.com\";          \n        \n        call foo;"
+	testutil.AssertString(t, want, vclusage.StripComments(in))
+}